@@ -24,17 +24,17 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/{organization}/{projectId}/git/repositories": {
+        "/api/{organization}/core/projects": {
             "post": {
-                "description": "Create a new GitRepository on Azure DevOps using the provided organization, project, and repository details.",
+                "description": "Create a project and wait for the asynchronous creation to complete. If a project with the same name already exists, it is returned with status 200.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Create a new GitRepository on Azure DevOps",
-                "operationId": "post-gitrepository",
+                "summary": "Create a project",
+                "operationId": "post-project",
                 "parameters": [
                     {
                         "type": "string",
@@ -45,24 +45,11 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Project ID or name",
-                        "name": "projectId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "API version (e.g., 7.2-preview.2)",
+                        "description": "API version (e.g., 7.1)",
                         "name": "api-version",
                         "in": "query",
                         "required": true
                     },
-                    {
-                        "type": "string",
-                        "description": "Specify the source refs to use while creating a fork repo",
-                        "name": "sourceRef",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
                         "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
@@ -71,26 +58,26 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "GitRepository creation request body (with additional fields handled by the plugin)",
-                        "name": "gitrepositoryCreate",
+                        "description": "Project creation request body",
+                        "name": "project",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/gitrepository.CreateRepositoryRequest"
+                            "$ref": "#/definitions/project.CreateProjectRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "GitRepository details",
+                    "200": {
+                        "description": "Existing project",
                         "schema": {
-                            "$ref": "#/definitions/gitrepository.CreateRepositoryResponse"
+                            "$ref": "#/definitions/project.ProjectResponse"
                         }
                     },
-                    "202": {
-                        "description": "GitRepository details (repo created but creation of branch deisgnated as default branch is pending, user must create it, then the gitrepository-controller will update the default branch later)",
+                    "201": {
+                        "description": "Created project",
                         "schema": {
-                            "$ref": "#/definitions/gitrepository.CreateRepositoryResponse"
+                            "$ref": "#/definitions/project.ProjectResponse"
                         }
                     },
                     "400": {
@@ -98,18 +85,24 @@ const docTemplate = `{
                     },
                     "401": {
                         "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    },
+                    "504": {
+                        "description": "Gateway Timeout"
                     }
                 }
             }
         },
-        "/api/{organization}/{project}/pipelines/pipelinepermissions/{resourceType}/{resourceId}": {
+        "/api/{organization}/core/projects/{id}": {
             "get": {
-                "description": "Get",
+                "description": "Get a project of the organization by ID or name, together with its source control and process template",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Get the pipeline permission of a resource",
-                "operationId": "get-pipelinepermission",
+                "summary": "Get a project",
+                "operationId": "get-project",
                 "parameters": [
                     {
                         "type": "string",
@@ -120,28 +113,69 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Project name",
-                        "name": "project",
+                        "description": "Project ID or name",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Resource type (e.g., pipelines, repositories)",
-                        "name": "resourceType",
+                        "description": "API version (e.g., 7.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Project details",
+                        "schema": {
+                            "$ref": "#/definitions/project.ProjectResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a project and wait for the asynchronous deletion to complete",
+                "summary": "Delete a project",
+                "operationId": "delete-project",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Resource ID (e.g., pipeline ID, repository ID)",
-                        "name": "resourceId",
+                        "description": "Project ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "API version (e.g., 7.2-preview.1)",
+                        "description": "API version (e.g., 7.1)",
                         "name": "api-version",
                         "in": "query",
                         "required": true
@@ -155,23 +189,36 @@ const docTemplate = `{
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Pipeline permission details",
-                        "schema": {
-                            "$ref": "#/definitions/pipelinepermission.ResourcePipelinePermissions"
-                        }
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    },
+                    "504": {
+                        "description": "Gateway Timeout"
                     }
                 }
-            }
-        },
-        "/api/{organization}/{project}/pipelines/{id}": {
-            "get": {
-                "description": "Get",
+            },
+            "patch": {
+                "description": "Update the name, description or visibility of a project and wait for the asynchronous update to complete. Fields not set are not changed.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Get a pipeline",
-                "operationId": "get-pipeline",
+                "summary": "Update a project",
+                "operationId": "patch-project",
                 "parameters": [
                     {
                         "type": "string",
@@ -182,21 +229,86 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Project name or ID",
-                        "name": "project",
+                        "description": "Project ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Pipeline ID",
-                        "name": "id",
+                        "description": "API version (e.g., 7.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Project update request body",
+                        "name": "project",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/project.UpdateProjectRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated project",
+                        "schema": {
+                            "$ref": "#/definitions/project.ProjectResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    },
+                    "504": {
+                        "description": "Gateway Timeout"
+                    }
+                }
+            }
+        },
+        "/api/{organization}/distributedtask/pools": {
+            "get": {
+                "description": "Find an agent pool of the organization by name (case insensitive)",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Find an agent pool by name",
+                "operationId": "find-agentpool",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "API version (e.g., 7.2-preview.1)",
+                        "description": "Agent pool name",
+                        "name": "name",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1)",
                         "name": "api-version",
                         "in": "query",
                         "required": true
@@ -211,9 +323,9 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Pipeline details",
+                        "description": "Agent pool details",
                         "schema": {
-                            "$ref": "#/definitions/pipeline.GetPipelineResponse"
+                            "$ref": "#/definitions/agentpool.AgentPoolResponse"
                         }
                     },
                     "400": {
@@ -222,21 +334,24 @@ const docTemplate = `{
                     "401": {
                         "description": "Unauthorized"
                     },
+                    "404": {
+                        "description": "Not Found"
+                    },
                     "500": {
                         "description": "Internal Server Error"
                     }
                 }
             },
-            "put": {
-                "description": "Update a pipeline using build definitions endpoint",
+            "post": {
+                "description": "Create a self-hosted agent pool. If a self-hosted pool with the same name and type already exists, its auto-provision and auto-update flags are updated and it is returned with status 200; if it is hosted or of another type, 409 is returned.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Update a pipeline",
-                "operationId": "put-pipeline",
+                "summary": "Create an agent pool",
+                "operationId": "post-agentpool",
                 "parameters": [
                     {
                         "type": "string",
@@ -247,16 +362,9 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Project name or ID",
-                        "name": "project",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Pipeline ID",
-                        "name": "id",
-                        "in": "path",
+                        "description": "API version (e.g., 7.1)",
+                        "name": "api-version",
+                        "in": "query",
                         "required": true
                     },
                     {
@@ -267,20 +375,26 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Pipeline update request body",
-                        "name": "pipelineUpdate",
+                        "description": "Agent pool creation request body",
+                        "name": "agentPool",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/pipeline.UpdatePipelineRequest"
+                            "$ref": "#/definitions/agentpool.CreateAgentPoolRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Updated pipeline details",
+                        "description": "Existing agent pool",
                         "schema": {
-                            "$ref": "#/definitions/pipeline.UpdatePipelineResponse"
+                            "$ref": "#/definitions/agentpool.AgentPoolResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Created agent pool",
+                        "schema": {
+                            "$ref": "#/definitions/agentpool.AgentPoolResponse"
                         }
                     },
                     "400": {
@@ -289,18 +403,23 @@ const docTemplate = `{
                     "401": {
                         "description": "Unauthorized"
                     },
-                    "404": {
-                        "description": "Not Found"
+                    "409": {
+                        "description": "Conflict"
                     },
                     "500": {
                         "description": "Internal Server Error"
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete a pipeline using build definitions endpoint",
-                "summary": "Delete a pipeline",
-                "operationId": "delete-pipeline",
+            }
+        },
+        "/api/{organization}/distributedtask/pools/{id}": {
+            "get": {
+                "description": "Get an agent pool of the organization",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get an agent pool",
+                "operationId": "get-agentpool",
                 "parameters": [
                     {
                         "type": "string",
@@ -311,16 +430,16 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Project name or ID",
-                        "name": "project",
+                        "description": "Agent pool ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Pipeline ID",
-                        "name": "id",
-                        "in": "path",
+                        "description": "API version (e.g., 7.1)",
+                        "name": "api-version",
+                        "in": "query",
                         "required": true
                     },
                     {
@@ -332,8 +451,11 @@ const docTemplate = `{
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content - Pipeline deleted successfully"
+                    "200": {
+                        "description": "Agent pool details",
+                        "schema": {
+                            "$ref": "#/definitions/agentpool.AgentPoolResponse"
+                        }
                     },
                     "400": {
                         "description": "Bad Request"
@@ -348,485 +470,8912 @@ const docTemplate = `{
                         "description": "Internal Server Error"
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "gitrepository.AzureDevOpsTime": {
-            "type": "object",
-            "properties": {
-                "time.Time": {
-                    "type": "string"
-                }
-            }
-        },
-        "gitrepository.CreateRepositoryRequest": {
-            "type": "object",
-            "properties": {
-                "defaultBranch": {
-                    "type": "string"
-                },
-                "initialize": {
-                    "description": "Indicates if the repository should be initialized with an initial commit",
-                    "type": "boolean"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "parentRepository": {
+            },
+            "delete": {
+                "description": "Delete an agent pool of the organization. The queues mapped to the pool are deleted by Azure DevOps.",
+                "summary": "Delete an agent pool",
+                "operationId": "delete-agentpool",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Agent pool ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/{organization}/graph/groups": {
+            "get": {
+                "description": "List the groups of the organization, or of a project when projectId is set, sorted by principal name. All the pages returned by Azure DevOps are followed.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "List groups",
+                "operationId": "list-groups",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project ID, the groups of the organization are listed if not set",
+                        "name": "projectId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1-preview.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of groups",
+                        "schema": {
+                            "$ref": "#/definitions/graph.GroupListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    },
+                    "502": {
+                        "description": "Bad Gateway"
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a group in the project, or in the organization when projectId is not set. If a group with the same display name already exists in the same scope, its description and members are updated instead and 200 is returned. If members are set, the members of the group are reconciled with them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Create a group",
+                "operationId": "post-group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1-preview.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Group creation request body",
+                        "name": "group",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/graph.GroupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Existing group",
+                        "schema": {
+                            "$ref": "#/definitions/graph.GroupResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Created group",
+                        "schema": {
+                            "$ref": "#/definitions/graph.GroupResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    },
+                    "502": {
+                        "description": "Bad Gateway"
+                    }
+                }
+            }
+        },
+        "/api/{organization}/graph/groups/{descriptor}": {
+            "get": {
+                "description": "Get a group with its direct members sorted by descriptor",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get a group",
+                "operationId": "get-group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Group descriptor",
+                        "name": "descriptor",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1-preview.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Group details",
+                        "schema": {
+                            "$ref": "#/definitions/graph.GroupResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "put": {
+                "description": "Update the description of a group. If members are set, the members of the group are reconciled with them: missing members are added and the others are removed. The display name and the project of a group cannot be changed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Update a group",
+                "operationId": "put-group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Group descriptor",
+                        "name": "descriptor",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1-preview.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Group update request body",
+                        "name": "group",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/graph.GroupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated group",
+                        "schema": {
+                            "$ref": "#/definitions/graph.GroupResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a group. Its memberships are removed by Azure DevOps.",
+                "summary": "Delete a group",
+                "operationId": "delete-group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Group descriptor",
+                        "name": "descriptor",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1-preview.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/{organization}/graph/subjects/resolve": {
+            "post": {
+                "description": "Resolve UPNs, emails and group principal names to the descriptors of the matching users and groups",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Resolve principal names to subject descriptors",
+                "operationId": "resolve-subjects",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1-preview.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Principal names to resolve",
+                        "name": "resolve",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/graph.ResolveRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Resolved subjects",
+                        "schema": {
+                            "$ref": "#/definitions/graph.ResolveResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/{organization}/{projectId}/git/repositories": {
+            "post": {
+                "description": "Create a new GitRepository on Azure DevOps using the provided organization, project, and repository details.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Create a new GitRepository on Azure DevOps",
+                "operationId": "post-gitrepository",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project ID or name",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.2-preview.2)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Specify the source refs to use while creating a fork repo",
+                        "name": "sourceRef",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "GitRepository creation request body (with additional fields handled by the plugin)",
+                        "name": "gitrepositoryCreate",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/gitrepository.CreateRepositoryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "GitRepository details",
+                        "schema": {
+                            "$ref": "#/definitions/gitrepository.CreateRepositoryResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "GitRepository details (repo created but creation of branch deisgnated as default branch is pending, user must create it, then the gitrepository-controller will update the default branch later)",
+                        "schema": {
+                            "$ref": "#/definitions/gitrepository.CreateRepositoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    }
+                }
+            }
+        },
+        "/api/{organization}/{projectId}/git/repositories/{repositoryId}/branches": {
+            "post": {
+                "description": "Create a branch from a source ref (branch or tag) or from a commit. If the branch already exists and points to the same commit, it is returned with 200. If it points to another commit, 409 is returned and the branch is not changed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Create a branch in a Git repository",
+                "operationId": "post-branch",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project ID or name",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Repository ID or name",
+                        "name": "repositoryId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Branch creation request body",
+                        "name": "branch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/gitref.CreateBranchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Existing branch",
+                        "schema": {
+                            "$ref": "#/definitions/gitref.BranchResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Created branch",
+                        "schema": {
+                            "$ref": "#/definitions/gitref.BranchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/{organization}/{projectId}/git/repositories/{repositoryId}/branches/{branch}": {
+            "get": {
+                "description": "Get a branch of a Git repository, with the commit it points to and its lock status",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get a branch of a Git repository",
+                "operationId": "get-branch",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project ID or name",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Repository ID or name",
+                        "name": "repositoryId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Branch name (e.g., main or feature/x)",
+                        "name": "branch",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Branch details",
+                        "schema": {
+                            "$ref": "#/definitions/gitref.BranchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a branch. When oldObjectId is set, the branch is only deleted if it still points to that commit, otherwise 409 is returned. Without it, the branch is only deleted if it was not updated since it was read.",
+                "summary": "Delete a branch of a Git repository",
+                "operationId": "delete-branch",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project ID or name",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Repository ID or name",
+                        "name": "repositoryId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Branch name (e.g., main or feature/x)",
+                        "name": "branch",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Commit the branch is expected to point to",
+                        "name": "oldObjectId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "patch": {
+                "description": "Lock or unlock a branch. A locked branch cannot be updated or deleted, and new pull requests cannot be merged into it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Lock or unlock a branch of a Git repository",
+                "operationId": "patch-branch",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project ID or name",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Repository ID or name",
+                        "name": "repositoryId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Branch name (e.g., main or feature/x)",
+                        "name": "branch",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Branch update request body",
+                        "name": "branchUpdate",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/gitref.UpdateBranchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated branch",
+                        "schema": {
+                            "$ref": "#/definitions/gitref.BranchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/{organization}/{projectId}/git/repositories/{repositoryId}/forksync": {
+            "get": {
+                "description": "Get the number of commits a branch of a fork is ahead of and behind a ref of its parent repository",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Compare a fork with its parent repository",
+                "operationId": "get-fork-status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project ID or name",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fork repository ID or name",
+                        "name": "repositoryId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ref of the parent repository (e.g., main), defaults to the default branch of the fork",
+                        "name": "sourceRef",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Branch of the fork, defaults to sourceRef",
+                        "name": "targetRef",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Fork status",
+                        "schema": {
+                            "$ref": "#/definitions/forksync.ForkStatusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "post": {
+                "description": "Synchronize a branch of a fork with a ref of its parent repository and wait for the synchronization to complete. Nothing is done if the branch is not behind. If a synchronization of the same refs is already running, it is waited for instead of creating another one.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Synchronize a fork with its parent repository",
+                "operationId": "post-fork-sync",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project ID or name",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fork repository ID or name",
+                        "name": "repositoryId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Fork sync request body",
+                        "name": "sync",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/forksync.SyncRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Fork up to date, or running synchronization completed",
+                        "schema": {
+                            "$ref": "#/definitions/forksync.SyncResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Synchronization created and completed",
+                        "schema": {
+                            "$ref": "#/definitions/forksync.SyncResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    },
+                    "504": {
+                        "description": "Gateway Timeout"
+                    }
+                }
+            }
+        },
+        "/api/{organization}/{projectId}/git/repositories/{repositoryId}/forksync/requests/{operationId}": {
+            "get": {
+                "description": "Get the status of a fork sync request, e.g., to poll a synchronization that did not complete within the timeout of the fork sync endpoint",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get a fork sync request",
+                "operationId": "get-fork-sync-request",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project ID or name",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fork repository ID or name",
+                        "name": "repositoryId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Fork sync request operation ID",
+                        "name": "operationId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Fork sync request status",
+                        "schema": {
+                            "$ref": "#/definitions/forksync.SyncOperationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/{organization}/{projectId}/git/repositories/{repositoryId}/permissions": {
+            "get": {
+                "description": "Get the explicit and effective permissions of the identities with an access control entry on the Git repository, or on a branch of it",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get the permissions of a Git repository",
+                "operationId": "get-git-repository-permissions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project ID or name",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Repository ID or name",
+                        "name": "repositoryId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Branch name (e.g., main or refs/heads/main), the permissions of the repository are returned if not set",
+                        "name": "branch",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Repository permissions",
+                        "schema": {
+                            "$ref": "#/definitions/gitpermission.RepositoryPermissions"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "put": {
+                "description": "Set the explicit permissions of the given identities on the Git repository, or on a branch of it. The permissions of each listed identity are replaced, the other identities are not changed. Entries already up to date are not written.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Set the permissions of a Git repository",
+                "operationId": "put-git-repository-permissions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project ID or name",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Repository ID or name",
+                        "name": "repositoryId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Branch name (e.g., main or refs/heads/main), the permissions of the repository are set if not set",
+                        "name": "branch",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Permissions keyed by descriptor",
+                        "name": "permissions",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/gitpermission.RepositoryPermissionsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated repository permissions",
+                        "schema": {
+                            "$ref": "#/definitions/gitpermission.RepositoryPermissions"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/{organization}/{projectId}/git/repositories/{repositoryId}/permissions/{descriptor}": {
+            "delete": {
+                "description": "Remove the access control entry of the identity on the Git repository, or on a branch of it, so that its permissions are inherited",
+                "summary": "Remove the permissions of an identity on a Git repository",
+                "operationId": "delete-git-repository-permission",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project ID or name",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Repository ID or name",
+                        "name": "repositoryId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Subject descriptor or identity descriptor",
+                        "name": "descriptor",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Branch name (e.g., main or refs/heads/main), the permissions on the repository are removed if not set",
+                        "name": "branch",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/{organization}/{projectId}/git/repositories/{repositoryId}/pullrequests": {
+            "post": {
+                "description": "Create a pull request with reviewers, work items, labels, completion options and auto-complete. If an active pull request of the same source and target branches already exists, it is updated if needed and 200 is returned instead: reviewers, work items and labels are added if missing, but not removed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Create a pull request",
+                "operationId": "post-pull-request",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project ID or name",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Repository ID or name",
+                        "name": "repositoryId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Pull request creation request body",
+                        "name": "pullRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/pullrequest.PullRequestRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Existing pull request",
+                        "schema": {
+                            "$ref": "#/definitions/pullrequest.PullRequestResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Created pull request",
+                        "schema": {
+                            "$ref": "#/definitions/pullrequest.PullRequestResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/{organization}/{projectId}/git/repositories/{repositoryId}/pullrequests/{pullRequestId}": {
+            "get": {
+                "description": "Get a pull request with its status, reviewers, work items, labels and completion options",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get a pull request",
+                "operationId": "get-pull-request",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project ID or name",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Repository ID or name",
+                        "name": "repositoryId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pull request ID",
+                        "name": "pullRequestId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Pull request details",
+                        "schema": {
+                            "$ref": "#/definitions/pullrequest.PullRequestResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "delete": {
+                "description": "Abandon an active pull request. Pull requests that are already abandoned or completed are left as they are.",
+                "summary": "Abandon a pull request",
+                "operationId": "delete-pull-request",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project ID or name",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Repository ID or name",
+                        "name": "repositoryId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pull request ID",
+                        "name": "pullRequestId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/{organization}/{projectId}/git/repositories/{repositoryId}/pushes": {
+            "post": {
+                "description": "Create, update or delete a set of files on a branch in one commit. Files already up to date are not changed, and no commit is pushed if all the files are up to date. If the branch is updated concurrently, the push is retried on top of the new tip of the branch.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Push a set of files to a branch",
+                "operationId": "post-push",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project ID or name",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Repository ID or name",
+                        "name": "repositoryId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Push request body",
+                        "name": "push",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/gitpush.PushRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Files already up to date, nothing pushed",
+                        "schema": {
+                            "$ref": "#/definitions/gitpush.PushResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Commit pushed",
+                        "schema": {
+                            "$ref": "#/definitions/gitpush.PushResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/{organization}/{projectId}/git/repositories/{repositoryId}/refs": {
+            "get": {
+                "description": "List the refs (branches and tags) of a Git repository, with their lock status. The refs can be filtered by prefix (e.g., heads/ or refs/heads/feature/) or by a substring, and paged with $top and the returned continuationToken.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "List the refs of a Git repository",
+                "operationId": "list-refs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project ID or name",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Repository ID or name",
+                        "name": "repositoryId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Prefix of the ref names, with or without refs/ (e.g., heads/ or tags/v1)",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Substring of the ref names",
+                        "name": "filterContains",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of refs returned",
+                        "name": "$top",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Continuation token returned by a previous page",
+                        "name": "continuationToken",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of refs",
+                        "schema": {
+                            "$ref": "#/definitions/gitref.RefListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/{organization}/{projectId}/hooks/subscriptions": {
+            "post": {
+                "description": "Create a service hook subscription for the project. If a subscription of the project with the same publisher, event type, consumer and consumer action already exists, it is updated if needed and 200 is returned instead. Secret consumer inputs are write only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Create a service hook subscription",
+                "operationId": "post-servicehook-subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project ID or name",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Subscription creation request body",
+                        "name": "subscription",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/servicehook.SubscriptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Existing subscription",
+                        "schema": {
+                            "$ref": "#/definitions/servicehook.SubscriptionResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Created subscription",
+                        "schema": {
+                            "$ref": "#/definitions/servicehook.SubscriptionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/{organization}/{projectId}/hooks/subscriptions/{id}": {
+            "get": {
+                "description": "Get a service hook subscription of the project. Secret consumer inputs are not returned.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get a service hook subscription",
+                "operationId": "get-servicehook-subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project ID or name",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Subscription details",
+                        "schema": {
+                            "$ref": "#/definitions/servicehook.SubscriptionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "put": {
+                "description": "Replace a service hook subscription of the project. Secret consumer inputs not sent keep their current value.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Update a service hook subscription",
+                "operationId": "put-servicehook-subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project ID or name",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Subscription update request body",
+                        "name": "subscription",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/servicehook.SubscriptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated subscription",
+                        "schema": {
+                            "$ref": "#/definitions/servicehook.SubscriptionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a service hook subscription of the project",
+                "summary": "Delete a service hook subscription",
+                "operationId": "delete-servicehook-subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project ID or name",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/{organization}/{projectId}/serviceendpoints": {
+            "post": {
+                "description": "Create a Kubernetes, Azure RM or generic service connection, optionally shared with other projects",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Create a service connection",
+                "operationId": "post-serviceendpoint",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Service connection creation request body",
+                        "name": "serviceEndpoint",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/serviceendpoint.ServiceEndpointRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created service connection",
+                        "schema": {
+                            "$ref": "#/definitions/serviceendpoint.ServiceEndpointResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/{organization}/{projectId}/serviceendpoints/{id}": {
+            "get": {
+                "description": "Get a service connection. Secret authorization parameters are not returned, parameter names are lowercased and project references are sorted by project ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get a service connection",
+                "operationId": "get-serviceendpoint",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Service connection ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Service connection details",
+                        "schema": {
+                            "$ref": "#/definitions/serviceendpoint.ServiceEndpointResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "put": {
+                "description": "Replace a service connection. Secret authorization parameters not sent keep their current value.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Update a service connection",
+                "operationId": "put-serviceendpoint",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Service connection ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Service connection update request body",
+                        "name": "serviceEndpoint",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/serviceendpoint.ServiceEndpointRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated service connection",
+                        "schema": {
+                            "$ref": "#/definitions/serviceendpoint.ServiceEndpointResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a service connection from all the projects it is shared with",
+                "summary": "Delete a service connection",
+                "operationId": "delete-serviceendpoint",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Service connection ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/{organization}/{projectId}/teams": {
+            "post": {
+                "description": "Create a team in the project. If members are set, they are added to the team after its creation.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Create a team",
+                "operationId": "post-team",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1-preview.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Team creation request body",
+                        "name": "team",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/team.TeamRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created team",
+                        "schema": {
+                            "$ref": "#/definitions/team.TeamResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/{organization}/{projectId}/teams/{id}": {
+            "get": {
+                "description": "Get a team of the project with its members sorted by descriptor",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get a team",
+                "operationId": "get-team",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Team ID or name",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1-preview.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Team details",
+                        "schema": {
+                            "$ref": "#/definitions/team.TeamResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "put": {
+                "description": "Update the name and description of a team. If members are set, the members of the team are reconciled with them: missing members are added and the others are removed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Update a team",
+                "operationId": "put-team",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Team ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1-preview.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Team update request body",
+                        "name": "team",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/team.TeamRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated team",
+                        "schema": {
+                            "$ref": "#/definitions/team.TeamResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a team of the project",
+                "summary": "Delete a team",
+                "operationId": "delete-team",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Team ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1-preview.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/{organization}/{projectId}/variablegroups": {
+            "post": {
+                "description": "Create a variable group, optionally linked to an Azure Key Vault and shared with other projects",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Create a variable group",
+                "operationId": "post-variablegroup",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Variable group creation request body",
+                        "name": "variableGroup",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/variablegroup.VariableGroupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created variable group",
+                        "schema": {
+                            "$ref": "#/definitions/variablegroup.VariableGroupResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/{organization}/{projectId}/variablegroups/{id}": {
+            "get": {
+                "description": "Get a variable group. Secret values are replaced with a hash of the value set through the plugin, audit fields are not returned and project references are sorted by project ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get a variable group",
+                "operationId": "get-variablegroup",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Variable group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Variable group details",
+                        "schema": {
+                            "$ref": "#/definitions/variablegroup.VariableGroupResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "put": {
+                "description": "Replace a variable group. Secret variables sent without value keep their current value.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Update a variable group",
+                "operationId": "put-variablegroup",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Variable group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Variable group update request body",
+                        "name": "variableGroup",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/variablegroup.VariableGroupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated variable group",
+                        "schema": {
+                            "$ref": "#/definitions/variablegroup.VariableGroupResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a variable group from all the projects it is shared with",
+                "summary": "Delete a variable group",
+                "operationId": "delete-variablegroup",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Variable group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/{organization}/{projectId}/wiki/wikis": {
+            "post": {
+                "description": "Create the project wiki, or publish a folder of a branch of a repository as a code wiki. If the project wiki, or a code wiki of the same name publishing the same folder, already exists, 200 is returned instead: the branch is added to the published branches of a code wiki if needed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Create a wiki",
+                "operationId": "post-wiki",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project ID or name",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Wiki creation request body",
+                        "name": "wiki",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/wiki.WikiRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Existing wiki",
+                        "schema": {
+                            "$ref": "#/definitions/wiki.WikiResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Created wiki",
+                        "schema": {
+                            "$ref": "#/definitions/wiki.WikiResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/{organization}/{projectId}/wiki/wikis/{wikiIdentifier}": {
+            "get": {
+                "description": "Get a project wiki or a code wiki with its published branches",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get a wiki",
+                "operationId": "get-wiki",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project ID or name",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Wiki ID or name",
+                        "name": "wikiIdentifier",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Wiki details",
+                        "schema": {
+                            "$ref": "#/definitions/wiki.WikiResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "delete": {
+                "description": "Unpublish a code wiki, the repository and its content are kept. Project wikis cannot be deleted.",
+                "summary": "Unpublish a code wiki",
+                "operationId": "delete-wiki",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project ID or name",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Wiki ID or name",
+                        "name": "wikiIdentifier",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/{organization}/{projectId}/wiki/wikis/{wikiIdentifier}/pages/{path}": {
+            "get": {
+                "description": "Get a wiki page with its content. Its ETag is returned in the body and in the ETag header, to send in the If-Match header of an update.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get a wiki page",
+                "operationId": "get-wiki-page",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project ID or name",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Wiki ID or name",
+                        "name": "wikiIdentifier",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page path (e.g., Onboarding/Access)",
+                        "name": "path",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Branch of a code wiki, defaults to its first published branch",
+                        "name": "version",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Wiki page",
+                        "schema": {
+                            "$ref": "#/definitions/wiki.PageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "put": {
+                "description": "Create a wiki page, or update its content. With an If-Match header, the page must exist and still have this ETag (412 otherwise). Without it, the page is updated from its current ETag, so that a concurrent change between the read and the update is not overwritten either. A page that already has the content is not updated.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Create or update a wiki page",
+                "operationId": "put-wiki-page",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project ID or name",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Wiki ID or name",
+                        "name": "wikiIdentifier",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page path (e.g., Onboarding/Access)",
+                        "name": "path",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Branch of a code wiki, defaults to its first published branch",
+                        "name": "version",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the page, as returned when it was read",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Wiki page request body",
+                        "name": "page",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/wiki.PageRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated or unchanged page",
+                        "schema": {
+                            "$ref": "#/definitions/wiki.PageResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Created page",
+                        "schema": {
+                            "$ref": "#/definitions/wiki.PageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "412": {
+                        "description": "Precondition Failed"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a wiki page and its sub-pages. With an If-Match header, the page must still have this ETag (412 otherwise).",
+                "summary": "Delete a wiki page",
+                "operationId": "delete-wiki-page",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project ID or name",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Wiki ID or name",
+                        "name": "wikiIdentifier",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page path (e.g., Onboarding/Access)",
+                        "name": "path",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Branch of a code wiki, defaults to its first published branch",
+                        "name": "version",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the page, as returned when it was read",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "412": {
+                        "description": "Precondition Failed"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/{organization}/{projectId}/wit/classificationnodes/{structureGroup}": {
+            "post": {
+                "description": "Create an area or iteration node and its missing parents. If the node already exists, 200 is returned instead and the dates of an iteration are updated if needed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Create an area or iteration node",
+                "operationId": "post-classification-node",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project ID or name",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Structure group (areas or iterations)",
+                        "name": "structureGroup",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Node creation request body",
+                        "name": "node",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/classification.CreateNodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Existing node",
+                        "schema": {
+                            "$ref": "#/definitions/classification.NodeResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Created node",
+                        "schema": {
+                            "$ref": "#/definitions/classification.NodeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/{organization}/{projectId}/wit/classificationnodes/{structureGroup}/{path}": {
+            "get": {
+                "description": "Get an area or iteration node by path. The path accepts both '/' and '\\' as separators and is returned normalized (e.g., 'Team A/Backend').",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get an area or iteration node",
+                "operationId": "get-classification-node",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project ID or name",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Structure group (areas or iterations)",
+                        "name": "structureGroup",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Node path below the root node (e.g., Team A/Backend)",
+                        "name": "path",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Node details",
+                        "schema": {
+                            "$ref": "#/definitions/classification.NodeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete an area or iteration node and its children. Their work items are moved to the reclassification node, which defaults to the parent of the deleted node.",
+                "summary": "Delete an area or iteration node",
+                "operationId": "delete-classification-node",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project ID or name",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Structure group (areas or iterations)",
+                        "name": "structureGroup",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Node path below the root node (e.g., Team A/Backend)",
+                        "name": "path",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Path of the node receiving the work items of the deleted nodes, defaults to the parent node",
+                        "name": "reclassifyPath",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "patch": {
+                "description": "Move the node below another parent (created if missing) and rename it if the path is set, and change the dates of an iteration if they are set. Work items keep their node.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Move, rename or change the dates of an area or iteration node",
+                "operationId": "patch-classification-node",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project ID or name",
+                        "name": "projectId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Structure group (areas or iterations)",
+                        "name": "structureGroup",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Current node path below the root node (e.g., Team A/Backend)",
+                        "name": "path",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Node update request body",
+                        "name": "nodeUpdate",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/classification.UpdateNodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated node",
+                        "schema": {
+                            "$ref": "#/definitions/classification.NodeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/{organization}/{project}/distributedtask/queues": {
+            "get": {
+                "description": "Find an agent queue of the project by name (case insensitive)",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Find an agent queue by name",
+                "operationId": "find-agentqueue",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project name or ID",
+                        "name": "project",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Agent queue name",
+                        "name": "name",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Agent queue details",
+                        "schema": {
+                            "$ref": "#/definitions/agentpool.AgentQueueResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "post": {
+                "description": "Create an agent queue in the project mapped to an agent pool of the organization. If a queue with the same name is already mapped to the pool, it is returned with status 200; if it is mapped to another pool, 409 is returned.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Create an agent queue",
+                "operationId": "post-agentqueue",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project name or ID",
+                        "name": "project",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Agent queue creation request body",
+                        "name": "agentQueue",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/agentpool.CreateAgentQueueRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Existing agent queue",
+                        "schema": {
+                            "$ref": "#/definitions/agentpool.AgentQueueResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Created agent queue",
+                        "schema": {
+                            "$ref": "#/definitions/agentpool.AgentQueueResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/{organization}/{project}/distributedtask/queues/{id}": {
+            "get": {
+                "description": "Get an agent queue of the project together with the agent pool it is mapped to",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get an agent queue",
+                "operationId": "get-agentqueue",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project name or ID",
+                        "name": "project",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Agent queue ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Agent queue details",
+                        "schema": {
+                            "$ref": "#/definitions/agentpool.AgentQueueResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete an agent queue of the project. The agent pool it is mapped to is not deleted.",
+                "summary": "Delete an agent queue",
+                "operationId": "delete-agentqueue",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project name or ID",
+                        "name": "project",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Agent queue ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/{organization}/{project}/environments": {
+            "post": {
+                "description": "Create an environment in the specified project",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Create an environment",
+                "operationId": "post-environment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project name or ID",
+                        "name": "project",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.2-preview.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Environment creation request body",
+                        "name": "environment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/environment.CreateEnvironmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created environment",
+                        "schema": {
+                            "$ref": "#/definitions/environment.EnvironmentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/{organization}/{project}/environments/{environmentId}/checks": {
+            "get": {
+                "description": "List the approvals and checks of an environment. Checks are sorted by type and ID, approval, branch control and business hours checks are returned with normalized settings.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "List the checks of an environment",
+                "operationId": "list-environment-checks",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project name or ID",
+                        "name": "project",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Environment ID",
+                        "name": "environmentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.2-preview.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Checks of the environment",
+                        "schema": {
+                            "$ref": "#/definitions/environment.CheckListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "post": {
+                "description": "Add an approval, branch control or business hours check to an environment. If the environment already has a check of the same kind (same type and, for task checks, same task), it is updated instead of adding another one.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Add a check to an environment",
+                "operationId": "post-environment-check",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project name or ID",
+                        "name": "project",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Environment ID",
+                        "name": "environmentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.2-preview.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Check creation request body",
+                        "name": "check",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/environment.CheckRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Existing check updated",
+                        "schema": {
+                            "$ref": "#/definitions/environment.CheckResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Created check",
+                        "schema": {
+                            "$ref": "#/definitions/environment.CheckResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/{organization}/{project}/environments/{environmentId}/checks/{checkId}": {
+            "get": {
+                "description": "Get an approval or check of an environment",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get a check of an environment",
+                "operationId": "get-environment-check",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project name or ID",
+                        "name": "project",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Environment ID",
+                        "name": "environmentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Check ID",
+                        "name": "checkId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.2-preview.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Check details",
+                        "schema": {
+                            "$ref": "#/definitions/environment.CheckResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "put": {
+                "description": "Replace the settings of an approval, branch control or business hours check. The type of the check cannot be changed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Update a check of an environment",
+                "operationId": "put-environment-check",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project name or ID",
+                        "name": "project",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Environment ID",
+                        "name": "environmentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Check ID",
+                        "name": "checkId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.2-preview.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Check update request body",
+                        "name": "check",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/environment.CheckRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated check",
+                        "schema": {
+                            "$ref": "#/definitions/environment.CheckResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete an approval or check of an environment",
+                "summary": "Delete a check of an environment",
+                "operationId": "delete-environment-check",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project name or ID",
+                        "name": "project",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Environment ID",
+                        "name": "environmentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Check ID",
+                        "name": "checkId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.2-preview.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/{organization}/{project}/environments/{environmentId}/kubernetes": {
+            "post": {
+                "description": "Attach a Kubernetes namespace to an environment using an existing Kubernetes service connection",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Attach a Kubernetes resource to an environment",
+                "operationId": "post-environment-kubernetes-resource",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project name or ID",
+                        "name": "project",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Environment ID",
+                        "name": "environmentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.2-preview.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Kubernetes resource creation request body",
+                        "name": "resource",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/environment.CreateKubernetesResourceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created Kubernetes resource",
+                        "schema": {
+                            "$ref": "#/definitions/environment.KubernetesResourceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/{organization}/{project}/environments/{environmentId}/kubernetes/{resourceId}": {
+            "get": {
+                "description": "Get a Kubernetes namespace attached to an environment. Tags are sorted and the service endpoint ID is lowercased.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get a Kubernetes resource of an environment",
+                "operationId": "get-environment-kubernetes-resource",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project name or ID",
+                        "name": "project",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Environment ID",
+                        "name": "environmentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kubernetes resource ID",
+                        "name": "resourceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.2-preview.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Kubernetes resource details",
+                        "schema": {
+                            "$ref": "#/definitions/environment.KubernetesResourceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove a Kubernetes namespace from an environment. The namespace in the cluster is not deleted.",
+                "summary": "Remove a Kubernetes resource from an environment",
+                "operationId": "delete-environment-kubernetes-resource",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project name or ID",
+                        "name": "project",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Environment ID",
+                        "name": "environmentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kubernetes resource ID",
+                        "name": "resourceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.2-preview.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/{organization}/{project}/environments/{id}": {
+            "get": {
+                "description": "Get an environment with the resources attached to it. Audit fields are not returned and resources are sorted by type and ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get an environment",
+                "operationId": "get-environment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project name or ID",
+                        "name": "project",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Environment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.2-preview.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Environment details",
+                        "schema": {
+                            "$ref": "#/definitions/environment.EnvironmentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "put": {
+                "description": "Update name and description of an environment",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Update an environment",
+                "operationId": "put-environment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project name or ID",
+                        "name": "project",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Environment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.2-preview.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Environment update request body",
+                        "name": "environment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/environment.UpdateEnvironmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated environment",
+                        "schema": {
+                            "$ref": "#/definitions/environment.EnvironmentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete an environment",
+                "summary": "Delete an environment",
+                "operationId": "delete-environment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project name or ID",
+                        "name": "project",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Environment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.2-preview.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/{organization}/{project}/pipelines": {
+            "get": {
+                "description": "List all the pipelines of a project, following Azure DevOps continuation tokens. Pipelines are ordered by ID and normalized as in the GET endpoint.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "List pipelines",
+                "operationId": "list-pipelines",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project name or ID",
+                        "name": "project",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.2-preview.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Return only pipelines in this folder (e.g., folder/subfolder)",
+                        "name": "folder",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also return pipelines in subfolders of 'folder'",
+                        "name": "includeSubfolders",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Return only pipelines whose name starts with this prefix (case insensitive)",
+                        "name": "namePrefix",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Return only pipelines using this repository",
+                        "name": "repositoryId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Type of the repository (e.g., azureReposGit, gitHub), default azureReposGit",
+                        "name": "repositoryType",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of pipelines",
+                        "schema": {
+                            "$ref": "#/definitions/pipeline.ListPipelinesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    },
+                    "502": {
+                        "description": "Bad Gateway"
+                    }
+                }
+            }
+        },
+        "/api/{organization}/{project}/pipelines/folders": {
+            "get": {
+                "description": "List the subfolders of a pipeline folder (all the folders by default). Paths are returned normalized (e.g., 'folder/subfolder') and sorted.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "List pipeline folders",
+                "operationId": "list-pipeline-folders",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project name or ID",
+                        "name": "project",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Return only the subfolders of this folder (e.g., folder/subfolder)",
+                        "name": "path",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.2-preview.2)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of folders",
+                        "schema": {
+                            "$ref": "#/definitions/pipeline.ListFoldersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a pipeline folder. The path accepts both '/' and '\\' as separators and is returned normalized (e.g., 'folder/subfolder').",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Create a pipeline folder",
+                "operationId": "post-pipeline-folder",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project name or ID",
+                        "name": "project",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.2-preview.2)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Folder creation request body",
+                        "name": "folderCreate",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/pipeline.CreateFolderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Folder details",
+                        "schema": {
+                            "$ref": "#/definitions/pipeline.FolderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/{organization}/{project}/pipelines/folders/{path}": {
+            "get": {
+                "description": "Get a pipeline folder by path. The path is returned normalized (e.g., 'folder/subfolder').",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get a pipeline folder",
+                "operationId": "get-pipeline-folder",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project name or ID",
+                        "name": "project",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Folder path (e.g., folder/subfolder)",
+                        "name": "path",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.2-preview.2)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Folder details",
+                        "schema": {
+                            "$ref": "#/definitions/pipeline.FolderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a pipeline folder. Azure DevOps also deletes the pipelines and subfolders it contains, unless refuseIfNotEmpty is set.",
+                "summary": "Delete a pipeline folder",
+                "operationId": "delete-pipeline-folder",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project name or ID",
+                        "name": "project",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Folder path (e.g., folder/subfolder)",
+                        "name": "path",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.2-preview.2)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Refuse to delete the folder if it contains pipelines or subfolders",
+                        "name": "refuseIfNotEmpty",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content - Folder deleted successfully"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "409": {
+                        "description": "Conflict - Folder is not empty"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/{organization}/{project}/pipelines/pipelinepermissions": {
+            "put": {
+                "description": "Authorize or revoke pipelines on many protected resources with a single batch request to Azure DevOps.\nOnly the resources that differ from the desired state are updated. With PUT, authorized pipelines not listed in the request are revoked. With PATCH, only the listed pipelines are updated.\nIf the batch request fails, the resources are updated one by one so that the failures are reported per resource.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Update the pipeline permissions of many resources",
+                "operationId": "update-pipelinepermissions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project name",
+                        "name": "project",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.2-preview.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Remove the volatile authorizedOn and authorizedBy fields from the response",
+                        "name": "stripAuditFields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Desired pipeline permissions of the resources",
+                        "name": "resources",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/pipelinepermission.BulkPipelinePermissionsUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Pipeline permissions of all the resources match the desired state",
+                        "schema": {
+                            "$ref": "#/definitions/pipelinepermission.BulkPipelinePermissionsResponse"
+                        }
+                    },
+                    "207": {
+                        "description": "Some resources could not be updated",
+                        "schema": {
+                            "$ref": "#/definitions/pipelinepermission.BulkPipelinePermissionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    }
+                }
+            },
+            "patch": {
+                "description": "Authorize or revoke pipelines on many protected resources with a single batch request to Azure DevOps.\nOnly the resources that differ from the desired state are updated. With PUT, authorized pipelines not listed in the request are revoked. With PATCH, only the listed pipelines are updated.\nIf the batch request fails, the resources are updated one by one so that the failures are reported per resource.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Update the pipeline permissions of many resources",
+                "operationId": "update-pipelinepermissions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project name",
+                        "name": "project",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.2-preview.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Remove the volatile authorizedOn and authorizedBy fields from the response",
+                        "name": "stripAuditFields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Desired pipeline permissions of the resources",
+                        "name": "resources",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/pipelinepermission.BulkPipelinePermissionsUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Pipeline permissions of all the resources match the desired state",
+                        "schema": {
+                            "$ref": "#/definitions/pipelinepermission.BulkPipelinePermissionsResponse"
+                        }
+                    },
+                    "207": {
+                        "description": "Some resources could not be updated",
+                        "schema": {
+                            "$ref": "#/definitions/pipelinepermission.BulkPipelinePermissionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    }
+                }
+            }
+        },
+        "/api/{organization}/{project}/pipelines/pipelinepermissions/query": {
+            "post": {
+                "description": "Get the pipeline permissions of the listed resources. A failure on a resource does not prevent the others from being returned.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get the pipeline permissions of many resources",
+                "operationId": "query-pipelinepermissions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project name",
+                        "name": "project",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.2-preview.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Remove the volatile authorizedOn and authorizedBy fields from the response",
+                        "name": "stripAuditFields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Resources to query",
+                        "name": "resources",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/pipelinepermission.BulkPipelinePermissionsQueryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Pipeline permissions of all the resources",
+                        "schema": {
+                            "$ref": "#/definitions/pipelinepermission.BulkPipelinePermissionsResponse"
+                        }
+                    },
+                    "207": {
+                        "description": "Pipeline permissions of the resources, some of them failed",
+                        "schema": {
+                            "$ref": "#/definitions/pipelinepermission.BulkPipelinePermissionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    }
+                }
+            }
+        },
+        "/api/{organization}/{project}/pipelines/pipelinepermissions/{resourceType}/{resourceId}": {
+            "get": {
+                "description": "Get",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get the pipeline permission of a resource",
+                "operationId": "get-pipelinepermission",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project name",
+                        "name": "project",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "queue",
+                            "endpoint",
+                            "variablegroup",
+                            "securefile",
+                            "environment",
+                            "repository"
+                        ],
+                        "type": "string",
+                        "description": "Resource type",
+                        "name": "resourceType",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Resource ID (numeric ID for queue, variablegroup and environment, GUID for endpoint and securefile, \u003cprojectId\u003e.\u003crepositoryId\u003e for repository)",
+                        "name": "resourceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.2-preview.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Remove the volatile authorizedOn and authorizedBy fields from the response",
+                        "name": "stripAuditFields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Pipeline permission details",
+                        "schema": {
+                            "$ref": "#/definitions/pipelinepermission.ResourcePipelinePermissions"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "put": {
+                "description": "Authorize or revoke pipelines on a protected resource. Only the entries that differ from the current state are sent to Azure DevOps.\nWith PUT, authorized pipelines not listed in the request are revoked. With PATCH, only the listed pipelines are updated.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Update the pipeline permission of a resource",
+                "operationId": "update-pipelinepermission",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project name",
+                        "name": "project",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "queue",
+                            "endpoint",
+                            "variablegroup",
+                            "securefile",
+                            "environment",
+                            "repository"
+                        ],
+                        "type": "string",
+                        "description": "Resource type",
+                        "name": "resourceType",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Resource ID (numeric ID for queue, variablegroup and environment, GUID for endpoint and securefile, \u003cprojectId\u003e.\u003crepositoryId\u003e for repository)",
+                        "name": "resourceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.2-preview.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Remove the volatile authorizedOn and authorizedBy fields from the response",
+                        "name": "stripAuditFields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Desired pipeline permissions",
+                        "name": "pipelinePermission",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/pipelinepermission.UpdatePipelinePermissionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated pipeline permission details",
+                        "schema": {
+                            "$ref": "#/definitions/pipelinepermission.ResourcePipelinePermissions"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "patch": {
+                "description": "Authorize or revoke pipelines on a protected resource. Only the entries that differ from the current state are sent to Azure DevOps.\nWith PUT, authorized pipelines not listed in the request are revoked. With PATCH, only the listed pipelines are updated.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Update the pipeline permission of a resource",
+                "operationId": "update-pipelinepermission",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project name",
+                        "name": "project",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "queue",
+                            "endpoint",
+                            "variablegroup",
+                            "securefile",
+                            "environment",
+                            "repository"
+                        ],
+                        "type": "string",
+                        "description": "Resource type",
+                        "name": "resourceType",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Resource ID (numeric ID for queue, variablegroup and environment, GUID for endpoint and securefile, \u003cprojectId\u003e.\u003crepositoryId\u003e for repository)",
+                        "name": "resourceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.2-preview.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Remove the volatile authorizedOn and authorizedBy fields from the response",
+                        "name": "stripAuditFields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Desired pipeline permissions",
+                        "name": "pipelinePermission",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/pipelinepermission.UpdatePipelinePermissionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated pipeline permission details",
+                        "schema": {
+                            "$ref": "#/definitions/pipelinepermission.ResourcePipelinePermissions"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/{organization}/{project}/pipelines/{id}": {
+            "get": {
+                "description": "Get",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get a pipeline",
+                "operationId": "get-pipeline",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project name or ID",
+                        "name": "project",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pipeline ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.2-preview.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Pipeline details",
+                        "schema": {
+                            "$ref": "#/definitions/pipeline.GetPipelineResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "put": {
+                "description": "Update a pipeline using build definitions endpoint",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Update a pipeline",
+                "operationId": "put-pipeline",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project name or ID",
+                        "name": "project",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pipeline ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Pipeline update request body",
+                        "name": "pipelineUpdate",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/pipeline.UpdatePipelineRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated pipeline details",
+                        "schema": {
+                            "$ref": "#/definitions/pipeline.UpdatePipelineResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a pipeline using build definitions endpoint",
+                "summary": "Delete a pipeline",
+                "operationId": "delete-pipeline",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project name or ID",
+                        "name": "project",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pipeline ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content - Pipeline deleted successfully"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/{organization}/{project}/policy/configurations": {
+            "post": {
+                "description": "Create a policy configuration scoped to a repository and a branch. If a policy of the same type already exists on the same scope (and, for build validation and status policies, for the same build definition or status), it is updated if needed and 200 is returned instead.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Create a branch policy",
+                "operationId": "post-policy",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project name or ID",
+                        "name": "project",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Policy creation request body",
+                        "name": "policy",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/policy.PolicyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Existing policy",
+                        "schema": {
+                            "$ref": "#/definitions/policy.PolicyResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Created policy",
+                        "schema": {
+                            "$ref": "#/definitions/policy.PolicyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    },
+                    "502": {
+                        "description": "Bad Gateway"
+                    }
+                }
+            }
+        },
+        "/api/{organization}/{project}/policy/configurations/{id}": {
+            "get": {
+                "description": "Get a policy configuration with its settings normalized: without scope and null values, keys sorted",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get a branch policy",
+                "operationId": "get-policy",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project name or ID",
+                        "name": "project",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Policy configuration ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Policy details",
+                        "schema": {
+                            "$ref": "#/definitions/policy.PolicyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "put": {
+                "description": "Update the scope, flags and settings of a policy configuration. Settings not set are reset to their defaults by Azure DevOps. The policy is not written if it is already up to date. The type of a policy cannot be changed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Update a branch policy",
+                "operationId": "put-policy",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project name or ID",
+                        "name": "project",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Policy configuration ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Policy update request body",
+                        "name": "policy",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/policy.PolicyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated policy",
+                        "schema": {
+                            "$ref": "#/definitions/policy.PolicyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a policy configuration",
+                "summary": "Delete a branch policy",
+                "operationId": "delete-policy",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project name or ID",
+                        "name": "project",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Policy configuration ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API version (e.g., 7.1)",
+                        "name": "api-version",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Basic Auth header (Basic \u003cbase64-encoded-username:password\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "agentpool.AgentPoolResponse": {
+            "type": "object",
+            "properties": {
+                "autoProvision": {
+                    "type": "boolean"
+                },
+                "autoUpdate": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "isHosted": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "poolType": {
+                    "description": "enum: automation, deployment",
+                    "type": "string"
+                }
+            }
+        },
+        "agentpool.AgentQueueResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "isHosted": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "poolId": {
+                    "type": "integer"
+                },
+                "poolName": {
+                    "type": "string"
+                },
+                "projectId": {
+                    "type": "string"
+                }
+            }
+        },
+        "agentpool.CreateAgentPoolRequest": {
+            "type": "object",
+            "properties": {
+                "autoProvision": {
+                    "description": "If true, a queue for the pool is created in every project",
+                    "type": "boolean"
+                },
+                "autoUpdate": {
+                    "description": "Defaults to true",
+                    "type": "boolean"
+                },
+                "name": {
+                    "description": "Required",
+                    "type": "string"
+                },
+                "poolType": {
+                    "description": "enum: automation, deployment - defaults to automation",
+                    "type": "string"
+                }
+            }
+        },
+        "agentpool.CreateAgentQueueRequest": {
+            "type": "object",
+            "properties": {
+                "authorizePipelines": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "description": "Defaults to the name of the pool",
+                    "type": "string"
+                },
+                "poolId": {
+                    "description": "Required - ID of the agent pool the queue is mapped to",
+                    "type": "integer"
+                }
+            }
+        },
+        "classification.CreateNodeRequest": {
+            "type": "object",
+            "properties": {
+                "finishDate": {
+                    "description": "Iterations only, set together with startDate",
+                    "type": "string"
+                },
+                "path": {
+                    "description": "Required: path below the root node, '/' and '\\' are both accepted as separators",
+                    "type": "string"
+                },
+                "startDate": {
+                    "description": "Iterations only, set together with finishDate",
+                    "type": "string"
+                }
+            }
+        },
+        "classification.NodeResponse": {
+            "type": "object",
+            "properties": {
+                "finishDate": {
+                    "description": "Iterations only, e.g., 2026-01-16",
+                    "type": "string"
+                },
+                "hasChildren": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "identifier": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "path": {
+                    "description": "Path below the root node, e.g., Team A/Backend",
+                    "type": "string"
+                },
+                "startDate": {
+                    "description": "Iterations only, e.g., 2026-01-05",
+                    "type": "string"
+                },
+                "structureType": {
+                    "description": "area or iteration",
+                    "type": "string"
+                }
+            }
+        },
+        "classification.UpdateNodeRequest": {
+            "type": "object",
+            "properties": {
+                "finishDate": {
+                    "description": "Iterations only, left as it is if not set",
+                    "type": "string"
+                },
+                "path": {
+                    "description": "New path of the node, left as it is if not set",
+                    "type": "string"
+                },
+                "startDate": {
+                    "description": "Iterations only, left as it is if not set",
+                    "type": "string"
+                }
+            }
+        },
+        "environment.ApprovalCheck": {
+            "type": "object",
+            "properties": {
+                "approvers": {
+                    "description": "Required - IDs of users or groups, lowercased and sorted unless executionOrder is inSequence",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "executionOrder": {
+                    "description": "enum: anyOrder (default), inSequence",
+                    "type": "string"
+                },
+                "instructions": {
+                    "type": "string"
+                },
+                "minRequiredApprovers": {
+                    "type": "integer"
+                },
+                "requesterCannotBeApprover": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "environment.BranchControlCheck": {
+            "type": "object",
+            "properties": {
+                "allowUnknownStatusBranch": {
+                    "type": "boolean"
+                },
+                "allowedBranches": {
+                    "description": "Required - e.g., refs/heads/main, refs/heads/release/*, sorted",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "ensureProtectionOfBranch": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "environment.BusinessHoursCheck": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "description": "Required - monday ... sunday, ordered from monday to sunday",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "endTime": {
+                    "description": "Required - HH:MM",
+                    "type": "string"
+                },
+                "startTime": {
+                    "description": "Required - HH:MM",
+                    "type": "string"
+                },
+                "timeZone": {
+                    "description": "Windows time zone ID, defaults to UTC",
+                    "type": "string"
+                }
+            }
+        },
+        "environment.Check": {
+            "type": "object",
+            "properties": {
+                "approval": {
+                    "$ref": "#/definitions/environment.ApprovalCheck"
+                },
+                "branchControl": {
+                    "$ref": "#/definitions/environment.BranchControlCheck"
+                },
+                "businessHours": {
+                    "$ref": "#/definitions/environment.BusinessHoursCheck"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "settings": {
+                    "description": "Only for check types not handled by the plugin, read only",
+                    "type": "object"
+                },
+                "timeout": {
+                    "description": "Minutes, defaults to 43200 (30 days)",
+                    "type": "integer"
+                },
+                "type": {
+                    "description": "enum: approval, branchControl, businessHours (other check types are returned with their Azure DevOps name)",
+                    "type": "string"
+                }
+            }
+        },
+        "environment.CheckListResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "value": {
+                    "description": "Sorted by type and ID",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/environment.Check"
+                    }
+                }
+            }
+        },
+        "environment.CheckRequest": {
+            "type": "object",
+            "properties": {
+                "approval": {
+                    "$ref": "#/definitions/environment.ApprovalCheck"
+                },
+                "branchControl": {
+                    "$ref": "#/definitions/environment.BranchControlCheck"
+                },
+                "businessHours": {
+                    "$ref": "#/definitions/environment.BusinessHoursCheck"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "settings": {
+                    "description": "Only for check types not handled by the plugin, read only",
+                    "type": "object"
+                },
+                "timeout": {
+                    "description": "Minutes, defaults to 43200 (30 days)",
+                    "type": "integer"
+                },
+                "type": {
+                    "description": "enum: approval, branchControl, businessHours (other check types are returned with their Azure DevOps name)",
+                    "type": "string"
+                }
+            }
+        },
+        "environment.CheckResponse": {
+            "type": "object",
+            "properties": {
+                "approval": {
+                    "$ref": "#/definitions/environment.ApprovalCheck"
+                },
+                "branchControl": {
+                    "$ref": "#/definitions/environment.BranchControlCheck"
+                },
+                "businessHours": {
+                    "$ref": "#/definitions/environment.BusinessHoursCheck"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "settings": {
+                    "description": "Only for check types not handled by the plugin, read only",
+                    "type": "object"
+                },
+                "timeout": {
+                    "description": "Minutes, defaults to 43200 (30 days)",
+                    "type": "integer"
+                },
+                "type": {
+                    "description": "enum: approval, branchControl, businessHours (other check types are returned with their Azure DevOps name)",
+                    "type": "string"
+                }
+            }
+        },
+        "environment.CreateEnvironmentRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Required",
+                    "type": "string"
+                }
+            }
+        },
+        "environment.CreateKubernetesResourceRequest": {
+            "type": "object",
+            "properties": {
+                "clusterName": {
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Required",
+                    "type": "string"
+                },
+                "namespace": {
+                    "description": "Required",
+                    "type": "string"
+                },
+                "serviceEndpointId": {
+                    "description": "Required - ID of the Kubernetes service connection",
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "environment.EnvironmentResourceReference": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "type": {
+                    "description": "enum: undefined, generic, virtualMachine, kubernetes",
+                    "type": "string"
+                }
+            }
+        },
+        "environment.EnvironmentResponse": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "project": {
+                    "$ref": "#/definitions/environment.ProjectReference"
+                },
+                "resources": {
+                    "description": "Sorted by type and ID, always present",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/environment.EnvironmentResourceReference"
+                    }
+                }
+            }
+        },
+        "environment.KubernetesResourceResponse": {
+            "type": "object",
+            "properties": {
+                "clusterName": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "serviceEndpointId": {
+                    "type": "string"
+                },
+                "tags": {
+                    "description": "Sorted, always present",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "environment.ProjectReference": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "environment.UpdateEnvironmentRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Required",
+                    "type": "string"
+                }
+            }
+        },
+        "forksync.ForkStatusResponse": {
+            "type": "object",
+            "properties": {
+                "aheadCount": {
+                    "description": "Commits of the fork branch that are not in the parent ref",
+                    "type": "integer"
+                },
+                "behindCount": {
+                    "description": "Commits of the parent ref that are not in the fork branch",
+                    "type": "integer"
+                },
+                "parentRepositoryId": {
+                    "type": "string"
+                },
+                "repositoryId": {
+                    "type": "string"
+                },
+                "sourceCommitId": {
+                    "type": "string"
+                },
+                "sourceRef": {
+                    "description": "Ref of the parent repository, e.g., refs/heads/main",
+                    "type": "string"
+                },
+                "targetCommitId": {
+                    "type": "string"
+                },
+                "targetRef": {
+                    "description": "Branch of the fork, e.g., refs/heads/main",
+                    "type": "string"
+                },
+                "upToDate": {
+                    "description": "true if the fork branch is not behind the parent ref",
+                    "type": "boolean"
+                }
+            }
+        },
+        "forksync.SourceToTargetRef": {
+            "type": "object",
+            "properties": {
+                "sourceRef": {
+                    "type": "string"
+                },
+                "targetRef": {
+                    "type": "string"
+                }
+            }
+        },
+        "forksync.SyncOperationResponse": {
+            "type": "object",
+            "properties": {
+                "currentStep": {
+                    "type": "string"
+                },
+                "errorMessage": {
+                    "type": "string"
+                },
+                "operationId": {
+                    "type": "integer"
+                },
+                "refs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/forksync.SourceToTargetRef"
+                    }
+                },
+                "status": {
+                    "description": "queued, inProgress, completed, failed or abandoned",
+                    "type": "string"
+                }
+            }
+        },
+        "forksync.SyncRequest": {
+            "type": "object",
+            "properties": {
+                "sourceRef": {
+                    "description": "Ref of the parent repository, defaults to the default branch of the fork",
+                    "type": "string"
+                },
+                "targetRef": {
+                    "description": "Branch of the fork, defaults to SourceRef",
+                    "type": "string"
+                }
+            }
+        },
+        "forksync.SyncResponse": {
+            "type": "object",
+            "properties": {
+                "aheadCount": {
+                    "description": "Commits of the fork branch that are not in the parent ref",
+                    "type": "integer"
+                },
+                "behindCount": {
+                    "description": "Commits of the parent ref that are not in the fork branch",
+                    "type": "integer"
+                },
+                "operationId": {
+                    "description": "Fork sync request, not set if the fork was already up to date",
+                    "type": "integer"
+                },
+                "parentRepositoryId": {
+                    "type": "string"
+                },
+                "repositoryId": {
+                    "type": "string"
+                },
+                "sourceCommitId": {
+                    "type": "string"
+                },
+                "sourceRef": {
+                    "description": "Ref of the parent repository, e.g., refs/heads/main",
+                    "type": "string"
+                },
+                "syncStatus": {
+                    "description": "upToDate (nothing to synchronize) or completed",
+                    "type": "string"
+                },
+                "targetCommitId": {
+                    "type": "string"
+                },
+                "targetRef": {
+                    "description": "Branch of the fork, e.g., refs/heads/main",
+                    "type": "string"
+                },
+                "upToDate": {
+                    "description": "true if the fork branch is not behind the parent ref",
+                    "type": "boolean"
+                }
+            }
+        },
+        "gitpermission.IdentityPermissions": {
+            "type": "object",
+            "properties": {
+                "allow": {
+                    "description": "Explicitly allowed permissions, sorted by name",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "deny": {
+                    "description": "Explicitly denied permissions, sorted by name",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "effective": {
+                    "description": "Effective permissions (explicit or inherited), \"allow\" or \"deny\" keyed by permission name",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "gitpermission.IdentityPermissionsRequest": {
+            "type": "object",
+            "properties": {
+                "allow": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "deny": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "gitpermission.RepositoryPermissions": {
+            "type": "object",
+            "properties": {
+                "branch": {
+                    "description": "Not set for the permissions of the repository",
+                    "type": "string"
+                },
+                "inheritPermissions": {
+                    "type": "boolean"
+                },
+                "permissions": {
+                    "description": "Keyed by subject descriptor",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/gitpermission.IdentityPermissions"
+                    }
+                },
+                "projectId": {
+                    "type": "string"
+                },
+                "repositoryId": {
+                    "type": "string"
+                },
+                "token": {
+                    "description": "Security token, e.g., repoV2/{projectId}/{repositoryId}",
+                    "type": "string"
+                }
+            }
+        },
+        "gitpermission.RepositoryPermissionsRequest": {
+            "type": "object",
+            "properties": {
+                "permissions": {
+                    "description": "Keyed by subject descriptor (e.g., vssgp.xxx, aad.xxx) or identity descriptor",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/gitpermission.IdentityPermissionsRequest"
+                    }
+                }
+            }
+        },
+        "gitpush.FileChange": {
+            "type": "object",
+            "properties": {
+                "changeType": {
+                    "description": "add, edit or delete",
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                }
+            }
+        },
+        "gitpush.FileRequest": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "description": "Content of the file, ignored when Delete is set",
+                    "type": "string"
+                },
+                "contentType": {
+                    "description": "rawtext (default) or base64encoded",
+                    "type": "string"
+                },
+                "delete": {
+                    "description": "The file must not exist",
+                    "type": "boolean"
+                },
+                "path": {
+                    "description": "Required: e.g., /pipelines/build.yml",
+                    "type": "string"
+                }
+            }
+        },
+        "gitpush.PushRequest": {
+            "type": "object",
+            "properties": {
+                "branch": {
+                    "description": "Required: e.g., main or refs/heads/main",
+                    "type": "string"
+                },
+                "comment": {
+                    "description": "Commit message, defaults to DefaultComment",
+                    "type": "string"
+                },
+                "files": {
+                    "description": "Required: at least one file",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/gitpush.FileRequest"
+                    }
+                }
+            }
+        },
+        "gitpush.PushResponse": {
+            "type": "object",
+            "properties": {
+                "branch": {
+                    "description": "e.g., refs/heads/main",
+                    "type": "string"
+                },
+                "changes": {
+                    "description": "Files changed by the commit, sorted by path",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/gitpush.FileChange"
+                    }
+                },
+                "commitId": {
+                    "description": "Commit the branch points to after the push",
+                    "type": "string"
+                },
+                "pushId": {
+                    "description": "Only set when a commit was pushed",
+                    "type": "integer"
+                },
+                "pushed": {
+                    "description": "false if all the files were already up to date",
+                    "type": "boolean"
+                }
+            }
+        },
+        "gitref.BranchResponse": {
+            "type": "object",
+            "properties": {
+                "creator": {
+                    "$ref": "#/definitions/gitref.IdentityRef"
+                },
+                "isLocked": {
+                    "type": "boolean"
+                },
+                "isLockedBy": {
+                    "$ref": "#/definitions/gitref.IdentityRef"
+                },
+                "name": {
+                    "description": "e.g., refs/heads/main",
+                    "type": "string"
+                },
+                "objectId": {
+                    "type": "string"
+                },
+                "peeledObjectId": {
+                    "description": "Commit of an annotated tag",
+                    "type": "string"
+                }
+            }
+        },
+        "gitref.CreateBranchRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "description": "Required: e.g., feature/x or refs/heads/feature/x",
+                    "type": "string"
+                },
+                "sourceCommitId": {
+                    "description": "Full SHA-1 of the commit",
+                    "type": "string"
+                },
+                "sourceRef": {
+                    "description": "e.g., main, refs/heads/main or refs/tags/v1.0",
+                    "type": "string"
+                }
+            }
+        },
+        "gitref.IdentityRef": {
+            "type": "object",
+            "properties": {
+                "displayName": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "uniqueName": {
+                    "type": "string"
+                }
+            }
+        },
+        "gitref.Ref": {
+            "type": "object",
+            "properties": {
+                "creator": {
+                    "$ref": "#/definitions/gitref.IdentityRef"
+                },
+                "isLocked": {
+                    "type": "boolean"
+                },
+                "isLockedBy": {
+                    "$ref": "#/definitions/gitref.IdentityRef"
+                },
+                "name": {
+                    "description": "e.g., refs/heads/main",
+                    "type": "string"
+                },
+                "objectId": {
+                    "type": "string"
+                },
+                "peeledObjectId": {
+                    "description": "Commit of an annotated tag",
+                    "type": "string"
+                }
+            }
+        },
+        "gitref.RefListResponse": {
+            "type": "object",
+            "properties": {
+                "continuationToken": {
+                    "description": "Set when there are more refs, to get the next page",
+                    "type": "string"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/gitref.Ref"
+                    }
+                }
+            }
+        },
+        "gitref.UpdateBranchRequest": {
+            "type": "object",
+            "properties": {
+                "isLocked": {
+                    "description": "Required",
+                    "type": "boolean"
+                }
+            }
+        },
+        "gitrepository.AzureDevOpsTime": {
+            "type": "object",
+            "properties": {
+                "time.Time": {
+                    "type": "string"
+                }
+            }
+        },
+        "gitrepository.CreateRepositoryRequest": {
+            "type": "object",
+            "properties": {
+                "defaultBranch": {
+                    "type": "string"
+                },
+                "initialize": {
+                    "description": "Indicates if the repository should be initialized with an initial commit",
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parentRepository": {
                     "$ref": "#/definitions/gitrepository.GitRepositoryRefMinimal"
                 },
-                "project": {
-                    "$ref": "#/definitions/gitrepository.TeamProjectReferenceMinimal"
+                "project": {
+                    "$ref": "#/definitions/gitrepository.TeamProjectReferenceMinimal"
+                }
+            }
+        },
+        "gitrepository.CreateRepositoryResponse": {
+            "type": "object",
+            "properties": {
+                "_links": {
+                    "$ref": "#/definitions/gitrepository.ReferenceLinks"
+                },
+                "creationDate": {
+                    "$ref": "#/definitions/gitrepository.AzureDevOpsTime"
+                },
+                "defaultBranch": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "isDisabled": {
+                    "type": "boolean"
+                },
+                "isFork": {
+                    "type": "boolean"
+                },
+                "isInMaintenance": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parentRepository": {
+                    "$ref": "#/definitions/gitrepository.GitRepositoryRef"
+                },
+                "project": {
+                    "$ref": "#/definitions/gitrepository.TeamProjectReference"
+                },
+                "remoteUrl": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "sshUrl": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "validRemoteUrls": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "webUrl": {
+                    "type": "string"
+                }
+            }
+        },
+        "gitrepository.GitRepositoryRef": {
+            "type": "object",
+            "properties": {
+                "collection": {
+                    "$ref": "#/definitions/gitrepository.TeamProjectCollectionReference"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "isFork": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "project": {
+                    "$ref": "#/definitions/gitrepository.TeamProjectReference"
+                },
+                "remoteUrl": {
+                    "type": "string"
+                },
+                "sshUrl": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "gitrepository.GitRepositoryRefMinimal": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "project": {
+                    "$ref": "#/definitions/gitrepository.TeamProjectReferenceMinimal"
+                }
+            }
+        },
+        "gitrepository.ProjectState": {
+            "type": "string",
+            "enum": [
+                "deleting",
+                "new",
+                "wellFormed",
+                "createPending",
+                "all",
+                "unchanged",
+                "deleted"
+            ],
+            "x-enum-varnames": [
+                "ProjectStateDeleting",
+                "ProjectStateNew",
+                "ProjectStateWellFormed",
+                "ProjectStateCreatePending",
+                "ProjectStateAll",
+                "ProjectStateUnchanged",
+                "ProjectStateDeleted"
+            ]
+        },
+        "gitrepository.ProjectVisibility": {
+            "type": "string",
+            "enum": [
+                "private",
+                "public"
+            ],
+            "x-enum-varnames": [
+                "ProjectVisibilityPrivate",
+                "ProjectVisibilityPublic"
+            ]
+        },
+        "gitrepository.ReferenceLinks": {
+            "type": "object",
+            "properties": {
+                "links": {
+                    "type": "object",
+                    "additionalProperties": true
+                }
+            }
+        },
+        "gitrepository.TeamProjectCollectionReference": {
+            "type": "object",
+            "properties": {
+                "avatarUrl": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "gitrepository.TeamProjectReference": {
+            "type": "object",
+            "properties": {
+                "abbreviation": {
+                    "type": "string"
+                },
+                "defaultTeamImageUrl": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lastUpdateTime": {
+                    "$ref": "#/definitions/gitrepository.AzureDevOpsTime"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "revision": {
+                    "type": "integer"
+                },
+                "state": {
+                    "$ref": "#/definitions/gitrepository.ProjectState"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "visibility": {
+                    "$ref": "#/definitions/gitrepository.ProjectVisibility"
+                }
+            }
+        },
+        "gitrepository.TeamProjectReferenceMinimal": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "graph.GroupListResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "groups": {
+                    "description": "Sorted by principal name",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/graph.GroupSummary"
+                    }
+                }
+            }
+        },
+        "graph.GroupMember": {
+            "type": "object",
+            "properties": {
+                "descriptor": {
+                    "type": "string"
+                },
+                "displayName": {
+                    "type": "string"
+                },
+                "principalName": {
+                    "type": "string"
+                },
+                "subjectKind": {
+                    "description": "e.g., user, group",
+                    "type": "string"
+                }
+            }
+        },
+        "graph.GroupMemberRequest": {
+            "type": "object",
+            "properties": {
+                "descriptor": {
+                    "type": "string"
+                },
+                "originId": {
+                    "type": "string"
+                },
+                "principalName": {
+                    "type": "string"
+                }
+            }
+        },
+        "graph.GroupRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "displayName": {
+                    "description": "Required on creation, cannot be changed",
+                    "type": "string"
+                },
+                "members": {
+                    "description": "If set, the members of the group are reconciled with it. If not set, members are not changed",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/graph.GroupMemberRequest"
+                    }
+                },
+                "projectId": {
+                    "description": "Project of the group, the group is created at organization level if not set. Cannot be changed",
+                    "type": "string"
+                }
+            }
+        },
+        "graph.GroupResponse": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "descriptor": {
+                    "type": "string"
+                },
+                "displayName": {
+                    "type": "string"
+                },
+                "domain": {
+                    "type": "string"
+                },
+                "members": {
+                    "description": "Sorted by descriptor",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/graph.GroupMember"
+                    }
+                },
+                "origin": {
+                    "description": "e.g., vsts, aad",
+                    "type": "string"
+                },
+                "originId": {
+                    "type": "string"
+                },
+                "principalName": {
+                    "description": "e.g., [Project]\\Group",
+                    "type": "string"
+                }
+            }
+        },
+        "graph.GroupSummary": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "descriptor": {
+                    "type": "string"
+                },
+                "displayName": {
+                    "type": "string"
+                },
+                "domain": {
+                    "type": "string"
+                },
+                "origin": {
+                    "description": "e.g., vsts, aad",
+                    "type": "string"
+                },
+                "originId": {
+                    "type": "string"
+                },
+                "principalName": {
+                    "description": "e.g., [Project]\\Group",
+                    "type": "string"
+                }
+            }
+        },
+        "graph.ResolveRequest": {
+            "type": "object",
+            "properties": {
+                "principalNames": {
+                    "description": "UPNs, emails or group principal names",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "graph.ResolveResponse": {
+            "type": "object",
+            "properties": {
+                "subjects": {
+                    "description": "Sorted by principal name",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/graph.ResolvedSubject"
+                    }
+                }
+            }
+        },
+        "graph.ResolvedSubject": {
+            "type": "object",
+            "properties": {
+                "descriptor": {
+                    "type": "string"
+                },
+                "displayName": {
+                    "type": "string"
+                },
+                "principalName": {
+                    "description": "As given in the request",
+                    "type": "string"
+                },
+                "subjectKind": {
+                    "type": "string"
+                }
+            }
+        },
+        "pipeline.AgentPoolQueue": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "pipeline.BuildRepository": {
+            "type": "object",
+            "properties": {
+                "connection": {
+                    "description": "Required for GitHub and Bitbucket repositories",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/pipeline.ServiceConnectionReference"
+                        }
+                    ]
+                },
+                "fullName": {
+                    "description": "GitHub and Bitbucket repositories only (e.g., owner/repo)",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Required - for GitHub and Bitbucket repositories it is the full name (e.g., owner/repo)",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "Required - enum: unknown, gitHub, azureReposGit, azureReposGitHyphenated, bitbucket",
+                    "type": "string"
+                }
+            }
+        },
+        "pipeline.CITrigger": {
+            "type": "object",
+            "properties": {
+                "batchChanges": {
+                    "type": "boolean"
+                },
+                "branchFilters": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "maxConcurrentBuildsPerBranch": {
+                    "type": "integer"
+                },
+                "overrideYaml": {
+                    "type": "boolean"
+                },
+                "pathFilters": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "pipeline.CreateFolderRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "path": {
+                    "description": "Required - any separator ('/' or '\\') is accepted",
+                    "type": "string"
+                }
+            }
+        },
+        "pipeline.DesignerConfiguration": {
+            "type": "object",
+            "properties": {
+                "process": {
+                    "description": "Required",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/pipeline.DesignerProcess"
+                        }
+                    ]
+                },
+                "queue": {
+                    "$ref": "#/definitions/pipeline.AgentPoolQueue"
+                }
+            }
+        },
+        "pipeline.DesignerProcess": {
+            "type": "object",
+            "properties": {
+                "phases": {
+                    "description": "Required",
+                    "type": "array",
+                    "items": {
+                        "type": "object"
+                    }
+                },
+                "target": {
+                    "type": "object"
+                }
+            }
+        },
+        "pipeline.FolderResponse": {
+            "type": "object",
+            "properties": {
+                "createdOn": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "lastChangedDate": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "project": {
+                    "$ref": "#/definitions/pipeline.TeamProjectReference"
+                }
+            }
+        },
+        "pipeline.GetPipelineResponse": {
+            "type": "object",
+            "properties": {
+                "_links": {
+                    "$ref": "#/definitions/pipeline.ReferenceLinks"
+                },
+                "configuration": {
+                    "$ref": "#/definitions/pipeline.PipelineConfiguration"
+                },
+                "decodeErrors": {
+                    "description": "Added by the plugin, the fields of the underlying build definition that could not be decoded (left empty in the response)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "folder": {
+                    "description": "Embedded fields from PipelineBase",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "revision": {
+                    "type": "integer"
+                },
+                "triggers": {
+                    "description": "Added by the plugin, read from the triggers of the underlying build definition",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/pipeline.PipelineTriggers"
+                        }
+                    ]
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "pipeline.ListFoldersResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pipeline.FolderResponse"
+                    }
+                }
+            }
+        },
+        "pipeline.ListPipelinesResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "array",
+                    "items": {
+                        "type": "object"
+                    }
+                }
+            }
+        },
+        "pipeline.PipelineConfiguration": {
+            "type": "object",
+            "properties": {
+                "designerJson": {
+                    "description": "Only for designer (classic) pipelines, added by the plugin",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/pipeline.DesignerConfiguration"
+                        }
+                    ]
+                },
+                "path": {
+                    "type": "string"
+                },
+                "repository": {
+                    "description": "Required",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/pipeline.BuildRepository"
+                        }
+                    ]
+                },
+                "type": {
+                    "description": "enum: unknown, yaml, designerJson, justInTime, designerHyphenJson",
+                    "type": "string"
+                }
+            }
+        },
+        "pipeline.PipelineConfigurationParameters": {
+            "type": "object",
+            "properties": {
+                "designerJson": {
+                    "description": "Required if type is designerJson or designerHyphenJson",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/pipeline.DesignerConfiguration"
+                        }
+                    ]
+                },
+                "path": {
+                    "type": "string"
+                },
+                "repository": {
+                    "description": "Required",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/pipeline.BuildRepository"
+                        }
+                    ]
+                },
+                "type": {
+                    "description": "Required - enum: unknown, yaml, designerJson, justInTime, designerHyphenJson",
+                    "type": "string"
+                }
+            }
+        },
+        "pipeline.PipelineSchedule": {
+            "type": "object",
+            "properties": {
+                "branchFilters": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "daysToBuild": {
+                    "description": "e.g., monday, tuesday (ordered from monday to sunday)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "scheduleOnlyWithChanges": {
+                    "type": "boolean"
+                },
+                "startHours": {
+                    "type": "integer"
+                },
+                "startMinutes": {
+                    "type": "integer"
+                },
+                "timeZoneId": {
+                    "type": "string"
+                }
+            }
+        },
+        "pipeline.PipelineTriggers": {
+            "type": "object",
+            "properties": {
+                "ci": {
+                    "$ref": "#/definitions/pipeline.CITrigger"
+                },
+                "pullRequest": {
+                    "$ref": "#/definitions/pipeline.PullRequestTrigger"
+                },
+                "schedule": {
+                    "$ref": "#/definitions/pipeline.ScheduleTrigger"
+                }
+            }
+        },
+        "pipeline.PullRequestForks": {
+            "type": "object",
+            "properties": {
+                "allowSecrets": {
+                    "type": "boolean"
+                },
+                "enabled": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "pipeline.PullRequestTrigger": {
+            "type": "object",
+            "properties": {
+                "autoCancel": {
+                    "type": "boolean"
+                },
+                "branchFilters": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "forks": {
+                    "$ref": "#/definitions/pipeline.PullRequestForks"
+                },
+                "isCommentRequiredForPullRequest": {
+                    "type": "boolean"
+                },
+                "overrideYaml": {
+                    "type": "boolean"
+                },
+                "pathFilters": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "requireCommentsForNonTeamMembersOnly": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "pipeline.ReferenceLinks": {
+            "type": "object",
+            "properties": {
+                "links": {
+                    "type": "object",
+                    "additionalProperties": true
+                }
+            }
+        },
+        "pipeline.ScheduleTrigger": {
+            "type": "object",
+            "properties": {
+                "overrideYaml": {
+                    "type": "boolean"
+                },
+                "schedules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pipeline.PipelineSchedule"
+                    }
+                }
+            }
+        },
+        "pipeline.ServiceConnectionReference": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "pipeline.TeamProjectReference": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "pipeline.UpdatePipelineRequest": {
+            "type": "object",
+            "properties": {
+                "configuration": {
+                    "$ref": "#/definitions/pipeline.PipelineConfigurationParameters"
+                },
+                "folder": {
+                    "type": "string"
+                },
+                "id": {
+                    "description": "maybe to be removed since RDC does not include it",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "revision": {
+                    "type": "integer"
+                },
+                "triggers": {
+                    "description": "Additional field handled by the plugin",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/pipeline.PipelineTriggers"
+                        }
+                    ]
+                }
+            }
+        },
+        "pipeline.UpdatePipelineResponse": {
+            "type": "object",
+            "properties": {
+                "_links": {
+                    "$ref": "#/definitions/pipeline.ReferenceLinks"
+                },
+                "configuration": {
+                    "$ref": "#/definitions/pipeline.PipelineConfiguration"
+                },
+                "decodeErrors": {
+                    "description": "Added by the plugin, the fields of the underlying build definition that could not be decoded (left empty in the response)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "folder": {
+                    "description": "Embedded fields from PipelineBase",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "revision": {
+                    "type": "integer"
+                },
+                "triggers": {
+                    "description": "Added by the plugin, read from the triggers of the underlying build definition",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/pipeline.PipelineTriggers"
+                        }
+                    ]
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "pipelinepermission.AllPipelinesPermissionRequest": {
+            "type": "object",
+            "properties": {
+                "authorized": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "pipelinepermission.BulkPipelinePermissionsQueryRequest": {
+            "type": "object",
+            "properties": {
+                "resources": {
+                    "description": "Required",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pipelinepermission.BulkResourceReference"
+                    }
+                }
+            }
+        },
+        "pipelinepermission.BulkPipelinePermissionsResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pipelinepermission.BulkPipelinePermissionsResult"
+                    }
+                },
+                "succeeded": {
+                    "type": "integer"
+                }
+            }
+        },
+        "pipelinepermission.BulkPipelinePermissionsResult": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "description": "Only for failed resources",
+                    "type": "string"
+                },
+                "permissions": {
+                    "description": "Normalized ResourcePipelinePermissions",
+                    "type": "object"
+                },
+                "resourceId": {
+                    "type": "string"
+                },
+                "resourceType": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "enum: retrieved, unchanged, updated, failed",
+                    "type": "string"
+                },
+                "statusCode": {
+                    "description": "Only for failed resources",
+                    "type": "integer"
+                }
+            }
+        },
+        "pipelinepermission.BulkPipelinePermissionsUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "resources": {
+                    "description": "Required",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pipelinepermission.BulkResourcePipelinePermissionsRequest"
+                    }
+                }
+            }
+        },
+        "pipelinepermission.BulkResourcePipelinePermissionsRequest": {
+            "type": "object",
+            "properties": {
+                "allPipelines": {
+                    "description": "If not set, the current value is kept",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/pipelinepermission.AllPipelinesPermissionRequest"
+                        }
+                    ]
+                },
+                "pipelines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pipelinepermission.PipelinePermissionRequest"
+                    }
+                },
+                "resourceId": {
+                    "description": "Required",
+                    "type": "string"
+                },
+                "resourceType": {
+                    "description": "Required - see ResourceType",
+                    "type": "string"
+                }
+            }
+        },
+        "pipelinepermission.BulkResourceReference": {
+            "type": "object",
+            "properties": {
+                "resourceId": {
+                    "description": "Required",
+                    "type": "string"
+                },
+                "resourceType": {
+                    "description": "Required - see ResourceType",
+                    "type": "string"
+                }
+            }
+        },
+        "pipelinepermission.IdentityRef": {
+            "type": "object",
+            "properties": {
+                "_links": {
+                    "$ref": "#/definitions/pipelinepermission.ReferenceLinks"
+                },
+                "descriptor": {
+                    "type": "string"
+                },
+                "directoryAlias": {
+                    "type": "string"
+                },
+                "displayName": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "imageUrl": {
+                    "type": "string"
+                },
+                "inactive": {
+                    "type": "boolean"
+                },
+                "isAadIdentity": {
+                    "type": "boolean"
+                },
+                "isContainer": {
+                    "type": "boolean"
+                },
+                "isDeletedInOrigin": {
+                    "type": "boolean"
+                },
+                "profileUrl": {
+                    "type": "string"
+                },
+                "uniqueName": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "pipelinepermission.Permission": {
+            "type": "object",
+            "properties": {
+                "authorized": {
+                    "type": "boolean"
+                },
+                "authorizedBy": {
+                    "$ref": "#/definitions/pipelinepermission.IdentityRef"
+                },
+                "authorizedOn": {
+                    "type": "string"
+                }
+            }
+        },
+        "pipelinepermission.PipelinePermission": {
+            "type": "object",
+            "properties": {
+                "authorized": {
+                    "type": "boolean"
+                },
+                "authorizedBy": {
+                    "$ref": "#/definitions/pipelinepermission.IdentityRef"
+                },
+                "authorizedOn": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "pipelinepermission.PipelinePermissionRequest": {
+            "type": "object",
+            "properties": {
+                "authorized": {
+                    "description": "Defaults to true",
+                    "type": "boolean"
+                },
+                "id": {
+                    "description": "Required",
+                    "type": "integer"
+                }
+            }
+        },
+        "pipelinepermission.ReferenceLinks": {
+            "type": "object",
+            "properties": {
+                "links": {
+                    "type": "object",
+                    "additionalProperties": true
+                }
+            }
+        },
+        "pipelinepermission.Resource": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "pipelinepermission.ResourcePipelinePermissions": {
+            "type": "object",
+            "properties": {
+                "allPipelines": {
+                    "$ref": "#/definitions/pipelinepermission.Permission"
+                },
+                "pipelines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pipelinepermission.PipelinePermission"
+                    }
+                },
+                "resource": {
+                    "$ref": "#/definitions/pipelinepermission.Resource"
+                }
+            }
+        },
+        "pipelinepermission.UpdatePipelinePermissionRequest": {
+            "type": "object",
+            "properties": {
+                "allPipelines": {
+                    "description": "If not set, the current value is kept",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/pipelinepermission.AllPipelinesPermissionRequest"
+                        }
+                    ]
+                },
+                "pipelines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pipelinepermission.PipelinePermissionRequest"
+                    }
+                }
+            }
+        },
+        "policy.PolicyRequest": {
+            "type": "object",
+            "properties": {
+                "isBlocking": {
+                    "description": "Defaults to true",
+                    "type": "boolean"
+                },
+                "isEnabled": {
+                    "description": "Defaults to true",
+                    "type": "boolean"
+                },
+                "matchKind": {
+                    "description": "Exact (default) or Prefix",
+                    "type": "string"
+                },
+                "refName": {
+                    "description": "e.g., main or refs/heads/main. If not set, the policy applies to all the branches",
+                    "type": "string"
+                },
+                "repositoryId": {
+                    "description": "If not set, the policy applies to all the repositories of the project",
+                    "type": "string"
+                },
+                "settings": {
+                    "description": "Settings of the policy type, without scope",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "type": {
+                    "description": "Required: policy type ID, alias (e.g., minimumReviewers) or display name",
+                    "type": "string"
+                }
+            }
+        },
+        "policy.PolicyResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "isBlocking": {
+                    "type": "boolean"
+                },
+                "isEnabled": {
+                    "type": "boolean"
+                },
+                "matchKind": {
+                    "type": "string"
+                },
+                "refName": {
+                    "type": "string"
+                },
+                "repositoryId": {
+                    "type": "string"
+                },
+                "settings": {
+                    "description": "Normalized: without scope and null values, keys sorted",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "type": {
+                    "description": "Policy type ID",
+                    "type": "string"
+                },
+                "typeName": {
+                    "description": "e.g., Minimum number of reviewers",
+                    "type": "string"
+                }
+            }
+        },
+        "project.CreateProjectRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Required",
+                    "type": "string"
+                },
+                "processTemplate": {
+                    "description": "Name or ID of the process, defaults to the default process of the organization",
+                    "type": "string"
+                },
+                "sourceControlType": {
+                    "description": "Git (default) or Tfvc",
+                    "type": "string"
+                },
+                "visibility": {
+                    "description": "Defaults to private",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/gitrepository.ProjectVisibility"
+                        }
+                    ]
+                }
+            }
+        },
+        "project.ProcessTemplateCapability": {
+            "type": "object",
+            "properties": {
+                "templateName": {
+                    "type": "string"
+                },
+                "templateTypeId": {
+                    "type": "string"
+                }
+            }
+        },
+        "project.ProjectCapabilities": {
+            "type": "object",
+            "properties": {
+                "processTemplate": {
+                    "$ref": "#/definitions/project.ProcessTemplateCapability"
+                },
+                "versioncontrol": {
+                    "$ref": "#/definitions/project.VersionControlCapability"
+                }
+            }
+        },
+        "project.ProjectResponse": {
+            "type": "object",
+            "properties": {
+                "abbreviation": {
+                    "type": "string"
+                },
+                "capabilities": {
+                    "$ref": "#/definitions/project.ProjectCapabilities"
+                },
+                "defaultTeamImageUrl": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lastUpdateTime": {
+                    "$ref": "#/definitions/gitrepository.AzureDevOpsTime"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "revision": {
+                    "type": "integer"
+                },
+                "state": {
+                    "$ref": "#/definitions/gitrepository.ProjectState"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "visibility": {
+                    "$ref": "#/definitions/gitrepository.ProjectVisibility"
                 }
             }
         },
-        "gitrepository.CreateRepositoryResponse": {
+        "project.UpdateProjectRequest": {
             "type": "object",
             "properties": {
-                "_links": {
-                    "$ref": "#/definitions/gitrepository.ReferenceLinks"
+                "description": {
+                    "type": "string"
                 },
-                "creationDate": {
-                    "$ref": "#/definitions/gitrepository.AzureDevOpsTime"
+                "name": {
+                    "type": "string"
                 },
-                "defaultBranch": {
+                "visibility": {
+                    "$ref": "#/definitions/gitrepository.ProjectVisibility"
+                }
+            }
+        },
+        "project.VersionControlCapability": {
+            "type": "object",
+            "properties": {
+                "sourceControlType": {
+                    "description": "enum: Git, Tfvc",
                     "type": "string"
+                }
+            }
+        },
+        "pullrequest.CompletionOptions": {
+            "type": "object",
+            "properties": {
+                "deleteSourceBranch": {
+                    "type": "boolean"
                 },
-                "id": {
+                "mergeCommitMessage": {
                     "type": "string"
                 },
-                "isDisabled": {
-                    "type": "boolean"
+                "mergeStrategy": {
+                    "description": "noFastForward, squash, rebase or rebaseMerge",
+                    "type": "string"
                 },
-                "isFork": {
+                "transitionWorkItems": {
                     "type": "boolean"
-                },
-                "isInMaintenance": {
+                }
+            }
+        },
+        "pullrequest.PullRequestRequest": {
+            "type": "object",
+            "properties": {
+                "autoComplete": {
+                    "description": "If not set, auto-complete is left as it is",
                     "type": "boolean"
                 },
-                "name": {
+                "completionOptions": {
+                    "description": "If not set, the completion options are left as they are",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/pullrequest.CompletionOptions"
+                        }
+                    ]
+                },
+                "description": {
+                    "description": "If not set, the description of an existing pull request is kept",
                     "type": "string"
                 },
-                "parentRepository": {
-                    "$ref": "#/definitions/gitrepository.GitRepositoryRef"
+                "isDraft": {
+                    "description": "If not set, defaults to false on creation",
+                    "type": "boolean"
                 },
-                "project": {
-                    "$ref": "#/definitions/gitrepository.TeamProjectReference"
+                "labels": {
+                    "description": "Labels to add",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "remoteUrl": {
+                "reviewers": {
+                    "description": "Reviewers to add",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pullrequest.ReviewerRequest"
+                    }
+                },
+                "sourceRefName": {
+                    "description": "Required: e.g., feature/x or refs/heads/feature/x",
                     "type": "string"
                 },
-                "size": {
-                    "type": "integer"
+                "targetRefName": {
+                    "description": "Required: e.g., main or refs/heads/main",
+                    "type": "string"
                 },
-                "sshUrl": {
+                "title": {
+                    "description": "Required",
                     "type": "string"
                 },
-                "url": {
+                "workItemIds": {
+                    "description": "Work items to link",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "pullrequest.PullRequestResponse": {
+            "type": "object",
+            "properties": {
+                "autoComplete": {
+                    "type": "boolean"
+                },
+                "completionOptions": {
+                    "$ref": "#/definitions/pullrequest.CompletionOptions"
+                },
+                "description": {
                     "type": "string"
                 },
-                "validRemoteUrls": {
+                "isDraft": {
+                    "type": "boolean"
+                },
+                "labels": {
+                    "description": "Sorted",
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "webUrl": {
+                "lastMergeCommitId": {
+                    "description": "Merge commit, set once the pull request is completed",
+                    "type": "string"
+                },
+                "mergeStatus": {
+                    "description": "e.g., queued, succeeded or conflicts",
+                    "type": "string"
+                },
+                "pullRequestId": {
+                    "type": "integer"
+                },
+                "reviewers": {
+                    "description": "Sorted by ID",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pullrequest.Reviewer"
+                    }
+                },
+                "sourceRefName": {
                     "type": "string"
+                },
+                "status": {
+                    "description": "active, abandoned or completed",
+                    "type": "string"
+                },
+                "targetRefName": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "workItemIds": {
+                    "description": "Sorted",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 }
             }
         },
-        "gitrepository.GitRepositoryRef": {
+        "pullrequest.Reviewer": {
             "type": "object",
             "properties": {
-                "collection": {
-                    "$ref": "#/definitions/gitrepository.TeamProjectCollectionReference"
+                "displayName": {
+                    "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "isFork": {
+                "isRequired": {
                     "type": "boolean"
                 },
-                "name": {
+                "uniqueName": {
                     "type": "string"
                 },
-                "project": {
-                    "$ref": "#/definitions/gitrepository.TeamProjectReference"
+                "vote": {
+                    "description": "10 approved, 5 approved with suggestions, 0 no vote, -5 waiting for author, -10 rejected",
+                    "type": "integer"
+                }
+            }
+        },
+        "pullrequest.ReviewerRequest": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "Required: identity ID (GUID) of a user or a group",
+                    "type": "string"
                 },
-                "remoteUrl": {
+                "isRequired": {
+                    "description": "Defaults to false (optional reviewer)",
+                    "type": "boolean"
+                }
+            }
+        },
+        "serviceendpoint.EndpointAuthorization": {
+            "type": "object",
+            "properties": {
+                "parameters": {
+                    "description": "Keys are lowercased. In responses secret parameters and parameters without value are not returned, always present",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "scheme": {
+                    "description": "e.g., Kubeconfig, ServiceAccount, ServicePrincipal, WorkloadIdentityFederation, ManagedServiceIdentity, UsernamePassword",
+                    "type": "string"
+                }
+            }
+        },
+        "serviceendpoint.ServiceEndpointProjectReference": {
+            "type": "object",
+            "properties": {
+                "description": {
                     "type": "string"
                 },
-                "sshUrl": {
+                "name": {
+                    "description": "Name of the service connection in the project, defaults to the name of the service connection",
                     "type": "string"
                 },
-                "url": {
+                "projectId": {
+                    "description": "Required",
                     "type": "string"
                 }
             }
         },
-        "gitrepository.GitRepositoryRefMinimal": {
+        "serviceendpoint.ServiceEndpointRequest": {
             "type": "object",
             "properties": {
-                "id": {
+                "authorization": {
+                    "description": "Required - secret parameters are write only",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/serviceendpoint.EndpointAuthorization"
+                        }
+                    ]
+                },
+                "data": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "description": {
                     "type": "string"
                 },
-                "project": {
-                    "$ref": "#/definitions/gitrepository.TeamProjectReferenceMinimal"
+                "name": {
+                    "description": "Required",
+                    "type": "string"
+                },
+                "projectReferences": {
+                    "description": "Defaults to the project in the path",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/serviceendpoint.ServiceEndpointProjectReference"
+                    }
+                },
+                "type": {
+                    "description": "Required - enum: kubernetes, azurerm, generic",
+                    "type": "string"
+                },
+                "url": {
+                    "description": "Required for kubernetes and generic, defaults to https://management.azure.com/ for azurerm",
+                    "type": "string"
                 }
             }
         },
-        "gitrepository.ProjectState": {
-            "type": "string",
-            "enum": [
-                "deleting",
-                "new",
-                "wellFormed",
-                "createPending",
-                "all",
-                "unchanged",
-                "deleted"
-            ],
-            "x-enum-varnames": [
-                "ProjectStateDeleting",
-                "ProjectStateNew",
-                "ProjectStateWellFormed",
-                "ProjectStateCreatePending",
-                "ProjectStateAll",
-                "ProjectStateUnchanged",
-                "ProjectStateDeleted"
-            ]
-        },
-        "gitrepository.ProjectVisibility": {
-            "type": "string",
-            "enum": [
-                "private",
-                "public"
-            ],
-            "x-enum-varnames": [
-                "ProjectVisibilityPrivate",
-                "ProjectVisibilityPublic"
-            ]
-        },
-        "gitrepository.ReferenceLinks": {
+        "serviceendpoint.ServiceEndpointResponse": {
             "type": "object",
             "properties": {
-                "links": {
+                "authorization": {
+                    "$ref": "#/definitions/serviceendpoint.EndpointAuthorization"
+                },
+                "data": {
                     "type": "object",
-                    "additionalProperties": true
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "isReady": {
+                    "type": "boolean"
+                },
+                "isShared": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "projectReferences": {
+                    "description": "Sorted by project ID",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/serviceendpoint.ServiceEndpointProjectReference"
+                    }
+                },
+                "type": {
+                    "description": "enum: kubernetes, azurerm, generic",
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
                 }
             }
         },
-        "gitrepository.TeamProjectCollectionReference": {
+        "servicehook.SubscriptionRequest": {
             "type": "object",
             "properties": {
-                "avatarUrl": {
+                "consumerActionId": {
+                    "description": "Required, e.g., httpRequest",
                     "type": "string"
                 },
-                "id": {
+                "consumerId": {
+                    "description": "Required, e.g., webHooks",
+                    "type": "string"
+                },
+                "consumerInputs": {
+                    "description": "e.g., url - secret inputs are write only",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "eventType": {
+                    "description": "Required, e.g., git.push",
                     "type": "string"
                 },
-                "name": {
+                "publisherId": {
+                    "description": "Required, e.g., tfs",
                     "type": "string"
                 },
-                "url": {
+                "publisherInputs": {
+                    "description": "Filters of the event (e.g., repository, branch), projectId is set from the path",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "resourceVersion": {
+                    "description": "e.g., 1.0",
                     "type": "string"
                 }
             }
         },
-        "gitrepository.TeamProjectReference": {
+        "servicehook.SubscriptionResponse": {
             "type": "object",
             "properties": {
-                "abbreviation": {
+                "actionDescription": {
                     "type": "string"
                 },
-                "defaultTeamImageUrl": {
+                "consumerActionId": {
                     "type": "string"
                 },
-                "description": {
+                "consumerId": {
                     "type": "string"
                 },
-                "id": {
+                "consumerInputs": {
+                    "description": "Without secret inputs, always present",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "eventDescription": {
                     "type": "string"
                 },
-                "lastUpdateTime": {
-                    "$ref": "#/definitions/gitrepository.AzureDevOpsTime"
+                "eventType": {
+                    "type": "string"
                 },
-                "name": {
+                "id": {
                     "type": "string"
                 },
-                "revision": {
-                    "type": "integer"
+                "projectId": {
+                    "type": "string"
                 },
-                "state": {
-                    "$ref": "#/definitions/gitrepository.ProjectState"
+                "publisherId": {
+                    "type": "string"
                 },
-                "url": {
+                "publisherInputs": {
+                    "description": "Filters of the event, without projectId, always present",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "resourceVersion": {
                     "type": "string"
                 },
-                "visibility": {
-                    "$ref": "#/definitions/gitrepository.ProjectVisibility"
+                "status": {
+                    "description": "e.g., enabled, onProbation, disabledByUser, disabledBySystem",
+                    "type": "string"
                 }
             }
         },
-        "gitrepository.TeamProjectReferenceMinimal": {
+        "team.TeamMember": {
             "type": "object",
             "properties": {
-                "id": {
+                "descriptor": {
+                    "type": "string"
+                },
+                "displayName": {
+                    "type": "string"
+                },
+                "principalName": {
                     "type": "string"
                 }
             }
         },
-        "pipeline.BuildRepository": {
+        "team.TeamMemberRequest": {
             "type": "object",
             "properties": {
-                "id": {
-                    "description": "Required",
+                "descriptor": {
                     "type": "string"
                 },
-                "type": {
-                    "description": "Required - enum: unknown, gitHub, azureReposGit, azureReposGitHyphenated",
+                "principalName": {
                     "type": "string"
                 }
             }
         },
-        "pipeline.GetPipelineResponse": {
+        "team.TeamRequest": {
             "type": "object",
             "properties": {
-                "_links": {
-                    "$ref": "#/definitions/pipeline.ReferenceLinks"
-                },
-                "configuration": {
-                    "$ref": "#/definitions/pipeline.PipelineConfiguration"
-                },
-                "folder": {
-                    "description": "Embedded fields from PipelineBase",
+                "description": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
+                "members": {
+                    "description": "If set, the members of the team are reconciled with it. If not set, members are not changed",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/team.TeamMemberRequest"
+                    }
                 },
                 "name": {
-                    "type": "string"
-                },
-                "revision": {
-                    "type": "integer"
-                },
-                "url": {
+                    "description": "Required",
                     "type": "string"
                 }
             }
         },
-        "pipeline.PipelineConfiguration": {
+        "team.TeamResponse": {
             "type": "object",
             "properties": {
-                "path": {
+                "description": {
                     "type": "string"
                 },
-                "repository": {
-                    "description": "Required",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/pipeline.BuildRepository"
-                        }
-                    ]
+                "id": {
+                    "type": "string"
                 },
-                "type": {
-                    "description": "enum: unknown, yaml, designerJson, justInTime, designerHyphenJson",
+                "members": {
+                    "description": "Sorted by descriptor",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/team.TeamMember"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "projectId": {
                     "type": "string"
                 }
             }
         },
-        "pipeline.PipelineConfigurationParameters": {
+        "variablegroup.KeyVaultLink": {
             "type": "object",
             "properties": {
-                "path": {
+                "serviceEndpointId": {
+                    "description": "Required - ID of the Azure Resource Manager service connection",
                     "type": "string"
                 },
-                "repository": {
-                    "description": "Required",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/pipeline.BuildRepository"
-                        }
-                    ]
-                },
-                "type": {
-                    "description": "Required - enum: unknown, yaml, designerJson, justInTime, designerHyphenJson",
+                "vault": {
+                    "description": "Required - Name of the key vault",
                     "type": "string"
                 }
             }
         },
-        "pipeline.ReferenceLinks": {
+        "variablegroup.Variable": {
             "type": "object",
             "properties": {
-                "links": {
-                    "type": "object",
-                    "additionalProperties": true
+                "isReadOnly": {
+                    "type": "boolean"
+                },
+                "isSecret": {
+                    "type": "boolean"
+                },
+                "value": {
+                    "type": "string"
                 }
             }
         },
-        "pipeline.UpdatePipelineRequest": {
+        "variablegroup.VariableGroupProjectReference": {
             "type": "object",
             "properties": {
-                "configuration": {
-                    "$ref": "#/definitions/pipeline.PipelineConfigurationParameters"
-                },
-                "folder": {
+                "description": {
                     "type": "string"
                 },
-                "id": {
-                    "description": "maybe to be removed since RDC does not include it",
-                    "type": "integer"
-                },
                 "name": {
+                    "description": "Name of the variable group in the project, defaults to the name of the variable group",
                     "type": "string"
                 },
-                "revision": {
-                    "type": "integer"
+                "projectId": {
+                    "description": "Required",
+                    "type": "string"
                 }
             }
         },
-        "pipeline.UpdatePipelineResponse": {
+        "variablegroup.VariableGroupRequest": {
             "type": "object",
             "properties": {
-                "_links": {
-                    "$ref": "#/definitions/pipeline.ReferenceLinks"
-                },
-                "configuration": {
-                    "$ref": "#/definitions/pipeline.PipelineConfiguration"
-                },
-                "folder": {
-                    "description": "Embedded fields from PipelineBase",
+                "description": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
+                "keyVault": {
+                    "$ref": "#/definitions/variablegroup.KeyVaultLink"
                 },
                 "name": {
+                    "description": "Required",
                     "type": "string"
                 },
-                "revision": {
-                    "type": "integer"
+                "projectReferences": {
+                    "description": "Defaults to the project in the path",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/variablegroup.VariableGroupProjectReference"
+                    }
                 },
-                "url": {
-                    "type": "string"
+                "variables": {
+                    "description": "Required - at least one variable",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/variablegroup.VariableRequest"
+                    }
                 }
             }
         },
-        "pipelinepermission.IdentityRef": {
+        "variablegroup.VariableGroupResponse": {
             "type": "object",
             "properties": {
-                "_links": {
-                    "$ref": "#/definitions/pipelinepermission.ReferenceLinks"
-                },
-                "descriptor": {
-                    "type": "string"
-                },
-                "directoryAlias": {
-                    "type": "string"
-                },
-                "displayName": {
+                "description": {
                     "type": "string"
                 },
                 "id": {
-                    "type": "string"
+                    "type": "integer"
                 },
-                "imageUrl": {
+                "keyVault": {
+                    "$ref": "#/definitions/variablegroup.KeyVaultLink"
+                },
+                "name": {
                     "type": "string"
                 },
-                "inactive": {
-                    "type": "boolean"
+                "projectReferences": {
+                    "description": "Sorted by project ID",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/variablegroup.VariableGroupProjectReference"
+                    }
                 },
-                "isAadIdentity": {
-                    "type": "boolean"
+                "type": {
+                    "description": "enum: Vsts, AzureKeyVault",
+                    "type": "string"
                 },
-                "isContainer": {
+                "variables": {
+                    "description": "Always present",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/variablegroup.Variable"
+                    }
+                }
+            }
+        },
+        "variablegroup.VariableRequest": {
+            "type": "object",
+            "properties": {
+                "isReadOnly": {
                     "type": "boolean"
                 },
-                "isDeletedInOrigin": {
+                "isSecret": {
                     "type": "boolean"
                 },
-                "profileUrl": {
-                    "type": "string"
-                },
-                "uniqueName": {
-                    "type": "string"
-                },
-                "url": {
+                "value": {
+                    "description": "Omit to keep the current value of a secret variable (update only), not allowed for Key Vault variable groups",
                     "type": "string"
                 }
             }
         },
-        "pipelinepermission.Permission": {
+        "wiki.PageRequest": {
             "type": "object",
             "properties": {
-                "authorized": {
-                    "type": "boolean"
-                },
-                "authorizedBy": {
-                    "$ref": "#/definitions/pipelinepermission.IdentityRef"
-                },
-                "authorizedOn": {
+                "content": {
+                    "description": "Markdown content of the page",
                     "type": "string"
                 }
             }
         },
-        "pipelinepermission.PipelinePermission": {
+        "wiki.PageResponse": {
             "type": "object",
             "properties": {
-                "authorized": {
-                    "type": "boolean"
+                "content": {
+                    "type": "string"
                 },
-                "authorizedBy": {
-                    "$ref": "#/definitions/pipelinepermission.IdentityRef"
+                "eTag": {
+                    "description": "Version of the page, to send in the If-Match header of an update",
+                    "type": "string"
                 },
-                "authorizedOn": {
+                "gitItemPath": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
+                },
+                "isParentPage": {
+                    "type": "boolean"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "remoteUrl": {
+                    "type": "string"
                 }
             }
         },
-        "pipelinepermission.ReferenceLinks": {
+        "wiki.WikiRequest": {
             "type": "object",
             "properties": {
-                "links": {
-                    "type": "object",
-                    "additionalProperties": true
+                "mappedPath": {
+                    "description": "Folder of the repository published by a code wiki, defaults to /",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Required",
+                    "type": "string"
+                },
+                "repositoryId": {
+                    "description": "Required for a code wiki: repository ID (GUID)",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "projectWiki (default) or codeWiki",
+                    "type": "string"
+                },
+                "version": {
+                    "description": "Required for a code wiki: branch published, e.g., main",
+                    "type": "string"
                 }
             }
         },
-        "pipelinepermission.Resource": {
+        "wiki.WikiResponse": {
             "type": "object",
             "properties": {
                 "id": {
                     "type": "string"
                 },
+                "mappedPath": {
+                    "type": "string"
+                },
                 "name": {
                     "type": "string"
                 },
+                "projectId": {
+                    "type": "string"
+                },
+                "remoteUrl": {
+                    "type": "string"
+                },
+                "repositoryId": {
+                    "type": "string"
+                },
                 "type": {
+                    "description": "projectWiki or codeWiki",
                     "type": "string"
-                }
-            }
-        },
-        "pipelinepermission.ResourcePipelinePermissions": {
-            "type": "object",
-            "properties": {
-                "allPipelines": {
-                    "$ref": "#/definitions/pipelinepermission.Permission"
                 },
-                "pipelines": {
+                "versions": {
+                    "description": "Published branches, only set for code wikis",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/pipelinepermission.PipelinePermission"
+                        "type": "string"
                     }
-                },
-                "resource": {
-                    "$ref": "#/definitions/pipelinepermission.Resource"
                 }
             }
         }
@@ -0,0 +1,362 @@
+package agentpool
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	neturl "net/url"
+	"strings"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers"
+)
+
+// errNotFound is returned when Azure DevOps returns an empty body for a missing resource
+var errNotFound = errors.New("resource not found")
+
+// Handler constructors
+func GetAgentPool(opts handlers.HandlerOptions) handlers.Handler {
+	return &getPoolHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func FindAgentPool(opts handlers.HandlerOptions) handlers.Handler {
+	return &findPoolHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func PostAgentPool(opts handlers.HandlerOptions) handlers.Handler {
+	return &postPoolHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func DeleteAgentPool(opts handlers.HandlerOptions) handlers.Handler {
+	return &deletePoolHandler{baseHandler: newBaseHandler(opts)}
+}
+
+// Interface compliance verification
+var _ handlers.Handler = &getPoolHandler{}
+var _ handlers.Handler = &findPoolHandler{}
+var _ handlers.Handler = &postPoolHandler{}
+var _ handlers.Handler = &deletePoolHandler{}
+
+// Base handler with common functionality
+type baseHandler struct {
+	*handlers.BaseHandler
+}
+
+// Constructor for the base handler
+func newBaseHandler(opts handlers.HandlerOptions) *baseHandler {
+	return &baseHandler{BaseHandler: handlers.NewBaseHandler(opts)}
+}
+
+// Handler types embedding the base handler
+type getPoolHandler struct {
+	*baseHandler
+}
+
+type findPoolHandler struct {
+	*baseHandler
+}
+
+type postPoolHandler struct {
+	*baseHandler
+}
+
+type deletePoolHandler struct {
+	*baseHandler
+}
+
+// validateOrganizationParams validates the parameters of the organization level endpoints
+func (h *baseHandler) validateOrganizationParams(w http.ResponseWriter, organization, apiVersion string) bool {
+	if organization == "" {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Organization parameter is required")
+		return false
+	}
+	if apiVersion == "" {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "API version is required")
+		return false
+	}
+	return true
+}
+
+// getAgentPool retrieves an agent pool by ID
+func (h *baseHandler) getAgentPool(organization, id, apiVersion, authHeader string) (*TaskAgentPool, error) {
+	url := fmt.Sprintf("https://dev.azure.com/%s/_apis/distributedtask/pools/%s?api-version=%s", organization, id, apiVersion)
+
+	body, err := h.DoAzureDevOpsRequest("GET", url, authHeader, nil)
+	if err != nil {
+		return nil, err
+	}
+	if isEmptyBody(body) {
+		return nil, errNotFound
+	}
+
+	var pool TaskAgentPool
+	if err := json.Unmarshal(body, &pool); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal agent pool: %w", err)
+	}
+	return &pool, nil
+}
+
+// findAgentPool looks up an agent pool by name, nil is returned if there is none
+func (h *baseHandler) findAgentPool(organization, name, apiVersion, authHeader string) (*TaskAgentPool, error) {
+	url := fmt.Sprintf("https://dev.azure.com/%s/_apis/distributedtask/pools?poolName=%s&api-version=%s", organization, neturl.QueryEscape(name), apiVersion)
+
+	body, err := h.DoAzureDevOpsRequest("GET", url, authHeader, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var pools TaskAgentPoolList
+	if err := json.Unmarshal(body, &pools); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal agent pools: %w", err)
+	}
+	return findAgentPoolByName(pools.Value, name), nil
+}
+
+// writeNotFoundAwareError writes the response for an error returned while calling Azure DevOps API
+// errNotFound and 404 are returned with the given message, other Azure DevOps errors are forwarded as they are
+func (h *baseHandler) writeNotFoundAwareError(w http.ResponseWriter, err error, action, notFoundMessage string) {
+	if errors.Is(err, errNotFound) {
+		h.WriteErrorResponse(w, http.StatusNotFound, notFoundMessage)
+		return
+	}
+	h.WriteAzureDevOpsError(w, err, action, notFoundMessage)
+}
+
+// GET handler implementation
+// @Summary Get an agent pool
+// @Description Get an agent pool of the organization
+// @ID get-agentpool
+// @Param organization path string true "Organization name"
+// @Param id path string true "Agent pool ID"
+// @Param api-version query string true "API version (e.g., 7.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Produce json
+// @Success 200 {object} AgentPoolResponse "Agent pool details"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/distributedtask/pools/{id} [get]
+func (h *getPoolHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	id := r.PathValue("id")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.validateOrganizationParams(w, organization, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	h.Log.Printf("Getting agent pool with ID %s for organization %s", id, organization)
+
+	pool, err := h.getAgentPool(organization, id, apiVersion, authHeader)
+	if err != nil {
+		h.writeNotFoundAwareError(w, err, "get agent pool", fmt.Sprintf("Agent pool with ID %s not found", id))
+		return
+	}
+
+	h.WriteObjectResponse(w, http.StatusOK, AgentPoolResponse(*mapAgentPoolFromAzure(pool)))
+	h.Log.Printf("Successfully retrieved agent pool with ID %s", id)
+}
+
+// FIND handler implementation
+// @Summary Find an agent pool by name
+// @Description Find an agent pool of the organization by name (case insensitive)
+// @ID find-agentpool
+// @Param organization path string true "Organization name"
+// @Param name query string true "Agent pool name"
+// @Param api-version query string true "API version (e.g., 7.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Produce json
+// @Success 200 {object} AgentPoolResponse "Agent pool details"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/distributedtask/pools [get]
+func (h *findPoolHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	name := r.URL.Query().Get("name")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.validateOrganizationParams(w, organization, apiVersion) {
+		return
+	}
+	if name == "" {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Query parameter 'name' is required")
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	h.Log.Printf("Looking up agent pool %s for organization %s", name, organization)
+
+	pool, err := h.findAgentPool(organization, name, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "find agent pool", fmt.Sprintf("Agent pool %s not found", name))
+		return
+	}
+	if pool == nil {
+		h.WriteErrorResponse(w, http.StatusNotFound, fmt.Sprintf("Agent pool %s not found", name))
+		return
+	}
+
+	h.WriteObjectResponse(w, http.StatusOK, AgentPoolResponse(*mapAgentPoolFromAzure(pool)))
+	h.Log.Printf("Successfully found agent pool %s with ID %d", name, pool.ID)
+}
+
+// POST handler implementation
+// @Summary Create an agent pool
+// @Description Create a self-hosted agent pool. If a self-hosted pool with the same name and type already exists, its auto-provision and auto-update flags are updated and it is returned with status 200; if it is hosted or of another type, 409 is returned.
+// @ID post-agentpool
+// @Param organization path string true "Organization name"
+// @Param api-version query string true "API version (e.g., 7.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Param agentPool body CreateAgentPoolRequest true "Agent pool creation request body"
+// @Accept json
+// @Produce json
+// @Success 200 {object} AgentPoolResponse "Existing agent pool"
+// @Success 201 {object} AgentPoolResponse "Created agent pool"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 409 "Conflict"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/distributedtask/pools [post]
+func (h *postPoolHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.validateOrganizationParams(w, organization, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Failed to read request body")
+		return
+	}
+
+	var createRequest CreateAgentPoolRequest
+	if err := json.Unmarshal(body, &createRequest); err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Invalid JSON in request body")
+		return
+	}
+	if strings.TrimSpace(createRequest.Name) == "" {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Agent pool name is required")
+		return
+	}
+	poolType := strings.ToLower(strings.TrimSpace(createRequest.PoolType))
+	if poolType == "" {
+		poolType = PoolTypeAutomation
+	}
+	if poolType != PoolTypeAutomation && poolType != PoolTypeDeployment {
+		h.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Agent pool type must be %s or %s", PoolTypeAutomation, PoolTypeDeployment))
+		return
+	}
+	autoUpdate := true
+	if createRequest.AutoUpdate != nil {
+		autoUpdate = *createRequest.AutoUpdate
+	}
+
+	h.Log.Printf("Creating agent pool %s for organization %s", createRequest.Name, organization)
+
+	// Look up the pool by name first so that the creation is idempotent
+	existing, err := h.findAgentPool(organization, createRequest.Name, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "find agent pool", fmt.Sprintf("Organization %s not found", organization))
+		return
+	}
+
+	pool := TaskAgentPool{Name: createRequest.Name, PoolType: poolType, AutoProvision: &createRequest.AutoProvision, AutoUpdate: &autoUpdate}
+	method := "POST"
+	url := fmt.Sprintf("https://dev.azure.com/%s/_apis/distributedtask/pools?api-version=%s", organization, apiVersion)
+	statusCode := http.StatusCreated
+	if existing != nil {
+		current := mapAgentPoolFromAzure(existing)
+		// A hosted pool or a pool of another type cannot be turned into the requested pool
+		if current.IsHosted || !strings.EqualFold(current.PoolType, poolType) {
+			h.WriteErrorResponse(w, http.StatusConflict, fmt.Sprintf("Agent pool %s already exists with type %s (hosted: %t)", current.Name, current.PoolType, current.IsHosted))
+			return
+		}
+		if current.AutoProvision == createRequest.AutoProvision && current.AutoUpdate == autoUpdate {
+			h.WriteObjectResponse(w, http.StatusOK, AgentPoolResponse(*current))
+			h.Log.Printf("Agent pool %s already exists with ID %d", createRequest.Name, existing.ID)
+			return
+		}
+		pool.Name = existing.Name
+		pool.PoolType = ""
+		method = "PATCH"
+		url = fmt.Sprintf("https://dev.azure.com/%s/_apis/distributedtask/pools/%d?api-version=%s", organization, existing.ID, apiVersion)
+		statusCode = http.StatusOK
+	}
+
+	requestBody, err := json.Marshal(pool)
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Failed to marshal agent pool: %v", err))
+		return
+	}
+
+	responseBody, err := h.DoAzureDevOpsRequest(method, url, authHeader, requestBody)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "create agent pool", fmt.Sprintf("Organization %s not found", organization))
+		return
+	}
+
+	var created TaskAgentPool
+	if err := json.Unmarshal(responseBody, &created); err != nil {
+		h.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Failed to unmarshal agent pool: %v", err))
+		return
+	}
+
+	h.WriteObjectResponse(w, statusCode, AgentPoolResponse(*mapAgentPoolFromAzure(&created)))
+	h.Log.Printf("Successfully created agent pool %s with ID %d", created.Name, created.ID)
+}
+
+// DELETE handler implementation
+// @Summary Delete an agent pool
+// @Description Delete an agent pool of the organization. The queues mapped to the pool are deleted by Azure DevOps.
+// @ID delete-agentpool
+// @Param organization path string true "Organization name"
+// @Param id path string true "Agent pool ID"
+// @Param api-version query string true "API version (e.g., 7.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Success 204 "No Content"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/distributedtask/pools/{id} [delete]
+func (h *deletePoolHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	id := r.PathValue("id")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.validateOrganizationParams(w, organization, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	h.Log.Printf("Deleting agent pool with ID %s for organization %s", id, organization)
+
+	url := fmt.Sprintf("https://dev.azure.com/%s/_apis/distributedtask/pools/%s?api-version=%s", organization, id, apiVersion)
+	if _, err := h.DoAzureDevOpsRequest("DELETE", url, authHeader, nil); err != nil {
+		h.WriteAzureDevOpsError(w, err, "delete agent pool", fmt.Sprintf("Agent pool with ID %s not found", id))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	h.Log.Printf("Successfully deleted agent pool with ID %s", id)
+}
@@ -0,0 +1,434 @@
+package agentpool
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers"
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/handlerstest"
+	"github.com/rs/zerolog"
+)
+
+// newTestBaseHandler creates a base handler for testing with a mock client
+func newTestBaseHandler(mockClient *handlerstest.MockHTTPClient) *baseHandler {
+	return newBaseHandler(handlerstest.NewHandlerOptions(mockClient))
+}
+
+// Test data constants
+const (
+	testOrg        = "testorg"
+	testProject    = "testproject"
+	testPoolID     = "10"
+	testQueueID    = "21"
+	testAPIVersion = "7.1"
+	testAuthHeader = "Basic dGVzdDp0ZXN0"
+	testUsername   = "test"
+	testPassword   = "test"
+)
+
+var (
+	poolsURL = fmt.Sprintf("https://dev.azure.com/%s/_apis/distributedtask/pools?api-version=%s", testOrg, testAPIVersion)
+	poolURL  = fmt.Sprintf("https://dev.azure.com/%s/_apis/distributedtask/pools/%s?api-version=%s", testOrg, testPoolID, testAPIVersion)
+
+	validPoolResp     = `{"id":10,"name":"Team Pool","poolType":"automation","isHosted":false,"autoProvision":true,"autoUpdate":true,"size":3,"createdOn":"2025-06-30T14:33:02.06Z","owner":{"id":"user-id"}}`
+	poolListResp      = `{"count":2,"value":[{"id":11,"name":"Team Pool Large","poolType":"automation","autoProvision":false,"autoUpdate":true},{"id":10,"name":"Team Pool","poolType":"automation","isHosted":false,"autoProvision":true,"autoUpdate":true}]}`
+	emptyPoolListResp = `{"count":0,"value":[]}`
+	expectedPoolResp  = `{"id":10,"name":"Team Pool","poolType":"automation","isHosted":false,"autoProvision":true,"autoUpdate":true}`
+)
+
+// poolByNameURL returns the URL used to look up an agent pool by name
+func poolByNameURL(name string) string {
+	return fmt.Sprintf("https://dev.azure.com/%s/_apis/distributedtask/pools?poolName=%s&api-version=%s", testOrg, url.QueryEscape(name), testAPIVersion)
+}
+
+// agentPoolTestCase is a test case for the handlers of this package
+type agentPoolTestCase struct {
+	name                 string
+	pathValues           map[string]string
+	query                url.Values
+	noAuth               bool
+	body                 string
+	setupMock            func(*handlerstest.MockHTTPClient)
+	expectedStatus       int
+	expectedBody         string
+	expectedBodyContains string
+	expectedRequestCount int
+	verify               func(t *testing.T, mockClient *handlerstest.MockHTTPClient)
+}
+
+// runAgentPoolTests runs the given test cases against the handler built by newHandler
+func runAgentPoolTests(t *testing.T, method string, newHandler func(*baseHandler) http.Handler, tests []agentPoolTestCase) {
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := handlerstest.NewMockHTTPClient()
+			if tt.setupMock != nil {
+				tt.setupMock(mockClient)
+			}
+			handler := newHandler(newTestBaseHandler(mockClient))
+
+			target := "/api/test"
+			if len(tt.query) > 0 {
+				target += "?" + tt.query.Encode()
+			}
+			var body io.Reader
+			if tt.body != "" {
+				body = strings.NewReader(tt.body)
+			}
+			req := httptest.NewRequest(method, target, body)
+			for key, value := range tt.pathValues {
+				req.SetPathValue(key, value)
+			}
+			if !tt.noAuth {
+				req.SetBasicAuth(testUsername, testPassword)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("Status code = %d, want %d. Body: %s", rr.Code, tt.expectedStatus, rr.Body.String())
+			}
+			if tt.expectedBody != "" && rr.Body.String() != tt.expectedBody {
+				t.Errorf("Body = %s, want %s", rr.Body.String(), tt.expectedBody)
+			}
+			if tt.expectedBodyContains != "" && !strings.Contains(rr.Body.String(), tt.expectedBodyContains) {
+				t.Errorf("Body = %s, want it to contain %s", rr.Body.String(), tt.expectedBodyContains)
+			}
+			if mockClient.RequestCount() != tt.expectedRequestCount {
+				t.Errorf("Request count = %d, want %d", mockClient.RequestCount(), tt.expectedRequestCount)
+			}
+			if tt.verify != nil {
+				tt.verify(t, mockClient)
+			}
+		})
+	}
+}
+
+// apiVersionQuery returns the query with the test API version and the given name, if any
+func apiVersionQuery(name string) url.Values {
+	query := url.Values{"api-version": {testAPIVersion}}
+	if name != "" {
+		query.Set("name", name)
+	}
+	return query
+}
+
+// requestBody returns the body of the n-th request sent to Azure DevOps
+func requestBody(t *testing.T, mockClient *handlerstest.MockHTTPClient, n int) string {
+	t.Helper()
+	if len(mockClient.Requests) <= n || mockClient.Requests[n].Body == nil {
+		t.Fatalf("request %d has no body", n)
+	}
+	body, err := io.ReadAll(mockClient.Requests[n].Body)
+	if err != nil {
+		t.Fatalf("failed to read request %d body: %v", n, err)
+	}
+	return string(body)
+}
+
+// Test constructor functions
+func TestAgentPoolConstructors(t *testing.T) {
+	client := &http.Client{}
+	logger := zerolog.New(os.Stdout).With().Timestamp().Logger()
+	opts := handlers.HandlerOptions{
+		Client: client,
+		Log:    &logger,
+	}
+
+	constructors := map[string]func(handlers.HandlerOptions) handlers.Handler{
+		"GetAgentPool":     GetAgentPool,
+		"FindAgentPool":    FindAgentPool,
+		"PostAgentPool":    PostAgentPool,
+		"DeleteAgentPool":  DeleteAgentPool,
+		"GetAgentQueue":    GetAgentQueue,
+		"FindAgentQueue":   FindAgentQueue,
+		"PostAgentQueue":   PostAgentQueue,
+		"DeleteAgentQueue": DeleteAgentQueue,
+	}
+
+	for name, constructor := range constructors {
+		t.Run(name, func(t *testing.T) {
+			if h := constructor(opts); h == nil {
+				t.Fatalf("%s should return a non-nil handler", name)
+			}
+		})
+	}
+}
+
+// Test GET handler
+func TestGetPoolHandler_ServeHTTP(t *testing.T) {
+	pathValues := map[string]string{"organization": testOrg, "id": testPoolID}
+	runAgentPoolTests(t, "GET", func(b *baseHandler) http.Handler { return &getPoolHandler{baseHandler: b} }, []agentPoolTestCase{
+		{
+			name:                 "successful retrieval without size and audit fields",
+			pathValues:           pathValues,
+			query:                apiVersionQuery(""),
+			setupMock:            func(m *handlerstest.MockHTTPClient) { m.SetResponse(poolURL, http.StatusOK, validPoolResp) },
+			expectedStatus:       http.StatusOK,
+			expectedBody:         expectedPoolResp,
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "missing organization",
+			pathValues:           map[string]string{"id": testPoolID},
+			query:                apiVersionQuery(""),
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Organization parameter is required",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "missing api version",
+			pathValues:           pathValues,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "API version is required",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "missing basic auth",
+			pathValues:           pathValues,
+			query:                apiVersionQuery(""),
+			noAuth:               true,
+			expectedStatus:       http.StatusUnauthorized,
+			expectedBody:         "Request rejected due to missing or invalid Basic authentication",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "pool not found",
+			pathValues:           pathValues,
+			query:                apiVersionQuery(""),
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Agent pool with ID 10 not found",
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "empty body treated as not found",
+			pathValues:           pathValues,
+			query:                apiVersionQuery(""),
+			setupMock:            func(m *handlerstest.MockHTTPClient) { m.SetResponse(poolURL, http.StatusOK, "") },
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Agent pool with ID 10 not found",
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "network error",
+			pathValues:           pathValues,
+			query:                apiVersionQuery(""),
+			setupMock:            func(m *handlerstest.MockHTTPClient) { m.SetError(poolURL, errors.New("connection refused")) },
+			expectedStatus:       http.StatusInternalServerError,
+			expectedBodyContains: "Failed to get agent pool",
+			expectedRequestCount: 1,
+		},
+	})
+}
+
+// Test FIND handler
+func TestFindPoolHandler_ServeHTTP(t *testing.T) {
+	pathValues := map[string]string{"organization": testOrg}
+	runAgentPoolTests(t, "GET", func(b *baseHandler) http.Handler { return &findPoolHandler{baseHandler: b} }, []agentPoolTestCase{
+		{
+			name:       "exact name match among prefix matches (case insensitive)",
+			pathValues: pathValues,
+			query:      apiVersionQuery("team pool"),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(poolByNameURL("team pool"), http.StatusOK, poolListResp)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBody:         expectedPoolResp,
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "missing name",
+			pathValues:           pathValues,
+			query:                apiVersionQuery(""),
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Query parameter 'name' is required",
+			expectedRequestCount: 0,
+		},
+		{
+			name:       "no pool with the name",
+			pathValues: pathValues,
+			query:      apiVersionQuery("team pool"),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(poolByNameURL("team pool"), http.StatusOK, emptyPoolListResp)
+			},
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Agent pool team pool not found",
+			expectedRequestCount: 1,
+		},
+		{
+			name:       "azure devops error forwarded",
+			pathValues: pathValues,
+			query:      apiVersionQuery("team pool"),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(poolByNameURL("team pool"), http.StatusForbidden, `{"message":"forbidden"}`)
+			},
+			expectedStatus:       http.StatusForbidden,
+			expectedBody:         `{"message":"forbidden"}`,
+			expectedRequestCount: 1,
+		},
+	})
+}
+
+// Test POST handler
+func TestPostPoolHandler_ServeHTTP(t *testing.T) {
+	pathValues := map[string]string{"organization": testOrg}
+	poolPatchURL := poolURL
+	runAgentPoolTests(t, "POST", func(b *baseHandler) http.Handler { return &postPoolHandler{baseHandler: b} }, []agentPoolTestCase{
+		{
+			name:       "pool created with auto update by default",
+			pathValues: pathValues,
+			query:      apiVersionQuery(""),
+			body:       `{"name":"Team Pool","autoProvision":true}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(poolByNameURL("Team Pool"), http.StatusOK, emptyPoolListResp)
+				m.SetMethodResponse("POST", poolsURL, http.StatusOK, validPoolResp)
+			},
+			expectedStatus:       http.StatusCreated,
+			expectedBody:         expectedPoolResp,
+			expectedRequestCount: 2,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				if got, want := requestBody(t, m, 1), `{"name":"Team Pool","poolType":"automation","autoProvision":true,"autoUpdate":true}`; got != want {
+					t.Errorf("Request body = %s, want %s", got, want)
+				}
+			},
+		},
+		{
+			name:       "existing pool with the same flags returned",
+			pathValues: pathValues,
+			query:      apiVersionQuery(""),
+			body:       `{"name":"team pool","autoProvision":true,"autoUpdate":true}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(poolByNameURL("team pool"), http.StatusOK, poolListResp)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBody:         expectedPoolResp,
+			expectedRequestCount: 1,
+		},
+		{
+			name:       "existing pool with different flags updated",
+			pathValues: pathValues,
+			query:      apiVersionQuery(""),
+			body:       `{"name":"team pool","autoProvision":false,"autoUpdate":false}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(poolByNameURL("team pool"), http.StatusOK, poolListResp)
+				m.SetMethodResponse("PATCH", poolPatchURL, http.StatusOK, `{"id":10,"name":"Team Pool","poolType":"automation","autoProvision":false,"autoUpdate":false}`)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBody:         `{"id":10,"name":"Team Pool","poolType":"automation","isHosted":false,"autoProvision":false,"autoUpdate":false}`,
+			expectedRequestCount: 2,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				if m.Requests[1].Method != "PATCH" {
+					t.Errorf("Method = %s, want PATCH", m.Requests[1].Method)
+				}
+				if got, want := requestBody(t, m, 1), `{"name":"Team Pool","autoProvision":false,"autoUpdate":false}`; got != want {
+					t.Errorf("Request body = %s, want %s", got, want)
+				}
+			},
+		},
+		{
+			name:       "existing hosted pool with the same name conflicts",
+			pathValues: pathValues,
+			query:      apiVersionQuery(""),
+			body:       `{"name":"Azure Pipelines"}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(poolByNameURL("Azure Pipelines"), http.StatusOK, `{"count":1,"value":[{"id":9,"name":"Azure Pipelines","poolType":"automation","isHosted":true,"autoProvision":true,"autoUpdate":true}]}`)
+			},
+			expectedStatus:       http.StatusConflict,
+			expectedBody:         "Agent pool Azure Pipelines already exists with type automation (hosted: true)",
+			expectedRequestCount: 1,
+		},
+		{
+			name:       "existing pool of another type conflicts",
+			pathValues: pathValues,
+			query:      apiVersionQuery(""),
+			body:       `{"name":"team pool","poolType":"deployment","autoProvision":true}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(poolByNameURL("team pool"), http.StatusOK, poolListResp)
+			},
+			expectedStatus:       http.StatusConflict,
+			expectedBody:         "Agent pool Team Pool already exists with type automation (hosted: false)",
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "invalid pool type",
+			pathValues:           pathValues,
+			query:                apiVersionQuery(""),
+			body:                 `{"name":"Team Pool","poolType":"hosted"}`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Agent pool type must be automation or deployment",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "missing name",
+			pathValues:           pathValues,
+			query:                apiVersionQuery(""),
+			body:                 `{"name":"  "}`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Agent pool name is required",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "invalid json",
+			pathValues:           pathValues,
+			query:                apiVersionQuery(""),
+			body:                 `{"name":`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid JSON in request body",
+			expectedRequestCount: 0,
+		},
+		{
+			name:       "azure devops error on creation forwarded",
+			pathValues: pathValues,
+			query:      apiVersionQuery(""),
+			body:       `{"name":"Team Pool"}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(poolByNameURL("Team Pool"), http.StatusOK, emptyPoolListResp)
+				m.SetMethodResponse("POST", poolsURL, http.StatusForbidden, `{"message":"forbidden"}`)
+			},
+			expectedStatus:       http.StatusForbidden,
+			expectedBody:         `{"message":"forbidden"}`,
+			expectedRequestCount: 2,
+		},
+	})
+}
+
+// Test DELETE handler
+func TestDeletePoolHandler_ServeHTTP(t *testing.T) {
+	pathValues := map[string]string{"organization": testOrg, "id": testPoolID}
+	runAgentPoolTests(t, "DELETE", func(b *baseHandler) http.Handler { return &deletePoolHandler{baseHandler: b} }, []agentPoolTestCase{
+		{
+			name:                 "successful deletion",
+			pathValues:           pathValues,
+			query:                apiVersionQuery(""),
+			setupMock:            func(m *handlerstest.MockHTTPClient) { m.SetMethodResponse("DELETE", poolURL, http.StatusNoContent, "") },
+			expectedStatus:       http.StatusNoContent,
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "pool not found",
+			pathValues:           pathValues,
+			query:                apiVersionQuery(""),
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Agent pool with ID 10 not found",
+			expectedRequestCount: 1,
+		},
+	})
+}
+
+func TestFindAgentPoolByName(t *testing.T) {
+	pools := []TaskAgentPool{{ID: 1, Name: "Default"}, {ID: 2, Name: "Team Pool Large"}, {ID: 3, Name: "Team Pool"}}
+
+	if pool := findAgentPoolByName(pools, "TEAM POOL"); pool == nil || pool.ID != 3 {
+		t.Errorf("findAgentPoolByName() = %v, want pool 3", pool)
+	}
+	if pool := findAgentPoolByName(pools, "Team"); pool != nil {
+		t.Errorf("findAgentPoolByName() = %v, want nil", pool)
+	}
+}
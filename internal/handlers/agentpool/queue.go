@@ -0,0 +1,322 @@
+package agentpool
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	neturl "net/url"
+	"strconv"
+	"strings"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers"
+)
+
+// Handler constructors
+func GetAgentQueue(opts handlers.HandlerOptions) handlers.Handler {
+	return &getQueueHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func FindAgentQueue(opts handlers.HandlerOptions) handlers.Handler {
+	return &findQueueHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func PostAgentQueue(opts handlers.HandlerOptions) handlers.Handler {
+	return &postQueueHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func DeleteAgentQueue(opts handlers.HandlerOptions) handlers.Handler {
+	return &deleteQueueHandler{baseHandler: newBaseHandler(opts)}
+}
+
+// Interface compliance verification
+var _ handlers.Handler = &getQueueHandler{}
+var _ handlers.Handler = &findQueueHandler{}
+var _ handlers.Handler = &postQueueHandler{}
+var _ handlers.Handler = &deleteQueueHandler{}
+
+// Handler types embedding the base handler
+type getQueueHandler struct {
+	*baseHandler
+}
+
+type findQueueHandler struct {
+	*baseHandler
+}
+
+type postQueueHandler struct {
+	*baseHandler
+}
+
+type deleteQueueHandler struct {
+	*baseHandler
+}
+
+// getAgentQueue retrieves an agent queue of the project by ID
+func (h *baseHandler) getAgentQueue(organization, project, id, apiVersion, authHeader string) (*TaskAgentQueue, error) {
+	url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/distributedtask/queues/%s?api-version=%s", organization, project, id, apiVersion)
+
+	body, err := h.DoAzureDevOpsRequest("GET", url, authHeader, nil)
+	if err != nil {
+		return nil, err
+	}
+	if isEmptyBody(body) {
+		return nil, errNotFound
+	}
+
+	var queue TaskAgentQueue
+	if err := json.Unmarshal(body, &queue); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal agent queue: %w", err)
+	}
+	return &queue, nil
+}
+
+// findAgentQueue looks up an agent queue of the project by name, nil is returned if there is none
+func (h *baseHandler) findAgentQueue(organization, project, name, apiVersion, authHeader string) (*TaskAgentQueue, error) {
+	url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/distributedtask/queues?queueNames=%s&api-version=%s", organization, project, neturl.QueryEscape(name), apiVersion)
+
+	body, err := h.DoAzureDevOpsRequest("GET", url, authHeader, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var queues TaskAgentQueueList
+	if err := json.Unmarshal(body, &queues); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal agent queues: %w", err)
+	}
+	return findAgentQueueByName(queues.Value, name), nil
+}
+
+// GET handler implementation
+// @Summary Get an agent queue
+// @Description Get an agent queue of the project together with the agent pool it is mapped to
+// @ID get-agentqueue
+// @Param organization path string true "Organization name"
+// @Param project path string true "Project name or ID"
+// @Param id path string true "Agent queue ID"
+// @Param api-version query string true "API version (e.g., 7.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Produce json
+// @Success 200 {object} AgentQueueResponse "Agent queue details"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{project}/distributedtask/queues/{id} [get]
+func (h *getQueueHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	project := r.PathValue("project")
+	id := r.PathValue("id")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, project, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	h.Log.Printf("Getting agent queue with ID %s for project %s in organization %s", id, project, organization)
+
+	queue, err := h.getAgentQueue(organization, project, id, apiVersion, authHeader)
+	if err != nil {
+		h.writeNotFoundAwareError(w, err, "get agent queue", fmt.Sprintf("Agent queue with ID %s not found in project %s", id, project))
+		return
+	}
+
+	h.WriteObjectResponse(w, http.StatusOK, AgentQueueResponse(*mapAgentQueueFromAzure(queue)))
+	h.Log.Printf("Successfully retrieved agent queue with ID %s", id)
+}
+
+// FIND handler implementation
+// @Summary Find an agent queue by name
+// @Description Find an agent queue of the project by name (case insensitive)
+// @ID find-agentqueue
+// @Param organization path string true "Organization name"
+// @Param project path string true "Project name or ID"
+// @Param name query string true "Agent queue name"
+// @Param api-version query string true "API version (e.g., 7.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Produce json
+// @Success 200 {object} AgentQueueResponse "Agent queue details"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{project}/distributedtask/queues [get]
+func (h *findQueueHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	project := r.PathValue("project")
+	name := r.URL.Query().Get("name")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, project, apiVersion) {
+		return
+	}
+	if name == "" {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Query parameter 'name' is required")
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	h.Log.Printf("Looking up agent queue %s for project %s in organization %s", name, project, organization)
+
+	queue, err := h.findAgentQueue(organization, project, name, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "find agent queue", fmt.Sprintf("Project %s not found", project))
+		return
+	}
+	if queue == nil {
+		h.WriteErrorResponse(w, http.StatusNotFound, fmt.Sprintf("Agent queue %s not found in project %s", name, project))
+		return
+	}
+
+	h.WriteObjectResponse(w, http.StatusOK, AgentQueueResponse(*mapAgentQueueFromAzure(queue)))
+	h.Log.Printf("Successfully found agent queue %s with ID %d", name, queue.ID)
+}
+
+// POST handler implementation
+// @Summary Create an agent queue
+// @Description Create an agent queue in the project mapped to an agent pool of the organization. If a queue with the same name is already mapped to the pool, it is returned with status 200; if it is mapped to another pool, 409 is returned.
+// @ID post-agentqueue
+// @Param organization path string true "Organization name"
+// @Param project path string true "Project name or ID"
+// @Param api-version query string true "API version (e.g., 7.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Param agentQueue body CreateAgentQueueRequest true "Agent queue creation request body"
+// @Accept json
+// @Produce json
+// @Success 200 {object} AgentQueueResponse "Existing agent queue"
+// @Success 201 {object} AgentQueueResponse "Created agent queue"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 409 "Conflict"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{project}/distributedtask/queues [post]
+func (h *postQueueHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	project := r.PathValue("project")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, project, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Failed to read request body")
+		return
+	}
+
+	var createRequest CreateAgentQueueRequest
+	if err := json.Unmarshal(body, &createRequest); err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Invalid JSON in request body")
+		return
+	}
+	if createRequest.PoolID <= 0 {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Agent pool ID is required")
+		return
+	}
+
+	// The queue is named after the pool when no name is given
+	name := strings.TrimSpace(createRequest.Name)
+	if name == "" {
+		pool, err := h.getAgentPool(organization, strconv.Itoa(int(createRequest.PoolID)), apiVersion, authHeader)
+		if err != nil {
+			h.writeNotFoundAwareError(w, err, "get agent pool", fmt.Sprintf("Agent pool with ID %d not found", createRequest.PoolID))
+			return
+		}
+		name = pool.Name
+	}
+
+	h.Log.Printf("Creating agent queue %s for pool %d in project %s", name, createRequest.PoolID, project)
+
+	// Look up the queue by name first so that the creation is idempotent
+	existing, err := h.findAgentQueue(organization, project, name, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "find agent queue", fmt.Sprintf("Project %s not found", project))
+		return
+	}
+	if existing != nil {
+		current := mapAgentQueueFromAzure(existing)
+		if current.PoolID != createRequest.PoolID {
+			h.WriteErrorResponse(w, http.StatusConflict, fmt.Sprintf("Agent queue %s already exists in project %s and is mapped to agent pool %d", current.Name, project, current.PoolID))
+			return
+		}
+		h.WriteObjectResponse(w, http.StatusOK, AgentQueueResponse(*current))
+		h.Log.Printf("Agent queue %s already exists with ID %d", name, existing.ID)
+		return
+	}
+
+	requestBody, err := json.Marshal(TaskAgentQueue{Name: name, Pool: &TaskAgentPool{ID: createRequest.PoolID}})
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Failed to marshal agent queue: %v", err))
+		return
+	}
+
+	url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/distributedtask/queues?authorizePipelines=%t&api-version=%s", organization, project, createRequest.AuthorizePipelines, apiVersion)
+	responseBody, err := h.DoAzureDevOpsRequest("POST", url, authHeader, requestBody)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "create agent queue", fmt.Sprintf("Project %s not found", project))
+		return
+	}
+
+	var created TaskAgentQueue
+	if err := json.Unmarshal(responseBody, &created); err != nil {
+		h.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Failed to unmarshal agent queue: %v", err))
+		return
+	}
+
+	h.WriteObjectResponse(w, http.StatusCreated, AgentQueueResponse(*mapAgentQueueFromAzure(&created)))
+	h.Log.Printf("Successfully created agent queue %s with ID %d", created.Name, created.ID)
+}
+
+// DELETE handler implementation
+// @Summary Delete an agent queue
+// @Description Delete an agent queue of the project. The agent pool it is mapped to is not deleted.
+// @ID delete-agentqueue
+// @Param organization path string true "Organization name"
+// @Param project path string true "Project name or ID"
+// @Param id path string true "Agent queue ID"
+// @Param api-version query string true "API version (e.g., 7.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Success 204 "No Content"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{project}/distributedtask/queues/{id} [delete]
+func (h *deleteQueueHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	project := r.PathValue("project")
+	id := r.PathValue("id")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, project, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	h.Log.Printf("Deleting agent queue with ID %s for project %s in organization %s", id, project, organization)
+
+	url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/distributedtask/queues/%s?api-version=%s", organization, project, id, apiVersion)
+	if _, err := h.DoAzureDevOpsRequest("DELETE", url, authHeader, nil); err != nil {
+		h.WriteAzureDevOpsError(w, err, "delete agent queue", fmt.Sprintf("Agent queue with ID %s not found in project %s", id, project))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	h.Log.Printf("Successfully deleted agent queue with ID %s", id)
+}
@@ -0,0 +1,192 @@
+package agentpool
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/handlerstest"
+)
+
+var (
+	queueURL = fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/distributedtask/queues/%s?api-version=%s", testOrg, testProject, testQueueID, testAPIVersion)
+
+	validQueueResp     = `{"id":21,"name":"Team Pool","projectId":"9A3C2B1D-0000-0000-0000-000000000001","pool":{"id":10,"name":"Team Pool","isHosted":false,"poolType":"automation"}}`
+	queueListResp      = `{"count":1,"value":[` + validQueueResp + `]}`
+	emptyQueueListResp = `{"count":0,"value":[]}`
+	expectedQueueResp  = `{"id":21,"name":"Team Pool","projectId":"9a3c2b1d-0000-0000-0000-000000000001","poolId":10,"poolName":"Team Pool","isHosted":false}`
+)
+
+// queueByNameURL returns the URL used to look up an agent queue by name
+func queueByNameURL(name string) string {
+	return fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/distributedtask/queues?queueNames=%s&api-version=%s", testOrg, testProject, url.QueryEscape(name), testAPIVersion)
+}
+
+// createQueueURL returns the URL used to create an agent queue
+func createQueueURL(authorizePipelines bool) string {
+	return fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/distributedtask/queues?authorizePipelines=%t&api-version=%s", testOrg, testProject, authorizePipelines, testAPIVersion)
+}
+
+// Test GET handler
+func TestGetQueueHandler_ServeHTTP(t *testing.T) {
+	pathValues := map[string]string{"organization": testOrg, "project": testProject, "id": testQueueID}
+	runAgentPoolTests(t, "GET", func(b *baseHandler) http.Handler { return &getQueueHandler{baseHandler: b} }, []agentPoolTestCase{
+		{
+			name:                 "successful retrieval with pool mapping",
+			pathValues:           pathValues,
+			query:                apiVersionQuery(""),
+			setupMock:            func(m *handlerstest.MockHTTPClient) { m.SetResponse(queueURL, http.StatusOK, validQueueResp) },
+			expectedStatus:       http.StatusOK,
+			expectedBody:         expectedQueueResp,
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "missing project",
+			pathValues:           map[string]string{"organization": testOrg, "id": testQueueID},
+			query:                apiVersionQuery(""),
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Project parameter is required",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "empty body treated as not found",
+			pathValues:           pathValues,
+			query:                apiVersionQuery(""),
+			setupMock:            func(m *handlerstest.MockHTTPClient) { m.SetResponse(queueURL, http.StatusOK, "") },
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Agent queue with ID 21 not found in project testproject",
+			expectedRequestCount: 1,
+		},
+	})
+}
+
+// Test FIND handler
+func TestFindQueueHandler_ServeHTTP(t *testing.T) {
+	pathValues := map[string]string{"organization": testOrg, "project": testProject}
+	runAgentPoolTests(t, "GET", func(b *baseHandler) http.Handler { return &findQueueHandler{baseHandler: b} }, []agentPoolTestCase{
+		{
+			name:       "queue found by name (case insensitive)",
+			pathValues: pathValues,
+			query:      apiVersionQuery("team pool"),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(queueByNameURL("team pool"), http.StatusOK, queueListResp)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBody:         expectedQueueResp,
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "missing name",
+			pathValues:           pathValues,
+			query:                apiVersionQuery(""),
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Query parameter 'name' is required",
+			expectedRequestCount: 0,
+		},
+		{
+			name:       "no queue with the name",
+			pathValues: pathValues,
+			query:      apiVersionQuery("team pool"),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(queueByNameURL("team pool"), http.StatusOK, emptyQueueListResp)
+			},
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Agent queue team pool not found in project testproject",
+			expectedRequestCount: 1,
+		},
+	})
+}
+
+// Test POST handler
+func TestPostQueueHandler_ServeHTTP(t *testing.T) {
+	pathValues := map[string]string{"organization": testOrg, "project": testProject}
+	runAgentPoolTests(t, "POST", func(b *baseHandler) http.Handler { return &postQueueHandler{baseHandler: b} }, []agentPoolTestCase{
+		{
+			name:       "queue named after the pool created",
+			pathValues: pathValues,
+			query:      apiVersionQuery(""),
+			body:       `{"poolId":10,"authorizePipelines":true}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(poolURL, http.StatusOK, validPoolResp)
+				m.SetResponse(queueByNameURL("Team Pool"), http.StatusOK, emptyQueueListResp)
+				m.SetMethodResponse("POST", createQueueURL(true), http.StatusOK, validQueueResp)
+			},
+			expectedStatus:       http.StatusCreated,
+			expectedBody:         expectedQueueResp,
+			expectedRequestCount: 3,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				if got, want := requestBody(t, m, 2), `{"name":"Team Pool","pool":{"id":10}}`; got != want {
+					t.Errorf("Request body = %s, want %s", got, want)
+				}
+			},
+		},
+		{
+			name:       "existing queue mapped to the same pool returned",
+			pathValues: pathValues,
+			query:      apiVersionQuery(""),
+			body:       `{"name":"team pool","poolId":10}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(queueByNameURL("team pool"), http.StatusOK, queueListResp)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBody:         expectedQueueResp,
+			expectedRequestCount: 1,
+		},
+		{
+			name:       "existing queue mapped to another pool",
+			pathValues: pathValues,
+			query:      apiVersionQuery(""),
+			body:       `{"name":"Team Pool","poolId":11}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(queueByNameURL("Team Pool"), http.StatusOK, queueListResp)
+			},
+			expectedStatus:       http.StatusConflict,
+			expectedBody:         "Agent queue Team Pool already exists in project testproject and is mapped to agent pool 10",
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "missing pool ID",
+			pathValues:           pathValues,
+			query:                apiVersionQuery(""),
+			body:                 `{"name":"Team Pool"}`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Agent pool ID is required",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "pool of the queue not found",
+			pathValues:           pathValues,
+			query:                apiVersionQuery(""),
+			body:                 `{"poolId":10}`,
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Agent pool with ID 10 not found",
+			expectedRequestCount: 1,
+		},
+	})
+}
+
+// Test DELETE handler
+func TestDeleteQueueHandler_ServeHTTP(t *testing.T) {
+	pathValues := map[string]string{"organization": testOrg, "project": testProject, "id": testQueueID}
+	runAgentPoolTests(t, "DELETE", func(b *baseHandler) http.Handler { return &deleteQueueHandler{baseHandler: b} }, []agentPoolTestCase{
+		{
+			name:       "successful deletion",
+			pathValues: pathValues,
+			query:      apiVersionQuery(""),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetMethodResponse("DELETE", queueURL, http.StatusNoContent, "")
+			},
+			expectedStatus:       http.StatusNoContent,
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "queue not found",
+			pathValues:           pathValues,
+			query:                apiVersionQuery(""),
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Agent queue with ID 21 not found in project testproject",
+			expectedRequestCount: 1,
+		},
+	})
+}
@@ -0,0 +1,61 @@
+package agentpool
+
+import (
+	"bytes"
+	"strings"
+)
+
+// mapAgentPoolFromAzure converts an Azure DevOps agent pool to the agent pool returned by the plugin
+func mapAgentPoolFromAzure(pool *TaskAgentPool) *AgentPool {
+	return &AgentPool{
+		ID:            pool.ID,
+		Name:          pool.Name,
+		PoolType:      pool.PoolType,
+		IsHosted:      pool.IsHosted,
+		AutoProvision: pool.AutoProvision != nil && *pool.AutoProvision,
+		AutoUpdate:    pool.AutoUpdate != nil && *pool.AutoUpdate,
+	}
+}
+
+// mapAgentQueueFromAzure converts an Azure DevOps agent queue to the agent queue returned by the plugin
+func mapAgentQueueFromAzure(queue *TaskAgentQueue) *AgentQueue {
+	agentQueue := &AgentQueue{
+		ID:        queue.ID,
+		Name:      queue.Name,
+		ProjectID: strings.ToLower(queue.ProjectID),
+	}
+	if queue.Pool != nil {
+		agentQueue.PoolID = queue.Pool.ID
+		agentQueue.PoolName = queue.Pool.Name
+		agentQueue.IsHosted = queue.Pool.IsHosted
+	}
+	return agentQueue
+}
+
+// findAgentPoolByName returns the pool with the given name (case insensitive), nil if there is none
+// Azure DevOps filters pools by name but may return pools with a matching prefix
+func findAgentPoolByName(pools []TaskAgentPool, name string) *TaskAgentPool {
+	for i := range pools {
+		if strings.EqualFold(pools[i].Name, name) {
+			return &pools[i]
+		}
+	}
+	return nil
+}
+
+// findAgentQueueByName returns the queue with the given name (case insensitive), nil if there is none
+func findAgentQueueByName(queues []TaskAgentQueue, name string) *TaskAgentQueue {
+	for i := range queues {
+		if strings.EqualFold(queues[i].Name, name) {
+			return &queues[i]
+		}
+	}
+	return nil
+}
+
+// isEmptyBody reports whether Azure DevOps returned an empty body or null
+// Azure DevOps returns 200 with an empty body for some missing resources (e.g., agent queues)
+func isEmptyBody(body []byte) bool {
+	trimmed := bytes.TrimSpace(body)
+	return len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null"))
+}
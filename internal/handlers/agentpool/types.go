@@ -0,0 +1,89 @@
+package agentpool
+
+// AgentPool represents the agent pool returned by the plugin
+// It is built from the TaskAgentPool returned by:
+// GET /{organization}/_apis/distributedtask/pools/{poolId}
+// Audit fields (createdBy, createdOn, owner) and the number of agents (size) are not returned since they change independently of the pool
+type AgentPool struct {
+	ID            int32  `json:"id"`
+	Name          string `json:"name"`
+	PoolType      string `json:"poolType"` // enum: automation, deployment
+	IsHosted      bool   `json:"isHosted"`
+	AutoProvision bool   `json:"autoProvision"`
+	AutoUpdate    bool   `json:"autoUpdate"`
+}
+
+// CreateAgentPoolRequest represents the request body for creating an agent pool
+type CreateAgentPoolRequest struct {
+	Name          string `json:"name"`                 // Required
+	PoolType      string `json:"poolType,omitempty"`   // enum: automation, deployment - defaults to automation
+	AutoProvision bool   `json:"autoProvision"`        // If true, a queue for the pool is created in every project
+	AutoUpdate    *bool  `json:"autoUpdate,omitempty"` // Defaults to true
+}
+
+// Agent pool types
+const (
+	PoolTypeAutomation = "automation"
+	PoolTypeDeployment = "deployment"
+)
+
+// AgentPoolResponse represents the response of the agent pool endpoints
+type AgentPoolResponse AgentPool
+
+// TaskAgentPool represents an agent pool of Azure DevOps:
+// /{organization}/_apis/distributedtask/pools/{poolId}
+type TaskAgentPool struct {
+	ID            int32  `json:"id,omitempty"`
+	Name          string `json:"name,omitempty"`
+	PoolType      string `json:"poolType,omitempty"`
+	IsHosted      bool   `json:"isHosted,omitempty"`
+	AutoProvision *bool  `json:"autoProvision,omitempty"`
+	AutoUpdate    *bool  `json:"autoUpdate,omitempty"`
+}
+
+// TaskAgentPoolList represents the response from:
+// GET /{organization}/_apis/distributedtask/pools?poolName={poolName}
+type TaskAgentPoolList struct {
+	Count int             `json:"count"`
+	Value []TaskAgentPool `json:"value"`
+}
+
+// ---
+
+// AgentQueue represents the agent queue returned by the plugin
+// It is built from the TaskAgentQueue returned by:
+// GET /{organization}/{project}/_apis/distributedtask/queues/{queueId}
+type AgentQueue struct {
+	ID        int32  `json:"id"`
+	Name      string `json:"name"`
+	ProjectID string `json:"projectId"`
+	PoolID    int32  `json:"poolId"`
+	PoolName  string `json:"poolName"`
+	IsHosted  bool   `json:"isHosted"`
+}
+
+// CreateAgentQueueRequest represents the request body for creating an agent queue
+type CreateAgentQueueRequest struct {
+	Name               string `json:"name,omitempty"` // Defaults to the name of the pool
+	PoolID             int32  `json:"poolId"`         // Required - ID of the agent pool the queue is mapped to
+	AuthorizePipelines bool   `json:"authorizePipelines"`
+}
+
+// AgentQueueResponse represents the response of the agent queue endpoints
+type AgentQueueResponse AgentQueue
+
+// TaskAgentQueue represents an agent queue of Azure DevOps:
+// /{organization}/{project}/_apis/distributedtask/queues/{queueId}
+type TaskAgentQueue struct {
+	ID        int32          `json:"id,omitempty"`
+	Name      string         `json:"name"`
+	ProjectID string         `json:"projectId,omitempty"`
+	Pool      *TaskAgentPool `json:"pool"`
+}
+
+// TaskAgentQueueList represents the response from:
+// GET /{organization}/{project}/_apis/distributedtask/queues?queueNames={queueName}
+type TaskAgentQueueList struct {
+	Count int              `json:"count"`
+	Value []TaskAgentQueue `json:"value"`
+}
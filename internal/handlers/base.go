@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// AzureDevOpsError is returned when Azure DevOps API responds with an unexpected status code
+// The body is kept so that it can be forwarded to the caller
+type AzureDevOpsError struct {
+	StatusCode int
+	Body       []byte
+}
+
+func (e *AzureDevOpsError) Error() string {
+	return fmt.Sprintf("azure devops API returned status %d: %s", e.StatusCode, string(e.Body))
+}
+
+// BaseHandler provides the methods shared by the handlers calling Azure DevOps API
+// The handler packages embed it in their own base handler
+type BaseHandler struct {
+	HandlerOptions
+}
+
+// NewBaseHandler creates a base handler with the given options
+func NewBaseHandler(opts HandlerOptions) *BaseHandler {
+	return &BaseHandler{HandlerOptions: opts}
+}
+
+// MakeAzureDevOpsRequest performs a request to Azure DevOps API with the given Authorization header
+// The response is returned whatever its status code, the caller must close its body
+func (h *BaseHandler) MakeAzureDevOpsRequest(method, url string, authHeader string, body []byte) (*http.Response, error) {
+	return h.makeAzureDevOpsRequest(context.Background(), method, url, authHeader, body, nil)
+}
+
+// MakeAzureDevOpsRequestWithContext is MakeAzureDevOpsRequest bounded by the given context (e.g., the deadline of a handler)
+func (h *BaseHandler) MakeAzureDevOpsRequestWithContext(ctx context.Context, method, url string, authHeader string, body []byte) (*http.Response, error) {
+	return h.makeAzureDevOpsRequest(ctx, method, url, authHeader, body, nil)
+}
+
+// makeAzureDevOpsRequest performs the request with the given headers added (e.g., If-Match)
+// A Content-Type given in requestHeader replaces the default one (e.g., application/json-patch+json)
+func (h *BaseHandler) makeAzureDevOpsRequest(ctx context.Context, method, url string, authHeader string, body []byte, requestHeader http.Header) (*http.Response, error) {
+	var bodyReader io.Reader
+	if len(body) > 0 {
+		bodyReader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Set Basic Auth header (Azure DevOps requires Basic Auth for API access)
+	if authHeader != "" {
+		h.Log.Print("Using provided Authorization header for Basic authentication")
+		req.Header.Set("Authorization", authHeader)
+	} else {
+		h.Log.Print("No Authorization header provided, Basic authentication required")
+		return nil, fmt.Errorf("no Authorization header provided, Basic authentication required")
+	}
+
+	if bodyReader != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for key, values := range requestHeader {
+		req.Header.Del(key)
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+
+	return resp, nil
+}
+
+// DoAzureDevOpsRequest performs a request to Azure DevOps API and returns the response body
+// Status codes other than 200, 201, 202 and 204 are returned as *AzureDevOpsError
+// (Azure DevOps answers 202 with an operation reference to the requests starting an asynchronous operation)
+func (h *BaseHandler) DoAzureDevOpsRequest(method, url, authHeader string, requestBody []byte) ([]byte, error) {
+	body, _, err := h.doAzureDevOpsRequest(context.Background(), method, url, authHeader, requestBody, nil)
+	return body, err
+}
+
+// DoAzureDevOpsRequestWithContext is DoAzureDevOpsRequest bounded by the given context (e.g., the deadline of a handler)
+func (h *BaseHandler) DoAzureDevOpsRequestWithContext(ctx context.Context, method, url, authHeader string, requestBody []byte) ([]byte, error) {
+	body, _, err := h.doAzureDevOpsRequest(ctx, method, url, authHeader, requestBody, nil)
+	return body, err
+}
+
+// DoAzureDevOpsRequestWithHeader is DoAzureDevOpsRequest sending the given request headers (may be nil)
+// and returning the response headers as well (e.g., the continuation token of a list)
+func (h *BaseHandler) DoAzureDevOpsRequestWithHeader(method, url, authHeader string, requestBody []byte, requestHeader http.Header) ([]byte, http.Header, error) {
+	return h.doAzureDevOpsRequest(context.Background(), method, url, authHeader, requestBody, requestHeader)
+}
+
+func (h *BaseHandler) doAzureDevOpsRequest(ctx context.Context, method, url, authHeader string, requestBody []byte, requestHeader http.Header) ([]byte, http.Header, error) {
+	resp, err := h.makeAzureDevOpsRequest(ctx, method, url, authHeader, requestBody, requestHeader)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated, http.StatusAccepted, http.StatusNoContent:
+		return body, resp.Header, nil
+	}
+
+	h.Log.Printf("Azure DevOps API returned status %d for %s %s. Body: %s", resp.StatusCode, method, url, string(body))
+	return nil, nil, &AzureDevOpsError{StatusCode: resp.StatusCode, Body: body}
+}
+
+func (h *BaseHandler) WriteErrorResponse(w http.ResponseWriter, statusCode int, message string) {
+	h.Log.Print(message)
+	w.WriteHeader(statusCode)
+	w.Write([]byte(message))
+}
+
+func (h *BaseHandler) WriteJSONResponse(w http.ResponseWriter, statusCode int, body []byte) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	w.Write(body)
+}
+
+// WriteObjectResponse marshals and writes the given object
+func (h *BaseHandler) WriteObjectResponse(w http.ResponseWriter, statusCode int, object interface{}) {
+	responseBytes, err := json.Marshal(object)
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Failed to marshal response: %v", err))
+		return
+	}
+	h.WriteJSONResponse(w, statusCode, responseBytes)
+}
+
+// WriteAzureDevOpsError writes the response for an error returned while calling Azure DevOps API
+// 404 is returned with the given message, other Azure DevOps errors are forwarded as they are
+func (h *BaseHandler) WriteAzureDevOpsError(w http.ResponseWriter, err error, action, notFoundMessage string) {
+	var adoErr *AzureDevOpsError
+	if errors.As(err, &adoErr) {
+		if adoErr.StatusCode == http.StatusNotFound {
+			h.WriteErrorResponse(w, http.StatusNotFound, notFoundMessage)
+			return
+		}
+		h.WriteJSONResponse(w, adoErr.StatusCode, adoErr.Body)
+		return
+	}
+	h.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Failed to %s: %v", action, err))
+}
+
+func (h *BaseHandler) ValidateBasicParams(w http.ResponseWriter, organization, project, apiVersion string) bool {
+	if organization == "" {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Organization parameter is required")
+		return false
+	}
+	if project == "" {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Project parameter is required")
+		return false
+	}
+	if apiVersion == "" {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "API version is required")
+		return false
+	}
+	return true
+}
+
+// ValidateBasicAuth rejects requests without Basic authentication
+func (h *BaseHandler) ValidateBasicAuth(w http.ResponseWriter, r *http.Request) bool {
+	// This single check handles missing headers, incorrect formats, and empty credentials.
+	username, password, ok := r.BasicAuth()
+	if !ok || username == "" || password == "" {
+		h.WriteErrorResponse(w, http.StatusUnauthorized, "Request rejected due to missing or invalid Basic authentication")
+		return false
+	}
+	return true
+}
@@ -0,0 +1,169 @@
+package handlers_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers"
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/handlerstest"
+)
+
+const (
+	testURL        = "https://dev.azure.com/testorg/testproject/_apis/test?api-version=7.1"
+	testAuthHeader = "Basic dGVzdDp0ZXN0"
+)
+
+func TestDoAzureDevOpsRequest(t *testing.T) {
+	tests := []struct {
+		name           string
+		authHeader     string
+		setupMock      func(*handlerstest.MockHTTPClient)
+		expectedBody   string
+		expectedStatus int
+		expectErr      bool
+	}{
+		{
+			name:         "successful request",
+			authHeader:   testAuthHeader,
+			setupMock:    func(m *handlerstest.MockHTTPClient) { m.SetResponse(testURL, http.StatusCreated, `{"id":1}`) },
+			expectedBody: `{"id":1}`,
+		},
+		{
+			name:       "unexpected status code",
+			authHeader: testAuthHeader,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(testURL, http.StatusConflict, `{"message":"conflict"}`)
+			},
+			expectedStatus: http.StatusConflict,
+			expectErr:      true,
+		},
+		{
+			name:       "network error",
+			authHeader: testAuthHeader,
+			setupMock:  func(m *handlerstest.MockHTTPClient) { m.SetError(testURL, fmt.Errorf("connection refused")) },
+			expectErr:  true,
+		},
+		{
+			name:      "missing authorization header",
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := handlerstest.NewMockHTTPClient()
+			if tt.setupMock != nil {
+				tt.setupMock(mockClient)
+			}
+			h := handlers.NewBaseHandler(handlerstest.NewHandlerOptions(mockClient))
+
+			body, err := h.DoAzureDevOpsRequest("POST", testURL, tt.authHeader, []byte(`{}`))
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("error = %v, expectErr %v", err, tt.expectErr)
+			}
+			if string(body) != tt.expectedBody {
+				t.Errorf("body = %s, want %s", string(body), tt.expectedBody)
+			}
+
+			var adoErr *handlers.AzureDevOpsError
+			if isADOErr := errors.As(err, &adoErr); isADOErr != (tt.expectedStatus != 0) {
+				t.Fatalf("error = %v, want an AzureDevOpsError: %v", err, tt.expectedStatus != 0)
+			}
+			if adoErr != nil && adoErr.StatusCode != tt.expectedStatus {
+				t.Errorf("status code = %d, want %d", adoErr.StatusCode, tt.expectedStatus)
+			}
+		})
+	}
+}
+
+func TestDoAzureDevOpsRequestWithContext(t *testing.T) {
+	mockClient := handlerstest.NewMockHTTPClient()
+	mockClient.SetResponse(testURL, http.StatusAccepted, `{"id":"op-1"}`)
+	h := handlers.NewBaseHandler(handlerstest.NewHandlerOptions(mockClient))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	body, err := h.DoAzureDevOpsRequestWithContext(ctx, "POST", testURL, testAuthHeader, []byte(`{}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(body) != `{"id":"op-1"}` {
+		t.Errorf("body = %s, want %s", string(body), `{"id":"op-1"}`)
+	}
+	if _, ok := mockClient.Requests[0].Context().Deadline(); !ok {
+		t.Error("request sent without the deadline of the context")
+	}
+}
+
+func TestDoAzureDevOpsRequestWithHeader(t *testing.T) {
+	mockClient := handlerstest.NewMockHTTPClient()
+	mockClient.SetResponse(testURL, http.StatusOK, `{"id":1}`)
+	mockClient.SetResponseHeader(testURL, "X-MS-ContinuationToken", "token+1")
+	h := handlers.NewBaseHandler(handlerstest.NewHandlerOptions(mockClient))
+
+	requestHeader := http.Header{"Content-Type": {"application/json-patch+json"}, "If-Match": {`"etag"`}}
+	body, header, err := h.DoAzureDevOpsRequestWithHeader("PATCH", testURL, testAuthHeader, []byte(`[]`), requestHeader)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(body) != `{"id":1}` || header.Get("X-MS-ContinuationToken") != "token+1" {
+		t.Errorf("response = %s with header %v, want the body and the continuation token", string(body), header)
+	}
+
+	sent := mockClient.Requests[0].Header
+	if sent.Get("Content-Type") != "application/json-patch+json" || len(sent.Values("Content-Type")) != 1 {
+		t.Errorf("Content-Type = %v, want the given one only", sent.Values("Content-Type"))
+	}
+	if sent.Get("If-Match") != `"etag"` {
+		t.Errorf("If-Match = %s, want %s", sent.Get("If-Match"), `"etag"`)
+	}
+}
+
+func TestWriteAzureDevOpsError(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "not found",
+			err:            &handlers.AzureDevOpsError{StatusCode: http.StatusNotFound, Body: []byte(`{"message":"missing"}`)},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   "Resource not found",
+		},
+		{
+			name:           "other azure devops error is forwarded",
+			err:            fmt.Errorf("wrapped: %w", &handlers.AzureDevOpsError{StatusCode: http.StatusConflict, Body: []byte(`{"message":"conflict"}`)}),
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"message":"conflict"}`,
+		},
+		{
+			name:           "unexpected error",
+			err:            fmt.Errorf("connection refused"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   "Failed to get resource: connection refused",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlers.NewBaseHandler(handlerstest.NewHandlerOptions(handlerstest.NewMockHTTPClient()))
+			rr := httptest.NewRecorder()
+
+			h.WriteAzureDevOpsError(rr, tt.err, "get resource", "Resource not found")
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("status code = %d, want %d", rr.Code, tt.expectedStatus)
+			}
+			if rr.Body.String() != tt.expectedBody {
+				t.Errorf("body = %s, want %s", rr.Body.String(), tt.expectedBody)
+			}
+		})
+	}
+}
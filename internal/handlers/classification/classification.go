@@ -0,0 +1,480 @@
+package classification
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers"
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/utils"
+)
+
+// Handler constructors
+func GetClassificationNode(opts handlers.HandlerOptions) handlers.Handler {
+	return &getHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func PostClassificationNode(opts handlers.HandlerOptions) handlers.Handler {
+	return &postHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func PatchClassificationNode(opts handlers.HandlerOptions) handlers.Handler {
+	return &patchHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func DeleteClassificationNode(opts handlers.HandlerOptions) handlers.Handler {
+	return &deleteHandler{baseHandler: newBaseHandler(opts)}
+}
+
+// Interface compliance verification
+var _ handlers.Handler = &getHandler{}
+var _ handlers.Handler = &postHandler{}
+var _ handlers.Handler = &patchHandler{}
+var _ handlers.Handler = &deleteHandler{}
+
+// Base handler with common functionality
+type baseHandler struct {
+	*handlers.BaseHandler
+}
+
+// Constructor for the base handler
+func newBaseHandler(opts handlers.HandlerOptions) *baseHandler {
+	return &baseHandler{BaseHandler: handlers.NewBaseHandler(opts)}
+}
+
+// Handler types embedding the base handler
+type getHandler struct {
+	*baseHandler
+}
+
+type postHandler struct {
+	*baseHandler
+}
+
+type patchHandler struct {
+	*baseHandler
+}
+
+type deleteHandler struct {
+	*baseHandler
+}
+
+// isNotFound reports whether Azure DevOps API responded 404
+func isNotFound(err error) bool {
+	var adoErr *handlers.AzureDevOpsError
+	return errors.As(err, &adoErr) && adoErr.StatusCode == http.StatusNotFound
+}
+
+// nodeURL returns the URL of the node with the given path segments, without query
+// The root node of the structure group is returned for an empty path
+func nodeURL(organization, projectId, structureGroup string, segments []string) string {
+	url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/wit/classificationnodes/%s", organization, projectId, structureGroup)
+	if len(segments) > 0 {
+		url += "/" + escapeNodePath(segments)
+	}
+	return url
+}
+
+// saveNode sends a request returning a node (GET, create or move with POST on the parent, update with PATCH)
+func (h *baseHandler) saveNode(method, url string, parameters interface{}, authHeader string) (*WorkItemClassificationNode, error) {
+	var requestBody []byte
+	if parameters != nil {
+		var err error
+		requestBody, err = json.Marshal(parameters)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal node: %w", err)
+		}
+	}
+
+	body, err := h.DoAzureDevOpsRequest(method, url, authHeader, requestBody)
+	if err != nil {
+		return nil, err
+	}
+
+	var node WorkItemClassificationNode
+	if err := json.Unmarshal(body, &node); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal node: %w", err)
+	}
+	return &node, nil
+}
+
+// getNode returns the node with the given path segments
+func (h *baseHandler) getNode(organization, projectId, structureGroup string, segments []string, apiVersion, authHeader string) (*WorkItemClassificationNode, error) {
+	url := fmt.Sprintf("%s?api-version=%s", nodeURL(organization, projectId, structureGroup, segments), apiVersion)
+	return h.saveNode("GET", url, nil, authHeader)
+}
+
+// ensureNode returns the node with the given path segments, creating it and its missing parents if needed
+// Only the node itself is created with the given attributes
+func (h *baseHandler) ensureNode(organization, projectId, structureGroup string, segments []string, attributes *NodeAttributes, apiVersion, authHeader string) (*WorkItemClassificationNode, bool, error) {
+	node, err := h.getNode(organization, projectId, structureGroup, segments, apiVersion, authHeader)
+	if err == nil {
+		return node, false, nil
+	}
+	if !isNotFound(err) {
+		return nil, false, err
+	}
+
+	parent := segments[:len(segments)-1]
+	if len(parent) > 0 {
+		if _, _, err := h.ensureNode(organization, projectId, structureGroup, parent, nil, apiVersion, authHeader); err != nil {
+			return nil, false, err
+		}
+	}
+
+	h.Log.Printf("Creating %s node %s", structureGroup, strings.Join(segments, "/"))
+	url := fmt.Sprintf("%s?api-version=%s", nodeURL(organization, projectId, structureGroup, parent), apiVersion)
+	node, err = h.saveNode("POST", url, NodeParameters{Name: segments[len(segments)-1], Attributes: attributes}, authHeader)
+	if err != nil {
+		return nil, false, err
+	}
+	return node, true, nil
+}
+
+// nodeFromPath returns the structure group and the node path segments of the URL
+func (h *baseHandler) nodeFromPath(w http.ResponseWriter, r *http.Request) (string, []string, bool) {
+	structureGroup, err := normalizeStructureGroup(r.PathValue("structureGroup"))
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid structure group: %v", err))
+		return "", nil, false
+	}
+	segments := utils.SplitPath(r.PathValue("path"))
+	if len(segments) == 0 {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Node path is required")
+		return "", nil, false
+	}
+	return structureGroup, segments, true
+}
+
+// nodeNotFoundMessage returns the message written when a node does not exist
+func nodeNotFoundMessage(structureGroup string, segments []string, projectId string) string {
+	return fmt.Sprintf("Node %s not found in %s of project %s", strings.Join(segments, "/"), structureGroup, projectId)
+}
+
+// GET handler implementation
+// @Summary Get an area or iteration node
+// @Description Get an area or iteration node by path. The path accepts both '/' and '\' as separators and is returned normalized (e.g., 'Team A/Backend').
+// @ID get-classification-node
+// @Param organization path string true "Organization name"
+// @Param projectId path string true "Project ID or name"
+// @Param structureGroup path string true "Structure group (areas or iterations)"
+// @Param path path string true "Node path below the root node (e.g., Team A/Backend)"
+// @Param api-version query string true "API version (e.g., 7.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Produce json
+// @Success 200 {object} NodeResponse "Node details"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{projectId}/wit/classificationnodes/{structureGroup}/{path} [get]
+func (h *getHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	projectId := r.PathValue("projectId")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, projectId, apiVersion) {
+		return
+	}
+	structureGroup, segments, ok := h.nodeFromPath(w, r)
+	if !ok {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	h.Log.Printf("Getting %s node %s for project %s in organization %s", structureGroup, strings.Join(segments, "/"), projectId, organization)
+
+	node, err := h.getNode(organization, projectId, structureGroup, segments, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "get node", nodeNotFoundMessage(structureGroup, segments, projectId))
+		return
+	}
+
+	h.WriteObjectResponse(w, http.StatusOK, NodeResponse(*mapNodeFromAzure(node)))
+	h.Log.Printf("Successfully retrieved node %d", node.ID)
+}
+
+// POST handler implementation
+// @Summary Create an area or iteration node
+// @Description Create an area or iteration node and its missing parents. If the node already exists, 200 is returned instead and the dates of an iteration are updated if needed.
+// @ID post-classification-node
+// @Param organization path string true "Organization name"
+// @Param projectId path string true "Project ID or name"
+// @Param structureGroup path string true "Structure group (areas or iterations)"
+// @Param api-version query string true "API version (e.g., 7.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Param node body CreateNodeRequest true "Node creation request body"
+// @Accept json
+// @Produce json
+// @Success 200 {object} NodeResponse "Existing node"
+// @Success 201 {object} NodeResponse "Created node"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{projectId}/wit/classificationnodes/{structureGroup} [post]
+func (h *postHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	projectId := r.PathValue("projectId")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, projectId, apiVersion) {
+		return
+	}
+	structureGroup, err := normalizeStructureGroup(r.PathValue("structureGroup"))
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid structure group: %v", err))
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Failed to read request body")
+		return
+	}
+	var nodeRequest CreateNodeRequest
+	if err := json.Unmarshal(body, &nodeRequest); err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Invalid JSON in request body")
+		return
+	}
+	segments, err := validateNodePath(nodeRequest.Path)
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid node: %v", err))
+		return
+	}
+	attributes, err := validateDates(structureGroup, nodeRequest.StartDate, nodeRequest.FinishDate)
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid node: %v", err))
+		return
+	}
+
+	h.Log.Printf("Creating %s node %s for project %s in organization %s", structureGroup, strings.Join(segments, "/"), projectId, organization)
+
+	notFoundMessage := fmt.Sprintf("Project %s not found", projectId)
+	node, created, err := h.ensureNode(organization, projectId, structureGroup, segments, attributes, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "create node", notFoundMessage)
+		return
+	}
+	if created {
+		h.WriteObjectResponse(w, http.StatusCreated, NodeResponse(*mapNodeFromAzure(node)))
+		h.Log.Printf("Successfully created node %d", node.ID)
+		return
+	}
+
+	h.Log.Printf("Node %s already exists with ID %d", strings.Join(segments, "/"), node.ID)
+	if !sameDates(node, attributes) {
+		url := fmt.Sprintf("%s?api-version=%s", nodeURL(organization, projectId, structureGroup, segments), apiVersion)
+		node, err = h.saveNode("PATCH", url, NodeParameters{Attributes: attributes}, authHeader)
+		if err != nil {
+			h.WriteAzureDevOpsError(w, err, "update node", nodeNotFoundMessage(structureGroup, segments, projectId))
+			return
+		}
+		h.Log.Printf("Updated the dates of node %d", node.ID)
+	}
+
+	h.WriteObjectResponse(w, http.StatusOK, NodeResponse(*mapNodeFromAzure(node)))
+}
+
+// PATCH handler implementation
+// @Summary Move, rename or change the dates of an area or iteration node
+// @Description Move the node below another parent (created if missing) and rename it if the path is set, and change the dates of an iteration if they are set. Work items keep their node.
+// @ID patch-classification-node
+// @Param organization path string true "Organization name"
+// @Param projectId path string true "Project ID or name"
+// @Param structureGroup path string true "Structure group (areas or iterations)"
+// @Param path path string true "Current node path below the root node (e.g., Team A/Backend)"
+// @Param api-version query string true "API version (e.g., 7.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Param nodeUpdate body UpdateNodeRequest true "Node update request body"
+// @Accept json
+// @Produce json
+// @Success 200 {object} NodeResponse "Updated node"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 409 "Conflict"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{projectId}/wit/classificationnodes/{structureGroup}/{path} [patch]
+func (h *patchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	projectId := r.PathValue("projectId")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, projectId, apiVersion) {
+		return
+	}
+	structureGroup, segments, ok := h.nodeFromPath(w, r)
+	if !ok {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Failed to read request body")
+		return
+	}
+	var updateRequest UpdateNodeRequest
+	if err := json.Unmarshal(body, &updateRequest); err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Invalid JSON in request body")
+		return
+	}
+	target := segments
+	if strings.TrimSpace(updateRequest.Path) != "" {
+		if target, err = validateNodePath(updateRequest.Path); err != nil {
+			h.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid node: %v", err))
+			return
+		}
+	}
+	attributes, err := validateDates(structureGroup, updateRequest.StartDate, updateRequest.FinishDate)
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid node: %v", err))
+		return
+	}
+
+	h.Log.Printf("Updating %s node %s for project %s in organization %s", structureGroup, strings.Join(segments, "/"), projectId, organization)
+
+	notFoundMessage := nodeNotFoundMessage(structureGroup, segments, projectId)
+	node, err := h.getNode(organization, projectId, structureGroup, segments, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "get node", notFoundMessage)
+		return
+	}
+
+	moved := len(target) != len(segments) || !hasPrefix(target[:len(target)-1], segments[:len(segments)-1])
+	renamed := target[len(target)-1] != segments[len(segments)-1]
+	if moved || renamed {
+		if moved && hasPrefix(target, segments) {
+			h.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid node: %s cannot be moved below itself", strings.Join(segments, "/")))
+			return
+		}
+
+		// Another node must not exist at the new path, a node renamed with another case is found itself
+		existing, err := h.getNode(organization, projectId, structureGroup, target, apiVersion, authHeader)
+		if err != nil && !isNotFound(err) {
+			h.WriteAzureDevOpsError(w, err, "get node", notFoundMessage)
+			return
+		}
+		if existing != nil && existing.ID != node.ID {
+			h.WriteErrorResponse(w, http.StatusConflict, fmt.Sprintf("Conflict: node %s already exists", strings.Join(target, "/")))
+			return
+		}
+	}
+
+	// The node keeps its name when it is moved, it is renamed afterwards
+	current := segments
+	if moved {
+		parent := target[:len(target)-1]
+		if len(parent) > 0 {
+			if _, _, err := h.ensureNode(organization, projectId, structureGroup, parent, nil, apiVersion, authHeader); err != nil {
+				h.WriteAzureDevOpsError(w, err, "create parent node", notFoundMessage)
+				return
+			}
+		}
+
+		url := fmt.Sprintf("%s?api-version=%s", nodeURL(organization, projectId, structureGroup, parent), apiVersion)
+		if node, err = h.saveNode("POST", url, MoveNodeParameters{ID: node.ID}, authHeader); err != nil {
+			h.WriteAzureDevOpsError(w, err, "move node", notFoundMessage)
+			return
+		}
+		current = append(append([]string{}, parent...), segments[len(segments)-1])
+		h.Log.Printf("Moved node %d below %s", node.ID, strings.Join(parent, "/"))
+	}
+
+	parameters := NodeParameters{}
+	if renamed {
+		parameters.Name = target[len(target)-1]
+	}
+	if !sameDates(node, attributes) {
+		parameters.Attributes = attributes
+	}
+	if parameters.Name != "" || parameters.Attributes != nil {
+		url := fmt.Sprintf("%s?api-version=%s", nodeURL(organization, projectId, structureGroup, current), apiVersion)
+		if node, err = h.saveNode("PATCH", url, parameters, authHeader); err != nil {
+			h.WriteAzureDevOpsError(w, err, "update node", notFoundMessage)
+			return
+		}
+		h.Log.Printf("Updated node %d", node.ID)
+	}
+
+	h.WriteObjectResponse(w, http.StatusOK, NodeResponse(*mapNodeFromAzure(node)))
+}
+
+// DELETE handler implementation
+// @Summary Delete an area or iteration node
+// @Description Delete an area or iteration node and its children. Their work items are moved to the reclassification node, which defaults to the parent of the deleted node.
+// @ID delete-classification-node
+// @Param organization path string true "Organization name"
+// @Param projectId path string true "Project ID or name"
+// @Param structureGroup path string true "Structure group (areas or iterations)"
+// @Param path path string true "Node path below the root node (e.g., Team A/Backend)"
+// @Param api-version query string true "API version (e.g., 7.1)"
+// @Param reclassifyPath query string false "Path of the node receiving the work items of the deleted nodes, defaults to the parent node"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Success 204 "No Content"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{projectId}/wit/classificationnodes/{structureGroup}/{path} [delete]
+func (h *deleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	projectId := r.PathValue("projectId")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, projectId, apiVersion) {
+		return
+	}
+	structureGroup, segments, ok := h.nodeFromPath(w, r)
+	if !ok {
+		return
+	}
+	reclassify := segments[:len(segments)-1]
+	if r.URL.Query().Has("reclassifyPath") {
+		reclassify = utils.SplitPath(r.URL.Query().Get("reclassifyPath"))
+	}
+	if hasPrefix(reclassify, segments) {
+		h.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid reclassifyPath: %s is deleted with node %s", strings.Join(reclassify, "/"), strings.Join(segments, "/")))
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	h.Log.Printf("Deleting %s node %s for project %s in organization %s", structureGroup, strings.Join(segments, "/"), projectId, organization)
+
+	notFoundMessage := nodeNotFoundMessage(structureGroup, segments, projectId)
+	if _, err := h.getNode(organization, projectId, structureGroup, segments, apiVersion, authHeader); err != nil {
+		h.WriteAzureDevOpsError(w, err, "get node", notFoundMessage)
+		return
+	}
+	reclassifyNode, err := h.getNode(organization, projectId, structureGroup, reclassify, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "get reclassification node", fmt.Sprintf("Reclassification node %s not found in %s of project %s", strings.Join(reclassify, "/"), structureGroup, projectId))
+		return
+	}
+
+	url := fmt.Sprintf("%s?$reclassifyId=%d&api-version=%s", nodeURL(organization, projectId, structureGroup, segments), reclassifyNode.ID, apiVersion)
+	if _, err := h.DoAzureDevOpsRequest("DELETE", url, authHeader, nil); err != nil {
+		h.WriteAzureDevOpsError(w, err, "delete node", notFoundMessage)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	h.Log.Printf("Successfully deleted node %s, work items moved to node %d", strings.Join(segments, "/"), reclassifyNode.ID)
+}
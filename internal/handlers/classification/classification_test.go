@@ -0,0 +1,576 @@
+package classification
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers"
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/handlerstest"
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/utils"
+	"github.com/rs/zerolog"
+)
+
+// newTestBaseHandler creates a base handler for testing with a mock client
+func newTestBaseHandler(mockClient *handlerstest.MockHTTPClient) *baseHandler {
+	return newBaseHandler(handlerstest.NewHandlerOptions(mockClient))
+}
+
+// Test data constants
+const (
+	testOrg        = "test-org"
+	testProject    = "Platform"
+	testAPIVersion = "7.1"
+	testUsername   = "user"
+	testPassword   = "token"
+)
+
+// testNodeURL returns the URL of the node with the given path of the test project
+func testNodeURL(structureGroup, nodePath string) string {
+	return nodeURL(testOrg, testProject, structureGroup, utils.SplitPath(nodePath)) + "?api-version=" + testAPIVersion
+}
+
+// areaResp returns an area node of the test project
+func areaResp(id int, nodePath string) string {
+	segments := utils.SplitPath(nodePath)
+	name := "Platform"
+	if len(segments) > 0 {
+		name = segments[len(segments)-1]
+	}
+	azurePath := `\\Platform\\Area`
+	for _, segment := range segments {
+		azurePath += `\\` + segment
+	}
+	return `{"id":` + strconv.Itoa(id) + `,"identifier":"8C1E3C64-5E7B-4A4B-9B0E-0F6C4C1B2A` + fmt.Sprintf("%02d", id) + `","name":"` + name + `","structureType":"area","hasChildren":false,"path":"` + azurePath + `"}`
+}
+
+// iterationResp returns an iteration node of the test project with the given dates
+func iterationResp(id int, nodePath, startDate, finishDate string) string {
+	segments := utils.SplitPath(nodePath)
+	azurePath := `\\Platform\\Iteration`
+	for _, segment := range segments {
+		azurePath += `\\` + segment
+	}
+	attributes := ""
+	if startDate != "" {
+		attributes = `,"attributes":{"startDate":"` + startDate + `T00:00:00Z","finishDate":"` + finishDate + `T00:00:00Z"}`
+	}
+	return `{"id":` + strconv.Itoa(id) + `,"identifier":"d3b07384-d9a0-4c9b-8f3e-1a2b3c4d5e` + fmt.Sprintf("%02d", id) + `","name":"` + segments[len(segments)-1] + `","structureType":"iteration","hasChildren":false,"path":"` + azurePath + `"` + attributes + `}`
+}
+
+// nodeTestCase is a test case for the handlers of this package
+type nodeTestCase struct {
+	name                 string
+	pathValues           map[string]string
+	query                url.Values
+	noAuth               bool
+	body                 string
+	setupMock            func(*handlerstest.MockHTTPClient)
+	expectedStatus       int
+	expectedBody         string
+	expectedBodyContains string
+	expectedRequestCount int
+	verify               func(t *testing.T, mockClient *handlerstest.MockHTTPClient)
+}
+
+// nodePathValues returns the path values of the classification node endpoints
+func nodePathValues(structureGroup, nodePath string) map[string]string {
+	return map[string]string{"organization": testOrg, "projectId": testProject, "structureGroup": structureGroup, "path": nodePath}
+}
+
+// runNodeTests runs the given test cases against the handler built by newHandler
+func runNodeTests(t *testing.T, method string, newHandler func(*baseHandler) http.Handler, tests []nodeTestCase) {
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := handlerstest.NewMockHTTPClient()
+			if tt.setupMock != nil {
+				tt.setupMock(mockClient)
+			}
+			handler := newHandler(newTestBaseHandler(mockClient))
+
+			target := "/api/test"
+			if len(tt.query) > 0 {
+				target += "?" + tt.query.Encode()
+			}
+			var body io.Reader
+			if tt.body != "" {
+				body = strings.NewReader(tt.body)
+			}
+			req := httptest.NewRequest(method, target, body)
+			for key, value := range tt.pathValues {
+				req.SetPathValue(key, value)
+			}
+			if !tt.noAuth {
+				req.SetBasicAuth(testUsername, testPassword)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("Status code = %d, want %d. Body: %s", rr.Code, tt.expectedStatus, rr.Body.String())
+			}
+			if tt.expectedBody != "" && rr.Body.String() != tt.expectedBody {
+				t.Errorf("Body = %s, want %s", rr.Body.String(), tt.expectedBody)
+			}
+			if tt.expectedBodyContains != "" && !strings.Contains(rr.Body.String(), tt.expectedBodyContains) {
+				t.Errorf("Body = %s, want it to contain %s", rr.Body.String(), tt.expectedBodyContains)
+			}
+			if mockClient.RequestCount() != tt.expectedRequestCount {
+				t.Errorf("Request count = %d, want %d", mockClient.RequestCount(), tt.expectedRequestCount)
+			}
+			if tt.verify != nil {
+				tt.verify(t, mockClient)
+			}
+		})
+	}
+}
+
+// apiVersionQuery returns the query with the test API version
+func apiVersionQuery() url.Values {
+	return url.Values{"api-version": {testAPIVersion}}
+}
+
+// requestBody returns the body of the n-th request sent to Azure DevOps
+func requestBody(t *testing.T, mockClient *handlerstest.MockHTTPClient, n int) string {
+	t.Helper()
+	if len(mockClient.Requests) <= n || mockClient.Requests[n].Body == nil {
+		t.Fatalf("request %d has no body", n)
+	}
+	body, err := io.ReadAll(mockClient.Requests[n].Body)
+	if err != nil {
+		t.Fatalf("failed to read request %d body: %v", n, err)
+	}
+	return string(body)
+}
+
+// containsRequest reports whether a request was sent with the given method and URL
+func containsRequest(mockClient *handlerstest.MockHTTPClient, method, url string) bool {
+	for _, req := range mockClient.Requests {
+		if req.Method+" "+req.URL.String() == method+" "+url {
+			return true
+		}
+	}
+	return false
+}
+
+// Test constructor functions
+func TestClassificationConstructors(t *testing.T) {
+	client := &http.Client{}
+	logger := zerolog.New(os.Stdout).With().Timestamp().Logger()
+	opts := handlers.HandlerOptions{
+		Client: client,
+		Log:    &logger,
+	}
+
+	constructors := map[string]func(handlers.HandlerOptions) handlers.Handler{
+		"GetClassificationNode":    GetClassificationNode,
+		"PostClassificationNode":   PostClassificationNode,
+		"PatchClassificationNode":  PatchClassificationNode,
+		"DeleteClassificationNode": DeleteClassificationNode,
+	}
+	for name, constructor := range constructors {
+		if h := constructor(opts); h == nil {
+			t.Fatalf("%s should return a non-nil handler", name)
+		}
+	}
+}
+
+// Test GET handler
+func TestGetHandler_ServeHTTP(t *testing.T) {
+	runNodeTests(t, "GET", func(b *baseHandler) http.Handler { return &getHandler{baseHandler: b} }, []nodeTestCase{
+		{
+			name:       "area with a normalized path",
+			pathValues: nodePathValues("areas", `Team A\Backend/`),
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(testNodeURL("areas", "Team A/Backend"), http.StatusOK, areaResp(12, "Team A/Backend"))
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBody:         `{"id":12,"identifier":"8c1e3c64-5e7b-4a4b-9b0e-0f6c4c1b2a12","name":"Backend","structureType":"area","path":"Team A/Backend","hasChildren":false}`,
+			expectedRequestCount: 1,
+		},
+		{
+			name:       "iteration with its dates",
+			pathValues: nodePathValues("Iterations", "Release 1/Sprint 1"),
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(testNodeURL("iterations", "Release 1/Sprint 1"), http.StatusOK, iterationResp(21, "Release 1/Sprint 1", "2026-01-05", "2026-01-16"))
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `"path":"Release 1/Sprint 1","hasChildren":false,"startDate":"2026-01-05","finishDate":"2026-01-16"}`,
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "node not found",
+			pathValues:           nodePathValues("areas", "Team A"),
+			query:                apiVersionQuery(),
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Node Team A not found in areas of project Platform",
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "invalid structure group",
+			pathValues:           nodePathValues("sprints", "Team A"),
+			query:                apiVersionQuery(),
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid structure group: structure group must be areas or iterations, got 'sprints'",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "root node",
+			pathValues:           nodePathValues("areas", "/"),
+			query:                apiVersionQuery(),
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Node path is required",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "missing basic auth",
+			pathValues:           nodePathValues("areas", "Team A"),
+			query:                apiVersionQuery(),
+			noAuth:               true,
+			expectedStatus:       http.StatusUnauthorized,
+			expectedBody:         "Request rejected due to missing or invalid Basic authentication",
+			expectedRequestCount: 0,
+		},
+	})
+}
+
+// Test POST handler
+func TestPostHandler_ServeHTTP(t *testing.T) {
+	runNodeTests(t, "POST", func(b *baseHandler) http.Handler { return &postHandler{baseHandler: b} }, []nodeTestCase{
+		{
+			name:       "area created below an existing parent",
+			pathValues: nodePathValues("areas", ""),
+			query:      apiVersionQuery(),
+			body:       `{"path":"Team A/Backend"}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(testNodeURL("areas", "Team A"), http.StatusOK, areaResp(11, "Team A"))
+				m.SetMethodResponse("POST", testNodeURL("areas", "Team A"), http.StatusCreated, areaResp(12, "Team A/Backend"))
+			},
+			expectedStatus:       http.StatusCreated,
+			expectedBodyContains: `"path":"Team A/Backend"`,
+			expectedRequestCount: 3,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				if got := requestBody(t, m, 2); got != `{"name":"Backend"}` {
+					t.Errorf("Request body = %s", got)
+				}
+			},
+		},
+		{
+			name:       "iteration created with its missing parents",
+			pathValues: nodePathValues("iterations", ""),
+			query:      apiVersionQuery(),
+			body:       `{"path":"\\Release 1\\Sprint 1","startDate":"2026-01-05","finishDate":"2026-01-16T00:00:00Z"}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetMethodResponse("POST", testNodeURL("iterations", ""), http.StatusCreated, iterationResp(20, "Release 1", "", ""))
+				m.SetMethodResponse("POST", testNodeURL("iterations", "Release 1"), http.StatusCreated, iterationResp(21, "Release 1/Sprint 1", "2026-01-05", "2026-01-16"))
+			},
+			expectedStatus:       http.StatusCreated,
+			expectedBodyContains: `"path":"Release 1/Sprint 1","hasChildren":false,"startDate":"2026-01-05","finishDate":"2026-01-16"}`,
+			expectedRequestCount: 4,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				if got := requestBody(t, m, 2); got != `{"name":"Release 1"}` {
+					t.Errorf("Parent request body = %s", got)
+				}
+				want := `{"name":"Sprint 1","attributes":{"startDate":"2026-01-05T00:00:00Z","finishDate":"2026-01-16T00:00:00Z"}}`
+				if got := requestBody(t, m, 3); got != want {
+					t.Errorf("Request body = %s, want %s", got, want)
+				}
+			},
+		},
+		{
+			name:       "existing iteration with other dates",
+			pathValues: nodePathValues("iterations", ""),
+			query:      apiVersionQuery(),
+			body:       `{"path":"Release 1/Sprint 1","startDate":"2026-01-12","finishDate":"2026-01-23"}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(testNodeURL("iterations", "Release 1/Sprint 1"), http.StatusOK, iterationResp(21, "Release 1/Sprint 1", "2026-01-05", "2026-01-16"))
+				m.SetMethodResponse("PATCH", testNodeURL("iterations", "Release 1/Sprint 1"), http.StatusOK, iterationResp(21, "Release 1/Sprint 1", "2026-01-12", "2026-01-23"))
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `"startDate":"2026-01-12","finishDate":"2026-01-23"`,
+			expectedRequestCount: 2,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				want := `{"attributes":{"startDate":"2026-01-12T00:00:00Z","finishDate":"2026-01-23T00:00:00Z"}}`
+				if got := requestBody(t, m, 1); got != want {
+					t.Errorf("Request body = %s, want %s", got, want)
+				}
+			},
+		},
+		{
+			name:       "existing iteration up to date",
+			pathValues: nodePathValues("iterations", ""),
+			query:      apiVersionQuery(),
+			body:       `{"path":"Release 1/Sprint 1","startDate":"2026-01-05","finishDate":"2026-01-16"}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(testNodeURL("iterations", "Release 1/Sprint 1"), http.StatusOK, iterationResp(21, "Release 1/Sprint 1", "2026-01-05", "2026-01-16"))
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `"id":21`,
+			expectedRequestCount: 1,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				if containsRequest(m, "PATCH", testNodeURL("iterations", "Release 1/Sprint 1")) {
+					t.Errorf("Iteration up to date should not be updated")
+				}
+			},
+		},
+		{
+			name:                 "project not found",
+			pathValues:           nodePathValues("areas", ""),
+			query:                apiVersionQuery(),
+			body:                 `{"path":"Team A"}`,
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Project Platform not found",
+			expectedRequestCount: 2,
+		},
+		{
+			name:                 "dates of an area",
+			pathValues:           nodePathValues("areas", ""),
+			query:                apiVersionQuery(),
+			body:                 `{"path":"Team A","startDate":"2026-01-05","finishDate":"2026-01-16"}`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid node: startDate and finishDate can only be set for iterations",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "reserved character",
+			pathValues:           nodePathValues("areas", ""),
+			query:                apiVersionQuery(),
+			body:                 `{"path":"Team A/R&D"}`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid node: name 'R&D' contains one of the reserved characters " + reservedCharacters,
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "missing path",
+			pathValues:           nodePathValues("areas", ""),
+			query:                apiVersionQuery(),
+			body:                 `{"path":" / "}`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid node: path is required",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "invalid JSON",
+			pathValues:           nodePathValues("areas", ""),
+			query:                apiVersionQuery(),
+			body:                 `{"path":`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid JSON in request body",
+			expectedRequestCount: 0,
+		},
+	})
+}
+
+// Test PATCH handler
+func TestPatchHandler_ServeHTTP(t *testing.T) {
+	runNodeTests(t, "PATCH", func(b *baseHandler) http.Handler { return &patchHandler{baseHandler: b} }, []nodeTestCase{
+		{
+			name:       "area renamed",
+			pathValues: nodePathValues("areas", "Team A/Backend"),
+			query:      apiVersionQuery(),
+			body:       `{"path":"Team A/Services"}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(testNodeURL("areas", "Team A/Backend"), http.StatusOK, areaResp(12, "Team A/Backend"))
+				m.SetMethodResponse("PATCH", testNodeURL("areas", "Team A/Backend"), http.StatusOK, areaResp(12, "Team A/Services"))
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `"name":"Services","structureType":"area","path":"Team A/Services"`,
+			expectedRequestCount: 3,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				if got := requestBody(t, m, 2); got != `{"name":"Services"}` {
+					t.Errorf("Request body = %s", got)
+				}
+			},
+		},
+		{
+			name:       "area moved below a new parent and renamed",
+			pathValues: nodePathValues("areas", "Team A/Backend"),
+			query:      apiVersionQuery(),
+			body:       `{"path":"Team B/Services"}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(testNodeURL("areas", "Team A/Backend"), http.StatusOK, areaResp(12, "Team A/Backend"))
+				m.SetMethodResponse("POST", testNodeURL("areas", ""), http.StatusCreated, areaResp(13, "Team B"))
+				m.SetMethodResponse("POST", testNodeURL("areas", "Team B"), http.StatusOK, areaResp(12, "Team B/Backend"))
+				m.SetMethodResponse("PATCH", testNodeURL("areas", "Team B/Backend"), http.StatusOK, areaResp(12, "Team B/Services"))
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `"path":"Team B/Services"`,
+			expectedRequestCount: 6,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				if got := requestBody(t, m, 4); got != `{"id":12}` {
+					t.Errorf("Move request body = %s", got)
+				}
+				if got := requestBody(t, m, 5); got != `{"name":"Services"}` {
+					t.Errorf("Rename request body = %s", got)
+				}
+			},
+		},
+		{
+			name:       "iteration moved to the root with new dates",
+			pathValues: nodePathValues("iterations", "Release 1/Sprint 1"),
+			query:      apiVersionQuery(),
+			body:       `{"path":"Sprint 1","startDate":"2026-01-12","finishDate":"2026-01-23"}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(testNodeURL("iterations", "Release 1/Sprint 1"), http.StatusOK, iterationResp(21, "Release 1/Sprint 1", "2026-01-05", "2026-01-16"))
+				m.SetMethodResponse("POST", testNodeURL("iterations", ""), http.StatusOK, iterationResp(21, "Sprint 1", "2026-01-05", "2026-01-16"))
+				m.SetMethodResponse("PATCH", testNodeURL("iterations", "Sprint 1"), http.StatusOK, iterationResp(21, "Sprint 1", "2026-01-12", "2026-01-23"))
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `"path":"Sprint 1","hasChildren":false,"startDate":"2026-01-12"`,
+			expectedRequestCount: 4,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				want := `{"attributes":{"startDate":"2026-01-12T00:00:00Z","finishDate":"2026-01-23T00:00:00Z"}}`
+				if got := requestBody(t, m, 3); got != want {
+					t.Errorf("Request body = %s, want %s", got, want)
+				}
+			},
+		},
+		{
+			name:       "node renamed with another case",
+			pathValues: nodePathValues("areas", "Team A/backend"),
+			query:      apiVersionQuery(),
+			body:       `{"path":"team a/Backend"}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(testNodeURL("areas", "Team A/backend"), http.StatusOK, areaResp(12, "Team A/backend"))
+				m.SetResponse(testNodeURL("areas", "team a/Backend"), http.StatusOK, areaResp(12, "Team A/backend"))
+				m.SetMethodResponse("PATCH", testNodeURL("areas", "Team A/backend"), http.StatusOK, areaResp(12, "Team A/Backend"))
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `"path":"Team A/Backend"`,
+			expectedRequestCount: 3,
+		},
+		{
+			name:       "node without changes",
+			pathValues: nodePathValues("areas", "Team A"),
+			query:      apiVersionQuery(),
+			body:       `{}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(testNodeURL("areas", "Team A"), http.StatusOK, areaResp(11, "Team A"))
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `"id":11`,
+			expectedRequestCount: 1,
+		},
+		{
+			name:       "another node at the new path",
+			pathValues: nodePathValues("areas", "Team A/Backend"),
+			query:      apiVersionQuery(),
+			body:       `{"path":"Team B"}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(testNodeURL("areas", "Team A/Backend"), http.StatusOK, areaResp(12, "Team A/Backend"))
+				m.SetResponse(testNodeURL("areas", "Team B"), http.StatusOK, areaResp(13, "Team B"))
+			},
+			expectedStatus:       http.StatusConflict,
+			expectedBody:         "Conflict: node Team B already exists",
+			expectedRequestCount: 2,
+		},
+		{
+			name:       "node moved below itself",
+			pathValues: nodePathValues("areas", "Team A"),
+			query:      apiVersionQuery(),
+			body:       `{"path":"Team A/Backend/Team A"}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(testNodeURL("areas", "Team A"), http.StatusOK, areaResp(11, "Team A"))
+			},
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid node: Team A cannot be moved below itself",
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "node not found",
+			pathValues:           nodePathValues("areas", "Team A"),
+			query:                apiVersionQuery(),
+			body:                 `{"path":"Team B"}`,
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Node Team A not found in areas of project Platform",
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "finish date before start date",
+			pathValues:           nodePathValues("iterations", "Sprint 1"),
+			query:                apiVersionQuery(),
+			body:                 `{"startDate":"2026-01-16","finishDate":"2026-01-05"}`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid node: finishDate 2026-01-05 is before startDate 2026-01-16",
+			expectedRequestCount: 0,
+		},
+	})
+}
+
+// Test DELETE handler
+func TestDeleteHandler_ServeHTTP(t *testing.T) {
+	runNodeTests(t, "DELETE", func(b *baseHandler) http.Handler { return &deleteHandler{baseHandler: b} }, []nodeTestCase{
+		{
+			name:       "work items moved to the parent",
+			pathValues: nodePathValues("areas", "Team A/Backend"),
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(testNodeURL("areas", "Team A/Backend"), http.StatusOK, areaResp(12, "Team A/Backend"))
+				m.SetResponse(testNodeURL("areas", "Team A"), http.StatusOK, areaResp(11, "Team A"))
+				m.SetMethodResponse("DELETE", nodeURL(testOrg, testProject, "areas", []string{"Team A", "Backend"})+"?$reclassifyId=11&api-version="+testAPIVersion, http.StatusNoContent, "")
+			},
+			expectedStatus:       http.StatusNoContent,
+			expectedRequestCount: 3,
+		},
+		{
+			name:       "work items moved to the root",
+			pathValues: nodePathValues("iterations", "Sprint 1"),
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(testNodeURL("iterations", "Sprint 1"), http.StatusOK, iterationResp(21, "Sprint 1", "", ""))
+				m.SetResponse(testNodeURL("iterations", ""), http.StatusOK, `{"id":2,"name":"Platform","structureType":"iteration","hasChildren":true,"path":"\\Platform\\Iteration"}`)
+				m.SetMethodResponse("DELETE", nodeURL(testOrg, testProject, "iterations", []string{"Sprint 1"})+"?$reclassifyId=2&api-version="+testAPIVersion, http.StatusNoContent, "")
+			},
+			expectedStatus:       http.StatusNoContent,
+			expectedRequestCount: 3,
+		},
+		{
+			name:       "work items moved to another node",
+			pathValues: nodePathValues("areas", "Team A/Backend"),
+			query:      url.Values{"api-version": {testAPIVersion}, "reclassifyPath": {`\Team B`}},
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(testNodeURL("areas", "Team A/Backend"), http.StatusOK, areaResp(12, "Team A/Backend"))
+				m.SetResponse(testNodeURL("areas", "Team B"), http.StatusOK, areaResp(13, "Team B"))
+				m.SetMethodResponse("DELETE", nodeURL(testOrg, testProject, "areas", []string{"Team A", "Backend"})+"?$reclassifyId=13&api-version="+testAPIVersion, http.StatusNoContent, "")
+			},
+			expectedStatus:       http.StatusNoContent,
+			expectedRequestCount: 3,
+		},
+		{
+			name:       "reclassification node not found",
+			pathValues: nodePathValues("areas", "Team A/Backend"),
+			query:      url.Values{"api-version": {testAPIVersion}, "reclassifyPath": {"Team C"}},
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(testNodeURL("areas", "Team A/Backend"), http.StatusOK, areaResp(12, "Team A/Backend"))
+			},
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Reclassification node Team C not found in areas of project Platform",
+			expectedRequestCount: 2,
+		},
+		{
+			name:                 "reclassification node deleted with the node",
+			pathValues:           nodePathValues("areas", "Team A"),
+			query:                url.Values{"api-version": {testAPIVersion}, "reclassifyPath": {"team a/Backend"}},
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid reclassifyPath: team a/Backend is deleted with node Team A",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "node not found",
+			pathValues:           nodePathValues("areas", "Team A"),
+			query:                apiVersionQuery(),
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Node Team A not found in areas of project Platform",
+			expectedRequestCount: 1,
+		},
+	})
+}
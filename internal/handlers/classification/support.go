@@ -0,0 +1,165 @@
+package classification
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/utils"
+)
+
+// reservedCharacters are the characters Azure DevOps does not accept in the name of an area or iteration
+// ('/' and '\' are not listed, as they separate the segments of a path)
+const reservedCharacters = `$?*:"&><#%|+`
+
+// normalizeStructureGroup checks the structure group of the URL (areas or iterations, case-insensitive)
+func normalizeStructureGroup(structureGroup string) (string, error) {
+	switch strings.ToLower(structureGroup) {
+	case AreasStructureGroup:
+		return AreasStructureGroup, nil
+	case IterationsStructureGroup:
+		return IterationsStructureGroup, nil
+	}
+	return "", fmt.Errorf("structure group must be %s or %s, got '%s'", AreasStructureGroup, IterationsStructureGroup, structureGroup)
+}
+
+// normalizeNodePath converts a node path in any format (e.g., '\Team A\Backend\') to the plugin format (e.g., 'Team A/Backend')
+func normalizeNodePath(nodePath string) string {
+	return strings.Join(utils.SplitPath(nodePath), "/")
+}
+
+// nodePathFromAzure converts the path of a node returned by Azure DevOps (e.g., '\Project\Area\Team A\Backend')
+// to the plugin format (e.g., 'Team A/Backend'), dropping the project and the structure segments
+// The root node is returned as an empty string
+func nodePathFromAzure(nodePath string) string {
+	segments := utils.SplitPath(nodePath)
+	if len(segments) <= 2 {
+		return ""
+	}
+	return strings.Join(segments[2:], "/")
+}
+
+// escapeNodePath returns the segments escaped for the URL of a node, the root node is an empty string
+func escapeNodePath(segments []string) string {
+	escaped := make([]string, len(segments))
+	for i, segment := range segments {
+		escaped[i] = url.PathEscape(segment)
+	}
+	return strings.Join(escaped, "/")
+}
+
+// validateNodePath splits a node path and checks the names of its segments
+func validateNodePath(nodePath string) ([]string, error) {
+	segments := utils.SplitPath(nodePath)
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("path is required")
+	}
+	for _, segment := range segments {
+		switch {
+		case len(segment) > 255:
+			return nil, fmt.Errorf("name '%s' is longer than 255 characters", segment)
+		case segment == "." || segment == "..":
+			return nil, fmt.Errorf("name '%s' is reserved", segment)
+		case strings.ContainsAny(segment, reservedCharacters):
+			return nil, fmt.Errorf("name '%s' contains one of the reserved characters %s", segment, reservedCharacters)
+		}
+	}
+	return segments, nil
+}
+
+// parseDate parses a date (e.g., 2026-01-05) or a date and time (e.g., 2026-01-05T00:00:00Z), keeping the date only
+func parseDate(value string) (time.Time, error) {
+	date, err := time.Parse(DateLayout, value)
+	if err != nil {
+		dateTime, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid date '%s', expected YYYY-MM-DD", value)
+		}
+		date = time.Date(dateTime.Year(), dateTime.Month(), dateTime.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	return date, nil
+}
+
+// formatDateFromAzure returns the date of a date and time returned by Azure DevOps, or the value as it is if it cannot be parsed
+func formatDateFromAzure(value string) string {
+	if value == "" {
+		return ""
+	}
+	date, err := parseDate(value)
+	if err != nil {
+		return value
+	}
+	return date.Format(DateLayout)
+}
+
+// validateDates checks the start and finish dates of a request and returns them as attributes for Azure DevOps API
+// No attributes are returned if no dates are set
+func validateDates(structureGroup, startDate, finishDate string) (*NodeAttributes, error) {
+	startDate = strings.TrimSpace(startDate)
+	finishDate = strings.TrimSpace(finishDate)
+	switch {
+	case startDate == "" && finishDate == "":
+		return nil, nil
+	case structureGroup != IterationsStructureGroup:
+		return nil, fmt.Errorf("startDate and finishDate can only be set for iterations")
+	case startDate == "" || finishDate == "":
+		return nil, fmt.Errorf("startDate and finishDate must be set together")
+	}
+
+	start, err := parseDate(startDate)
+	if err != nil {
+		return nil, fmt.Errorf("startDate: %w", err)
+	}
+	finish, err := parseDate(finishDate)
+	if err != nil {
+		return nil, fmt.Errorf("finishDate: %w", err)
+	}
+	if finish.Before(start) {
+		return nil, fmt.Errorf("finishDate %s is before startDate %s", finish.Format(DateLayout), start.Format(DateLayout))
+	}
+
+	return &NodeAttributes{StartDate: start.Format(time.RFC3339), FinishDate: finish.Format(time.RFC3339)}, nil
+}
+
+// sameDates reports whether the node already has the given dates
+func sameDates(node *WorkItemClassificationNode, attributes *NodeAttributes) bool {
+	if attributes == nil {
+		return true
+	}
+	if node.Attributes == nil {
+		return false
+	}
+	return formatDateFromAzure(node.Attributes.StartDate) == formatDateFromAzure(attributes.StartDate) &&
+		formatDateFromAzure(node.Attributes.FinishDate) == formatDateFromAzure(attributes.FinishDate)
+}
+
+// hasPrefix reports whether the path is the given ancestor path or one of its descendants (names are case-insensitive)
+func hasPrefix(segments, ancestor []string) bool {
+	if len(segments) < len(ancestor) {
+		return false
+	}
+	for i := range ancestor {
+		if !strings.EqualFold(segments[i], ancestor[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// mapNodeFromAzure converts an Azure DevOps node to the node returned by the plugin
+func mapNodeFromAzure(node *WorkItemClassificationNode) *Node {
+	mapped := &Node{
+		ID:            node.ID,
+		Identifier:    strings.ToLower(node.Identifier),
+		Name:          node.Name,
+		StructureType: node.StructureType,
+		Path:          nodePathFromAzure(node.Path),
+		HasChildren:   node.HasChildren,
+	}
+	if node.Attributes != nil {
+		mapped.StartDate = formatDateFromAzure(node.Attributes.StartDate)
+		mapped.FinishDate = formatDateFromAzure(node.Attributes.FinishDate)
+	}
+	return mapped
+}
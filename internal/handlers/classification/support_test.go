@@ -0,0 +1,95 @@
+package classification
+
+import (
+	"testing"
+)
+
+func TestNormalizeNodePath(t *testing.T) {
+	tests := map[string]string{
+		"Team A/Backend":        "Team A/Backend",
+		`\Team A\Backend\`:      "Team A/Backend",
+		" / Team A //Backend/ ": "Team A/Backend",
+		"/":                     "",
+	}
+	for nodePath, expected := range tests {
+		if got := normalizeNodePath(nodePath); got != expected {
+			t.Errorf("normalizeNodePath(%q) = %q, want %q", nodePath, got, expected)
+		}
+	}
+}
+
+func TestNodePathFromAzure(t *testing.T) {
+	tests := map[string]string{
+		`\Platform\Area\Team A\Backend`: "Team A/Backend",
+		`\Platform\Iteration\Sprint 1`:  "Sprint 1",
+		`\Platform\Area`:                "",
+	}
+	for nodePath, expected := range tests {
+		if got := nodePathFromAzure(nodePath); got != expected {
+			t.Errorf("nodePathFromAzure(%q) = %q, want %q", nodePath, got, expected)
+		}
+	}
+}
+
+func TestEscapeNodePath(t *testing.T) {
+	if got := escapeNodePath([]string{"Team A", "Q1/Q2"}); got != "Team%20A/Q1%2FQ2" {
+		t.Errorf("escapeNodePath() = %s", got)
+	}
+}
+
+func TestValidateNodePath(t *testing.T) {
+	tests := []struct {
+		nodePath      string
+		expectedError string
+	}{
+		{nodePath: "Team A/Backend"},
+		{nodePath: "", expectedError: "path is required"},
+		{nodePath: "Team A/..", expectedError: "name '..' is reserved"},
+		{nodePath: "Team A/Q1?", expectedError: "name 'Q1?' contains one of the reserved characters " + reservedCharacters},
+	}
+	for _, tt := range tests {
+		_, err := validateNodePath(tt.nodePath)
+		if tt.expectedError == "" && err != nil {
+			t.Errorf("validateNodePath(%q) error = %v", tt.nodePath, err)
+		}
+		if tt.expectedError != "" && (err == nil || err.Error() != tt.expectedError) {
+			t.Errorf("validateNodePath(%q) error = %v, want %s", tt.nodePath, err, tt.expectedError)
+		}
+	}
+}
+
+func TestValidateDates(t *testing.T) {
+	tests := []struct {
+		name           string
+		structureGroup string
+		startDate      string
+		finishDate     string
+		expected       *NodeAttributes
+		expectedError  string
+	}{
+		{name: "no dates", structureGroup: AreasStructureGroup},
+		{name: "dates", structureGroup: IterationsStructureGroup, startDate: "2026-01-05", finishDate: "2026-01-16T18:30:00+01:00", expected: &NodeAttributes{StartDate: "2026-01-05T00:00:00Z", FinishDate: "2026-01-16T00:00:00Z"}},
+		{name: "same day", structureGroup: IterationsStructureGroup, startDate: "2026-01-05", finishDate: "2026-01-05", expected: &NodeAttributes{StartDate: "2026-01-05T00:00:00Z", FinishDate: "2026-01-05T00:00:00Z"}},
+		{name: "area", structureGroup: AreasStructureGroup, startDate: "2026-01-05", finishDate: "2026-01-16", expectedError: "startDate and finishDate can only be set for iterations"},
+		{name: "start date only", structureGroup: IterationsStructureGroup, startDate: "2026-01-05", expectedError: "startDate and finishDate must be set together"},
+		{name: "invalid date", structureGroup: IterationsStructureGroup, startDate: "05/01/2026", finishDate: "2026-01-16", expectedError: "startDate: invalid date '05/01/2026', expected YYYY-MM-DD"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attributes, err := validateDates(tt.structureGroup, tt.startDate, tt.finishDate)
+			if tt.expectedError != "" {
+				if err == nil || err.Error() != tt.expectedError {
+					t.Fatalf("validateDates() error = %v, want %s", err, tt.expectedError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("validateDates() error = %v", err)
+			}
+			if (attributes == nil) != (tt.expected == nil) || (attributes != nil && *attributes != *tt.expected) {
+				t.Errorf("validateDates() = %+v, want %+v", attributes, tt.expected)
+			}
+		})
+	}
+}
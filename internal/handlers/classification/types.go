@@ -0,0 +1,73 @@
+package classification
+
+// Structure groups of the classification nodes, as used in the URLs of Azure DevOps API
+const (
+	AreasStructureGroup      = "areas"
+	IterationsStructureGroup = "iterations"
+)
+
+// DateLayout is the layout of the start and finish dates of the iterations returned by the plugin
+const DateLayout = "2006-01-02"
+
+// Node represents the area or iteration node returned by the plugin
+// It is built from the WorkItemClassificationNode returned by:
+// GET https://dev.azure.com/{organization}/{project}/_apis/wit/classificationnodes/{structureGroup}/{path}
+type Node struct {
+	ID            int    `json:"id"`
+	Identifier    string `json:"identifier"`
+	Name          string `json:"name"`
+	StructureType string `json:"structureType"` // area or iteration
+	Path          string `json:"path"`          // Path below the root node, e.g., Team A/Backend
+	HasChildren   bool   `json:"hasChildren"`
+	StartDate     string `json:"startDate,omitempty"`  // Iterations only, e.g., 2026-01-05
+	FinishDate    string `json:"finishDate,omitempty"` // Iterations only, e.g., 2026-01-16
+}
+
+// NodeResponse represents the response of the classification node endpoints
+type NodeResponse Node
+
+// CreateNodeRequest represents the request body for creating an area or iteration node
+type CreateNodeRequest struct {
+	Path       string `json:"path"`                 // Required: path below the root node, '/' and '\' are both accepted as separators
+	StartDate  string `json:"startDate,omitempty"`  // Iterations only, set together with finishDate
+	FinishDate string `json:"finishDate,omitempty"` // Iterations only, set together with startDate
+}
+
+// UpdateNodeRequest represents the request body for moving, renaming or changing the dates of a node
+type UpdateNodeRequest struct {
+	Path       string `json:"path,omitempty"`       // New path of the node, left as it is if not set
+	StartDate  string `json:"startDate,omitempty"`  // Iterations only, left as it is if not set
+	FinishDate string `json:"finishDate,omitempty"` // Iterations only, left as it is if not set
+}
+
+// Azure DevOps API types
+
+// WorkItemClassificationNode represents an area or iteration node from:
+// GET https://dev.azure.com/{organization}/{project}/_apis/wit/classificationnodes/{structureGroup}/{path}
+type WorkItemClassificationNode struct {
+	ID            int             `json:"id"`
+	Identifier    string          `json:"identifier"`
+	Name          string          `json:"name"`
+	StructureType string          `json:"structureType"`
+	HasChildren   bool            `json:"hasChildren"`
+	Path          string          `json:"path"` // e.g., \Project\Area\Team A\Backend
+	Attributes    *NodeAttributes `json:"attributes,omitempty"`
+	URL           string          `json:"url,omitempty"`
+}
+
+// NodeAttributes represents the attributes of an iteration node
+type NodeAttributes struct {
+	StartDate  string `json:"startDate,omitempty"`  // e.g., 2026-01-05T00:00:00Z
+	FinishDate string `json:"finishDate,omitempty"` // e.g., 2026-01-16T00:00:00Z
+}
+
+// NodeParameters represents the request body for creating (POST on the parent) or updating (PATCH) a node
+type NodeParameters struct {
+	Name       string          `json:"name,omitempty"`
+	Attributes *NodeAttributes `json:"attributes,omitempty"`
+}
+
+// MoveNodeParameters represents the request body for moving a node (POST on the new parent)
+type MoveNodeParameters struct {
+	ID int `json:"id"`
+}
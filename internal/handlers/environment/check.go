@@ -0,0 +1,456 @@
+package environment
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers"
+)
+
+// errCheckNotFound is returned when a check configuration does not exist or does not protect the requested environment
+var errCheckNotFound = errors.New("check not found")
+
+// Handler constructors
+func ListEnvironmentChecks(opts handlers.HandlerOptions) handlers.Handler {
+	return &listChecksHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func GetEnvironmentCheck(opts handlers.HandlerOptions) handlers.Handler {
+	return &getCheckHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func PostEnvironmentCheck(opts handlers.HandlerOptions) handlers.Handler {
+	return &postCheckHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func PutEnvironmentCheck(opts handlers.HandlerOptions) handlers.Handler {
+	return &putCheckHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func DeleteEnvironmentCheck(opts handlers.HandlerOptions) handlers.Handler {
+	return &deleteCheckHandler{baseHandler: newBaseHandler(opts)}
+}
+
+// Interface compliance verification
+var _ handlers.Handler = &listChecksHandler{}
+var _ handlers.Handler = &getCheckHandler{}
+var _ handlers.Handler = &postCheckHandler{}
+var _ handlers.Handler = &putCheckHandler{}
+var _ handlers.Handler = &deleteCheckHandler{}
+
+type listChecksHandler struct {
+	*baseHandler
+}
+
+type getCheckHandler struct {
+	*baseHandler
+}
+
+type postCheckHandler struct {
+	*baseHandler
+}
+
+type putCheckHandler struct {
+	*baseHandler
+}
+
+type deleteCheckHandler struct {
+	*baseHandler
+}
+
+// getEnvironmentCheck retrieves a check configuration and verifies that it protects the given environment
+// errCheckNotFound is returned if the check does not exist or protects another resource
+func (h *baseHandler) getEnvironmentCheck(organization, project, environmentID, checkID, apiVersion, authHeader string) (*CheckConfiguration, error) {
+	url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/pipelines/checks/configurations/%s?$expand=settings&api-version=%s", organization, project, checkID, apiVersion)
+
+	body, err := h.DoAzureDevOpsRequest("GET", url, authHeader, nil)
+	if err != nil {
+		var adoErr *handlers.AzureDevOpsError
+		if errors.As(err, &adoErr) && adoErr.StatusCode == http.StatusNotFound {
+			return nil, errCheckNotFound
+		}
+		return nil, err
+	}
+
+	var configuration CheckConfiguration
+	if err := json.Unmarshal(body, &configuration); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal check configuration: %w", err)
+	}
+	if !isEnvironmentCheck(&configuration, environmentID) {
+		return nil, errCheckNotFound
+	}
+
+	return &configuration, nil
+}
+
+// listEnvironmentChecks retrieves the check configurations protecting the given environment
+func (h *baseHandler) listEnvironmentChecks(organization, project, environmentID, apiVersion, authHeader string) ([]CheckConfiguration, error) {
+	url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/pipelines/checks/configurations?resourceType=%s&resourceId=%s&$expand=settings&api-version=%s", organization, project, ResourceTypeEnvironment, environmentID, apiVersion)
+	body, err := h.DoAzureDevOpsRequest("GET", url, authHeader, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var configurations CheckConfigurationList
+	if err := json.Unmarshal(body, &configurations); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal check configurations: %w", err)
+	}
+
+	checks := []CheckConfiguration{}
+	for _, configuration := range configurations.Value {
+		if isEnvironmentCheck(&configuration, environmentID) {
+			checks = append(checks, configuration)
+		}
+	}
+	return checks, nil
+}
+
+// writeCheckError writes the response for an error returned while managing a check
+func (h *baseHandler) writeCheckError(w http.ResponseWriter, err error, action, environmentID, checkID string) {
+	if errors.Is(err, errCheckNotFound) {
+		h.WriteErrorResponse(w, http.StatusNotFound, fmt.Sprintf("Check with ID %s not found in environment %s", checkID, environmentID))
+		return
+	}
+	h.WriteAzureDevOpsError(w, err, action, fmt.Sprintf("Check with ID %s not found in environment %s", checkID, environmentID))
+}
+
+// readCheckRequest reads and validates the check in the request body
+func (h *baseHandler) readCheckRequest(w http.ResponseWriter, r *http.Request) (*CheckRequest, bool) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Failed to read request body")
+		return nil, false
+	}
+
+	var checkRequest CheckRequest
+	if err := json.Unmarshal(body, &checkRequest); err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Invalid JSON in request body")
+		return nil, false
+	}
+	if err := validateCheckRequest(&checkRequest); err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid check: %v", err))
+		return nil, false
+	}
+
+	return &checkRequest, true
+}
+
+// writeCheckConfigurationResponse decodes a check configuration returned by Azure DevOps and writes it as a check
+func (h *baseHandler) writeCheckConfigurationResponse(w http.ResponseWriter, statusCode int, body []byte) {
+	var configuration CheckConfiguration
+	if err := json.Unmarshal(body, &configuration); err != nil {
+		h.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Failed to unmarshal check configuration: %v", err))
+		return
+	}
+	h.WriteObjectResponse(w, statusCode, CheckResponse(*mapCheckFromAzure(&configuration)))
+}
+
+// LIST handler implementation
+// @Summary List the checks of an environment
+// @Description List the approvals and checks of an environment. Checks are sorted by type and ID, approval, branch control and business hours checks are returned with normalized settings.
+// @ID list-environment-checks
+// @Param organization path string true "Organization name"
+// @Param project path string true "Project name or ID"
+// @Param environmentId path string true "Environment ID"
+// @Param api-version query string true "API version (e.g., 7.2-preview.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Produce json
+// @Success 200 {object} CheckListResponse "Checks of the environment"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{project}/environments/{environmentId}/checks [get]
+func (h *listChecksHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	project := r.PathValue("project")
+	environmentID := r.PathValue("environmentId")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, project, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	h.Log.Printf("Listing checks of environment %s for organization %s and project %s", environmentID, organization, project)
+
+	configurations, err := h.listEnvironmentChecks(organization, project, environmentID, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "list checks", fmt.Sprintf("Environment with ID %s not found", environmentID))
+		return
+	}
+
+	response := CheckListResponse{Value: []Check{}}
+	for i := range configurations {
+		response.Value = append(response.Value, *mapCheckFromAzure(&configurations[i]))
+	}
+	sortChecks(response.Value)
+	response.Count = len(response.Value)
+
+	h.WriteObjectResponse(w, http.StatusOK, response)
+	h.Log.Printf("Successfully listed %d checks of environment %s", response.Count, environmentID)
+}
+
+// GET handler implementation
+// @Summary Get a check of an environment
+// @Description Get an approval or check of an environment
+// @ID get-environment-check
+// @Param organization path string true "Organization name"
+// @Param project path string true "Project name or ID"
+// @Param environmentId path string true "Environment ID"
+// @Param checkId path string true "Check ID"
+// @Param api-version query string true "API version (e.g., 7.2-preview.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Produce json
+// @Success 200 {object} CheckResponse "Check details"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{project}/environments/{environmentId}/checks/{checkId} [get]
+func (h *getCheckHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	project := r.PathValue("project")
+	environmentID := r.PathValue("environmentId")
+	checkID := r.PathValue("checkId")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, project, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	h.Log.Printf("Getting check %s of environment %s for organization %s and project %s", checkID, environmentID, organization, project)
+
+	configuration, err := h.getEnvironmentCheck(organization, project, environmentID, checkID, apiVersion, authHeader)
+	if err != nil {
+		h.writeCheckError(w, err, "get check", environmentID, checkID)
+		return
+	}
+
+	h.WriteObjectResponse(w, http.StatusOK, CheckResponse(*mapCheckFromAzure(configuration)))
+	h.Log.Printf("Successfully retrieved check %s of environment %s", checkID, environmentID)
+}
+
+// POST handler implementation
+// @Summary Add a check to an environment
+// @Description Add an approval, branch control or business hours check to an environment. If the environment already has a check of the same kind (same type and, for task checks, same task), it is updated instead of adding another one.
+// @ID post-environment-check
+// @Param organization path string true "Organization name"
+// @Param project path string true "Project name or ID"
+// @Param environmentId path string true "Environment ID"
+// @Param api-version query string true "API version (e.g., 7.2-preview.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Param check body CheckRequest true "Check creation request body"
+// @Accept json
+// @Produce json
+// @Success 200 {object} CheckResponse "Existing check updated"
+// @Success 201 {object} CheckResponse "Created check"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{project}/environments/{environmentId}/checks [post]
+func (h *postCheckHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	project := r.PathValue("project")
+	environmentID := r.PathValue("environmentId")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, project, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	checkRequest, ok := h.readCheckRequest(w, r)
+	if !ok {
+		return
+	}
+
+	h.Log.Printf("Adding %s check to environment %s for organization %s and project %s", checkRequest.Type, environmentID, organization, project)
+
+	configuration, err := mapCheckToAzure(checkRequest, environmentID)
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid check: %v", err))
+		return
+	}
+
+	// A retried request must not add the same check twice, so an existing check of the same kind is updated instead
+	existing, err := h.listEnvironmentChecks(organization, project, environmentID, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "list checks", fmt.Sprintf("Environment with ID %s not found", environmentID))
+		return
+	}
+	if current := findMatchingCheck(existing, configuration); current != nil {
+		h.Log.Printf("Environment %s already has a %s check with ID %d, updating it", environmentID, checkRequest.Type, current.ID)
+		configuration.ID = current.ID
+		configuration.Version = current.Version
+	}
+
+	requestBody, err := json.Marshal(configuration)
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Failed to marshal check configuration: %v", err))
+		return
+	}
+
+	if configuration.ID != 0 {
+		checkID := strconv.Itoa(int(configuration.ID))
+		url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/pipelines/checks/configurations/%s?api-version=%s", organization, project, checkID, apiVersion)
+		responseBody, err := h.DoAzureDevOpsRequest("PATCH", url, authHeader, requestBody)
+		if err != nil {
+			h.writeCheckError(w, err, "update check", environmentID, checkID)
+			return
+		}
+
+		h.writeCheckConfigurationResponse(w, http.StatusOK, responseBody)
+		h.Log.Printf("Successfully updated %s check %s of environment %s", checkRequest.Type, checkID, environmentID)
+		return
+	}
+
+	url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/pipelines/checks/configurations?api-version=%s", organization, project, apiVersion)
+	responseBody, err := h.DoAzureDevOpsRequest("POST", url, authHeader, requestBody)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "create check", fmt.Sprintf("Environment with ID %s not found", environmentID))
+		return
+	}
+
+	h.writeCheckConfigurationResponse(w, http.StatusCreated, responseBody)
+	h.Log.Printf("Successfully added %s check to environment %s", checkRequest.Type, environmentID)
+}
+
+// PUT handler implementation
+// @Summary Update a check of an environment
+// @Description Replace the settings of an approval, branch control or business hours check. The type of the check cannot be changed.
+// @ID put-environment-check
+// @Param organization path string true "Organization name"
+// @Param project path string true "Project name or ID"
+// @Param environmentId path string true "Environment ID"
+// @Param checkId path string true "Check ID"
+// @Param api-version query string true "API version (e.g., 7.2-preview.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Param check body CheckRequest true "Check update request body"
+// @Accept json
+// @Produce json
+// @Success 200 {object} CheckResponse "Updated check"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{project}/environments/{environmentId}/checks/{checkId} [put]
+func (h *putCheckHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	project := r.PathValue("project")
+	environmentID := r.PathValue("environmentId")
+	checkID := r.PathValue("checkId")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, project, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	checkRequest, ok := h.readCheckRequest(w, r)
+	if !ok {
+		return
+	}
+
+	h.Log.Printf("Updating check %s of environment %s for organization %s and project %s", checkID, environmentID, organization, project)
+
+	current, err := h.getEnvironmentCheck(organization, project, environmentID, checkID, apiVersion, authHeader)
+	if err != nil {
+		h.writeCheckError(w, err, "get check", environmentID, checkID)
+		return
+	}
+	if currentType := mapCheckFromAzure(current).Type; currentType != checkRequest.Type {
+		h.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid check: type cannot be changed from '%s' to '%s'", currentType, checkRequest.Type))
+		return
+	}
+
+	configuration, err := mapCheckToAzure(checkRequest, environmentID)
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid check: %v", err))
+		return
+	}
+	configuration.ID = current.ID
+	configuration.Version = current.Version
+
+	requestBody, err := json.Marshal(configuration)
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Failed to marshal check configuration: %v", err))
+		return
+	}
+
+	url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/pipelines/checks/configurations/%s?api-version=%s", organization, project, checkID, apiVersion)
+	responseBody, err := h.DoAzureDevOpsRequest("PATCH", url, authHeader, requestBody)
+	if err != nil {
+		h.writeCheckError(w, err, "update check", environmentID, checkID)
+		return
+	}
+
+	h.writeCheckConfigurationResponse(w, http.StatusOK, responseBody)
+	h.Log.Printf("Successfully updated check %s of environment %s", checkID, environmentID)
+}
+
+// DELETE handler implementation
+// @Summary Delete a check of an environment
+// @Description Delete an approval or check of an environment
+// @ID delete-environment-check
+// @Param organization path string true "Organization name"
+// @Param project path string true "Project name or ID"
+// @Param environmentId path string true "Environment ID"
+// @Param checkId path string true "Check ID"
+// @Param api-version query string true "API version (e.g., 7.2-preview.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Success 204 "No Content"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{project}/environments/{environmentId}/checks/{checkId} [delete]
+func (h *deleteCheckHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	project := r.PathValue("project")
+	environmentID := r.PathValue("environmentId")
+	checkID := r.PathValue("checkId")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, project, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	h.Log.Printf("Deleting check %s of environment %s for organization %s and project %s", checkID, environmentID, organization, project)
+
+	// Make sure the check protects the environment before deleting it
+	if _, err := h.getEnvironmentCheck(organization, project, environmentID, checkID, apiVersion, authHeader); err != nil {
+		h.writeCheckError(w, err, "get check", environmentID, checkID)
+		return
+	}
+
+	url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/pipelines/checks/configurations/%s?api-version=%s", organization, project, checkID, apiVersion)
+	if _, err := h.DoAzureDevOpsRequest("DELETE", url, authHeader, nil); err != nil {
+		h.writeCheckError(w, err, "delete check", environmentID, checkID)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	h.Log.Printf("Successfully deleted check %s of environment %s", checkID, environmentID)
+}
@@ -0,0 +1,316 @@
+package environment
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/handlerstest"
+)
+
+const testCheckID = "42"
+
+var (
+	checksURL          = fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/pipelines/checks/configurations?api-version=%s", testOrg, testProject, testAPIVersion)
+	checksListURL      = fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/pipelines/checks/configurations?resourceType=environment&resourceId=%s&$expand=settings&api-version=%s", testOrg, testProject, testEnvironmentID, testAPIVersion)
+	checkURL           = fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/pipelines/checks/configurations/%s?api-version=%s", testOrg, testProject, testCheckID, testAPIVersion)
+	checkExpandURL     = fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/pipelines/checks/configurations/%s?$expand=settings&api-version=%s", testOrg, testProject, testCheckID, testAPIVersion)
+	approvalCheckResp  = `{"id":42,"version":3,"type":{"id":"8C6F20A7-A545-4486-9777-F762FAFE0D4D","name":"Approval"},"settings":{"approvers":[{"id":"B-USER","displayName":"B"},{"id":"a-user","displayName":"A"}],"executionOrder":1,"instructions":"Check","minRequiredApprovers":1,"requesterCannotBeApprover":true},"resource":{"type":"environment","id":"7"},"timeout":43200}`
+	otherEnvCheckResp  = `{"id":42,"version":3,"type":{"id":"8c6f20a7-a545-4486-9777-f762fafe0d4d","name":"Approval"},"settings":{"approvers":[{"id":"a-user"}],"executionOrder":1},"resource":{"type":"environment","id":"8"},"timeout":43200}`
+	branchControlResp  = `{"id":40,"type":{"id":"fe1de3ee-a436-41b4-bb20-f6eb4cb879a7","name":"Task Check"},"settings":{"definitionRef":{"id":"86b05a0c-73e6-4f7d-b3cf-e38f3b39a75b","name":"evaluatebranchProtection","version":"0.0.1"},"displayName":"Branch control","inputs":{"allowedBranches":"refs/heads/release/*, refs/heads/main","ensureProtectionOfBranch":"true","allowUnknownStatusBranch":"false"},"retryInterval":5},"resource":{"type":"environment","id":"7"},"timeout":1440}`
+	businessHoursResp  = `{"id":41,"type":{"id":"fe1de3ee-a436-41b4-bb20-f6eb4cb879a7","name":"Task Check"},"settings":{"definitionRef":{"id":"445fde2f-6c39-441c-807f-8a59ff2e075f","name":"evaluateBusinessHours","version":"0.0.1"},"displayName":"Business Hours","inputs":{"businessDays":"Friday,Monday","timeZone":"UTC","startTime":"09:00","endTime":"17:00"},"retryInterval":5},"resource":{"type":"environment","id":"7"},"timeout":1440}`
+	approvalCheckInput = `{"type":"approval","approval":{"approvers":["B-USER","a-user"],"minRequiredApprovers":1,"instructions":"Check","requesterCannotBeApprover":true}}`
+)
+
+// checkPathValues returns the path values of the check endpoints
+func checkPathValues() map[string]string {
+	return map[string]string{"organization": testOrg, "project": testProject, "environmentId": testEnvironmentID, "checkId": testCheckID}
+}
+
+// Test check list handler
+func TestListChecksHandler_ServeHTTP(t *testing.T) {
+	runEnvironmentTests(t, "GET", func(b *baseHandler) http.Handler { return &listChecksHandler{baseHandler: b} }, []environmentTestCase{
+		{
+			name:       "checks sorted and normalized",
+			pathValues: checkPathValues(),
+			apiVersion: testAPIVersion,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(checksListURL, http.StatusOK, fmt.Sprintf(`{"count":4,"value":[%s,%s,%s,%s]}`, businessHoursResp, approvalCheckResp, otherEnvCheckResp, branchControlResp))
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBody:         `{"count":3,"value":[{"id":42,"type":"approval","timeout":43200,"approval":{"approvers":["a-user","b-user"],"minRequiredApprovers":1,"executionOrder":"anyOrder","instructions":"Check","requesterCannotBeApprover":true}},{"id":40,"type":"branchControl","timeout":1440,"branchControl":{"allowedBranches":["refs/heads/main","refs/heads/release/*"],"ensureProtectionOfBranch":true,"allowUnknownStatusBranch":false}},{"id":41,"type":"businessHours","timeout":1440,"businessHours":{"days":["monday","friday"],"timeZone":"UTC","startTime":"09:00","endTime":"17:00"}}]}`,
+			expectedRequestCount: 1,
+		},
+		{
+			name:       "no checks",
+			pathValues: checkPathValues(),
+			apiVersion: testAPIVersion,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(checksListURL, http.StatusOK, `{"count":0,"value":[]}`)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBody:         `{"count":0,"value":[]}`,
+			expectedRequestCount: 1,
+		},
+	})
+}
+
+// Test check GET handler
+func TestGetCheckHandler_ServeHTTP(t *testing.T) {
+	runEnvironmentTests(t, "GET", func(b *baseHandler) http.Handler { return &getCheckHandler{baseHandler: b} }, []environmentTestCase{
+		{
+			name:                 "successful retrieval",
+			pathValues:           checkPathValues(),
+			apiVersion:           testAPIVersion,
+			setupMock:            func(m *handlerstest.MockHTTPClient) { m.SetResponse(checkExpandURL, http.StatusOK, approvalCheckResp) },
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `"approvers":["a-user","b-user"]`,
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "check of another environment",
+			pathValues:           checkPathValues(),
+			apiVersion:           testAPIVersion,
+			setupMock:            func(m *handlerstest.MockHTTPClient) { m.SetResponse(checkExpandURL, http.StatusOK, otherEnvCheckResp) },
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Check with ID 42 not found in environment 7",
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "check not found",
+			pathValues:           checkPathValues(),
+			apiVersion:           testAPIVersion,
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Check with ID 42 not found in environment 7",
+			expectedRequestCount: 1,
+		},
+	})
+}
+
+// Test check POST handler
+func TestPostCheckHandler_ServeHTTP(t *testing.T) {
+	runEnvironmentTests(t, "POST", func(b *baseHandler) http.Handler { return &postCheckHandler{baseHandler: b} }, []environmentTestCase{
+		{
+			name:       "successful creation of an approval check",
+			pathValues: checkPathValues(),
+			apiVersion: testAPIVersion,
+			body:       approvalCheckInput,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(checksListURL, http.StatusOK, `{"count":0,"value":[]}`)
+				m.SetResponse(checksURL, http.StatusOK, approvalCheckResp)
+			},
+			expectedStatus:       http.StatusCreated,
+			expectedBodyContains: `"type":"approval"`,
+			expectedRequestCount: 2,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				body, _ := io.ReadAll(m.Requests[1].Body)
+				want := `{"type":{"id":"8c6f20a7-a545-4486-9777-f762fafe0d4d","name":"Approval"},"settings":{"approvers":[{"id":"a-user"},{"id":"b-user"}],"blockedApprovers":[],"executionOrder":1,"instructions":"Check","minRequiredApprovers":1,"requesterCannotBeApprover":true},"resource":{"type":"environment","id":"7"},"timeout":43200}`
+				if string(body) != want {
+					t.Errorf("Request body = %s, want %s", string(body), want)
+				}
+			},
+		},
+		{
+			name:       "existing check of the same type is updated",
+			pathValues: checkPathValues(),
+			apiVersion: testAPIVersion,
+			body:       approvalCheckInput,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(checksListURL, http.StatusOK, fmt.Sprintf(`{"count":3,"value":[%s,%s,%s]}`, branchControlResp, approvalCheckResp, otherEnvCheckResp))
+				m.SetMethodResponse("PATCH", checkURL, http.StatusOK, approvalCheckResp)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `"id":42`,
+			expectedRequestCount: 2,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				if m.Requests[1].Method != "PATCH" {
+					t.Errorf("Second request method = %s, want PATCH", m.Requests[1].Method)
+				}
+				if !strings.HasPrefix(m.Bodies[1], `{"id":42,"version":3,`) {
+					t.Errorf("Request body = %s, want it to start with ID and version", m.Bodies[1])
+				}
+			},
+		},
+		{
+			name:       "task check running another task is not updated",
+			pathValues: checkPathValues(),
+			apiVersion: testAPIVersion,
+			body:       `{"type":"businessHours","businessHours":{"days":["monday"],"startTime":"09:00","endTime":"17:00"}}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(checksListURL, http.StatusOK, fmt.Sprintf(`{"count":1,"value":[%s]}`, branchControlResp))
+				m.SetResponse(checksURL, http.StatusOK, businessHoursResp)
+			},
+			expectedStatus:       http.StatusCreated,
+			expectedBodyContains: `"type":"businessHours"`,
+			expectedRequestCount: 2,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				if m.Requests[1].Method != "POST" {
+					t.Errorf("Second request method = %s, want POST", m.Requests[1].Method)
+				}
+			},
+		},
+		{
+			name:                 "environment not found",
+			pathValues:           checkPathValues(),
+			apiVersion:           testAPIVersion,
+			body:                 approvalCheckInput,
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Environment with ID 7 not found",
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "unsupported check type",
+			pathValues:           checkPathValues(),
+			apiVersion:           testAPIVersion,
+			body:                 `{"type":"invokeRestApi"}`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBodyContains: "Invalid check: check type 'invokeRestApi' is not supported",
+			expectedRequestCount: 0,
+		},
+	})
+}
+
+// Test check PUT handler
+func TestPutCheckHandler_ServeHTTP(t *testing.T) {
+	runEnvironmentTests(t, "PUT", func(b *baseHandler) http.Handler { return &putCheckHandler{baseHandler: b} }, []environmentTestCase{
+		{
+			name:       "successful update sends ID and version",
+			pathValues: checkPathValues(),
+			apiVersion: testAPIVersion,
+			body:       approvalCheckInput,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(checkExpandURL, http.StatusOK, approvalCheckResp)
+				m.SetMethodResponse("PATCH", checkURL, http.StatusOK, approvalCheckResp)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `"id":42`,
+			expectedRequestCount: 2,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				body, _ := io.ReadAll(m.Requests[1].Body)
+				if !strings.HasPrefix(string(body), `{"id":42,"version":3,`) {
+					t.Errorf("Request body = %s, want it to start with ID and version", string(body))
+				}
+			},
+		},
+		{
+			name:                 "type cannot be changed",
+			pathValues:           checkPathValues(),
+			apiVersion:           testAPIVersion,
+			body:                 `{"type":"branchControl","branchControl":{"allowedBranches":["refs/heads/main"]}}`,
+			setupMock:            func(m *handlerstest.MockHTTPClient) { m.SetResponse(checkExpandURL, http.StatusOK, approvalCheckResp) },
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid check: type cannot be changed from 'approval' to 'branchControl'",
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "check of another environment",
+			pathValues:           checkPathValues(),
+			apiVersion:           testAPIVersion,
+			body:                 approvalCheckInput,
+			setupMock:            func(m *handlerstest.MockHTTPClient) { m.SetResponse(checkExpandURL, http.StatusOK, otherEnvCheckResp) },
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Check with ID 42 not found in environment 7",
+			expectedRequestCount: 1,
+		},
+	})
+}
+
+// Test check DELETE handler
+func TestDeleteCheckHandler_ServeHTTP(t *testing.T) {
+	runEnvironmentTests(t, "DELETE", func(b *baseHandler) http.Handler { return &deleteCheckHandler{baseHandler: b} }, []environmentTestCase{
+		{
+			name:       "successful deletion",
+			pathValues: checkPathValues(),
+			apiVersion: testAPIVersion,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(checkExpandURL, http.StatusOK, approvalCheckResp)
+				m.SetMethodResponse("DELETE", checkURL, http.StatusNoContent, "")
+			},
+			expectedStatus:       http.StatusNoContent,
+			expectedRequestCount: 2,
+		},
+		{
+			name:                 "check of another environment is not deleted",
+			pathValues:           checkPathValues(),
+			apiVersion:           testAPIVersion,
+			setupMock:            func(m *handlerstest.MockHTTPClient) { m.SetResponse(checkExpandURL, http.StatusOK, otherEnvCheckResp) },
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Check with ID 42 not found in environment 7",
+			expectedRequestCount: 1,
+		},
+	})
+}
+
+func TestValidateCheckRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		check       string
+		errContains string
+	}{
+		{name: "valid approval", check: approvalCheckInput},
+		{name: "valid branch control", check: `{"type":"branchControl","branchControl":{"allowedBranches":["refs/heads/main"]}}`},
+		{name: "valid business hours", check: `{"type":"businessHours","businessHours":{"days":["Monday"],"startTime":"09:00","endTime":"17:00"}}`},
+		{name: "missing settings", check: `{"type":"approval"}`, errContains: "approval settings are required"},
+		{name: "settings of another type", check: `{"type":"approval","approval":{"approvers":["a"]},"branchControl":{"allowedBranches":["refs/heads/main"]}}`, errContains: "only the settings matching the check type"},
+		{name: "too many required approvers", check: `{"type":"approval","approval":{"approvers":["a"],"minRequiredApprovers":2}}`, errContains: "minRequiredApprovers"},
+		{name: "invalid execution order", check: `{"type":"approval","approval":{"approvers":["a"],"executionOrder":"random"}}`, errContains: "executionOrder"},
+		{name: "branch with comma", check: `{"type":"branchControl","branchControl":{"allowedBranches":["refs/heads/a,refs/heads/b"]}}`, errContains: "invalid branch"},
+		{name: "unknown day", check: `{"type":"businessHours","businessHours":{"days":["someday"],"startTime":"09:00","endTime":"17:00"}}`, errContains: "unknown day"},
+		{name: "invalid time", check: `{"type":"businessHours","businessHours":{"days":["monday"],"startTime":"9:00","endTime":"17:00"}}`, errContains: "startTime must have the format"},
+		{name: "start after end", check: `{"type":"businessHours","businessHours":{"days":["monday"],"startTime":"18:00","endTime":"17:00"}}`, errContains: "must be before"},
+		{name: "negative timeout", check: `{"type":"approval","timeout":-1,"approval":{"approvers":["a"]}}`, errContains: "timeout"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var check CheckRequest
+			if err := json.Unmarshal([]byte(tt.check), &check); err != nil {
+				t.Fatalf("Failed to unmarshal check: %v", err)
+			}
+			err := validateCheckRequest(&check)
+			if tt.errContains == "" {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+				t.Errorf("Error = %v, want it to contain %s", err, tt.errContains)
+			}
+		})
+	}
+}
+
+func TestMapCheckRoundTrip(t *testing.T) {
+	checks := []string{
+		`{"type":"approval","timeout":60,"approval":{"approvers":["b","a"],"minRequiredApprovers":1,"executionOrder":"inSequence"}}`,
+		`{"type":"branchControl","branchControl":{"allowedBranches":["refs/heads/release/*","refs/heads/main"],"ensureProtectionOfBranch":true}}`,
+		`{"type":"businessHours","businessHours":{"days":["sunday","Monday"],"startTime":"08:30","endTime":"18:00"}}`,
+	}
+	expected := []Check{
+		{Type: CheckTypeApproval, Timeout: 60, Approval: &ApprovalCheck{Approvers: []string{"b", "a"}, MinRequiredApprovers: 1, ExecutionOrder: ExecutionOrderInSequence}},
+		{Type: CheckTypeBranchControl, Timeout: DefaultCheckTimeout, BranchControl: &BranchControlCheck{AllowedBranches: []string{"refs/heads/main", "refs/heads/release/*"}, EnsureProtectionOfBranch: true}},
+		{Type: CheckTypeBusinessHours, Timeout: DefaultCheckTimeout, BusinessHours: &BusinessHoursCheck{Days: []string{"monday", "sunday"}, TimeZone: "UTC", StartTime: "08:30", EndTime: "18:00"}},
+	}
+
+	for i, input := range checks {
+		var check CheckRequest
+		if err := json.Unmarshal([]byte(input), &check); err != nil {
+			t.Fatalf("Failed to unmarshal check: %v", err)
+		}
+		configuration, err := mapCheckToAzure(&check, testEnvironmentID)
+		if err != nil {
+			t.Fatalf("mapCheckToAzure() error = %v", err)
+		}
+		if !isEnvironmentCheck(configuration, testEnvironmentID) {
+			t.Errorf("Check %d does not protect environment %s", i, testEnvironmentID)
+		}
+		if got := mapCheckFromAzure(configuration); !reflect.DeepEqual(*got, expected[i]) {
+			t.Errorf("Round trip of check %d = %+v, want %+v", i, *got, expected[i])
+		}
+	}
+}
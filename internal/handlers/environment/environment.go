@@ -0,0 +1,310 @@
+package environment
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers"
+)
+
+// Handler constructors
+func GetEnvironment(opts handlers.HandlerOptions) handlers.Handler {
+	return &getHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func PostEnvironment(opts handlers.HandlerOptions) handlers.Handler {
+	return &postHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func PutEnvironment(opts handlers.HandlerOptions) handlers.Handler {
+	return &putHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func DeleteEnvironment(opts handlers.HandlerOptions) handlers.Handler {
+	return &deleteHandler{baseHandler: newBaseHandler(opts)}
+}
+
+// Interface compliance verification
+var _ handlers.Handler = &getHandler{}
+var _ handlers.Handler = &postHandler{}
+var _ handlers.Handler = &putHandler{}
+var _ handlers.Handler = &deleteHandler{}
+
+// Base handler with common functionality
+type baseHandler struct {
+	*handlers.BaseHandler
+}
+
+// Constructor for the base handler
+func newBaseHandler(opts handlers.HandlerOptions) *baseHandler {
+	return &baseHandler{BaseHandler: handlers.NewBaseHandler(opts)}
+}
+
+// Handler types embedding the base handler
+type getHandler struct {
+	*baseHandler
+}
+
+type postHandler struct {
+	*baseHandler
+}
+
+type putHandler struct {
+	*baseHandler
+}
+
+type deleteHandler struct {
+	*baseHandler
+}
+
+// getEnvironment retrieves an environment together with its resources
+func (h *baseHandler) getEnvironment(organization, project, environmentID, apiVersion, authHeader string) (*Environment, error) {
+	url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/pipelines/environments/%s?expands=resourceReferences&api-version=%s", organization, project, environmentID, apiVersion)
+
+	body, err := h.DoAzureDevOpsRequest("GET", url, authHeader, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeEnvironment(body)
+}
+
+// decodeEnvironment decodes an Azure DevOps environment and normalizes it
+func decodeEnvironment(body []byte) (*Environment, error) {
+	var instance EnvironmentInstance
+	if err := json.Unmarshal(body, &instance); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal environment: %w", err)
+	}
+	return mapEnvironmentFromAzure(&instance), nil
+}
+
+// GET handler implementation
+// @Summary Get an environment
+// @Description Get an environment with the resources attached to it. Audit fields are not returned and resources are sorted by type and ID.
+// @ID get-environment
+// @Param organization path string true "Organization name"
+// @Param project path string true "Project name or ID"
+// @Param id path string true "Environment ID"
+// @Param api-version query string true "API version (e.g., 7.2-preview.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Produce json
+// @Success 200 {object} EnvironmentResponse "Environment details"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{project}/environments/{id} [get]
+func (h *getHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	project := r.PathValue("project")
+	id := r.PathValue("id")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, project, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	h.Log.Printf("Getting environment with ID %s for organization %s and project %s", id, organization, project)
+
+	environment, err := h.getEnvironment(organization, project, id, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "get environment", fmt.Sprintf("Environment with ID %s not found", id))
+		return
+	}
+
+	h.WriteObjectResponse(w, http.StatusOK, EnvironmentResponse(*environment))
+	h.Log.Printf("Successfully retrieved environment with ID %s", id)
+}
+
+// POST handler implementation
+// @Summary Create an environment
+// @Description Create an environment in the specified project
+// @ID post-environment
+// @Param organization path string true "Organization name"
+// @Param project path string true "Project name or ID"
+// @Param api-version query string true "API version (e.g., 7.2-preview.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Param environment body CreateEnvironmentRequest true "Environment creation request body"
+// @Accept json
+// @Produce json
+// @Success 201 {object} EnvironmentResponse "Created environment"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{project}/environments [post]
+func (h *postHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	project := r.PathValue("project")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, project, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Failed to read request body")
+		return
+	}
+
+	var createRequest CreateEnvironmentRequest
+	if err := json.Unmarshal(body, &createRequest); err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Invalid JSON in request body")
+		return
+	}
+	if strings.TrimSpace(createRequest.Name) == "" {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Environment name is required")
+		return
+	}
+
+	h.Log.Printf("Creating environment %s for organization %s and project %s", createRequest.Name, organization, project)
+
+	requestBody, err := json.Marshal(createRequest)
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Failed to marshal environment: %v", err))
+		return
+	}
+
+	url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/pipelines/environments?api-version=%s", organization, project, apiVersion)
+	responseBody, err := h.DoAzureDevOpsRequest("POST", url, authHeader, requestBody)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "create environment", fmt.Sprintf("Project %s not found", project))
+		return
+	}
+
+	environment, err := decodeEnvironment(responseBody)
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	h.WriteObjectResponse(w, http.StatusCreated, EnvironmentResponse(*environment))
+	h.Log.Printf("Successfully created environment with ID %d", environment.ID)
+}
+
+// PUT handler implementation
+// @Summary Update an environment
+// @Description Update name and description of an environment
+// @ID put-environment
+// @Param organization path string true "Organization name"
+// @Param project path string true "Project name or ID"
+// @Param id path string true "Environment ID"
+// @Param api-version query string true "API version (e.g., 7.2-preview.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Param environment body UpdateEnvironmentRequest true "Environment update request body"
+// @Accept json
+// @Produce json
+// @Success 200 {object} EnvironmentResponse "Updated environment"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{project}/environments/{id} [put]
+func (h *putHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	project := r.PathValue("project")
+	id := r.PathValue("id")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, project, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Failed to read request body")
+		return
+	}
+
+	var updateRequest UpdateEnvironmentRequest
+	if err := json.Unmarshal(body, &updateRequest); err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Invalid JSON in request body")
+		return
+	}
+	if strings.TrimSpace(updateRequest.Name) == "" {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Environment name is required")
+		return
+	}
+
+	h.Log.Printf("Updating environment with ID %s for organization %s and project %s", id, organization, project)
+
+	requestBody, err := json.Marshal(updateRequest)
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Failed to marshal environment: %v", err))
+		return
+	}
+
+	notFound := fmt.Sprintf("Environment with ID %s not found", id)
+
+	url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/pipelines/environments/%s?api-version=%s", organization, project, id, apiVersion)
+	if _, err := h.DoAzureDevOpsRequest("PATCH", url, authHeader, requestBody); err != nil {
+		h.WriteAzureDevOpsError(w, err, "update environment", notFound)
+		return
+	}
+
+	// The update response does not include the resources, read the environment again
+	environment, err := h.getEnvironment(organization, project, id, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "get updated environment", notFound)
+		return
+	}
+
+	h.WriteObjectResponse(w, http.StatusOK, EnvironmentResponse(*environment))
+	h.Log.Printf("Successfully updated environment with ID %s", id)
+}
+
+// DELETE handler implementation
+// @Summary Delete an environment
+// @Description Delete an environment
+// @ID delete-environment
+// @Param organization path string true "Organization name"
+// @Param project path string true "Project name or ID"
+// @Param id path string true "Environment ID"
+// @Param api-version query string true "API version (e.g., 7.2-preview.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Success 204 "No Content"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{project}/environments/{id} [delete]
+func (h *deleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	project := r.PathValue("project")
+	id := r.PathValue("id")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, project, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	h.Log.Printf("Deleting environment with ID %s for organization %s and project %s", id, organization, project)
+
+	url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/pipelines/environments/%s?api-version=%s", organization, project, id, apiVersion)
+	if _, err := h.DoAzureDevOpsRequest("DELETE", url, authHeader, nil); err != nil {
+		h.WriteAzureDevOpsError(w, err, "delete environment", fmt.Sprintf("Environment with ID %s not found", id))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	h.Log.Printf("Successfully deleted environment with ID %s", id)
+}
@@ -0,0 +1,330 @@
+package environment
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers"
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/handlerstest"
+	"github.com/rs/zerolog"
+)
+
+// newTestBaseHandler creates a base handler for testing with a mock client
+func newTestBaseHandler(mockClient *handlerstest.MockHTTPClient) *baseHandler {
+	return newBaseHandler(handlerstest.NewHandlerOptions(mockClient))
+}
+
+// Test data constants
+const (
+	testOrg           = "testorg"
+	testProject       = "testproject"
+	testEnvironmentID = "7"
+	testAPIVersion    = "7.2-preview.1"
+	testAuthHeader    = "Basic dGVzdDp0ZXN0"
+	testUsername      = "test"
+	testPassword      = "test"
+)
+
+var (
+	environmentsURL      = fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/pipelines/environments?api-version=%s", testOrg, testProject, testAPIVersion)
+	environmentURL       = fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/pipelines/environments/%s?api-version=%s", testOrg, testProject, testEnvironmentID, testAPIVersion)
+	environmentExpandURL = fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/pipelines/environments/%s?expands=resourceReferences&api-version=%s", testOrg, testProject, testEnvironmentID, testAPIVersion)
+
+	validEnvironmentResp   = `{"id":7,"name":"production","description":"Production","createdBy":{"id":"user-id"},"createdOn":"2025-06-30T14:33:02.06Z","lastModifiedOn":"2025-07-01T10:00:00Z","project":{"id":"project-id"},"resources":[{"id":12,"name":"web","type":"kubernetes","tags":["b","a"]},{"id":3,"name":"api","type":"kubernetes"},{"id":5,"name":"vm","type":"virtualMachine"}]}`
+	createdEnvironmentResp = `{"id":7,"name":"production","description":"Production","createdOn":"2025-06-30T14:33:02.06Z","project":{"id":"project-id"}}`
+)
+
+// environmentTestCase is a test case for the handlers of this package
+type environmentTestCase struct {
+	name                 string
+	pathValues           map[string]string
+	apiVersion           string
+	noAuth               bool
+	body                 string
+	setupMock            func(*handlerstest.MockHTTPClient)
+	expectedStatus       int
+	expectedBody         string
+	expectedBodyContains string
+	expectedRequestCount int
+	verify               func(t *testing.T, mockClient *handlerstest.MockHTTPClient)
+}
+
+// runEnvironmentTests runs the given test cases against the handler built by newHandler
+func runEnvironmentTests(t *testing.T, method string, newHandler func(*baseHandler) http.Handler, tests []environmentTestCase) {
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := handlerstest.NewMockHTTPClient()
+			if tt.setupMock != nil {
+				tt.setupMock(mockClient)
+			}
+			handler := newHandler(newTestBaseHandler(mockClient))
+
+			url := "/api/test"
+			if tt.apiVersion != "" {
+				url += "?api-version=" + tt.apiVersion
+			}
+			var body io.Reader
+			if tt.body != "" {
+				body = strings.NewReader(tt.body)
+			}
+			req := httptest.NewRequest(method, url, body)
+			for key, value := range tt.pathValues {
+				req.SetPathValue(key, value)
+			}
+			if !tt.noAuth {
+				req.SetBasicAuth(testUsername, testPassword)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("Status code = %d, want %d. Body: %s", rr.Code, tt.expectedStatus, rr.Body.String())
+			}
+			if tt.expectedBody != "" && rr.Body.String() != tt.expectedBody {
+				t.Errorf("Body = %s, want %s", rr.Body.String(), tt.expectedBody)
+			}
+			if tt.expectedBodyContains != "" && !strings.Contains(rr.Body.String(), tt.expectedBodyContains) {
+				t.Errorf("Body = %s, want it to contain %s", rr.Body.String(), tt.expectedBodyContains)
+			}
+			if mockClient.RequestCount() != tt.expectedRequestCount {
+				t.Errorf("Request count = %d, want %d", mockClient.RequestCount(), tt.expectedRequestCount)
+			}
+			if tt.verify != nil {
+				tt.verify(t, mockClient)
+			}
+		})
+	}
+}
+
+// environmentPathValues returns the path values of the environment endpoints
+func environmentPathValues() map[string]string {
+	return map[string]string{"organization": testOrg, "project": testProject, "id": testEnvironmentID}
+}
+
+// Test constructor functions
+func TestEnvironmentConstructors(t *testing.T) {
+	client := &http.Client{}
+	logger := zerolog.New(os.Stdout).With().Timestamp().Logger()
+	opts := handlers.HandlerOptions{
+		Client: client,
+		Log:    &logger,
+	}
+
+	constructors := map[string]func(handlers.HandlerOptions) handlers.Handler{
+		"GetEnvironment":           GetEnvironment,
+		"PostEnvironment":          PostEnvironment,
+		"PutEnvironment":           PutEnvironment,
+		"DeleteEnvironment":        DeleteEnvironment,
+		"GetKubernetesResource":    GetKubernetesResource,
+		"PostKubernetesResource":   PostKubernetesResource,
+		"DeleteKubernetesResource": DeleteKubernetesResource,
+		"ListEnvironmentChecks":    ListEnvironmentChecks,
+		"GetEnvironmentCheck":      GetEnvironmentCheck,
+		"PostEnvironmentCheck":     PostEnvironmentCheck,
+		"PutEnvironmentCheck":      PutEnvironmentCheck,
+		"DeleteEnvironmentCheck":   DeleteEnvironmentCheck,
+	}
+
+	for name, constructor := range constructors {
+		t.Run(name, func(t *testing.T) {
+			if h := constructor(opts); h == nil {
+				t.Fatalf("%s should return a non-nil handler", name)
+			}
+		})
+	}
+}
+
+// Test GET handler
+func TestGetHandler_ServeHTTP(t *testing.T) {
+	runEnvironmentTests(t, "GET", func(b *baseHandler) http.Handler { return &getHandler{baseHandler: b} }, []environmentTestCase{
+		{
+			name:       "successful retrieval with sorted resources and without audit fields",
+			pathValues: environmentPathValues(),
+			apiVersion: testAPIVersion,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(environmentExpandURL, http.StatusOK, validEnvironmentResp)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBody:         `{"id":7,"name":"production","description":"Production","project":{"id":"project-id"},"resources":[{"id":3,"name":"api","type":"kubernetes"},{"id":12,"name":"web","type":"kubernetes","tags":["a","b"]},{"id":5,"name":"vm","type":"virtualMachine"}]}`,
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "missing api version",
+			pathValues:           environmentPathValues(),
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "API version is required",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "missing basic auth",
+			pathValues:           environmentPathValues(),
+			apiVersion:           testAPIVersion,
+			noAuth:               true,
+			expectedStatus:       http.StatusUnauthorized,
+			expectedBody:         "Request rejected due to missing or invalid Basic authentication",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "environment not found",
+			pathValues:           environmentPathValues(),
+			apiVersion:           testAPIVersion,
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Environment with ID 7 not found",
+			expectedRequestCount: 1,
+		},
+		{
+			name:       "azure devops error forwarded",
+			pathValues: environmentPathValues(),
+			apiVersion: testAPIVersion,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(environmentExpandURL, http.StatusForbidden, `{"message":"Forbidden"}`)
+			},
+			expectedStatus:       http.StatusForbidden,
+			expectedBody:         `{"message":"Forbidden"}`,
+			expectedRequestCount: 1,
+		},
+		{
+			name:       "network error",
+			pathValues: environmentPathValues(),
+			apiVersion: testAPIVersion,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetError(environmentExpandURL, fmt.Errorf("connection refused"))
+			},
+			expectedStatus:       http.StatusInternalServerError,
+			expectedBodyContains: "Failed to get environment",
+			expectedRequestCount: 1,
+		},
+	})
+}
+
+// Test POST handler
+func TestPostHandler_ServeHTTP(t *testing.T) {
+	runEnvironmentTests(t, "POST", func(b *baseHandler) http.Handler { return &postHandler{baseHandler: b} }, []environmentTestCase{
+		{
+			name:       "successful creation",
+			pathValues: map[string]string{"organization": testOrg, "project": testProject},
+			apiVersion: testAPIVersion,
+			body:       `{"name":"production","description":"Production"}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(environmentsURL, http.StatusOK, createdEnvironmentResp)
+			},
+			expectedStatus:       http.StatusCreated,
+			expectedBody:         `{"id":7,"name":"production","description":"Production","project":{"id":"project-id"},"resources":[]}`,
+			expectedRequestCount: 1,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				body, _ := io.ReadAll(m.Requests[0].Body)
+				if string(body) != `{"name":"production","description":"Production"}` {
+					t.Errorf("Request body = %s", string(body))
+				}
+			},
+		},
+		{
+			name:                 "missing name",
+			pathValues:           map[string]string{"organization": testOrg, "project": testProject},
+			apiVersion:           testAPIVersion,
+			body:                 `{"description":"Production"}`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Environment name is required",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "invalid JSON",
+			pathValues:           map[string]string{"organization": testOrg, "project": testProject},
+			apiVersion:           testAPIVersion,
+			body:                 `{"name":`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid JSON in request body",
+			expectedRequestCount: 0,
+		},
+		{
+			name:       "environment already exists",
+			pathValues: map[string]string{"organization": testOrg, "project": testProject},
+			apiVersion: testAPIVersion,
+			body:       `{"name":"production"}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(environmentsURL, http.StatusConflict, `{"message":"Environment already exists"}`)
+			},
+			expectedStatus:       http.StatusConflict,
+			expectedBody:         `{"message":"Environment already exists"}`,
+			expectedRequestCount: 1,
+		},
+	})
+}
+
+// Test PUT handler
+func TestPutHandler_ServeHTTP(t *testing.T) {
+	runEnvironmentTests(t, "PUT", func(b *baseHandler) http.Handler { return &putHandler{baseHandler: b} }, []environmentTestCase{
+		{
+			name:       "successful update returns environment with resources",
+			pathValues: environmentPathValues(),
+			apiVersion: testAPIVersion,
+			body:       `{"name":"production","description":"Production"}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetMethodResponse("PATCH", environmentURL, http.StatusOK, createdEnvironmentResp)
+				m.SetResponse(environmentExpandURL, http.StatusOK, validEnvironmentResp)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `"resources":[{"id":3,"name":"api","type":"kubernetes"}`,
+			expectedRequestCount: 2,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				if m.Requests[0].Method != "PATCH" {
+					t.Errorf("First request method = %s, want PATCH", m.Requests[0].Method)
+				}
+			},
+		},
+		{
+			name:                 "environment not found",
+			pathValues:           environmentPathValues(),
+			apiVersion:           testAPIVersion,
+			body:                 `{"name":"production"}`,
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Environment with ID 7 not found",
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "missing name",
+			pathValues:           environmentPathValues(),
+			apiVersion:           testAPIVersion,
+			body:                 `{"name":" "}`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Environment name is required",
+			expectedRequestCount: 0,
+		},
+	})
+}
+
+// Test DELETE handler
+func TestDeleteHandler_ServeHTTP(t *testing.T) {
+	runEnvironmentTests(t, "DELETE", func(b *baseHandler) http.Handler { return &deleteHandler{baseHandler: b} }, []environmentTestCase{
+		{
+			name:                 "successful deletion",
+			pathValues:           environmentPathValues(),
+			apiVersion:           testAPIVersion,
+			setupMock:            func(m *handlerstest.MockHTTPClient) { m.SetResponse(environmentURL, http.StatusNoContent, "") },
+			expectedStatus:       http.StatusNoContent,
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "environment not found",
+			pathValues:           environmentPathValues(),
+			apiVersion:           testAPIVersion,
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Environment with ID 7 not found",
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "missing project",
+			pathValues:           map[string]string{"organization": testOrg, "id": testEnvironmentID},
+			apiVersion:           testAPIVersion,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Project parameter is required",
+			expectedRequestCount: 0,
+		},
+	})
+}
@@ -0,0 +1,219 @@
+package environment
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers"
+)
+
+// Handler constructors
+func GetKubernetesResource(opts handlers.HandlerOptions) handlers.Handler {
+	return &getKubernetesHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func PostKubernetesResource(opts handlers.HandlerOptions) handlers.Handler {
+	return &postKubernetesHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func DeleteKubernetesResource(opts handlers.HandlerOptions) handlers.Handler {
+	return &deleteKubernetesHandler{baseHandler: newBaseHandler(opts)}
+}
+
+// Interface compliance verification
+var _ handlers.Handler = &getKubernetesHandler{}
+var _ handlers.Handler = &postKubernetesHandler{}
+var _ handlers.Handler = &deleteKubernetesHandler{}
+
+type getKubernetesHandler struct {
+	*baseHandler
+}
+
+type postKubernetesHandler struct {
+	*baseHandler
+}
+
+type deleteKubernetesHandler struct {
+	*baseHandler
+}
+
+// decodeKubernetesResource decodes an Azure DevOps Kubernetes resource and normalizes it
+func decodeKubernetesResource(body []byte) (*KubernetesResource, error) {
+	var resource AzureKubernetesResource
+	if err := json.Unmarshal(body, &resource); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal Kubernetes resource: %w", err)
+	}
+	return mapKubernetesResourceFromAzure(&resource), nil
+}
+
+// GET handler implementation
+// @Summary Get a Kubernetes resource of an environment
+// @Description Get a Kubernetes namespace attached to an environment. Tags are sorted and the service endpoint ID is lowercased.
+// @ID get-environment-kubernetes-resource
+// @Param organization path string true "Organization name"
+// @Param project path string true "Project name or ID"
+// @Param environmentId path string true "Environment ID"
+// @Param resourceId path string true "Kubernetes resource ID"
+// @Param api-version query string true "API version (e.g., 7.2-preview.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Produce json
+// @Success 200 {object} KubernetesResourceResponse "Kubernetes resource details"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{project}/environments/{environmentId}/kubernetes/{resourceId} [get]
+func (h *getKubernetesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	project := r.PathValue("project")
+	environmentID := r.PathValue("environmentId")
+	resourceID := r.PathValue("resourceId")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, project, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	h.Log.Printf("Getting Kubernetes resource %s of environment %s for organization %s and project %s", resourceID, environmentID, organization, project)
+
+	url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/distributedtask/environments/%s/providers/kubernetes/%s?api-version=%s", organization, project, environmentID, resourceID, apiVersion)
+	body, err := h.DoAzureDevOpsRequest("GET", url, authHeader, nil)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "get Kubernetes resource", fmt.Sprintf("Kubernetes resource with ID %s not found in environment %s", resourceID, environmentID))
+		return
+	}
+
+	resource, err := decodeKubernetesResource(body)
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	h.WriteObjectResponse(w, http.StatusOK, KubernetesResourceResponse(*resource))
+	h.Log.Printf("Successfully retrieved Kubernetes resource %s of environment %s", resourceID, environmentID)
+}
+
+// POST handler implementation
+// @Summary Attach a Kubernetes resource to an environment
+// @Description Attach a Kubernetes namespace to an environment using an existing Kubernetes service connection
+// @ID post-environment-kubernetes-resource
+// @Param organization path string true "Organization name"
+// @Param project path string true "Project name or ID"
+// @Param environmentId path string true "Environment ID"
+// @Param api-version query string true "API version (e.g., 7.2-preview.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Param resource body CreateKubernetesResourceRequest true "Kubernetes resource creation request body"
+// @Accept json
+// @Produce json
+// @Success 201 {object} KubernetesResourceResponse "Created Kubernetes resource"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{project}/environments/{environmentId}/kubernetes [post]
+func (h *postKubernetesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	project := r.PathValue("project")
+	environmentID := r.PathValue("environmentId")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, project, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Failed to read request body")
+		return
+	}
+
+	var createRequest CreateKubernetesResourceRequest
+	if err := json.Unmarshal(body, &createRequest); err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Invalid JSON in request body")
+		return
+	}
+	if err := validateKubernetesResourceRequest(&createRequest); err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid Kubernetes resource: %v", err))
+		return
+	}
+	createRequest.ServiceEndpointID = strings.ToLower(createRequest.ServiceEndpointID)
+	createRequest.Tags = sortedStrings(createRequest.Tags)
+
+	h.Log.Printf("Attaching Kubernetes namespace %s to environment %s for organization %s and project %s", createRequest.Namespace, environmentID, organization, project)
+
+	requestBody, err := json.Marshal(createRequest)
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Failed to marshal Kubernetes resource: %v", err))
+		return
+	}
+
+	url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/distributedtask/environments/%s/providers/kubernetes?api-version=%s", organization, project, environmentID, apiVersion)
+	responseBody, err := h.DoAzureDevOpsRequest("POST", url, authHeader, requestBody)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "create Kubernetes resource", fmt.Sprintf("Environment with ID %s not found", environmentID))
+		return
+	}
+
+	resource, err := decodeKubernetesResource(responseBody)
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	h.WriteObjectResponse(w, http.StatusCreated, KubernetesResourceResponse(*resource))
+	h.Log.Printf("Successfully attached Kubernetes resource %d to environment %s", resource.ID, environmentID)
+}
+
+// DELETE handler implementation
+// @Summary Remove a Kubernetes resource from an environment
+// @Description Remove a Kubernetes namespace from an environment. The namespace in the cluster is not deleted.
+// @ID delete-environment-kubernetes-resource
+// @Param organization path string true "Organization name"
+// @Param project path string true "Project name or ID"
+// @Param environmentId path string true "Environment ID"
+// @Param resourceId path string true "Kubernetes resource ID"
+// @Param api-version query string true "API version (e.g., 7.2-preview.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Success 204 "No Content"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{project}/environments/{environmentId}/kubernetes/{resourceId} [delete]
+func (h *deleteKubernetesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	project := r.PathValue("project")
+	environmentID := r.PathValue("environmentId")
+	resourceID := r.PathValue("resourceId")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, project, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	h.Log.Printf("Removing Kubernetes resource %s from environment %s for organization %s and project %s", resourceID, environmentID, organization, project)
+
+	url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/distributedtask/environments/%s/providers/kubernetes/%s?api-version=%s", organization, project, environmentID, resourceID, apiVersion)
+	if _, err := h.DoAzureDevOpsRequest("DELETE", url, authHeader, nil); err != nil {
+		h.WriteAzureDevOpsError(w, err, "delete Kubernetes resource", fmt.Sprintf("Kubernetes resource with ID %s not found in environment %s", resourceID, environmentID))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	h.Log.Printf("Successfully removed Kubernetes resource %s from environment %s", resourceID, environmentID)
+}
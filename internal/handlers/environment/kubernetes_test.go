@@ -0,0 +1,125 @@
+package environment
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/handlerstest"
+)
+
+const testKubernetesResourceID = "12"
+
+var (
+	kubernetesURL         = fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/distributedtask/environments/%s/providers/kubernetes?api-version=%s", testOrg, testProject, testEnvironmentID, testAPIVersion)
+	kubernetesResourceURL = fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/distributedtask/environments/%s/providers/kubernetes/%s?api-version=%s", testOrg, testProject, testEnvironmentID, testKubernetesResourceID, testAPIVersion)
+
+	validKubernetesResourceResp = `{"id":12,"name":"web","namespace":"web","clusterName":"aks-prod","serviceEndpointId":"2E9F2B16-6A0D-4F38-9C1B-0E4F5C3A1D2B","tags":["team-b","team-a"],"environmentReference":{"id":7,"name":"production"},"createdOn":"2025-06-30T14:33:02.06Z"}`
+)
+
+// kubernetesPathValues returns the path values of the Kubernetes resource endpoints
+func kubernetesPathValues() map[string]string {
+	return map[string]string{"organization": testOrg, "project": testProject, "environmentId": testEnvironmentID, "resourceId": testKubernetesResourceID}
+}
+
+// Test Kubernetes resource GET handler
+func TestGetKubernetesHandler_ServeHTTP(t *testing.T) {
+	runEnvironmentTests(t, "GET", func(b *baseHandler) http.Handler { return &getKubernetesHandler{baseHandler: b} }, []environmentTestCase{
+		{
+			name:       "successful retrieval with normalized fields",
+			pathValues: kubernetesPathValues(),
+			apiVersion: testAPIVersion,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(kubernetesResourceURL, http.StatusOK, validKubernetesResourceResp)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBody:         `{"id":12,"name":"web","namespace":"web","clusterName":"aks-prod","serviceEndpointId":"2e9f2b16-6a0d-4f38-9c1b-0e4f5c3a1d2b","tags":["team-a","team-b"]}`,
+			expectedRequestCount: 1,
+		},
+		{
+			name:       "resource without tags",
+			pathValues: kubernetesPathValues(),
+			apiVersion: testAPIVersion,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(kubernetesResourceURL, http.StatusOK, `{"id":12,"name":"web","namespace":"web","serviceEndpointId":"se"}`)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `"tags":[]`,
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "resource not found",
+			pathValues:           kubernetesPathValues(),
+			apiVersion:           testAPIVersion,
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Kubernetes resource with ID 12 not found in environment 7",
+			expectedRequestCount: 1,
+		},
+	})
+}
+
+// Test Kubernetes resource POST handler
+func TestPostKubernetesHandler_ServeHTTP(t *testing.T) {
+	runEnvironmentTests(t, "POST", func(b *baseHandler) http.Handler { return &postKubernetesHandler{baseHandler: b} }, []environmentTestCase{
+		{
+			name:       "successful creation",
+			pathValues: kubernetesPathValues(),
+			apiVersion: testAPIVersion,
+			body:       `{"name":"web","namespace":"web","clusterName":"aks-prod","serviceEndpointId":"2E9F2B16-6A0D-4F38-9C1B-0E4F5C3A1D2B","tags":["team-b","team-a"]}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(kubernetesURL, http.StatusOK, validKubernetesResourceResp)
+			},
+			expectedStatus:       http.StatusCreated,
+			expectedBodyContains: `"serviceEndpointId":"2e9f2b16-6a0d-4f38-9c1b-0e4f5c3a1d2b"`,
+			expectedRequestCount: 1,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				body, _ := io.ReadAll(m.Requests[0].Body)
+				want := `{"name":"web","namespace":"web","clusterName":"aks-prod","serviceEndpointId":"2e9f2b16-6a0d-4f38-9c1b-0e4f5c3a1d2b","tags":["team-a","team-b"]}`
+				if string(body) != want {
+					t.Errorf("Request body = %s, want %s", string(body), want)
+				}
+			},
+		},
+		{
+			name:                 "missing service endpoint",
+			pathValues:           kubernetesPathValues(),
+			apiVersion:           testAPIVersion,
+			body:                 `{"name":"web","namespace":"web"}`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid Kubernetes resource: serviceEndpointId is required",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "environment not found",
+			pathValues:           kubernetesPathValues(),
+			apiVersion:           testAPIVersion,
+			body:                 `{"name":"web","namespace":"web","serviceEndpointId":"se"}`,
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Environment with ID 7 not found",
+			expectedRequestCount: 1,
+		},
+	})
+}
+
+// Test Kubernetes resource DELETE handler
+func TestDeleteKubernetesHandler_ServeHTTP(t *testing.T) {
+	runEnvironmentTests(t, "DELETE", func(b *baseHandler) http.Handler { return &deleteKubernetesHandler{baseHandler: b} }, []environmentTestCase{
+		{
+			name:                 "successful deletion",
+			pathValues:           kubernetesPathValues(),
+			apiVersion:           testAPIVersion,
+			setupMock:            func(m *handlerstest.MockHTTPClient) { m.SetResponse(kubernetesResourceURL, http.StatusNoContent, "") },
+			expectedStatus:       http.StatusNoContent,
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "resource not found",
+			pathValues:           kubernetesPathValues(),
+			apiVersion:           testAPIVersion,
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Kubernetes resource with ID 12 not found in environment 7",
+			expectedRequestCount: 1,
+		},
+	})
+}
@@ -0,0 +1,407 @@
+package environment
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// weekDays lists the days accepted by business hours checks, in the order used in responses
+var weekDays = []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"}
+
+var timeOfDayRegex = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`)
+
+// mapEnvironmentFromAzure converts an Azure DevOps environment to the environment returned by the plugin
+func mapEnvironmentFromAzure(instance *EnvironmentInstance) *Environment {
+	environment := &Environment{
+		ID:          instance.ID,
+		Name:        instance.Name,
+		Description: instance.Description,
+		Project:     instance.Project,
+		Resources:   []EnvironmentResourceReference{},
+	}
+
+	for _, resource := range instance.Resources {
+		resource.Tags = sortedStrings(resource.Tags)
+		environment.Resources = append(environment.Resources, resource)
+	}
+	sort.Slice(environment.Resources, func(i, j int) bool {
+		if environment.Resources[i].Type != environment.Resources[j].Type {
+			return environment.Resources[i].Type < environment.Resources[j].Type
+		}
+		return environment.Resources[i].ID < environment.Resources[j].ID
+	})
+
+	return environment
+}
+
+// mapKubernetesResourceFromAzure converts an Azure DevOps Kubernetes resource to the resource returned by the plugin
+func mapKubernetesResourceFromAzure(resource *AzureKubernetesResource) *KubernetesResource {
+	tags := sortedStrings(resource.Tags)
+	if tags == nil {
+		tags = []string{}
+	}
+	return &KubernetesResource{
+		ID:                resource.ID,
+		Name:              resource.Name,
+		Namespace:         resource.Namespace,
+		ClusterName:       resource.ClusterName,
+		ServiceEndpointID: strings.ToLower(resource.ServiceEndpointID),
+		Tags:              tags,
+	}
+}
+
+// validateKubernetesResourceRequest checks the required fields of a Kubernetes resource
+func validateKubernetesResourceRequest(request *CreateKubernetesResourceRequest) error {
+	if strings.TrimSpace(request.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+	if strings.TrimSpace(request.Namespace) == "" {
+		return fmt.Errorf("namespace is required")
+	}
+	if strings.TrimSpace(request.ServiceEndpointID) == "" {
+		return fmt.Errorf("serviceEndpointId is required")
+	}
+	return nil
+}
+
+// validateCheckRequest checks that the settings matching the type of the check are set and valid
+func validateCheckRequest(check *CheckRequest) error {
+	if check.Timeout < 0 {
+		return fmt.Errorf("timeout must not be negative")
+	}
+
+	settings := 0
+	for _, set := range []bool{check.Approval != nil, check.BranchControl != nil, check.BusinessHours != nil} {
+		if set {
+			settings++
+		}
+	}
+	if settings > 1 {
+		return fmt.Errorf("only the settings matching the check type must be set")
+	}
+
+	switch check.Type {
+	case CheckTypeApproval:
+		if check.Approval == nil {
+			return fmt.Errorf("approval settings are required for checks of type '%s'", check.Type)
+		}
+		if len(check.Approval.Approvers) == 0 {
+			return fmt.Errorf("approval.approvers must contain at least one approver")
+		}
+		if check.Approval.MinRequiredApprovers < 0 || int(check.Approval.MinRequiredApprovers) > len(check.Approval.Approvers) {
+			return fmt.Errorf("approval.minRequiredApprovers must be between 0 and the number of approvers")
+		}
+		switch check.Approval.ExecutionOrder {
+		case "", ExecutionOrderAnyOrder, ExecutionOrderInSequence:
+		default:
+			return fmt.Errorf("approval.executionOrder '%s' is not supported, supported values are: %s, %s", check.Approval.ExecutionOrder, ExecutionOrderAnyOrder, ExecutionOrderInSequence)
+		}
+	case CheckTypeBranchControl:
+		if check.BranchControl == nil {
+			return fmt.Errorf("branchControl settings are required for checks of type '%s'", check.Type)
+		}
+		if len(check.BranchControl.AllowedBranches) == 0 {
+			return fmt.Errorf("branchControl.allowedBranches must contain at least one branch")
+		}
+		for _, branch := range check.BranchControl.AllowedBranches {
+			if strings.TrimSpace(branch) == "" || strings.Contains(branch, ",") {
+				return fmt.Errorf("branchControl.allowedBranches contains an invalid branch '%s'", branch)
+			}
+		}
+	case CheckTypeBusinessHours:
+		if check.BusinessHours == nil {
+			return fmt.Errorf("businessHours settings are required for checks of type '%s'", check.Type)
+		}
+		if _, err := normalizeWeekDays(check.BusinessHours.Days); err != nil {
+			return fmt.Errorf("businessHours.days: %w", err)
+		}
+		if !timeOfDayRegex.MatchString(check.BusinessHours.StartTime) {
+			return fmt.Errorf("businessHours.startTime must have the format HH:MM")
+		}
+		if !timeOfDayRegex.MatchString(check.BusinessHours.EndTime) {
+			return fmt.Errorf("businessHours.endTime must have the format HH:MM")
+		}
+		if check.BusinessHours.StartTime >= check.BusinessHours.EndTime {
+			return fmt.Errorf("businessHours.startTime must be before businessHours.endTime")
+		}
+	default:
+		return fmt.Errorf("check type '%s' is not supported, supported types are: %s, %s, %s", check.Type, CheckTypeApproval, CheckTypeBranchControl, CheckTypeBusinessHours)
+	}
+
+	return nil
+}
+
+// normalizeWeekDays validates the given day names and returns them lowercased, ordered from monday to sunday
+func normalizeWeekDays(days []string) ([]string, error) {
+	if len(days) == 0 {
+		return nil, fmt.Errorf("at least one day is required")
+	}
+
+	selected := map[string]bool{}
+	for _, day := range days {
+		day = strings.ToLower(strings.TrimSpace(day))
+		valid := false
+		for _, weekDay := range weekDays {
+			if day == weekDay {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return nil, fmt.Errorf("unknown day '%s'", day)
+		}
+		selected[day] = true
+	}
+
+	normalized := []string{}
+	for _, weekDay := range weekDays {
+		if selected[weekDay] {
+			normalized = append(normalized, weekDay)
+		}
+	}
+	return normalized, nil
+}
+
+// mapCheckToAzure converts a check of the plugin to an Azure DevOps check configuration protecting the given environment
+// The check request must be validated with validateCheckRequest
+func mapCheckToAzure(check *CheckRequest, environmentID string) (*CheckConfiguration, error) {
+	configuration := &CheckConfiguration{
+		Resource: &CheckResource{Type: ResourceTypeEnvironment, ID: environmentID},
+		Timeout:  check.Timeout,
+	}
+	if configuration.Timeout == 0 {
+		configuration.Timeout = DefaultCheckTimeout
+	}
+
+	var settings interface{}
+	switch check.Type {
+	case CheckTypeApproval:
+		configuration.Type = &CheckType{ID: ApprovalCheckTypeID, Name: ApprovalCheckName}
+		approvers := []IdentityReference{}
+		inSequence := check.Approval.ExecutionOrder == ExecutionOrderInSequence
+		for _, approver := range normalizeIdentityIDs(check.Approval.Approvers, !inSequence) {
+			approvers = append(approvers, IdentityReference{ID: approver})
+		}
+		executionOrder := int32(1)
+		if inSequence {
+			executionOrder = 2
+		}
+		settings = ApprovalSettings{
+			Approvers:                 approvers,
+			BlockedApprovers:          []IdentityReference{},
+			ExecutionOrder:            executionOrder,
+			Instructions:              check.Approval.Instructions,
+			MinRequiredApprovers:      check.Approval.MinRequiredApprovers,
+			RequesterCannotBeApprover: check.Approval.RequesterCannotBeApprover,
+		}
+	case CheckTypeBranchControl:
+		configuration.Type = &CheckType{ID: TaskCheckTypeID, Name: TaskCheckName}
+		settings = TaskCheckSettings{
+			DefinitionRef: &TaskCheckDefinitionReference{ID: BranchControlTaskID, Name: BranchControlTask, Version: TaskCheckVersion},
+			DisplayName:   "Branch control",
+			Inputs: map[string]string{
+				"allowedBranches":          strings.Join(sortedStrings(check.BranchControl.AllowedBranches), ","),
+				"ensureProtectionOfBranch": strconv.FormatBool(check.BranchControl.EnsureProtectionOfBranch),
+				"allowUnknownStatusBranch": strconv.FormatBool(check.BranchControl.AllowUnknownStatusBranch),
+			},
+			RetryInterval: DefaultRetryInterval,
+		}
+	case CheckTypeBusinessHours:
+		configuration.Type = &CheckType{ID: TaskCheckTypeID, Name: TaskCheckName}
+		days, err := normalizeWeekDays(check.BusinessHours.Days)
+		if err != nil {
+			return nil, err
+		}
+		for i, day := range days {
+			days[i] = strings.ToUpper(day[:1]) + day[1:]
+		}
+		timeZone := check.BusinessHours.TimeZone
+		if timeZone == "" {
+			timeZone = "UTC"
+		}
+		settings = TaskCheckSettings{
+			DefinitionRef: &TaskCheckDefinitionReference{ID: BusinessHoursTaskID, Name: BusinessHoursTask, Version: TaskCheckVersion},
+			DisplayName:   "Business Hours",
+			Inputs: map[string]string{
+				"businessDays": strings.Join(days, ","),
+				"timeZone":     timeZone,
+				"startTime":    check.BusinessHours.StartTime,
+				"endTime":      check.BusinessHours.EndTime,
+			},
+			RetryInterval: DefaultRetryInterval,
+		}
+	default:
+		return nil, fmt.Errorf("check type '%s' is not supported", check.Type)
+	}
+
+	settingsBytes, err := json.Marshal(settings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal check settings: %w", err)
+	}
+	configuration.Settings = settingsBytes
+
+	return configuration, nil
+}
+
+// mapCheckFromAzure converts an Azure DevOps check configuration to the check returned by the plugin
+// Check types not handled by the plugin are returned with their Azure DevOps name and raw settings
+func mapCheckFromAzure(configuration *CheckConfiguration) *Check {
+	check := &Check{
+		ID:      configuration.ID,
+		Timeout: configuration.Timeout,
+	}
+
+	typeID := ""
+	typeName := ""
+	if configuration.Type != nil {
+		typeID = strings.ToLower(configuration.Type.ID)
+		typeName = configuration.Type.Name
+	}
+
+	switch typeID {
+	case ApprovalCheckTypeID:
+		var settings ApprovalSettings
+		if err := json.Unmarshal(configuration.Settings, &settings); err == nil {
+			approvers := []string{}
+			for _, approver := range settings.Approvers {
+				approvers = append(approvers, approver.ID)
+			}
+			executionOrder := ExecutionOrderAnyOrder
+			if settings.ExecutionOrder == 2 {
+				executionOrder = ExecutionOrderInSequence
+			}
+			check.Type = CheckTypeApproval
+			check.Approval = &ApprovalCheck{
+				Approvers:                 normalizeIdentityIDs(approvers, executionOrder == ExecutionOrderAnyOrder),
+				MinRequiredApprovers:      settings.MinRequiredApprovers,
+				ExecutionOrder:            executionOrder,
+				Instructions:              settings.Instructions,
+				RequesterCannotBeApprover: settings.RequesterCannotBeApprover,
+			}
+			return check
+		}
+	case TaskCheckTypeID:
+		var settings TaskCheckSettings
+		if err := json.Unmarshal(configuration.Settings, &settings); err == nil && settings.DefinitionRef != nil {
+			switch strings.ToLower(settings.DefinitionRef.ID) {
+			case BranchControlTaskID:
+				check.Type = CheckTypeBranchControl
+				check.BranchControl = &BranchControlCheck{
+					AllowedBranches:          splitList(settings.Inputs["allowedBranches"]),
+					EnsureProtectionOfBranch: parseBoolInput(settings.Inputs["ensureProtectionOfBranch"]),
+					AllowUnknownStatusBranch: parseBoolInput(settings.Inputs["allowUnknownStatusBranch"]),
+				}
+				return check
+			case BusinessHoursTaskID:
+				days, err := normalizeWeekDays(splitList(settings.Inputs["businessDays"]))
+				if err != nil {
+					days = []string{}
+				}
+				check.Type = CheckTypeBusinessHours
+				check.BusinessHours = &BusinessHoursCheck{
+					Days:      days,
+					TimeZone:  settings.Inputs["timeZone"],
+					StartTime: settings.Inputs["startTime"],
+					EndTime:   settings.Inputs["endTime"],
+				}
+				return check
+			}
+		}
+	}
+
+	// Check type not handled by the plugin
+	check.Type = typeName
+	check.Settings = configuration.Settings
+	return check
+}
+
+// checkKind identifies the kind of a check configuration: its type ID and, for task checks, the ID of the task
+// An environment is expected to have a single check of each kind
+func checkKind(configuration *CheckConfiguration) string {
+	if configuration.Type == nil {
+		return ""
+	}
+	kind := strings.ToLower(configuration.Type.ID)
+	if kind == TaskCheckTypeID {
+		var settings TaskCheckSettings
+		if err := json.Unmarshal(configuration.Settings, &settings); err == nil && settings.DefinitionRef != nil {
+			kind += "/" + strings.ToLower(settings.DefinitionRef.ID)
+		}
+	}
+	return kind
+}
+
+// findMatchingCheck returns the check configuration of the same kind as the given one, the one with the lowest ID if there are several
+func findMatchingCheck(configurations []CheckConfiguration, configuration *CheckConfiguration) *CheckConfiguration {
+	kind := checkKind(configuration)
+	var match *CheckConfiguration
+	for i := range configurations {
+		if checkKind(&configurations[i]) != kind {
+			continue
+		}
+		if match == nil || configurations[i].ID < match.ID {
+			match = &configurations[i]
+		}
+	}
+	return match
+}
+
+// sortChecks sorts checks by type and ID
+func sortChecks(checks []Check) {
+	sort.Slice(checks, func(i, j int) bool {
+		if checks[i].Type != checks[j].Type {
+			return checks[i].Type < checks[j].Type
+		}
+		return checks[i].ID < checks[j].ID
+	})
+}
+
+// isEnvironmentCheck reports whether a check configuration protects the given environment
+func isEnvironmentCheck(configuration *CheckConfiguration, environmentID string) bool {
+	return configuration.Resource != nil &&
+		strings.EqualFold(configuration.Resource.Type, ResourceTypeEnvironment) &&
+		configuration.Resource.ID == environmentID
+}
+
+// normalizeIdentityIDs returns the given identity IDs (GUIDs) lowercased, sorted if the order is not meaningful
+func normalizeIdentityIDs(ids []string, sorted bool) []string {
+	normalized := make([]string, 0, len(ids))
+	for _, id := range ids {
+		normalized = append(normalized, strings.ToLower(strings.TrimSpace(id)))
+	}
+	if sorted {
+		return sortedStrings(normalized)
+	}
+	return normalized
+}
+
+// splitList splits a comma separated list, dropping empty items, and returns it sorted
+func splitList(list string) []string {
+	items := []string{}
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return sortedStrings(items)
+}
+
+// parseBoolInput parses a boolean task input, defaulting to false
+func parseBoolInput(input string) bool {
+	value, err := strconv.ParseBool(input)
+	return err == nil && value
+}
+
+// sortedStrings returns a sorted copy of the given strings
+func sortedStrings(values []string) []string {
+	if values == nil {
+		return nil
+	}
+	sorted := append([]string{}, values...)
+	sort.Strings(sorted)
+	return sorted
+}
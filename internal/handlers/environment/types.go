@@ -0,0 +1,243 @@
+package environment
+
+import (
+	"encoding/json"
+)
+
+// Environment represents the environment returned by the plugin
+// It is built from the EnvironmentInstance returned by:
+// GET /{organization}/{project}/_apis/pipelines/environments/{environmentId}
+// Audit fields (createdBy, createdOn, lastModifiedBy, lastModifiedOn) are not returned since they change at every update
+type Environment struct {
+	ID          int32                          `json:"id"`
+	Name        string                         `json:"name"`
+	Description string                         `json:"description,omitempty"`
+	Project     *ProjectReference              `json:"project,omitempty"`
+	Resources   []EnvironmentResourceReference `json:"resources"` // Sorted by type and ID, always present
+}
+
+// ProjectReference represents a reference to a project
+type ProjectReference struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// EnvironmentResourceReference represents a resource (e.g., a Kubernetes namespace) attached to an environment
+type EnvironmentResourceReference struct {
+	ID   int32    `json:"id"`
+	Name string   `json:"name"`
+	Type string   `json:"type"` // enum: undefined, generic, virtualMachine, kubernetes
+	Tags []string `json:"tags,omitempty"`
+}
+
+// EnvironmentInstance represents the response from:
+// GET /{organization}/{project}/_apis/pipelines/environments/{environmentId}
+type EnvironmentInstance struct {
+	ID          int32                          `json:"id"`
+	Name        string                         `json:"name"`
+	Description string                         `json:"description"`
+	Project     *ProjectReference              `json:"project"`
+	Resources   []EnvironmentResourceReference `json:"resources"`
+}
+
+// CreateEnvironmentRequest represents the request body for creating an environment
+type CreateEnvironmentRequest struct {
+	Name        string `json:"name"` // Required
+	Description string `json:"description,omitempty"`
+}
+
+// UpdateEnvironmentRequest represents the request body for updating an environment
+type UpdateEnvironmentRequest struct {
+	Name        string `json:"name"` // Required
+	Description string `json:"description"`
+}
+
+// EnvironmentResponse represents the response of the environment endpoints
+type EnvironmentResponse Environment
+
+// ---
+
+// KubernetesResource represents the Kubernetes resource returned by the plugin
+// It is built from the KubernetesResource returned by:
+// GET /{organization}/{project}/_apis/distributedtask/environments/{environmentId}/providers/kubernetes/{resourceId}
+type KubernetesResource struct {
+	ID                int32    `json:"id"`
+	Name              string   `json:"name"`
+	Namespace         string   `json:"namespace"`
+	ClusterName       string   `json:"clusterName,omitempty"`
+	ServiceEndpointID string   `json:"serviceEndpointId"`
+	Tags              []string `json:"tags"` // Sorted, always present
+}
+
+// AzureKubernetesResource represents the response from:
+// GET /{organization}/{project}/_apis/distributedtask/environments/{environmentId}/providers/kubernetes/{resourceId}
+type AzureKubernetesResource struct {
+	ID                   int32                 `json:"id"`
+	Name                 string                `json:"name"`
+	Namespace            string                `json:"namespace"`
+	ClusterName          string                `json:"clusterName"`
+	ServiceEndpointID    string                `json:"serviceEndpointId"`
+	Tags                 []string              `json:"tags"`
+	EnvironmentReference *EnvironmentReference `json:"environmentReference"`
+}
+
+// EnvironmentReference represents a reference to an environment
+type EnvironmentReference struct {
+	ID   int32  `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+// CreateKubernetesResourceRequest represents the request body for attaching a Kubernetes namespace to an environment
+type CreateKubernetesResourceRequest struct {
+	Name              string   `json:"name"`      // Required
+	Namespace         string   `json:"namespace"` // Required
+	ClusterName       string   `json:"clusterName,omitempty"`
+	ServiceEndpointID string   `json:"serviceEndpointId"` // Required - ID of the Kubernetes service connection
+	Tags              []string `json:"tags,omitempty"`
+}
+
+// KubernetesResourceResponse represents the response of the Kubernetes resource endpoints
+type KubernetesResourceResponse KubernetesResource
+
+// ---
+
+// Check represents a check of an environment as exposed by the plugin
+// Only the settings matching the type are set
+type Check struct {
+	ID            int32               `json:"id,omitempty"`
+	Type          string              `json:"type"`              // enum: approval, branchControl, businessHours (other check types are returned with their Azure DevOps name)
+	Timeout       int32               `json:"timeout,omitempty"` // Minutes, defaults to 43200 (30 days)
+	Approval      *ApprovalCheck      `json:"approval,omitempty"`
+	BranchControl *BranchControlCheck `json:"branchControl,omitempty"`
+	BusinessHours *BusinessHoursCheck `json:"businessHours,omitempty"`
+	Settings      json.RawMessage     `json:"settings,omitempty" swaggertype:"object"` // Only for check types not handled by the plugin, read only
+}
+
+// ApprovalCheck represents the settings of an approval check
+type ApprovalCheck struct {
+	Approvers                 []string `json:"approvers"` // Required - IDs of users or groups, lowercased and sorted unless executionOrder is inSequence
+	MinRequiredApprovers      int32    `json:"minRequiredApprovers"`
+	ExecutionOrder            string   `json:"executionOrder"` // enum: anyOrder (default), inSequence
+	Instructions              string   `json:"instructions,omitempty"`
+	RequesterCannotBeApprover bool     `json:"requesterCannotBeApprover"`
+}
+
+// BranchControlCheck represents the settings of a branch control check
+type BranchControlCheck struct {
+	AllowedBranches          []string `json:"allowedBranches"` // Required - e.g., refs/heads/main, refs/heads/release/*, sorted
+	EnsureProtectionOfBranch bool     `json:"ensureProtectionOfBranch"`
+	AllowUnknownStatusBranch bool     `json:"allowUnknownStatusBranch"`
+}
+
+// BusinessHoursCheck represents the settings of a business hours check
+type BusinessHoursCheck struct {
+	Days      []string `json:"days"`      // Required - monday ... sunday, ordered from monday to sunday
+	TimeZone  string   `json:"timeZone"`  // Windows time zone ID, defaults to UTC
+	StartTime string   `json:"startTime"` // Required - HH:MM
+	EndTime   string   `json:"endTime"`   // Required - HH:MM
+}
+
+// CheckRequest represents the request body for creating or updating a check
+type CheckRequest Check
+
+// CheckResponse represents the response of the check endpoints
+type CheckResponse Check
+
+// CheckListResponse represents the response of the check list endpoint
+type CheckListResponse struct {
+	Count int     `json:"count"`
+	Value []Check `json:"value"` // Sorted by type and ID
+}
+
+// CheckConfiguration represents a check configuration of Azure DevOps:
+// /{organization}/{project}/_apis/pipelines/checks/configurations
+type CheckConfiguration struct {
+	ID       int32           `json:"id,omitempty"`
+	Version  int32           `json:"version,omitempty"`
+	Type     *CheckType      `json:"type"`
+	Settings json.RawMessage `json:"settings,omitempty"`
+	Resource *CheckResource  `json:"resource"`
+	Timeout  int32           `json:"timeout,omitempty"`
+}
+
+// CheckType represents the type of a check configuration
+type CheckType struct {
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+// CheckResource represents the resource protected by a check configuration
+type CheckResource struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+// CheckConfigurationList represents the response from:
+// GET /{organization}/{project}/_apis/pipelines/checks/configurations
+type CheckConfigurationList struct {
+	Count int                  `json:"count"`
+	Value []CheckConfiguration `json:"value"`
+}
+
+// ApprovalSettings represents the settings of an approval check configuration
+type ApprovalSettings struct {
+	Approvers                 []IdentityReference `json:"approvers"`
+	BlockedApprovers          []IdentityReference `json:"blockedApprovers"`
+	ExecutionOrder            int32               `json:"executionOrder"`
+	Instructions              string              `json:"instructions"`
+	MinRequiredApprovers      int32               `json:"minRequiredApprovers"`
+	RequesterCannotBeApprover bool                `json:"requesterCannotBeApprover"`
+}
+
+// IdentityReference represents a reference to a user or group
+type IdentityReference struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName,omitempty"`
+}
+
+// TaskCheckSettings represents the settings of a task check configuration (e.g., branch control, business hours)
+type TaskCheckSettings struct {
+	DefinitionRef *TaskCheckDefinitionReference `json:"definitionRef"`
+	DisplayName   string                        `json:"displayName"`
+	Inputs        map[string]string             `json:"inputs"`
+	RetryInterval int32                         `json:"retryInterval"`
+}
+
+// TaskCheckDefinitionReference represents the task run by a task check configuration
+type TaskCheckDefinitionReference struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// Check types handled by the plugin
+const (
+	CheckTypeApproval      = "approval"
+	CheckTypeBranchControl = "branchControl"
+	CheckTypeBusinessHours = "businessHours"
+)
+
+// Azure DevOps check configuration types and task check definitions
+const (
+	ApprovalCheckTypeID  = "8c6f20a7-a545-4486-9777-f762fafe0d4d"
+	TaskCheckTypeID      = "fe1de3ee-a436-41b4-bb20-f6eb4cb879a7"
+	ApprovalCheckName    = "Approval"
+	TaskCheckName        = "Task Check"
+	BranchControlTaskID  = "86b05a0c-73e6-4f7d-b3cf-e38f3b39a75b"
+	BranchControlTask    = "evaluatebranchProtection"
+	BusinessHoursTaskID  = "445fde2f-6c39-441c-807f-8a59ff2e075f"
+	BusinessHoursTask    = "evaluateBusinessHours"
+	TaskCheckVersion     = "0.0.1"
+	DefaultCheckTimeout  = 43200 // 30 days, in minutes
+	DefaultRetryInterval = 5     // Minutes
+)
+
+// Approval execution orders
+const (
+	ExecutionOrderAnyOrder   = "anyOrder"
+	ExecutionOrderInSequence = "inSequence"
+)
+
+// ResourceTypeEnvironment is the resource type of the checks of an environment
+const ResourceTypeEnvironment = "environment"
@@ -0,0 +1,479 @@
+package forksync
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	neturl "net/url"
+	"strconv"
+	"strings"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers"
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/gitrepository"
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/utils"
+)
+
+// Handler constructors
+func GetForkStatus(opts handlers.HandlerOptions) handlers.Handler {
+	return &getHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func PostForkSync(opts handlers.HandlerOptions) handlers.Handler {
+	return &postHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func GetForkSyncRequest(opts handlers.HandlerOptions) handlers.Handler {
+	return &getRequestHandler{baseHandler: newBaseHandler(opts)}
+}
+
+// Interface compliance verification
+var _ handlers.Handler = &getHandler{}
+var _ handlers.Handler = &postHandler{}
+var _ handlers.Handler = &getRequestHandler{}
+
+// Base handler with common functionality
+type baseHandler struct {
+	*handlers.BaseHandler
+}
+
+// Constructor for the base handler
+func newBaseHandler(opts handlers.HandlerOptions) *baseHandler {
+	return &baseHandler{BaseHandler: handlers.NewBaseHandler(opts)}
+}
+
+// Handler types embedding the base handler
+type getHandler struct {
+	*baseHandler
+}
+
+type postHandler struct {
+	*baseHandler
+}
+
+type getRequestHandler struct {
+	*baseHandler
+}
+
+// errNotFork is returned when the repository is not a fork
+var errNotFork = errors.New("repository is not a fork")
+
+// errRefNotFound is returned when a ref of the parent repository or a branch of the fork does not exist
+type errRefNotFound struct {
+	Ref        string
+	Repository string // Name of the repository
+	Fork       bool   // true if the branch of the fork is missing
+}
+
+func (e *errRefNotFound) Error() string {
+	return fmt.Sprintf("ref %s not found in repository %s", e.Ref, e.Repository)
+}
+
+// getFork returns the repository, errNotFork if it has no parent repository
+func (h *baseHandler) getFork(ctx context.Context, organization, projectId, repositoryId, apiVersion, authHeader string) (*gitrepository.GitRepository, error) {
+	url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/git/repositories/%s?api-version=%s", organization, projectId, repositoryId, apiVersion)
+
+	body, err := h.DoAzureDevOpsRequestWithContext(ctx, "GET", url, authHeader, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var repository gitrepository.GitRepository
+	if err := json.Unmarshal(body, &repository); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal repository: %w", err)
+	}
+	if !repository.IsFork || repository.ParentRepository == nil || repository.ParentRepository.ID == "" {
+		return nil, errNotFork
+	}
+	return &repository, nil
+}
+
+// parentProjectID returns the project of the parent repository of the fork
+func parentProjectID(repository *gitrepository.GitRepository, projectId string) string {
+	if repository.ParentRepository.Project != nil && repository.ParentRepository.Project.ID != "" {
+		return repository.ParentRepository.Project.ID
+	}
+	return projectId
+}
+
+// getRef returns the ref with the given full name, nil if it does not exist
+func (h *baseHandler) getRef(ctx context.Context, organization, projectId, repositoryId, name, apiVersion, authHeader string) (*GitRef, error) {
+	query := neturl.Values{}
+	query.Set("filter", strings.TrimPrefix(name, "refs/"))
+	query.Set("peelTags", "true")
+	query.Set("api-version", apiVersion)
+	url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/git/repositories/%s/refs?%s", organization, projectId, repositoryId, query.Encode())
+
+	body, err := h.DoAzureDevOpsRequestWithContext(ctx, "GET", url, authHeader, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var refs GitRefList
+	if err := json.Unmarshal(body, &refs); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal refs: %w", err)
+	}
+	return findRef(refs.Value, name), nil
+}
+
+// resolveRefs returns the full names of the source and target refs, defaulting to the default branch of the fork
+func resolveRefs(repository *gitrepository.GitRepository, sourceRef, targetRef string) (string, string, error) {
+	sourceRef = normalizeRefName(sourceRef)
+	if sourceRef == "" {
+		sourceRef = repository.DefaultBranch
+	}
+	if sourceRef == "" {
+		return "", "", fmt.Errorf("sourceRef is required, the fork has no default branch")
+	}
+
+	targetRef = normalizeRefName(targetRef)
+	if targetRef == "" {
+		targetRef = sourceRef
+	}
+	if !strings.HasPrefix(targetRef, "refs/heads/") {
+		return "", "", fmt.Errorf("targetRef must be a branch, got '%s'", targetRef)
+	}
+	return sourceRef, targetRef, nil
+}
+
+// compareFork returns the ahead and behind counts of the branch of the fork compared to the ref of the parent repository
+// Forks share the Git objects of their parent repository, so that the commit of the parent ref can be used as base in the fork
+func (h *baseHandler) compareFork(ctx context.Context, organization, projectId string, repository *gitrepository.GitRepository, sourceRef, targetRef, apiVersion, authHeader string) (*ForkStatus, error) {
+	parent := repository.ParentRepository
+	source, err := h.getRef(ctx, organization, parentProjectID(repository, projectId), parent.ID, sourceRef, apiVersion, authHeader)
+	if err != nil {
+		return nil, err
+	}
+	if source == nil {
+		return nil, &errRefNotFound{Ref: sourceRef, Repository: parent.Name}
+	}
+	target, err := h.getRef(ctx, organization, projectId, repository.ID, targetRef, apiVersion, authHeader)
+	if err != nil {
+		return nil, err
+	}
+	if target == nil {
+		return nil, &errRefNotFound{Ref: targetRef, Repository: repository.Name, Fork: true}
+	}
+
+	status := &ForkStatus{
+		RepositoryID:       strings.ToLower(repository.ID),
+		ParentRepositoryID: strings.ToLower(parent.ID),
+		SourceRef:          sourceRef,
+		TargetRef:          targetRef,
+		SourceCommitID:     refCommit(source),
+		TargetCommitID:     refCommit(target),
+	}
+
+	if status.SourceCommitID != status.TargetCommitID {
+		query := neturl.Values{}
+		query.Set("name", strings.TrimPrefix(targetRef, "refs/heads/"))
+		query.Set("baseVersionDescriptor.version", status.SourceCommitID)
+		query.Set("baseVersionDescriptor.versionType", "commit")
+		query.Set("api-version", apiVersion)
+		url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/git/repositories/%s/stats/branches?%s", organization, projectId, repository.ID, query.Encode())
+
+		body, err := h.DoAzureDevOpsRequestWithContext(ctx, "GET", url, authHeader, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var stats GitBranchStats
+		if err := json.Unmarshal(body, &stats); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal branch stats: %w", err)
+		}
+		status.AheadCount, status.BehindCount = stats.AheadCount, stats.BehindCount
+	}
+
+	status.UpToDate = status.BehindCount == 0
+	return status, nil
+}
+
+// findActiveSyncRequest returns the running fork sync request of the same refs, nil if there is none
+func (h *baseHandler) findActiveSyncRequest(ctx context.Context, organization, projectId, repositoryId, parentRepositoryID, sourceRef, targetRef, authHeader string) (*GitForkSyncRequest, error) {
+	url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/git/repositories/%s/forkSyncRequests?includeAbandoned=false&api-version=%s", organization, projectId, repositoryId, forkSyncAPIVersion())
+
+	body, err := h.DoAzureDevOpsRequestWithContext(ctx, "GET", url, authHeader, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var requests GitForkSyncRequestList
+	if err := json.Unmarshal(body, &requests); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal fork sync requests: %w", err)
+	}
+	for i := range requests.Value {
+		if isActive(&requests.Value[i]) && matchesSyncRequest(&requests.Value[i], parentRepositoryID, sourceRef, targetRef) {
+			return &requests.Value[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// createSyncRequest creates a fork sync request of the ref of the parent repository to the branch of the fork
+func (h *baseHandler) createSyncRequest(ctx context.Context, organization, projectId string, repository *gitrepository.GitRepository, sourceRef, targetRef, authHeader string) (*GitForkSyncRequest, error) {
+	requestBody, err := json.Marshal(GitForkSyncRequestParameters{
+		Source:             GlobalGitRepositoryKey{RepositoryID: repository.ParentRepository.ID, ProjectID: parentProjectID(repository, projectId)},
+		SourceToTargetRefs: []SourceToTargetRef{{SourceRef: sourceRef, TargetRef: targetRef}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal fork sync request: %w", err)
+	}
+
+	url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/git/repositories/%s/forkSyncRequests?api-version=%s", organization, projectId, repository.ID, forkSyncAPIVersion())
+	body, err := h.DoAzureDevOpsRequestWithContext(ctx, "POST", url, authHeader, requestBody)
+	if err != nil {
+		return nil, err
+	}
+
+	var request GitForkSyncRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal fork sync request: %w", err)
+	}
+	return &request, nil
+}
+
+// writeForkError writes the response for an error returned while reading the fork
+// A repository that is not a fork and a missing ref are returned as 400 and 404, the other errors as writeOperationError does
+func (h *baseHandler) writeForkError(w http.ResponseWriter, err error, action, repositoryId, projectId string) {
+	var refErr *errRefNotFound
+	switch {
+	case errors.Is(err, errNotFork):
+		h.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid fork: repository %s is not a fork", repositoryId))
+	case errors.As(err, &refErr):
+		h.WriteErrorResponse(w, http.StatusNotFound, fmt.Sprintf("Ref %s not found in repository %s", refErr.Ref, refErr.Repository))
+	default:
+		h.writeOperationError(w, err, action, fmt.Sprintf("Repository %s not found in project %s", repositoryId, projectId))
+	}
+}
+
+// writeOperationError writes the response for an error returned by the handler synchronizing a fork
+// A timeout (of the polling or of a request interrupted by the deadline of the handler) is returned as 504 so that
+// the caller retries, the other errors as WriteAzureDevOpsError does
+func (h *baseHandler) writeOperationError(w http.ResponseWriter, err error, action, notFoundMessage string) {
+	if errors.Is(err, utils.ErrPollTimeout) || errors.Is(err, context.DeadlineExceeded) {
+		h.WriteErrorResponse(w, http.StatusGatewayTimeout, fmt.Sprintf("Failed to %s: the operation did not complete within %s, retry the request", action, syncPoller.Timeout))
+		return
+	}
+	h.WriteAzureDevOpsError(w, err, action, notFoundMessage)
+}
+
+// GET handler implementation
+// @Summary Compare a fork with its parent repository
+// @Description Get the number of commits a branch of a fork is ahead of and behind a ref of its parent repository
+// @ID get-fork-status
+// @Param organization path string true "Organization name"
+// @Param projectId path string true "Project ID or name"
+// @Param repositoryId path string true "Fork repository ID or name"
+// @Param api-version query string true "API version (e.g., 7.1)"
+// @Param sourceRef query string false "Ref of the parent repository (e.g., main), defaults to the default branch of the fork"
+// @Param targetRef query string false "Branch of the fork, defaults to sourceRef"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Produce json
+// @Success 200 {object} ForkStatusResponse "Fork status"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{projectId}/git/repositories/{repositoryId}/forksync [get]
+func (h *getHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	projectId := r.PathValue("projectId")
+	repositoryId := r.PathValue("repositoryId")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, projectId, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	h.Log.Printf("Comparing fork %s with its parent repository for project %s in organization %s", repositoryId, projectId, organization)
+
+	repository, err := h.getFork(r.Context(), organization, projectId, repositoryId, apiVersion, authHeader)
+	if err != nil {
+		h.writeForkError(w, err, "get repository", repositoryId, projectId)
+		return
+	}
+	sourceRef, targetRef, err := resolveRefs(repository, r.URL.Query().Get("sourceRef"), r.URL.Query().Get("targetRef"))
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid fork sync: %v", err))
+		return
+	}
+
+	status, err := h.compareFork(r.Context(), organization, projectId, repository, sourceRef, targetRef, apiVersion, authHeader)
+	if err != nil {
+		h.writeForkError(w, err, "compare fork", repositoryId, projectId)
+		return
+	}
+
+	h.WriteObjectResponse(w, http.StatusOK, ForkStatusResponse(*status))
+	h.Log.Printf("Fork %s is %d commits ahead and %d commits behind %s", repositoryId, status.AheadCount, status.BehindCount, sourceRef)
+}
+
+// POST handler implementation
+// @Summary Synchronize a fork with its parent repository
+// @Description Synchronize a branch of a fork with a ref of its parent repository and wait for the synchronization to complete. Nothing is done if the branch is not behind. If a synchronization of the same refs is already running, it is waited for instead of creating another one.
+// @ID post-fork-sync
+// @Param organization path string true "Organization name"
+// @Param projectId path string true "Project ID or name"
+// @Param repositoryId path string true "Fork repository ID or name"
+// @Param api-version query string true "API version (e.g., 7.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Param sync body SyncRequest false "Fork sync request body"
+// @Accept json
+// @Produce json
+// @Success 200 {object} SyncResponse "Fork up to date, or running synchronization completed"
+// @Success 201 {object} SyncResponse "Synchronization created and completed"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Failure 504 "Gateway Timeout"
+// @Router /api/{organization}/{projectId}/git/repositories/{repositoryId}/forksync [post]
+func (h *postHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	projectId := r.PathValue("projectId")
+	repositoryId := r.PathValue("repositoryId")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, projectId, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Failed to read request body")
+		return
+	}
+
+	// The body is optional: the default branch of the fork is synchronized
+	var syncRequest SyncRequest
+	if len(bytes.TrimSpace(body)) > 0 {
+		if err := json.Unmarshal(body, &syncRequest); err != nil {
+			h.WriteErrorResponse(w, http.StatusBadRequest, "Invalid JSON in request body")
+			return
+		}
+	}
+
+	// All the requests share the timeout of the synchronization so that the caller gets a response before the write timeout
+	ctx, cancel := syncPoller.WithTimeout(r.Context())
+	defer cancel()
+
+	repository, err := h.getFork(ctx, organization, projectId, repositoryId, apiVersion, authHeader)
+	if err != nil {
+		h.writeForkError(w, err, "get repository", repositoryId, projectId)
+		return
+	}
+	sourceRef, targetRef, err := resolveRefs(repository, syncRequest.SourceRef, syncRequest.TargetRef)
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid fork sync: %v", err))
+		return
+	}
+
+	h.Log.Printf("Synchronizing %s of fork %s with %s of its parent repository", targetRef, repositoryId, sourceRef)
+
+	// The branch of the fork may not exist yet, the synchronization creates it
+	status, err := h.compareFork(ctx, organization, projectId, repository, sourceRef, targetRef, apiVersion, authHeader)
+	var refErr *errRefNotFound
+	switch {
+	case errors.As(err, &refErr) && refErr.Fork:
+		h.Log.Printf("Branch %s does not exist in fork %s", targetRef, repositoryId)
+	case err != nil:
+		h.writeForkError(w, err, "compare fork", repositoryId, projectId)
+		return
+	case status.UpToDate:
+		h.Log.Printf("Fork %s is not behind %s, nothing to synchronize", repositoryId, sourceRef)
+		h.WriteObjectResponse(w, http.StatusOK, SyncResponse{ForkStatus: *status, SyncStatus: SyncStatusUpToDate})
+		return
+	}
+
+	notFoundMessage := fmt.Sprintf("Repository %s not found in project %s", repositoryId, projectId)
+	statusCode := http.StatusOK
+	request, err := h.findActiveSyncRequest(ctx, organization, projectId, repository.ID, repository.ParentRepository.ID, sourceRef, targetRef, authHeader)
+	if err != nil {
+		h.writeOperationError(w, err, "list fork sync requests", notFoundMessage)
+		return
+	}
+	if request != nil {
+		h.Log.Printf("Fork sync request %d of the same refs is already %s", request.OperationID, request.Status)
+	} else {
+		request, err = h.createSyncRequest(ctx, organization, projectId, repository, sourceRef, targetRef, authHeader)
+		if err != nil {
+			h.writeOperationError(w, err, "create fork sync request", notFoundMessage)
+			return
+		}
+		statusCode = http.StatusCreated
+	}
+
+	if _, err := h.waitForSync(ctx, organization, projectId, repository.ID, request.OperationID, authHeader); err != nil {
+		h.writeOperationError(w, err, "synchronize fork", fmt.Sprintf("Fork sync request %d not found", request.OperationID))
+		return
+	}
+
+	status, err = h.compareFork(ctx, organization, projectId, repository, sourceRef, targetRef, apiVersion, authHeader)
+	if err != nil {
+		h.writeForkError(w, err, "compare fork", repositoryId, projectId)
+		return
+	}
+
+	h.WriteObjectResponse(w, statusCode, SyncResponse{ForkStatus: *status, OperationID: request.OperationID, SyncStatus: SyncStatusCompleted})
+	h.Log.Printf("Successfully synchronized fork %s with fork sync request %d", repositoryId, request.OperationID)
+}
+
+// GET request handler implementation
+// @Summary Get a fork sync request
+// @Description Get the status of a fork sync request, e.g., to poll a synchronization that did not complete within the timeout of the fork sync endpoint
+// @ID get-fork-sync-request
+// @Param organization path string true "Organization name"
+// @Param projectId path string true "Project ID or name"
+// @Param repositoryId path string true "Fork repository ID or name"
+// @Param operationId path int true "Fork sync request operation ID"
+// @Param api-version query string true "API version (e.g., 7.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Produce json
+// @Success 200 {object} SyncOperationResponse "Fork sync request status"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{projectId}/git/repositories/{repositoryId}/forksync/requests/{operationId} [get]
+func (h *getRequestHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	projectId := r.PathValue("projectId")
+	repositoryId := r.PathValue("repositoryId")
+	operationID := r.PathValue("operationId")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, projectId, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+	id, err := strconv.Atoi(operationID)
+	if err != nil || id <= 0 {
+		h.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid operation ID: %s", operationID))
+		return
+	}
+
+	h.Log.Printf("Getting fork sync request %d of repository %s", id, repositoryId)
+
+	request, err := h.getSyncRequest(r.Context(), organization, projectId, repositoryId, id, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "get fork sync request", fmt.Sprintf("Fork sync request %d not found in repository %s", id, repositoryId))
+		return
+	}
+
+	h.WriteObjectResponse(w, http.StatusOK, SyncOperationResponse(mapSyncOperation(request)))
+	h.Log.Printf("Fork sync request %d is %s", id, request.Status)
+}
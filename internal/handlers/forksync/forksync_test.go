@@ -0,0 +1,549 @@
+package forksync
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers"
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/handlerstest"
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/utils"
+	"github.com/rs/zerolog"
+)
+
+// newTestBaseHandler creates a base handler for testing with a mock client
+func newTestBaseHandler(mockClient *handlerstest.MockHTTPClient) *baseHandler {
+	return newBaseHandler(handlerstest.NewHandlerOptions(mockClient))
+}
+
+// Test data constants
+const (
+	testOrg           = "testorg"
+	testProject       = "Platform"
+	testParentProject = "0a1b2c3d-0000-0000-0000-000000000001"
+	testRepository    = "platform-api-fork"
+	testForkID        = "6f2d1e4a-0000-0000-0000-00000000000f"
+	testParentID      = "9c8b7a65-0000-0000-0000-00000000000a"
+	testAPIVersion    = "7.1"
+	testUsername      = "test"
+	testPassword      = "test"
+	parentCommit      = "1111111111111111111111111111111111111111"
+	forkCommit        = "2222222222222222222222222222222222222222"
+	otherCommit       = "3333333333333333333333333333333333333333"
+)
+
+var (
+	repositoryURL        = fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/git/repositories/%s?api-version=%s", testOrg, testProject, testRepository, testAPIVersion)
+	parentRefsURL        = refsURL(testParentProject, testParentID, "heads/main")
+	forkRefsURL          = refsURL(testProject, testForkID, "heads/main")
+	forkSyncURL          = fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/git/repositories/%s/forkSyncRequests?api-version=%s", testOrg, testProject, testForkID, DefaultForkSyncAPIVersion)
+	activeRequestsURL    = fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/git/repositories/%s/forkSyncRequests?includeAbandoned=false&api-version=%s", testOrg, testProject, testForkID, DefaultForkSyncAPIVersion)
+	syncRequestURL       = fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/git/repositories/%s/forkSyncRequests/7?api-version=%s", testOrg, testProject, testForkID, DefaultForkSyncAPIVersion)
+	syncRequestByNameURL = fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/git/repositories/%s/forkSyncRequests/7?api-version=%s", testOrg, testProject, testRepository, DefaultForkSyncAPIVersion)
+
+	forkResp       = `{"id":"` + testForkID + `","name":"platform-api-fork","defaultBranch":"refs/heads/main","isFork":true,"parentRepository":{"id":"` + testParentID + `","name":"platform-api","project":{"id":"` + testParentProject + `","name":"Shared"}}}`
+	notForkResp    = `{"id":"` + testForkID + `","name":"platform-api-fork","defaultBranch":"refs/heads/main"}`
+	noActiveResp   = `{"count":0,"value":[]}`
+	syncRefs       = `"sourceToTargetRefs":[{"sourceRef":"refs/heads/main","targetRef":"refs/heads/main"}]`
+	syncSource     = `"source":{"repositoryId":"` + testParentID + `","projectId":"` + testParentProject + `"}`
+	syncQueued     = handlerstest.MockResponse{StatusCode: http.StatusOK, Body: `{"operationId":7,` + syncSource + `,` + syncRefs + `,"status":"queued"}`}
+	syncInProgress = handlerstest.MockResponse{StatusCode: http.StatusOK, Body: `{"operationId":7,` + syncSource + `,` + syncRefs + `,"status":"inProgress","detailedStatus":{"allSteps":["Queued","Fetching","Updating refs"],"currentStep":1}}`}
+	syncCompleted  = handlerstest.MockResponse{StatusCode: http.StatusOK, Body: `{"operationId":7,` + syncSource + `,` + syncRefs + `,"status":"completed"}`}
+	syncFailed     = handlerstest.MockResponse{StatusCode: http.StatusOK, Body: `{"operationId":7,` + syncSource + `,` + syncRefs + `,"status":"failed","detailedStatus":{"allSteps":["Queued","Fetching"],"currentStep":1,"errorMessage":"TF401030: merge conflict"}}`}
+)
+
+// refsURL returns the URL used to get the refs matching the filter in the repository
+func refsURL(project, repositoryID, filter string) string {
+	query := url.Values{"filter": {filter}, "peelTags": {"true"}, "api-version": {testAPIVersion}}
+	return fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/git/repositories/%s/refs?%s", testOrg, project, repositoryID, query.Encode())
+}
+
+// refsResp returns the refs of the branch filter, with main at the given commit
+func refsResp(commit string) string {
+	return `{"count":2,"value":[{"name":"refs/heads/main","objectId":"` + commit + `"},{"name":"refs/heads/main-old","objectId":"` + otherCommit + `"}]}`
+}
+
+// statsURL returns the URL used to compare the branch of the fork with the given base commit
+func statsURL(branch, baseCommit string) string {
+	query := url.Values{
+		"name":                              {branch},
+		"baseVersionDescriptor.version":     {baseCommit},
+		"baseVersionDescriptor.versionType": {"commit"},
+		"api-version":                       {testAPIVersion},
+	}
+	return fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/git/repositories/%s/stats/branches?%s", testOrg, testProject, testForkID, query.Encode())
+}
+
+// statsResp returns the branch statistics with the given counts
+func statsResp(ahead, behind int) string {
+	return fmt.Sprintf(`{"name":"main","aheadCount":%d,"behindCount":%d}`, ahead, behind)
+}
+
+// forkSyncTestCase is a test case for the handlers of this package
+type forkSyncTestCase struct {
+	name                 string
+	pathValues           map[string]string
+	query                url.Values
+	noAuth               bool
+	body                 string
+	setupMock            func(*handlerstest.MockHTTPClient)
+	expectedStatus       int
+	expectedBody         string
+	expectedBodyContains string
+	expectedRequestCount int
+	verify               func(t *testing.T, mockClient *handlerstest.MockHTTPClient)
+}
+
+// forkPathValues returns the path values of the fork sync endpoints
+func forkPathValues() map[string]string {
+	return map[string]string{"organization": testOrg, "projectId": testProject, "repositoryId": testRepository}
+}
+
+// runForkSyncTests runs the given test cases against the handler built by newHandler
+func runForkSyncTests(t *testing.T, method string, newHandler func(*baseHandler) http.Handler, tests []forkSyncTestCase) {
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := handlerstest.NewMockHTTPClient()
+			if tt.setupMock != nil {
+				tt.setupMock(mockClient)
+			}
+			handler := newHandler(newTestBaseHandler(mockClient))
+
+			target := "/api/test"
+			if len(tt.query) > 0 {
+				target += "?" + tt.query.Encode()
+			}
+			var body io.Reader
+			if tt.body != "" {
+				body = strings.NewReader(tt.body)
+			}
+			req := httptest.NewRequest(method, target, body)
+			for key, value := range tt.pathValues {
+				req.SetPathValue(key, value)
+			}
+			if !tt.noAuth {
+				req.SetBasicAuth(testUsername, testPassword)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("Status code = %d, want %d. Body: %s", rr.Code, tt.expectedStatus, rr.Body.String())
+			}
+			if tt.expectedBody != "" && rr.Body.String() != tt.expectedBody {
+				t.Errorf("Body = %s, want %s", rr.Body.String(), tt.expectedBody)
+			}
+			if tt.expectedBodyContains != "" && !strings.Contains(rr.Body.String(), tt.expectedBodyContains) {
+				t.Errorf("Body = %s, want it to contain %s", rr.Body.String(), tt.expectedBodyContains)
+			}
+			if mockClient.RequestCount() != tt.expectedRequestCount {
+				t.Errorf("Request count = %d, want %d", mockClient.RequestCount(), tt.expectedRequestCount)
+			}
+			if tt.verify != nil {
+				tt.verify(t, mockClient)
+			}
+		})
+	}
+}
+
+// apiVersionQuery returns the query with the test API version
+func apiVersionQuery() url.Values {
+	return url.Values{"api-version": {testAPIVersion}}
+}
+
+// requestBody returns the body of the n-th request sent to Azure DevOps
+func requestBody(t *testing.T, mockClient *handlerstest.MockHTTPClient, n int) string {
+	t.Helper()
+	if len(mockClient.Requests) <= n || mockClient.Requests[n].Body == nil {
+		t.Fatalf("request %d has no body", n)
+	}
+	body, err := io.ReadAll(mockClient.Requests[n].Body)
+	if err != nil {
+		t.Fatalf("failed to read request %d body: %v", n, err)
+	}
+	return string(body)
+}
+
+// containsRequest reports whether a request was sent with the given method and URL
+func containsRequest(mockClient *handlerstest.MockHTTPClient, method, url string) bool {
+	for _, req := range mockClient.Requests {
+		if req.Method+" "+req.URL.String() == method+" "+url {
+			return true
+		}
+	}
+	return false
+}
+
+// shortenOperationPolling makes the fork sync requests polled by the handlers complete quickly in tests
+func shortenOperationPolling(t *testing.T, interval, timeout time.Duration) {
+	poller := syncPoller
+	syncPoller = utils.Poller{Interval: interval, Timeout: timeout}
+	t.Cleanup(func() {
+		syncPoller = poller
+	})
+}
+
+// Test constructor functions
+func TestForkSyncConstructors(t *testing.T) {
+	client := &http.Client{}
+	logger := zerolog.New(os.Stdout).With().Timestamp().Logger()
+	opts := handlers.HandlerOptions{
+		Client: client,
+		Log:    &logger,
+	}
+
+	if h := GetForkStatus(opts); h == nil {
+		t.Fatalf("GetForkStatus should return a non-nil handler")
+	}
+	if h := PostForkSync(opts); h == nil {
+		t.Fatalf("PostForkSync should return a non-nil handler")
+	}
+	if h := GetForkSyncRequest(opts); h == nil {
+		t.Fatalf("GetForkSyncRequest should return a non-nil handler")
+	}
+}
+
+// Test GET handler
+func TestGetHandler_ServeHTTP(t *testing.T) {
+	runForkSyncTests(t, "GET", func(b *baseHandler) http.Handler { return &getHandler{baseHandler: b} }, []forkSyncTestCase{
+		{
+			name:       "fork behind its parent repository",
+			pathValues: forkPathValues(),
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(repositoryURL, http.StatusOK, forkResp)
+				m.SetResponse(parentRefsURL, http.StatusOK, refsResp(parentCommit))
+				m.SetResponse(forkRefsURL, http.StatusOK, refsResp(forkCommit))
+				m.SetResponse(statsURL("main", parentCommit), http.StatusOK, statsResp(1, 4))
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBody:         `{"repositoryId":"` + testForkID + `","parentRepositoryId":"` + testParentID + `","sourceRef":"refs/heads/main","targetRef":"refs/heads/main","sourceCommitId":"` + parentCommit + `","targetCommitId":"` + forkCommit + `","aheadCount":1,"behindCount":4,"upToDate":false}`,
+			expectedRequestCount: 4,
+		},
+		{
+			name:       "fork at the commit of its parent repository",
+			pathValues: forkPathValues(),
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(repositoryURL, http.StatusOK, forkResp)
+				m.SetResponse(parentRefsURL, http.StatusOK, refsResp(parentCommit))
+				m.SetResponse(forkRefsURL, http.StatusOK, refsResp(parentCommit))
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `"aheadCount":0,"behindCount":0,"upToDate":true`,
+			expectedRequestCount: 3,
+		},
+		{
+			name:       "annotated tag of the parent repository compared by its commit",
+			pathValues: forkPathValues(),
+			query:      url.Values{"api-version": {testAPIVersion}, "sourceRef": {"refs/tags/v1.0"}, "targetRef": {"main"}},
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(repositoryURL, http.StatusOK, forkResp)
+				m.SetResponse(refsURL(testParentProject, testParentID, "tags/v1.0"), http.StatusOK, `{"count":1,"value":[{"name":"refs/tags/v1.0","objectId":"`+otherCommit+`","peeledObjectId":"`+parentCommit+`"}]}`)
+				m.SetResponse(forkRefsURL, http.StatusOK, refsResp(forkCommit))
+				m.SetResponse(statsURL("main", parentCommit), http.StatusOK, statsResp(2, 0))
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `"sourceRef":"refs/tags/v1.0","targetRef":"refs/heads/main","sourceCommitId":"` + parentCommit + `","targetCommitId":"` + forkCommit + `","aheadCount":2,"behindCount":0,"upToDate":true`,
+			expectedRequestCount: 4,
+		},
+		{
+			name:       "ref not found in the parent repository",
+			pathValues: forkPathValues(),
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(repositoryURL, http.StatusOK, forkResp)
+				m.SetResponse(parentRefsURL, http.StatusOK, `{"count":1,"value":[{"name":"refs/heads/main-old","objectId":"`+otherCommit+`"}]}`)
+			},
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Ref refs/heads/main not found in repository platform-api",
+			expectedRequestCount: 2,
+		},
+		{
+			name:       "target ref that is not a branch",
+			pathValues: forkPathValues(),
+			query:      url.Values{"api-version": {testAPIVersion}, "targetRef": {"refs/tags/v1.0"}},
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(repositoryURL, http.StatusOK, forkResp)
+			},
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid fork sync: targetRef must be a branch, got 'refs/tags/v1.0'",
+			expectedRequestCount: 1,
+		},
+		{
+			name:       "repository that is not a fork",
+			pathValues: forkPathValues(),
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(repositoryURL, http.StatusOK, notForkResp)
+			},
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid fork: repository platform-api-fork is not a fork",
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "repository not found",
+			pathValues:           forkPathValues(),
+			query:                apiVersionQuery(),
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Repository platform-api-fork not found in project Platform",
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "missing API version",
+			pathValues:           forkPathValues(),
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "API version is required",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "missing basic auth",
+			pathValues:           forkPathValues(),
+			query:                apiVersionQuery(),
+			noAuth:               true,
+			expectedStatus:       http.StatusUnauthorized,
+			expectedBody:         "Request rejected due to missing or invalid Basic authentication",
+			expectedRequestCount: 0,
+		},
+	})
+}
+
+// Test POST handler
+func TestPostHandler_ServeHTTP(t *testing.T) {
+	t.Setenv("GIT_FORK_SYNC_API_VERSION", "")
+	shortenOperationPolling(t, time.Millisecond, time.Second)
+
+	runForkSyncTests(t, "POST", func(b *baseHandler) http.Handler { return &postHandler{baseHandler: b} }, []forkSyncTestCase{
+		{
+			name:       "fork synchronized after polling the fork sync request",
+			pathValues: forkPathValues(),
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(repositoryURL, http.StatusOK, forkResp)
+				m.SetResponseSequence(parentRefsURL, handlerstest.MockResponse{StatusCode: http.StatusOK, Body: refsResp(parentCommit)})
+				m.SetResponseSequence(forkRefsURL, handlerstest.MockResponse{StatusCode: http.StatusOK, Body: refsResp(forkCommit)}, handlerstest.MockResponse{StatusCode: http.StatusOK, Body: refsResp(parentCommit)})
+				m.SetResponse(statsURL("main", parentCommit), http.StatusOK, statsResp(0, 4))
+				m.SetResponse(activeRequestsURL, http.StatusOK, noActiveResp)
+				m.SetMethodResponse("POST", forkSyncURL, http.StatusCreated, syncQueued.Body)
+				m.SetResponseSequence(syncRequestURL, syncInProgress, syncCompleted)
+			},
+			expectedStatus:       http.StatusCreated,
+			expectedBody:         `{"repositoryId":"` + testForkID + `","parentRepositoryId":"` + testParentID + `","sourceRef":"refs/heads/main","targetRef":"refs/heads/main","sourceCommitId":"` + parentCommit + `","targetCommitId":"` + parentCommit + `","aheadCount":0,"behindCount":0,"upToDate":true,"operationId":7,"syncStatus":"completed"}`,
+			expectedRequestCount: 10,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				want := `{` + syncSource + `,` + syncRefs + `}`
+				if got := requestBody(t, m, 5); got != want {
+					t.Errorf("Request body = %s, want %s", got, want)
+				}
+			},
+		},
+		{
+			name:       "fork not behind its parent repository",
+			pathValues: forkPathValues(),
+			query:      apiVersionQuery(),
+			body:       `{"sourceRef":"main"}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(repositoryURL, http.StatusOK, forkResp)
+				m.SetResponse(parentRefsURL, http.StatusOK, refsResp(parentCommit))
+				m.SetResponse(forkRefsURL, http.StatusOK, refsResp(forkCommit))
+				m.SetResponse(statsURL("main", parentCommit), http.StatusOK, statsResp(3, 0))
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `"aheadCount":3,"behindCount":0,"upToDate":true,"syncStatus":"upToDate"}`,
+			expectedRequestCount: 4,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				if containsRequest(m, "POST", forkSyncURL) {
+					t.Errorf("No fork sync request should be created when the fork is up to date")
+				}
+			},
+		},
+		{
+			name:       "running fork sync request of the same refs waited for",
+			pathValues: forkPathValues(),
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(repositoryURL, http.StatusOK, forkResp)
+				m.SetResponseSequence(parentRefsURL, handlerstest.MockResponse{StatusCode: http.StatusOK, Body: refsResp(parentCommit)})
+				m.SetResponseSequence(forkRefsURL, handlerstest.MockResponse{StatusCode: http.StatusOK, Body: refsResp(forkCommit)}, handlerstest.MockResponse{StatusCode: http.StatusOK, Body: refsResp(parentCommit)})
+				m.SetResponse(statsURL("main", parentCommit), http.StatusOK, statsResp(0, 4))
+				m.SetResponse(activeRequestsURL, http.StatusOK, `{"count":1,"value":[`+syncInProgress.Body+`]}`)
+				m.SetResponseSequence(syncRequestURL, syncCompleted)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `"upToDate":true,"operationId":7,"syncStatus":"completed"}`,
+			expectedRequestCount: 8,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				if containsRequest(m, "POST", forkSyncURL) {
+					t.Errorf("No fork sync request should be created when one is already running")
+				}
+			},
+		},
+		{
+			name:       "branch missing in the fork created by the synchronization",
+			pathValues: forkPathValues(),
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(repositoryURL, http.StatusOK, forkResp)
+				m.SetResponseSequence(parentRefsURL, handlerstest.MockResponse{StatusCode: http.StatusOK, Body: refsResp(parentCommit)})
+				m.SetResponseSequence(forkRefsURL, handlerstest.MockResponse{StatusCode: http.StatusOK, Body: noActiveResp}, handlerstest.MockResponse{StatusCode: http.StatusOK, Body: refsResp(parentCommit)})
+				m.SetResponse(activeRequestsURL, http.StatusOK, noActiveResp)
+				m.SetMethodResponse("POST", forkSyncURL, http.StatusCreated, syncQueued.Body)
+				m.SetResponseSequence(syncRequestURL, syncCompleted)
+			},
+			expectedStatus:       http.StatusCreated,
+			expectedBodyContains: `"targetCommitId":"` + parentCommit + `"`,
+			expectedRequestCount: 8,
+		},
+		{
+			name:       "failed fork sync request",
+			pathValues: forkPathValues(),
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(repositoryURL, http.StatusOK, forkResp)
+				m.SetResponse(parentRefsURL, http.StatusOK, refsResp(parentCommit))
+				m.SetResponse(forkRefsURL, http.StatusOK, refsResp(forkCommit))
+				m.SetResponse(statsURL("main", parentCommit), http.StatusOK, statsResp(1, 4))
+				m.SetResponse(activeRequestsURL, http.StatusOK, noActiveResp)
+				m.SetMethodResponse("POST", forkSyncURL, http.StatusCreated, syncQueued.Body)
+				m.SetResponseSequence(syncRequestURL, syncFailed)
+			},
+			expectedStatus:       http.StatusInternalServerError,
+			expectedBody:         "Failed to synchronize fork: fork sync request 7 failed: TF401030: merge conflict",
+			expectedRequestCount: 7,
+		},
+		{
+			name:                 "invalid JSON",
+			pathValues:           forkPathValues(),
+			query:                apiVersionQuery(),
+			body:                 `{"sourceRef":`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid JSON in request body",
+			expectedRequestCount: 0,
+		},
+		{
+			name:       "repository that is not a fork",
+			pathValues: forkPathValues(),
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(repositoryURL, http.StatusOK, notForkResp)
+			},
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid fork: repository platform-api-fork is not a fork",
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "missing basic auth",
+			pathValues:           forkPathValues(),
+			query:                apiVersionQuery(),
+			noAuth:               true,
+			expectedStatus:       http.StatusUnauthorized,
+			expectedBody:         "Request rejected due to missing or invalid Basic authentication",
+			expectedRequestCount: 0,
+		},
+	})
+}
+
+// Test POST handler when the fork sync request does not complete in time
+func TestPostHandler_OperationTimeout(t *testing.T) {
+	t.Setenv("GIT_FORK_SYNC_API_VERSION", "")
+	shortenOperationPolling(t, 10*time.Millisecond, 5*time.Millisecond)
+
+	runForkSyncTests(t, "POST", func(b *baseHandler) http.Handler { return &postHandler{baseHandler: b} }, []forkSyncTestCase{
+		{
+			name:       "fork sync request still queued",
+			pathValues: forkPathValues(),
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(repositoryURL, http.StatusOK, forkResp)
+				m.SetResponse(parentRefsURL, http.StatusOK, refsResp(parentCommit))
+				m.SetResponse(forkRefsURL, http.StatusOK, refsResp(forkCommit))
+				m.SetResponse(statsURL("main", parentCommit), http.StatusOK, statsResp(0, 4))
+				m.SetResponse(activeRequestsURL, http.StatusOK, noActiveResp)
+				m.SetMethodResponse("POST", forkSyncURL, http.StatusCreated, syncQueued.Body)
+				m.SetResponseSequence(syncRequestURL, syncQueued)
+			},
+			expectedStatus:       http.StatusGatewayTimeout,
+			expectedBody:         "Failed to synchronize fork: the operation did not complete within 5ms, retry the request",
+			expectedRequestCount: 7,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				// The requests made before the polling are bounded too
+				for i, req := range m.Requests {
+					if _, ok := req.Context().Deadline(); !ok {
+						t.Errorf("request %d (%s %s) has no deadline", i, req.Method, req.URL)
+					}
+				}
+			},
+		},
+		{
+			name:       "request interrupted by the deadline",
+			pathValues: forkPathValues(),
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(repositoryURL, http.StatusOK, forkResp)
+				m.SetError(parentRefsURL, context.DeadlineExceeded)
+			},
+			expectedStatus:       http.StatusGatewayTimeout,
+			expectedBody:         "Failed to compare fork: the operation did not complete within 5ms, retry the request",
+			expectedRequestCount: 2,
+		},
+	})
+}
+
+// Test GET fork sync request handler
+func TestGetRequestHandler_ServeHTTP(t *testing.T) {
+	t.Setenv("GIT_FORK_SYNC_API_VERSION", "")
+	requestPathValues := func(operationID string) map[string]string {
+		pathValues := forkPathValues()
+		pathValues["operationId"] = operationID
+		return pathValues
+	}
+
+	runForkSyncTests(t, "GET", func(b *baseHandler) http.Handler { return &getRequestHandler{baseHandler: b} }, []forkSyncTestCase{
+		{
+			name:       "fork sync request in progress",
+			pathValues: requestPathValues("7"),
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(syncRequestByNameURL, http.StatusOK, syncInProgress.Body)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBody:         `{"operationId":7,"status":"inProgress","refs":[{"sourceRef":"refs/heads/main","targetRef":"refs/heads/main"}],"currentStep":"Fetching"}`,
+			expectedRequestCount: 1,
+		},
+		{
+			name:       "failed fork sync request",
+			pathValues: requestPathValues("7"),
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(syncRequestByNameURL, http.StatusOK, syncFailed.Body)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `"status":"failed","refs":[{"sourceRef":"refs/heads/main","targetRef":"refs/heads/main"}],"currentStep":"Fetching","errorMessage":"TF401030: merge conflict"}`,
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "fork sync request not found",
+			pathValues:           requestPathValues("7"),
+			query:                apiVersionQuery(),
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Fork sync request 7 not found in repository platform-api-fork",
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "invalid operation ID",
+			pathValues:           requestPathValues("latest"),
+			query:                apiVersionQuery(),
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid operation ID: latest",
+			expectedRequestCount: 0,
+		},
+	})
+}
@@ -0,0 +1,68 @@
+package forksync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/utils"
+)
+
+// syncPoller polls the fork sync requests until the fork is synchronized (a variable, so that tests can shorten it)
+var syncPoller = utils.DefaultPoller()
+
+// syncError is returned when a fork sync request ends without completing (failed or abandoned)
+type syncError struct {
+	Request GitForkSyncRequest
+}
+
+func (e *syncError) Error() string {
+	message := ""
+	if e.Request.DetailedStatus != nil {
+		message = e.Request.DetailedStatus.ErrorMessage
+	}
+	return fmt.Sprintf("fork sync request %d %s: %s", e.Request.OperationID, e.Request.Status, message)
+}
+
+// getSyncRequest returns the fork sync request with the given operation ID
+func (h *baseHandler) getSyncRequest(ctx context.Context, organization, projectId, repositoryId string, operationID int, authHeader string) (*GitForkSyncRequest, error) {
+	url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/git/repositories/%s/forkSyncRequests/%d?api-version=%s", organization, projectId, repositoryId, operationID, forkSyncAPIVersion())
+
+	body, err := h.DoAzureDevOpsRequestWithContext(ctx, "GET", url, authHeader, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var request GitForkSyncRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal fork sync request: %w", err)
+	}
+	return &request, nil
+}
+
+// waitForSync polls the fork sync request until it completes
+// *syncError is returned if it fails or is abandoned, utils.ErrPollTimeout if it does not complete in time
+func (h *baseHandler) waitForSync(ctx context.Context, organization, projectId, repositoryId string, operationID int, authHeader string) (*GitForkSyncRequest, error) {
+	var request *GitForkSyncRequest
+	err := syncPoller.Poll(ctx, func() (bool, error) {
+		var err error
+		request, err = h.getSyncRequest(ctx, organization, projectId, repositoryId, operationID, authHeader)
+		if err != nil {
+			return false, err
+		}
+
+		switch request.Status {
+		case SyncStatusCompleted:
+			return true, nil
+		case SyncStatusFailed, SyncStatusAbandoned:
+			return false, &syncError{Request: *request}
+		}
+
+		h.Log.Printf("Fork sync request %d is %s, waiting %s", operationID, request.Status, syncPoller.Interval)
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return request, nil
+}
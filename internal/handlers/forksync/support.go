@@ -0,0 +1,80 @@
+package forksync
+
+import (
+	"os"
+	"strings"
+)
+
+// normalizeRefName returns the full name of the ref: branch names are completed (e.g., main becomes refs/heads/main),
+// full ref names (e.g., refs/tags/v1.0) are kept
+func normalizeRefName(ref string) string {
+	ref = strings.Trim(strings.TrimSpace(ref), "/")
+	if ref == "" || strings.HasPrefix(ref, "refs/") {
+		return ref
+	}
+	return "refs/heads/" + ref
+}
+
+// forkSyncAPIVersion returns the API version of the fork sync requests API
+func forkSyncAPIVersion() string {
+	if apiVersion := os.Getenv("GIT_FORK_SYNC_API_VERSION"); apiVersion != "" {
+		return apiVersion
+	}
+	return DefaultForkSyncAPIVersion
+}
+
+// findRef returns the ref with exactly the given name, nil if there is none
+// The refs API filters by prefix, so that refs/heads/main also returns refs/heads/main-old
+func findRef(refs []GitRef, name string) *GitRef {
+	for i := range refs {
+		if refs[i].Name == name {
+			return &refs[i]
+		}
+	}
+	return nil
+}
+
+// refCommit returns the commit of the ref, which is the peeled object of an annotated tag
+func refCommit(ref *GitRef) string {
+	if ref.PeeledObjectID != "" {
+		return ref.PeeledObjectID
+	}
+	return ref.ObjectID
+}
+
+// isActive reports whether the fork sync request is still running
+func isActive(request *GitForkSyncRequest) bool {
+	return request.Status == SyncStatusQueued || request.Status == SyncStatusInProgress
+}
+
+// matchesSyncRequest reports whether the fork sync request synchronizes the ref of the parent repository to the branch of the fork
+func matchesSyncRequest(request *GitForkSyncRequest, parentRepositoryID, sourceRef, targetRef string) bool {
+	if !strings.EqualFold(request.Source.RepositoryID, parentRepositoryID) {
+		return false
+	}
+	for _, ref := range request.SourceToTargetRefs {
+		if ref.SourceRef == sourceRef && ref.TargetRef == targetRef {
+			return true
+		}
+	}
+	return false
+}
+
+// mapSyncOperation converts an Azure DevOps fork sync request to the operation returned by the plugin
+func mapSyncOperation(request *GitForkSyncRequest) SyncOperation {
+	operation := SyncOperation{
+		OperationID: request.OperationID,
+		Status:      request.Status,
+		Refs:        request.SourceToTargetRefs,
+	}
+	if operation.Refs == nil {
+		operation.Refs = []SourceToTargetRef{}
+	}
+	if detail := request.DetailedStatus; detail != nil {
+		operation.ErrorMessage = detail.ErrorMessage
+		if detail.CurrentStep >= 0 && detail.CurrentStep < len(detail.AllSteps) {
+			operation.CurrentStep = detail.AllSteps[detail.CurrentStep]
+		}
+	}
+	return operation
+}
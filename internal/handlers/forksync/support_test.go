@@ -0,0 +1,109 @@
+package forksync
+
+import (
+	"testing"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/gitrepository"
+)
+
+func TestNormalizeRefName(t *testing.T) {
+	tests := map[string]string{
+		"main":            "refs/heads/main",
+		" release/1.0/ ":  "refs/heads/release/1.0",
+		"refs/tags/v1.0":  "refs/tags/v1.0",
+		"refs/heads/main": "refs/heads/main",
+		"":                "",
+	}
+	for ref, expected := range tests {
+		if got := normalizeRefName(ref); got != expected {
+			t.Errorf("normalizeRefName(%q) = %q, want %q", ref, got, expected)
+		}
+	}
+}
+
+func TestForkSyncAPIVersion(t *testing.T) {
+	t.Setenv("GIT_FORK_SYNC_API_VERSION", "")
+	if got := forkSyncAPIVersion(); got != DefaultForkSyncAPIVersion {
+		t.Errorf("forkSyncAPIVersion() = %s, want %s", got, DefaultForkSyncAPIVersion)
+	}
+
+	t.Setenv("GIT_FORK_SYNC_API_VERSION", "7.2-preview.1")
+	if got := forkSyncAPIVersion(); got != "7.2-preview.1" {
+		t.Errorf("forkSyncAPIVersion() = %s, want 7.2-preview.1", got)
+	}
+}
+
+func TestMatchesSyncRequest(t *testing.T) {
+	request := &GitForkSyncRequest{
+		Source:             GlobalGitRepositoryKey{RepositoryID: "9C8B7A65-0000-0000-0000-00000000000A"},
+		SourceToTargetRefs: []SourceToTargetRef{{SourceRef: "refs/heads/main", TargetRef: "refs/heads/upstream"}},
+	}
+
+	tests := []struct {
+		name      string
+		parentID  string
+		sourceRef string
+		targetRef string
+		expected  bool
+	}{
+		{name: "same refs", parentID: testParentID, sourceRef: "refs/heads/main", targetRef: "refs/heads/upstream", expected: true},
+		{name: "other target ref", parentID: testParentID, sourceRef: "refs/heads/main", targetRef: "refs/heads/main", expected: false},
+		{name: "other parent repository", parentID: testForkID, sourceRef: "refs/heads/main", targetRef: "refs/heads/upstream", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchesSyncRequest(request, tt.parentID, tt.sourceRef, tt.targetRef); got != tt.expected {
+				t.Errorf("matchesSyncRequest() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestResolveRefs(t *testing.T) {
+	fork := &gitrepository.GitRepository{ID: testForkID, IsFork: true, ParentRepository: &gitrepository.GitRepositoryRef{ID: testParentID}}
+	tests := []struct {
+		name           string
+		defaultBranch  string
+		sourceRef      string
+		targetRef      string
+		expectedSource string
+		expectedTarget string
+		expectedError  string
+	}{
+		{name: "default branch of the fork", defaultBranch: "refs/heads/main", expectedSource: "refs/heads/main", expectedTarget: "refs/heads/main"},
+		{name: "target defaults to source", defaultBranch: "refs/heads/main", sourceRef: "release/1.0", expectedSource: "refs/heads/release/1.0", expectedTarget: "refs/heads/release/1.0"},
+		{name: "tag to a branch", defaultBranch: "refs/heads/main", sourceRef: "refs/tags/v1.0", targetRef: "upstream/v1.0", expectedSource: "refs/tags/v1.0", expectedTarget: "refs/heads/upstream/v1.0"},
+		{name: "tag as target", defaultBranch: "refs/heads/main", sourceRef: "refs/tags/v1.0", expectedError: "targetRef must be a branch, got 'refs/tags/v1.0'"},
+		{name: "empty fork", expectedError: "sourceRef is required, the fork has no default branch"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fork.DefaultBranch = tt.defaultBranch
+			source, target, err := resolveRefs(fork, tt.sourceRef, tt.targetRef)
+			if tt.expectedError != "" {
+				if err == nil || err.Error() != tt.expectedError {
+					t.Fatalf("resolveRefs() error = %v, want %s", err, tt.expectedError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveRefs() error = %v", err)
+			}
+			if source != tt.expectedSource || target != tt.expectedTarget {
+				t.Errorf("resolveRefs() = %s, %s, want %s, %s", source, target, tt.expectedSource, tt.expectedTarget)
+			}
+		})
+	}
+}
+
+func TestMapSyncOperation(t *testing.T) {
+	operation := mapSyncOperation(&GitForkSyncRequest{OperationID: 3, Status: SyncStatusQueued, DetailedStatus: &GitForkOperationStatusDetail{CurrentStep: 2, AllSteps: []string{"Queued"}}})
+	if operation.Refs == nil || len(operation.Refs) != 0 {
+		t.Errorf("Refs = %v, want an empty list", operation.Refs)
+	}
+	if operation.CurrentStep != "" {
+		t.Errorf("CurrentStep = %q, want empty for a step out of range", operation.CurrentStep)
+	}
+}
@@ -0,0 +1,125 @@
+package forksync
+
+// ForkStatus represents the comparison of a branch of a fork with a ref of its parent repository
+type ForkStatus struct {
+	RepositoryID       string `json:"repositoryId"`
+	ParentRepositoryID string `json:"parentRepositoryId"`
+	SourceRef          string `json:"sourceRef"` // Ref of the parent repository, e.g., refs/heads/main
+	TargetRef          string `json:"targetRef"` // Branch of the fork, e.g., refs/heads/main
+	SourceCommitID     string `json:"sourceCommitId"`
+	TargetCommitID     string `json:"targetCommitId"`
+	AheadCount         int    `json:"aheadCount"`  // Commits of the fork branch that are not in the parent ref
+	BehindCount        int    `json:"behindCount"` // Commits of the parent ref that are not in the fork branch
+	UpToDate           bool   `json:"upToDate"`    // true if the fork branch is not behind the parent ref
+}
+
+// ForkStatusResponse represents the response of the fork status endpoint
+type ForkStatusResponse ForkStatus
+
+// SyncRequest represents the request body for synchronizing a branch of a fork with its parent repository
+type SyncRequest struct {
+	SourceRef string `json:"sourceRef,omitempty"` // Ref of the parent repository, defaults to the default branch of the fork
+	TargetRef string `json:"targetRef,omitempty"` // Branch of the fork, defaults to SourceRef
+}
+
+// SyncResponse represents the response of the fork sync endpoint
+type SyncResponse struct {
+	ForkStatus         // Status after the synchronization
+	OperationID int    `json:"operationId,omitempty"` // Fork sync request, not set if the fork was already up to date
+	SyncStatus  string `json:"syncStatus"`            // upToDate (nothing to synchronize) or completed
+}
+
+// SyncOperation represents a fork sync request returned by the plugin
+type SyncOperation struct {
+	OperationID  int                 `json:"operationId"`
+	Status       string              `json:"status"` // queued, inProgress, completed, failed or abandoned
+	Refs         []SourceToTargetRef `json:"refs"`
+	CurrentStep  string              `json:"currentStep,omitempty"`
+	ErrorMessage string              `json:"errorMessage,omitempty"`
+}
+
+// SyncOperationResponse represents the response of the fork sync request endpoint
+type SyncOperationResponse SyncOperation
+
+// ---
+
+// GlobalGitRepositoryKey represents the repository a fork is synchronized from
+type GlobalGitRepositoryKey struct {
+	RepositoryID string `json:"repositoryId"`
+	ProjectID    string `json:"projectId,omitempty"`
+}
+
+// SourceToTargetRef represents a ref of the parent repository and the branch of the fork it is synchronized to
+type SourceToTargetRef struct {
+	SourceRef string `json:"sourceRef"`
+	TargetRef string `json:"targetRef"`
+}
+
+// GitForkSyncRequestParameters represents the request body of:
+// POST https://dev.azure.com/{organization}/{project}/_apis/git/repositories/{repositoryId}/forkSyncRequests
+type GitForkSyncRequestParameters struct {
+	Source             GlobalGitRepositoryKey `json:"source"`
+	SourceToTargetRefs []SourceToTargetRef    `json:"sourceToTargetRefs"`
+}
+
+// GitForkSyncRequest represents a fork sync request of Azure DevOps, from:
+// GET https://dev.azure.com/{organization}/{project}/_apis/git/repositories/{repositoryId}/forkSyncRequests/{forkSyncOperationId}
+type GitForkSyncRequest struct {
+	OperationID        int                           `json:"operationId"`
+	Source             GlobalGitRepositoryKey        `json:"source"`
+	SourceToTargetRefs []SourceToTargetRef           `json:"sourceToTargetRefs"`
+	Status             string                        `json:"status"`
+	DetailedStatus     *GitForkOperationStatusDetail `json:"detailedStatus,omitempty"`
+}
+
+// GitForkOperationStatusDetail represents the progress of a fork sync request
+type GitForkOperationStatusDetail struct {
+	AllSteps     []string `json:"allSteps,omitempty"`
+	CurrentStep  int      `json:"currentStep"`
+	ErrorMessage string   `json:"errorMessage,omitempty"`
+}
+
+// GitForkSyncRequestList represents the response from:
+// GET https://dev.azure.com/{organization}/{project}/_apis/git/repositories/{repositoryId}/forkSyncRequests?includeAbandoned=false
+type GitForkSyncRequestList struct {
+	Count int                  `json:"count"`
+	Value []GitForkSyncRequest `json:"value"`
+}
+
+// GitRef represents a Git ref of Azure DevOps
+type GitRef struct {
+	Name           string `json:"name"`
+	ObjectID       string `json:"objectId"`
+	PeeledObjectID string `json:"peeledObjectId,omitempty"`
+}
+
+// GitRefList represents the response from:
+// GET https://dev.azure.com/{organization}/{project}/_apis/git/repositories/{repositoryId}/refs?filter={filter}
+type GitRefList struct {
+	Count int      `json:"count"`
+	Value []GitRef `json:"value"`
+}
+
+// GitBranchStats represents the ahead and behind counts of a branch, from:
+// GET https://dev.azure.com/{organization}/{project}/_apis/git/repositories/{repositoryId}/stats/branches?name={name}&baseVersionDescriptor.version={commitId}
+type GitBranchStats struct {
+	Name        string `json:"name"`
+	AheadCount  int    `json:"aheadCount"`
+	BehindCount int    `json:"behindCount"`
+}
+
+// Statuses of a fork sync request
+const (
+	SyncStatusQueued     = "queued"
+	SyncStatusInProgress = "inProgress"
+	SyncStatusCompleted  = "completed"
+	SyncStatusFailed     = "failed"
+	SyncStatusAbandoned  = "abandoned"
+)
+
+// SyncStatusUpToDate is returned instead of an operation status when the fork did not need to be synchronized
+const SyncStatusUpToDate = "upToDate"
+
+// DefaultForkSyncAPIVersion is the API version of the fork sync requests API, which is only available as preview
+// It can be changed with the GIT_FORK_SYNC_API_VERSION environment variable
+const DefaultForkSyncAPIVersion = "7.1-preview.1"
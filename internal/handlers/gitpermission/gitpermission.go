@@ -0,0 +1,477 @@
+package gitpermission
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	neturl "net/url"
+	"sort"
+	"strings"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers"
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/gitrepository"
+)
+
+// Handler constructors
+func GetRepositoryPermissions(opts handlers.HandlerOptions) handlers.Handler {
+	return &getHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func PutRepositoryPermissions(opts handlers.HandlerOptions) handlers.Handler {
+	return &putHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func DeleteRepositoryPermission(opts handlers.HandlerOptions) handlers.Handler {
+	return &deleteHandler{baseHandler: newBaseHandler(opts)}
+}
+
+// Interface compliance verification
+var _ handlers.Handler = &getHandler{}
+var _ handlers.Handler = &putHandler{}
+var _ handlers.Handler = &deleteHandler{}
+
+// Base handler with common functionality
+type baseHandler struct {
+	*handlers.BaseHandler
+}
+
+// Constructor for the base handler
+func newBaseHandler(opts handlers.HandlerOptions) *baseHandler {
+	return &baseHandler{BaseHandler: handlers.NewBaseHandler(opts)}
+}
+
+// Handler types embedding the base handler
+type getHandler struct {
+	*baseHandler
+}
+
+type putHandler struct {
+	*baseHandler
+}
+
+type deleteHandler struct {
+	*baseHandler
+}
+
+// errIdentityNotFound is returned when a descriptor does not match any identity
+type errIdentityNotFound struct {
+	Descriptor string
+}
+
+func (e *errIdentityNotFound) Error() string {
+	return fmt.Sprintf("identity '%s' not found", e.Descriptor)
+}
+
+// getRepository returns the repository, used to build the security token from the IDs of the project and of the repository
+func (h *baseHandler) getRepository(organization, projectID, repositoryID, apiVersion, authHeader string) (*gitrepository.GitRepository, error) {
+	url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/git/repositories/%s?api-version=%s", organization, projectID, repositoryID, apiVersion)
+
+	body, err := h.DoAzureDevOpsRequest("GET", url, authHeader, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var repository gitrepository.GitRepository
+	if err := json.Unmarshal(body, &repository); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal repository: %w", err)
+	}
+	if repository.ID == "" || repository.Project == nil || repository.Project.ID == "" {
+		return nil, fmt.Errorf("repository %s returned without its ID or project", repositoryID)
+	}
+	return &repository, nil
+}
+
+// getAccessControlList returns the access control list of the token, with the inherited and effective permissions
+// An empty list is returned when no permission is set on the token
+func (h *baseHandler) getAccessControlList(organization, token, apiVersion, authHeader string) (*AccessControlList, error) {
+	url := fmt.Sprintf("https://dev.azure.com/%s/_apis/accesscontrollists/%s?token=%s&includeExtendedInfo=true&api-version=%s", organization, GitRepositoriesNamespaceID, neturl.QueryEscape(token), apiVersion)
+
+	body, err := h.DoAzureDevOpsRequest("GET", url, authHeader, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var lists AccessControlListCollection
+	if err := json.Unmarshal(body, &lists); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal access control lists: %w", err)
+	}
+
+	acl := &AccessControlList{InheritPermissions: true, Token: token}
+	for _, list := range lists.Value {
+		if strings.EqualFold(list.Token, token) {
+			acl = &list
+			break
+		}
+	}
+	if acl.AcesDictionary == nil {
+		acl.AcesDictionary = map[string]AccessControlEntry{}
+	}
+	return acl, nil
+}
+
+// lookupIdentities returns the identities with the given descriptors
+// filter is the query parameter of the lookup: subjectDescriptors or descriptors
+func (h *baseHandler) lookupIdentities(organization, filter string, descriptors []string, apiVersion, authHeader string) ([]Identity, error) {
+	if len(descriptors) == 0 {
+		return []Identity{}, nil
+	}
+
+	url := fmt.Sprintf("https://vssps.dev.azure.com/%s/_apis/identities?%s=%s&queryMembership=None&api-version=%s", organization, filter, neturl.QueryEscape(strings.Join(descriptors, ",")), apiVersion)
+	body, err := h.DoAzureDevOpsRequest("GET", url, authHeader, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var identities IdentityList
+	if err := json.Unmarshal(body, &identities); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal identities: %w", err)
+	}
+
+	result := []Identity{}
+	for _, identity := range identities.Value {
+		if identity != nil {
+			result = append(result, *identity)
+		}
+	}
+	return result, nil
+}
+
+// resolveIdentityDescriptors returns the identity descriptors of the given descriptors, keyed by the given descriptor
+// Subject descriptors of the graph are resolved through the identities lookup, identity descriptors are kept as they are
+func (h *baseHandler) resolveIdentityDescriptors(organization string, descriptors []string, apiVersion, authHeader string) (map[string]string, error) {
+	resolved := map[string]string{}
+	subjectDescriptors := []string{}
+	for _, descriptor := range descriptors {
+		if isIdentityDescriptor(descriptor) {
+			resolved[descriptor] = descriptor
+		} else {
+			subjectDescriptors = append(subjectDescriptors, descriptor)
+		}
+	}
+	sort.Strings(subjectDescriptors)
+
+	identities, err := h.lookupIdentities(organization, "subjectDescriptors", subjectDescriptors, apiVersion, authHeader)
+	if err != nil {
+		return nil, err
+	}
+	for _, identity := range identities {
+		resolved[identity.SubjectDescriptor] = identity.Descriptor
+	}
+
+	for _, descriptor := range subjectDescriptors {
+		if resolved[descriptor] == "" {
+			return nil, &errIdentityNotFound{Descriptor: descriptor}
+		}
+	}
+	return resolved, nil
+}
+
+// buildRepositoryPermissions returns the permissions of the access control list keyed by subject descriptor
+// Identities without a subject descriptor are keyed by identity descriptor
+func (h *baseHandler) buildRepositoryPermissions(organization string, repository *gitrepository.GitRepository, branch string, acl *AccessControlList, apiVersion, authHeader string) (*RepositoryPermissions, error) {
+	identityDescriptors := []string{}
+	for descriptor := range acl.AcesDictionary {
+		identityDescriptors = append(identityDescriptors, descriptor)
+	}
+	sort.Strings(identityDescriptors)
+
+	identities, err := h.lookupIdentities(organization, "descriptors", identityDescriptors, apiVersion, authHeader)
+	if err != nil {
+		return nil, err
+	}
+	subjectDescriptors := map[string]string{}
+	for _, identity := range identities {
+		if identity.SubjectDescriptor != "" {
+			subjectDescriptors[strings.ToLower(identity.Descriptor)] = identity.SubjectDescriptor
+		}
+	}
+
+	permissions := map[string]IdentityPermissions{}
+	for _, descriptor := range identityDescriptors {
+		entry := acl.AcesDictionary[descriptor]
+		key := descriptor
+		if subjectDescriptor, exists := subjectDescriptors[strings.ToLower(descriptor)]; exists {
+			key = subjectDescriptor
+		}
+		permissions[key] = buildIdentityPermissions(&entry)
+	}
+
+	return &RepositoryPermissions{
+		ProjectID:          strings.ToLower(repository.Project.ID),
+		RepositoryID:       strings.ToLower(repository.ID),
+		Branch:             branch,
+		Token:              acl.Token,
+		InheritPermissions: acl.InheritPermissions,
+		Permissions:        permissions,
+	}, nil
+}
+
+// findEntry returns the entry of the identity in the access control list (descriptors are case insensitive), nil if there is none
+func findEntry(acl *AccessControlList, identityDescriptor string) *AccessControlEntry {
+	for descriptor, entry := range acl.AcesDictionary {
+		if strings.EqualFold(descriptor, identityDescriptor) {
+			return &entry
+		}
+	}
+	return nil
+}
+
+// GET handler implementation
+// @Summary Get the permissions of a Git repository
+// @Description Get the explicit and effective permissions of the identities with an access control entry on the Git repository, or on a branch of it
+// @ID get-git-repository-permissions
+// @Param organization path string true "Organization name"
+// @Param projectId path string true "Project ID or name"
+// @Param repositoryId path string true "Repository ID or name"
+// @Param branch query string false "Branch name (e.g., main or refs/heads/main), the permissions of the repository are returned if not set"
+// @Param api-version query string true "API version (e.g., 7.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Produce json
+// @Success 200 {object} RepositoryPermissions "Repository permissions"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{projectId}/git/repositories/{repositoryId}/permissions [get]
+func (h *getHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	projectID := r.PathValue("projectId")
+	repositoryID := r.PathValue("repositoryId")
+	branch := normalizeBranch(r.URL.Query().Get("branch"))
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, projectID, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	h.Log.Printf("Getting permissions of repository %s (branch '%s') for project %s in organization %s", repositoryID, branch, projectID, organization)
+
+	repository, err := h.getRepository(organization, projectID, repositoryID, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "get repository", fmt.Sprintf("Repository %s not found in project %s", repositoryID, projectID))
+		return
+	}
+
+	token := buildToken(repository.Project.ID, repository.ID, branch)
+	acl, err := h.getAccessControlList(organization, token, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "get access control list", fmt.Sprintf("Organization %s not found", organization))
+		return
+	}
+
+	result, err := h.buildRepositoryPermissions(organization, repository, branch, acl, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "resolve identities", fmt.Sprintf("Organization %s not found", organization))
+		return
+	}
+
+	h.WriteObjectResponse(w, http.StatusOK, result)
+	h.Log.Printf("Successfully retrieved permissions of %d identities on %s", len(result.Permissions), token)
+}
+
+// PUT handler implementation
+// @Summary Set the permissions of a Git repository
+// @Description Set the explicit permissions of the given identities on the Git repository, or on a branch of it. The permissions of each listed identity are replaced, the other identities are not changed. Entries already up to date are not written.
+// @ID put-git-repository-permissions
+// @Param organization path string true "Organization name"
+// @Param projectId path string true "Project ID or name"
+// @Param repositoryId path string true "Repository ID or name"
+// @Param branch query string false "Branch name (e.g., main or refs/heads/main), the permissions of the repository are set if not set"
+// @Param api-version query string true "API version (e.g., 7.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Param permissions body RepositoryPermissionsRequest true "Permissions keyed by descriptor"
+// @Accept json
+// @Produce json
+// @Success 200 {object} RepositoryPermissions "Updated repository permissions"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{projectId}/git/repositories/{repositoryId}/permissions [put]
+func (h *putHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	projectID := r.PathValue("projectId")
+	repositoryID := r.PathValue("repositoryId")
+	branch := normalizeBranch(r.URL.Query().Get("branch"))
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, projectID, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Failed to read request body")
+		return
+	}
+
+	var permissionsRequest RepositoryPermissionsRequest
+	if err := json.Unmarshal(body, &permissionsRequest); err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Invalid JSON in request body")
+		return
+	}
+	desired, err := validatePermissionsRequest(&permissionsRequest)
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid permissions: %v", err))
+		return
+	}
+
+	h.Log.Printf("Setting permissions of %d identities on repository %s (branch '%s') for project %s in organization %s", len(desired), repositoryID, branch, projectID, organization)
+
+	repository, err := h.getRepository(organization, projectID, repositoryID, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "get repository", fmt.Sprintf("Repository %s not found in project %s", repositoryID, projectID))
+		return
+	}
+
+	descriptors := []string{}
+	for descriptor := range desired {
+		descriptors = append(descriptors, descriptor)
+	}
+	sort.Strings(descriptors)
+
+	// Descriptors are resolved before changing anything so that an unknown identity leaves the permissions untouched
+	identityDescriptors, err := h.resolveIdentityDescriptors(organization, descriptors, apiVersion, authHeader)
+	if err != nil {
+		var notFound *errIdentityNotFound
+		if errors.As(err, &notFound) {
+			h.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid permissions: %v", err))
+			return
+		}
+		h.WriteAzureDevOpsError(w, err, "resolve identities", fmt.Sprintf("Organization %s not found", organization))
+		return
+	}
+
+	token := buildToken(repository.Project.ID, repository.ID, branch)
+	acl, err := h.getAccessControlList(organization, token, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "get access control list", fmt.Sprintf("Organization %s not found", organization))
+		return
+	}
+
+	changed := []AccessControlEntry{}
+	for _, descriptor := range descriptors {
+		entry := desired[descriptor]
+		entry.Descriptor = identityDescriptors[descriptor]
+		current := findEntry(acl, entry.Descriptor)
+		if current == nil {
+			current = &AccessControlEntry{}
+		}
+		if current.Allow != entry.Allow || current.Deny != entry.Deny {
+			changed = append(changed, entry)
+		}
+	}
+
+	if len(changed) > 0 {
+		requestBody, err := json.Marshal(AccessControlEntriesRequest{Token: token, Merge: false, AccessControlEntries: changed})
+		if err != nil {
+			h.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Failed to marshal access control entries: %v", err))
+			return
+		}
+
+		url := fmt.Sprintf("https://dev.azure.com/%s/_apis/accesscontrolentries/%s?api-version=%s", organization, GitRepositoriesNamespaceID, apiVersion)
+		if _, err := h.DoAzureDevOpsRequest("POST", url, authHeader, requestBody); err != nil {
+			h.WriteAzureDevOpsError(w, err, "set access control entries", fmt.Sprintf("Organization %s not found", organization))
+			return
+		}
+		h.Log.Printf("Set %d access control entries on %s", len(changed), token)
+
+		// The effective permissions are computed by Azure DevOps
+		acl, err = h.getAccessControlList(organization, token, apiVersion, authHeader)
+		if err != nil {
+			h.WriteAzureDevOpsError(w, err, "get access control list", fmt.Sprintf("Organization %s not found", organization))
+			return
+		}
+	}
+
+	result, err := h.buildRepositoryPermissions(organization, repository, branch, acl, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "resolve identities", fmt.Sprintf("Organization %s not found", organization))
+		return
+	}
+
+	h.WriteObjectResponse(w, http.StatusOK, result)
+	h.Log.Printf("Successfully set permissions on %s", token)
+}
+
+// DELETE handler implementation
+// @Summary Remove the permissions of an identity on a Git repository
+// @Description Remove the access control entry of the identity on the Git repository, or on a branch of it, so that its permissions are inherited
+// @ID delete-git-repository-permission
+// @Param organization path string true "Organization name"
+// @Param projectId path string true "Project ID or name"
+// @Param repositoryId path string true "Repository ID or name"
+// @Param descriptor path string true "Subject descriptor or identity descriptor"
+// @Param branch query string false "Branch name (e.g., main or refs/heads/main), the permissions on the repository are removed if not set"
+// @Param api-version query string true "API version (e.g., 7.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Success 204 "No Content"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{projectId}/git/repositories/{repositoryId}/permissions/{descriptor} [delete]
+func (h *deleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	projectID := r.PathValue("projectId")
+	repositoryID := r.PathValue("repositoryId")
+	descriptor := r.PathValue("descriptor")
+	branch := normalizeBranch(r.URL.Query().Get("branch"))
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, projectID, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	h.Log.Printf("Removing permissions of %s on repository %s (branch '%s') for project %s in organization %s", descriptor, repositoryID, branch, projectID, organization)
+
+	repository, err := h.getRepository(organization, projectID, repositoryID, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "get repository", fmt.Sprintf("Repository %s not found in project %s", repositoryID, projectID))
+		return
+	}
+
+	identityDescriptors, err := h.resolveIdentityDescriptors(organization, []string{descriptor}, apiVersion, authHeader)
+	if err != nil {
+		var notFound *errIdentityNotFound
+		if errors.As(err, &notFound) {
+			h.WriteErrorResponse(w, http.StatusNotFound, fmt.Sprintf("Identity %s not found", descriptor))
+			return
+		}
+		h.WriteAzureDevOpsError(w, err, "resolve identities", fmt.Sprintf("Organization %s not found", organization))
+		return
+	}
+
+	token := buildToken(repository.Project.ID, repository.ID, branch)
+	acl, err := h.getAccessControlList(organization, token, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "get access control list", fmt.Sprintf("Organization %s not found", organization))
+		return
+	}
+
+	// Removing an entry that does not exist is not an error, so that the deletion can be retried
+	if entry := findEntry(acl, identityDescriptors[descriptor]); entry != nil {
+		url := fmt.Sprintf("https://dev.azure.com/%s/_apis/accesscontrolentries/%s?token=%s&descriptors=%s&api-version=%s", organization, GitRepositoriesNamespaceID, neturl.QueryEscape(token), neturl.QueryEscape(entry.Descriptor), apiVersion)
+		if _, err := h.DoAzureDevOpsRequest("DELETE", url, authHeader, nil); err != nil {
+			h.WriteAzureDevOpsError(w, err, "remove access control entry", fmt.Sprintf("Organization %s not found", organization))
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	h.Log.Printf("Successfully removed permissions of %s on %s", descriptor, token)
+}
@@ -0,0 +1,408 @@
+package gitpermission
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers"
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/handlerstest"
+	"github.com/rs/zerolog"
+)
+
+// newTestBaseHandler creates a base handler for testing with a mock client
+func newTestBaseHandler(mockClient *handlerstest.MockHTTPClient) *baseHandler {
+	return newBaseHandler(handlerstest.NewHandlerOptions(mockClient))
+}
+
+// Test data constants
+const (
+	testOrg           = "testorg"
+	testProject       = "Platform"
+	testRepository    = "platform-api"
+	testAPIVersion    = "7.1"
+	testUsername      = "test"
+	testPassword      = "test"
+	repositoryToken   = "repoV2/9a3c2b1d-0000-0000-0000-000000000001/5b6c7d8e-0000-0000-0000-000000000002"
+	mainBranchToken   = repositoryToken + "/refs/heads/6d00610069006e00"
+	contributorsID    = "Microsoft.TeamFoundation.Identity;S-1-9-1551374245-1"
+	reviewersID       = "Microsoft.TeamFoundation.Identity;S-1-9-1551374245-2"
+	contributorsGroup = "vssgp.Contributors"
+	reviewersGroup    = "vssgp.Reviewers"
+)
+
+var (
+	repositoryURL  = fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/git/repositories/%s?api-version=%s", testOrg, testProject, testRepository, testAPIVersion)
+	entriesURL     = fmt.Sprintf("https://dev.azure.com/%s/_apis/accesscontrolentries/%s?api-version=%s", testOrg, GitRepositoriesNamespaceID, testAPIVersion)
+	repositoryResp = `{"id":"5B6C7D8E-0000-0000-0000-000000000002","name":"platform-api","project":{"id":"9A3C2B1D-0000-0000-0000-000000000001","name":"Platform"}}`
+
+	contributorsACLResp = `{"count":1,"value":[{"inheritPermissions":true,"token":"` + repositoryToken + `","acesDictionary":{"` + contributorsID + `":{"descriptor":"` + contributorsID + `","allow":20,"deny":8,"extendedInfo":{"effectiveAllow":22,"effectiveDeny":8,"inheritedAllow":2}}}}]}`
+	bothACLResp         = `{"count":1,"value":[{"inheritPermissions":true,"token":"` + repositoryToken + `","acesDictionary":{"` + contributorsID + `":{"descriptor":"` + contributorsID + `","allow":20,"deny":8,"extendedInfo":{"effectiveAllow":22,"effectiveDeny":8,"inheritedAllow":2}},"` + reviewersID + `":{"descriptor":"` + reviewersID + `","allow":16384,"deny":0,"extendedInfo":{"effectiveAllow":16386,"inheritedAllow":2}}}}]}`
+	contributorsResp    = `{"count":1,"value":[{"id":"c0000000-0000-0000-0000-000000000001","descriptor":"` + contributorsID + `","subjectDescriptor":"vssgp.Contributors"}]}`
+	bothIdentitiesResp  = `{"count":2,"value":[{"id":"c0000000-0000-0000-0000-000000000001","descriptor":"` + contributorsID + `","subjectDescriptor":"vssgp.Contributors"},{"id":"c0000000-0000-0000-0000-000000000002","descriptor":"` + reviewersID + `","subjectDescriptor":"vssgp.Reviewers"}]}`
+
+	expectedContributors = `"vssgp.Contributors":{"allow":["CreateBranch","GenericContribute"],"deny":["ForcePush"],"effective":{"CreateBranch":"allow","ForcePush":"deny","GenericContribute":"allow","GenericRead":"allow"}}`
+)
+
+// aclURL returns the URL used to get the access control list of the token
+func aclURL(token string) string {
+	return fmt.Sprintf("https://dev.azure.com/%s/_apis/accesscontrollists/%s?token=%s&includeExtendedInfo=true&api-version=%s", testOrg, GitRepositoriesNamespaceID, url.QueryEscape(token), testAPIVersion)
+}
+
+// identitiesURL returns the URL used to look up identities by subject descriptors or by identity descriptors
+func identitiesURL(filter string, descriptors ...string) string {
+	return fmt.Sprintf("https://vssps.dev.azure.com/%s/_apis/identities?%s=%s&queryMembership=None&api-version=%s", testOrg, filter, url.QueryEscape(strings.Join(descriptors, ",")), testAPIVersion)
+}
+
+// permissionTestCase is a test case for the handlers of this package
+type permissionTestCase struct {
+	name                 string
+	pathValues           map[string]string
+	query                url.Values
+	noAuth               bool
+	body                 string
+	setupMock            func(*handlerstest.MockHTTPClient)
+	expectedStatus       int
+	expectedBody         string
+	expectedBodyContains string
+	expectedRequestCount int
+	verify               func(t *testing.T, mockClient *handlerstest.MockHTTPClient)
+}
+
+// permissionPathValues returns the path values of the permission endpoints
+func permissionPathValues() map[string]string {
+	return map[string]string{"organization": testOrg, "projectId": testProject, "repositoryId": testRepository}
+}
+
+// runPermissionTests runs the given test cases against the handler built by newHandler
+func runPermissionTests(t *testing.T, method string, newHandler func(*baseHandler) http.Handler, tests []permissionTestCase) {
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := handlerstest.NewMockHTTPClient()
+			if tt.setupMock != nil {
+				tt.setupMock(mockClient)
+			}
+			handler := newHandler(newTestBaseHandler(mockClient))
+
+			target := "/api/test"
+			if len(tt.query) > 0 {
+				target += "?" + tt.query.Encode()
+			}
+			var body io.Reader
+			if tt.body != "" {
+				body = strings.NewReader(tt.body)
+			}
+			req := httptest.NewRequest(method, target, body)
+			for key, value := range tt.pathValues {
+				req.SetPathValue(key, value)
+			}
+			if !tt.noAuth {
+				req.SetBasicAuth(testUsername, testPassword)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("Status code = %d, want %d. Body: %s", rr.Code, tt.expectedStatus, rr.Body.String())
+			}
+			if tt.expectedBody != "" && rr.Body.String() != tt.expectedBody {
+				t.Errorf("Body = %s, want %s", rr.Body.String(), tt.expectedBody)
+			}
+			if tt.expectedBodyContains != "" && !strings.Contains(rr.Body.String(), tt.expectedBodyContains) {
+				t.Errorf("Body = %s, want it to contain %s", rr.Body.String(), tt.expectedBodyContains)
+			}
+			if mockClient.RequestCount() != tt.expectedRequestCount {
+				t.Errorf("Request count = %d, want %d", mockClient.RequestCount(), tt.expectedRequestCount)
+			}
+			if tt.verify != nil {
+				tt.verify(t, mockClient)
+			}
+		})
+	}
+}
+
+// apiVersionQuery returns the query with the test API version
+func apiVersionQuery() url.Values {
+	return url.Values{"api-version": {testAPIVersion}}
+}
+
+// requestBody returns the body of the n-th request sent to Azure DevOps
+func requestBody(t *testing.T, mockClient *handlerstest.MockHTTPClient, n int) string {
+	t.Helper()
+	if len(mockClient.Requests) <= n || mockClient.Requests[n].Body == nil {
+		t.Fatalf("request %d has no body", n)
+	}
+	body, err := io.ReadAll(mockClient.Requests[n].Body)
+	if err != nil {
+		t.Fatalf("failed to read request %d body: %v", n, err)
+	}
+	return string(body)
+}
+
+// requestURLs returns "METHOD url" for every request sent to Azure DevOps
+func requestURLs(mockClient *handlerstest.MockHTTPClient) []string {
+	urls := []string{}
+	for _, req := range mockClient.Requests {
+		urls = append(urls, req.Method+" "+req.URL.String())
+	}
+	return urls
+}
+
+// containsRequest reports whether a request was sent with the given method and URL
+func containsRequest(mockClient *handlerstest.MockHTTPClient, method, url string) bool {
+	for _, request := range requestURLs(mockClient) {
+		if request == method+" "+url {
+			return true
+		}
+	}
+	return false
+}
+
+// Test constructor functions
+func TestGitPermissionConstructors(t *testing.T) {
+	client := &http.Client{}
+	logger := zerolog.New(os.Stdout).With().Timestamp().Logger()
+	opts := handlers.HandlerOptions{
+		Client: client,
+		Log:    &logger,
+	}
+
+	constructors := map[string]func(handlers.HandlerOptions) handlers.Handler{
+		"GetRepositoryPermissions":   GetRepositoryPermissions,
+		"PutRepositoryPermissions":   PutRepositoryPermissions,
+		"DeleteRepositoryPermission": DeleteRepositoryPermission,
+	}
+
+	for name, constructor := range constructors {
+		t.Run(name, func(t *testing.T) {
+			if h := constructor(opts); h == nil {
+				t.Fatalf("%s should return a non-nil handler", name)
+			}
+		})
+	}
+}
+
+// Test GET handler
+func TestGetHandler_ServeHTTP(t *testing.T) {
+	runPermissionTests(t, "GET", func(b *baseHandler) http.Handler { return &getHandler{baseHandler: b} }, []permissionTestCase{
+		{
+			name:       "repository permissions keyed by subject descriptor",
+			pathValues: permissionPathValues(),
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(repositoryURL, http.StatusOK, repositoryResp)
+				m.SetResponse(aclURL(repositoryToken), http.StatusOK, contributorsACLResp)
+				m.SetResponse(identitiesURL("descriptors", contributorsID), http.StatusOK, contributorsResp)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBody:         `{"projectId":"9a3c2b1d-0000-0000-0000-000000000001","repositoryId":"5b6c7d8e-0000-0000-0000-000000000002","token":"` + repositoryToken + `","inheritPermissions":true,"permissions":{` + expectedContributors + `}}`,
+			expectedRequestCount: 3,
+		},
+		{
+			name:       "branch without permissions",
+			pathValues: permissionPathValues(),
+			query:      url.Values{"api-version": {testAPIVersion}, "branch": {"refs/heads/main"}},
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(repositoryURL, http.StatusOK, repositoryResp)
+				m.SetResponse(aclURL(mainBranchToken), http.StatusOK, `{"count":0,"value":[]}`)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBody:         `{"projectId":"9a3c2b1d-0000-0000-0000-000000000001","repositoryId":"5b6c7d8e-0000-0000-0000-000000000002","branch":"main","token":"` + mainBranchToken + `","inheritPermissions":true,"permissions":{}}`,
+			expectedRequestCount: 2,
+		},
+		{
+			name:       "identity without subject descriptor keyed by identity descriptor",
+			pathValues: permissionPathValues(),
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(repositoryURL, http.StatusOK, repositoryResp)
+				m.SetResponse(aclURL(repositoryToken), http.StatusOK, contributorsACLResp)
+				m.SetResponse(identitiesURL("descriptors", contributorsID), http.StatusOK, `{"count":1,"value":[null]}`)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `"permissions":{"` + contributorsID + `":{"allow":["CreateBranch","GenericContribute"]`,
+			expectedRequestCount: 3,
+		},
+		{
+			name:                 "missing project",
+			pathValues:           map[string]string{"organization": testOrg, "repositoryId": testRepository},
+			query:                apiVersionQuery(),
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Project parameter is required",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "missing basic auth",
+			pathValues:           permissionPathValues(),
+			query:                apiVersionQuery(),
+			noAuth:               true,
+			expectedStatus:       http.StatusUnauthorized,
+			expectedBody:         "Request rejected due to missing or invalid Basic authentication",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "repository not found",
+			pathValues:           permissionPathValues(),
+			query:                apiVersionQuery(),
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Repository platform-api not found in project Platform",
+			expectedRequestCount: 1,
+		},
+	})
+}
+
+// Test PUT handler
+func TestPutHandler_ServeHTTP(t *testing.T) {
+	runPermissionTests(t, "PUT", func(b *baseHandler) http.Handler { return &putHandler{baseHandler: b} }, []permissionTestCase{
+		{
+			name:       "only the entries that differ are written",
+			pathValues: permissionPathValues(),
+			query:      apiVersionQuery(),
+			body:       `{"permissions":{"vssgp.Contributors":{"allow":["genericcontribute","CreateBranch"],"deny":["ForcePush"]},"vssgp.Reviewers":{"allow":["PullRequestContribute"]}}}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(repositoryURL, http.StatusOK, repositoryResp)
+				m.SetResponse(identitiesURL("subjectDescriptors", contributorsGroup, reviewersGroup), http.StatusOK, bothIdentitiesResp)
+				m.SetResponseSequence(aclURL(repositoryToken), handlerstest.MockResponse{StatusCode: http.StatusOK, Body: contributorsACLResp}, handlerstest.MockResponse{StatusCode: http.StatusOK, Body: bothACLResp})
+				m.SetMethodResponse("POST", entriesURL, http.StatusOK, `{"count":1,"value":[]}`)
+				m.SetResponse(identitiesURL("descriptors", contributorsID, reviewersID), http.StatusOK, bothIdentitiesResp)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `"permissions":{` + expectedContributors + `,"vssgp.Reviewers":{"allow":["PullRequestContribute"],"deny":[],"effective":{"GenericRead":"allow","PullRequestContribute":"allow"}}}`,
+			expectedRequestCount: 6,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				want := `{"token":"` + repositoryToken + `","merge":false,"accessControlEntries":[{"descriptor":"` + reviewersID + `","allow":16384,"deny":0}]}`
+				if got := requestBody(t, m, 3); got != want {
+					t.Errorf("Request body = %s, want %s", got, want)
+				}
+			},
+		},
+		{
+			name:       "permissions already up to date",
+			pathValues: permissionPathValues(),
+			query:      apiVersionQuery(),
+			body:       `{"permissions":{"vssgp.Contributors":{"allow":["GenericContribute","CreateBranch"],"deny":["ForcePush"]}}}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(repositoryURL, http.StatusOK, repositoryResp)
+				m.SetResponse(identitiesURL("subjectDescriptors", contributorsGroup), http.StatusOK, contributorsResp)
+				m.SetResponse(aclURL(repositoryToken), http.StatusOK, contributorsACLResp)
+				m.SetResponse(identitiesURL("descriptors", contributorsID), http.StatusOK, contributorsResp)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: expectedContributors,
+			expectedRequestCount: 4,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				if containsRequest(m, "POST", entriesURL) {
+					t.Errorf("Access control entries should not be written when they are up to date")
+				}
+			},
+		},
+		{
+			name:       "identity descriptor used as it is",
+			pathValues: permissionPathValues(),
+			query:      url.Values{"api-version": {testAPIVersion}, "branch": {"main"}},
+			body:       `{"permissions":{"` + contributorsID + `":{"deny":["ForcePush"]}}}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(repositoryURL, http.StatusOK, repositoryResp)
+				m.SetResponseSequence(aclURL(mainBranchToken), handlerstest.MockResponse{StatusCode: http.StatusOK, Body: `{"count":0,"value":[]}`}, handlerstest.MockResponse{StatusCode: http.StatusOK, Body: `{"count":1,"value":[{"inheritPermissions":true,"token":"` + mainBranchToken + `","acesDictionary":{"` + contributorsID + `":{"descriptor":"` + contributorsID + `","allow":0,"deny":8}}}]}`})
+				m.SetMethodResponse("POST", entriesURL, http.StatusOK, `{"count":1,"value":[]}`)
+				m.SetResponse(identitiesURL("descriptors", contributorsID), http.StatusOK, contributorsResp)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `"branch":"main","token":"` + mainBranchToken + `","inheritPermissions":true,"permissions":{"vssgp.Contributors":{"allow":[],"deny":["ForcePush"],"effective":{"ForcePush":"deny"}}}`,
+			expectedRequestCount: 5,
+		},
+		{
+			name:       "unknown identity",
+			pathValues: permissionPathValues(),
+			query:      apiVersionQuery(),
+			body:       `{"permissions":{"vssgp.Unknown":{"allow":["GenericRead"]}}}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(repositoryURL, http.StatusOK, repositoryResp)
+				m.SetResponse(identitiesURL("subjectDescriptors", "vssgp.Unknown"), http.StatusOK, `{"count":1,"value":[null]}`)
+			},
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid permissions: identity 'vssgp.Unknown' not found",
+			expectedRequestCount: 2,
+		},
+		{
+			name:                 "unknown permission",
+			pathValues:           permissionPathValues(),
+			query:                apiVersionQuery(),
+			body:                 `{"permissions":{"vssgp.Contributors":{"allow":["Contribute"]}}}`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid permissions: vssgp.Contributors: unknown permission 'Contribute'",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "permission both allowed and denied",
+			pathValues:           permissionPathValues(),
+			query:                apiVersionQuery(),
+			body:                 `{"permissions":{"vssgp.Contributors":{"allow":["ForcePush","CreateTag"],"deny":["forcepush"]}}}`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid permissions: vssgp.Contributors: permissions both allowed and denied: ForcePush",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "missing permissions",
+			pathValues:           permissionPathValues(),
+			query:                apiVersionQuery(),
+			body:                 `{}`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid permissions: permissions is required",
+			expectedRequestCount: 0,
+		},
+	})
+}
+
+// Test DELETE handler
+func TestDeleteHandler_ServeHTTP(t *testing.T) {
+	pathValues := permissionPathValues()
+	pathValues["descriptor"] = contributorsGroup
+	deleteURL := fmt.Sprintf("https://dev.azure.com/%s/_apis/accesscontrolentries/%s?token=%s&descriptors=%s&api-version=%s", testOrg, GitRepositoriesNamespaceID, url.QueryEscape(repositoryToken), url.QueryEscape(contributorsID), testAPIVersion)
+
+	runPermissionTests(t, "DELETE", func(b *baseHandler) http.Handler { return &deleteHandler{baseHandler: b} }, []permissionTestCase{
+		{
+			name:       "entry removed",
+			pathValues: pathValues,
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(repositoryURL, http.StatusOK, repositoryResp)
+				m.SetResponse(identitiesURL("subjectDescriptors", contributorsGroup), http.StatusOK, contributorsResp)
+				m.SetResponse(aclURL(repositoryToken), http.StatusOK, contributorsACLResp)
+				m.SetMethodResponse("DELETE", deleteURL, http.StatusOK, `true`)
+			},
+			expectedStatus:       http.StatusNoContent,
+			expectedRequestCount: 4,
+		},
+		{
+			name:       "no entry to remove",
+			pathValues: pathValues,
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(repositoryURL, http.StatusOK, repositoryResp)
+				m.SetResponse(identitiesURL("subjectDescriptors", contributorsGroup), http.StatusOK, contributorsResp)
+				m.SetResponse(aclURL(repositoryToken), http.StatusOK, `{"count":0,"value":[]}`)
+			},
+			expectedStatus:       http.StatusNoContent,
+			expectedRequestCount: 3,
+		},
+		{
+			name:       "unknown identity",
+			pathValues: pathValues,
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(repositoryURL, http.StatusOK, repositoryResp)
+				m.SetResponse(identitiesURL("subjectDescriptors", contributorsGroup), http.StatusOK, `{"count":0,"value":[]}`)
+			},
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Identity vssgp.Contributors not found",
+			expectedRequestCount: 2,
+		},
+	})
+}
@@ -0,0 +1,139 @@
+package gitpermission
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// normalizeBranch returns the name of the branch without the refs/heads/ prefix
+func normalizeBranch(branch string) string {
+	return strings.TrimPrefix(strings.TrimSpace(branch), "refs/heads/")
+}
+
+// buildToken returns the security token of the repository, or of the branch when it is set
+// Branch tokens encode every segment of the branch name as hexadecimal UTF-16LE, e.g., main becomes 6d00610069006e00
+func buildToken(projectID, repositoryID, branch string) string {
+	token := fmt.Sprintf("repoV2/%s/%s", strings.ToLower(projectID), strings.ToLower(repositoryID))
+	if branch == "" {
+		return token
+	}
+
+	segments := []string{}
+	for _, segment := range strings.Split(branch, "/") {
+		var encoded strings.Builder
+		for _, unit := range utf16.Encode([]rune(segment)) {
+			fmt.Fprintf(&encoded, "%02x%02x", unit&0xff, unit>>8)
+		}
+		segments = append(segments, encoded.String())
+	}
+	return token + "/refs/heads/" + strings.Join(segments, "/")
+}
+
+// isIdentityDescriptor reports whether the descriptor is an identity descriptor (e.g., Microsoft.TeamFoundation.Identity;S-1-9-...)
+// rather than a subject descriptor of the graph (e.g., vssgp.xxx)
+func isIdentityDescriptor(descriptor string) bool {
+	return strings.Contains(descriptor, ";")
+}
+
+// permissionBits returns the bitmask of the given permission names (case insensitive)
+// Bits not known by the plugin can be given as decimal values
+func permissionBits(names []string) (int, error) {
+	bits := 0
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		bit := 0
+		for _, permission := range Permissions {
+			if strings.EqualFold(permission.Name, name) {
+				bit = permission.Bit
+				break
+			}
+		}
+		if bit == 0 {
+			value, err := strconv.Atoi(name)
+			if err != nil || value <= 0 || value&(value-1) != 0 {
+				return 0, fmt.Errorf("unknown permission '%s'", name)
+			}
+			bit = value
+		}
+		bits |= bit
+	}
+	return bits, nil
+}
+
+// permissionNames returns the names of the permissions of the bitmask, sorted
+// Bits not known by the plugin are returned as decimal values
+func permissionNames(bits int) []string {
+	names := []string{}
+	for bit := 1; bit > 0 && bit <= bits; bit <<= 1 {
+		if bits&bit == 0 {
+			continue
+		}
+		name := strconv.Itoa(bit)
+		for _, permission := range Permissions {
+			if permission.Bit == bit {
+				name = permission.Name
+				break
+			}
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// validatePermissionsRequest checks the request and returns the desired entries keyed by the requested descriptor
+func validatePermissionsRequest(request *RepositoryPermissionsRequest) (map[string]AccessControlEntry, error) {
+	if request.Permissions == nil {
+		return nil, fmt.Errorf("permissions is required")
+	}
+
+	entries := map[string]AccessControlEntry{}
+	for descriptor, permissions := range request.Permissions {
+		trimmed := strings.TrimSpace(descriptor)
+		if trimmed == "" {
+			return nil, fmt.Errorf("descriptor is required")
+		}
+		allow, err := permissionBits(permissions.Allow)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", trimmed, err)
+		}
+		deny, err := permissionBits(permissions.Deny)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", trimmed, err)
+		}
+		if overlap := allow & deny; overlap != 0 {
+			return nil, fmt.Errorf("%s: permissions both allowed and denied: %s", trimmed, strings.Join(permissionNames(overlap), ", "))
+		}
+		if _, exists := entries[trimmed]; exists {
+			return nil, fmt.Errorf("%s: listed more than once", trimmed)
+		}
+		entries[trimmed] = AccessControlEntry{Descriptor: trimmed, Allow: allow, Deny: deny}
+	}
+	return entries, nil
+}
+
+// buildIdentityPermissions converts an access control entry to the permissions returned by the plugin
+func buildIdentityPermissions(entry *AccessControlEntry) IdentityPermissions {
+	effectiveAllow, effectiveDeny := entry.Allow, entry.Deny
+	if entry.ExtendedInfo != nil {
+		effectiveAllow, effectiveDeny = entry.ExtendedInfo.EffectiveAllow, entry.ExtendedInfo.EffectiveDeny
+	}
+
+	effective := map[string]string{}
+	for _, name := range permissionNames(effectiveAllow) {
+		effective[name] = "allow"
+	}
+	// Deny takes precedence over allow
+	for _, name := range permissionNames(effectiveDeny) {
+		effective[name] = "deny"
+	}
+
+	return IdentityPermissions{
+		Allow:     permissionNames(entry.Allow),
+		Deny:      permissionNames(entry.Deny),
+		Effective: effective,
+	}
+}
@@ -0,0 +1,80 @@
+package gitpermission
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBuildToken(t *testing.T) {
+	tests := []struct {
+		name     string
+		branch   string
+		expected string
+	}{
+		{name: "repository", expected: repositoryToken},
+		{name: "branch", branch: "main", expected: mainBranchToken},
+		{name: "branch with folders", branch: "feature/x", expected: repositoryToken + "/refs/heads/6600650061007400750072006500/7800"},
+		{name: "non ASCII branch", branch: "é", expected: repositoryToken + "/refs/heads/e900"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := buildToken("9A3C2B1D-0000-0000-0000-000000000001", "5b6c7d8e-0000-0000-0000-000000000002", tt.branch); got != tt.expected {
+				t.Errorf("buildToken() = %s, want %s", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPermissionBitsAndNames(t *testing.T) {
+	bits, err := permissionBits([]string{"ForcePush", "genericread", "65536"})
+	if err != nil {
+		t.Fatalf("permissionBits() error = %v", err)
+	}
+	if bits != 8|2|65536 {
+		t.Errorf("permissionBits() = %d, want %d", bits, 8|2|65536)
+	}
+	if names := permissionNames(bits); !reflect.DeepEqual(names, []string{"65536", "ForcePush", "GenericRead"}) {
+		t.Errorf("permissionNames() = %v", names)
+	}
+
+	for _, name := range []string{"Contribute", "3", "0", "-2"} {
+		if _, err := permissionBits([]string{name}); err == nil {
+			t.Errorf("permissionBits(%q) should fail", name)
+		}
+	}
+}
+
+func TestBuildIdentityPermissions(t *testing.T) {
+	entry := AccessControlEntry{Allow: 4, Deny: 8, ExtendedInfo: &AceExtendedInformation{EffectiveAllow: 4 | 8 | 2, EffectiveDeny: 8}}
+	expected := IdentityPermissions{
+		Allow:     []string{"GenericContribute"},
+		Deny:      []string{"ForcePush"},
+		Effective: map[string]string{"GenericContribute": "allow", "GenericRead": "allow", "ForcePush": "deny"}, // Deny takes precedence
+	}
+	if got := buildIdentityPermissions(&entry); !reflect.DeepEqual(got, expected) {
+		t.Errorf("buildIdentityPermissions() = %v, want %v", got, expected)
+	}
+}
+
+func TestValidatePermissionsRequest(t *testing.T) {
+	request := RepositoryPermissionsRequest{Permissions: map[string]IdentityPermissionsRequest{
+		" vssgp.Contributors ": {Allow: []string{"GenericContribute"}, Deny: []string{"ForcePush"}},
+		"vssgp.Readers":        {},
+	}}
+	entries, err := validatePermissionsRequest(&request)
+	if err != nil {
+		t.Fatalf("validatePermissionsRequest() error = %v", err)
+	}
+	expected := map[string]AccessControlEntry{
+		"vssgp.Contributors": {Descriptor: "vssgp.Contributors", Allow: 4, Deny: 8},
+		"vssgp.Readers":      {Descriptor: "vssgp.Readers"}, // Explicit permissions removed
+	}
+	if !reflect.DeepEqual(entries, expected) {
+		t.Errorf("validatePermissionsRequest() = %v, want %v", entries, expected)
+	}
+
+	if _, err := validatePermissionsRequest(&RepositoryPermissionsRequest{Permissions: map[string]IdentityPermissionsRequest{" ": {}}}); err == nil || err.Error() != "descriptor is required" {
+		t.Errorf("validatePermissionsRequest() error = %v, want descriptor is required", err)
+	}
+}
@@ -0,0 +1,115 @@
+package gitpermission
+
+// RepositoryPermissions represents the permissions of a Git repository, or of a branch of it, returned by the plugin
+// It is built from the access control list returned by:
+// GET https://dev.azure.com/{organization}/_apis/accesscontrollists/{securityNamespaceId}?token={token}&includeExtendedInfo=true
+type RepositoryPermissions struct {
+	ProjectID          string                         `json:"projectId"`
+	RepositoryID       string                         `json:"repositoryId"`
+	Branch             string                         `json:"branch,omitempty"` // Not set for the permissions of the repository
+	Token              string                         `json:"token"`            // Security token, e.g., repoV2/{projectId}/{repositoryId}
+	InheritPermissions bool                           `json:"inheritPermissions"`
+	Permissions        map[string]IdentityPermissions `json:"permissions"` // Keyed by subject descriptor
+}
+
+// IdentityPermissions represents the permissions of an identity
+type IdentityPermissions struct {
+	Allow     []string          `json:"allow"`     // Explicitly allowed permissions, sorted by name
+	Deny      []string          `json:"deny"`      // Explicitly denied permissions, sorted by name
+	Effective map[string]string `json:"effective"` // Effective permissions (explicit or inherited), "allow" or "deny" keyed by permission name
+}
+
+// RepositoryPermissionsRequest represents the request body for setting the permissions of a Git repository or of a branch
+type RepositoryPermissionsRequest struct {
+	Permissions map[string]IdentityPermissionsRequest `json:"permissions"` // Keyed by subject descriptor (e.g., vssgp.xxx, aad.xxx) or identity descriptor
+}
+
+// IdentityPermissionsRequest represents the explicit permissions to set for an identity
+// The permissions not listed are not set, so they are inherited
+type IdentityPermissionsRequest struct {
+	Allow []string `json:"allow,omitempty"`
+	Deny  []string `json:"deny,omitempty"`
+}
+
+// ---
+
+// AccessControlEntry represents the permissions of an identity in an access control list
+type AccessControlEntry struct {
+	Descriptor   string                  `json:"descriptor"` // Identity descriptor, e.g., Microsoft.TeamFoundation.Identity;S-1-9-...
+	Allow        int                     `json:"allow"`
+	Deny         int                     `json:"deny"`
+	ExtendedInfo *AceExtendedInformation `json:"extendedInfo,omitempty"`
+}
+
+// AceExtendedInformation represents the inherited and effective permissions of an access control entry
+type AceExtendedInformation struct {
+	EffectiveAllow int `json:"effectiveAllow"`
+	EffectiveDeny  int `json:"effectiveDeny"`
+	InheritedAllow int `json:"inheritedAllow"`
+	InheritedDeny  int `json:"inheritedDeny"`
+}
+
+// AccessControlList represents the access control list of a security token
+type AccessControlList struct {
+	InheritPermissions bool                          `json:"inheritPermissions"`
+	Token              string                        `json:"token"`
+	AcesDictionary     map[string]AccessControlEntry `json:"acesDictionary"` // Keyed by identity descriptor
+}
+
+// AccessControlListCollection represents the response from:
+// GET https://dev.azure.com/{organization}/_apis/accesscontrollists/{securityNamespaceId}?token={token}
+type AccessControlListCollection struct {
+	Count int                 `json:"count"`
+	Value []AccessControlList `json:"value"`
+}
+
+// AccessControlEntriesRequest represents the request body of:
+// POST https://dev.azure.com/{organization}/_apis/accesscontrolentries/{securityNamespaceId}
+type AccessControlEntriesRequest struct {
+	Token                string               `json:"token"`
+	Merge                bool                 `json:"merge"` // If false, the given entries replace the existing ones of the same identities
+	AccessControlEntries []AccessControlEntry `json:"accessControlEntries"`
+}
+
+// Identity represents an identity returned by:
+// GET https://vssps.dev.azure.com/{organization}/_apis/identities?subjectDescriptors={subjectDescriptors}
+type Identity struct {
+	ID                string `json:"id"`
+	Descriptor        string `json:"descriptor"`
+	SubjectDescriptor string `json:"subjectDescriptor"`
+}
+
+// IdentityList represents the response of the identities lookup
+type IdentityList struct {
+	Count int         `json:"count"`
+	Value []*Identity `json:"value"` // Unknown descriptors are returned as null
+}
+
+// GitRepositoriesNamespaceID is the ID of the Git Repositories security namespace
+const GitRepositoriesNamespaceID = "2e9eb7ed-3c0a-47d4-87c1-0ffdd275fd87"
+
+// Permission represents a permission (action) of the Git Repositories security namespace
+type Permission struct {
+	Name string
+	Bit  int
+}
+
+// Permissions lists the permissions of the Git Repositories security namespace, sorted by bit
+var Permissions = []Permission{
+	{Name: "Administer", Bit: 1},
+	{Name: "GenericRead", Bit: 2},
+	{Name: "GenericContribute", Bit: 4},
+	{Name: "ForcePush", Bit: 8},
+	{Name: "CreateBranch", Bit: 16},
+	{Name: "CreateTag", Bit: 32},
+	{Name: "ManageNote", Bit: 64},
+	{Name: "PolicyExempt", Bit: 128},
+	{Name: "CreateRepository", Bit: 256},
+	{Name: "DeleteRepository", Bit: 512},
+	{Name: "RenameRepository", Bit: 1024},
+	{Name: "EditPolicies", Bit: 2048},
+	{Name: "RemoveOthersLocks", Bit: 4096},
+	{Name: "ManagePermissions", Bit: 8192},
+	{Name: "PullRequestContribute", Bit: 16384},
+	{Name: "PullRequestBypassPolicy", Bit: 32768},
+}
@@ -0,0 +1,269 @@
+package gitpush
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	neturl "net/url"
+	"os"
+	"strings"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers"
+)
+
+// Handler constructors
+func PostPush(opts handlers.HandlerOptions) handlers.Handler {
+	return &postHandler{baseHandler: newBaseHandler(opts)}
+}
+
+// Interface compliance verification
+var _ handlers.Handler = &postHandler{}
+
+// Base handler with common functionality
+type baseHandler struct {
+	*handlers.BaseHandler
+}
+
+// Constructor for the base handler
+func newBaseHandler(opts handlers.HandlerOptions) *baseHandler {
+	return &baseHandler{BaseHandler: handlers.NewBaseHandler(opts)}
+}
+
+// Handler types embedding the base handler
+type postHandler struct {
+	*baseHandler
+}
+
+// errBranchNotFound is returned when the branch of a push does not exist
+var errBranchNotFound = errors.New("branch not found")
+
+// errFolderPath is returned when the path of a file of a push is a folder of the repository
+type errFolderPath struct {
+	Path string
+}
+
+func (e *errFolderPath) Error() string {
+	return fmt.Sprintf("%s is a folder", e.Path)
+}
+
+// pushesAPIVersion returns the API version used for the Git Pushes API
+// As for the repository initialization, it can be set with the GIT_PUSHES_API_VERSION environment variable,
+// otherwise the API version of the request is used
+func pushesAPIVersion(apiVersion string) string {
+	if pushesVersion := os.Getenv("GIT_PUSHES_API_VERSION"); pushesVersion != "" {
+		return pushesVersion
+	}
+	return apiVersion
+}
+
+// getBranchTip returns the commit the branch points to
+// errBranchNotFound is returned if the branch does not exist
+func (h *baseHandler) getBranchTip(organization, projectId, repositoryId, branch, apiVersion, authHeader string) (string, error) {
+	query := neturl.Values{}
+	query.Set("filter", strings.TrimPrefix(branch, "refs/"))
+	query.Set("api-version", apiVersion)
+	url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/git/repositories/%s/refs?%s", organization, projectId, repositoryId, query.Encode())
+
+	body, err := h.DoAzureDevOpsRequest("GET", url, authHeader, nil)
+	if err != nil {
+		return "", err
+	}
+
+	var refs GitRefList
+	if err := json.Unmarshal(body, &refs); err != nil {
+		return "", fmt.Errorf("failed to unmarshal refs: %w", err)
+	}
+	// The refs API filters by prefix, so that refs/heads/main also returns refs/heads/main-old
+	for _, ref := range refs.Value {
+		if ref.Name == branch {
+			return ref.ObjectID, nil
+		}
+	}
+	return "", errBranchNotFound
+}
+
+// getItem returns the item at the path in the given commit, nil if it does not exist
+func (h *baseHandler) getItem(organization, projectId, repositoryId, itemPath, commitID, apiVersion, authHeader string) (*GitItem, error) {
+	query := neturl.Values{}
+	query.Set("path", itemPath)
+	query.Set("versionDescriptor.version", commitID)
+	query.Set("versionDescriptor.versionType", "commit")
+	query.Set("$format", "json")
+	query.Set("api-version", apiVersion)
+	url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/git/repositories/%s/items?%s", organization, projectId, repositoryId, query.Encode())
+
+	body, err := h.DoAzureDevOpsRequest("GET", url, authHeader, nil)
+	if err != nil {
+		var adoErr *handlers.AzureDevOpsError
+		if errors.As(err, &adoErr) && adoErr.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var item GitItem
+	if err := json.Unmarshal(body, &item); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal item: %w", err)
+	}
+	return &item, nil
+}
+
+// buildChanges compares the files of the request with the files at the given commit
+// and returns the changes of the files that are not up to date
+func (h *baseHandler) buildChanges(organization, projectId, repositoryId string, files []FileRequest, commitID, apiVersion, authHeader string) ([]GitChange, error) {
+	changes := []GitChange{}
+	for i := range files {
+		item, err := h.getItem(organization, projectId, repositoryId, files[i].Path, commitID, apiVersion, authHeader)
+		if err != nil {
+			return nil, err
+		}
+		if item != nil && item.GitObjectType == "tree" {
+			return nil, &errFolderPath{Path: files[i].Path}
+		}
+		if change := buildChange(&files[i], item); change != nil {
+			changes = append(changes, *change)
+		}
+	}
+	return changes, nil
+}
+
+// push pushes a commit with the changes on top of the given commit of the branch
+// Azure DevOps rejects the push with 409 if the branch does not point to that commit anymore
+func (h *baseHandler) push(organization, projectId, repositoryId, branch, oldObjectID, comment string, changes []GitChange, apiVersion, authHeader string) (*GitPush, error) {
+	requestBody, err := json.Marshal(GitPush{
+		RefUpdates: []GitRefUpdate{{Name: branch, OldObjectID: oldObjectID}},
+		Commits:    []GitCommit{{Comment: comment, Changes: changes}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal push: %w", err)
+	}
+
+	url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/git/repositories/%s/pushes?api-version=%s", organization, projectId, repositoryId, pushesAPIVersion(apiVersion))
+	body, err := h.DoAzureDevOpsRequest("POST", url, authHeader, requestBody)
+	if err != nil {
+		return nil, err
+	}
+
+	var pushed GitPush
+	if err := json.Unmarshal(body, &pushed); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal push: %w", err)
+	}
+	return &pushed, nil
+}
+
+// POST handler implementation
+// @Summary Push a set of files to a branch
+// @Description Create, update or delete a set of files on a branch in one commit. Files already up to date are not changed, and no commit is pushed if all the files are up to date. If the branch is updated concurrently, the push is retried on top of the new tip of the branch.
+// @ID post-push
+// @Param organization path string true "Organization name"
+// @Param projectId path string true "Project ID or name"
+// @Param repositoryId path string true "Repository ID or name"
+// @Param api-version query string true "API version (e.g., 7.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Param push body PushRequest true "Push request body"
+// @Accept json
+// @Produce json
+// @Success 200 {object} PushResponse "Files already up to date, nothing pushed"
+// @Success 201 {object} PushResponse "Commit pushed"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 409 "Conflict"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{projectId}/git/repositories/{repositoryId}/pushes [post]
+func (h *postHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	projectId := r.PathValue("projectId")
+	repositoryId := r.PathValue("repositoryId")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, projectId, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Failed to read request body")
+		return
+	}
+
+	var pushRequest PushRequest
+	if err := json.Unmarshal(body, &pushRequest); err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Invalid JSON in request body")
+		return
+	}
+	if err := validatePushRequest(&pushRequest); err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid push: %v", err))
+		return
+	}
+
+	h.Log.Printf("Pushing %d files to branch %s of repository %s for project %s in organization %s", len(pushRequest.Files), pushRequest.Branch, repositoryId, projectId, organization)
+
+	notFoundMessage := fmt.Sprintf("Repository %s not found in project %s", repositoryId, projectId)
+	for attempt := 1; ; attempt++ {
+		tip, err := h.getBranchTip(organization, projectId, repositoryId, pushRequest.Branch, apiVersion, authHeader)
+		if errors.Is(err, errBranchNotFound) {
+			h.WriteErrorResponse(w, http.StatusNotFound, fmt.Sprintf("Branch %s not found in repository %s", pushRequest.Branch, repositoryId))
+			return
+		}
+		if err != nil {
+			h.WriteAzureDevOpsError(w, err, "get branch", notFoundMessage)
+			return
+		}
+
+		changes, err := h.buildChanges(organization, projectId, repositoryId, pushRequest.Files, tip, apiVersion, authHeader)
+		if err != nil {
+			var folderErr *errFolderPath
+			if errors.As(err, &folderErr) {
+				h.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid push: %v", err))
+				return
+			}
+			h.WriteAzureDevOpsError(w, err, "get files", notFoundMessage)
+			return
+		}
+
+		response := PushResponse{Branch: pushRequest.Branch, CommitID: tip, Changes: []FileChange{}}
+		for _, change := range changes {
+			response.Changes = append(response.Changes, FileChange{Path: change.Item.Path, ChangeType: change.ChangeType})
+		}
+		if len(changes) == 0 {
+			h.Log.Printf("Files already up to date on branch %s at %s", pushRequest.Branch, tip)
+			h.WriteObjectResponse(w, http.StatusOK, response)
+			return
+		}
+
+		pushed, err := h.push(organization, projectId, repositoryId, pushRequest.Branch, tip, pushRequest.Comment, changes, apiVersion, authHeader)
+		if err != nil {
+			// The branch was updated since its tip was read (non fast-forward): the changes are built again on top of the new tip
+			var adoErr *handlers.AzureDevOpsError
+			if errors.As(err, &adoErr) && adoErr.StatusCode == http.StatusConflict {
+				if attempt < MaxPushAttempts {
+					h.Log.Printf("Branch %s was updated since %s, retrying push (attempt %d of %d)", pushRequest.Branch, tip, attempt+1, MaxPushAttempts)
+					continue
+				}
+				h.WriteErrorResponse(w, http.StatusConflict, fmt.Sprintf("Conflict: branch %s was updated concurrently, push failed after %d attempts", pushRequest.Branch, MaxPushAttempts))
+				return
+			}
+			h.WriteAzureDevOpsError(w, err, "push files", notFoundMessage)
+			return
+		}
+
+		response.Pushed = true
+		response.PushID = pushed.PushID
+		if len(pushed.RefUpdates) > 0 && pushed.RefUpdates[0].NewObjectID != "" {
+			response.CommitID = pushed.RefUpdates[0].NewObjectID
+		} else if len(pushed.Commits) > 0 {
+			response.CommitID = pushed.Commits[len(pushed.Commits)-1].CommitID
+		}
+
+		h.WriteObjectResponse(w, http.StatusCreated, response)
+		h.Log.Printf("Successfully pushed %d changes to branch %s with commit %s", len(changes), pushRequest.Branch, response.CommitID)
+		return
+	}
+}
@@ -0,0 +1,350 @@
+package gitpush
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers"
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/handlerstest"
+	"github.com/rs/zerolog"
+)
+
+// newTestBaseHandler creates a base handler for testing with a mock client
+func newTestBaseHandler(mockClient *handlerstest.MockHTTPClient) *baseHandler {
+	return newBaseHandler(handlerstest.NewHandlerOptions(mockClient))
+}
+
+// Test data constants
+const (
+	testOrg        = "testorg"
+	testProject    = "Platform"
+	testRepository = "platform-api"
+	testAPIVersion = "7.1"
+	testUsername   = "test"
+	testPassword   = "test"
+	firstTip       = "1111111111111111111111111111111111111111"
+	secondTip      = "2222222222222222222222222222222222222222"
+	pushedCommit   = "3333333333333333333333333333333333333333"
+	buildBlob      = "069606e9430ceb4ae3174d0997436bb63a0327f0" // trigger: none\n
+	otherBlob      = "4444444444444444444444444444444444444444"
+)
+
+var (
+	refsURL   = fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/git/repositories/%s/refs?api-version=%s&filter=%s", testOrg, testProject, testRepository, testAPIVersion, url.QueryEscape("heads/main"))
+	pushesURL = fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/git/repositories/%s/pushes?api-version=%s", testOrg, testProject, testRepository, testAPIVersion)
+
+	pushedResp = `{"pushId":42,"refUpdates":[{"name":"refs/heads/main","oldObjectId":"` + firstTip + `","newObjectId":"` + pushedCommit + `"}],"commits":[{"commitId":"` + pushedCommit + `","comment":"Update managed files","changes":[]}]}`
+)
+
+// refsResp returns the refs of the branch filter, with main at the given commit
+func refsResp(tip string) string {
+	return `{"count":2,"value":[{"name":"refs/heads/main","objectId":"` + tip + `"},{"name":"refs/heads/main-old","objectId":"` + otherBlob + `"}]}`
+}
+
+// itemURL returns the URL used to get the item at the path in the given commit
+func itemURL(itemPath, commitID string) string {
+	query := url.Values{
+		"path":                          {itemPath},
+		"versionDescriptor.version":     {commitID},
+		"versionDescriptor.versionType": {"commit"},
+		"$format":                       {"json"},
+		"api-version":                   {testAPIVersion},
+	}
+	return fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/git/repositories/%s/items?%s", testOrg, testProject, testRepository, query.Encode())
+}
+
+// itemResp returns the item of a file with the given blob
+func itemResp(itemPath, objectID string) string {
+	return `{"objectId":"` + objectID + `","gitObjectType":"blob","path":"` + itemPath + `"}`
+}
+
+// pushTestCase is a test case for the handlers of this package
+type pushTestCase struct {
+	name                 string
+	pathValues           map[string]string
+	query                url.Values
+	noAuth               bool
+	body                 string
+	setupMock            func(*handlerstest.MockHTTPClient)
+	expectedStatus       int
+	expectedBody         string
+	expectedBodyContains string
+	expectedRequestCount int
+	verify               func(t *testing.T, mockClient *handlerstest.MockHTTPClient)
+}
+
+// pushPathValues returns the path values of the push endpoint
+func pushPathValues() map[string]string {
+	return map[string]string{"organization": testOrg, "projectId": testProject, "repositoryId": testRepository}
+}
+
+// runPushTests runs the given test cases against the handler built by newHandler
+func runPushTests(t *testing.T, method string, newHandler func(*baseHandler) http.Handler, tests []pushTestCase) {
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := handlerstest.NewMockHTTPClient()
+			if tt.setupMock != nil {
+				tt.setupMock(mockClient)
+			}
+			handler := newHandler(newTestBaseHandler(mockClient))
+
+			target := "/api/test"
+			if len(tt.query) > 0 {
+				target += "?" + tt.query.Encode()
+			}
+			var body io.Reader
+			if tt.body != "" {
+				body = strings.NewReader(tt.body)
+			}
+			req := httptest.NewRequest(method, target, body)
+			for key, value := range tt.pathValues {
+				req.SetPathValue(key, value)
+			}
+			if !tt.noAuth {
+				req.SetBasicAuth(testUsername, testPassword)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("Status code = %d, want %d. Body: %s", rr.Code, tt.expectedStatus, rr.Body.String())
+			}
+			if tt.expectedBody != "" && rr.Body.String() != tt.expectedBody {
+				t.Errorf("Body = %s, want %s", rr.Body.String(), tt.expectedBody)
+			}
+			if tt.expectedBodyContains != "" && !strings.Contains(rr.Body.String(), tt.expectedBodyContains) {
+				t.Errorf("Body = %s, want it to contain %s", rr.Body.String(), tt.expectedBodyContains)
+			}
+			if mockClient.RequestCount() != tt.expectedRequestCount {
+				t.Errorf("Request count = %d, want %d", mockClient.RequestCount(), tt.expectedRequestCount)
+			}
+			if tt.verify != nil {
+				tt.verify(t, mockClient)
+			}
+		})
+	}
+}
+
+// apiVersionQuery returns the query with the test API version
+func apiVersionQuery() url.Values {
+	return url.Values{"api-version": {testAPIVersion}}
+}
+
+// requestBody returns the body of the n-th request sent to Azure DevOps
+func requestBody(t *testing.T, mockClient *handlerstest.MockHTTPClient, n int) string {
+	t.Helper()
+	if len(mockClient.Requests) <= n || mockClient.Requests[n].Body == nil {
+		t.Fatalf("request %d has no body", n)
+	}
+	body, err := io.ReadAll(mockClient.Requests[n].Body)
+	if err != nil {
+		t.Fatalf("failed to read request %d body: %v", n, err)
+	}
+	return string(body)
+}
+
+// containsRequest reports whether a request was sent with the given method and URL
+func containsRequest(mockClient *handlerstest.MockHTTPClient, method, url string) bool {
+	for _, req := range mockClient.Requests {
+		if req.Method+" "+req.URL.String() == method+" "+url {
+			return true
+		}
+	}
+	return false
+}
+
+// Test constructor functions
+func TestGitPushConstructors(t *testing.T) {
+	client := &http.Client{}
+	logger := zerolog.New(os.Stdout).With().Timestamp().Logger()
+	opts := handlers.HandlerOptions{
+		Client: client,
+		Log:    &logger,
+	}
+
+	if h := PostPush(opts); h == nil {
+		t.Fatalf("PostPush should return a non-nil handler")
+	}
+}
+
+// Test POST handler
+func TestPostHandler_ServeHTTP(t *testing.T) {
+	t.Setenv("GIT_PUSHES_API_VERSION", "")
+
+	managedFiles := `{"branch":"main","files":[` +
+		`{"path":"pipelines/deploy.yml","content":"stages: []\n"},` +
+		`{"path":"/pipelines/build.yml","content":"trigger: none\n"},` +
+		`{"path":"/old.yml","delete":true},` +
+		`{"path":"/README.md","content":"IyBQbGF0Zm9ybQo=","contentType":"base64encoded"}]}`
+
+	runPushTests(t, "POST", func(b *baseHandler) http.Handler { return &postHandler{baseHandler: b} }, []pushTestCase{
+		{
+			name:       "only the files that differ are pushed",
+			pathValues: pushPathValues(),
+			query:      apiVersionQuery(),
+			body:       managedFiles,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(refsURL, http.StatusOK, refsResp(firstTip))
+				m.SetResponse(itemURL("/README.md", firstTip), http.StatusOK, itemResp("/README.md", otherBlob))
+				m.SetResponse(itemURL("/old.yml", firstTip), http.StatusOK, itemResp("/old.yml", otherBlob))
+				m.SetResponse(itemURL("/pipelines/build.yml", firstTip), http.StatusOK, itemResp("/pipelines/build.yml", buildBlob))
+				m.SetMethodResponse("POST", pushesURL, http.StatusCreated, pushedResp)
+			},
+			expectedStatus:       http.StatusCreated,
+			expectedBody:         `{"branch":"refs/heads/main","commitId":"` + pushedCommit + `","pushId":42,"pushed":true,"changes":[{"path":"/README.md","changeType":"edit"},{"path":"/old.yml","changeType":"delete"},{"path":"/pipelines/deploy.yml","changeType":"add"}]}`,
+			expectedRequestCount: 6,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				want := `{"refUpdates":[{"name":"refs/heads/main","oldObjectId":"` + firstTip + `"}],"commits":[{"comment":"Update managed files","changes":[` +
+					`{"changeType":"edit","item":{"path":"/README.md"},"newContent":{"content":"IyBQbGF0Zm9ybQo=","contentType":"base64encoded"}},` +
+					`{"changeType":"delete","item":{"path":"/old.yml"}},` +
+					`{"changeType":"add","item":{"path":"/pipelines/deploy.yml"},"newContent":{"content":"stages: []\n","contentType":"rawtext"}}]}]}`
+				if got := requestBody(t, m, 5); got != want {
+					t.Errorf("Request body = %s, want %s", got, want)
+				}
+			},
+		},
+		{
+			name:       "files already up to date",
+			pathValues: pushPathValues(),
+			query:      apiVersionQuery(),
+			body:       `{"branch":"refs/heads/main","comment":"Sync","files":[{"path":"/pipelines/build.yml","content":"trigger: none\n"},{"path":"/old.yml","delete":true}]}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(refsURL, http.StatusOK, refsResp(firstTip))
+				m.SetResponse(itemURL("/pipelines/build.yml", firstTip), http.StatusOK, itemResp("/pipelines/build.yml", buildBlob))
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBody:         `{"branch":"refs/heads/main","commitId":"` + firstTip + `","pushed":false,"changes":[]}`,
+			expectedRequestCount: 3,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				if containsRequest(m, "POST", pushesURL) {
+					t.Errorf("Nothing should be pushed when the files are up to date")
+				}
+			},
+		},
+		{
+			name:       "push retried on top of the new tip",
+			pathValues: pushPathValues(),
+			query:      apiVersionQuery(),
+			body:       `{"branch":"main","files":[{"path":"/pipelines/build.yml","content":"trigger: none\n"}]}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponseSequence(refsURL, handlerstest.MockResponse{StatusCode: http.StatusOK, Body: refsResp(firstTip)}, handlerstest.MockResponse{StatusCode: http.StatusOK, Body: refsResp(secondTip)})
+				m.SetResponse(itemURL("/pipelines/build.yml", firstTip), http.StatusOK, itemResp("/pipelines/build.yml", otherBlob))
+				m.SetResponse(itemURL("/pipelines/build.yml", secondTip), http.StatusOK, itemResp("/pipelines/build.yml", otherBlob))
+				m.SetResponseSequence("POST "+pushesURL, handlerstest.MockResponse{StatusCode: http.StatusConflict, Body: `{"message":"TF401028: The reference 'refs/heads/main' has already been updated by another client"}`}, handlerstest.MockResponse{StatusCode: http.StatusCreated, Body: pushedResp})
+			},
+			expectedStatus:       http.StatusCreated,
+			expectedBodyContains: `"commitId":"` + pushedCommit + `"`,
+			expectedRequestCount: 6,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				if got := requestBody(t, m, 5); !strings.Contains(got, `"oldObjectId":"`+secondTip+`"`) {
+					t.Errorf("Retried push should be based on the new tip, got %s", got)
+				}
+			},
+		},
+		{
+			name:       "push retried until the attempts are exhausted",
+			pathValues: pushPathValues(),
+			query:      apiVersionQuery(),
+			body:       `{"branch":"main","files":[{"path":"/pipelines/build.yml","content":"trigger: none\n"}]}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				// Sequences with a single response return it for every request
+				m.SetResponseSequence(refsURL, handlerstest.MockResponse{StatusCode: http.StatusOK, Body: refsResp(firstTip)})
+				m.SetResponseSequence(itemURL("/pipelines/build.yml", firstTip), handlerstest.MockResponse{StatusCode: http.StatusNotFound, Body: `{"message":"TF401174: The item could not be found"}`})
+				m.SetResponseSequence("POST "+pushesURL, handlerstest.MockResponse{StatusCode: http.StatusConflict, Body: `{"message":"TF401028"}`})
+			},
+			expectedStatus:       http.StatusConflict,
+			expectedBody:         "Conflict: branch refs/heads/main was updated concurrently, push failed after 3 attempts",
+			expectedRequestCount: 9,
+		},
+		{
+			name:       "path of a folder",
+			pathValues: pushPathValues(),
+			query:      apiVersionQuery(),
+			body:       `{"branch":"main","files":[{"path":"/pipelines","content":"x"}]}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(refsURL, http.StatusOK, refsResp(firstTip))
+				m.SetResponse(itemURL("/pipelines", firstTip), http.StatusOK, `{"objectId":"`+otherBlob+`","gitObjectType":"tree","path":"/pipelines"}`)
+			},
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid push: /pipelines is a folder",
+			expectedRequestCount: 2,
+		},
+		{
+			name:       "branch not found",
+			pathValues: pushPathValues(),
+			query:      apiVersionQuery(),
+			body:       `{"branch":"main","files":[{"path":"/a.yml","content":"x"}]}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(refsURL, http.StatusOK, `{"count":0,"value":[]}`)
+			},
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Branch refs/heads/main not found in repository platform-api",
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "repository not found",
+			pathValues:           pushPathValues(),
+			query:                apiVersionQuery(),
+			body:                 `{"branch":"main","files":[{"path":"/a.yml","content":"x"}]}`,
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Repository platform-api not found in project Platform",
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "file listed twice",
+			pathValues:           pushPathValues(),
+			query:                apiVersionQuery(),
+			body:                 `{"branch":"main","files":[{"path":"a.yml","content":"x"},{"path":"/a.yml","delete":true}]}`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid push: /a.yml: listed more than once",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "invalid JSON",
+			pathValues:           pushPathValues(),
+			query:                apiVersionQuery(),
+			body:                 `{"branch":`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid JSON in request body",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "missing basic auth",
+			pathValues:           pushPathValues(),
+			query:                apiVersionQuery(),
+			noAuth:               true,
+			expectedStatus:       http.StatusUnauthorized,
+			expectedBody:         "Request rejected due to missing or invalid Basic authentication",
+			expectedRequestCount: 0,
+		},
+	})
+}
+
+// Test the API version of the Git Pushes API set by environment variable
+func TestPostHandler_PushesAPIVersion(t *testing.T) {
+	t.Setenv("GIT_PUSHES_API_VERSION", "7.2-preview.3")
+	pushesPreviewURL := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/git/repositories/%s/pushes?api-version=7.2-preview.3", testOrg, testProject, testRepository)
+
+	runPushTests(t, "POST", func(b *baseHandler) http.Handler { return &postHandler{baseHandler: b} }, []pushTestCase{
+		{
+			name:       "pushes API version from environment",
+			pathValues: pushPathValues(),
+			query:      apiVersionQuery(),
+			body:       `{"branch":"main","files":[{"path":"/a.yml","content":"x"}]}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(refsURL, http.StatusOK, refsResp(firstTip))
+				m.SetMethodResponse("POST", pushesPreviewURL, http.StatusCreated, pushedResp)
+			},
+			expectedStatus:       http.StatusCreated,
+			expectedBodyContains: `"changes":[{"path":"/a.yml","changeType":"add"}]`,
+			expectedRequestCount: 3,
+		},
+	})
+}
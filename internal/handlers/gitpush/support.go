@@ -0,0 +1,126 @@
+package gitpush
+
+import (
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// normalizeBranchName returns the full ref name of the branch, e.g., main becomes refs/heads/main
+func normalizeBranchName(branch string) string {
+	branch = strings.Trim(strings.TrimSpace(branch), "/")
+	if branch == "" {
+		return ""
+	}
+	return "refs/heads/" + strings.TrimPrefix(branch, "refs/heads/")
+}
+
+// normalizePath returns the path of the file from the root of the repository, e.g., pipelines//build.yml becomes /pipelines/build.yml
+// An empty string is returned for the root and for paths leaving the repository
+func normalizePath(filePath string) string {
+	filePath = strings.TrimSpace(filePath)
+	if filePath == "" || strings.Contains(filePath, "..") {
+		return ""
+	}
+	cleaned := path.Clean("/" + filePath)
+	if cleaned == "/" {
+		return ""
+	}
+	return cleaned
+}
+
+// validatePushRequest checks and normalizes the fields of a push request
+func validatePushRequest(request *PushRequest) error {
+	request.Branch = normalizeBranchName(request.Branch)
+	if request.Branch == "" {
+		return fmt.Errorf("branch is required")
+	}
+	if strings.TrimSpace(request.Comment) == "" {
+		request.Comment = DefaultComment
+	}
+	if len(request.Files) == 0 {
+		return fmt.Errorf("files is required")
+	}
+
+	paths := map[string]bool{}
+	for i := range request.Files {
+		file := &request.Files[i]
+		normalized := normalizePath(file.Path)
+		if normalized == "" {
+			return fmt.Errorf("invalid path '%s'", file.Path)
+		}
+		if paths[normalized] {
+			return fmt.Errorf("%s: listed more than once", normalized)
+		}
+		paths[normalized] = true
+		file.Path = normalized
+
+		if file.Delete {
+			if file.Content != "" {
+				return fmt.Errorf("%s: content cannot be set for a file to delete", file.Path)
+			}
+			continue
+		}
+		switch strings.ToLower(file.ContentType) {
+		case "", "rawtext":
+			file.ContentType = "rawtext"
+		case "base64encoded":
+			file.ContentType = "base64encoded"
+			if _, err := base64.StdEncoding.DecodeString(file.Content); err != nil {
+				return fmt.Errorf("%s: content is not valid base64", file.Path)
+			}
+		default:
+			return fmt.Errorf("%s: contentType must be rawtext or base64encoded, got '%s'", file.Path, file.ContentType)
+		}
+	}
+
+	// Changes are built and returned sorted by path
+	sort.Slice(request.Files, func(i, j int) bool { return request.Files[i].Path < request.Files[j].Path })
+	return nil
+}
+
+// fileBytes returns the bytes of the content of the file
+func fileBytes(file *FileRequest) []byte {
+	if file.ContentType == "base64encoded" {
+		content, _ := base64.StdEncoding.DecodeString(file.Content) // Validated by validatePushRequest
+		return content
+	}
+	return []byte(file.Content)
+}
+
+// blobObjectID returns the Git object ID of a blob with the given content,
+// which Azure DevOps returns as the object ID of a file
+func blobObjectID(content []byte) string {
+	hash := sha1.New()
+	fmt.Fprintf(hash, "blob %d\x00", len(content))
+	hash.Write(content)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// buildChange returns the change needed to bring the file to its desired state, nil if it is already up to date
+// current is the item of the file at the tip of the branch, nil if it does not exist
+func buildChange(file *FileRequest, current *GitItem) *GitChange {
+	if file.Delete {
+		if current == nil {
+			return nil
+		}
+		return &GitChange{ChangeType: "delete", Item: GitItemPath{Path: file.Path}}
+	}
+
+	changeType := "add"
+	if current != nil {
+		if strings.EqualFold(current.ObjectID, blobObjectID(fileBytes(file))) {
+			return nil
+		}
+		changeType = "edit"
+	}
+	return &GitChange{
+		ChangeType: changeType,
+		Item:       GitItemPath{Path: file.Path},
+		NewContent: &ItemContent{Content: file.Content, ContentType: file.ContentType},
+	}
+}
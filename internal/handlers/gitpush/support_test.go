@@ -0,0 +1,82 @@
+package gitpush
+
+import (
+	"testing"
+)
+
+func TestBlobObjectID(t *testing.T) {
+	tests := map[string]string{
+		"":                "e69de29bb2d1d6434b8b29ae775ad8c2e48c5391",
+		"hello\n":         "ce013625030ba8dba906f756967f9e9ca394464a",
+		"trigger: none\n": buildBlob,
+	}
+	for content, expected := range tests {
+		if got := blobObjectID([]byte(content)); got != expected {
+			t.Errorf("blobObjectID(%q) = %s, want %s", content, got, expected)
+		}
+	}
+}
+
+func TestNormalizePath(t *testing.T) {
+	tests := map[string]string{
+		"pipelines/build.yml":    "/pipelines/build.yml",
+		" /pipelines//build.yml": "/pipelines/build.yml",
+		"/pipelines/":            "/pipelines",
+		"/":                      "",
+		"../secrets":             "",
+	}
+	for filePath, expected := range tests {
+		if got := normalizePath(filePath); got != expected {
+			t.Errorf("normalizePath(%q) = %q, want %q", filePath, got, expected)
+		}
+	}
+}
+
+func TestValidatePushRequest(t *testing.T) {
+	tests := []struct {
+		name          string
+		request       PushRequest
+		expectedError string
+	}{
+		{name: "valid", request: PushRequest{Branch: "main", Files: []FileRequest{{Path: "/a.yml", Content: "x"}, {Path: "/b.yml", Delete: true}}}},
+		{name: "missing branch", request: PushRequest{Files: []FileRequest{{Path: "/a.yml"}}}, expectedError: "branch is required"},
+		{name: "missing files", request: PushRequest{Branch: "main"}, expectedError: "files is required"},
+		{name: "root path", request: PushRequest{Branch: "main", Files: []FileRequest{{Path: "/"}}}, expectedError: "invalid path '/'"},
+		{name: "content of a file to delete", request: PushRequest{Branch: "main", Files: []FileRequest{{Path: "/a.yml", Content: "x", Delete: true}}}, expectedError: "/a.yml: content cannot be set for a file to delete"},
+		{name: "invalid base64", request: PushRequest{Branch: "main", Files: []FileRequest{{Path: "/a.bin", Content: "%%", ContentType: "base64Encoded"}}}, expectedError: "/a.bin: content is not valid base64"},
+		{name: "unknown content type", request: PushRequest{Branch: "main", Files: []FileRequest{{Path: "/a.yml", ContentType: "binary"}}}, expectedError: "/a.yml: contentType must be rawtext or base64encoded, got 'binary'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePushRequest(&tt.request)
+			if tt.expectedError == "" && err != nil {
+				t.Fatalf("validatePushRequest() error = %v", err)
+			}
+			if tt.expectedError != "" && (err == nil || err.Error() != tt.expectedError) {
+				t.Fatalf("validatePushRequest() error = %v, want %s", err, tt.expectedError)
+			}
+		})
+	}
+}
+
+func TestBuildChange(t *testing.T) {
+	file := FileRequest{Path: "/pipelines/build.yml", Content: "trigger: none\n", ContentType: "rawtext"}
+	if change := buildChange(&file, &GitItem{ObjectID: buildBlob}); change != nil {
+		t.Errorf("buildChange() = %v, want nil for an unchanged file", change)
+	}
+	if change := buildChange(&file, &GitItem{ObjectID: otherBlob}); change == nil || change.ChangeType != "edit" {
+		t.Errorf("buildChange() = %v, want edit", change)
+	}
+	if change := buildChange(&file, nil); change == nil || change.ChangeType != "add" {
+		t.Errorf("buildChange() = %v, want add", change)
+	}
+
+	deleted := FileRequest{Path: "/old.yml", Delete: true}
+	if change := buildChange(&deleted, nil); change != nil {
+		t.Errorf("buildChange() = %v, want nil for a file already deleted", change)
+	}
+	if change := buildChange(&deleted, &GitItem{ObjectID: otherBlob}); change == nil || change.ChangeType != "delete" || change.NewContent != nil {
+		t.Errorf("buildChange() = %v, want delete without content", change)
+	}
+}
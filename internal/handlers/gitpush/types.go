@@ -0,0 +1,102 @@
+package gitpush
+
+// PushRequest represents the request body for pushing a set of files to a branch in one commit
+type PushRequest struct {
+	Branch  string        `json:"branch"`            // Required: e.g., main or refs/heads/main
+	Comment string        `json:"comment,omitempty"` // Commit message, defaults to DefaultComment
+	Files   []FileRequest `json:"files"`             // Required: at least one file
+}
+
+// FileRequest represents the desired state of a file of a push
+type FileRequest struct {
+	Path        string `json:"path"`                  // Required: e.g., /pipelines/build.yml
+	Content     string `json:"content,omitempty"`     // Content of the file, ignored when Delete is set
+	ContentType string `json:"contentType,omitempty"` // rawtext (default) or base64encoded
+	Delete      bool   `json:"delete,omitempty"`      // The file must not exist
+}
+
+// PushResponse represents the response of the push endpoint
+type PushResponse struct {
+	Branch   string       `json:"branch"`           // e.g., refs/heads/main
+	CommitID string       `json:"commitId"`         // Commit the branch points to after the push
+	PushID   int          `json:"pushId,omitempty"` // Only set when a commit was pushed
+	Pushed   bool         `json:"pushed"`           // false if all the files were already up to date
+	Changes  []FileChange `json:"changes"`          // Files changed by the commit, sorted by path
+}
+
+// FileChange represents a file changed by a push
+type FileChange struct {
+	Path       string `json:"path"`
+	ChangeType string `json:"changeType"` // add, edit or delete
+}
+
+// ---
+
+// GitRef represents a Git ref of Azure DevOps
+type GitRef struct {
+	Name     string `json:"name"`
+	ObjectID string `json:"objectId"`
+}
+
+// GitRefList represents the response from:
+// GET https://dev.azure.com/{organization}/{project}/_apis/git/repositories/{repositoryId}/refs?filter={filter}
+type GitRefList struct {
+	Count int      `json:"count"`
+	Value []GitRef `json:"value"`
+}
+
+// GitItem represents an item (file or folder) of a Git repository, from:
+// GET https://dev.azure.com/{organization}/{project}/_apis/git/repositories/{repositoryId}/items?path={path}&versionDescriptor.version={commitId}&versionDescriptor.versionType=commit
+type GitItem struct {
+	ObjectID      string `json:"objectId"`      // SHA-1 of the blob of a file
+	GitObjectType string `json:"gitObjectType"` // blob or tree
+	Path          string `json:"path"`
+	CommitID      string `json:"commitId,omitempty"`
+}
+
+// GitPush represents the request body and the response of:
+// POST https://dev.azure.com/{organization}/{project}/_apis/git/repositories/{repositoryId}/pushes
+type GitPush struct {
+	PushID     int            `json:"pushId,omitempty"`
+	RefUpdates []GitRefUpdate `json:"refUpdates"`
+	Commits    []GitCommit    `json:"commits"`
+}
+
+// GitRefUpdate represents the update of the branch of a push
+// The push is rejected if the branch does not point to OldObjectID anymore
+type GitRefUpdate struct {
+	Name        string `json:"name"`
+	OldObjectID string `json:"oldObjectId"`
+	NewObjectID string `json:"newObjectId,omitempty"`
+}
+
+// GitCommit represents a commit of a push
+type GitCommit struct {
+	CommitID string      `json:"commitId,omitempty"`
+	Comment  string      `json:"comment"`
+	Changes  []GitChange `json:"changes"`
+}
+
+// GitChange represents the change of a file in a commit
+type GitChange struct {
+	ChangeType string       `json:"changeType"` // add, edit or delete
+	Item       GitItemPath  `json:"item"`
+	NewContent *ItemContent `json:"newContent,omitempty"`
+}
+
+// GitItemPath represents the path of the item of a change
+type GitItemPath struct {
+	Path string `json:"path"`
+}
+
+// ItemContent represents the content of a file in a change
+type ItemContent struct {
+	Content     string `json:"content"`
+	ContentType string `json:"contentType"` // rawtext or base64encoded
+}
+
+// DefaultComment is the commit message used when the request does not set one
+const DefaultComment = "Update managed files"
+
+// MaxPushAttempts is the number of times a push is attempted when the branch is updated concurrently
+const MaxPushAttempts = 3
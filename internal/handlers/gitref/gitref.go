@@ -0,0 +1,562 @@
+package gitref
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	neturl "net/url"
+	"strconv"
+	"strings"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers"
+)
+
+// Handler constructors
+func ListRefs(opts handlers.HandlerOptions) handlers.Handler {
+	return &listHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func GetBranch(opts handlers.HandlerOptions) handlers.Handler {
+	return &getHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func PostBranch(opts handlers.HandlerOptions) handlers.Handler {
+	return &postHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func PatchBranch(opts handlers.HandlerOptions) handlers.Handler {
+	return &patchHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func DeleteBranch(opts handlers.HandlerOptions) handlers.Handler {
+	return &deleteHandler{baseHandler: newBaseHandler(opts)}
+}
+
+// Interface compliance verification
+var _ handlers.Handler = &listHandler{}
+var _ handlers.Handler = &getHandler{}
+var _ handlers.Handler = &postHandler{}
+var _ handlers.Handler = &patchHandler{}
+var _ handlers.Handler = &deleteHandler{}
+
+// Base handler with common functionality
+type baseHandler struct {
+	*handlers.BaseHandler
+}
+
+// Constructor for the base handler
+func newBaseHandler(opts handlers.HandlerOptions) *baseHandler {
+	return &baseHandler{BaseHandler: handlers.NewBaseHandler(opts)}
+}
+
+// Handler types embedding the base handler
+type listHandler struct {
+	*baseHandler
+}
+
+type getHandler struct {
+	*baseHandler
+}
+
+type postHandler struct {
+	*baseHandler
+}
+
+type patchHandler struct {
+	*baseHandler
+}
+
+type deleteHandler struct {
+	*baseHandler
+}
+
+// errSourceNotFound is returned when the source ref or commit of a new branch does not exist
+var errSourceNotFound = errors.New("source not found")
+
+// listRefs returns a page of the refs of the repository and the continuation token of the next page
+func (h *baseHandler) listRefs(organization, projectId, repositoryId string, query neturl.Values, apiVersion, authHeader string) (*GitRefList, string, error) {
+	query.Set("api-version", apiVersion)
+	url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/git/repositories/%s/refs?%s", organization, projectId, repositoryId, query.Encode())
+
+	body, header, err := h.DoAzureDevOpsRequestWithHeader("GET", url, authHeader, nil, nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var refs GitRefList
+	if err := json.Unmarshal(body, &refs); err != nil {
+		return nil, "", fmt.Errorf("failed to unmarshal refs: %w", err)
+	}
+	return &refs, header.Get(ContinuationTokenHeader), nil
+}
+
+// getRef returns the ref with the given full name, nil if it does not exist
+func (h *baseHandler) getRef(organization, projectId, repositoryId, name, apiVersion, authHeader string) (*GitRef, error) {
+	query := neturl.Values{}
+	query.Set("filter", refFilter(name))
+	query.Set("peelTags", "true")
+
+	refs, _, err := h.listRefs(organization, projectId, repositoryId, query, apiVersion, authHeader)
+	if err != nil {
+		return nil, err
+	}
+	return findRef(refs.Value, name), nil
+}
+
+// resolveSource returns the commit a new branch must point to
+// errSourceNotFound is returned if the source ref or commit does not exist
+func (h *baseHandler) resolveSource(organization, projectId, repositoryId string, request *CreateBranchRequest, apiVersion, authHeader string) (string, error) {
+	if request.SourceCommitID != "" {
+		url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/git/repositories/%s/commits/%s?api-version=%s", organization, projectId, repositoryId, request.SourceCommitID, apiVersion)
+		body, err := h.DoAzureDevOpsRequest("GET", url, authHeader, nil)
+		if err != nil {
+			var adoErr *handlers.AzureDevOpsError
+			if errors.As(err, &adoErr) && adoErr.StatusCode == http.StatusNotFound {
+				return "", errSourceNotFound
+			}
+			return "", err
+		}
+
+		var commit GitCommitRef
+		if err := json.Unmarshal(body, &commit); err != nil {
+			return "", fmt.Errorf("failed to unmarshal commit: %w", err)
+		}
+		return commit.CommitID, nil
+	}
+
+	source, err := h.getRef(organization, projectId, repositoryId, request.SourceRef, apiVersion, authHeader)
+	if err != nil {
+		return "", err
+	}
+	if source == nil {
+		return "", errSourceNotFound
+	}
+	// Annotated tags point to a tag object, the branch must point to its commit
+	if source.PeeledObjectID != "" {
+		return source.PeeledObjectID, nil
+	}
+	return source.ObjectID, nil
+}
+
+// updateRef sends the ref update to Azure DevOps and returns its result
+// The update is only applied if the ref still points to the old object ID of the update
+func (h *baseHandler) updateRef(organization, projectId, repositoryId string, update GitRefUpdate, apiVersion, authHeader string) (*GitRefUpdateResult, error) {
+	requestBody, err := json.Marshal([]GitRefUpdate{update})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal ref update: %w", err)
+	}
+
+	url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/git/repositories/%s/refs?api-version=%s", organization, projectId, repositoryId, apiVersion)
+	body, err := h.DoAzureDevOpsRequest("POST", url, authHeader, requestBody)
+	if err != nil {
+		return nil, err
+	}
+
+	var results GitRefUpdateResultList
+	if err := json.Unmarshal(body, &results); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ref update results: %w", err)
+	}
+	if len(results.Value) != 1 {
+		return nil, fmt.Errorf("expected 1 ref update result, got %d", len(results.Value))
+	}
+	return &results.Value[0], nil
+}
+
+// branchFromPath returns the full ref name of the branch of the request path, writing 400 if it is empty
+func (h *baseHandler) branchFromPath(w http.ResponseWriter, r *http.Request) (string, bool) {
+	branch := normalizeBranchName(r.PathValue("branch"))
+	if branch == "" {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Branch parameter is required")
+		return "", false
+	}
+	return branch, true
+}
+
+// LIST handler implementation
+// @Summary List the refs of a Git repository
+// @Description List the refs (branches and tags) of a Git repository, with their lock status. The refs can be filtered by prefix (e.g., heads/ or refs/heads/feature/) or by a substring, and paged with $top and the returned continuationToken.
+// @ID list-refs
+// @Param organization path string true "Organization name"
+// @Param projectId path string true "Project ID or name"
+// @Param repositoryId path string true "Repository ID or name"
+// @Param api-version query string true "API version (e.g., 7.1)"
+// @Param filter query string false "Prefix of the ref names, with or without refs/ (e.g., heads/ or tags/v1)"
+// @Param filterContains query string false "Substring of the ref names"
+// @Param $top query int false "Maximum number of refs returned"
+// @Param continuationToken query string false "Continuation token returned by a previous page"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Produce json
+// @Success 200 {object} RefListResponse "Page of refs"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{projectId}/git/repositories/{repositoryId}/refs [get]
+func (h *listHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	projectId := r.PathValue("projectId")
+	repositoryId := r.PathValue("repositoryId")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, projectId, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	query := neturl.Values{}
+	if filter := strings.TrimSpace(r.URL.Query().Get("filter")); filter != "" {
+		query.Set("filter", refFilter(filter))
+	}
+	if filterContains := strings.TrimSpace(r.URL.Query().Get("filterContains")); filterContains != "" {
+		query.Set("filterContains", filterContains)
+	}
+	if top := r.URL.Query().Get("$top"); top != "" {
+		if value, err := strconv.Atoi(top); err != nil || value <= 0 {
+			h.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid $top: %s", top))
+			return
+		}
+		query.Set("$top", top)
+	}
+	if continuationToken := r.URL.Query().Get("continuationToken"); continuationToken != "" {
+		query.Set("continuationToken", continuationToken)
+	}
+
+	h.Log.Printf("Listing refs of repository %s for project %s in organization %s", repositoryId, projectId, organization)
+
+	refs, continuationToken, err := h.listRefs(organization, projectId, repositoryId, query, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "list refs", fmt.Sprintf("Repository %s not found in project %s", repositoryId, projectId))
+		return
+	}
+
+	response := RefListResponse{Count: len(refs.Value), Value: []Ref{}, ContinuationToken: continuationToken}
+	for i := range refs.Value {
+		response.Value = append(response.Value, mapRefFromAzure(&refs.Value[i]))
+	}
+
+	h.WriteObjectResponse(w, http.StatusOK, response)
+	h.Log.Printf("Successfully listed %d refs of repository %s", response.Count, repositoryId)
+}
+
+// GET handler implementation
+// @Summary Get a branch of a Git repository
+// @Description Get a branch of a Git repository, with the commit it points to and its lock status
+// @ID get-branch
+// @Param organization path string true "Organization name"
+// @Param projectId path string true "Project ID or name"
+// @Param repositoryId path string true "Repository ID or name"
+// @Param branch path string true "Branch name (e.g., main or feature/x)"
+// @Param api-version query string true "API version (e.g., 7.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Produce json
+// @Success 200 {object} BranchResponse "Branch details"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{projectId}/git/repositories/{repositoryId}/branches/{branch} [get]
+func (h *getHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	projectId := r.PathValue("projectId")
+	repositoryId := r.PathValue("repositoryId")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, projectId, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+	branch, ok := h.branchFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	h.Log.Printf("Getting branch %s of repository %s for project %s in organization %s", branch, repositoryId, projectId, organization)
+
+	ref, err := h.getRef(organization, projectId, repositoryId, branch, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "get branch", fmt.Sprintf("Repository %s not found in project %s", repositoryId, projectId))
+		return
+	}
+	if ref == nil {
+		h.WriteErrorResponse(w, http.StatusNotFound, fmt.Sprintf("Branch %s not found in repository %s", branch, repositoryId))
+		return
+	}
+
+	h.WriteObjectResponse(w, http.StatusOK, BranchResponse(mapRefFromAzure(ref)))
+	h.Log.Printf("Successfully retrieved branch %s", branch)
+}
+
+// POST handler implementation
+// @Summary Create a branch in a Git repository
+// @Description Create a branch from a source ref (branch or tag) or from a commit. If the branch already exists and points to the same commit, it is returned with 200. If it points to another commit, 409 is returned and the branch is not changed.
+// @ID post-branch
+// @Param organization path string true "Organization name"
+// @Param projectId path string true "Project ID or name"
+// @Param repositoryId path string true "Repository ID or name"
+// @Param api-version query string true "API version (e.g., 7.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Param branch body CreateBranchRequest true "Branch creation request body"
+// @Accept json
+// @Produce json
+// @Success 200 {object} BranchResponse "Existing branch"
+// @Success 201 {object} BranchResponse "Created branch"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 403 "Forbidden"
+// @Failure 404 "Not Found"
+// @Failure 409 "Conflict"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{projectId}/git/repositories/{repositoryId}/branches [post]
+func (h *postHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	projectId := r.PathValue("projectId")
+	repositoryId := r.PathValue("repositoryId")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, projectId, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Failed to read request body")
+		return
+	}
+
+	var createRequest CreateBranchRequest
+	if err := json.Unmarshal(body, &createRequest); err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Invalid JSON in request body")
+		return
+	}
+	if err := validateCreateBranchRequest(&createRequest); err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid branch: %v", err))
+		return
+	}
+
+	h.Log.Printf("Creating branch %s in repository %s for project %s in organization %s", createRequest.Name, repositoryId, projectId, organization)
+
+	notFoundMessage := fmt.Sprintf("Repository %s not found in project %s", repositoryId, projectId)
+	commitID, err := h.resolveSource(organization, projectId, repositoryId, &createRequest, apiVersion, authHeader)
+	if err != nil {
+		if errors.Is(err, errSourceNotFound) {
+			source := createRequest.SourceRef
+			if source == "" {
+				source = createRequest.SourceCommitID
+			}
+			h.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid branch: source %s not found in repository %s", source, repositoryId))
+			return
+		}
+		h.WriteAzureDevOpsError(w, err, "resolve branch source", notFoundMessage)
+		return
+	}
+
+	existing, err := h.getRef(organization, projectId, repositoryId, createRequest.Name, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "get branch", notFoundMessage)
+		return
+	}
+	if existing != nil {
+		if !strings.EqualFold(existing.ObjectID, commitID) {
+			h.WriteErrorResponse(w, http.StatusConflict, fmt.Sprintf("Conflict: branch %s already exists at %s, not at %s", createRequest.Name, existing.ObjectID, commitID))
+			return
+		}
+		h.Log.Printf("Branch %s already exists at %s", createRequest.Name, commitID)
+		h.WriteObjectResponse(w, http.StatusOK, BranchResponse(mapRefFromAzure(existing)))
+		return
+	}
+
+	result, err := h.updateRef(organization, projectId, repositoryId, GitRefUpdate{Name: createRequest.Name, OldObjectID: EmptyObjectID, NewObjectID: commitID}, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "create branch", notFoundMessage)
+		return
+	}
+	if !result.Success {
+		h.WriteErrorResponse(w, updateStatusCode(result.UpdateStatus), updateFailureMessage(result))
+		return
+	}
+
+	h.WriteObjectResponse(w, http.StatusCreated, BranchResponse{Name: result.Name, ObjectID: result.NewObjectID, IsLocked: result.IsLocked})
+	h.Log.Printf("Successfully created branch %s at %s", createRequest.Name, commitID)
+}
+
+// PATCH handler implementation
+// @Summary Lock or unlock a branch of a Git repository
+// @Description Lock or unlock a branch. A locked branch cannot be updated or deleted, and new pull requests cannot be merged into it.
+// @ID patch-branch
+// @Param organization path string true "Organization name"
+// @Param projectId path string true "Project ID or name"
+// @Param repositoryId path string true "Repository ID or name"
+// @Param branch path string true "Branch name (e.g., main or feature/x)"
+// @Param api-version query string true "API version (e.g., 7.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Param branchUpdate body UpdateBranchRequest true "Branch update request body"
+// @Accept json
+// @Produce json
+// @Success 200 {object} BranchResponse "Updated branch"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{projectId}/git/repositories/{repositoryId}/branches/{branch} [patch]
+func (h *patchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	projectId := r.PathValue("projectId")
+	repositoryId := r.PathValue("repositoryId")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, projectId, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+	branch, ok := h.branchFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Failed to read request body")
+		return
+	}
+
+	var updateRequest UpdateBranchRequest
+	if err := json.Unmarshal(body, &updateRequest); err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Invalid JSON in request body")
+		return
+	}
+	if updateRequest.IsLocked == nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Invalid branch: isLocked is required")
+		return
+	}
+
+	h.Log.Printf("Setting lock of branch %s of repository %s to %t", branch, repositoryId, *updateRequest.IsLocked)
+
+	notFoundMessage := fmt.Sprintf("Repository %s not found in project %s", repositoryId, projectId)
+	ref, err := h.getRef(organization, projectId, repositoryId, branch, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "get branch", notFoundMessage)
+		return
+	}
+	if ref == nil {
+		h.WriteErrorResponse(w, http.StatusNotFound, fmt.Sprintf("Branch %s not found in repository %s", branch, repositoryId))
+		return
+	}
+
+	if ref.IsLocked != *updateRequest.IsLocked {
+		requestBody, err := json.Marshal(GitRefLockUpdate{IsLocked: *updateRequest.IsLocked})
+		if err != nil {
+			h.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Failed to marshal request: %v", err))
+			return
+		}
+
+		query := neturl.Values{}
+		query.Set("filter", refFilter(branch))
+		query.Set("api-version", apiVersion)
+		url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/git/repositories/%s/refs?%s", organization, projectId, repositoryId, query.Encode())
+		responseBody, err := h.DoAzureDevOpsRequest("PATCH", url, authHeader, requestBody)
+		if err != nil {
+			h.WriteAzureDevOpsError(w, err, "update branch lock", notFoundMessage)
+			return
+		}
+
+		ref = &GitRef{}
+		if err := json.Unmarshal(responseBody, ref); err != nil {
+			h.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Failed to unmarshal branch: %v", err))
+			return
+		}
+	}
+
+	h.WriteObjectResponse(w, http.StatusOK, BranchResponse(mapRefFromAzure(ref)))
+	h.Log.Printf("Successfully updated branch %s", branch)
+}
+
+// DELETE handler implementation
+// @Summary Delete a branch of a Git repository
+// @Description Delete a branch. When oldObjectId is set, the branch is only deleted if it still points to that commit, otherwise 409 is returned. Without it, the branch is only deleted if it was not updated since it was read.
+// @ID delete-branch
+// @Param organization path string true "Organization name"
+// @Param projectId path string true "Project ID or name"
+// @Param repositoryId path string true "Repository ID or name"
+// @Param branch path string true "Branch name (e.g., main or feature/x)"
+// @Param api-version query string true "API version (e.g., 7.1)"
+// @Param oldObjectId query string false "Commit the branch is expected to point to"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Success 204 "No Content"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 403 "Forbidden"
+// @Failure 404 "Not Found"
+// @Failure 409 "Conflict"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{projectId}/git/repositories/{repositoryId}/branches/{branch} [delete]
+func (h *deleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	projectId := r.PathValue("projectId")
+	repositoryId := r.PathValue("repositoryId")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, projectId, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+	branch, ok := h.branchFromPath(w, r)
+	if !ok {
+		return
+	}
+	oldObjectID := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("oldObjectId")))
+	if oldObjectID != "" && !objectIDRegex.MatchString(oldObjectID) {
+		h.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid oldObjectId: %s", oldObjectID))
+		return
+	}
+
+	h.Log.Printf("Deleting branch %s of repository %s for project %s in organization %s", branch, repositoryId, projectId, organization)
+
+	notFoundMessage := fmt.Sprintf("Repository %s not found in project %s", repositoryId, projectId)
+	ref, err := h.getRef(organization, projectId, repositoryId, branch, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "get branch", notFoundMessage)
+		return
+	}
+	if ref == nil {
+		h.WriteErrorResponse(w, http.StatusNotFound, fmt.Sprintf("Branch %s not found in repository %s", branch, repositoryId))
+		return
+	}
+	if oldObjectID == "" {
+		oldObjectID = ref.ObjectID
+	}
+
+	result, err := h.updateRef(organization, projectId, repositoryId, GitRefUpdate{Name: branch, OldObjectID: oldObjectID, NewObjectID: EmptyObjectID}, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "delete branch", notFoundMessage)
+		return
+	}
+	if !result.Success {
+		message := updateFailureMessage(result)
+		if result.UpdateStatus == "staleOldObjectId" {
+			message = fmt.Sprintf("Conflict: branch %s does not point to %s (it was read at %s)", branch, oldObjectID, ref.ObjectID)
+		}
+		h.WriteErrorResponse(w, updateStatusCode(result.UpdateStatus), message)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	h.Log.Printf("Successfully deleted branch %s", branch)
+}
@@ -0,0 +1,535 @@
+package gitref
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers"
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/handlerstest"
+	"github.com/rs/zerolog"
+)
+
+// newTestBaseHandler creates a base handler for testing with a mock client
+func newTestBaseHandler(mockClient *handlerstest.MockHTTPClient) *baseHandler {
+	return newBaseHandler(handlerstest.NewHandlerOptions(mockClient))
+}
+
+// Test data constants
+const (
+	testOrg        = "testorg"
+	testProject    = "Platform"
+	testRepository = "platform-api"
+	testAPIVersion = "7.1"
+	testUsername   = "test"
+	testPassword   = "test"
+	mainCommit     = "1111111111111111111111111111111111111111"
+	featureCommit  = "2222222222222222222222222222222222222222"
+	tagObject      = "3333333333333333333333333333333333333333"
+)
+
+var (
+	updateURL = fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/git/repositories/%s/refs?api-version=%s", testOrg, testProject, testRepository, testAPIVersion)
+
+	mainRef    = `{"name":"refs/heads/main","objectId":"` + mainCommit + `","creator":{"displayName":"Jane Doe","uniqueName":"jane@example.com"}}`
+	featureRef = `{"name":"refs/heads/feature/x","objectId":"` + featureCommit + `"}`
+	lockedRef  = `{"name":"refs/heads/main","objectId":"` + mainCommit + `","isLocked":true,"isLockedBy":{"displayName":"Jane Doe"}}`
+
+	expectedMain = `{"name":"refs/heads/main","objectId":"` + mainCommit + `","isLocked":false,"creator":{"displayName":"Jane Doe","uniqueName":"jane@example.com"}}`
+)
+
+// refsURL returns the URL used to get the refs of the test repository with the given name prefix (without refs/)
+func refsURL(filter string) string {
+	query := url.Values{"api-version": {testAPIVersion}, "filter": {filter}, "peelTags": {"true"}}
+	return fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/git/repositories/%s/refs?%s", testOrg, testProject, testRepository, query.Encode())
+}
+
+// refList returns a list response of refs
+func refList(refs ...string) string {
+	return fmt.Sprintf(`{"count":%d,"value":[%s]}`, len(refs), strings.Join(refs, ","))
+}
+
+// updateResult returns the response of a ref update with a single result
+func updateResult(name, oldObjectID, newObjectID string, success bool, updateStatus string) string {
+	return fmt.Sprintf(`{"count":1,"value":[{"name":"%s","oldObjectId":"%s","newObjectId":"%s","isLocked":false,"success":%t,"updateStatus":"%s"}]}`, name, oldObjectID, newObjectID, success, updateStatus)
+}
+
+// refTestCase is a test case for the handlers of this package
+type refTestCase struct {
+	name                 string
+	pathValues           map[string]string
+	query                url.Values
+	noAuth               bool
+	body                 string
+	setupMock            func(*handlerstest.MockHTTPClient)
+	expectedStatus       int
+	expectedBody         string
+	expectedBodyContains string
+	expectedRequestCount int
+	verify               func(t *testing.T, mockClient *handlerstest.MockHTTPClient)
+}
+
+// refPathValues returns the path values of the ref endpoints, with the branch if it is set
+func refPathValues(branch string) map[string]string {
+	values := map[string]string{"organization": testOrg, "projectId": testProject, "repositoryId": testRepository}
+	if branch != "" {
+		values["branch"] = branch
+	}
+	return values
+}
+
+// runRefTests runs the given test cases against the handler built by newHandler
+func runRefTests(t *testing.T, method string, newHandler func(*baseHandler) http.Handler, tests []refTestCase) {
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := handlerstest.NewMockHTTPClient()
+			if tt.setupMock != nil {
+				tt.setupMock(mockClient)
+			}
+			handler := newHandler(newTestBaseHandler(mockClient))
+
+			target := "/api/test"
+			if len(tt.query) > 0 {
+				target += "?" + tt.query.Encode()
+			}
+			var body io.Reader
+			if tt.body != "" {
+				body = strings.NewReader(tt.body)
+			}
+			req := httptest.NewRequest(method, target, body)
+			for key, value := range tt.pathValues {
+				req.SetPathValue(key, value)
+			}
+			if !tt.noAuth {
+				req.SetBasicAuth(testUsername, testPassword)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("Status code = %d, want %d. Body: %s", rr.Code, tt.expectedStatus, rr.Body.String())
+			}
+			if tt.expectedBody != "" && rr.Body.String() != tt.expectedBody {
+				t.Errorf("Body = %s, want %s", rr.Body.String(), tt.expectedBody)
+			}
+			if tt.expectedBodyContains != "" && !strings.Contains(rr.Body.String(), tt.expectedBodyContains) {
+				t.Errorf("Body = %s, want it to contain %s", rr.Body.String(), tt.expectedBodyContains)
+			}
+			if mockClient.RequestCount() != tt.expectedRequestCount {
+				t.Errorf("Request count = %d, want %d", mockClient.RequestCount(), tt.expectedRequestCount)
+			}
+			if tt.verify != nil {
+				tt.verify(t, mockClient)
+			}
+		})
+	}
+}
+
+// apiVersionQuery returns the query with the test API version
+func apiVersionQuery() url.Values {
+	return url.Values{"api-version": {testAPIVersion}}
+}
+
+// requestBody returns the body of the n-th request sent to Azure DevOps
+func requestBody(t *testing.T, mockClient *handlerstest.MockHTTPClient, n int) string {
+	t.Helper()
+	if len(mockClient.Requests) <= n || mockClient.Requests[n].Body == nil {
+		t.Fatalf("request %d has no body", n)
+	}
+	body, err := io.ReadAll(mockClient.Requests[n].Body)
+	if err != nil {
+		t.Fatalf("failed to read request %d body: %v", n, err)
+	}
+	return string(body)
+}
+
+// containsRequest reports whether a request was sent with the given method and URL
+func containsRequest(mockClient *handlerstest.MockHTTPClient, method, url string) bool {
+	for _, req := range mockClient.Requests {
+		if req.Method+" "+req.URL.String() == method+" "+url {
+			return true
+		}
+	}
+	return false
+}
+
+// Test constructor functions
+func TestGitRefConstructors(t *testing.T) {
+	client := &http.Client{}
+	logger := zerolog.New(os.Stdout).With().Timestamp().Logger()
+	opts := handlers.HandlerOptions{
+		Client: client,
+		Log:    &logger,
+	}
+
+	constructors := map[string]func(handlers.HandlerOptions) handlers.Handler{
+		"ListRefs":     ListRefs,
+		"GetBranch":    GetBranch,
+		"PostBranch":   PostBranch,
+		"PatchBranch":  PatchBranch,
+		"DeleteBranch": DeleteBranch,
+	}
+
+	for name, constructor := range constructors {
+		t.Run(name, func(t *testing.T) {
+			if h := constructor(opts); h == nil {
+				t.Fatalf("%s should return a non-nil handler", name)
+			}
+		})
+	}
+}
+
+// Test LIST handler
+func TestListHandler_ServeHTTP(t *testing.T) {
+	pageURL := func(query url.Values) string {
+		query.Set("api-version", testAPIVersion)
+		return fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/git/repositories/%s/refs?%s", testOrg, testProject, testRepository, query.Encode())
+	}
+
+	runRefTests(t, "GET", func(b *baseHandler) http.Handler { return &listHandler{baseHandler: b} }, []refTestCase{
+		{
+			name:       "page of branches with continuation token",
+			pathValues: refPathValues(""),
+			query:      url.Values{"api-version": {testAPIVersion}, "filter": {"refs/heads/"}, "$top": {"2"}},
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(pageURL(url.Values{"filter": {"heads/"}, "$top": {"2"}}), http.StatusOK, refList(featureRef, mainRef))
+				m.SetResponseHeader(pageURL(url.Values{"filter": {"heads/"}, "$top": {"2"}}), ContinuationTokenHeader, "page 2")
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBody:         `{"count":2,"value":[{"name":"refs/heads/feature/x","objectId":"` + featureCommit + `","isLocked":false},` + expectedMain + `],"continuationToken":"page 2"}`,
+			expectedRequestCount: 1,
+		},
+		{
+			name:       "next page filtered by substring",
+			pathValues: refPathValues(""),
+			query:      url.Values{"api-version": {testAPIVersion}, "filterContains": {"feature"}, "continuationToken": {"page 2"}},
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(pageURL(url.Values{"filterContains": {"feature"}, "continuationToken": {"page 2"}}), http.StatusOK, refList())
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBody:         `{"count":0,"value":[]}`,
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "invalid top",
+			pathValues:           refPathValues(""),
+			query:                url.Values{"api-version": {testAPIVersion}, "$top": {"-1"}},
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid $top: -1",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "repository not found",
+			pathValues:           refPathValues(""),
+			query:                apiVersionQuery(),
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Repository platform-api not found in project Platform",
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "missing basic auth",
+			pathValues:           refPathValues(""),
+			query:                apiVersionQuery(),
+			noAuth:               true,
+			expectedStatus:       http.StatusUnauthorized,
+			expectedBody:         "Request rejected due to missing or invalid Basic authentication",
+			expectedRequestCount: 0,
+		},
+	})
+}
+
+// Test GET handler
+func TestGetHandler_ServeHTTP(t *testing.T) {
+	runRefTests(t, "GET", func(b *baseHandler) http.Handler { return &getHandler{baseHandler: b} }, []refTestCase{
+		{
+			name:       "branch matched exactly",
+			pathValues: refPathValues("main"),
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(refsURL("heads/main"), http.StatusOK, refList(mainRef, `{"name":"refs/heads/main-old","objectId":"`+featureCommit+`"}`))
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBody:         expectedMain,
+			expectedRequestCount: 1,
+		},
+		{
+			name:       "branch not found",
+			pathValues: refPathValues("refs/heads/ma"),
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(refsURL("heads/ma"), http.StatusOK, refList(mainRef))
+			},
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Branch refs/heads/ma not found in repository platform-api",
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "missing project",
+			pathValues:           map[string]string{"organization": testOrg, "repositoryId": testRepository, "branch": "main"},
+			query:                apiVersionQuery(),
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Project parameter is required",
+			expectedRequestCount: 0,
+		},
+	})
+}
+
+// Test POST handler
+func TestPostHandler_ServeHTTP(t *testing.T) {
+	runRefTests(t, "POST", func(b *baseHandler) http.Handler { return &postHandler{baseHandler: b} }, []refTestCase{
+		{
+			name:       "branch created from a branch",
+			pathValues: refPathValues(""),
+			query:      apiVersionQuery(),
+			body:       `{"name":"feature/y","sourceRef":"main"}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(refsURL("heads/main"), http.StatusOK, refList(mainRef))
+				m.SetResponse(refsURL("heads/feature/y"), http.StatusOK, refList())
+				m.SetMethodResponse("POST", updateURL, http.StatusOK, updateResult("refs/heads/feature/y", EmptyObjectID, mainCommit, true, "succeeded"))
+			},
+			expectedStatus:       http.StatusCreated,
+			expectedBody:         `{"name":"refs/heads/feature/y","objectId":"` + mainCommit + `","isLocked":false}`,
+			expectedRequestCount: 3,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				want := `[{"name":"refs/heads/feature/y","oldObjectId":"` + EmptyObjectID + `","newObjectId":"` + mainCommit + `"}]`
+				if got := requestBody(t, m, 2); got != want {
+					t.Errorf("Request body = %s, want %s", got, want)
+				}
+			},
+		},
+		{
+			name:       "branch created from an annotated tag",
+			pathValues: refPathValues(""),
+			query:      apiVersionQuery(),
+			body:       `{"name":"release/1.0","sourceRef":"refs/tags/v1.0"}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(refsURL("tags/v1.0"), http.StatusOK, refList(`{"name":"refs/tags/v1.0","objectId":"`+tagObject+`","peeledObjectId":"`+featureCommit+`"}`))
+				m.SetResponse(refsURL("heads/release/1.0"), http.StatusOK, refList())
+				m.SetMethodResponse("POST", updateURL, http.StatusOK, updateResult("refs/heads/release/1.0", EmptyObjectID, featureCommit, true, "succeeded"))
+			},
+			expectedStatus:       http.StatusCreated,
+			expectedBodyContains: `"objectId":"` + featureCommit + `"`,
+			expectedRequestCount: 3,
+		},
+		{
+			name:       "branch created from a commit",
+			pathValues: refPathValues(""),
+			query:      apiVersionQuery(),
+			body:       `{"name":"hotfix","sourceCommitId":"` + strings.ToUpper(featureCommit) + `"}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/git/repositories/%s/commits/%s?api-version=%s", testOrg, testProject, testRepository, featureCommit, testAPIVersion), http.StatusOK, `{"commitId":"`+featureCommit+`"}`)
+				m.SetResponse(refsURL("heads/hotfix"), http.StatusOK, refList())
+				m.SetMethodResponse("POST", updateURL, http.StatusOK, updateResult("refs/heads/hotfix", EmptyObjectID, featureCommit, true, "succeeded"))
+			},
+			expectedStatus:       http.StatusCreated,
+			expectedBody:         `{"name":"refs/heads/hotfix","objectId":"` + featureCommit + `","isLocked":false}`,
+			expectedRequestCount: 3,
+		},
+		{
+			name:       "existing branch at the same commit",
+			pathValues: refPathValues(""),
+			query:      apiVersionQuery(),
+			body:       `{"name":"refs/heads/feature/x","sourceCommitId":"` + featureCommit + `"}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/git/repositories/%s/commits/%s?api-version=%s", testOrg, testProject, testRepository, featureCommit, testAPIVersion), http.StatusOK, `{"commitId":"`+featureCommit+`"}`)
+				m.SetResponse(refsURL("heads/feature/x"), http.StatusOK, refList(featureRef))
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBody:         `{"name":"refs/heads/feature/x","objectId":"` + featureCommit + `","isLocked":false}`,
+			expectedRequestCount: 2,
+		},
+		{
+			name:       "existing branch at another commit",
+			pathValues: refPathValues(""),
+			query:      apiVersionQuery(),
+			body:       `{"name":"feature/x","sourceRef":"main"}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(refsURL("heads/main"), http.StatusOK, refList(mainRef))
+				m.SetResponse(refsURL("heads/feature/x"), http.StatusOK, refList(featureRef))
+			},
+			expectedStatus:       http.StatusConflict,
+			expectedBody:         "Conflict: branch refs/heads/feature/x already exists at " + featureCommit + ", not at " + mainCommit,
+			expectedRequestCount: 2,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				if containsRequest(m, "POST", updateURL) {
+					t.Errorf("Existing branch should not be updated")
+				}
+			},
+		},
+		{
+			name:       "branch created concurrently",
+			pathValues: refPathValues(""),
+			query:      apiVersionQuery(),
+			body:       `{"name":"feature/y","sourceRef":"main"}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(refsURL("heads/main"), http.StatusOK, refList(mainRef))
+				m.SetResponse(refsURL("heads/feature/y"), http.StatusOK, refList())
+				m.SetMethodResponse("POST", updateURL, http.StatusOK, updateResult("refs/heads/feature/y", EmptyObjectID, mainCommit, false, "staleOldObjectId"))
+			},
+			expectedStatus:       http.StatusConflict,
+			expectedBody:         "Failed to update ref refs/heads/feature/y: staleOldObjectId",
+			expectedRequestCount: 3,
+		},
+		{
+			name:       "source not found",
+			pathValues: refPathValues(""),
+			query:      apiVersionQuery(),
+			body:       `{"name":"feature/y","sourceRef":"develop"}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(refsURL("heads/develop"), http.StatusOK, refList())
+			},
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid branch: source refs/heads/develop not found in repository platform-api",
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "source ref and commit both set",
+			pathValues:           refPathValues(""),
+			query:                apiVersionQuery(),
+			body:                 `{"name":"feature/y","sourceRef":"main","sourceCommitId":"` + mainCommit + `"}`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid branch: sourceRef and sourceCommitId cannot be both set",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "invalid JSON",
+			pathValues:           refPathValues(""),
+			query:                apiVersionQuery(),
+			body:                 `{"name":`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid JSON in request body",
+			expectedRequestCount: 0,
+		},
+	})
+}
+
+// Test PATCH handler
+func TestPatchHandler_ServeHTTP(t *testing.T) {
+	lockURL := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/git/repositories/%s/refs?api-version=%s&filter=%s", testOrg, testProject, testRepository, testAPIVersion, url.QueryEscape("heads/main"))
+
+	runRefTests(t, "PATCH", func(b *baseHandler) http.Handler { return &patchHandler{baseHandler: b} }, []refTestCase{
+		{
+			name:       "branch locked",
+			pathValues: refPathValues("main"),
+			query:      apiVersionQuery(),
+			body:       `{"isLocked":true}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(refsURL("heads/main"), http.StatusOK, refList(mainRef))
+				m.SetMethodResponse("PATCH", lockURL, http.StatusOK, lockedRef)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBody:         `{"name":"refs/heads/main","objectId":"` + mainCommit + `","isLocked":true,"isLockedBy":{"displayName":"Jane Doe"}}`,
+			expectedRequestCount: 2,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				if got := requestBody(t, m, 1); got != `{"isLocked":true}` {
+					t.Errorf("Request body = %s", got)
+				}
+			},
+		},
+		{
+			name:       "branch already unlocked",
+			pathValues: refPathValues("main"),
+			query:      apiVersionQuery(),
+			body:       `{"isLocked":false}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(refsURL("heads/main"), http.StatusOK, refList(mainRef))
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBody:         expectedMain,
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "missing isLocked",
+			pathValues:           refPathValues("main"),
+			query:                apiVersionQuery(),
+			body:                 `{}`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid branch: isLocked is required",
+			expectedRequestCount: 0,
+		},
+		{
+			name:       "branch not found",
+			pathValues: refPathValues("main"),
+			query:      apiVersionQuery(),
+			body:       `{"isLocked":true}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(refsURL("heads/main"), http.StatusOK, refList())
+			},
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Branch refs/heads/main not found in repository platform-api",
+			expectedRequestCount: 1,
+		},
+	})
+}
+
+// Test DELETE handler
+func TestDeleteHandler_ServeHTTP(t *testing.T) {
+	runRefTests(t, "DELETE", func(b *baseHandler) http.Handler { return &deleteHandler{baseHandler: b} }, []refTestCase{
+		{
+			name:       "branch deleted at the commit read",
+			pathValues: refPathValues("feature/x"),
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(refsURL("heads/feature/x"), http.StatusOK, refList(featureRef))
+				m.SetMethodResponse("POST", updateURL, http.StatusOK, updateResult("refs/heads/feature/x", featureCommit, EmptyObjectID, true, "succeeded"))
+			},
+			expectedStatus:       http.StatusNoContent,
+			expectedRequestCount: 2,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				want := `[{"name":"refs/heads/feature/x","oldObjectId":"` + featureCommit + `","newObjectId":"` + EmptyObjectID + `"}]`
+				if got := requestBody(t, m, 1); got != want {
+					t.Errorf("Request body = %s, want %s", got, want)
+				}
+			},
+		},
+		{
+			name:       "branch moved since the expected commit",
+			pathValues: refPathValues("feature/x"),
+			query:      url.Values{"api-version": {testAPIVersion}, "oldObjectId": {mainCommit}},
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(refsURL("heads/feature/x"), http.StatusOK, refList(featureRef))
+				m.SetMethodResponse("POST", updateURL, http.StatusOK, updateResult("refs/heads/feature/x", mainCommit, EmptyObjectID, false, "staleOldObjectId"))
+			},
+			expectedStatus:       http.StatusConflict,
+			expectedBody:         "Conflict: branch refs/heads/feature/x does not point to " + mainCommit + " (it was read at " + featureCommit + ")",
+			expectedRequestCount: 2,
+		},
+		{
+			name:       "locked branch",
+			pathValues: refPathValues("main"),
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(refsURL("heads/main"), http.StatusOK, refList(lockedRef))
+				m.SetMethodResponse("POST", updateURL, http.StatusOK, updateResult("refs/heads/main", mainCommit, EmptyObjectID, false, "locked"))
+			},
+			expectedStatus:       http.StatusConflict,
+			expectedBody:         "Failed to update ref refs/heads/main: locked",
+			expectedRequestCount: 2,
+		},
+		{
+			name:       "branch not found",
+			pathValues: refPathValues("feature/x"),
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(refsURL("heads/feature/x"), http.StatusOK, refList())
+			},
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Branch refs/heads/feature/x not found in repository platform-api",
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "invalid old object ID",
+			pathValues:           refPathValues("feature/x"),
+			query:                url.Values{"api-version": {testAPIVersion}, "oldObjectId": {"abc"}},
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid oldObjectId: abc",
+			expectedRequestCount: 0,
+		},
+	})
+}
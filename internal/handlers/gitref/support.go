@@ -0,0 +1,98 @@
+package gitref
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+var objectIDRegex = regexp.MustCompile(`^[0-9a-fA-F]{40}$`)
+
+// normalizeBranchName returns the full ref name of the branch, e.g., main becomes refs/heads/main
+func normalizeBranchName(branch string) string {
+	branch = strings.Trim(strings.TrimSpace(branch), "/")
+	if branch == "" {
+		return ""
+	}
+	return "refs/heads/" + strings.TrimPrefix(branch, "refs/heads/")
+}
+
+// normalizeSourceRef returns the full name of the source ref: branch names are completed, full ref names (e.g., refs/tags/v1.0) are kept
+func normalizeSourceRef(ref string) string {
+	ref = strings.TrimSpace(ref)
+	if strings.HasPrefix(ref, "refs/") {
+		return ref
+	}
+	return normalizeBranchName(ref)
+}
+
+// refFilter returns the filter of the refs API for the ref name, which is given without the refs/ prefix (e.g., heads/main)
+func refFilter(refName string) string {
+	return strings.TrimPrefix(refName, "refs/")
+}
+
+// validateCreateBranchRequest checks and normalizes the fields of a branch creation request
+func validateCreateBranchRequest(request *CreateBranchRequest) error {
+	request.Name = normalizeBranchName(request.Name)
+	if request.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	request.SourceRef = normalizeSourceRef(request.SourceRef)
+	request.SourceCommitID = strings.ToLower(strings.TrimSpace(request.SourceCommitID))
+	switch {
+	case request.SourceRef == "" && request.SourceCommitID == "":
+		return fmt.Errorf("one of sourceRef and sourceCommitId is required")
+	case request.SourceRef != "" && request.SourceCommitID != "":
+		return fmt.Errorf("sourceRef and sourceCommitId cannot be both set")
+	case request.SourceCommitID != "" && !objectIDRegex.MatchString(request.SourceCommitID):
+		return fmt.Errorf("sourceCommitId must be a full commit SHA, got '%s'", request.SourceCommitID)
+	}
+	return nil
+}
+
+// findRef returns the ref with exactly the given name, nil if there is none
+// The refs API filters by prefix, so that refs/heads/main also returns refs/heads/main-old
+func findRef(refs []GitRef, name string) *GitRef {
+	for i := range refs {
+		if refs[i].Name == name {
+			return &refs[i]
+		}
+	}
+	return nil
+}
+
+// updateStatusCode returns the status code of the response for a ref update that did not succeed
+func updateStatusCode(updateStatus string) int {
+	switch {
+	case updateStatus == "staleOldObjectId" || updateStatus == "refNameConflict" || updateStatus == "locked":
+		return http.StatusConflict
+	case updateStatus == "invalidRefName" || updateStatus == "unresolvableToCommit":
+		return http.StatusBadRequest
+	case strings.HasSuffix(updateStatus, "PermissionRequired") || strings.HasPrefix(updateStatus, "rejectedBy") || updateStatus == "forcePushRequired":
+		return http.StatusForbidden
+	}
+	return http.StatusInternalServerError
+}
+
+// updateFailureMessage returns the message of the response for a ref update that did not succeed
+func updateFailureMessage(result *GitRefUpdateResult) string {
+	message := fmt.Sprintf("Failed to update ref %s: %s", result.Name, result.UpdateStatus)
+	if result.CustomMessage != "" {
+		message += " (" + result.CustomMessage + ")"
+	}
+	return message
+}
+
+// mapRefFromAzure converts an Azure DevOps ref to the ref returned by the plugin
+func mapRefFromAzure(ref *GitRef) Ref {
+	return Ref{
+		Name:           ref.Name,
+		ObjectID:       ref.ObjectID,
+		PeeledObjectID: ref.PeeledObjectID,
+		IsLocked:       ref.IsLocked,
+		IsLockedBy:     ref.IsLockedBy,
+		Creator:        ref.Creator,
+	}
+}
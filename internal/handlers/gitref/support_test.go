@@ -0,0 +1,67 @@
+package gitref
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestNormalizeBranchName(t *testing.T) {
+	tests := map[string]string{
+		"main":                 "refs/heads/main",
+		" refs/heads/main ":    "refs/heads/main",
+		"feature/x/":           "refs/heads/feature/x",
+		"refs/heads/feature/x": "refs/heads/feature/x",
+		"":                     "",
+	}
+	for branch, expected := range tests {
+		if got := normalizeBranchName(branch); got != expected {
+			t.Errorf("normalizeBranchName(%q) = %q, want %q", branch, got, expected)
+		}
+	}
+
+	if got := normalizeSourceRef("refs/tags/v1.0"); got != "refs/tags/v1.0" {
+		t.Errorf("normalizeSourceRef() = %q, want refs/tags/v1.0", got)
+	}
+}
+
+func TestValidateCreateBranchRequest(t *testing.T) {
+	tests := []struct {
+		name          string
+		request       CreateBranchRequest
+		expectedError string
+	}{
+		{name: "source ref", request: CreateBranchRequest{Name: "feature/x", SourceRef: "main"}},
+		{name: "source commit", request: CreateBranchRequest{Name: "feature/x", SourceCommitID: mainCommit}},
+		{name: "missing name", request: CreateBranchRequest{SourceRef: "main"}, expectedError: "name is required"},
+		{name: "missing source", request: CreateBranchRequest{Name: "feature/x"}, expectedError: "one of sourceRef and sourceCommitId is required"},
+		{name: "short commit", request: CreateBranchRequest{Name: "feature/x", SourceCommitID: "abc123"}, expectedError: "sourceCommitId must be a full commit SHA, got 'abc123'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCreateBranchRequest(&tt.request)
+			if tt.expectedError == "" && err != nil {
+				t.Fatalf("validateCreateBranchRequest() error = %v", err)
+			}
+			if tt.expectedError != "" && (err == nil || err.Error() != tt.expectedError) {
+				t.Fatalf("validateCreateBranchRequest() error = %v, want %s", err, tt.expectedError)
+			}
+		})
+	}
+}
+
+func TestUpdateStatusCode(t *testing.T) {
+	tests := map[string]int{
+		"staleOldObjectId":               http.StatusConflict,
+		"locked":                         http.StatusConflict,
+		"invalidRefName":                 http.StatusBadRequest,
+		"createBranchPermissionRequired": http.StatusForbidden,
+		"rejectedByPolicy":               http.StatusForbidden,
+		"unprocessed":                    http.StatusInternalServerError,
+	}
+	for updateStatus, expected := range tests {
+		if got := updateStatusCode(updateStatus); got != expected {
+			t.Errorf("updateStatusCode(%q) = %d, want %d", updateStatus, got, expected)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package gitref
+
+// Ref represents the Git ref returned by the plugin
+type Ref struct {
+	Name           string       `json:"name"` // e.g., refs/heads/main
+	ObjectID       string       `json:"objectId"`
+	PeeledObjectID string       `json:"peeledObjectId,omitempty"` // Commit of an annotated tag
+	IsLocked       bool         `json:"isLocked"`
+	IsLockedBy     *IdentityRef `json:"isLockedBy,omitempty"`
+	Creator        *IdentityRef `json:"creator,omitempty"`
+}
+
+// RefList represents a page of refs returned by the plugin
+type RefList struct {
+	Count             int    `json:"count"`
+	Value             []Ref  `json:"value"`
+	ContinuationToken string `json:"continuationToken,omitempty"` // Set when there are more refs, to get the next page
+}
+
+// BranchResponse represents the response of the branch endpoints
+type BranchResponse Ref
+
+// RefListResponse represents the response of the list refs endpoint
+type RefListResponse RefList
+
+// CreateBranchRequest represents the request body for creating a branch
+// Exactly one of SourceRef and SourceCommitID must be set
+type CreateBranchRequest struct {
+	Name           string `json:"name"`                     // Required: e.g., feature/x or refs/heads/feature/x
+	SourceRef      string `json:"sourceRef,omitempty"`      // e.g., main, refs/heads/main or refs/tags/v1.0
+	SourceCommitID string `json:"sourceCommitId,omitempty"` // Full SHA-1 of the commit
+}
+
+// UpdateBranchRequest represents the request body for locking or unlocking a branch
+type UpdateBranchRequest struct {
+	IsLocked *bool `json:"isLocked"` // Required
+}
+
+// ---
+
+// IdentityRef represents a reference to an identity of Azure DevOps
+type IdentityRef struct {
+	ID          string `json:"id,omitempty"`
+	DisplayName string `json:"displayName,omitempty"`
+	UniqueName  string `json:"uniqueName,omitempty"`
+}
+
+// GitRef represents a Git ref of Azure DevOps
+type GitRef struct {
+	Name           string       `json:"name"`
+	ObjectID       string       `json:"objectId"`
+	PeeledObjectID string       `json:"peeledObjectId,omitempty"`
+	IsLocked       bool         `json:"isLocked,omitempty"`
+	IsLockedBy     *IdentityRef `json:"isLockedBy,omitempty"`
+	Creator        *IdentityRef `json:"creator,omitempty"`
+	URL            string       `json:"url,omitempty"`
+}
+
+// GitRefList represents a page of the response from:
+// GET https://dev.azure.com/{organization}/{project}/_apis/git/repositories/{repositoryId}/refs?filter={filter}&$top={top}&continuationToken={continuationToken}
+// The continuation token of the next page is returned in the x-ms-continuationtoken header
+type GitRefList struct {
+	Count int      `json:"count"`
+	Value []GitRef `json:"value"`
+}
+
+// GitRefUpdate represents an update of a ref sent to:
+// POST https://dev.azure.com/{organization}/{project}/_apis/git/repositories/{repositoryId}/refs
+// The ref is only updated if it still points to OldObjectID (EmptyObjectID to create it)
+type GitRefUpdate struct {
+	Name        string `json:"name"`
+	OldObjectID string `json:"oldObjectId"`
+	NewObjectID string `json:"newObjectId"`
+}
+
+// GitRefLockUpdate represents the request body for locking or unlocking a branch, sent to:
+// PATCH https://dev.azure.com/{organization}/{project}/_apis/git/repositories/{repositoryId}/refs?filter={filter}
+type GitRefLockUpdate struct {
+	IsLocked bool `json:"isLocked"`
+}
+
+// GitRefUpdateResult represents the result of a ref update
+type GitRefUpdateResult struct {
+	Name          string `json:"name"`
+	OldObjectID   string `json:"oldObjectId"`
+	NewObjectID   string `json:"newObjectId"`
+	IsLocked      bool   `json:"isLocked"`
+	Success       bool   `json:"success"`
+	UpdateStatus  string `json:"updateStatus"` // e.g., succeeded, staleOldObjectId, locked
+	CustomMessage string `json:"customMessage,omitempty"`
+	RejectedBy    string `json:"rejectedBy,omitempty"`
+}
+
+// GitRefUpdateResultList represents the response of a ref update
+type GitRefUpdateResultList struct {
+	Count int                  `json:"count"`
+	Value []GitRefUpdateResult `json:"value"`
+}
+
+// GitCommitRef represents a commit of Azure DevOps, from:
+// GET https://dev.azure.com/{organization}/{project}/_apis/git/repositories/{repositoryId}/commits/{commitId}
+type GitCommitRef struct {
+	CommitID string `json:"commitId"`
+}
+
+// EmptyObjectID is the object ID of a ref that does not exist, used as the old object ID to create a ref
+// and as the new object ID to delete it
+const EmptyObjectID = "0000000000000000000000000000000000000000"
+
+// ContinuationTokenHeader is the header with the continuation token of the next page of refs
+const ContinuationTokenHeader = "x-ms-continuationtoken"
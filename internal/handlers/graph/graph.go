@@ -0,0 +1,504 @@
+package graph
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers"
+)
+
+// Handler constructors
+func ListGroups(opts handlers.HandlerOptions) handlers.Handler {
+	return &listHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func GetGroup(opts handlers.HandlerOptions) handlers.Handler {
+	return &getHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func PostGroup(opts handlers.HandlerOptions) handlers.Handler {
+	return &postHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func PutGroup(opts handlers.HandlerOptions) handlers.Handler {
+	return &putHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func DeleteGroup(opts handlers.HandlerOptions) handlers.Handler {
+	return &deleteHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func ResolveSubjects(opts handlers.HandlerOptions) handlers.Handler {
+	return &resolveHandler{baseHandler: newBaseHandler(opts)}
+}
+
+// Interface compliance verification
+var _ handlers.Handler = &listHandler{}
+var _ handlers.Handler = &getHandler{}
+var _ handlers.Handler = &postHandler{}
+var _ handlers.Handler = &putHandler{}
+var _ handlers.Handler = &deleteHandler{}
+var _ handlers.Handler = &resolveHandler{}
+
+// Base handler with common functionality
+type baseHandler struct {
+	*handlers.BaseHandler
+}
+
+// Constructor for the base handler
+func newBaseHandler(opts handlers.HandlerOptions) *baseHandler {
+	return &baseHandler{BaseHandler: handlers.NewBaseHandler(opts)}
+}
+
+// Handler types embedding the base handler
+type listHandler struct {
+	*baseHandler
+}
+
+type getHandler struct {
+	*baseHandler
+}
+
+type postHandler struct {
+	*baseHandler
+}
+
+type putHandler struct {
+	*baseHandler
+}
+
+type deleteHandler struct {
+	*baseHandler
+}
+
+type resolveHandler struct {
+	*baseHandler
+}
+
+// validateOrganizationParams validates the parameters of the organization level endpoints
+func (h *baseHandler) validateOrganizationParams(w http.ResponseWriter, organization, apiVersion string) bool {
+	if organization == "" {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Organization parameter is required")
+		return false
+	}
+	if apiVersion == "" {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "API version is required")
+		return false
+	}
+	return true
+}
+
+// getGroup returns the group with the given descriptor
+func (h *baseHandler) getGroup(organization, descriptor, apiVersion, authHeader string) (*GraphGroup, error) {
+	url := fmt.Sprintf("https://vssps.dev.azure.com/%s/_apis/graph/groups/%s?api-version=%s", organization, descriptor, apiVersion)
+
+	body, err := h.DoAzureDevOpsRequest("GET", url, authHeader, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var group GraphGroup
+	if err := json.Unmarshal(body, &group); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal group: %w", err)
+	}
+	return &group, nil
+}
+
+// updateDescription replaces the description of the group, it is the only property of a group that can be changed
+func (h *baseHandler) updateDescription(organization string, group *GraphGroup, description, apiVersion, authHeader string) (*GraphGroup, error) {
+	requestBody, err := json.Marshal([]JSONPatchOperation{{Op: "replace", Path: "/description", Value: description}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal group patch: %w", err)
+	}
+
+	url := fmt.Sprintf("https://vssps.dev.azure.com/%s/_apis/graph/groups/%s?api-version=%s", organization, group.Descriptor, apiVersion)
+	body, _, err := h.DoAzureDevOpsRequestWithHeader("PATCH", url, authHeader, requestBody, http.Header{"Content-Type": {"application/json-patch+json"}})
+	if err != nil {
+		return nil, err
+	}
+
+	var updated GraphGroup
+	if err := json.Unmarshal(body, &updated); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal group: %w", err)
+	}
+	return &updated, nil
+}
+
+// writeListGroupsError writes the response for an error returned while listing groups
+// A repeated continuation token is returned as 502 since the list returned by Azure DevOps cannot be trusted
+func (h *baseHandler) writeListGroupsError(w http.ResponseWriter, err error, organization string) {
+	if errors.Is(err, ErrRepeatedContinuationToken) {
+		h.WriteErrorResponse(w, http.StatusBadGateway, fmt.Sprintf("Failed to list groups: %v", err))
+		return
+	}
+	h.WriteAzureDevOpsError(w, err, "list groups", fmt.Sprintf("Organization %s not found", organization))
+}
+
+// buildGroup reconciles the members of the group when desired is not nil, then returns the group with its members
+func (h *baseHandler) buildGroup(organization string, group *GraphGroup, desired []string, apiVersion, authHeader string) (*Group, error) {
+	if desired != nil {
+		if err := h.memberships().ReconcileMembers(organization, group.Descriptor, desired, apiVersion, authHeader); err != nil {
+			return nil, err
+		}
+	}
+
+	members, err := h.getGroupMembers(organization, group.Descriptor, apiVersion, authHeader)
+	if err != nil {
+		return nil, err
+	}
+	return &Group{GroupSummary: *mapGroupFromAzure(group), Members: members}, nil
+}
+
+// readGroupRequest reads and validates the request body, and resolves the requested members to descriptors
+// The returned descriptors are nil when the members are not managed by the request
+func (h *baseHandler) readGroupRequest(w http.ResponseWriter, r *http.Request, organization string, create bool, apiVersion, authHeader string) (*GroupRequest, []string, bool) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Failed to read request body")
+		return nil, nil, false
+	}
+
+	var groupRequest GroupRequest
+	if err := json.Unmarshal(body, &groupRequest); err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Invalid JSON in request body")
+		return nil, nil, false
+	}
+	if err := validateGroupRequest(&groupRequest, create); err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid group: %v", err))
+		return nil, nil, false
+	}
+	if groupRequest.Members == nil {
+		return &groupRequest, nil, true
+	}
+
+	// Members are resolved before changing anything so that an unknown principal name leaves the group untouched
+	desired, err := h.resolveMembers(organization, groupRequest.Members, apiVersion, authHeader)
+	if err != nil {
+		var notFound *errSubjectNotFound
+		if errors.As(err, &notFound) {
+			h.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid group: %v", err))
+			return nil, nil, false
+		}
+		h.WriteAzureDevOpsError(w, err, "resolve group members", fmt.Sprintf("Organization %s not found", organization))
+		return nil, nil, false
+	}
+	return &groupRequest, desired, true
+}
+
+// LIST handler implementation
+// @Summary List groups
+// @Description List the groups of the organization, or of a project when projectId is set, sorted by principal name. All the pages returned by Azure DevOps are followed.
+// @ID list-groups
+// @Param organization path string true "Organization name"
+// @Param projectId query string false "Project ID, the groups of the organization are listed if not set"
+// @Param api-version query string true "API version (e.g., 7.1-preview.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Produce json
+// @Success 200 {object} GroupListResponse "List of groups"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Failure 502 "Bad Gateway"
+// @Router /api/{organization}/graph/groups [get]
+func (h *listHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	projectID := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("projectId")))
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.validateOrganizationParams(w, organization, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	h.Log.Printf("Listing groups for project '%s' in organization %s", projectID, organization)
+
+	scopeDescriptor := ""
+	if projectID != "" {
+		descriptor, err := h.getScopeDescriptor(organization, projectID, apiVersion, authHeader)
+		if err != nil {
+			h.WriteAzureDevOpsError(w, err, "get project descriptor", fmt.Sprintf("Project %s not found", projectID))
+			return
+		}
+		scopeDescriptor = descriptor
+	}
+
+	graphGroups, err := h.listGroups(organization, scopeDescriptor, apiVersion, authHeader)
+	if err != nil {
+		h.writeListGroupsError(w, err, organization)
+		return
+	}
+
+	// Without a scope Azure DevOps returns the groups of all the projects too
+	groups := []GroupSummary{}
+	for i := range graphGroups {
+		if isInScope(&graphGroups[i], projectID) {
+			groups = append(groups, *mapGroupFromAzure(&graphGroups[i]))
+		}
+	}
+	sortGroups(groups)
+
+	h.WriteObjectResponse(w, http.StatusOK, GroupListResponse{Count: len(groups), Groups: groups})
+	h.Log.Printf("Successfully listed %d groups", len(groups))
+}
+
+// GET handler implementation
+// @Summary Get a group
+// @Description Get a group with its direct members sorted by descriptor
+// @ID get-group
+// @Param organization path string true "Organization name"
+// @Param descriptor path string true "Group descriptor"
+// @Param api-version query string true "API version (e.g., 7.1-preview.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Produce json
+// @Success 200 {object} GroupResponse "Group details"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/graph/groups/{descriptor} [get]
+func (h *getHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	descriptor := r.PathValue("descriptor")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.validateOrganizationParams(w, organization, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	h.Log.Printf("Getting group %s in organization %s", descriptor, organization)
+
+	group, err := h.getGroup(organization, descriptor, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "get group", fmt.Sprintf("Group %s not found", descriptor))
+		return
+	}
+
+	result, err := h.buildGroup(organization, group, nil, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "get group members", fmt.Sprintf("Group %s not found", descriptor))
+		return
+	}
+
+	h.WriteObjectResponse(w, http.StatusOK, GroupResponse(*result))
+	h.Log.Printf("Successfully retrieved group %s", descriptor)
+}
+
+// POST handler implementation
+// @Summary Create a group
+// @Description Create a group in the project, or in the organization when projectId is not set. If a group with the same display name already exists in the same scope, its description and members are updated instead and 200 is returned. If members are set, the members of the group are reconciled with them.
+// @ID post-group
+// @Param organization path string true "Organization name"
+// @Param api-version query string true "API version (e.g., 7.1-preview.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Param group body GroupRequest true "Group creation request body"
+// @Accept json
+// @Produce json
+// @Success 200 {object} GroupResponse "Existing group"
+// @Success 201 {object} GroupResponse "Created group"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Failure 502 "Bad Gateway"
+// @Router /api/{organization}/graph/groups [post]
+func (h *postHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.validateOrganizationParams(w, organization, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	groupRequest, desired, ok := h.readGroupRequest(w, r, organization, true, apiVersion, authHeader)
+	if !ok {
+		return
+	}
+
+	h.Log.Printf("Creating group %s for project '%s' in organization %s", groupRequest.DisplayName, groupRequest.ProjectID, organization)
+
+	scopeDescriptor := ""
+	if groupRequest.ProjectID != "" {
+		descriptor, err := h.getScopeDescriptor(organization, groupRequest.ProjectID, apiVersion, authHeader)
+		if err != nil {
+			h.WriteAzureDevOpsError(w, err, "get project descriptor", fmt.Sprintf("Project %s not found", groupRequest.ProjectID))
+			return
+		}
+		scopeDescriptor = descriptor
+	}
+
+	groups, err := h.listGroups(organization, scopeDescriptor, apiVersion, authHeader)
+	if err != nil {
+		h.writeListGroupsError(w, err, organization)
+		return
+	}
+
+	statusCode := http.StatusOK
+	group := findGroupByName(groups, groupRequest.DisplayName, groupRequest.ProjectID)
+	if group != nil {
+		h.Log.Printf("Group %s already exists with descriptor %s", groupRequest.DisplayName, group.Descriptor)
+		if group.Description != groupRequest.Description {
+			group, err = h.updateDescription(organization, group, groupRequest.Description, apiVersion, authHeader)
+			if err != nil {
+				h.WriteAzureDevOpsError(w, err, "update group", fmt.Sprintf("Group %s not found", groupRequest.DisplayName))
+				return
+			}
+		}
+	} else {
+		requestBody, err := json.Marshal(GraphGroupCreationContext{DisplayName: groupRequest.DisplayName, Description: groupRequest.Description})
+		if err != nil {
+			h.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Failed to marshal group: %v", err))
+			return
+		}
+
+		url := fmt.Sprintf("https://vssps.dev.azure.com/%s/_apis/graph/groups?api-version=%s", organization, apiVersion)
+		if scopeDescriptor != "" {
+			url = fmt.Sprintf("https://vssps.dev.azure.com/%s/_apis/graph/groups?scopeDescriptor=%s&api-version=%s", organization, scopeDescriptor, apiVersion)
+		}
+		responseBody, err := h.DoAzureDevOpsRequest("POST", url, authHeader, requestBody)
+		if err != nil {
+			h.WriteAzureDevOpsError(w, err, "create group", fmt.Sprintf("Organization %s not found", organization))
+			return
+		}
+
+		group = &GraphGroup{}
+		if err := json.Unmarshal(responseBody, group); err != nil {
+			h.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Failed to unmarshal group: %v", err))
+			return
+		}
+		statusCode = http.StatusCreated
+	}
+
+	result, err := h.buildGroup(organization, group, desired, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "update group members", fmt.Sprintf("Group %s not found", group.Descriptor))
+		return
+	}
+
+	h.WriteObjectResponse(w, statusCode, GroupResponse(*result))
+	h.Log.Printf("Successfully created group %s with descriptor %s", group.DisplayName, group.Descriptor)
+}
+
+// PUT handler implementation
+// @Summary Update a group
+// @Description Update the description of a group. If members are set, the members of the group are reconciled with them: missing members are added and the others are removed. The display name and the project of a group cannot be changed.
+// @ID put-group
+// @Param organization path string true "Organization name"
+// @Param descriptor path string true "Group descriptor"
+// @Param api-version query string true "API version (e.g., 7.1-preview.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Param group body GroupRequest true "Group update request body"
+// @Accept json
+// @Produce json
+// @Success 200 {object} GroupResponse "Updated group"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/graph/groups/{descriptor} [put]
+func (h *putHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	descriptor := r.PathValue("descriptor")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.validateOrganizationParams(w, organization, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	groupRequest, desired, ok := h.readGroupRequest(w, r, organization, false, apiVersion, authHeader)
+	if !ok {
+		return
+	}
+
+	h.Log.Printf("Updating group %s in organization %s", descriptor, organization)
+
+	group, err := h.getGroup(organization, descriptor, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "get group", fmt.Sprintf("Group %s not found", descriptor))
+		return
+	}
+
+	if groupRequest.DisplayName != "" && !strings.EqualFold(groupRequest.DisplayName, group.DisplayName) {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Invalid group: displayName cannot be changed")
+		return
+	}
+	if groupRequest.ProjectID != "" && !isInScope(group, groupRequest.ProjectID) {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Invalid group: projectId cannot be changed")
+		return
+	}
+
+	if group.Description != groupRequest.Description {
+		group, err = h.updateDescription(organization, group, groupRequest.Description, apiVersion, authHeader)
+		if err != nil {
+			h.WriteAzureDevOpsError(w, err, "update group", fmt.Sprintf("Group %s not found", descriptor))
+			return
+		}
+	}
+
+	result, err := h.buildGroup(organization, group, desired, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "update group members", fmt.Sprintf("Group %s not found", descriptor))
+		return
+	}
+
+	h.WriteObjectResponse(w, http.StatusOK, GroupResponse(*result))
+	h.Log.Printf("Successfully updated group %s", descriptor)
+}
+
+// DELETE handler implementation
+// @Summary Delete a group
+// @Description Delete a group. Its memberships are removed by Azure DevOps.
+// @ID delete-group
+// @Param organization path string true "Organization name"
+// @Param descriptor path string true "Group descriptor"
+// @Param api-version query string true "API version (e.g., 7.1-preview.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Success 204 "No Content"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/graph/groups/{descriptor} [delete]
+func (h *deleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	descriptor := r.PathValue("descriptor")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.validateOrganizationParams(w, organization, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	h.Log.Printf("Deleting group %s in organization %s", descriptor, organization)
+
+	url := fmt.Sprintf("https://vssps.dev.azure.com/%s/_apis/graph/groups/%s?api-version=%s", organization, descriptor, apiVersion)
+	if _, err := h.DoAzureDevOpsRequest("DELETE", url, authHeader, nil); err != nil {
+		h.WriteAzureDevOpsError(w, err, "delete group", fmt.Sprintf("Group %s not found", descriptor))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	h.Log.Printf("Successfully deleted group %s", descriptor)
+}
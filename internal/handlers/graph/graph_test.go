@@ -0,0 +1,561 @@
+package graph
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers"
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/handlerstest"
+	"github.com/rs/zerolog"
+)
+
+// newTestBaseHandler creates a base handler for testing with a mock client
+func newTestBaseHandler(mockClient *handlerstest.MockHTTPClient) *baseHandler {
+	return newBaseHandler(handlerstest.NewHandlerOptions(mockClient))
+}
+
+// Test data constants
+const (
+	testOrg             = "testorg"
+	testProjectID       = "9a3c2b1d-0000-0000-0000-000000000001"
+	testScopeDescriptor = "scp.PlatformProject"
+	testGroupDescriptor = "vssgp.Reviewers"
+	testAPIVersion      = "7.1-preview.1"
+	testUsername        = "test"
+	testPassword        = "test"
+	aliceDescriptor     = "aad.Alice"
+	adminsDescriptor    = "aadgp.Admins"
+	nestedDescriptor    = "vssgp.Nested"
+)
+
+var (
+	scopeDescriptorURL = fmt.Sprintf("https://vssps.dev.azure.com/%s/_apis/graph/descriptors/%s?api-version=%s", testOrg, testProjectID, testAPIVersion)
+	groupURL           = fmt.Sprintf("https://vssps.dev.azure.com/%s/_apis/graph/groups/%s?api-version=%s", testOrg, testGroupDescriptor, testAPIVersion)
+	createGroupURL     = fmt.Sprintf("https://vssps.dev.azure.com/%s/_apis/graph/groups?api-version=%s", testOrg, testAPIVersion)
+	createScopedURL    = fmt.Sprintf("https://vssps.dev.azure.com/%s/_apis/graph/groups?scopeDescriptor=%s&api-version=%s", testOrg, testScopeDescriptor, testAPIVersion)
+	membershipsURL     = fmt.Sprintf("https://vssps.dev.azure.com/%s/_apis/graph/memberships/%s?direction=down&api-version=%s", testOrg, testGroupDescriptor, testAPIVersion)
+	subjectLookupURL   = fmt.Sprintf("https://vssps.dev.azure.com/%s/_apis/graph/subjectlookup?api-version=%s", testOrg, testAPIVersion)
+	subjectQueryURL    = fmt.Sprintf("https://vssps.dev.azure.com/%s/_apis/graph/subjectquery?api-version=%s", testOrg, testAPIVersion)
+
+	reviewersResp       = `{"descriptor":"vssgp.Reviewers","displayName":"Reviewers","description":"Code reviewers","principalName":"[Platform]\\Reviewers","domain":"vstfs:///Classification/TeamProject/9A3C2B1D-0000-0000-0000-000000000001","origin":"vsts","originId":"5E6F7A8B-0000-0000-0000-000000000003","url":"https://vssps.dev.azure.com/testorg/_apis/Graph/Groups/vssgp.Reviewers"}`
+	auditorsResp        = `{"descriptor":"vssgp.Auditors","displayName":"Auditors","description":"","principalName":"[testorg]\\Auditors","domain":"vstfs:///Framework/IdentityDomain/0d1e2f3a-0000-0000-0000-000000000004","origin":"vsts","originId":"1a2b3c4d-0000-0000-0000-000000000005"}`
+	adminsResp          = `{"descriptor":"aadgp.Admins","displayName":"Admins","description":"","principalName":"[TEAM FOUNDATION]\\Admins","domain":"1b2c3d4e-0000-0000-0000-000000000006","origin":"aad","originId":"7c8d9e0f-0000-0000-0000-000000000007"}`
+	scopeDescriptorResp = `{"value":"scp.PlatformProject"}`
+	aliceAndAdminsResp  = `{"count":2,"value":[{"containerDescriptor":"vssgp.Reviewers","memberDescriptor":"aadgp.Admins"},{"containerDescriptor":"vssgp.Reviewers","memberDescriptor":"aad.Alice"}]}`
+	subjectLookupResp   = `{"count":3,"value":{"aad.Alice":{"descriptor":"aad.Alice","subjectKind":"user","principalName":"alice@example.com","mailAddress":"alice@example.com","displayName":"Alice"},"aadgp.Admins":{"descriptor":"aadgp.Admins","subjectKind":"group","principalName":"[TEAM FOUNDATION]\\Admins","displayName":"Admins"},"vssgp.Nested":{"descriptor":"vssgp.Nested","subjectKind":"group","principalName":"[Platform]\\Nested","displayName":"Nested"}}}`
+	aliceQueryResp      = `{"count":2,"value":[{"descriptor":"aad.AliceSmith","subjectKind":"user","principalName":"alice@example.org","mailAddress":"alice.smith@example.com","displayName":"Alice Smith"},{"descriptor":"aad.Alice","subjectKind":"user","principalName":"alice@example.com","mailAddress":"alice@example.com","displayName":"Alice"}]}`
+	bobQueryResp        = `{"count":1,"value":[{"descriptor":"aad.Bob","subjectKind":"user","principalName":"bob@contoso.com","mailAddress":"bob@example.com","displayName":"Bob"}]}`
+	emptyListResp       = `{"count":0,"value":[]}`
+
+	expectedReviewers = `{"descriptor":"vssgp.Reviewers","displayName":"Reviewers","description":"Code reviewers","principalName":"[Platform]\\Reviewers","domain":"vstfs:///Classification/TeamProject/9A3C2B1D-0000-0000-0000-000000000001","origin":"vsts","originId":"5e6f7a8b-0000-0000-0000-000000000003","members":[{"descriptor":"aad.Alice","subjectKind":"user","principalName":"alice@example.com","displayName":"Alice"},{"descriptor":"aadgp.Admins","subjectKind":"group","principalName":"[TEAM FOUNDATION]\\Admins","displayName":"Admins"}]}`
+)
+
+// groupsURL returns the URL used to list a page of the groups of the scope
+func groupsURL(scopeDescriptor, continuationToken string) string {
+	query := url.Values{}
+	if scopeDescriptor != "" {
+		query.Set("scopeDescriptor", scopeDescriptor)
+	}
+	if continuationToken != "" {
+		query.Set("continuationToken", continuationToken)
+	}
+	query.Set("api-version", testAPIVersion)
+	return fmt.Sprintf("https://vssps.dev.azure.com/%s/_apis/graph/groups?%s", testOrg, query.Encode())
+}
+
+// membershipURL returns the URL used to add or remove a member of the test group
+func membershipURL(descriptor string) string {
+	return fmt.Sprintf("https://vssps.dev.azure.com/%s/_apis/graph/memberships/%s/%s?api-version=%s", testOrg, descriptor, testGroupDescriptor, testAPIVersion)
+}
+
+// groupList returns a page of groups as returned by Azure DevOps
+func groupList(groups ...string) string {
+	return fmt.Sprintf(`{"count":%d,"value":[%s]}`, len(groups), strings.Join(groups, ","))
+}
+
+// graphTestCase is a test case for the handlers of this package
+type graphTestCase struct {
+	name                 string
+	pathValues           map[string]string
+	query                url.Values
+	noAuth               bool
+	body                 string
+	setupMock            func(*handlerstest.MockHTTPClient)
+	expectedStatus       int
+	expectedBody         string
+	expectedBodyContains string
+	expectedRequestCount int
+	verify               func(t *testing.T, mockClient *handlerstest.MockHTTPClient)
+}
+
+// runGraphTests runs the given test cases against the handler built by newHandler
+func runGraphTests(t *testing.T, method string, newHandler func(*baseHandler) http.Handler, tests []graphTestCase) {
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := handlerstest.NewMockHTTPClient()
+			if tt.setupMock != nil {
+				tt.setupMock(mockClient)
+			}
+			handler := newHandler(newTestBaseHandler(mockClient))
+
+			target := "/api/test"
+			if len(tt.query) > 0 {
+				target += "?" + tt.query.Encode()
+			}
+			var body io.Reader
+			if tt.body != "" {
+				body = strings.NewReader(tt.body)
+			}
+			req := httptest.NewRequest(method, target, body)
+			for key, value := range tt.pathValues {
+				req.SetPathValue(key, value)
+			}
+			if !tt.noAuth {
+				req.SetBasicAuth(testUsername, testPassword)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("Status code = %d, want %d. Body: %s", rr.Code, tt.expectedStatus, rr.Body.String())
+			}
+			if tt.expectedBody != "" && rr.Body.String() != tt.expectedBody {
+				t.Errorf("Body = %s, want %s", rr.Body.String(), tt.expectedBody)
+			}
+			if tt.expectedBodyContains != "" && !strings.Contains(rr.Body.String(), tt.expectedBodyContains) {
+				t.Errorf("Body = %s, want it to contain %s", rr.Body.String(), tt.expectedBodyContains)
+			}
+			if mockClient.RequestCount() != tt.expectedRequestCount {
+				t.Errorf("Request count = %d, want %d", mockClient.RequestCount(), tt.expectedRequestCount)
+			}
+			if tt.verify != nil {
+				tt.verify(t, mockClient)
+			}
+		})
+	}
+}
+
+// apiVersionQuery returns the query with the test API version
+func apiVersionQuery() url.Values {
+	return url.Values{"api-version": {testAPIVersion}}
+}
+
+// requestBody returns the body of the n-th request sent to Azure DevOps
+func requestBody(t *testing.T, mockClient *handlerstest.MockHTTPClient, n int) string {
+	t.Helper()
+	if len(mockClient.Requests) <= n || mockClient.Requests[n].Body == nil {
+		t.Fatalf("request %d has no body", n)
+	}
+	body, err := io.ReadAll(mockClient.Requests[n].Body)
+	if err != nil {
+		t.Fatalf("failed to read request %d body: %v", n, err)
+	}
+	return string(body)
+}
+
+// groupPathValues returns the path values of the group endpoints
+func groupPathValues() map[string]string {
+	return map[string]string{"organization": testOrg, "descriptor": testGroupDescriptor}
+}
+
+// requestURLs returns "METHOD url" for every request sent to Azure DevOps
+func requestURLs(mockClient *handlerstest.MockHTTPClient) []string {
+	urls := []string{}
+	for _, req := range mockClient.Requests {
+		urls = append(urls, req.Method+" "+req.URL.String())
+	}
+	return urls
+}
+
+// containsRequest reports whether a request was sent with the given method and URL
+func containsRequest(mockClient *handlerstest.MockHTTPClient, method, url string) bool {
+	for _, request := range requestURLs(mockClient) {
+		if request == method+" "+url {
+			return true
+		}
+	}
+	return false
+}
+
+// Test constructor functions
+func TestGraphConstructors(t *testing.T) {
+	client := &http.Client{}
+	logger := zerolog.New(os.Stdout).With().Timestamp().Logger()
+	opts := handlers.HandlerOptions{
+		Client: client,
+		Log:    &logger,
+	}
+
+	constructors := map[string]func(handlers.HandlerOptions) handlers.Handler{
+		"ListGroups":      ListGroups,
+		"GetGroup":        GetGroup,
+		"PostGroup":       PostGroup,
+		"PutGroup":        PutGroup,
+		"DeleteGroup":     DeleteGroup,
+		"ResolveSubjects": ResolveSubjects,
+	}
+
+	for name, constructor := range constructors {
+		t.Run(name, func(t *testing.T) {
+			if h := constructor(opts); h == nil {
+				t.Fatalf("%s should return a non-nil handler", name)
+			}
+		})
+	}
+}
+
+// Test LIST handler
+func TestListHandler_ServeHTTP(t *testing.T) {
+	runGraphTests(t, "GET", func(b *baseHandler) http.Handler { return &listHandler{baseHandler: b} }, []graphTestCase{
+		{
+			name:       "organization groups from all the pages sorted by principal name",
+			pathValues: map[string]string{"organization": testOrg},
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(groupsURL("", ""), http.StatusOK, groupList(reviewersResp, auditorsResp))
+				m.SetResponseHeader(groupsURL("", ""), ContinuationTokenHeader, "page 2")
+				m.SetResponse(groupsURL("", "page 2"), http.StatusOK, groupList(adminsResp))
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBody:         `{"count":2,"groups":[{"descriptor":"aadgp.Admins","displayName":"Admins","description":"","principalName":"[TEAM FOUNDATION]\\Admins","domain":"1b2c3d4e-0000-0000-0000-000000000006","origin":"aad","originId":"7c8d9e0f-0000-0000-0000-000000000007"},{"descriptor":"vssgp.Auditors","displayName":"Auditors","description":"","principalName":"[testorg]\\Auditors","domain":"vstfs:///Framework/IdentityDomain/0d1e2f3a-0000-0000-0000-000000000004","origin":"vsts","originId":"1a2b3c4d-0000-0000-0000-000000000005"}]}`,
+			expectedRequestCount: 2,
+		},
+		{
+			name:       "project groups",
+			pathValues: map[string]string{"organization": testOrg},
+			query:      url.Values{"api-version": {testAPIVersion}, "projectId": {strings.ToUpper(testProjectID)}},
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(scopeDescriptorURL, http.StatusOK, scopeDescriptorResp)
+				m.SetResponse(groupsURL(testScopeDescriptor, ""), http.StatusOK, groupList(reviewersResp))
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `{"count":1,"groups":[{"descriptor":"vssgp.Reviewers"`,
+			expectedRequestCount: 2,
+		},
+		{
+			name:       "repeated continuation token",
+			pathValues: map[string]string{"organization": testOrg},
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(groupsURL("", ""), http.StatusOK, groupList(reviewersResp, auditorsResp))
+				m.SetResponseHeader(groupsURL("", ""), ContinuationTokenHeader, "page 2")
+				m.SetResponse(groupsURL("", "page 2"), http.StatusOK, groupList(adminsResp))
+				m.SetResponseHeader(groupsURL("", "page 2"), ContinuationTokenHeader, "page 2")
+			},
+			expectedStatus:       http.StatusBadGateway,
+			expectedBodyContains: "already seen continuation token",
+			expectedRequestCount: 2,
+		},
+		{
+			name:                 "missing organization",
+			pathValues:           map[string]string{},
+			query:                apiVersionQuery(),
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Organization parameter is required",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "missing basic auth",
+			pathValues:           map[string]string{"organization": testOrg},
+			query:                apiVersionQuery(),
+			noAuth:               true,
+			expectedStatus:       http.StatusUnauthorized,
+			expectedBody:         "Request rejected due to missing or invalid Basic authentication",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "project not found",
+			pathValues:           map[string]string{"organization": testOrg},
+			query:                url.Values{"api-version": {testAPIVersion}, "projectId": {testProjectID}},
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Project 9a3c2b1d-0000-0000-0000-000000000001 not found",
+			expectedRequestCount: 1,
+		},
+	})
+}
+
+// Test GET handler
+func TestGetHandler_ServeHTTP(t *testing.T) {
+	runGraphTests(t, "GET", func(b *baseHandler) http.Handler { return &getHandler{baseHandler: b} }, []graphTestCase{
+		{
+			name:       "successful retrieval with members sorted by descriptor",
+			pathValues: groupPathValues(),
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(groupURL, http.StatusOK, reviewersResp)
+				m.SetResponse(membershipsURL, http.StatusOK, aliceAndAdminsResp)
+				m.SetMethodResponse("POST", subjectLookupURL, http.StatusOK, subjectLookupResp)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBody:         expectedReviewers,
+			expectedRequestCount: 3,
+		},
+		{
+			name:       "group without members",
+			pathValues: groupPathValues(),
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(groupURL, http.StatusOK, reviewersResp)
+				m.SetResponse(membershipsURL, http.StatusOK, emptyListResp)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `"members":[]`,
+			expectedRequestCount: 2,
+		},
+		{
+			name:                 "missing API version",
+			pathValues:           groupPathValues(),
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "API version is required",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "group not found",
+			pathValues:           groupPathValues(),
+			query:                apiVersionQuery(),
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Group vssgp.Reviewers not found",
+			expectedRequestCount: 1,
+		},
+	})
+}
+
+// Test POST handler
+func TestPostHandler_ServeHTTP(t *testing.T) {
+	pathValues := map[string]string{"organization": testOrg}
+	runGraphTests(t, "POST", func(b *baseHandler) http.Handler { return &postHandler{baseHandler: b} }, []graphTestCase{
+		{
+			name:       "project group created with a user, an AAD group and a nested group",
+			pathValues: pathValues,
+			query:      apiVersionQuery(),
+			body:       `{"displayName":"Reviewers","description":"Code reviewers","projectId":"` + testProjectID + `","members":[{"principalName":"alice@example.com"},{"originId":"7C8D9E0F-0000-0000-0000-000000000007"},{"descriptor":"vssgp.Nested"}]}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetMethodResponse("POST", subjectQueryURL, http.StatusOK, aliceQueryResp)
+				m.SetMethodResponse("POST", createGroupURL, http.StatusCreated, adminsResp)
+				m.SetResponse(scopeDescriptorURL, http.StatusOK, scopeDescriptorResp)
+				m.SetResponse(groupsURL(testScopeDescriptor, ""), http.StatusOK, groupList())
+				m.SetMethodResponse("POST", createScopedURL, http.StatusCreated, reviewersResp)
+				m.SetResponseSequence(membershipsURL, handlerstest.MockResponse{StatusCode: http.StatusOK, Body: emptyListResp}, handlerstest.MockResponse{StatusCode: http.StatusOK, Body: `{"count":3,"value":[{"memberDescriptor":"vssgp.Nested"},{"memberDescriptor":"aadgp.Admins"},{"memberDescriptor":"aad.Alice"}]}`})
+				m.SetMethodResponse("PUT", membershipURL(aliceDescriptor), http.StatusCreated, `{}`)
+				m.SetMethodResponse("PUT", membershipURL(adminsDescriptor), http.StatusCreated, `{}`)
+				m.SetMethodResponse("PUT", membershipURL(nestedDescriptor), http.StatusCreated, `{}`)
+				m.SetMethodResponse("POST", subjectLookupURL, http.StatusOK, subjectLookupResp)
+			},
+			expectedStatus:       http.StatusCreated,
+			expectedBodyContains: `"members":[{"descriptor":"aad.Alice","subjectKind":"user","principalName":"alice@example.com","displayName":"Alice"},{"descriptor":"aadgp.Admins","subjectKind":"group","principalName":"[TEAM FOUNDATION]\\Admins","displayName":"Admins"},{"descriptor":"vssgp.Nested","subjectKind":"group","principalName":"[Platform]\\Nested","displayName":"Nested"}]`,
+			expectedRequestCount: 11,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				if got, want := requestBody(t, m, 0), `{"query":"alice@example.com","subjectKind":["User","Group"]}`; got != want {
+					t.Errorf("Subject query body = %s, want %s", got, want)
+				}
+				if got, want := requestBody(t, m, 1), `{"originId":"7c8d9e0f-0000-0000-0000-000000000007"}`; got != want {
+					t.Errorf("AAD group body = %s, want %s", got, want)
+				}
+				if got, want := requestBody(t, m, 4), `{"displayName":"Reviewers","description":"Code reviewers"}`; got != want {
+					t.Errorf("Group creation body = %s, want %s", got, want)
+				}
+			},
+		},
+		{
+			name:       "existing group found by name gets its description updated",
+			pathValues: pathValues,
+			query:      apiVersionQuery(),
+			body:       `{"displayName":"reviewers","description":"Reviewers of the platform","projectId":"` + testProjectID + `"}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(scopeDescriptorURL, http.StatusOK, scopeDescriptorResp)
+				m.SetResponse(groupsURL(testScopeDescriptor, ""), http.StatusOK, groupList(auditorsResp, reviewersResp))
+				m.SetMethodResponse("PATCH", groupURL, http.StatusOK, strings.Replace(reviewersResp, "Code reviewers", "Reviewers of the platform", 1))
+				m.SetResponse(membershipsURL, http.StatusOK, aliceAndAdminsResp)
+				m.SetMethodResponse("POST", subjectLookupURL, http.StatusOK, subjectLookupResp)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBody:         strings.Replace(expectedReviewers, "Code reviewers", "Reviewers of the platform", 1),
+			expectedRequestCount: 5,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				if got, want := requestBody(t, m, 2), `[{"op":"replace","path":"/description","value":"Reviewers of the platform"}]`; got != want {
+					t.Errorf("Patch body = %s, want %s", got, want)
+				}
+				if got := m.Requests[2].Header.Get("Content-Type"); got != "application/json-patch+json" {
+					t.Errorf("Patch content type = %s, want application/json-patch+json", got)
+				}
+			},
+		},
+		{
+			name:       "organization group created even if a project group has the same name",
+			pathValues: pathValues,
+			query:      apiVersionQuery(),
+			body:       `{"displayName":"Reviewers","description":"Code reviewers"}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(groupsURL("", ""), http.StatusOK, groupList(reviewersResp, adminsResp))
+				m.SetMethodResponse("POST", createGroupURL, http.StatusCreated, reviewersResp)
+				m.SetResponse(membershipsURL, http.StatusOK, emptyListResp)
+			},
+			expectedStatus:       http.StatusCreated,
+			expectedBodyContains: `"descriptor":"vssgp.Reviewers"`,
+			expectedRequestCount: 3,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				if containsRequest(m, "GET", scopeDescriptorURL) {
+					t.Errorf("Project descriptor should not be requested for an organization group")
+				}
+			},
+		},
+		{
+			name:       "unknown principal name",
+			pathValues: pathValues,
+			query:      apiVersionQuery(),
+			body:       `{"displayName":"Reviewers","members":[{"principalName":"alice@example.org.uk"}]}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetMethodResponse("POST", subjectQueryURL, http.StatusOK, aliceQueryResp)
+			},
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid group: subject 'alice@example.org.uk' not found",
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "member with several identifiers",
+			pathValues:           pathValues,
+			query:                apiVersionQuery(),
+			body:                 `{"displayName":"Reviewers","members":[{"descriptor":"aad.Alice"},{"descriptor":"aad.Alice","principalName":"alice@example.com"}]}`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid group: members[1]: exactly one of descriptor, principalName and originId is required",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "missing display name",
+			pathValues:           pathValues,
+			query:                apiVersionQuery(),
+			body:                 `{"description":"Code reviewers"}`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid group: displayName is required",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "invalid JSON",
+			pathValues:           pathValues,
+			query:                apiVersionQuery(),
+			body:                 `{"displayName":`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid JSON in request body",
+			expectedRequestCount: 0,
+		},
+	})
+}
+
+// Test PUT handler
+func TestPutHandler_ServeHTTP(t *testing.T) {
+	runGraphTests(t, "PUT", func(b *baseHandler) http.Handler { return &putHandler{baseHandler: b} }, []graphTestCase{
+		{
+			name:       "members reconciled without changing the description",
+			pathValues: groupPathValues(),
+			query:      apiVersionQuery(),
+			body:       `{"description":"Code reviewers","members":[{"descriptor":"aad.Alice"}]}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(groupURL, http.StatusOK, reviewersResp)
+				m.SetResponseSequence(membershipsURL, handlerstest.MockResponse{StatusCode: http.StatusOK, Body: aliceAndAdminsResp}, handlerstest.MockResponse{StatusCode: http.StatusOK, Body: `{"count":1,"value":[{"memberDescriptor":"aad.Alice"}]}`})
+				m.SetMethodResponse("DELETE", membershipURL(adminsDescriptor), http.StatusOK, ``)
+				m.SetMethodResponse("POST", subjectLookupURL, http.StatusOK, subjectLookupResp)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `"members":[{"descriptor":"aad.Alice","subjectKind":"user","principalName":"alice@example.com","displayName":"Alice"}]`,
+			expectedRequestCount: 5,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				if containsRequest(m, "PATCH", groupURL) {
+					t.Errorf("Group should not be patched when the description is unchanged")
+				}
+				if !containsRequest(m, "DELETE", membershipURL(adminsDescriptor)) {
+					t.Errorf("Admins should be removed from the group. Requests: %v", requestURLs(m))
+				}
+			},
+		},
+		{
+			name:       "empty members remove everyone",
+			pathValues: groupPathValues(),
+			query:      apiVersionQuery(),
+			body:       `{"description":"Code reviewers","members":[]}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(groupURL, http.StatusOK, reviewersResp)
+				m.SetResponseSequence(membershipsURL, handlerstest.MockResponse{StatusCode: http.StatusOK, Body: aliceAndAdminsResp}, handlerstest.MockResponse{StatusCode: http.StatusOK, Body: emptyListResp})
+				m.SetMethodResponse("DELETE", membershipURL(aliceDescriptor), http.StatusOK, ``)
+				m.SetMethodResponse("DELETE", membershipURL(adminsDescriptor), http.StatusOK, ``)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `"members":[]`,
+			expectedRequestCount: 5,
+		},
+		{
+			name:       "display name cannot be changed",
+			pathValues: groupPathValues(),
+			query:      apiVersionQuery(),
+			body:       `{"displayName":"Approvers","description":"Code reviewers"}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(groupURL, http.StatusOK, reviewersResp)
+			},
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid group: displayName cannot be changed",
+			expectedRequestCount: 1,
+		},
+		{
+			name:       "project cannot be changed",
+			pathValues: groupPathValues(),
+			query:      apiVersionQuery(),
+			body:       `{"projectId":"0f0f0f0f-0000-0000-0000-000000000008"}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(groupURL, http.StatusOK, reviewersResp)
+			},
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid group: projectId cannot be changed",
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "group not found",
+			pathValues:           groupPathValues(),
+			query:                apiVersionQuery(),
+			body:                 `{"description":"Code reviewers"}`,
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Group vssgp.Reviewers not found",
+			expectedRequestCount: 1,
+		},
+	})
+}
+
+// Test DELETE handler
+func TestDeleteHandler_ServeHTTP(t *testing.T) {
+	runGraphTests(t, "DELETE", func(b *baseHandler) http.Handler { return &deleteHandler{baseHandler: b} }, []graphTestCase{
+		{
+			name:       "successful deletion",
+			pathValues: groupPathValues(),
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetMethodResponse("DELETE", groupURL, http.StatusNoContent, ``)
+			},
+			expectedStatus:       http.StatusNoContent,
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "group not found",
+			pathValues:           groupPathValues(),
+			query:                apiVersionQuery(),
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Group vssgp.Reviewers not found",
+			expectedRequestCount: 1,
+		},
+		{
+			name:       "Azure DevOps error forwarded",
+			pathValues: groupPathValues(),
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetMethodResponse("DELETE", groupURL, http.StatusForbidden, `{"message":"Access denied"}`)
+			},
+			expectedStatus:       http.StatusForbidden,
+			expectedBody:         `{"message":"Access denied"}`,
+			expectedRequestCount: 1,
+		},
+	})
+}
@@ -0,0 +1,250 @@
+package graph
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/graphmembership"
+)
+
+// ErrRepeatedContinuationToken is returned when Azure DevOps returns a continuation token already followed while listing groups
+var ErrRepeatedContinuationToken = errors.New("azure devops returned an already seen continuation token")
+
+// errSubjectNotFound is returned when a principal name does not match any user or group
+type errSubjectNotFound struct {
+	PrincipalName string
+}
+
+func (e *errSubjectNotFound) Error() string {
+	return fmt.Sprintf("subject '%s' not found", e.PrincipalName)
+}
+
+// getScopeDescriptor returns the graph descriptor of the project, used as scope of its groups
+func (h *baseHandler) getScopeDescriptor(organization, projectID, apiVersion, authHeader string) (string, error) {
+	url := fmt.Sprintf("https://vssps.dev.azure.com/%s/_apis/graph/descriptors/%s?api-version=%s", organization, projectID, apiVersion)
+
+	body, err := h.DoAzureDevOpsRequest("GET", url, authHeader, nil)
+	if err != nil {
+		return "", err
+	}
+
+	var result GraphDescriptorResult
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", fmt.Errorf("failed to unmarshal project descriptor: %w", err)
+	}
+	if result.Value == "" {
+		return "", fmt.Errorf("no descriptor returned for project %s", projectID)
+	}
+	return result.Value, nil
+}
+
+// listGroups returns all the groups of the scope (all the groups of the organization when scopeDescriptor is empty)
+// The pages are followed through the continuation token returned by Azure DevOps
+func (h *baseHandler) listGroups(organization, scopeDescriptor, apiVersion, authHeader string) ([]GraphGroup, error) {
+	groups := []GraphGroup{}
+	seenTokens := map[string]bool{}
+	continuationToken := ""
+	for page := 1; ; page++ {
+		query := url.Values{}
+		if scopeDescriptor != "" {
+			query.Set("scopeDescriptor", scopeDescriptor)
+		}
+		if continuationToken != "" {
+			query.Set("continuationToken", continuationToken)
+		}
+		query.Set("api-version", apiVersion)
+		url := fmt.Sprintf("https://vssps.dev.azure.com/%s/_apis/graph/groups?%s", organization, query.Encode())
+
+		body, header, err := h.DoAzureDevOpsRequestWithHeader("GET", url, authHeader, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var groupList GraphGroupList
+		if err := json.Unmarshal(body, &groupList); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal groups: %w", err)
+		}
+		groups = append(groups, groupList.Value...)
+
+		continuationToken = header.Get(ContinuationTokenHeader)
+		if continuationToken == "" {
+			return groups, nil
+		}
+		// Azure DevOps returning the same token over and over would make the list incomplete, so it is reported instead of truncating
+		if seenTokens[continuationToken] {
+			h.Log.Printf("Continuation token of page %d already seen", page)
+			return nil, fmt.Errorf("%w after %d pages", ErrRepeatedContinuationToken, page)
+		}
+		seenTokens[continuationToken] = true
+	}
+}
+
+// resolvePrincipalName returns the user or group whose principal name or mail address is the given one
+func (h *baseHandler) resolvePrincipalName(organization, principalName, apiVersion, authHeader string) (*graphmembership.GraphSubject, error) {
+	requestBody, err := json.Marshal(GraphSubjectQuery{Query: principalName, SubjectKind: []string{"User", "Group"}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal subject query: %w", err)
+	}
+
+	url := fmt.Sprintf("https://vssps.dev.azure.com/%s/_apis/graph/subjectquery?api-version=%s", organization, apiVersion)
+	body, err := h.DoAzureDevOpsRequest("POST", url, authHeader, requestBody)
+	if err != nil {
+		return nil, err
+	}
+
+	var subjects GraphSubjectList
+	if err := json.Unmarshal(body, &subjects); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal subject query: %w", err)
+	}
+
+	// The query also matches display names and prefixes, only exact matches are kept
+	subject := matchSubject(subjects.Value, principalName)
+	if subject == nil {
+		return nil, &errSubjectNotFound{PrincipalName: principalName}
+	}
+	return subject, nil
+}
+
+// materializeAADGroup adds the AAD group with the given object ID to the organization, if needed, and returns its descriptor
+func (h *baseHandler) materializeAADGroup(organization, originID, apiVersion, authHeader string) (string, error) {
+	requestBody, err := json.Marshal(GraphGroupCreationContext{OriginID: originID})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal group: %w", err)
+	}
+
+	url := fmt.Sprintf("https://vssps.dev.azure.com/%s/_apis/graph/groups?api-version=%s", organization, apiVersion)
+	body, err := h.DoAzureDevOpsRequest("POST", url, authHeader, requestBody)
+	if err != nil {
+		return "", err
+	}
+
+	var group GraphGroup
+	if err := json.Unmarshal(body, &group); err != nil {
+		return "", fmt.Errorf("failed to unmarshal group: %w", err)
+	}
+	return group.Descriptor, nil
+}
+
+// resolveMembers returns the descriptors of the requested members
+// Principal names are resolved through the subject query and AAD groups are materialized
+func (h *baseHandler) resolveMembers(organization string, members []GroupMemberRequest, apiVersion, authHeader string) ([]string, error) {
+	descriptors := []string{}
+	for _, member := range members {
+		switch {
+		case member.Descriptor != "":
+			descriptors = append(descriptors, member.Descriptor)
+		case member.PrincipalName != "":
+			subject, err := h.resolvePrincipalName(organization, member.PrincipalName, apiVersion, authHeader)
+			if err != nil {
+				return nil, err
+			}
+			descriptors = append(descriptors, subject.Descriptor)
+		default:
+			descriptor, err := h.materializeAADGroup(organization, member.OriginID, apiVersion, authHeader)
+			if err != nil {
+				return nil, fmt.Errorf("failed to add AAD group %s: %w", member.OriginID, err)
+			}
+			descriptors = append(descriptors, descriptor)
+		}
+	}
+	return descriptors, nil
+}
+
+// memberships returns the graph membership helper sending its requests through this handler
+func (h *baseHandler) memberships() *graphmembership.Memberships {
+	return graphmembership.New(h.BaseHandler)
+}
+
+// getGroupMembers returns the members of the group sorted by descriptor
+func (h *baseHandler) getGroupMembers(organization, groupDescriptor, apiVersion, authHeader string) ([]GroupMember, error) {
+	memberships := h.memberships()
+	descriptors, err := memberships.ListMemberDescriptors(organization, groupDescriptor, apiVersion, authHeader)
+	if err != nil {
+		return nil, err
+	}
+	subjects, err := memberships.LookupSubjects(organization, descriptors, apiVersion, authHeader)
+	if err != nil {
+		return nil, err
+	}
+	return buildGroupMembers(descriptors, subjects), nil
+}
+
+// RESOLVE handler implementation
+// @Summary Resolve principal names to subject descriptors
+// @Description Resolve UPNs, emails and group principal names to the descriptors of the matching users and groups
+// @ID resolve-subjects
+// @Param organization path string true "Organization name"
+// @Param api-version query string true "API version (e.g., 7.1-preview.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Param resolve body ResolveRequest true "Principal names to resolve"
+// @Accept json
+// @Produce json
+// @Success 200 {object} ResolveResponse "Resolved subjects"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/graph/subjects/resolve [post]
+func (h *resolveHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.validateOrganizationParams(w, organization, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Failed to read request body")
+		return
+	}
+
+	var resolveRequest ResolveRequest
+	if err := json.Unmarshal(body, &resolveRequest); err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Invalid JSON in request body")
+		return
+	}
+	if len(resolveRequest.PrincipalNames) == 0 {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "At least one principal name is required")
+		return
+	}
+
+	h.Log.Printf("Resolving %d principal names for organization %s", len(resolveRequest.PrincipalNames), organization)
+
+	response := ResolveResponse{Subjects: []ResolvedSubject{}}
+	for _, principalName := range resolveRequest.PrincipalNames {
+		principalName = strings.TrimSpace(principalName)
+		subject, err := h.resolvePrincipalName(organization, principalName, apiVersion, authHeader)
+		if err != nil {
+			var notFound *errSubjectNotFound
+			if errors.As(err, &notFound) {
+				h.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid principal names: %v", err))
+				return
+			}
+			h.WriteAzureDevOpsError(w, err, "resolve principal names", fmt.Sprintf("Organization %s not found", organization))
+			return
+		}
+		response.Subjects = append(response.Subjects, ResolvedSubject{
+			PrincipalName: principalName,
+			Descriptor:    subject.Descriptor,
+			SubjectKind:   subject.SubjectKind,
+			DisplayName:   subject.DisplayName,
+		})
+	}
+	sort.Slice(response.Subjects, func(i, j int) bool {
+		return response.Subjects[i].PrincipalName < response.Subjects[j].PrincipalName
+	})
+
+	h.WriteObjectResponse(w, http.StatusOK, response)
+	h.Log.Printf("Successfully resolved %d principal names", len(response.Subjects))
+}
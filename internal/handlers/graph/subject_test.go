@@ -0,0 +1,154 @@
+package graph
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/graphmembership"
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/handlerstest"
+)
+
+// Test RESOLVE handler
+func TestResolveHandler_ServeHTTP(t *testing.T) {
+	pathValues := map[string]string{"organization": testOrg}
+	runGraphTests(t, "POST", func(b *baseHandler) http.Handler { return &resolveHandler{baseHandler: b} }, []graphTestCase{
+		{
+			name:       "UPN and email resolved and sorted by principal name",
+			pathValues: pathValues,
+			query:      apiVersionQuery(),
+			body:       `{"principalNames":["bob@example.com","alice@example.com"]}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponseSequence("POST "+subjectQueryURL, handlerstest.MockResponse{StatusCode: http.StatusOK, Body: bobQueryResp}, handlerstest.MockResponse{StatusCode: http.StatusOK, Body: aliceQueryResp})
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBody:         `{"subjects":[{"principalName":"alice@example.com","descriptor":"aad.Alice","subjectKind":"user","displayName":"Alice"},{"principalName":"bob@example.com","descriptor":"aad.Bob","subjectKind":"user","displayName":"Bob"}]}`,
+			expectedRequestCount: 2,
+		},
+		{
+			name:       "unknown principal name",
+			pathValues: pathValues,
+			query:      apiVersionQuery(),
+			body:       `{"principalNames":["nobody@example.com"]}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetMethodResponse("POST", subjectQueryURL, http.StatusOK, emptyListResp)
+			},
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid principal names: subject 'nobody@example.com' not found",
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "no principal names",
+			pathValues:           pathValues,
+			query:                apiVersionQuery(),
+			body:                 `{"principalNames":[]}`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "At least one principal name is required",
+			expectedRequestCount: 0,
+		},
+	})
+}
+
+func TestListGroupsPagination(t *testing.T) {
+	mockClient := handlerstest.NewMockHTTPClient()
+	mockClient.SetResponse(groupsURL(testScopeDescriptor, ""), http.StatusOK, groupList(reviewersResp))
+	mockClient.SetResponseHeader(groupsURL(testScopeDescriptor, ""), ContinuationTokenHeader, "token+1")
+	mockClient.SetResponse(groupsURL(testScopeDescriptor, "token+1"), http.StatusOK, groupList(auditorsResp))
+	mockClient.SetResponseHeader(groupsURL(testScopeDescriptor, "token+1"), ContinuationTokenHeader, "token+2")
+	mockClient.SetResponse(groupsURL(testScopeDescriptor, "token+2"), http.StatusOK, groupList())
+	h := newTestBaseHandler(mockClient)
+
+	groups, err := h.listGroups(testOrg, testScopeDescriptor, testAPIVersion, "Basic dGVzdDp0ZXN0")
+	if err != nil {
+		t.Fatalf("listGroups() error = %v", err)
+	}
+	if len(groups) != 2 || groups[0].Descriptor != testGroupDescriptor || groups[1].Descriptor != "vssgp.Auditors" {
+		t.Errorf("listGroups() = %v, want Reviewers and Auditors", groups)
+	}
+	if mockClient.RequestCount() != 3 {
+		t.Errorf("Request count = %d, want 3", mockClient.RequestCount())
+	}
+}
+
+func TestListGroupsRepeatedContinuationToken(t *testing.T) {
+	mockClient := handlerstest.NewMockHTTPClient()
+	mockClient.SetResponse(groupsURL(testScopeDescriptor, ""), http.StatusOK, groupList(reviewersResp))
+	mockClient.SetResponseHeader(groupsURL(testScopeDescriptor, ""), ContinuationTokenHeader, "token+1")
+	mockClient.SetResponse(groupsURL(testScopeDescriptor, "token+1"), http.StatusOK, groupList(auditorsResp))
+	mockClient.SetResponseHeader(groupsURL(testScopeDescriptor, "token+1"), ContinuationTokenHeader, "token+1")
+	h := newTestBaseHandler(mockClient)
+
+	groups, err := h.listGroups(testOrg, testScopeDescriptor, testAPIVersion, "Basic dGVzdDp0ZXN0")
+	if !errors.Is(err, ErrRepeatedContinuationToken) {
+		t.Fatalf("listGroups() = %v, %v, want %v", groups, err, ErrRepeatedContinuationToken)
+	}
+	if mockClient.RequestCount() != 2 {
+		t.Errorf("Request count = %d, want 2", mockClient.RequestCount())
+	}
+}
+
+func TestMatchSubject(t *testing.T) {
+	subjects := []graphmembership.GraphSubject{
+		{Descriptor: "aad.Zed", PrincipalName: "alice@example.com"},
+		{Descriptor: "aad.Alice", PrincipalName: "Alice@Example.com"},
+		{Descriptor: "aad.Bob", PrincipalName: "bob@contoso.com", MailAddress: "bob@example.com"},
+		{Descriptor: "aad.Alicia", PrincipalName: "alicia@example.com", DisplayName: "alice@example.com"},
+	}
+
+	tests := []struct {
+		principalName string
+		expected      string
+	}{
+		{principalName: "alice@example.com", expected: "aad.Alice"}, // Lowest descriptor among the matches
+		{principalName: "BOB@example.com", expected: "aad.Bob"},     // Matched by mail address
+		{principalName: "alice@example", expected: ""},              // Prefixes are not matched
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.principalName, func(t *testing.T) {
+			subject := matchSubject(subjects, tt.principalName)
+			got := ""
+			if subject != nil {
+				got = subject.Descriptor
+			}
+			if got != tt.expected {
+				t.Errorf("matchSubject() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFindGroupByName(t *testing.T) {
+	groups := []GraphGroup{
+		{Descriptor: "aadgp.Reviewers", DisplayName: "Reviewers", Origin: "aad", Domain: "1b2c3d4e-0000-0000-0000-000000000006"},
+		{Descriptor: "vssgp.ProjectReviewers", DisplayName: "Reviewers", Origin: "vsts", Domain: ProjectDomainPrefix + "9A3C2B1D-0000-0000-0000-000000000001"},
+		{Descriptor: "vssgp.OrgReviewers", DisplayName: "Reviewers", Origin: "vsts", Domain: "vstfs:///Framework/IdentityDomain/0d1e2f3a-0000-0000-0000-000000000004"},
+	}
+
+	if group := findGroupByName(groups, "REVIEWERS", testProjectID); group == nil || group.Descriptor != "vssgp.ProjectReviewers" {
+		t.Errorf("findGroupByName() in project = %v, want vssgp.ProjectReviewers", group)
+	}
+	if group := findGroupByName(groups, "reviewers", ""); group == nil || group.Descriptor != "vssgp.OrgReviewers" {
+		t.Errorf("findGroupByName() in organization = %v, want vssgp.OrgReviewers", group)
+	}
+	if group := findGroupByName(groups, "Approvers", ""); group != nil {
+		t.Errorf("findGroupByName() = %v, want nil", group)
+	}
+}
+
+func TestValidateGroupRequest(t *testing.T) {
+	request := GroupRequest{DisplayName: " Reviewers ", ProjectID: " 9A3C2B1D-0000-0000-0000-000000000001 ", Members: []GroupMemberRequest{{OriginID: " 7C8D9E0F-0000-0000-0000-000000000007 "}}}
+	if err := validateGroupRequest(&request, true); err != nil {
+		t.Fatalf("validateGroupRequest() error = %v", err)
+	}
+	if request.DisplayName != "Reviewers" || request.ProjectID != testProjectID || request.Members[0].OriginID != "7c8d9e0f-0000-0000-0000-000000000007" {
+		t.Errorf("validateGroupRequest() did not normalize the request: %+v", request)
+	}
+
+	if err := validateGroupRequest(&GroupRequest{Description: "Code reviewers"}, false); err != nil {
+		t.Errorf("validateGroupRequest() on update error = %v, want nil", err)
+	}
+	if err := validateGroupRequest(&GroupRequest{DisplayName: "Reviewers", Members: []GroupMemberRequest{{}}}, true); err == nil {
+		t.Errorf("validateGroupRequest() with an empty member should fail")
+	}
+}
@@ -0,0 +1,114 @@
+package graph
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/graphmembership"
+)
+
+// validateGroupRequest checks the fields of a group request
+// The display name is only required on creation
+func validateGroupRequest(request *GroupRequest, create bool) error {
+	request.DisplayName = strings.TrimSpace(request.DisplayName)
+	if create && request.DisplayName == "" {
+		return fmt.Errorf("displayName is required")
+	}
+	request.ProjectID = strings.ToLower(strings.TrimSpace(request.ProjectID))
+
+	for i := range request.Members {
+		member := &request.Members[i]
+		member.Descriptor = strings.TrimSpace(member.Descriptor)
+		member.PrincipalName = strings.TrimSpace(member.PrincipalName)
+		member.OriginID = strings.ToLower(strings.TrimSpace(member.OriginID))
+
+		set := 0
+		for _, value := range []string{member.Descriptor, member.PrincipalName, member.OriginID} {
+			if value != "" {
+				set++
+			}
+		}
+		if set != 1 {
+			return fmt.Errorf("members[%d]: exactly one of descriptor, principalName and originId is required", i)
+		}
+	}
+
+	return nil
+}
+
+// isInScope reports whether the group belongs to the project, or to the organization when projectID is empty
+func isInScope(group *GraphGroup, projectID string) bool {
+	domain := strings.ToLower(group.Domain)
+	if projectID == "" {
+		return !strings.HasPrefix(domain, strings.ToLower(ProjectDomainPrefix))
+	}
+	return domain == strings.ToLower(ProjectDomainPrefix+projectID)
+}
+
+// findGroupByName returns the Azure DevOps group with the given display name (case insensitive) in the scope, nil if there is none
+// Groups synchronized from AAD are ignored since they are not managed by Azure DevOps
+func findGroupByName(groups []GraphGroup, displayName, projectID string) *GraphGroup {
+	for i := range groups {
+		if groups[i].Origin == "vsts" && strings.EqualFold(groups[i].DisplayName, displayName) && isInScope(&groups[i], projectID) {
+			return &groups[i]
+		}
+	}
+	return nil
+}
+
+// matchSubject returns the subject whose principal name or mail address is the given one (case insensitive), nil if there is none
+// When several subjects match, the one with the lowest descriptor is returned so that the result is stable
+func matchSubject(subjects []graphmembership.GraphSubject, principalName string) *graphmembership.GraphSubject {
+	var match *graphmembership.GraphSubject
+	for i := range subjects {
+		if !strings.EqualFold(subjects[i].PrincipalName, principalName) && !strings.EqualFold(subjects[i].MailAddress, principalName) {
+			continue
+		}
+		if match == nil || subjects[i].Descriptor < match.Descriptor {
+			match = &subjects[i]
+		}
+	}
+	return match
+}
+
+// buildGroupMembers returns the members of the group sorted by descriptor, with the details found by the subject lookup
+func buildGroupMembers(descriptors []string, subjects map[string]graphmembership.GraphSubject) []GroupMember {
+	members := []GroupMember{}
+	for _, descriptor := range descriptors {
+		subject := subjects[descriptor]
+		members = append(members, GroupMember{
+			Descriptor:    descriptor,
+			SubjectKind:   subject.SubjectKind,
+			PrincipalName: subject.PrincipalName,
+			DisplayName:   subject.DisplayName,
+		})
+	}
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].Descriptor < members[j].Descriptor
+	})
+	return members
+}
+
+// mapGroupFromAzure converts an Azure DevOps graph group to the group summary returned by the plugin
+func mapGroupFromAzure(group *GraphGroup) *GroupSummary {
+	return &GroupSummary{
+		Descriptor:    group.Descriptor,
+		DisplayName:   group.DisplayName,
+		Description:   group.Description,
+		PrincipalName: group.PrincipalName,
+		Domain:        group.Domain,
+		Origin:        group.Origin,
+		OriginID:      strings.ToLower(group.OriginID),
+	}
+}
+
+// sortGroups sorts the groups by principal name, then by descriptor
+func sortGroups(groups []GroupSummary) {
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].PrincipalName != groups[j].PrincipalName {
+			return groups[i].PrincipalName < groups[j].PrincipalName
+		}
+		return groups[i].Descriptor < groups[j].Descriptor
+	})
+}
@@ -0,0 +1,136 @@
+package graph
+
+import "github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/graphmembership"
+
+// Group represents the security group returned by the plugin
+// It is built from the GraphGroup returned by:
+// GET https://vssps.dev.azure.com/{organization}/_apis/graph/groups/{groupDescriptor}
+// and from the members returned by:
+// GET https://vssps.dev.azure.com/{organization}/_apis/graph/memberships/{groupDescriptor}?direction=down
+type Group struct {
+	GroupSummary
+	Members []GroupMember `json:"members"` // Sorted by descriptor
+}
+
+// GroupSummary represents a group without its members, as returned when listing groups
+type GroupSummary struct {
+	Descriptor    string `json:"descriptor"`
+	DisplayName   string `json:"displayName"`
+	Description   string `json:"description"`
+	PrincipalName string `json:"principalName"` // e.g., [Project]\Group
+	Domain        string `json:"domain"`
+	Origin        string `json:"origin"` // e.g., vsts, aad
+	OriginID      string `json:"originId"`
+}
+
+// GroupMember represents a member of a group: a user, an AAD group or a nested group
+type GroupMember struct {
+	Descriptor    string `json:"descriptor"`
+	SubjectKind   string `json:"subjectKind,omitempty"` // e.g., user, group
+	PrincipalName string `json:"principalName,omitempty"`
+	DisplayName   string `json:"displayName,omitempty"`
+}
+
+// GroupRequest represents the request body for creating or updating a group
+type GroupRequest struct {
+	DisplayName string               `json:"displayName"` // Required on creation, cannot be changed
+	Description string               `json:"description,omitempty"`
+	ProjectID   string               `json:"projectId,omitempty"` // Project of the group, the group is created at organization level if not set. Cannot be changed
+	Members     []GroupMemberRequest `json:"members,omitempty"`   // If set, the members of the group are reconciled with it. If not set, members are not changed
+}
+
+// GroupMemberRequest represents a member of a group in a request
+// Exactly one of descriptor, principalName (UPN or email of a user, principal name of a group) and originId (object ID of an AAD group) is required
+type GroupMemberRequest struct {
+	Descriptor    string `json:"descriptor,omitempty"`
+	PrincipalName string `json:"principalName,omitempty"`
+	OriginID      string `json:"originId,omitempty"`
+}
+
+// GroupResponse represents the response of the group endpoints
+type GroupResponse Group
+
+// GroupListResponse represents the response of the group list endpoint
+type GroupListResponse struct {
+	Count  int            `json:"count"`
+	Groups []GroupSummary `json:"groups"` // Sorted by principal name
+}
+
+// ResolveRequest represents the request body for resolving principal names to descriptors
+type ResolveRequest struct {
+	PrincipalNames []string `json:"principalNames"` // UPNs, emails or group principal names
+}
+
+// ResolvedSubject represents a principal name resolved to a subject
+type ResolvedSubject struct {
+	PrincipalName string `json:"principalName"` // As given in the request
+	Descriptor    string `json:"descriptor"`
+	SubjectKind   string `json:"subjectKind"`
+	DisplayName   string `json:"displayName"`
+}
+
+// ResolveResponse represents the response of the resolve endpoint
+type ResolveResponse struct {
+	Subjects []ResolvedSubject `json:"subjects"` // Sorted by principal name
+}
+
+// ---
+
+// GraphGroup represents a group of the Azure DevOps graph
+type GraphGroup struct {
+	Descriptor    string `json:"descriptor"`
+	DisplayName   string `json:"displayName"`
+	Description   string `json:"description"`
+	PrincipalName string `json:"principalName"`
+	Domain        string `json:"domain"`
+	Origin        string `json:"origin"`
+	OriginID      string `json:"originId"`
+}
+
+// GraphGroupList represents a page of the response from:
+// GET https://vssps.dev.azure.com/{organization}/_apis/graph/groups?scopeDescriptor={scopeDescriptor}&continuationToken={continuationToken}
+// The continuation token of the next page is returned in the X-MS-ContinuationToken header
+type GraphGroupList struct {
+	Count int          `json:"count"`
+	Value []GraphGroup `json:"value"`
+}
+
+// GraphGroupCreationContext represents the request body for creating a group, or materializing an AAD group:
+// POST https://vssps.dev.azure.com/{organization}/_apis/graph/groups
+type GraphGroupCreationContext struct {
+	DisplayName string `json:"displayName,omitempty"`
+	Description string `json:"description,omitempty"`
+	OriginID    string `json:"originId,omitempty"`
+}
+
+// JSONPatchOperation represents an operation of a JSON patch document
+type JSONPatchOperation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	Value string `json:"value"`
+}
+
+// GraphDescriptorResult represents the response from:
+// GET https://vssps.dev.azure.com/{organization}/_apis/graph/descriptors/{storageKey}
+type GraphDescriptorResult struct {
+	Value string `json:"value"`
+}
+
+// GraphSubjectQuery represents the request body of:
+// POST https://vssps.dev.azure.com/{organization}/_apis/graph/subjectquery
+type GraphSubjectQuery struct {
+	Query       string   `json:"query"`
+	SubjectKind []string `json:"subjectKind"`
+}
+
+// GraphSubjectList represents the response of the subject query
+type GraphSubjectList struct {
+	Count int                            `json:"count"`
+	Value []graphmembership.GraphSubject `json:"value"`
+}
+
+// ContinuationTokenHeader is the header with the continuation token of the next page of a graph list
+const ContinuationTokenHeader = "X-MS-ContinuationToken"
+
+// ProjectDomainPrefix is the prefix of the domain of the groups of a project
+const ProjectDomainPrefix = "vstfs:///Classification/TeamProject/"
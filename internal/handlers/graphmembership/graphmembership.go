@@ -0,0 +1,126 @@
+// Package graphmembership reads and reconciles the direct members of the groups of the Azure DevOps graph
+// It is shared by the handlers of the resources backed by a graph group (e.g., teams)
+package graphmembership
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers"
+)
+
+// Memberships sends the membership requests through the base handler of the calling package
+type Memberships struct {
+	*handlers.BaseHandler
+}
+
+// New creates the membership helper of the given base handler
+func New(base *handlers.BaseHandler) *Memberships {
+	return &Memberships{BaseHandler: base}
+}
+
+// ListMemberDescriptors returns the descriptors of the direct members of the group, sorted
+func (m *Memberships) ListMemberDescriptors(organization, groupDescriptor, apiVersion, authHeader string) ([]string, error) {
+	url := fmt.Sprintf("https://vssps.dev.azure.com/%s/_apis/graph/memberships/%s?direction=down&api-version=%s", organization, groupDescriptor, apiVersion)
+
+	body, err := m.DoAzureDevOpsRequest("GET", url, authHeader, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var memberships GraphMembershipList
+	if err := json.Unmarshal(body, &memberships); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal group memberships: %w", err)
+	}
+
+	descriptors := []string{}
+	for _, membership := range memberships.Value {
+		descriptors = append(descriptors, membership.MemberDescriptor)
+	}
+	sort.Strings(descriptors)
+	return descriptors, nil
+}
+
+// LookupSubjects returns the users and groups with the given descriptors, keyed by descriptor
+func (m *Memberships) LookupSubjects(organization string, descriptors []string, apiVersion, authHeader string) (map[string]GraphSubject, error) {
+	if len(descriptors) == 0 {
+		return map[string]GraphSubject{}, nil
+	}
+
+	lookup := GraphSubjectLookup{LookupKeys: []GraphSubjectLookupKey{}}
+	for _, descriptor := range descriptors {
+		lookup.LookupKeys = append(lookup.LookupKeys, GraphSubjectLookupKey{Descriptor: descriptor})
+	}
+	requestBody, err := json.Marshal(lookup)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal subject lookup: %w", err)
+	}
+
+	url := fmt.Sprintf("https://vssps.dev.azure.com/%s/_apis/graph/subjectlookup?api-version=%s", organization, apiVersion)
+	body, err := m.DoAzureDevOpsRequest("POST", url, authHeader, requestBody)
+	if err != nil {
+		return nil, err
+	}
+
+	var result GraphSubjectLookupResult
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal subject lookup: %w", err)
+	}
+	if result.Value == nil {
+		result.Value = map[string]GraphSubject{}
+	}
+	return result.Value, nil
+}
+
+// ReconcileMembers adds the missing members to the group and removes the ones not desired
+func (m *Memberships) ReconcileMembers(organization, groupDescriptor string, desired []string, apiVersion, authHeader string) error {
+	current, err := m.ListMemberDescriptors(organization, groupDescriptor, apiVersion, authHeader)
+	if err != nil {
+		return err
+	}
+
+	toAdd, toRemove := diffMembers(current, desired)
+	for _, descriptor := range toAdd {
+		url := fmt.Sprintf("https://vssps.dev.azure.com/%s/_apis/graph/memberships/%s/%s?api-version=%s", organization, descriptor, groupDescriptor, apiVersion)
+		if _, err := m.DoAzureDevOpsRequest("PUT", url, authHeader, nil); err != nil {
+			return fmt.Errorf("failed to add member %s: %w", descriptor, err)
+		}
+		m.Log.Printf("Added member %s to group %s", descriptor, groupDescriptor)
+	}
+	for _, descriptor := range toRemove {
+		url := fmt.Sprintf("https://vssps.dev.azure.com/%s/_apis/graph/memberships/%s/%s?api-version=%s", organization, descriptor, groupDescriptor, apiVersion)
+		if _, err := m.DoAzureDevOpsRequest("DELETE", url, authHeader, nil); err != nil {
+			return fmt.Errorf("failed to remove member %s: %w", descriptor, err)
+		}
+		m.Log.Printf("Removed member %s from group %s", descriptor, groupDescriptor)
+	}
+	return nil
+}
+
+// diffMembers returns the descriptors to add to and to remove from the group, sorted
+// Descriptors are case sensitive
+func diffMembers(current, desired []string) (toAdd []string, toRemove []string) {
+	currentSet := map[string]bool{}
+	for _, descriptor := range current {
+		currentSet[descriptor] = true
+	}
+	desiredSet := map[string]bool{}
+	for _, descriptor := range desired {
+		desiredSet[descriptor] = true
+	}
+
+	for descriptor := range desiredSet {
+		if !currentSet[descriptor] {
+			toAdd = append(toAdd, descriptor)
+		}
+	}
+	for descriptor := range currentSet {
+		if !desiredSet[descriptor] {
+			toRemove = append(toRemove, descriptor)
+		}
+	}
+	sort.Strings(toAdd)
+	sort.Strings(toRemove)
+	return toAdd, toRemove
+}
@@ -0,0 +1,120 @@
+package graphmembership
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers"
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/handlerstest"
+)
+
+// Test data constants
+const (
+	testOrg         = "testorg"
+	testAPIVersion  = "7.1-preview.1"
+	testAuthHeader  = "Basic dGVzdDp0ZXN0"
+	groupDescriptor = "vssgp.Platform"
+	aliceDescriptor = "aad.Alice"
+	bobDescriptor   = "aad.Bob"
+	carolDescriptor = "aad.Carol"
+)
+
+// membershipURL returns the URL adding or removing a member of the test group
+func membershipURL(memberDescriptor string) string {
+	return fmt.Sprintf("https://vssps.dev.azure.com/%s/_apis/graph/memberships/%s/%s?api-version=%s", testOrg, memberDescriptor, groupDescriptor, testAPIVersion)
+}
+
+func TestDiffMembers(t *testing.T) {
+	tests := []struct {
+		name             string
+		current          []string
+		desired          []string
+		expectedToAdd    []string
+		expectedToRemove []string
+	}{
+		{
+			name:          "add to empty group",
+			desired:       []string{bobDescriptor, aliceDescriptor},
+			expectedToAdd: []string{aliceDescriptor, bobDescriptor},
+		},
+		{
+			name:             "add and remove",
+			current:          []string{carolDescriptor, aliceDescriptor},
+			desired:          []string{aliceDescriptor, bobDescriptor},
+			expectedToAdd:    []string{bobDescriptor},
+			expectedToRemove: []string{carolDescriptor},
+		},
+		{
+			name:    "duplicates and up to date",
+			current: []string{aliceDescriptor},
+			desired: []string{aliceDescriptor, aliceDescriptor},
+		},
+		{
+			name:             "descriptors are case sensitive",
+			current:          []string{"aad.alice"},
+			desired:          []string{aliceDescriptor},
+			expectedToAdd:    []string{aliceDescriptor},
+			expectedToRemove: []string{"aad.alice"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			toAdd, toRemove := diffMembers(tt.current, tt.desired)
+			if !reflect.DeepEqual(toAdd, tt.expectedToAdd) {
+				t.Errorf("toAdd = %v, want %v", toAdd, tt.expectedToAdd)
+			}
+			if !reflect.DeepEqual(toRemove, tt.expectedToRemove) {
+				t.Errorf("toRemove = %v, want %v", toRemove, tt.expectedToRemove)
+			}
+		})
+	}
+}
+
+func TestReconcileMembers(t *testing.T) {
+	mockClient := handlerstest.NewMockHTTPClient()
+	membersURL := fmt.Sprintf("https://vssps.dev.azure.com/%s/_apis/graph/memberships/%s?direction=down&api-version=%s", testOrg, groupDescriptor, testAPIVersion)
+	mockClient.SetResponse(membersURL, http.StatusOK, `{"count":2,"value":[{"memberDescriptor":"aad.Carol"},{"memberDescriptor":"aad.Alice"}]}`)
+	mockClient.SetMethodResponse("PUT", membershipURL(bobDescriptor), http.StatusOK, `{}`)
+	mockClient.SetMethodResponse("DELETE", membershipURL(carolDescriptor), http.StatusOK, ``)
+	memberships := New(handlers.NewBaseHandler(handlerstest.NewHandlerOptions(mockClient)))
+
+	if err := memberships.ReconcileMembers(testOrg, groupDescriptor, []string{aliceDescriptor, bobDescriptor}, testAPIVersion, testAuthHeader); err != nil {
+		t.Fatalf("ReconcileMembers() error = %v", err)
+	}
+
+	var requests []string
+	for _, req := range mockClient.Requests {
+		requests = append(requests, req.Method+" "+req.URL.String())
+	}
+	expected := []string{"GET " + membersURL, "PUT " + membershipURL(bobDescriptor), "DELETE " + membershipURL(carolDescriptor)}
+	if !reflect.DeepEqual(requests, expected) {
+		t.Errorf("requests = %v, want %v", requests, expected)
+	}
+}
+
+func TestLookupSubjects(t *testing.T) {
+	mockClient := handlerstest.NewMockHTTPClient()
+	lookupURL := fmt.Sprintf("https://vssps.dev.azure.com/%s/_apis/graph/subjectlookup?api-version=%s", testOrg, testAPIVersion)
+	mockClient.SetResponse(lookupURL, http.StatusOK, `{"count":1,"value":{"aad.Alice":{"descriptor":"aad.Alice","subjectKind":"user","principalName":"alice@example.com","displayName":"Alice"}}}`)
+	memberships := New(handlers.NewBaseHandler(handlerstest.NewHandlerOptions(mockClient)))
+
+	subjects, err := memberships.LookupSubjects(testOrg, []string{aliceDescriptor}, testAPIVersion, testAuthHeader)
+	if err != nil {
+		t.Fatalf("LookupSubjects() error = %v", err)
+	}
+	if subjects[aliceDescriptor].PrincipalName != "alice@example.com" {
+		t.Errorf("LookupSubjects() = %v, want the subject of %s", subjects, aliceDescriptor)
+	}
+	if body := mockClient.Bodies[0]; body != `{"lookupKeys":[{"descriptor":"aad.Alice"}]}` {
+		t.Errorf("lookup body = %s", body)
+	}
+
+	// No request is sent without descriptors
+	subjects, err = memberships.LookupSubjects(testOrg, nil, testAPIVersion, testAuthHeader)
+	if err != nil || len(subjects) != 0 || mockClient.RequestCount() != 1 {
+		t.Errorf("LookupSubjects(nil) = %v, %v after %d requests, want an empty map without request", subjects, err, mockClient.RequestCount())
+	}
+}
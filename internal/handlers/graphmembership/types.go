@@ -0,0 +1,40 @@
+package graphmembership
+
+// GraphMembership represents a membership of the graph
+type GraphMembership struct {
+	ContainerDescriptor string `json:"containerDescriptor"`
+	MemberDescriptor    string `json:"memberDescriptor"`
+}
+
+// GraphMembershipList represents the response from:
+// GET https://vssps.dev.azure.com/{organization}/_apis/graph/memberships/{subjectDescriptor}?direction=down
+type GraphMembershipList struct {
+	Count int               `json:"count"`
+	Value []GraphMembership `json:"value"`
+}
+
+// GraphSubject represents a user or a group of the graph
+type GraphSubject struct {
+	Descriptor    string `json:"descriptor"`
+	SubjectKind   string `json:"subjectKind"`
+	PrincipalName string `json:"principalName"`
+	MailAddress   string `json:"mailAddress"`
+	DisplayName   string `json:"displayName"`
+}
+
+// GraphSubjectLookup represents the request body of:
+// POST https://vssps.dev.azure.com/{organization}/_apis/graph/subjectlookup
+type GraphSubjectLookup struct {
+	LookupKeys []GraphSubjectLookupKey `json:"lookupKeys"`
+}
+
+// GraphSubjectLookupKey represents a subject to look up
+type GraphSubjectLookupKey struct {
+	Descriptor string `json:"descriptor"`
+}
+
+// GraphSubjectLookupResult represents the response of the subject lookup, keyed by descriptor
+type GraphSubjectLookupResult struct {
+	Count int                     `json:"count"`
+	Value map[string]GraphSubject `json:"value"`
+}
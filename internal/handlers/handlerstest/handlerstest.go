@@ -0,0 +1,158 @@
+// Package handlerstest provides the mock of the Azure DevOps API used by the tests of the handlers
+package handlerstest
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers"
+	"github.com/rs/zerolog"
+)
+
+// MockHTTPClient implements http.Client's Do method for testing
+// this is needed for external API calls in the handler
+// it allows us to simulate responses and errors without making real HTTP requests (e.g., for Azure DevOps API calls).
+// The requests and their bodies are recorded for verification
+type MockHTTPClient struct {
+	Requests []*http.Request
+	Bodies   []string
+
+	responses map[string]*http.Response
+	sequences map[string][]MockResponse
+	headers   map[string]http.Header
+	errors    map[string]error
+}
+
+// MockResponse is a response of a sequence returned for the same URL (e.g., an operation polled several times)
+type MockResponse struct {
+	StatusCode int
+	Body       string
+}
+
+// NewMockHTTPClient creates a new instance of MockHTTPClient
+// with empty maps for responses and errors
+// and an empty slice for requests.
+func NewMockHTTPClient() *MockHTTPClient {
+	return &MockHTTPClient{
+		Requests:  make([]*http.Request, 0),
+		responses: make(map[string]*http.Response),
+		sequences: make(map[string][]MockResponse),
+		headers:   make(map[string]http.Header),
+		errors:    make(map[string]error),
+	}
+}
+
+// Do implements the http.Client Do method for MockHTTPClient.
+// It simulates sending an HTTP request and returns a response or an error
+func (m *MockHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	// Store the request and its body for verification, the body stays readable from the request
+	m.Requests = append(m.Requests, req)
+	body := ""
+	if req.Body != nil {
+		bodyBytes, _ := io.ReadAll(req.Body)
+		req.Body = io.NopCloser(bytes.NewReader(bodyBytes))
+		body = string(bodyBytes)
+	}
+	m.Bodies = append(m.Bodies, body)
+
+	key := req.URL.String()
+
+	// Check if there's an error configured for this URL
+	if err, exists := m.errors[key]; exists {
+		return nil, err
+	}
+
+	// Return the next response of a configured sequence, the last one is returned once the others are consumed
+	for _, sequenceKey := range []string{req.Method + " " + key, key} {
+		if sequence := m.sequences[sequenceKey]; len(sequence) > 0 {
+			if len(sequence) > 1 {
+				m.sequences[sequenceKey] = sequence[1:]
+			}
+			return &http.Response{
+				StatusCode: sequence[0].StatusCode,
+				Body:       io.NopCloser(strings.NewReader(sequence[0].Body)),
+				Header:     m.responseHeader(req.Method, key),
+			}, nil
+		}
+	}
+
+	// Return configured response (method specific first) or default 404
+	if resp, exists := m.responses[req.Method+" "+key]; exists {
+		resp.Header = m.responseHeader(req.Method, key)
+		return resp, nil
+	}
+	if resp, exists := m.responses[key]; exists {
+		resp.Header = m.responseHeader(req.Method, key)
+		return resp, nil
+	}
+
+	// Default response
+	return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Body:       io.NopCloser(strings.NewReader(`{"message": "Not Found"}`)),
+			Header:     make(http.Header),
+		},
+		nil
+}
+
+// SetResponse allows setting a predefined response for a specific URL
+func (m *MockHTTPClient) SetResponse(url string, statusCode int, body string) {
+	m.responses[url] = &http.Response{
+		StatusCode: statusCode,
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Header:     make(http.Header),
+	}
+}
+
+// SetMethodResponse allows setting a predefined response for a specific method and URL
+// (e.g., GET and PATCH on the same URL)
+func (m *MockHTTPClient) SetMethodResponse(method, url string, statusCode int, body string) {
+	m.SetResponse(method+" "+url, statusCode, body)
+}
+
+// SetResponseSequence allows setting the responses returned one after the other for a specific URL
+// (e.g., "GET <url>" for a method specific sequence)
+func (m *MockHTTPClient) SetResponseSequence(url string, responses ...MockResponse) {
+	m.sequences[url] = responses
+}
+
+// responseHeader returns the headers configured for the method and URL (method specific first)
+func (m *MockHTTPClient) responseHeader(method, url string) http.Header {
+	if header, exists := m.headers[method+" "+url]; exists {
+		return header
+	}
+	if header, exists := m.headers[url]; exists {
+		return header
+	}
+	return make(http.Header)
+}
+
+// SetResponseHeader allows setting a header of the predefined response for a specific URL
+// (e.g., the continuation token of a page, "PUT <url>" for a method specific header)
+func (m *MockHTTPClient) SetResponseHeader(url, key, value string) {
+	if m.headers[url] == nil {
+		m.headers[url] = make(http.Header)
+	}
+	m.headers[url].Set(key, value)
+}
+
+// SetError allows making the requests to a specific URL fail (e.g., a network error)
+func (m *MockHTTPClient) SetError(url string, err error) {
+	m.errors[url] = err
+}
+
+// RequestCount returns the number of requests received by the mock
+func (m *MockHTTPClient) RequestCount() int {
+	return len(m.Requests)
+}
+
+// NewHandlerOptions returns the options of a handler using the given client and a logger discarding the logs
+func NewHandlerOptions(client handlers.HTTPClient) handlers.HandlerOptions {
+	logger := zerolog.New(io.Discard).With().Timestamp().Logger()
+	return handlers.HandlerOptions{
+		Client: client,
+		Log:    &logger,
+	}
+}
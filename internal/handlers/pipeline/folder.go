@@ -0,0 +1,493 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	neturl "net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers"
+)
+
+// Handler constructors
+func ListPipelineFolders(opts handlers.HandlerOptions) handlers.Handler {
+	return &listFoldersHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func GetPipelineFolder(opts handlers.HandlerOptions) handlers.Handler {
+	return &getFolderHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func PostPipelineFolder(opts handlers.HandlerOptions) handlers.Handler {
+	return &postFolderHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func DeletePipelineFolder(opts handlers.HandlerOptions) handlers.Handler {
+	return &deleteFolderHandler{baseHandler: newBaseHandler(opts)}
+}
+
+// Interface compliance verification
+var _ handlers.Handler = &listFoldersHandler{}
+var _ handlers.Handler = &getFolderHandler{}
+var _ handlers.Handler = &postFolderHandler{}
+var _ handlers.Handler = &deleteFolderHandler{}
+
+// Handler types embedding the base handler
+type listFoldersHandler struct {
+	*baseHandler
+}
+
+type getFolderHandler struct {
+	*baseHandler
+}
+
+type postFolderHandler struct {
+	*baseHandler
+}
+
+type deleteFolderHandler struct {
+	*baseHandler
+}
+
+// listFolders returns the folder identified by the path and all its descendants
+// The path must be in the Azure DevOps format (e.g., '\folder\subfolder')
+func (h *baseHandler) listFolders(organization, project, azurePath, apiVersion, authHeader string) ([]Folder, int, error) {
+	folderURL := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/build/folders/%s?api-version=%s", organization, project, neturl.PathEscape(azurePath), apiVersion)
+
+	resp, err := h.makeAzuredevopsRequest("GET", folderURL, authHeader, nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list folders: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read list folders response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		h.Log.Printf("Azure DevOps API returned a non-200 status for folders list: %d. Body: %s", resp.StatusCode, string(body))
+		return nil, resp.StatusCode, fmt.Errorf("azure devops API returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var folders FolderList
+	if err := json.Unmarshal(body, &folders); err != nil {
+		return nil, resp.StatusCode, fmt.Errorf("failed to unmarshal list folders response: %w", err)
+	}
+
+	return folders.Value, resp.StatusCode, nil
+}
+
+// findFolder looks for the folder with the given path (any format) in a list of folders
+func findFolder(folders []Folder, folderPath string) *Folder {
+	normalizedPath := normalizeFolderFromAzure(folderPath)
+	for i := range folders {
+		if strings.EqualFold(normalizeFolderFromAzure(folders[i].Path), normalizedPath) {
+			return &folders[i]
+		}
+	}
+	return nil
+}
+
+// subfolders returns the descendants of a folder in a list of folders (all the other folders for the root folder)
+// The list returned by Azure DevOps matches the path by prefix, so it may also contain siblings (e.g., '\foo-old' for '\foo')
+func subfolders(folders []Folder, folderPath string) []Folder {
+	prefix := strings.ToLower(normalizeFolderFromAzure(folderPath))
+	if prefix != "" {
+		prefix += "/"
+	}
+	descendants := []Folder{}
+	for _, folder := range folders {
+		path := strings.ToLower(normalizeFolderFromAzure(folder.Path))
+		if path != "" && strings.HasPrefix(path, prefix) {
+			descendants = append(descendants, folder)
+		}
+	}
+	return descendants
+}
+
+// writeFolderResponse normalizes the folder path and writes the folder as JSON
+func (h *baseHandler) writeFolderResponse(w http.ResponseWriter, statusCode int, folder Folder) {
+	folder.Path = normalizeFolderFromAzure(folder.Path)
+
+	responseBody, err := json.Marshal(FolderResponse(folder))
+	if err != nil {
+		h.Log.Printf("Failed to marshal folder response: %v", err)
+		h.writeErrorResponse(w, http.StatusInternalServerError, "Failed to marshal response")
+		return
+	}
+
+	h.writeJSONResponse(w, statusCode, responseBody)
+}
+
+// LIST handler implementation
+// @Summary List pipeline folders
+// @Description List the subfolders of a pipeline folder (all the folders by default). Paths are returned normalized (e.g., 'folder/subfolder') and sorted.
+// @ID list-pipeline-folders
+// @Param organization path string true "Organization name"
+// @Param project path string true "Project name or ID"
+// @Param path query string false "Return only the subfolders of this folder (e.g., folder/subfolder)"
+// @Param api-version query string true "API version (e.g., 7.2-preview.2)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Produce json
+// @Success 200 {object} ListFoldersResponse "List of folders"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{project}/pipelines/folders [get]
+func (h *listFoldersHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	project := r.PathValue("project")
+	folderPath := r.URL.Query().Get("path")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	// Validate required parameters
+	if !h.validateBasicParams(w, organization, project, apiVersion) {
+		return
+	}
+
+	// This single check handles missing headers, incorrect formats, and empty credentials.
+	username, password, ok := r.BasicAuth()
+	if !ok || username == "" || password == "" {
+		h.writeErrorResponse(w, http.StatusUnauthorized, "Request rejected due to missing or invalid Basic authentication")
+		return
+	}
+
+	azurePath := normalizeFolderToAzure(folderPath)
+	h.Log.Printf("Listing pipeline folders under '%s' for organization %s and project %s", azurePath, organization, project)
+
+	folders, statusCode, err := h.listFolders(organization, project, azurePath, apiVersion, authHeader)
+	if err != nil {
+		if statusCode == http.StatusNotFound {
+			h.writeErrorResponse(w, http.StatusNotFound, fmt.Sprintf("Folder '%s' not found", normalizeFolderFromAzure(azurePath)))
+			return
+		}
+		h.writeErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Error listing folders: %v", err))
+		return
+	}
+	if normalizeFolderFromAzure(azurePath) != "" && findFolder(folders, azurePath) == nil {
+		h.writeErrorResponse(w, http.StatusNotFound, fmt.Sprintf("Folder '%s' not found", normalizeFolderFromAzure(azurePath)))
+		return
+	}
+
+	response := ListFoldersResponse{Value: []FolderResponse{}}
+	for _, folder := range subfolders(folders, azurePath) {
+		folder.Path = normalizeFolderFromAzure(folder.Path)
+		response.Value = append(response.Value, FolderResponse(folder))
+	}
+	sort.Slice(response.Value, func(i, j int) bool {
+		return strings.ToLower(response.Value[i].Path) < strings.ToLower(response.Value[j].Path)
+	})
+	response.Count = len(response.Value)
+
+	responseBody, err := json.Marshal(response)
+	if err != nil {
+		h.Log.Printf("Failed to marshal folders response: %v", err)
+		h.writeErrorResponse(w, http.StatusInternalServerError, "Failed to marshal response")
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, responseBody)
+	h.Log.Printf("Successfully listed %d pipeline folders under '%s'", response.Count, azurePath)
+}
+
+// GET handler implementation
+// @Summary Get a pipeline folder
+// @Description Get a pipeline folder by path. The path is returned normalized (e.g., 'folder/subfolder').
+// @ID get-pipeline-folder
+// @Param organization path string true "Organization name"
+// @Param project path string true "Project name or ID"
+// @Param path path string true "Folder path (e.g., folder/subfolder)"
+// @Param api-version query string true "API version (e.g., 7.2-preview.2)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Produce json
+// @Success 200 {object} FolderResponse "Folder details"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{project}/pipelines/folders/{path} [get]
+func (h *getFolderHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	project := r.PathValue("project")
+	folderPath := r.PathValue("path")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	// Validate required parameters
+	if !h.validateBasicParams(w, organization, project, apiVersion) {
+		return
+	}
+	if normalizeFolderFromAzure(folderPath) == "" {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Folder path is required")
+		return
+	}
+
+	// This single check handles missing headers, incorrect formats, and empty credentials.
+	username, password, ok := r.BasicAuth()
+	if !ok || username == "" || password == "" {
+		h.writeErrorResponse(w, http.StatusUnauthorized, "Request rejected due to missing or invalid Basic authentication")
+		return
+	}
+
+	h.Log.Printf("Getting pipeline folder '%s' for organization %s and project %s", folderPath, organization, project)
+
+	folders, statusCode, err := h.listFolders(organization, project, normalizeFolderToAzure(folderPath), apiVersion, authHeader)
+	if err != nil {
+		if statusCode == http.StatusNotFound {
+			h.writeErrorResponse(w, http.StatusNotFound, fmt.Sprintf("Folder '%s' not found", normalizeFolderFromAzure(folderPath)))
+			return
+		}
+		h.writeErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Error getting folder: %v", err))
+		return
+	}
+
+	folder := findFolder(folders, folderPath)
+	if folder == nil {
+		h.writeErrorResponse(w, http.StatusNotFound, fmt.Sprintf("Folder '%s' not found", normalizeFolderFromAzure(folderPath)))
+		return
+	}
+
+	h.writeFolderResponse(w, http.StatusOK, *folder)
+	h.Log.Printf("Successfully retrieved pipeline folder '%s'", folder.Path)
+}
+
+// POST handler implementation
+// @Summary Create a pipeline folder
+// @Description Create a pipeline folder. The path accepts both '/' and '\' as separators and is returned normalized (e.g., 'folder/subfolder').
+// @ID post-pipeline-folder
+// @Param organization path string true "Organization name"
+// @Param project path string true "Project name or ID"
+// @Param api-version query string true "API version (e.g., 7.2-preview.2)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Param folderCreate body CreateFolderRequest true "Folder creation request body"
+// @Accept json
+// @Produce json
+// @Success 201 {object} FolderResponse "Folder details"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{project}/pipelines/folders [post]
+func (h *postFolderHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	project := r.PathValue("project")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	// Validate required parameters
+	if !h.validateBasicParams(w, organization, project, apiVersion) {
+		return
+	}
+
+	// This single check handles missing headers, incorrect formats, and empty credentials.
+	username, password, ok := r.BasicAuth()
+	if !ok || username == "" || password == "" {
+		h.writeErrorResponse(w, http.StatusUnauthorized, "Request rejected due to missing or invalid Basic authentication")
+		return
+	}
+
+	// Read and parse the request body
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Failed to read request body")
+		return
+	}
+
+	var createRequest CreateFolderRequest
+	if err := json.Unmarshal(body, &createRequest); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid JSON in request body")
+		return
+	}
+
+	// Validate required fields
+	if normalizeFolderFromAzure(createRequest.Path) == "" {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Folder path is required")
+		return
+	}
+
+	azurePath := normalizeFolderToAzure(createRequest.Path)
+	h.Log.Printf("Creating pipeline folder '%s' for organization %s and project %s", azurePath, organization, project)
+
+	// Create the folder using the Azure DevOps format for the path
+	folderURL := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/build/folders?path=%s&api-version=%s", organization, project, neturl.QueryEscape(azurePath), apiVersion)
+
+	requestBody, err := json.Marshal(Folder{Path: azurePath, Description: createRequest.Description})
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusInternalServerError, "Failed to marshal create request")
+		return
+	}
+
+	resp, err := h.makeAzuredevopsRequest("PUT", folderURL, authHeader, requestBody)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Failed to create folder: %v", err))
+		return
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Failed to read create folder response: %v", err))
+		return
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		h.Log.Printf("Azure DevOps API returned a non-200 status for folder creation: %d. Body: %s", resp.StatusCode, string(respBody))
+		h.writeJSONResponse(w, resp.StatusCode, respBody)
+		return
+	}
+
+	var createdFolder Folder
+	if err := json.Unmarshal(respBody, &createdFolder); err != nil {
+		h.writeErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Failed to unmarshal create folder response: %v", err))
+		return
+	}
+
+	h.writeFolderResponse(w, http.StatusCreated, createdFolder)
+	h.Log.Printf("Successfully created pipeline folder '%s'", azurePath)
+}
+
+// DELETE handler implementation
+// @Summary Delete a pipeline folder
+// @Description Delete a pipeline folder. Azure DevOps also deletes the pipelines and subfolders it contains, unless refuseIfNotEmpty is set.
+// @ID delete-pipeline-folder
+// @Param organization path string true "Organization name"
+// @Param project path string true "Project name or ID"
+// @Param path path string true "Folder path (e.g., folder/subfolder)"
+// @Param api-version query string true "API version (e.g., 7.2-preview.2)"
+// @Param refuseIfNotEmpty query bool false "Refuse to delete the folder if it contains pipelines or subfolders"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Success 204 "No Content - Folder deleted successfully"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 409 "Conflict - Folder is not empty"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{project}/pipelines/folders/{path} [delete]
+func (h *deleteFolderHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	project := r.PathValue("project")
+	folderPath := r.PathValue("path")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	// Validate required parameters
+	if !h.validateBasicParams(w, organization, project, apiVersion) {
+		return
+	}
+	if normalizeFolderFromAzure(folderPath) == "" {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Folder path is required")
+		return
+	}
+
+	refuseIfNotEmpty := false
+	if value := r.URL.Query().Get("refuseIfNotEmpty"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			h.writeErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid refuseIfNotEmpty value: %s", value))
+			return
+		}
+		refuseIfNotEmpty = parsed
+	}
+
+	// This single check handles missing headers, incorrect formats, and empty credentials.
+	username, password, ok := r.BasicAuth()
+	if !ok || username == "" || password == "" {
+		h.writeErrorResponse(w, http.StatusUnauthorized, "Request rejected due to missing or invalid Basic authentication")
+		return
+	}
+
+	azurePath := normalizeFolderToAzure(folderPath)
+	h.Log.Printf("Deleting pipeline folder '%s' for organization %s and project %s", azurePath, organization, project)
+
+	// Check that the folder exists (and is empty, if requested) before deleting it
+	folders, statusCode, err := h.listFolders(organization, project, azurePath, apiVersion, authHeader)
+	if err != nil && statusCode != http.StatusNotFound {
+		h.writeErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Error getting folder: %v", err))
+		return
+	}
+	if findFolder(folders, azurePath) == nil {
+		h.writeErrorResponse(w, http.StatusNotFound, fmt.Sprintf("Folder '%s' not found", normalizeFolderFromAzure(azurePath)))
+		return
+	}
+
+	if refuseIfNotEmpty {
+		if count := len(subfolders(folders, azurePath)); count > 0 {
+			h.writeErrorResponse(w, http.StatusConflict, fmt.Sprintf("Folder '%s' is not empty: it contains %d subfolder(s)", normalizeFolderFromAzure(azurePath), count))
+			return
+		}
+
+		count, err := h.countPipelinesInFolder(organization, project, azurePath, authHeader)
+		if err != nil {
+			h.writeErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Error checking pipelines in folder: %v", err))
+			return
+		}
+		if count > 0 {
+			h.writeErrorResponse(w, http.StatusConflict, fmt.Sprintf("Folder '%s' is not empty: it contains %d pipeline(s)", normalizeFolderFromAzure(azurePath), count))
+			return
+		}
+	}
+
+	folderURL := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/build/folders?path=%s&api-version=%s", organization, project, neturl.QueryEscape(azurePath), apiVersion)
+
+	resp, err := h.makeAzuredevopsRequest("DELETE", folderURL, authHeader, nil)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Error deleting folder: %v", err))
+		return
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Failed to read delete folder response: %v", err))
+		return
+	}
+
+	if resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusNoContent {
+		h.Log.Printf("Successfully deleted pipeline folder '%s'", azurePath)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		h.writeErrorResponse(w, http.StatusNotFound, fmt.Sprintf("Folder '%s' not found", normalizeFolderFromAzure(azurePath)))
+		return
+	}
+
+	h.Log.Printf("Azure DevOps API returned status %d for folder deletion: %s", resp.StatusCode, string(body))
+	h.writeJSONResponse(w, resp.StatusCode, body)
+}
+
+// countPipelinesInFolder returns the number of build definitions directly contained in a folder
+func (h *deleteFolderHandler) countPipelinesInFolder(organization, project, azurePath, authHeader string) (int, error) {
+	definitionsURL := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/build/definitions?path=%s&api-version=%s", organization, project, neturl.QueryEscape(azurePath), h.getBuildDefinitionsAPIVersion())
+
+	resp, err := h.makeAzuredevopsRequest("GET", definitionsURL, authHeader, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list build definitions: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read build definitions response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("azure devops API returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var definitions struct {
+		Value []json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal(body, &definitions); err != nil {
+		return 0, fmt.Errorf("failed to unmarshal build definitions response: %w", err)
+	}
+
+	return len(definitions.Value), nil
+}
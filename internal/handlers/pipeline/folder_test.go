@@ -0,0 +1,457 @@
+package pipeline
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers"
+	"github.com/rs/zerolog"
+)
+
+// createTestFolderBaseHandler creates a base handler instance for testing with a mock client
+func createTestFolderBaseHandler(mockClient *mockHTTPClient) *baseHandler {
+	logger := zerolog.New(io.Discard).With().Timestamp().Logger()
+	return &baseHandler{
+		HandlerOptions: handlers.HandlerOptions{
+			Client: mockClient,
+			Log:    &logger,
+		},
+	}
+}
+
+const testFolderAPIVersion = "7.2-preview.2"
+
+var (
+	folderListURL    = fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/build/folders/%%5Cparent%%5Cchild?api-version=%s", testOrg, testProject, testFolderAPIVersion)
+	folderPutURL     = fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/build/folders?path=%%5Cparent%%5Cchild&api-version=%s", testOrg, testProject, testFolderAPIVersion)
+	folderDeleteURL  = folderPutURL
+	folderDefListURL = fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/build/definitions?path=%%5Cparent%%5Cchild&api-version=%s", testOrg, testProject, buildAPIVersion)
+
+	validFolderResp = `{
+		"path": "\\parent\\child",
+		"description": "test folder",
+		"createdOn": "2024-01-01T00:00:00Z",
+		"project": {"id": "project-id", "name": "testproject"}
+	}`
+
+	validFolderListResp = `{
+		"count": 1,
+		"value": [` + validFolderResp + `]
+	}`
+
+	validFolderListWithChildrenResp = `{
+		"count": 2,
+		"value": [
+			` + validFolderResp + `,
+			{"path": "\\parent\\child\\nested"}
+		]
+	}`
+)
+
+func TestNormalizeFolder(t *testing.T) {
+	tests := []struct {
+		input     string
+		wantPlug  string
+		wantAzure string
+	}{
+		{"", "", "\\"},
+		{"\\", "", "\\"},
+		{"\\test-folder", "test-folder", "\\test-folder"},
+		{"test-folder", "test-folder", "\\test-folder"},
+		{"\\parent\\child", "parent/child", "\\parent\\child"},
+		{"/parent/child/", "parent/child", "\\parent\\child"},
+		{"parent\\\\child//nested", "parent/child/nested", "\\parent\\child\\nested"},
+		{"/parent\\child/", "parent/child", "\\parent\\child"},
+	}
+
+	for _, tt := range tests {
+		if got := normalizeFolderFromAzure(tt.input); got != tt.wantPlug {
+			t.Errorf("normalizeFolderFromAzure(%q) = %q, want %q", tt.input, got, tt.wantPlug)
+		}
+		if got := normalizeFolderToAzure(tt.input); got != tt.wantAzure {
+			t.Errorf("normalizeFolderToAzure(%q) = %q, want %q", tt.input, got, tt.wantAzure)
+		}
+	}
+}
+
+func TestListFoldersHandler_ServeHTTP(t *testing.T) {
+	folderRootListURL := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/build/folders/%%5C?api-version=%s", testOrg, testProject, testFolderAPIVersion)
+
+	tests := []struct {
+		name                 string
+		path                 string
+		authHeader           string
+		setupMock            func(*mockHTTPClient)
+		expectedStatus       int
+		expectedBody         string
+		expectedBodyContains string
+		expectedRequestCount int
+	}{
+		{
+			name:       "subfolders sorted without the folder itself and its siblings",
+			path:       "parent\\child/",
+			authHeader: testAuthHeader,
+			setupMock: func(mockClient *mockHTTPClient) {
+				mockClient.setResponse(folderListURL, http.StatusOK, `{"count": 4, "value": [{"path": "\\parent\\child"}, {"path": "\\parent\\child\\zeta"}, {"path": "\\parent\\child-old"}, {"path": "\\parent\\child\\Nested"}]}`)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBody:         `{"count":2,"value":[{"path":"parent/child/Nested"},{"path":"parent/child/zeta"}]}`,
+			expectedRequestCount: 1,
+		},
+		{
+			name:       "all the folders without path",
+			path:       "",
+			authHeader: testAuthHeader,
+			setupMock: func(mockClient *mockHTTPClient) {
+				mockClient.setResponse(folderRootListURL, http.StatusOK, `{"count": 3, "value": [{"path": "\\"}, {"path": "\\b"}, {"path": "\\a\\c"}]}`)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBody:         `{"count":2,"value":[{"path":"a/c"},{"path":"b"}]}`,
+			expectedRequestCount: 1,
+		},
+		{
+			name:       "folder not in list",
+			path:       "parent/child",
+			authHeader: testAuthHeader,
+			setupMock: func(mockClient *mockHTTPClient) {
+				mockClient.setResponse(folderListURL, http.StatusOK, `{"count": 1, "value": [{"path": "\\parent\\child-old"}]}`)
+			},
+			expectedStatus:       http.StatusNotFound,
+			expectedBodyContains: "Folder 'parent/child' not found",
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "missing authorization header",
+			path:                 "parent/child",
+			authHeader:           "",
+			expectedStatus:       http.StatusUnauthorized,
+			expectedBodyContains: "Request rejected due to missing or invalid Basic authentication",
+			expectedRequestCount: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := newMockHTTPClient()
+			if tt.setupMock != nil {
+				tt.setupMock(mockClient)
+			}
+
+			handler := &listFoldersHandler{baseHandler: createTestFolderBaseHandler(mockClient)}
+
+			req := httptest.NewRequest("GET", fmt.Sprintf("/api/placeholder?api-version=%s&path=%s", testFolderAPIVersion, url.QueryEscape(tt.path)), nil)
+			req.SetPathValue("organization", testOrg)
+			req.SetPathValue("project", testProject)
+			if tt.authHeader != "" {
+				req.Header.Set("Authorization", tt.authHeader)
+				req.SetBasicAuth(testUsername, testPassword)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, tt.expectedStatus)
+			}
+			if tt.expectedBody != "" && rr.Body.String() != tt.expectedBody {
+				t.Errorf("handler returned unexpected body.\nGot: %s\nWant: %s", rr.Body.String(), tt.expectedBody)
+			}
+			if !strings.Contains(rr.Body.String(), tt.expectedBodyContains) {
+				t.Errorf("handler response body does not contain expected content.\nGot: %s\nWant to contain: %s", rr.Body.String(), tt.expectedBodyContains)
+			}
+			if mockClient.getRequestCount() != tt.expectedRequestCount {
+				t.Errorf("expected %d requests, got %d", tt.expectedRequestCount, mockClient.getRequestCount())
+			}
+		})
+	}
+}
+
+func TestGetFolderHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name                 string
+		path                 string
+		authHeader           string
+		setupMock            func(*mockHTTPClient)
+		expectedStatus       int
+		expectedBodyContains string
+		expectedRequestCount int
+	}{
+		{
+			name:       "successful folder retrieval with mixed separators",
+			path:       "parent\\child/",
+			authHeader: testAuthHeader,
+			setupMock: func(mockClient *mockHTTPClient) {
+				mockClient.setResponse(folderListURL, http.StatusOK, validFolderListWithChildrenResp)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `"path":"parent/child"`,
+			expectedRequestCount: 1,
+		},
+		{
+			name:       "folder not in list",
+			path:       "parent/child",
+			authHeader: testAuthHeader,
+			setupMock: func(mockClient *mockHTTPClient) {
+				mockClient.setResponse(folderListURL, http.StatusOK, `{"count": 0, "value": []}`)
+			},
+			expectedStatus:       http.StatusNotFound,
+			expectedBodyContains: "Folder 'parent/child' not found",
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "empty folder path",
+			path:                 "/",
+			authHeader:           testAuthHeader,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBodyContains: "Folder path is required",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "missing authorization header",
+			path:                 "parent/child",
+			authHeader:           "",
+			expectedStatus:       http.StatusUnauthorized,
+			expectedBodyContains: "Request rejected due to missing or invalid Basic authentication",
+			expectedRequestCount: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := newMockHTTPClient()
+			if tt.setupMock != nil {
+				tt.setupMock(mockClient)
+			}
+
+			handler := &getFolderHandler{baseHandler: createTestFolderBaseHandler(mockClient)}
+
+			req := httptest.NewRequest("GET", fmt.Sprintf("/api/placeholder?api-version=%s", testFolderAPIVersion), nil)
+			req.SetPathValue("organization", testOrg)
+			req.SetPathValue("project", testProject)
+			req.SetPathValue("path", tt.path)
+			if tt.authHeader != "" {
+				req.Header.Set("Authorization", tt.authHeader)
+				req.SetBasicAuth(testUsername, testPassword)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, tt.expectedStatus)
+			}
+			if !strings.Contains(rr.Body.String(), tt.expectedBodyContains) {
+				t.Errorf("handler response body does not contain expected content.\nGot: %s\nWant to contain: %s", rr.Body.String(), tt.expectedBodyContains)
+			}
+			if mockClient.getRequestCount() != tt.expectedRequestCount {
+				t.Errorf("expected %d requests, got %d", tt.expectedRequestCount, mockClient.getRequestCount())
+			}
+		})
+	}
+}
+
+func TestPostFolderHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name                 string
+		requestBody          string
+		setupMock            func(*mockHTTPClient)
+		expectedStatus       int
+		expectedBodyContains string
+		expectedRequestCount int
+		verifyRequests       func(t *testing.T, mockClient *mockHTTPClient)
+	}{
+		{
+			name:        "successful folder creation",
+			requestBody: `{"path": "/parent/child/", "description": "test folder"}`,
+			setupMock: func(mockClient *mockHTTPClient) {
+				mockClient.setResponse(folderPutURL, http.StatusOK, validFolderResp)
+			},
+			expectedStatus:       http.StatusCreated,
+			expectedBodyContains: `"path":"parent/child"`,
+			expectedRequestCount: 1,
+			verifyRequests: func(t *testing.T, mockClient *mockHTTPClient) {
+				req := mockClient.getLastRequest()
+				if req.Method != "PUT" {
+					t.Errorf("Request Method = %s, want PUT", req.Method)
+				}
+				body, _ := io.ReadAll(req.Body)
+				if !strings.Contains(string(body), `"path":"\\parent\\child"`) {
+					t.Errorf("Request body does not contain normalized path. Got: %s", string(body))
+				}
+			},
+		},
+		{
+			name:                 "missing path",
+			requestBody:          `{"description": "test folder"}`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBodyContains: "Folder path is required",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "invalid json",
+			requestBody:          `{"path": `,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBodyContains: "Invalid JSON in request body",
+			expectedRequestCount: 0,
+		},
+		{
+			name:        "azure devops error is forwarded",
+			requestBody: `{"path": "parent/child"}`,
+			setupMock: func(mockClient *mockHTTPClient) {
+				mockClient.setResponse(folderPutURL, http.StatusConflict, `{"message": "folder already exists"}`)
+			},
+			expectedStatus:       http.StatusConflict,
+			expectedBodyContains: "folder already exists",
+			expectedRequestCount: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := newMockHTTPClient()
+			if tt.setupMock != nil {
+				tt.setupMock(mockClient)
+			}
+
+			handler := &postFolderHandler{baseHandler: createTestFolderBaseHandler(mockClient)}
+
+			req := httptest.NewRequest("POST", fmt.Sprintf("/api/placeholder?api-version=%s", testFolderAPIVersion), strings.NewReader(tt.requestBody))
+			req.SetPathValue("organization", testOrg)
+			req.SetPathValue("project", testProject)
+			req.Header.Set("Authorization", testAuthHeader)
+			req.SetBasicAuth(testUsername, testPassword)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, tt.expectedStatus)
+			}
+			if !strings.Contains(rr.Body.String(), tt.expectedBodyContains) {
+				t.Errorf("handler response body does not contain expected content.\nGot: %s\nWant to contain: %s", rr.Body.String(), tt.expectedBodyContains)
+			}
+			if mockClient.getRequestCount() != tt.expectedRequestCount {
+				t.Errorf("expected %d requests, got %d", tt.expectedRequestCount, mockClient.getRequestCount())
+			}
+			if tt.verifyRequests != nil {
+				tt.verifyRequests(t, mockClient)
+			}
+		})
+	}
+}
+
+func TestDeleteFolderHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name                 string
+		query                string
+		setupMock            func(*mockHTTPClient)
+		expectedStatus       int
+		expectedBodyContains string
+		expectedRequestCount int
+	}{
+		{
+			name: "successful folder deletion",
+			setupMock: func(mockClient *mockHTTPClient) {
+				mockClient.setResponse(folderListURL, http.StatusOK, validFolderListWithChildrenResp)
+				mockClient.setResponse(folderDeleteURL, http.StatusNoContent, "")
+			},
+			expectedStatus:       http.StatusNoContent,
+			expectedRequestCount: 2,
+		},
+		{
+			name:  "refuse deletion when folder has subfolders",
+			query: "&refuseIfNotEmpty=true",
+			setupMock: func(mockClient *mockHTTPClient) {
+				mockClient.setResponse(folderListURL, http.StatusOK, validFolderListWithChildrenResp)
+			},
+			expectedStatus:       http.StatusConflict,
+			expectedBodyContains: "contains 1 subfolder(s)",
+			expectedRequestCount: 1,
+		},
+		{
+			name:  "sibling folders with the same prefix do not refuse deletion",
+			query: "&refuseIfNotEmpty=true",
+			setupMock: func(mockClient *mockHTTPClient) {
+				mockClient.setResponse(folderListURL, http.StatusOK, `{"count": 3, "value": [`+validFolderResp+`, {"path": "\\parent\\child-old"}, {"path": "\\parent\\child-old\\nested"}]}`)
+				mockClient.setResponse(folderDefListURL, http.StatusOK, `{"count": 0, "value": []}`)
+				mockClient.setResponse(folderDeleteURL, http.StatusNoContent, "")
+			},
+			expectedStatus:       http.StatusNoContent,
+			expectedRequestCount: 3,
+		},
+		{
+			name:  "refuse deletion when folder has pipelines",
+			query: "&refuseIfNotEmpty=true",
+			setupMock: func(mockClient *mockHTTPClient) {
+				mockClient.setResponse(folderListURL, http.StatusOK, validFolderListResp)
+				mockClient.setResponse(folderDefListURL, http.StatusOK, `{"count": 2, "value": [{"id": 1}, {"id": 2}]}`)
+			},
+			expectedStatus:       http.StatusConflict,
+			expectedBodyContains: "contains 2 pipeline(s)",
+			expectedRequestCount: 2,
+		},
+		{
+			name:  "empty folder is deleted when refuseIfNotEmpty is set",
+			query: "&refuseIfNotEmpty=true",
+			setupMock: func(mockClient *mockHTTPClient) {
+				mockClient.setResponse(folderListURL, http.StatusOK, validFolderListResp)
+				mockClient.setResponse(folderDefListURL, http.StatusOK, `{"count": 0, "value": []}`)
+				mockClient.setResponse(folderDeleteURL, http.StatusNoContent, "")
+			},
+			expectedStatus:       http.StatusNoContent,
+			expectedRequestCount: 3,
+		},
+		{
+			name: "folder not found",
+			setupMock: func(mockClient *mockHTTPClient) {
+				mockClient.setResponse(folderListURL, http.StatusOK, `{"count": 0, "value": []}`)
+			},
+			expectedStatus:       http.StatusNotFound,
+			expectedBodyContains: "Folder 'parent/child' not found",
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "invalid refuseIfNotEmpty value",
+			query:                "&refuseIfNotEmpty=maybe",
+			expectedStatus:       http.StatusBadRequest,
+			expectedBodyContains: "Invalid refuseIfNotEmpty value",
+			expectedRequestCount: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := newMockHTTPClient()
+			if tt.setupMock != nil {
+				tt.setupMock(mockClient)
+			}
+
+			handler := &deleteFolderHandler{baseHandler: createTestFolderBaseHandler(mockClient)}
+
+			req := httptest.NewRequest("DELETE", fmt.Sprintf("/api/placeholder?api-version=%s%s", testFolderAPIVersion, tt.query), nil)
+			req.SetPathValue("organization", testOrg)
+			req.SetPathValue("project", testProject)
+			req.SetPathValue("path", "parent/child")
+			req.Header.Set("Authorization", testAuthHeader)
+			req.SetBasicAuth(testUsername, testPassword)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, tt.expectedStatus)
+			}
+			if !strings.Contains(rr.Body.String(), tt.expectedBodyContains) {
+				t.Errorf("handler response body does not contain expected content.\nGot: %s\nWant to contain: %s", rr.Body.String(), tt.expectedBodyContains)
+			}
+			if mockClient.getRequestCount() != tt.expectedRequestCount {
+				t.Errorf("expected %d requests, got %d", tt.expectedRequestCount, mockClient.getRequestCount())
+			}
+		})
+	}
+}
@@ -7,7 +7,9 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	neturl "net/url"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -15,12 +17,28 @@ import (
 )
 
 var ErrPipelineNotFound = errors.New("pipeline not found")
+var ErrRepeatedContinuationToken = errors.New("azure devops returned an already seen continuation token")
+
+// azureDevOpsError is returned when Azure DevOps answers with an unexpected status code
+// The status code and the body are kept to be forwarded to the client
+type azureDevOpsError struct {
+	StatusCode int
+	Body       []byte
+}
+
+func (e *azureDevOpsError) Error() string {
+	return fmt.Sprintf("azure devops API returned status %d: %s", e.StatusCode, string(e.Body))
+}
 
 // Handler constructors
 func GetPipeline(opts handlers.HandlerOptions) handlers.Handler {
 	return &getHandler{baseHandler: newBaseHandler(opts)}
 }
 
+func ListPipelines(opts handlers.HandlerOptions) handlers.Handler {
+	return &listHandler{baseHandler: newBaseHandler(opts)}
+}
+
 //func PostPipeline(opts handlers.HandlerOptions) handlers.Handler {
 //	return &postHandler{baseHandler: newBaseHandler(opts)}
 //}
@@ -35,6 +53,7 @@ func PutPipeline(opts handlers.HandlerOptions) handlers.Handler {
 
 // Interface compliance verification
 var _ handlers.Handler = &getHandler{}
+var _ handlers.Handler = &listHandler{}
 
 // var _ handlers.Handler = &postHandler{}
 var _ handlers.Handler = &deleteHandler{}
@@ -56,6 +75,10 @@ type getHandler struct {
 	*baseHandler
 }
 
+type listHandler struct {
+	*baseHandler
+}
+
 //type postHandler struct {
 //	*baseHandler
 //}
@@ -129,6 +152,90 @@ func (h *baseHandler) validateBasicParams(w http.ResponseWriter, organization, p
 	return true
 }
 
+// getAllPages follows the 'x-ms-continuationtoken' response header of Azure DevOps list endpoints
+// and returns the items of the 'value' array of all the pages
+func (h *baseHandler) getAllPages(url, authHeader string) ([]json.RawMessage, error) {
+	items := []json.RawMessage{}
+	seenTokens := map[string]bool{}
+	pageURL := url
+
+	for page := 1; ; page++ {
+		resp, err := h.makeAzuredevopsRequest("GET", pageURL, authHeader, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get page %d: %w", page, err)
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read page %d: %w", page, err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			h.Log.Printf("Azure DevOps API returned a non-200 status for page %d: %d. Body: %s", page, resp.StatusCode, string(body))
+			return nil, &azureDevOpsError{StatusCode: resp.StatusCode, Body: body}
+		}
+
+		var list struct {
+			Value []json.RawMessage `json:"value"`
+		}
+		if err := json.Unmarshal(body, &list); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal page %d: %w", page, err)
+		}
+		items = append(items, list.Value...)
+
+		// No continuation token means this was the last page
+		token := resp.Header.Get("x-ms-continuationtoken")
+		if token == "" {
+			break
+		}
+		// Azure DevOps returning the same token over and over would make the list incomplete, so it is reported instead of truncating
+		if seenTokens[token] {
+			h.Log.Printf("Continuation token of page %d already seen", page)
+			return nil, fmt.Errorf("%w after %d pages", ErrRepeatedContinuationToken, page)
+		}
+		seenTokens[token] = true
+
+		h.Log.Printf("Following continuation token for page %d", page+1)
+		pageURL = fmt.Sprintf("%s&continuationToken=%s", url, neturl.QueryEscape(token))
+	}
+
+	return items, nil
+}
+
+// Build definitions may require a different API version, we pass it via env variable BUILD_DEFINITIONS_API_VERSION
+func (h *baseHandler) getBuildDefinitionsAPIVersion() string {
+	apiVersion := os.Getenv("BUILD_DEFINITIONS_API_VERSION")
+	if apiVersion == "" {
+		h.Log.Print("BUILD_DEFINITIONS_API_VERSION environment variable not set, using default API version")
+		apiVersion = "7.2-preview.7" // Default Build Definition API version if not set
+	}
+	return apiVersion
+}
+
+// getPipelineTriggers reads the triggers of the build definition behind a pipeline
+// The /pipelines/{id} endpoint does not return triggers, so the build definitions endpoint is used
+func (h *baseHandler) getPipelineTriggers(organization, project, id, authHeader string) (*PipelineTriggers, error) {
+	url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/build/definitions/%s?api-version=%s", organization, project, id, h.getBuildDefinitionsAPIVersion())
+
+	resp, err := h.makeAzuredevopsRequest("GET", url, authHeader, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get build definition: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read build definition response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("azure devops API returned status %d for build definition: %s", resp.StatusCode, string(body))
+	}
+
+	return parseBuildDefinitionTriggers(body)
+}
+
 func (h *baseHandler) processPipelineResponse(body []byte) ([]byte, error) {
 
 	var finalBody []byte = body
@@ -142,11 +249,11 @@ func (h *baseHandler) processPipelineResponse(body []byte) ([]byte, error) {
 
 		// Ensure the value is a string
 		if folder, ok := value.(string); ok {
-			if strings.HasPrefix(folder, "\\") {
-				h.Log.Printf("Field 'folder' starts with '\\' (escaped backslash), modifying it")
+			// Remove the leading escaped backslash and use '/' as separator for nested folders
+			modifiedFolder := normalizeFolderFromAzure(folder)
 
-				// Remove the leading escaped backslash
-				modifiedFolder := folder[1:]
+			if modifiedFolder != folder {
+				h.Log.Printf("Field 'folder' is not normalized (e.g., starts with '\\' or uses '\\' as separator), modifying it")
 
 				// Update the field in the body
 				finalBody, err = AddFieldToBody(finalBody, "folder", modifiedFolder)
@@ -155,7 +262,7 @@ func (h *baseHandler) processPipelineResponse(body []byte) ([]byte, error) {
 				}
 				h.Log.Printf("Modified 'folder' field added to response body")
 			} else {
-				h.Log.Printf("Field 'folder' does not start with '\\' (escaped backslash), no modification needed")
+				h.Log.Printf("Field 'folder' is already normalized, no modification needed")
 			}
 		} else {
 			h.Log.Printf("Field 'folder' is not a string, skipping modification")
@@ -256,11 +363,214 @@ func (h *getHandler) getPipelineAndRespond(w http.ResponseWriter, organization,
 		return nil
 	}
 
+	// Add the triggers of the underlying build definition (not returned by the /pipelines/{id} endpoint)
+	triggers, err := h.getPipelineTriggers(organization, project, id, authHeader)
+	if err != nil {
+		h.Log.Printf("Failed to get pipeline triggers, returning pipeline without triggers: %v", err)
+	} else if withTriggers, err := AddFieldToBody(processedBody, "triggers", triggers); err != nil {
+		h.Log.Printf("Failed to add 'triggers' field to response body: %v", err)
+	} else {
+		processedBody = withTriggers
+	}
+
 	h.writeJSONResponse(w, http.StatusOK, processedBody)
 	h.Log.Printf("Successfully retrieved pipeline with ID %s", id)
 	return nil
 }
 
+// LIST handler implementation
+// @Summary List pipelines
+// @Description List all the pipelines of a project, following Azure DevOps continuation tokens. Pipelines are ordered by ID and normalized as in the GET endpoint.
+// @ID list-pipelines
+// @Param organization path string true "Organization name"
+// @Param project path string true "Project name or ID"
+// @Param api-version query string true "API version (e.g., 7.2-preview.1)"
+// @Param folder query string false "Return only pipelines in this folder (e.g., folder/subfolder)"
+// @Param includeSubfolders query bool false "Also return pipelines in subfolders of 'folder'"
+// @Param namePrefix query string false "Return only pipelines whose name starts with this prefix (case insensitive)"
+// @Param repositoryId query string false "Return only pipelines using this repository"
+// @Param repositoryType query string false "Type of the repository (e.g., azureReposGit, gitHub), default azureReposGit"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Produce json
+// @Success 200 {object} ListPipelinesResponse "List of pipelines"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 500 "Internal Server Error"
+// @Failure 502 "Bad Gateway"
+// @Router /api/{organization}/{project}/pipelines [get]
+func (h *listHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	project := r.PathValue("project")
+	query := r.URL.Query()
+	apiVersion := query.Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	// Validate required parameters
+	if !h.validateBasicParams(w, organization, project, apiVersion) {
+		return
+	}
+
+	filter := pipelineListFilter{
+		Folder:         query.Get("folder"),
+		NamePrefix:     query.Get("namePrefix"),
+		RepositoryID:   query.Get("repositoryId"),
+		RepositoryType: query.Get("repositoryType"),
+	}
+	if value := query.Get("includeSubfolders"); value != "" {
+		includeSubfolders, err := strconv.ParseBool(value)
+		if err != nil {
+			h.writeErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid includeSubfolders value: %s", value))
+			return
+		}
+		filter.IncludeSubfolders = includeSubfolders
+	}
+	if filter.RepositoryType != "" && filter.RepositoryID == "" {
+		h.writeErrorResponse(w, http.StatusBadRequest, "repositoryType requires repositoryId")
+		return
+	}
+
+	// This single check handles missing headers, incorrect formats, and empty credentials.
+	username, password, ok := r.BasicAuth()
+	if !ok || username == "" || password == "" {
+		h.writeErrorResponse(w, http.StatusUnauthorized, "Request rejected due to missing or invalid Basic authentication")
+		return
+	}
+
+	h.Log.Printf("Listing pipelines for organization %s and project %s", organization, project)
+
+	pipelines, err := h.listPipelines(organization, project, apiVersion, authHeader, filter)
+	if err != nil {
+		var apiErr *azureDevOpsError
+		if errors.As(err, &apiErr) && apiErr.StatusCode < http.StatusInternalServerError {
+			h.writeJSONResponse(w, apiErr.StatusCode, apiErr.Body)
+			return
+		}
+		if errors.Is(err, ErrRepeatedContinuationToken) {
+			h.writeErrorResponse(w, http.StatusBadGateway, fmt.Sprintf("Error listing pipelines: %v", err))
+			return
+		}
+		h.writeErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Error listing pipelines: %v", err))
+		return
+	}
+
+	responseBody, err := json.Marshal(ListPipelinesResponse{Count: len(pipelines), Value: pipelines})
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusInternalServerError, "Failed to marshal response")
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, responseBody)
+	h.Log.Printf("Successfully listed %d pipelines", len(pipelines))
+}
+
+// pipelineListFilter holds the optional filters of the list endpoint
+type pipelineListFilter struct {
+	Folder            string
+	IncludeSubfolders bool
+	NamePrefix        string
+	RepositoryID      string
+	RepositoryType    string
+}
+
+// matches reports whether a (normalized) pipeline satisfies the folder and name filters
+func (f pipelineListFilter) matches(pipeline Pipeline) bool {
+	if f.Folder != "" {
+		folder := strings.ToLower(normalizeFolderFromAzure(pipeline.Folder))
+		wanted := strings.ToLower(normalizeFolderFromAzure(f.Folder))
+		if folder != wanted && !(f.IncludeSubfolders && strings.HasPrefix(folder, wanted+"/")) {
+			return false
+		}
+	}
+	if f.NamePrefix != "" && !strings.HasPrefix(strings.ToLower(pipeline.Name), strings.ToLower(f.NamePrefix)) {
+		return false
+	}
+	return true
+}
+
+// listPipelines returns all the pipelines matching the filter, normalized and ordered by ID
+func (h *listHandler) listPipelines(organization, project, apiVersion, authHeader string, filter pipelineListFilter) ([]json.RawMessage, error) {
+	url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/pipelines?api-version=%s", organization, project, apiVersion)
+
+	items, err := h.getAllPages(url, authHeader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pipelines: %w", err)
+	}
+
+	// The pipelines list does not include the repository, so the build definitions list (which supports a repository filter) is used
+	var repositoryPipelineIDs map[int32]bool
+	if filter.RepositoryID != "" {
+		repositoryPipelineIDs, err = h.listPipelineIDsByRepository(organization, project, authHeader, filter.RepositoryID, filter.RepositoryType)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	type listedPipeline struct {
+		ID   int32
+		Body json.RawMessage
+	}
+	listed := []listedPipeline{}
+
+	for _, item := range items {
+		// Same normalization as the GET endpoint
+		processedItem, err := h.processPipelineResponse(item)
+		if err != nil {
+			h.Log.Printf("Failed to process pipeline in list, using raw item: %v", err)
+			processedItem = item
+		}
+
+		var pipeline Pipeline
+		if err := json.Unmarshal(processedItem, &pipeline); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal pipeline in list: %w", err)
+		}
+
+		if !filter.matches(pipeline) {
+			continue
+		}
+		if repositoryPipelineIDs != nil && !repositoryPipelineIDs[pipeline.ID] {
+			continue
+		}
+
+		listed = append(listed, listedPipeline{ID: pipeline.ID, Body: processedItem})
+	}
+
+	// Azure DevOps does not guarantee the order across pages, sort by ID for a stable output
+	sort.SliceStable(listed, func(i, j int) bool { return listed[i].ID < listed[j].ID })
+
+	pipelines := make([]json.RawMessage, 0, len(listed))
+	for _, p := range listed {
+		pipelines = append(pipelines, p.Body)
+	}
+	return pipelines, nil
+}
+
+// listPipelineIDsByRepository returns the IDs of the build definitions using the given repository
+func (h *listHandler) listPipelineIDsByRepository(organization, project, authHeader, repositoryID, repositoryType string) (map[int32]bool, error) {
+	if repositoryType == "" {
+		repositoryType = RepositoryTypeAzureReposGit
+	}
+
+	url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/build/definitions?repositoryId=%s&repositoryType=%s&api-version=%s",
+		organization, project, neturl.QueryEscape(repositoryID), neturl.QueryEscape(normalizeRepoTypeToAzure(repositoryType)), h.getBuildDefinitionsAPIVersion())
+
+	items, err := h.getAllPages(url, authHeader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list build definitions for repository %s: %w", repositoryID, err)
+	}
+
+	ids := map[int32]bool{}
+	for _, item := range items {
+		var definition struct {
+			ID int32 `json:"id"`
+		}
+		if err := json.Unmarshal(item, &definition); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal build definition in list: %w", err)
+		}
+		ids[definition.ID] = true
+	}
+	return ids, nil
+}
+
 // Implemented but not currently used by the RestDefinition
 // POST handler implementation
 // @Summary Create a new Pipeline
@@ -403,11 +713,7 @@ func (h *deleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	project := r.PathValue("project")
 	id := r.PathValue("id")
 
-	apiVersion := os.Getenv("BUILD_DEFINITIONS_API_VERSION")
-	if apiVersion == "" {
-		h.Log.Print("BUILD_DEFINITIONS_API_VERSION environment variable not set, using default API version")
-		apiVersion = "7.2-preview.7" // Default Build Definition API version if not set
-	}
+	apiVersion := h.getBuildDefinitionsAPIVersion()
 
 	authHeader := r.Header.Get("Authorization")
 
@@ -491,11 +797,7 @@ func (h *putHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	project := r.PathValue("project")
 	id := r.PathValue("id")
 
-	apiVersion := os.Getenv("BUILD_DEFINITIONS_API_VERSION")
-	if apiVersion == "" {
-		h.Log.Print("BUILD_DEFINITIONS_API_VERSION environment variable not set, using default API version")
-		apiVersion = "7.2-preview.7" // Default Build Definition API version if not set
-	}
+	apiVersion := h.getBuildDefinitionsAPIVersion()
 
 	authHeader := r.Header.Get("Authorization")
 
@@ -542,21 +844,33 @@ func (h *putHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		pipelineID = int32(idInt)
 	}
 
+	// Validate the configuration according to its type (yaml or designer) and to the repository type
+	if err := validatePipelineConfiguration(updateRequest.Configuration); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid pipeline configuration: %v", err))
+		return
+	}
+
+	process, queue := mapConfigurationToAzure(updateRequest.Configuration)
+
 	// create the object BuildDefinitionMinimal to be used as request body  for the PUT request to Azure DevOps API
 	buildDefinitionMinimal := &BuildDefinitionMinimal{
-		Name:     updateRequest.Name,
-		Path:     updateRequest.Folder,
-		ID:       pipelineID,
-		Revision: updateRequest.Revision,
-		Repository: &BuildRepository{
-			ID:   updateRequest.Configuration.Repository.ID,
-			Type: normalizeRepoTypeToAzure(updateRequest.Configuration.Repository.Type),
-		},
-		Type: updateRequest.Configuration.Type,
-		Process: &Process{
-			YAMLFilename: updateRequest.Configuration.Path,
-		},
+		Name:       updateRequest.Name,
+		Path:       normalizeFolderToAzure(updateRequest.Folder),
+		ID:         pipelineID,
+		Revision:   updateRequest.Revision,
+		Repository: mapRepositoryToAzure(updateRequest.Configuration.Repository),
+		Type:       DefinitionTypeBuild,
+		Process:    process,
+		Queue:      queue,
+	}
+
+	// Map the trigger configuration (if any) to the build definition triggers
+	buildTriggers, err := mapTriggersToAzure(updateRequest.Triggers, isDesignerConfiguration(updateRequest.Configuration.Type))
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid triggers configuration: %v", err))
+		return
 	}
+	buildDefinitionMinimal.Triggers = buildTriggers
 
 	h.Log.Printf("BuildDefinitionMinimal created for update: %+v", buildDefinitionMinimal)
 
@@ -571,6 +885,8 @@ func (h *putHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		h.writeErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Failed to update pipeline: %v", err))
 		return
 	}
+	keepRequestedTypes(updatedPipeline.Configuration, updateRequest.Configuration)
+	keepRequestedScheduleDays(updatedPipeline.Triggers, updateRequest.Triggers)
 
 	// Marshal updated pipeline to JSON
 	updatedPipelineBytes, err := json.Marshal(updatedPipeline)
@@ -645,41 +961,29 @@ func (h *putHandler) updatePipeline(organization, project, id, apiVersion, authH
 		return nil, fmt.Errorf("azure devops API returned status %d: %s", resp.StatusCode, string(body))
 	}
 
-	// Map full Azure DevOps response to Pipeline struct
-	var raw map[string]interface{}
-	if err := json.Unmarshal(body, &raw); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response as raw JSON: %w", err)
-	}
-
 	// log the raw response for debugging
 	h.Log.Printf("[PUT] Raw response from Azure DevOps API: %s", string(body))
 
-	// we need to map the raw response (Build Definition complete) to the Pipeline struct
-
-	pipeline := &Pipeline{
-		ID:       int32(raw["id"].(float64)),
-		Name:     raw["name"].(string),
-		Folder:   raw["path"].(string), // Build Definition uses 'path' for folder
-		URL:      raw["_links"].(map[string]interface{})["self"].(map[string]interface{})["href"].(string),
-		Revision: int32(raw["revision"].(float64)),
-		Configuration: &PipelineConfiguration{
-			Type: raw["type"].(string),
-			Path: raw["process"].(map[string]interface{})["yamlFilename"].(string), // Build Definition uses 'process.yamlFilename' for path
-
-		},
-		Links: &ReferenceLinks{
-			Links: raw["_links"].(map[string]interface{}),
-		},
-	}
-
-	// Map repository if available
-	if repoRaw, ok := raw["repository"].(map[string]interface{}); ok {
-		pipeline.Configuration.Repository = &BuildRepository{
-			ID:   repoRaw["id"].(string),
-			Type: normalizeRepoTypeFromAzure(repoRaw["type"].(string)),
+	// we need to map the response (Build Definition complete) to the Pipeline struct
+	definition, err := decodeBuildDefinition(body)
+	if definition == nil {
+		return nil, fmt.Errorf("failed to decode updated build definition: %w", err)
+	}
+	if err != nil {
+		// The fields that could not be decoded are left empty in the returned pipeline
+		h.Log.Printf("[PUT] Build definition of pipeline %d partially decoded: %v", definition.ID, err)
+	}
+
+	pipeline := mapBuildDefinitionToPipeline(definition)
+
+	var decodeErr *BuildDefinitionDecodeError
+	if errors.As(err, &decodeErr) {
+		// Reported in the response, so that the empty fields are not mistaken for the values set on Azure DevOps
+		pipeline.DecodeErrors = decodeErr.Messages()
+		if decodeErr.HasField("triggers") {
+			// Triggers are optional, do not report them as disabled when they cannot be read
+			pipeline.Triggers = nil
 		}
-	} else {
-		pipeline.Configuration.Repository = nil
 	}
 
 	return pipeline, nil
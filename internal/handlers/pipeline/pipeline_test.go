@@ -1,11 +1,14 @@
 package pipeline
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -147,6 +150,7 @@ var (
 	pipelineGetURL    = fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/pipelines/%s?api-version=%s", testOrg, testProject, testPipelineID, testAPIVersion)
 	pipelineDeleteURL = fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/build/definitions/%s?api-version=%s", testOrg, testProject, testPipelineID, buildAPIVersion)
 	pipelinePutURL    = fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/build/definitions/%s?api-version=%s", testOrg, testProject, testPipelineID, buildAPIVersion)
+	buildDefGetURL    = fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/build/definitions/%s?api-version=%s", testOrg, testProject, testPipelineID, buildAPIVersion)
 
 	validPipelineResp = `{
 		"id": 123,
@@ -210,6 +214,166 @@ var (
 		}
 	}`
 
+	validBuildDefinitionRespWithTriggers = `{
+		"id": 123,
+		"name": "test-pipeline",
+		"path": "\\TestFolder",
+		"revision": 2,
+		"type": "build",
+		"process": {
+			"yamlFilename": "azure-pipelines.yml"
+		},
+		"repository": {
+			"id": "repo123",
+			"type": "TfsGit"
+		},
+		"triggers": [
+			{
+				"branchFilters": ["+refs/heads/main"],
+				"pathFilters": ["+/src"],
+				"batchChanges": true,
+				"maxConcurrentBuildsPerBranch": 1,
+				"settingsSourceType": 1,
+				"triggerType": "continuousIntegration"
+			},
+			{
+				"settingsSourceType": 2,
+				"triggerType": "pullRequest"
+			},
+			{
+				"schedules": [
+					{
+						"branchFilters": ["+refs/heads/main"],
+						"daysToBuild": "monday, friday",
+						"scheduleOnlyWithChanges": true,
+						"startHours": 3,
+						"startMinutes": 30,
+						"timeZoneId": "UTC"
+					}
+				],
+				"settingsSourceType": 1,
+				"triggerType": "schedule"
+			}
+		],
+		"_links": {
+			"self": {
+				"href": "https://dev.azure.com/testorg/testproject/_apis/build/definitions/123"
+			}
+		}
+	}`
+
+	validPutRequestBodyWithTriggers = `{
+		"name": "updated-pipeline",
+		"folder": "UpdatedFolder",
+		"revision": 1,
+		"configuration": {
+			"type": "yaml",
+			"path": "updated-pipeline.yml",
+			"repository": {
+				"id": "repo456",
+				"type": "azureReposGit"
+			}
+		},
+		"triggers": {
+			"ci": {
+				"enabled": false
+			},
+			"schedule": {
+				"overrideYaml": true,
+				"schedules": [
+					{
+						"branchFilters": ["+refs/heads/main"],
+						"daysToBuild": ["wednesday", "Monday"],
+						"startHours": 3,
+						"startMinutes": 0
+					}
+				]
+			}
+		}
+	}`
+
+	validPutRequestBodyDesigner = `{
+		"name": "classic-pipeline",
+		"folder": "classic",
+		"revision": 4,
+		"configuration": {
+			"type": "designerJson",
+			"repository": {
+				"id": "repo123",
+				"type": "azureReposGit"
+			},
+			"designerJson": {
+				"process": {
+					"phases": [{"name": "Agent job 1", "steps": []}]
+				},
+				"queue": {"id": 42}
+			}
+		}
+	}`
+
+	validBuildDefinitionRespDesigner = `{
+		"id": 123,
+		"name": "classic-pipeline",
+		"path": "\\classic",
+		"revision": 5,
+		"type": "build",
+		"process": {
+			"type": 1,
+			"phases": [{"name": "Agent job 1", "steps": []}]
+		},
+		"queue": {"id": 42, "name": "Default", "pool": {"id": 1, "name": "Default"}},
+		"repository": {
+			"id": "repo123",
+			"type": "TfsGit"
+		},
+		"_links": {
+			"self": {
+				"href": "https://dev.azure.com/testorg/testproject/_apis/build/definitions/123"
+			}
+		}
+	}`
+
+	validPutRequestBodyGitHub = `{
+		"name": "github-pipeline",
+		"revision": 1,
+		"configuration": {
+			"type": "yaml",
+			"path": "azure-pipelines.yml",
+			"repository": {
+				"fullName": "octo/app",
+				"type": "gitHub",
+				"connection": {"id": "service-connection-id"}
+			}
+		}
+	}`
+
+	validBuildDefinitionRespGitHub = `{
+		"id": 123,
+		"name": "github-pipeline",
+		"path": "\\",
+		"revision": 2,
+		"type": "build",
+		"process": {
+			"type": 2,
+			"yamlFilename": "azure-pipelines.yml"
+		},
+		"repository": {
+			"id": "octo/app",
+			"name": "octo/app",
+			"type": "GitHub",
+			"url": "https://github.com/octo/app.git",
+			"properties": {
+				"connectedServiceId": "service-connection-id",
+				"apiUrl": "https://api.github.com/repos/octo/app"
+			}
+		},
+		"_links": {
+			"self": {
+				"href": "https://dev.azure.com/testorg/testproject/_apis/build/definitions/123"
+			}
+		}
+	}`
+
 	pipelineNotFoundResp = `{
 		"message": "Pipeline not found"
 	}`
@@ -361,17 +525,18 @@ func TestGetHandler_ServeHTTP(t *testing.T) {
 			authHeader:   testAuthHeader,
 			setupMock: func(mockClient *mockHTTPClient) {
 				mockClient.setResponse(pipelineGetURL, http.StatusOK, validPipelineResp)
+				mockClient.setResponse(buildDefGetURL, http.StatusOK, validBuildDefinitionResp)
 			},
 			expectedStatus:       http.StatusOK,
 			expectedContentType:  "application/json",
 			expectedBodyContains: `"id":123`,
-			expectedRequestCount: 1,
+			expectedRequestCount: 2,
 			verifyRequests: func(t *testing.T, mockClient *mockHTTPClient) {
-				if mockClient.getRequestCount() != 1 {
-					t.Errorf("Expected 1 request, got %d", mockClient.getRequestCount())
+				if mockClient.getRequestCount() != 2 {
+					t.Errorf("Expected 2 requests, got %d", mockClient.getRequestCount())
 				}
 
-				req := mockClient.getLastRequest()
+				req := mockClient.requests[0]
 				if req.URL.String() != pipelineGetURL {
 					t.Errorf("Request URL = %s, want %s", req.URL.String(), pipelineGetURL)
 				}
@@ -381,6 +546,11 @@ func TestGetHandler_ServeHTTP(t *testing.T) {
 				if req.Method != "GET" {
 					t.Errorf("Request Method = %s, want GET", req.Method)
 				}
+
+				req = mockClient.getLastRequest()
+				if req.URL.String() != buildDefGetURL {
+					t.Errorf("Request URL = %s, want %s", req.URL.String(), buildDefGetURL)
+				}
 			},
 		},
 		{
@@ -396,7 +566,39 @@ func TestGetHandler_ServeHTTP(t *testing.T) {
 			expectedStatus:       http.StatusOK,
 			expectedContentType:  "application/json",
 			expectedBodyContains: `"folder":"TestFolder"`, // Should be processed to remove leading backslash
-			expectedRequestCount: 1,
+			expectedRequestCount: 2,
+		},
+		{
+			name:         "successful pipeline retrieval with triggers",
+			organization: testOrg,
+			project:      testProject,
+			pipelineID:   testPipelineID,
+			apiVersion:   testAPIVersion,
+			authHeader:   testAuthHeader,
+			setupMock: func(mockClient *mockHTTPClient) {
+				mockClient.setResponse(pipelineGetURL, http.StatusOK, validPipelineResp)
+				mockClient.setResponse(buildDefGetURL, http.StatusOK, validBuildDefinitionRespWithTriggers)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedContentType:  "application/json",
+			expectedBodyContains: `"daysToBuild":["monday","friday"]`,
+			expectedRequestCount: 2,
+		},
+		{
+			name:         "pipeline retrieval without triggers when build definition fails",
+			organization: testOrg,
+			project:      testProject,
+			pipelineID:   testPipelineID,
+			apiVersion:   testAPIVersion,
+			authHeader:   testAuthHeader,
+			setupMock: func(mockClient *mockHTTPClient) {
+				mockClient.setResponse(pipelineGetURL, http.StatusOK, validPipelineResp)
+				mockClient.setError(buildDefGetURL, fmt.Errorf("network error"))
+			},
+			expectedStatus:       http.StatusOK,
+			expectedContentType:  "application/json",
+			expectedBodyContains: `"id":123`,
+			expectedRequestCount: 2,
 		},
 		{
 			name:         "missing organization parameter",
@@ -810,6 +1012,210 @@ func TestPutHandler_ServeHTTP(t *testing.T) {
 			expectedBodyContains: `"folder":"TestFolder"`,
 			expectedRequestCount: 1,
 		},
+		{
+			name:         "successful update with triggers",
+			organization: testOrg,
+			project:      testProject,
+			pipelineID:   testPipelineID,
+			authHeader:   testAuthHeader,
+			requestBody:  validPutRequestBodyWithTriggers,
+			setupMock: func(mockClient *mockHTTPClient) {
+				mockClient.setResponse(pipelinePutURL, http.StatusOK, validBuildDefinitionRespWithTriggers)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedContentType:  "application/json",
+			expectedBodyContains: `"pullRequest":{"enabled":true,"overrideYaml":false}`,
+			expectedRequestCount: 1,
+			verifyRequests: func(t *testing.T, mockClient *mockHTTPClient) {
+				body, _ := io.ReadAll(mockClient.getLastRequest().Body)
+				// CI disabled: no continuousIntegration trigger must be sent
+				if strings.Contains(string(body), `"triggerType":"continuousIntegration"`) {
+					t.Errorf("Request body should not contain a CI trigger. Got: %s", string(body))
+				}
+				// Pull request not set: inherited from the YAML file
+				if !strings.Contains(string(body), `{"triggerType":"pullRequest","settingsSourceType":2}`) {
+					t.Errorf("Request body does not contain expected pull request trigger. Got: %s", string(body))
+				}
+				// Schedules override: days converted to bitmask (monday + wednesday = 5)
+				if !strings.Contains(string(body), `"daysToBuild":5`) {
+					t.Errorf("Request body does not contain expected schedule days. Got: %s", string(body))
+				}
+			},
+		},
+		{
+			name:         "update with all the days listed returns the days as listed",
+			organization: testOrg,
+			project:      testProject,
+			pipelineID:   testPipelineID,
+			authHeader:   testAuthHeader,
+			requestBody:  `{"name": "p", "configuration": {"type": "yaml", "path": "p.yml", "repository": {"id": "r", "type": "azureReposGit"}}, "triggers": {"schedule": {"overrideYaml": true, "schedules": [{"daysToBuild": ["monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"], "startHours": 3}]}}}`,
+			setupMock: func(mockClient *mockHTTPClient) {
+				mockClient.setResponse(pipelinePutURL, http.StatusOK, strings.Replace(validBuildDefinitionRespWithTriggers, `"daysToBuild": "monday, friday"`, `"daysToBuild": "all"`, 1))
+			},
+			expectedStatus:       http.StatusOK,
+			expectedContentType:  "application/json",
+			expectedBodyContains: `"daysToBuild":["monday","tuesday","wednesday","thursday","friday","saturday","sunday"]`,
+			expectedRequestCount: 1,
+		},
+		{
+			name:         "successful update of a designer pipeline",
+			organization: testOrg,
+			project:      testProject,
+			pipelineID:   testPipelineID,
+			authHeader:   testAuthHeader,
+			requestBody:  validPutRequestBodyDesigner,
+			setupMock: func(mockClient *mockHTTPClient) {
+				mockClient.setResponse(pipelinePutURL, http.StatusOK, validBuildDefinitionRespDesigner)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedContentType:  "application/json",
+			expectedBodyContains: `"configuration":{"type":"designerJson","repository":{"id":"repo123","type":"azureReposGit"},"designerJson":{"process":{"phases":[{"name":"Agent job 1","steps":[]}]},"queue":{"id":42,"name":"Default"}}}`,
+			expectedRequestCount: 1,
+			verifyRequests: func(t *testing.T, mockClient *mockHTTPClient) {
+				body, _ := io.ReadAll(mockClient.getLastRequest().Body)
+				if !strings.Contains(string(body), `"process":{"type":1,"phases":[{"name":"Agent job 1","steps":[]}]}`) {
+					t.Errorf("Request body does not contain expected designer process. Got: %s", string(body))
+				}
+				if !strings.Contains(string(body), `"queue":{"id":42}`) {
+					t.Errorf("Request body does not contain expected queue. Got: %s", string(body))
+				}
+				if !strings.Contains(string(body), `"type":"build"`) {
+					t.Errorf("Request body does not contain expected definition type. Got: %s", string(body))
+				}
+			},
+		},
+		{
+			name:         "hyphenated designer and repository types are sent as TfsGit and returned as sent",
+			organization: testOrg,
+			project:      testProject,
+			pipelineID:   testPipelineID,
+			authHeader:   testAuthHeader,
+			requestBody:  strings.NewReplacer(`"type": "designerJson"`, `"type": "designerHyphenJson"`, `"type": "azureReposGit"`, `"type": "azureReposGitHyphenated"`).Replace(validPutRequestBodyDesigner),
+			setupMock: func(mockClient *mockHTTPClient) {
+				mockClient.setResponse(pipelinePutURL, http.StatusOK, validBuildDefinitionRespDesigner)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedContentType:  "application/json",
+			expectedBodyContains: `"configuration":{"type":"designerHyphenJson","repository":{"id":"repo123","type":"azureReposGitHyphenated"}`,
+			expectedRequestCount: 1,
+			verifyRequests: func(t *testing.T, mockClient *mockHTTPClient) {
+				body, _ := io.ReadAll(mockClient.getLastRequest().Body)
+				if !strings.Contains(string(body), `"repository":{"id":"repo123","type":"TfsGit"}`) {
+					t.Errorf("Request body does not contain expected repository type. Got: %s", string(body))
+				}
+				if !strings.Contains(string(body), `"process":{"type":1,`) {
+					t.Errorf("Request body does not contain expected designer process. Got: %s", string(body))
+				}
+			},
+		},
+		{
+			name:         "successful update of a GitHub pipeline",
+			organization: testOrg,
+			project:      testProject,
+			pipelineID:   testPipelineID,
+			authHeader:   testAuthHeader,
+			requestBody:  validPutRequestBodyGitHub,
+			setupMock: func(mockClient *mockHTTPClient) {
+				mockClient.setResponse(pipelinePutURL, http.StatusOK, validBuildDefinitionRespGitHub)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedContentType:  "application/json",
+			expectedBodyContains: `"repository":{"id":"octo/app","type":"gitHub","fullName":"octo/app","connection":{"id":"service-connection-id"}}`,
+			expectedRequestCount: 1,
+			verifyRequests: func(t *testing.T, mockClient *mockHTTPClient) {
+				body, _ := io.ReadAll(mockClient.getLastRequest().Body)
+				expected := `"repository":{"id":"octo/app","name":"octo/app","type":"GitHub","url":"https://github.com/octo/app.git","properties":{"connectedServiceId":"service-connection-id"}}`
+				if !strings.Contains(string(body), expected) {
+					t.Errorf("Request body does not contain expected repository.\nGot: %s\nWant to contain: %s", string(body), expected)
+				}
+			},
+		},
+		{
+			name:                 "GitHub repository without service connection",
+			organization:         testOrg,
+			project:              testProject,
+			pipelineID:           testPipelineID,
+			authHeader:           testAuthHeader,
+			requestBody:          `{"name": "p", "configuration": {"type": "yaml", "path": "p.yml", "repository": {"fullName": "octo/app", "type": "gitHub"}}}`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBodyContains: "configuration.repository.connection.id (service connection) is required for gitHub repositories",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "missing configuration",
+			organization:         testOrg,
+			project:              testProject,
+			pipelineID:           testPipelineID,
+			authHeader:           testAuthHeader,
+			requestBody:          `{"name": "p"}`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBodyContains: "Invalid pipeline configuration: configuration is required",
+			expectedRequestCount: 0,
+		},
+		{
+			name:         "response without _links and with unexpected process shape",
+			organization: testOrg,
+			project:      testProject,
+			pipelineID:   testPipelineID,
+			authHeader:   testAuthHeader,
+			requestBody:  validPutRequestBody,
+			setupMock: func(mockClient *mockHTTPClient) {
+				mockClient.setResponse(pipelinePutURL, http.StatusOK, `{
+					"id": 123,
+					"name": "test-pipeline",
+					"path": "\\TestFolder",
+					"revision": 2,
+					"url": "https://dev.azure.com/testorg/testproject/_apis/build/Definitions/123",
+					"process": "unexpected",
+					"repository": {"id": "repo123", "type": "TfsGit"}
+				}`)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedContentType:  "application/json",
+			expectedBodyContains: `"url":"https://dev.azure.com/testorg/testproject/_apis/build/Definitions/123"`,
+			expectedRequestCount: 1,
+		},
+		{
+			name:         "response with fields that cannot be decoded reports them",
+			organization: testOrg,
+			project:      testProject,
+			pipelineID:   testPipelineID,
+			authHeader:   testAuthHeader,
+			requestBody:  validPutRequestBody,
+			setupMock: func(mockClient *mockHTTPClient) {
+				mockClient.setResponse(pipelinePutURL, http.StatusOK, `{"id": 123, "name": "test-pipeline", "revision": 2, "process": "unexpected", "triggers": {}}`)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedContentType:  "application/json",
+			expectedBodyContains: `"decodeErrors":["process: json: cannot unmarshal string into Go value of type pipeline.Process","triggers: json: cannot unmarshal object into Go value of type []pipeline.BuildTrigger"]`,
+			expectedRequestCount: 1,
+		},
+		{
+			name:         "response without id",
+			organization: testOrg,
+			project:      testProject,
+			pipelineID:   testPipelineID,
+			authHeader:   testAuthHeader,
+			requestBody:  validPutRequestBody,
+			setupMock: func(mockClient *mockHTTPClient) {
+				mockClient.setResponse(pipelinePutURL, http.StatusOK, `{"name": "test-pipeline"}`)
+			},
+			expectedStatus:       http.StatusInternalServerError,
+			expectedBodyContains: "build definition has no id",
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "invalid triggers configuration",
+			organization:         testOrg,
+			project:              testProject,
+			pipelineID:           testPipelineID,
+			authHeader:           testAuthHeader,
+			requestBody:          `{"name": "p", "configuration": {"type": "yaml", "path": "p.yml", "repository": {"id": "r", "type": "azureReposGit"}}, "triggers": {"schedule": {"overrideYaml": true, "schedules": [{"daysToBuild": ["someday"]}]}}}`,
+			setupMock:            nil,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBodyContains: "Invalid triggers configuration",
+			expectedRequestCount: 0,
+		},
 		{
 			name:                 "missing authorization header",
 			organization:         testOrg,
@@ -939,3 +1345,475 @@ func TestPutHandler_ServeHTTP(t *testing.T) {
 		})
 	}
 }
+
+// Test trigger mapping helpers
+func TestMapTriggersToAzure(t *testing.T) {
+	t.Run("nil triggers are not sent", func(t *testing.T) {
+		triggers, err := mapTriggersToAzure(nil, false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if triggers != nil {
+			t.Errorf("expected nil triggers, got %+v", triggers)
+		}
+	})
+
+	t.Run("empty configuration inherits CI and pull request triggers from YAML", func(t *testing.T) {
+		triggers, err := mapTriggersToAzure(&PipelineTriggers{}, false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(triggers) != 2 {
+			t.Fatalf("expected 2 triggers, got %d", len(triggers))
+		}
+		for _, trigger := range triggers {
+			if trigger.SettingsSourceType != SettingsSourceTypeProcess {
+				t.Errorf("trigger %s: settingsSourceType = %d, want %d", trigger.TriggerType, trigger.SettingsSourceType, SettingsSourceTypeProcess)
+			}
+		}
+	})
+
+	t.Run("CI override keeps filters", func(t *testing.T) {
+		triggers, err := mapTriggersToAzure(&PipelineTriggers{
+			CI:          &CITrigger{Enabled: true, OverrideYAML: true, BranchFilters: []string{"+refs/heads/main"}, PathFilters: []string{"-/docs"}},
+			PullRequest: &PullRequestTrigger{Enabled: false},
+		}, false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(triggers) != 1 {
+			t.Fatalf("expected 1 trigger, got %d", len(triggers))
+		}
+		if triggers[0].TriggerType != TriggerTypeContinuousIntegration || triggers[0].SettingsSourceType != SettingsSourceTypeDefinition {
+			t.Errorf("unexpected trigger: %+v", triggers[0])
+		}
+		if len(triggers[0].PathFilters) != 1 || triggers[0].PathFilters[0] != "-/docs" {
+			t.Errorf("unexpected path filters: %v", triggers[0].PathFilters)
+		}
+	})
+
+	t.Run("invalid schedule hours", func(t *testing.T) {
+		_, err := mapTriggersToAzure(&PipelineTriggers{
+			Schedule: &ScheduleTrigger{OverrideYAML: true, Schedules: []PipelineSchedule{{StartHours: 24}}},
+		}, false)
+		if err == nil {
+			t.Error("expected an error for invalid startHours")
+		}
+	})
+
+	t.Run("designer pipelines always use definition settings", func(t *testing.T) {
+		triggers, err := mapTriggersToAzure(&PipelineTriggers{
+			CI: &CITrigger{Enabled: true, BranchFilters: []string{"+refs/heads/main"}},
+		}, true)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		// Pull request trigger not set: disabled for designer pipelines (no YAML file to inherit from)
+		if len(triggers) != 1 {
+			t.Fatalf("expected 1 trigger, got %d", len(triggers))
+		}
+		if triggers[0].SettingsSourceType != SettingsSourceTypeDefinition || len(triggers[0].BranchFilters) != 1 {
+			t.Errorf("unexpected trigger: %+v", triggers[0])
+		}
+	})
+}
+
+func TestMapTriggersFromAzure(t *testing.T) {
+	t.Run("missing triggers are reported as disabled", func(t *testing.T) {
+		triggers := mapTriggersFromAzure(nil)
+		if triggers.CI == nil || triggers.CI.Enabled {
+			t.Errorf("expected CI trigger disabled, got %+v", triggers.CI)
+		}
+		if triggers.PullRequest == nil || triggers.PullRequest.Enabled {
+			t.Errorf("expected pull request trigger disabled, got %+v", triggers.PullRequest)
+		}
+		if triggers.Schedule == nil || triggers.Schedule.OverrideYAML {
+			t.Errorf("expected schedules not overridden, got %+v", triggers.Schedule)
+		}
+	})
+
+	t.Run("round trip of overridden triggers", func(t *testing.T) {
+		desired := &PipelineTriggers{
+			CI:          &CITrigger{Enabled: true, OverrideYAML: true, BranchFilters: []string{"+refs/heads/main"}},
+			PullRequest: &PullRequestTrigger{Enabled: true, OverrideYAML: true, AutoCancel: true, Forks: &PullRequestForks{Enabled: true}},
+			Schedule:    &ScheduleTrigger{OverrideYAML: true, Schedules: []PipelineSchedule{{DaysToBuild: []string{"all"}, StartHours: 1, TimeZoneID: "UTC"}}},
+		}
+		buildTriggers, err := mapTriggersToAzure(desired, false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		observed := mapTriggersFromAzure(buildTriggers)
+
+		if !observed.CI.OverrideYAML || observed.CI.BranchFilters[0] != "+refs/heads/main" {
+			t.Errorf("unexpected CI trigger: %+v", observed.CI)
+		}
+		if !observed.PullRequest.AutoCancel || observed.PullRequest.Forks == nil || !observed.PullRequest.Forks.Enabled {
+			t.Errorf("unexpected pull request trigger: %+v", observed.PullRequest)
+		}
+		if len(observed.Schedule.Schedules) != 1 || !reflect.DeepEqual(observed.Schedule.Schedules[0].DaysToBuild, []string{"all"}) {
+			t.Errorf("unexpected schedules: %+v", observed.Schedule.Schedules)
+		}
+	})
+
+	t.Run("schedule without time zone is sent and read back without time zone", func(t *testing.T) {
+		desired := &PipelineTriggers{
+			Schedule: &ScheduleTrigger{OverrideYAML: true, Schedules: []PipelineSchedule{{DaysToBuild: []string{"monday", "sunday"}, StartHours: 2}}},
+		}
+		buildTriggers, err := mapTriggersToAzure(desired, false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		body, _ := json.Marshal(buildTriggers)
+		if strings.Contains(string(body), "timeZoneId") {
+			t.Errorf("expected no timeZoneId in the build triggers, got %s", string(body))
+		}
+		observed := mapTriggersFromAzure(buildTriggers)
+		if len(observed.Schedule.Schedules) != 1 || observed.Schedule.Schedules[0].TimeZoneID != "" ||
+			!reflect.DeepEqual(observed.Schedule.Schedules[0].DaysToBuild, []string{"monday", "sunday"}) {
+			t.Errorf("unexpected schedules: %+v", observed.Schedule.Schedules)
+		}
+	})
+}
+
+func TestScheduleDays_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		input string
+		want  ScheduleDays
+	}{
+		{`31`, ScheduleDaysMonday | ScheduleDaysTuesday | ScheduleDaysWednesday | ScheduleDaysThursday | ScheduleDaysFriday},
+		{`"all"`, ScheduleDaysAll},
+		{`"saturday, sunday"`, ScheduleDaysSaturday | ScheduleDaysSunday},
+		{`"none"`, ScheduleDaysNone},
+	}
+
+	for _, tt := range tests {
+		var days ScheduleDays
+		if err := days.UnmarshalJSON([]byte(tt.input)); err != nil {
+			t.Errorf("UnmarshalJSON(%s) returned error: %v", tt.input, err)
+			continue
+		}
+		if days != tt.want {
+			t.Errorf("UnmarshalJSON(%s) = %d, want %d", tt.input, days, tt.want)
+		}
+	}
+}
+
+// createTestListHandler creates a LIST handler instance for testing with a mock client
+func createTestListHandler(mockClient *mockHTTPClient) *listHandler {
+	logger := zerolog.New(io.Discard).With().Timestamp().Logger()
+	h := &listHandler{
+		baseHandler: &baseHandler{
+			HandlerOptions: handlers.HandlerOptions{
+				Client: mockClient,
+				Log:    &logger,
+			},
+		},
+	}
+	return h
+}
+
+// Test LIST handler
+func TestListHandler_ServeHTTP(t *testing.T) {
+	pipelineListURL := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/pipelines?api-version=%s", testOrg, testProject, testAPIVersion)
+	pipelineListPage2URL := pipelineListURL + "&continuationToken=token%2B2"
+	definitionsByRepoURL := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/build/definitions?repositoryId=repo123&repositoryType=TfsGit&api-version=%s", testOrg, testProject, buildAPIVersion)
+
+	page1 := `{"count": 2, "value": [
+		{"id": 30, "name": "web-deploy", "folder": "\\apps\\web", "revision": 1},
+		{"id": 10, "name": "api-build", "folder": "\\apps", "revision": 3}
+	]}`
+	page2 := `{"count": 2, "value": [
+		{"id": 20, "name": "web-build", "folder": "\\apps\\web", "revision": 2},
+		{"id": 5, "name": "infra", "folder": "\\", "revision": 1}
+	]}`
+
+	setupPages := func(mockClient *mockHTTPClient) {
+		mockClient.setResponse(pipelineListURL, http.StatusOK, page1)
+		mockClient.responses[pipelineListURL].Header.Set("x-ms-continuationtoken", "token+2")
+		mockClient.setResponse(pipelineListPage2URL, http.StatusOK, page2)
+	}
+
+	tests := []struct {
+		name                 string
+		query                string
+		authHeader           string
+		setupMock            func(*mockHTTPClient)
+		expectedStatus       int
+		expectedBody         string
+		expectedBodyContains string
+		expectedRequestCount int
+	}{
+		{
+			name:                 "all pages aggregated and ordered by id",
+			authHeader:           testAuthHeader,
+			setupMock:            setupPages,
+			expectedStatus:       http.StatusOK,
+			expectedBody:         `{"count":4,"value":[{"folder":"","id":5,"name":"infra","revision":1},{"folder":"apps","id":10,"name":"api-build","revision":3},{"folder":"apps/web","id":20,"name":"web-build","revision":2},{"folder":"apps/web","id":30,"name":"web-deploy","revision":1}]}`,
+			expectedRequestCount: 2,
+		},
+		{
+			name:                 "folder filter with mixed separators",
+			query:                "&folder=%2Fapps%5Cweb",
+			authHeader:           testAuthHeader,
+			setupMock:            setupPages,
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `{"count":2,`,
+			expectedRequestCount: 2,
+		},
+		{
+			name:                 "folder filter including subfolders",
+			query:                "&folder=apps&includeSubfolders=true",
+			authHeader:           testAuthHeader,
+			setupMock:            setupPages,
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `{"count":3,`,
+			expectedRequestCount: 2,
+		},
+		{
+			name:                 "name prefix filter",
+			query:                "&namePrefix=WEB-",
+			authHeader:           testAuthHeader,
+			setupMock:            setupPages,
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `{"count":2,`,
+			expectedRequestCount: 2,
+		},
+		{
+			name:       "repository filter",
+			query:      "&repositoryId=repo123",
+			authHeader: testAuthHeader,
+			setupMock: func(mockClient *mockHTTPClient) {
+				setupPages(mockClient)
+				mockClient.setResponse(definitionsByRepoURL, http.StatusOK, `{"count": 2, "value": [{"id": 20}, {"id": 5}]}`)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `{"count":2,"value":[{"folder":"","id":5`,
+			expectedRequestCount: 3,
+		},
+		{
+			name:                 "repository type without repository id",
+			query:                "&repositoryType=gitHub",
+			authHeader:           testAuthHeader,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBodyContains: "repositoryType requires repositoryId",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "missing authorization header",
+			authHeader:           "",
+			expectedStatus:       http.StatusUnauthorized,
+			expectedBodyContains: "Request rejected due to missing or invalid Basic authentication",
+			expectedRequestCount: 0,
+		},
+		{
+			name:       "azure devops error is forwarded",
+			authHeader: testAuthHeader,
+			setupMock: func(mockClient *mockHTTPClient) {
+				mockClient.setResponse(pipelineListURL, http.StatusUnauthorized, unauthorizedResp)
+			},
+			expectedStatus:       http.StatusUnauthorized,
+			expectedBodyContains: "Unauthorized",
+			expectedRequestCount: 1,
+		},
+		{
+			name:       "repeated continuation token",
+			authHeader: testAuthHeader,
+			setupMock: func(mockClient *mockHTTPClient) {
+				setupPages(mockClient)
+				mockClient.responses[pipelineListPage2URL].Header.Set("x-ms-continuationtoken", "token+2")
+			},
+			expectedStatus:       http.StatusBadGateway,
+			expectedBodyContains: "already seen continuation token",
+			expectedRequestCount: 2,
+		},
+		{
+			name:       "network error on second page",
+			authHeader: testAuthHeader,
+			setupMock: func(mockClient *mockHTTPClient) {
+				setupPages(mockClient)
+				mockClient.setError(pipelineListPage2URL, fmt.Errorf("network error"))
+			},
+			expectedStatus:       http.StatusInternalServerError,
+			expectedBodyContains: "Error listing pipelines",
+			expectedRequestCount: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := newMockHTTPClient()
+			if tt.setupMock != nil {
+				tt.setupMock(mockClient)
+			}
+
+			handler := createTestListHandler(mockClient)
+
+			req := httptest.NewRequest("GET", fmt.Sprintf("/api/placeholder?api-version=%s%s", testAPIVersion, tt.query), nil)
+			req.SetPathValue("organization", testOrg)
+			req.SetPathValue("project", testProject)
+			if tt.authHeader != "" {
+				req.Header.Set("Authorization", tt.authHeader)
+				req.SetBasicAuth(testUsername, testPassword)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, tt.expectedStatus)
+			}
+			if tt.expectedBody != "" && rr.Body.String() != tt.expectedBody {
+				t.Errorf("handler returned unexpected body.\nGot:  %s\nWant: %s", rr.Body.String(), tt.expectedBody)
+			}
+			if !strings.Contains(rr.Body.String(), tt.expectedBodyContains) {
+				t.Errorf("handler response body does not contain expected content.\nGot: %s\nWant to contain: %s", rr.Body.String(), tt.expectedBodyContains)
+			}
+			if mockClient.getRequestCount() != tt.expectedRequestCount {
+				t.Errorf("expected %d requests, got %d", tt.expectedRequestCount, mockClient.getRequestCount())
+			}
+		})
+	}
+}
+
+func TestValidatePipelineConfiguration(t *testing.T) {
+	azureRepo := &BuildRepository{ID: "repo123", Type: RepositoryTypeAzureReposGit}
+	designer := &DesignerConfiguration{Process: &DesignerProcess{Phases: []byte(`[{"name": "job"}]`)}}
+
+	tests := []struct {
+		name          string
+		configuration *PipelineConfigurationParameters
+		wantErr       string
+	}{
+		{"valid yaml", &PipelineConfigurationParameters{Type: ConfigurationTypeYAML, Path: "p.yml", Repository: azureRepo}, ""},
+		{"yaml without path", &PipelineConfigurationParameters{Type: ConfigurationTypeYAML, Repository: azureRepo}, "configuration.path is required"},
+		{"yaml with designerJson", &PipelineConfigurationParameters{Type: ConfigurationTypeYAML, Path: "p.yml", Repository: azureRepo, DesignerJSON: designer}, "not allowed for yaml"},
+		{"valid designer", &PipelineConfigurationParameters{Type: ConfigurationTypeDesignerJSON, Repository: azureRepo, DesignerJSON: designer}, ""},
+		{"valid designer hyphen", &PipelineConfigurationParameters{Type: ConfigurationTypeDesignerHyphenJSON, Repository: azureRepo, DesignerJSON: designer}, ""},
+		{"designer without process", &PipelineConfigurationParameters{Type: ConfigurationTypeDesignerJSON, Repository: azureRepo}, "designerJson.process is required"},
+		{"designer with empty phases", &PipelineConfigurationParameters{Type: ConfigurationTypeDesignerJSON, Repository: azureRepo, DesignerJSON: &DesignerConfiguration{Process: &DesignerProcess{Phases: []byte(`[]`)}}}, "phases must be a non-empty array"},
+		{"designer with path", &PipelineConfigurationParameters{Type: ConfigurationTypeDesignerJSON, Path: "p.yml", Repository: azureRepo, DesignerJSON: designer}, "configuration.path is not allowed"},
+		{"unsupported type", &PipelineConfigurationParameters{Type: ConfigurationTypeJustInTime, Repository: azureRepo}, "not supported"},
+		{"missing repository", &PipelineConfigurationParameters{Type: ConfigurationTypeYAML, Path: "p.yml"}, "configuration.repository is required"},
+		{"azure repo without id", &PipelineConfigurationParameters{Type: ConfigurationTypeYAML, Path: "p.yml", Repository: &BuildRepository{Type: RepositoryTypeAzureReposGit}}, "repository.id is required"},
+		{"valid bitbucket", &PipelineConfigurationParameters{Type: ConfigurationTypeYAML, Path: "p.yml", Repository: &BuildRepository{ID: "team/repo", Type: RepositoryTypeBitbucket, Connection: &ServiceConnectionReference{ID: "sc"}}}, ""},
+		{"github with invalid full name", &PipelineConfigurationParameters{Type: ConfigurationTypeYAML, Path: "p.yml", Repository: &BuildRepository{FullName: "repo", Type: RepositoryTypeGitHub, Connection: &ServiceConnectionReference{ID: "sc"}}}, "format 'owner/repository'"},
+		{"github with mismatching id", &PipelineConfigurationParameters{Type: ConfigurationTypeYAML, Path: "p.yml", Repository: &BuildRepository{ID: "octo/other", FullName: "octo/app", Type: RepositoryTypeGitHub, Connection: &ServiceConnectionReference{ID: "sc"}}}, "must match"},
+		{"unsupported repository type", &PipelineConfigurationParameters{Type: ConfigurationTypeYAML, Path: "p.yml", Repository: &BuildRepository{ID: "r", Type: "svn"}}, "repository type 'svn' is not supported"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePipelineConfiguration(tt.configuration)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestDecodeBuildDefinition(t *testing.T) {
+	tests := []struct {
+		name            string
+		body            string
+		wantErr         bool
+		wantNil         bool
+		wantFieldErrors []string
+		verify          func(t *testing.T, definition *BuildDefinition)
+	}{
+		{
+			name: "complete build definition",
+			body: validBuildDefinitionRespWithTriggers,
+			verify: func(t *testing.T, definition *BuildDefinition) {
+				if definition.ID != 123 || definition.Name != "test-pipeline" || definition.Revision != 2 {
+					t.Errorf("unexpected definition: %+v", definition)
+				}
+				if definition.selfLink() != "https://dev.azure.com/testorg/testproject/_apis/build/definitions/123" {
+					t.Errorf("unexpected self link: %s", definition.selfLink())
+				}
+				if len(definition.Triggers) == 0 {
+					t.Errorf("expected triggers to be decoded")
+				}
+			},
+		},
+		{
+			name: "classic pipeline without _links",
+			body: `{"id": 7, "name": "classic", "url": "https://example/7", "process": {"type": 1, "phases": [{"name": "job"}]}, "queue": {"id": 3}}`,
+			verify: func(t *testing.T, definition *BuildDefinition) {
+				if definition.selfLink() != "https://example/7" {
+					t.Errorf("expected self link to fall back to url, got %s", definition.selfLink())
+				}
+				pipeline := mapBuildDefinitionToPipeline(definition)
+				if pipeline.Links != nil {
+					t.Errorf("expected no links, got %+v", pipeline.Links)
+				}
+				if pipeline.Configuration.Type != ConfigurationTypeDesignerJSON {
+					t.Errorf("expected designer configuration, got %s", pipeline.Configuration.Type)
+				}
+			},
+		},
+		{
+			name:            "fields with unexpected shape",
+			body:            `{"id": 7, "name": 42, "_links": [], "process": "yaml", "revision": 3}`,
+			wantErr:         true,
+			wantFieldErrors: []string{"name", "_links", "process"},
+			verify: func(t *testing.T, definition *BuildDefinition) {
+				if definition.ID != 7 || definition.Revision != 3 {
+					t.Errorf("expected valid fields to be decoded, got %+v", definition)
+				}
+			},
+		},
+		{
+			name:    "missing id",
+			body:    `{"name": "test"}`,
+			wantErr: true,
+			wantNil: true,
+		},
+		{
+			name:    "invalid id",
+			body:    `{"id": "abc"}`,
+			wantErr: true,
+			wantNil: true,
+		},
+		{
+			name:    "not a JSON object",
+			body:    `[]`,
+			wantErr: true,
+			wantNil: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			definition, err := decodeBuildDefinition([]byte(tt.body))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if (definition == nil) != tt.wantNil {
+				t.Fatalf("unexpected definition: %+v", definition)
+			}
+			if len(tt.wantFieldErrors) > 0 {
+				var decodeErr *BuildDefinitionDecodeError
+				if !errors.As(err, &decodeErr) {
+					t.Fatalf("expected *BuildDefinitionDecodeError, got %T", err)
+				}
+				if len(decodeErr.Fields) != len(tt.wantFieldErrors) {
+					t.Errorf("expected %d field errors, got %v", len(tt.wantFieldErrors), decodeErr)
+				}
+				for _, field := range tt.wantFieldErrors {
+					if !decodeErr.HasField(field) {
+						t.Errorf("expected field error for %s, got %v", field, decodeErr)
+					}
+				}
+			}
+			if tt.verify != nil && definition != nil {
+				tt.verify(t, definition)
+			}
+		})
+	}
+}
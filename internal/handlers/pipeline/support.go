@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/utils"
 )
 
 // function to add a field to the response body
@@ -40,6 +42,8 @@ func normalizeRepoTypeFromAzure(typ string) string {
 		return RepositoryTypeAzureReposGit
 	case "GitHub":
 		return RepositoryTypeGitHub
+	case "Bitbucket":
+		return RepositoryTypeBitbucket
 	default:
 		return strings.TrimSpace(strings.ToLower(typ))
 	}
@@ -47,11 +51,486 @@ func normalizeRepoTypeFromAzure(typ string) string {
 
 func normalizeRepoTypeToAzure(typ string) string {
 	switch typ {
-	case RepositoryTypeAzureReposGit:
+	case RepositoryTypeAzureReposGit, RepositoryTypeAzureReposGitHyphenated:
 		return "TfsGit"
 	case RepositoryTypeGitHub:
 		return "GitHub"
+	case RepositoryTypeBitbucket:
+		return "Bitbucket"
 	default:
 		return strings.TrimSpace(strings.ToLower(typ))
 	}
 }
+
+// mapTriggersToAzure converts the plugin trigger configuration into the 'triggers' array of a build definition
+// - CI and pull request triggers not set in the request are inherited from the YAML file
+// - disabled CI and pull request triggers are not included in the array (Azure DevOps disables them)
+// - schedules are included only when they override the YAML file
+// Designer (classic) pipelines have no YAML file: triggers not set are disabled and the settings are always read from the definition
+func mapTriggersToAzure(triggers *PipelineTriggers, designer bool) ([]BuildTrigger, error) {
+	if triggers == nil {
+		return nil, nil
+	}
+
+	buildTriggers := []BuildTrigger{}
+
+	ci := triggers.CI
+	if ci == nil {
+		ci = &CITrigger{Enabled: !designer}
+	}
+	if ci.Enabled {
+		trigger := BuildTrigger{
+			TriggerType:        TriggerTypeContinuousIntegration,
+			SettingsSourceType: SettingsSourceTypeProcess,
+		}
+		if ci.OverrideYAML || designer {
+			trigger.SettingsSourceType = SettingsSourceTypeDefinition
+			trigger.BranchFilters = ci.BranchFilters
+			trigger.PathFilters = ci.PathFilters
+			trigger.BatchChanges = ci.BatchChanges
+			trigger.MaxConcurrentBuildsPerBranch = ci.MaxConcurrentBuildsPerBranch
+		}
+		buildTriggers = append(buildTriggers, trigger)
+	}
+
+	pr := triggers.PullRequest
+	if pr == nil {
+		pr = &PullRequestTrigger{Enabled: !designer}
+	}
+	if pr.Enabled {
+		trigger := BuildTrigger{
+			TriggerType:        TriggerTypePullRequest,
+			SettingsSourceType: SettingsSourceTypeProcess,
+		}
+		if pr.OverrideYAML || designer {
+			autoCancel := pr.AutoCancel
+			trigger.SettingsSourceType = SettingsSourceTypeDefinition
+			trigger.BranchFilters = pr.BranchFilters
+			trigger.PathFilters = pr.PathFilters
+			trigger.AutoCancel = &autoCancel
+			trigger.IsCommentRequiredForPullRequest = pr.IsCommentRequiredForPullRequest
+			trigger.RequireCommentsForNonTeamMembersOnly = pr.RequireCommentsForNonTeamMembersOnly
+			trigger.Forks = &Forks{}
+			if pr.Forks != nil {
+				trigger.Forks.Enabled = pr.Forks.Enabled
+				trigger.Forks.AllowSecrets = pr.Forks.AllowSecrets
+			}
+		}
+		buildTriggers = append(buildTriggers, trigger)
+	}
+
+	if triggers.Schedule != nil && (triggers.Schedule.OverrideYAML || designer) {
+		trigger := BuildTrigger{
+			TriggerType:        TriggerTypeSchedule,
+			SettingsSourceType: SettingsSourceTypeDefinition,
+			Schedules:          []BuildSchedule{},
+		}
+		for i, schedule := range triggers.Schedule.Schedules {
+			if schedule.StartHours < 0 || schedule.StartHours > 23 {
+				return nil, fmt.Errorf("schedule %d: startHours must be between 0 and 23", i)
+			}
+			if schedule.StartMinutes < 0 || schedule.StartMinutes > 59 {
+				return nil, fmt.Errorf("schedule %d: startMinutes must be between 0 and 59", i)
+			}
+			days, err := ParseScheduleDays(schedule.DaysToBuild)
+			if err != nil {
+				return nil, fmt.Errorf("schedule %d: %w", i, err)
+			}
+			trigger.Schedules = append(trigger.Schedules, BuildSchedule{
+				BranchFilters:           schedule.BranchFilters,
+				DaysToBuild:             days,
+				ScheduleOnlyWithChanges: schedule.ScheduleOnlyWithChanges,
+				StartHours:              schedule.StartHours,
+				StartMinutes:            schedule.StartMinutes,
+				TimeZoneID:              schedule.TimeZoneID,
+			})
+		}
+		buildTriggers = append(buildTriggers, trigger)
+	}
+
+	return buildTriggers, nil
+}
+
+// mapTriggersFromAzure converts the 'triggers' array of a build definition into the plugin trigger configuration
+// The result always contains the three sections so that the controller can compare them with the desired state
+func mapTriggersFromAzure(buildTriggers []BuildTrigger) *PipelineTriggers {
+	triggers := &PipelineTriggers{
+		CI:          &CITrigger{Enabled: false},
+		PullRequest: &PullRequestTrigger{Enabled: false},
+		Schedule:    &ScheduleTrigger{OverrideYAML: false},
+	}
+
+	for _, trigger := range buildTriggers {
+		override := trigger.SettingsSourceType == SettingsSourceTypeDefinition
+
+		switch trigger.TriggerType {
+		case TriggerTypeContinuousIntegration:
+			triggers.CI = &CITrigger{Enabled: true, OverrideYAML: override}
+			if override {
+				triggers.CI.BranchFilters = trigger.BranchFilters
+				triggers.CI.PathFilters = trigger.PathFilters
+				triggers.CI.BatchChanges = trigger.BatchChanges
+				triggers.CI.MaxConcurrentBuildsPerBranch = trigger.MaxConcurrentBuildsPerBranch
+			}
+		case TriggerTypePullRequest:
+			triggers.PullRequest = &PullRequestTrigger{Enabled: true, OverrideYAML: override}
+			if override {
+				triggers.PullRequest.BranchFilters = trigger.BranchFilters
+				triggers.PullRequest.PathFilters = trigger.PathFilters
+				triggers.PullRequest.IsCommentRequiredForPullRequest = trigger.IsCommentRequiredForPullRequest
+				triggers.PullRequest.RequireCommentsForNonTeamMembersOnly = trigger.RequireCommentsForNonTeamMembersOnly
+				if trigger.AutoCancel != nil {
+					triggers.PullRequest.AutoCancel = *trigger.AutoCancel
+				}
+				if trigger.Forks != nil {
+					triggers.PullRequest.Forks = &PullRequestForks{
+						Enabled:      trigger.Forks.Enabled,
+						AllowSecrets: trigger.Forks.AllowSecrets,
+					}
+				}
+			}
+		case TriggerTypeSchedule:
+			if !override {
+				continue
+			}
+			triggers.Schedule = &ScheduleTrigger{OverrideYAML: true}
+			for _, schedule := range trigger.Schedules {
+				triggers.Schedule.Schedules = append(triggers.Schedule.Schedules, PipelineSchedule{
+					BranchFilters:           schedule.BranchFilters,
+					DaysToBuild:             schedule.DaysToBuild.Names(),
+					StartHours:              schedule.StartHours,
+					StartMinutes:            schedule.StartMinutes,
+					TimeZoneID:              schedule.TimeZoneID,
+					ScheduleOnlyWithChanges: schedule.ScheduleOnlyWithChanges,
+				})
+			}
+		}
+	}
+
+	return triggers
+}
+
+// keepRequestedScheduleDays returns the days of the updated schedules in the form used by the request
+// The days are stored as a bitmask, so the seven days listed one by one would otherwise be read back as 'all' (and 'all' as the seven days)
+func keepRequestedScheduleDays(triggers *PipelineTriggers, requested *PipelineTriggers) {
+	if triggers == nil || triggers.Schedule == nil || requested == nil || requested.Schedule == nil {
+		return
+	}
+	for i := range triggers.Schedule.Schedules {
+		if i >= len(requested.Schedule.Schedules) {
+			return
+		}
+		requestedDays, err := ParseScheduleDays(requested.Schedule.Schedules[i].DaysToBuild)
+		if err != nil {
+			continue
+		}
+		days, err := ParseScheduleDays(triggers.Schedule.Schedules[i].DaysToBuild)
+		if err == nil && days == requestedDays {
+			triggers.Schedule.Schedules[i].DaysToBuild = requested.Schedule.Schedules[i].DaysToBuild
+		}
+	}
+}
+
+// parseBuildDefinitionTriggers reads the 'triggers' array from a build definition body and maps it to the plugin configuration
+func parseBuildDefinitionTriggers(body []byte) (*PipelineTriggers, error) {
+	var definition struct {
+		Triggers []BuildTrigger `json:"triggers"`
+	}
+	if err := json.Unmarshal(body, &definition); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal build definition triggers: %w", err)
+	}
+	return mapTriggersFromAzure(definition.Triggers), nil
+}
+
+// decodeBuildDefinition decodes a build definition field by field, so that a field with an unexpected shape
+// (e.g., the process of a classic pipeline or missing '_links') does not prevent the others from being decoded
+// An error is returned if the body is not a JSON object or if the ID of the build definition cannot be read,
+// otherwise the fields that could not be decoded are reported with a *BuildDefinitionDecodeError
+func decodeBuildDefinition(body []byte) (*BuildDefinition, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal build definition: %w", err)
+	}
+
+	definition := &BuildDefinition{}
+	decodeErr := &BuildDefinitionDecodeError{}
+
+	targets := []struct {
+		field  string
+		target interface{}
+	}{
+		{"id", &definition.ID},
+		{"name", &definition.Name},
+		{"path", &definition.Path},
+		{"revision", &definition.Revision},
+		{"url", &definition.URL},
+		{"_links", &definition.Links},
+		{"process", &definition.Process},
+		{"queue", &definition.Queue},
+		{"repository", &definition.Repository},
+		{"triggers", &definition.Triggers},
+	}
+	for _, t := range targets {
+		raw, ok := fields[t.field]
+		if !ok || string(raw) == "null" {
+			continue
+		}
+		if err := json.Unmarshal(raw, t.target); err != nil {
+			decodeErr.Fields = append(decodeErr.Fields, BuildDefinitionFieldError{Field: t.field, Err: err})
+		}
+	}
+
+	if _, ok := fields["id"]; !ok {
+		return nil, fmt.Errorf("build definition has no id")
+	}
+	if decodeErr.HasField("id") {
+		return nil, decodeErr
+	}
+
+	if len(decodeErr.Fields) > 0 {
+		return definition, decodeErr
+	}
+	return definition, nil
+}
+
+// selfLink returns the 'self' link of a build definition, falling back to its 'url' field
+func (d *BuildDefinition) selfLink() string {
+	if self, ok := d.Links["self"].(map[string]interface{}); ok {
+		if href, ok := self["href"].(string); ok && href != "" {
+			return href
+		}
+	}
+	return d.URL
+}
+
+// mapBuildDefinitionToPipeline converts a build definition to the pipeline returned by the plugin
+func mapBuildDefinitionToPipeline(definition *BuildDefinition) *Pipeline {
+	pipeline := &Pipeline{
+		ID:            definition.ID,
+		Name:          definition.Name,
+		Folder:        definition.Path, // Build Definition uses 'path' for folder
+		URL:           definition.selfLink(),
+		Revision:      definition.Revision,
+		Configuration: mapConfigurationFromAzure(definition.Process, definition.Queue, definition.Repository),
+		Triggers:      mapTriggersFromAzure(definition.Triggers),
+	}
+	if definition.Links != nil {
+		pipeline.Links = &ReferenceLinks{Links: definition.Links}
+	}
+	return pipeline
+}
+
+// normalizeFolderFromAzure converts an Azure DevOps folder path (e.g., '\folder\subfolder') to the plugin format (e.g., 'folder/subfolder')
+// The root folder is returned as an empty string
+func normalizeFolderFromAzure(folder string) string {
+	return strings.Join(utils.SplitPath(folder), "/")
+}
+
+// normalizeFolderToAzure converts a folder path in any format (e.g., 'folder/subfolder', '/folder\subfolder/') to the Azure DevOps format (e.g., '\folder\subfolder')
+// The root folder is returned as '\'
+func normalizeFolderToAzure(folder string) string {
+	return "\\" + strings.Join(utils.SplitPath(folder), "\\")
+}
+
+// isDesignerConfiguration reports whether the configuration type refers to a designer (classic) pipeline
+func isDesignerConfiguration(configurationType string) bool {
+	return configurationType == ConfigurationTypeDesignerJSON || configurationType == ConfigurationTypeDesignerHyphenJSON
+}
+
+// isExternalRepository reports whether the repository type requires a service connection (e.g., GitHub, Bitbucket)
+func isExternalRepository(repositoryType string) bool {
+	return repositoryType == RepositoryTypeGitHub || repositoryType == RepositoryTypeBitbucket
+}
+
+// validatePipelineConfiguration checks the fields required by each configuration type and repository type
+func validatePipelineConfiguration(configuration *PipelineConfigurationParameters) error {
+	if configuration == nil {
+		return fmt.Errorf("configuration is required")
+	}
+
+	switch {
+	case configuration.Type == ConfigurationTypeYAML:
+		if configuration.Path == "" {
+			return fmt.Errorf("configuration.path is required for yaml pipelines")
+		}
+		if configuration.DesignerJSON != nil {
+			return fmt.Errorf("configuration.designerJson is not allowed for yaml pipelines")
+		}
+	case isDesignerConfiguration(configuration.Type):
+		if configuration.Path != "" {
+			return fmt.Errorf("configuration.path is not allowed for %s pipelines", configuration.Type)
+		}
+		if configuration.DesignerJSON == nil || configuration.DesignerJSON.Process == nil {
+			return fmt.Errorf("configuration.designerJson.process is required for %s pipelines", configuration.Type)
+		}
+		var phases []json.RawMessage
+		if err := json.Unmarshal(configuration.DesignerJSON.Process.Phases, &phases); err != nil || len(phases) == 0 {
+			return fmt.Errorf("configuration.designerJson.process.phases must be a non-empty array")
+		}
+	default:
+		return fmt.Errorf("configuration type '%s' is not supported, supported types are: %s, %s, %s", configuration.Type, ConfigurationTypeYAML, ConfigurationTypeDesignerJSON, ConfigurationTypeDesignerHyphenJSON)
+	}
+
+	repository := configuration.Repository
+	if repository == nil {
+		return fmt.Errorf("configuration.repository is required")
+	}
+
+	switch {
+	case repository.Type == RepositoryTypeAzureReposGit || repository.Type == RepositoryTypeAzureReposGitHyphenated:
+		if repository.ID == "" {
+			return fmt.Errorf("configuration.repository.id is required for %s repositories", repository.Type)
+		}
+	case isExternalRepository(repository.Type):
+		fullName := repositoryFullName(repository)
+		if fullName == "" {
+			return fmt.Errorf("configuration.repository.fullName (or id) is required for %s repositories", repository.Type)
+		}
+		if parts := strings.Split(fullName, "/"); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return fmt.Errorf("configuration.repository.fullName must be in the format 'owner/repository', got '%s'", fullName)
+		}
+		if repository.ID != "" && repository.FullName != "" && !strings.EqualFold(repository.ID, repository.FullName) {
+			return fmt.Errorf("configuration.repository.id and configuration.repository.fullName must match for %s repositories", repository.Type)
+		}
+		if repository.Connection == nil || repository.Connection.ID == "" {
+			return fmt.Errorf("configuration.repository.connection.id (service connection) is required for %s repositories", repository.Type)
+		}
+	default:
+		return fmt.Errorf("repository type '%s' is not supported, supported types are: %s, %s, %s", repository.Type, RepositoryTypeAzureReposGit, RepositoryTypeGitHub, RepositoryTypeBitbucket)
+	}
+
+	return nil
+}
+
+// repositoryFullName returns the full name (owner/repository) of an external repository
+func repositoryFullName(repository *BuildRepository) string {
+	if repository.FullName != "" {
+		return repository.FullName
+	}
+	return repository.ID
+}
+
+// mapRepositoryToAzure converts the repository of the configuration to the repository of a build definition
+func mapRepositoryToAzure(repository *BuildRepository) *BuildDefinitionRepository {
+	if repository == nil {
+		return nil
+	}
+
+	if !isExternalRepository(repository.Type) {
+		return &BuildDefinitionRepository{
+			ID:   repository.ID,
+			Type: normalizeRepoTypeToAzure(repository.Type),
+		}
+	}
+
+	fullName := repositoryFullName(repository)
+	host := "github.com"
+	if repository.Type == RepositoryTypeBitbucket {
+		host = "bitbucket.org"
+	}
+
+	definitionRepository := &BuildDefinitionRepository{
+		ID:   fullName,
+		Name: fullName,
+		Type: normalizeRepoTypeToAzure(repository.Type),
+		URL:  fmt.Sprintf("https://%s/%s.git", host, fullName),
+	}
+	if repository.Connection != nil {
+		definitionRepository.Properties = map[string]interface{}{
+			"connectedServiceId": repository.Connection.ID,
+		}
+	}
+	return definitionRepository
+}
+
+// mapRepositoryFromAzure converts the repository of a build definition to the repository of the configuration
+func mapRepositoryFromAzure(definitionRepository *BuildDefinitionRepository) *BuildRepository {
+	if definitionRepository == nil {
+		return nil
+	}
+
+	repository := &BuildRepository{
+		ID:   definitionRepository.ID,
+		Type: normalizeRepoTypeFromAzure(definitionRepository.Type),
+	}
+
+	if isExternalRepository(repository.Type) {
+		repository.FullName = definitionRepository.Name
+		if repository.FullName == "" {
+			repository.FullName = definitionRepository.ID
+		}
+		if connectedServiceID, ok := definitionRepository.Properties["connectedServiceId"].(string); ok && connectedServiceID != "" {
+			repository.Connection = &ServiceConnectionReference{ID: connectedServiceID}
+		}
+	}
+
+	return repository
+}
+
+// mapConfigurationToAzure converts a (validated) configuration to the process and queue of a build definition
+func mapConfigurationToAzure(configuration *PipelineConfigurationParameters) (*Process, *AgentPoolQueue) {
+	if isDesignerConfiguration(configuration.Type) {
+		return &Process{
+			Type:   ProcessTypeDesigner,
+			Phases: configuration.DesignerJSON.Process.Phases,
+			Target: configuration.DesignerJSON.Process.Target,
+		}, configuration.DesignerJSON.Queue
+	}
+
+	return &Process{
+		Type:         ProcessTypeYAML,
+		YAMLFilename: configuration.Path,
+	}, nil
+}
+
+// mapConfigurationFromAzure converts process, queue and repository of a build definition to the configuration of a pipeline
+func mapConfigurationFromAzure(process *Process, queue *AgentPoolQueue, repository *BuildDefinitionRepository) *PipelineConfiguration {
+	configuration := &PipelineConfiguration{
+		Type:       ConfigurationTypeUnknown,
+		Repository: mapRepositoryFromAzure(repository),
+	}
+
+	if process == nil {
+		return configuration
+	}
+
+	switch process.Type {
+	case ProcessTypeYAML:
+		configuration.Type = ConfigurationTypeYAML
+		configuration.Path = process.YAMLFilename
+	case ProcessTypeDesigner:
+		configuration.Type = ConfigurationTypeDesignerJSON
+		configuration.DesignerJSON = &DesignerConfiguration{
+			Process: &DesignerProcess{
+				Phases: process.Phases,
+				Target: process.Target,
+			},
+			Queue: queue,
+		}
+	case ProcessTypeJustInTime:
+		configuration.Type = ConfigurationTypeJustInTime
+	default:
+		// Older responses may not include the process type, the YAML file name is enough to recognize YAML pipelines
+		if process.YAMLFilename != "" {
+			configuration.Type = ConfigurationTypeYAML
+			configuration.Path = process.YAMLFilename
+		}
+	}
+
+	return configuration
+}
+
+// keepRequestedTypes returns the configuration and repository types as sent in the request when Azure DevOps stores them
+// as the same type (designerHyphenJson as designerJson, azureReposGitHyphenated as azureReposGit)
+func keepRequestedTypes(configuration *PipelineConfiguration, requested *PipelineConfigurationParameters) {
+	if configuration == nil || requested == nil {
+		return
+	}
+	if configuration.Type == ConfigurationTypeDesignerJSON && isDesignerConfiguration(requested.Type) {
+		configuration.Type = requested.Type
+	}
+	if configuration.Repository != nil && requested.Repository != nil &&
+		configuration.Repository.Type == RepositoryTypeAzureReposGit && requested.Repository.Type == RepositoryTypeAzureReposGitHyphenated {
+		configuration.Repository.Type = requested.Repository.Type
+	}
+}
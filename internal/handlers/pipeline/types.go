@@ -1,5 +1,11 @@
 package pipeline
 
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
 // Pipeline represents the response from:
 // GET /{organization}/{project}/_apis/pipelines/{id}
 type Pipeline struct {
@@ -11,13 +17,18 @@ type Pipeline struct {
 	ID       int32  `json:"id,omitempty"`
 	Name     string `json:"name,omitempty"`
 	Revision int32  `json:"revision,omitempty"`
+	// Added by the plugin, read from the triggers of the underlying build definition
+	Triggers *PipelineTriggers `json:"triggers,omitempty"`
+	// Added by the plugin, the fields of the underlying build definition that could not be decoded (left empty in the response)
+	DecodeErrors []string `json:"decodeErrors,omitempty"`
 }
 
 // PipelineConfiguration represents the configuration of a pipeline
 type PipelineConfiguration struct {
-	Type       string           `json:"type,omitempty"` // enum: unknown, yaml, designerJson, justInTime, designerHyphenJson
-	Path       string           `json:"path,omitempty"`
-	Repository *BuildRepository `json:"repository,omitempty"` // Required
+	Type         string                 `json:"type,omitempty"` // enum: unknown, yaml, designerJson, justInTime, designerHyphenJson
+	Path         string                 `json:"path,omitempty"`
+	Repository   *BuildRepository       `json:"repository,omitempty"`   // Required
+	DesignerJSON *DesignerConfiguration `json:"designerJson,omitempty"` // Only for designer (classic) pipelines, added by the plugin
 }
 
 // ReferenceLinks represents a collection of REST reference links
@@ -37,31 +48,123 @@ type CreatePipelineParameters struct {
 
 // CreatePipelineConfigurationParametersComplete represents the configuration for creating a pipeline
 type PipelineConfigurationParameters struct {
-	Type       string           `json:"type"` // Required - enum: unknown, yaml, designerJson, justInTime, designerHyphenJson
-	Path       string           `json:"path,omitempty"`
-	Repository *BuildRepository `json:"repository"` // Required
+	Type         string                 `json:"type"` // Required - enum: unknown, yaml, designerJson, justInTime, designerHyphenJson
+	Path         string                 `json:"path,omitempty"`
+	Repository   *BuildRepository       `json:"repository"`             // Required
+	DesignerJSON *DesignerConfiguration `json:"designerJson,omitempty"` // Required if type is designerJson or designerHyphenJson
 }
 
 // BuildRepository represents repository information for the pipeline
 type BuildRepository struct {
-	ID   string `json:"id"`   // Required
-	Type string `json:"type"` // Required - enum: unknown, gitHub, azureReposGit, azureReposGitHyphenated
+	ID         string                      `json:"id"`                   // Required - for GitHub and Bitbucket repositories it is the full name (e.g., owner/repo)
+	Type       string                      `json:"type"`                 // Required - enum: unknown, gitHub, azureReposGit, azureReposGitHyphenated, bitbucket
+	FullName   string                      `json:"fullName,omitempty"`   // GitHub and Bitbucket repositories only (e.g., owner/repo)
+	Connection *ServiceConnectionReference `json:"connection,omitempty"` // Required for GitHub and Bitbucket repositories
+}
+
+// ServiceConnectionReference represents a reference to the service connection used to access an external repository
+type ServiceConnectionReference struct {
+	ID string `json:"id"`
+}
+
+// DesignerConfiguration represents the definition of a designer (classic) pipeline
+type DesignerConfiguration struct {
+	Process *DesignerProcess `json:"process"` // Required
+	Queue   *AgentPoolQueue  `json:"queue,omitempty"`
+}
+
+// DesignerProcess represents the phases (jobs and steps) of a designer (classic) pipeline
+// Phases and target are forwarded as they are to Azure DevOps
+type DesignerProcess struct {
+	Phases json.RawMessage `json:"phases" swaggertype:"array,object"` // Required
+	Target json.RawMessage `json:"target,omitempty" swaggertype:"object"`
+}
+
+// AgentPoolQueue represents the default agent queue of a designer (classic) pipeline
+type AgentPoolQueue struct {
+	ID   int32  `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// BuildDefinitionRepository represents the repository of a build definition
+type BuildDefinitionRepository struct {
+	ID         string                 `json:"id,omitempty"`
+	Name       string                 `json:"name,omitempty"`
+	Type       string                 `json:"type,omitempty"` // e.g., TfsGit, GitHub, Bitbucket
+	URL        string                 `json:"url,omitempty"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
 }
 
 // BuildDefinitionMinimal represents the request made by the plugin to Azure DevOps API when updating a pipeline
 // The plugin will construct this object starting from the request body (UpdatePipelineParameters) coming from RDC
 type BuildDefinitionMinimal struct {
-	Name       string           `json:"name,omitempty"`
-	Path       string           `json:"path,omitempty"`
-	Repository *BuildRepository `json:"repository,omitempty"`
-	Type       string           `json:"type,omitempty"`
-	Revision   int32            `json:"revision,omitempty"`
-	ID         int32            `json:"id,omitempty"`
-	Process    *Process         `json:"process,omitempty"`
+	Name       string                     `json:"name,omitempty"`
+	Path       string                     `json:"path,omitempty"`
+	Repository *BuildDefinitionRepository `json:"repository,omitempty"`
+	Type       string                     `json:"type,omitempty"` // Definition type: build
+	Revision   int32                      `json:"revision,omitempty"`
+	ID         int32                      `json:"id,omitempty"`
+	Process    *Process                   `json:"process,omitempty"`
+	Queue      *AgentPoolQueue            `json:"queue,omitempty"`
+	Triggers   []BuildTrigger             `json:"triggers,omitempty"`
+}
+
+// BuildDefinition represents the subset of the response from:
+// GET|PUT /{organization}/{project}/_apis/build/definitions/{id}
+// used by the plugin to build the pipeline returned to RDC (see decodeBuildDefinition)
+type BuildDefinition struct {
+	ID         int32                      `json:"id"`
+	Name       string                     `json:"name"`
+	Path       string                     `json:"path"`
+	Revision   int32                      `json:"revision"`
+	URL        string                     `json:"url"`
+	Links      map[string]interface{}     `json:"_links"`
+	Process    *Process                   `json:"process"`
+	Queue      *AgentPoolQueue            `json:"queue"`
+	Repository *BuildDefinitionRepository `json:"repository"`
+	Triggers   []BuildTrigger             `json:"triggers"`
+}
+
+// BuildDefinitionFieldError reports a field of a build definition that could not be decoded
+type BuildDefinitionFieldError struct {
+	Field string
+	Err   error
+}
+
+// BuildDefinitionDecodeError reports the fields of a build definition that could not be decoded
+// The other fields of the build definition are still decoded
+type BuildDefinitionDecodeError struct {
+	Fields []BuildDefinitionFieldError
+}
+
+func (e *BuildDefinitionDecodeError) Error() string {
+	return fmt.Sprintf("failed to decode build definition fields: %s", strings.Join(e.Messages(), "; "))
+}
+
+// Messages returns a message for each field that could not be decoded (e.g., 'process: json: cannot unmarshal ...')
+func (e *BuildDefinitionDecodeError) Messages() []string {
+	messages := make([]string, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		messages = append(messages, fmt.Sprintf("%s: %v", field.Field, field.Err))
+	}
+	return messages
+}
+
+// HasField reports whether the given field could not be decoded
+func (e *BuildDefinitionDecodeError) HasField(field string) bool {
+	for _, f := range e.Fields {
+		if f.Field == field {
+			return true
+		}
+	}
+	return false
 }
 
 type Process struct {
-	YAMLFilename string `json:"yamlFilename,omitempty"` // Required if type is yaml
+	Type         int32           `json:"type,omitempty"`         // enum: 1 (designer), 2 (yaml), 3 (docker), 4 (justInTime)
+	YAMLFilename string          `json:"yamlFilename,omitempty"` // Required if type is yaml
+	Phases       json.RawMessage `json:"phases,omitempty"`       // Required if type is designer
+	Target       json.RawMessage `json:"target,omitempty"`
 }
 
 type UpdatePipelineParameters struct {
@@ -70,6 +173,177 @@ type UpdatePipelineParameters struct {
 	Name          string                           `json:"name"`
 	ID            int32                            `json:"id"` // maybe to be removed since RDC does not include it
 	Revision      int32                            `json:"revision"`
+	Triggers      *PipelineTriggers                `json:"triggers,omitempty"` // Additional field handled by the plugin
+}
+
+// PipelineTriggers represents the trigger overrides of a pipeline as exposed by the plugin
+// The plugin maps it to and from the 'triggers' array of the underlying build definition
+type PipelineTriggers struct {
+	CI          *CITrigger          `json:"ci,omitempty"`
+	PullRequest *PullRequestTrigger `json:"pullRequest,omitempty"`
+	Schedule    *ScheduleTrigger    `json:"schedule,omitempty"`
+}
+
+// CITrigger represents the continuous integration trigger of a pipeline
+// If OverrideYAML is false, the trigger defined in the YAML file is used and the filters are ignored
+type CITrigger struct {
+	Enabled                      bool     `json:"enabled"`
+	OverrideYAML                 bool     `json:"overrideYaml"`
+	BranchFilters                []string `json:"branchFilters,omitempty"`
+	PathFilters                  []string `json:"pathFilters,omitempty"`
+	BatchChanges                 bool     `json:"batchChanges,omitempty"`
+	MaxConcurrentBuildsPerBranch int32    `json:"maxConcurrentBuildsPerBranch,omitempty"`
+}
+
+// PullRequestTrigger represents the pull request trigger of a pipeline
+// If OverrideYAML is false, the trigger defined in the YAML file is used and the filters are ignored
+type PullRequestTrigger struct {
+	Enabled                              bool              `json:"enabled"`
+	OverrideYAML                         bool              `json:"overrideYaml"`
+	BranchFilters                        []string          `json:"branchFilters,omitempty"`
+	PathFilters                          []string          `json:"pathFilters,omitempty"`
+	AutoCancel                           bool              `json:"autoCancel,omitempty"`
+	Forks                                *PullRequestForks `json:"forks,omitempty"`
+	IsCommentRequiredForPullRequest      bool              `json:"isCommentRequiredForPullRequest,omitempty"`
+	RequireCommentsForNonTeamMembersOnly bool              `json:"requireCommentsForNonTeamMembersOnly,omitempty"`
+}
+
+// PullRequestForks represents the settings for pull requests coming from forks
+type PullRequestForks struct {
+	Enabled      bool `json:"enabled"`
+	AllowSecrets bool `json:"allowSecrets"`
+}
+
+// ScheduleTrigger represents the scheduled triggers of a pipeline
+// If OverrideYAML is true, the schedules below replace the ones defined in the YAML file (an empty list disables them)
+type ScheduleTrigger struct {
+	OverrideYAML bool               `json:"overrideYaml"`
+	Schedules    []PipelineSchedule `json:"schedules,omitempty"`
+}
+
+// PipelineSchedule represents a single schedule of a pipeline
+type PipelineSchedule struct {
+	BranchFilters           []string `json:"branchFilters,omitempty"`
+	DaysToBuild             []string `json:"daysToBuild,omitempty"` // e.g., monday, tuesday (ordered from monday to sunday)
+	StartHours              int32    `json:"startHours"`
+	StartMinutes            int32    `json:"startMinutes"`
+	TimeZoneID              string   `json:"timeZoneId,omitempty"`
+	ScheduleOnlyWithChanges bool     `json:"scheduleOnlyWithChanges"`
+}
+
+// BuildTrigger represents an entry of the 'triggers' array of a build definition
+// Fields not related to the trigger type are left empty
+type BuildTrigger struct {
+	TriggerType                          string          `json:"triggerType"` // enum: continuousIntegration, pullRequest, schedule, ...
+	SettingsSourceType                   int32           `json:"settingsSourceType,omitempty"`
+	BranchFilters                        []string        `json:"branchFilters,omitempty"`
+	PathFilters                          []string        `json:"pathFilters,omitempty"`
+	BatchChanges                         bool            `json:"batchChanges,omitempty"`
+	MaxConcurrentBuildsPerBranch         int32           `json:"maxConcurrentBuildsPerBranch,omitempty"`
+	AutoCancel                           *bool           `json:"autoCancel,omitempty"`
+	Forks                                *Forks          `json:"forks,omitempty"`
+	IsCommentRequiredForPullRequest      bool            `json:"isCommentRequiredForPullRequest,omitempty"`
+	RequireCommentsForNonTeamMembersOnly bool            `json:"requireCommentsForNonTeamMembersOnly,omitempty"`
+	Schedules                            []BuildSchedule `json:"schedules,omitempty"`
+}
+
+// Forks represents the fork settings of a pull request build trigger
+type Forks struct {
+	Enabled      bool `json:"enabled"`
+	AllowSecrets bool `json:"allowSecrets"`
+}
+
+// BuildSchedule represents a schedule of a build definition schedule trigger
+type BuildSchedule struct {
+	BranchFilters           []string     `json:"branchFilters,omitempty"`
+	DaysToBuild             ScheduleDays `json:"daysToBuild"`
+	ScheduleJobID           string       `json:"scheduleJobId,omitempty"`
+	ScheduleOnlyWithChanges bool         `json:"scheduleOnlyWithChanges"`
+	StartHours              int32        `json:"startHours"`
+	StartMinutes            int32        `json:"startMinutes"`
+	TimeZoneID              string       `json:"timeZoneId,omitempty"`
+}
+
+// ScheduleDays is the bitmask of days used by build definition schedules
+// Azure DevOps may return it either as a number or as a comma-separated list of day names
+type ScheduleDays int32
+
+// scheduleDayNames lists the single days in the order used by the plugin
+var scheduleDayNames = []struct {
+	Name string
+	Day  ScheduleDays
+}{
+	{"monday", ScheduleDaysMonday},
+	{"tuesday", ScheduleDaysTuesday},
+	{"wednesday", ScheduleDaysWednesday},
+	{"thursday", ScheduleDaysThursday},
+	{"friday", ScheduleDaysFriday},
+	{"saturday", ScheduleDaysSaturday},
+	{"sunday", ScheduleDaysSunday},
+}
+
+// UnmarshalJSON implements json.Unmarshaler for ScheduleDays
+func (d *ScheduleDays) UnmarshalJSON(data []byte) error {
+	var number int32
+	if err := json.Unmarshal(data, &number); err == nil {
+		*d = ScheduleDays(number)
+		return nil
+	}
+
+	var names string
+	if err := json.Unmarshal(data, &names); err != nil {
+		return fmt.Errorf("unable to parse daysToBuild: %s", string(data))
+	}
+
+	days, err := ParseScheduleDays(strings.Split(names, ","))
+	if err != nil {
+		return err
+	}
+	*d = days
+	return nil
+}
+
+// Names returns the day names contained in the bitmask, ordered from monday to sunday
+// A bitmask with all the days is returned as 'all', as sent in the request
+func (d ScheduleDays) Names() []string {
+	if d&ScheduleDaysAll == ScheduleDaysAll {
+		return []string{"all"}
+	}
+	names := []string{}
+	for _, day := range scheduleDayNames {
+		if d&day.Day != 0 {
+			names = append(names, day.Name)
+		}
+	}
+	return names
+}
+
+// ParseScheduleDays converts a list of day names (case insensitive, 'all' and 'none' allowed) to a bitmask
+func ParseScheduleDays(names []string) (ScheduleDays, error) {
+	var days ScheduleDays
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch name {
+		case "", "none":
+			continue
+		case "all":
+			days |= ScheduleDaysAll
+			continue
+		}
+
+		found := false
+		for _, day := range scheduleDayNames {
+			if day.Name == name {
+				days |= day.Day
+				found = true
+				break
+			}
+		}
+		if !found {
+			return ScheduleDaysNone, fmt.Errorf("invalid day '%s' in daysToBuild", name)
+		}
+	}
+	return days, nil
 }
 
 // ConfigurationType enum values
@@ -81,17 +355,62 @@ const (
 	ConfigurationTypeDesignerHyphenJSON = "designerHyphenJson"
 )
 
+// TriggerType enum values (build definition triggers managed by the plugin)
+const (
+	TriggerTypeContinuousIntegration = "continuousIntegration"
+	TriggerTypePullRequest           = "pullRequest"
+	TriggerTypeSchedule              = "schedule"
+)
+
+// SettingsSourceType enum values
+const (
+	SettingsSourceTypeDefinition = 1 // settings overridden in the build definition
+	SettingsSourceTypeProcess    = 2 // settings read from the YAML file
+)
+
+// ScheduleDays flag values
+const (
+	ScheduleDaysNone      ScheduleDays = 0
+	ScheduleDaysMonday    ScheduleDays = 1
+	ScheduleDaysTuesday   ScheduleDays = 2
+	ScheduleDaysWednesday ScheduleDays = 4
+	ScheduleDaysThursday  ScheduleDays = 8
+	ScheduleDaysFriday    ScheduleDays = 16
+	ScheduleDaysSaturday  ScheduleDays = 32
+	ScheduleDaysSunday    ScheduleDays = 64
+	ScheduleDaysAll       ScheduleDays = 127
+)
+
 // RepositoryType enum values
 const (
 	RepositoryTypeUnknown                 = "unknown"
 	RepositoryTypeGitHub                  = "gitHub"
 	RepositoryTypeAzureReposGit           = "azureReposGit"
 	RepositoryTypeAzureReposGitHyphenated = "azureReposGitHyphenated"
+	RepositoryTypeBitbucket               = "bitbucket"
+)
+
+// ProcessType enum values (build definition process)
+const (
+	ProcessTypeDesigner   = 1
+	ProcessTypeYAML       = 2
+	ProcessTypeDocker     = 3
+	ProcessTypeJustInTime = 4
 )
 
+// DefinitionTypeBuild is the only build definition type supported by the plugin
+const DefinitionTypeBuild = "build"
+
 // GetPipelineResponse represents the response for getting a single pipeline
 type GetPipelineResponse Pipeline
 
+// ListPipelinesResponse represents the response for listing pipelines
+// Items have the same shape as GetPipelineResponse (without triggers)
+type ListPipelinesResponse struct {
+	Count int               `json:"count"`
+	Value []json.RawMessage `json:"value" swaggertype:"array,object"`
+}
+
 // CreatePipelineRequest represents the request for creating a pipeline
 //type CreatePipelineRequest CreatePipelineParametersComplete
 
@@ -103,3 +422,41 @@ type UpdatePipelineRequest UpdatePipelineParameters
 
 // UpdatePipelineResponse represents the response for updating a pipeline
 type UpdatePipelineResponse Pipeline
+
+// Folder represents a pipeline (build) folder from:
+// GET /{organization}/{project}/_apis/build/folders/{path}
+// The plugin returns the path normalized (e.g., 'folder/subfolder' instead of '\folder\subfolder')
+type Folder struct {
+	CreatedOn       string                `json:"createdOn,omitempty"`
+	Description     string                `json:"description,omitempty"`
+	LastChangedDate string                `json:"lastChangedDate,omitempty"`
+	Path            string                `json:"path"`
+	Project         *TeamProjectReference `json:"project,omitempty"`
+}
+
+// TeamProjectReference represents a shallow reference to a TeamProject
+type TeamProjectReference struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// FolderList represents the list response of the build folders endpoint
+type FolderList struct {
+	Count int32    `json:"count"`
+	Value []Folder `json:"value"`
+}
+
+// CreateFolderRequest represents the request for creating a pipeline folder
+type CreateFolderRequest struct {
+	Path        string `json:"path"` // Required - any separator ('/' or '\') is accepted
+	Description string `json:"description,omitempty"`
+}
+
+// FolderResponse represents the response for getting or creating a pipeline folder
+type FolderResponse Folder
+
+// ListFoldersResponse represents the response for listing pipeline folders
+type ListFoldersResponse struct {
+	Count int              `json:"count"`
+	Value []FolderResponse `json:"value"`
+}
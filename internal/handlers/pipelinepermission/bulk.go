@@ -0,0 +1,411 @@
+package pipelinepermission
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers"
+)
+
+// Handler constructors
+func QueryPipelinePermissions(opts handlers.HandlerOptions) handlers.Handler {
+	return &bulkQueryHandler{baseHandler: newBaseHandler(opts)}
+}
+
+// PutPipelinePermissions replaces the pipeline permissions of many resources (see PutPipelinePermission)
+func PutPipelinePermissions(opts handlers.HandlerOptions) handlers.Handler {
+	return &bulkUpdateHandler{baseHandler: newBaseHandler(opts), replace: true}
+}
+
+// PatchPipelinePermissions updates the listed pipeline permissions of many resources (see PatchPipelinePermission)
+func PatchPipelinePermissions(opts handlers.HandlerOptions) handlers.Handler {
+	return &bulkUpdateHandler{baseHandler: newBaseHandler(opts), replace: false}
+}
+
+// Interface compliance verification
+var _ handlers.Handler = &bulkQueryHandler{}
+var _ handlers.Handler = &bulkUpdateHandler{}
+
+// Handler types embedding the base handler
+type bulkQueryHandler struct {
+	*baseHandler
+}
+
+type bulkUpdateHandler struct {
+	*baseHandler
+	replace bool // true for PUT, false for PATCH
+}
+
+// bulkResource keeps track of a resource of a bulk request while it is processed
+type bulkResource struct {
+	resourceType ResourceType
+	resourceId   string
+	delta        *ResourcePipelinePermissions // Entries to be sent to Azure DevOps, nil if the resource is up to date
+	result       *BulkPipelinePermissionsResult
+}
+
+// key identifies a resource in a bulk request, resource IDs are GUIDs or numbers and compared case insensitively
+func (r *bulkResource) key() string {
+	return string(r.resourceType) + "/" + strings.ToLower(r.resourceId)
+}
+
+// Bulk query handler implementation
+// @Summary Get the pipeline permissions of many resources
+// @Description Get the pipeline permissions of the listed resources. A failure on a resource does not prevent the others from being returned.
+// @ID query-pipelinepermissions
+// @Param organization path string true "Organization name"
+// @Param project path string true "Project name"
+// @Param api-version query string true "API version (e.g., 7.2-preview.1)"
+// @Param stripAuditFields query bool false "Remove the volatile authorizedOn and authorizedBy fields from the response"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Param resources body BulkPipelinePermissionsQueryRequest true "Resources to query"
+// @Accept json
+// @Produce json
+// @Success 200 {object} BulkPipelinePermissionsResponse "Pipeline permissions of all the resources"
+// @Success 207 {object} BulkPipelinePermissionsResponse "Pipeline permissions of the resources, some of them failed"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Router /api/{organization}/{project}/pipelines/pipelinepermissions/query [post]
+func (h *bulkQueryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	project := r.PathValue("project")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	// This single check handles missing headers, incorrect formats, and empty credentials.
+	username, password, ok := r.BasicAuth()
+	if !ok || username == "" || password == "" {
+		h.writeErrorResponse(w, http.StatusUnauthorized, "Request rejected due to missing or invalid Basic authentication")
+		return
+	}
+
+	stripAuditFields, ok := h.parseStripAuditFields(w, r)
+	if !ok {
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Failed to read request body")
+		return
+	}
+
+	var queryRequest BulkPipelinePermissionsQueryRequest
+	if err := json.Unmarshal(body, &queryRequest); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid JSON in request body")
+		h.Log.Printf("Failed to unmarshal request body: %v", err)
+		return
+	}
+
+	if err := validateBulkResourceCount(len(queryRequest.Resources)); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid bulk request: %v", err))
+		return
+	}
+
+	h.Log.Printf("Getting pipeline permissions of %d resources for %s/%s", len(queryRequest.Resources), organization, project)
+
+	results := make([]BulkPipelinePermissionsResult, len(queryRequest.Resources))
+	for i, reference := range queryRequest.Resources {
+		resource := newBulkResource(reference, &results[i])
+		if resource == nil {
+			continue
+		}
+
+		_, currentBody, err := h.getPipelinePermissions(organization, project, string(resource.resourceType), resource.resourceId, apiVersion, authHeader)
+		if err != nil {
+			setBulkFailure(resource.result, err)
+			continue
+		}
+		h.setBulkSuccess(resource.result, BulkStatusRetrieved, currentBody, stripAuditFields)
+	}
+
+	h.writeBulkResponse(w, results)
+}
+
+// Bulk update handler implementation
+// @Summary Update the pipeline permissions of many resources
+// @Description Authorize or revoke pipelines on many protected resources with a single batch request to Azure DevOps.
+// @Description Only the resources that differ from the desired state are updated. With PUT, authorized pipelines not listed in the request are revoked. With PATCH, only the listed pipelines are updated.
+// @Description If the batch request fails, the resources are updated one by one so that the failures are reported per resource.
+// @ID update-pipelinepermissions
+// @Param organization path string true "Organization name"
+// @Param project path string true "Project name"
+// @Param api-version query string true "API version (e.g., 7.2-preview.1)"
+// @Param stripAuditFields query bool false "Remove the volatile authorizedOn and authorizedBy fields from the response"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Param resources body BulkPipelinePermissionsUpdateRequest true "Desired pipeline permissions of the resources"
+// @Accept json
+// @Produce json
+// @Success 200 {object} BulkPipelinePermissionsResponse "Pipeline permissions of all the resources match the desired state"
+// @Success 207 {object} BulkPipelinePermissionsResponse "Some resources could not be updated"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Router /api/{organization}/{project}/pipelines/pipelinepermissions [put]
+// @Router /api/{organization}/{project}/pipelines/pipelinepermissions [patch]
+func (h *bulkUpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	project := r.PathValue("project")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	// This single check handles missing headers, incorrect formats, and empty credentials.
+	username, password, ok := r.BasicAuth()
+	if !ok || username == "" || password == "" {
+		h.writeErrorResponse(w, http.StatusUnauthorized, "Request rejected due to missing or invalid Basic authentication")
+		return
+	}
+
+	stripAuditFields, ok := h.parseStripAuditFields(w, r)
+	if !ok {
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Failed to read request body")
+		return
+	}
+
+	var updateRequest BulkPipelinePermissionsUpdateRequest
+	if err := json.Unmarshal(body, &updateRequest); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid JSON in request body")
+		h.Log.Printf("Failed to unmarshal request body: %v", err)
+		return
+	}
+
+	if err := validateBulkResourceCount(len(updateRequest.Resources)); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid bulk request: %v", err))
+		return
+	}
+
+	h.Log.Printf("Updating pipeline permissions of %d resources for %s/%s", len(updateRequest.Resources), organization, project)
+
+	// Validate the resources and compute the entries to be authorized or revoked for each of them
+	results := make([]BulkPipelinePermissionsResult, len(updateRequest.Resources))
+	seen := make(map[string]bool, len(updateRequest.Resources))
+	pending := make([]*bulkResource, 0, len(updateRequest.Resources))
+	for i, desired := range updateRequest.Resources {
+		resource := newBulkResource(desired.BulkResourceReference, &results[i])
+		if resource == nil {
+			continue
+		}
+		if seen[resource.key()] {
+			setBulkValidationFailure(resource.result, fmt.Sprintf("Resource %s is listed more than once", resource.key()))
+			continue
+		}
+		seen[resource.key()] = true
+
+		if err := validateUpdatePipelinePermissionRequest(&desired.UpdatePipelinePermissionRequest); err != nil {
+			setBulkValidationFailure(resource.result, fmt.Sprintf("Invalid pipeline permission: %v", err))
+			continue
+		}
+
+		current, currentBody, err := h.getPipelinePermissions(organization, project, string(resource.resourceType), resource.resourceId, apiVersion, authHeader)
+		if err != nil {
+			setBulkFailure(resource.result, err)
+			continue
+		}
+
+		resource.delta = computePipelinePermissionsDelta(current, &desired.UpdatePipelinePermissionRequest, h.replace)
+		if resource.delta == nil {
+			h.setBulkSuccess(resource.result, BulkStatusUnchanged, currentBody, stripAuditFields)
+			continue
+		}
+		resource.delta.Resource = &Resource{Type: string(resource.resourceType), ID: resource.resourceId}
+		pending = append(pending, resource)
+	}
+
+	if len(pending) > 0 {
+		h.applyBulkUpdate(organization, project, apiVersion, authHeader, pending, stripAuditFields)
+	}
+
+	h.writeBulkResponse(w, results)
+}
+
+// applyBulkUpdate sends the pending entries with a single batch request to Azure DevOps
+// If the batch request fails, the resources are updated one by one to report the failures per resource
+func (h *bulkUpdateHandler) applyBulkUpdate(organization, project, apiVersion, authHeader string, pending []*bulkResource, stripAuditFields bool) {
+	updated, err := h.patchPipelinePermissionsBatch(organization, project, apiVersion, authHeader, pending)
+	if err != nil {
+		h.Log.Printf("Batch update of pipeline permissions failed, updating the %d resources one by one: %v", len(pending), err)
+		for _, resource := range pending {
+			_, updatedBody, err := h.patchPipelinePermissions(organization, project, string(resource.resourceType), resource.resourceId, apiVersion, authHeader, resource.delta)
+			if err != nil {
+				setBulkFailure(resource.result, err)
+				continue
+			}
+			h.setBulkSuccess(resource.result, BulkStatusUpdated, updatedBody, stripAuditFields)
+		}
+		return
+	}
+
+	for _, resource := range pending {
+		updatedBody, ok := updated[resource.key()]
+		if !ok {
+			// Not expected, read the state of the resource to return it anyway
+			h.Log.Printf("Resource %s not found in the batch update response, reading it", resource.key())
+			_, currentBody, err := h.getPipelinePermissions(organization, project, string(resource.resourceType), resource.resourceId, apiVersion, authHeader)
+			if err != nil {
+				setBulkFailure(resource.result, err)
+				continue
+			}
+			updatedBody = currentBody
+		}
+		h.setBulkSuccess(resource.result, BulkStatusUpdated, updatedBody, stripAuditFields)
+	}
+}
+
+// patchPipelinePermissionsBatch applies the pending entries with the batch pipeline permissions API
+// It returns the updated pipeline permissions indexed by resource key
+func (h *baseHandler) patchPipelinePermissionsBatch(organization, project, apiVersion, authHeader string, pending []*bulkResource) (map[string][]byte, error) {
+	url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/pipelines/pipelinepermissions?api-version=%s", organization, project, apiVersion)
+
+	deltas := make([]*ResourcePipelinePermissions, 0, len(pending))
+	for _, resource := range pending {
+		deltas = append(deltas, resource.delta)
+	}
+
+	requestBody, err := json.Marshal(deltas)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal batch pipeline permission update: %w", err)
+	}
+	h.Log.Printf("Updating pipeline permissions with batch request body: %s", string(requestBody))
+
+	resp, err := h.makeAzuredevopsRequest("PATCH", url, authHeader, requestBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make batch pipeline permission request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		h.Log.Printf("Azure DevOps API returned a non-200 status for batch update: %d. Body: %s", resp.StatusCode, string(body))
+		return nil, &azureDevOpsError{StatusCode: resp.StatusCode, Body: body}
+	}
+
+	var items []json.RawMessage
+	if err := json.Unmarshal(body, &items); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal batch pipeline permission response: %w", err)
+	}
+
+	updated := make(map[string][]byte, len(items))
+	for _, item := range items {
+		var permissions ResourcePipelinePermissions
+		if err := json.Unmarshal(item, &permissions); err != nil || permissions.Resource == nil {
+			h.Log.Printf("Skipping unexpected item in batch update response: %s", string(item))
+			continue
+		}
+		resourceType, err := parseResourceType(permissions.Resource.Type)
+		if err != nil {
+			h.Log.Printf("Skipping item with unexpected resource type in batch update response: %s", string(item))
+			continue
+		}
+		resource := bulkResource{resourceType: resourceType, resourceId: permissions.Resource.ID}
+		updated[resource.key()] = item
+	}
+
+	return updated, nil
+}
+
+// newBulkResource validates a resource of a bulk request
+// It returns nil, recording the failure in the result, if the resource is not valid
+func newBulkResource(reference BulkResourceReference, result *BulkPipelinePermissionsResult) *bulkResource {
+	result.ResourceType = reference.ResourceType
+	result.ResourceID = reference.ResourceID
+
+	resourceType, err := parseResource(reference.ResourceType, reference.ResourceID)
+	if err != nil {
+		setBulkValidationFailure(result, fmt.Sprintf("Invalid resource: %v", err))
+		return nil
+	}
+	result.ResourceType = string(resourceType)
+
+	return &bulkResource{resourceType: resourceType, resourceId: reference.ResourceID, result: result}
+}
+
+// setBulkValidationFailure records a validation error (400) in the result of a resource
+func setBulkValidationFailure(result *BulkPipelinePermissionsResult, message string) {
+	result.Status = BulkStatusFailed
+	result.StatusCode = http.StatusBadRequest
+	result.Error = message
+}
+
+// setBulkFailure records an error returned while calling Azure DevOps in the result of a resource
+// Errors returned by Azure DevOps keep their status code, other errors are reported as 500
+func setBulkFailure(result *BulkPipelinePermissionsResult, err error) {
+	result.Status = BulkStatusFailed
+	result.Permissions = nil
+
+	var adoErr *azureDevOpsError
+	if !errors.As(err, &adoErr) {
+		result.StatusCode = http.StatusInternalServerError
+		result.Error = err.Error()
+		return
+	}
+
+	result.StatusCode = adoErr.StatusCode
+	if adoErr.StatusCode == http.StatusNotFound {
+		result.Error = "Pipeline permission for resource not found"
+		return
+	}
+	result.Error = string(adoErr.Body)
+}
+
+// setBulkSuccess records the normalized pipeline permissions in the result of a resource
+func (h *baseHandler) setBulkSuccess(result *BulkPipelinePermissionsResult, status string, body []byte, stripAuditFields bool) {
+	processedBody, err := h.processPipelinepermissionResponse(body, stripAuditFields)
+	if err != nil {
+		h.Log.Printf("Failed to process response, returning original raw response from Azure DevOps API: %v", err)
+		processedBody = body
+	}
+	result.Status = status
+	result.Permissions = processedBody
+}
+
+// validateBulkResourceCount checks the number of resources of a bulk request
+func validateBulkResourceCount(count int) error {
+	if count == 0 {
+		return fmt.Errorf("at least one resource is required")
+	}
+	if count > maxBulkResources {
+		return fmt.Errorf("at most %d resources are allowed, got %d", maxBulkResources, count)
+	}
+	return nil
+}
+
+// writeBulkResponse writes the results of a bulk request
+// The status code is 200 if all the resources succeeded, 207 (Multi-Status) otherwise
+func (h *baseHandler) writeBulkResponse(w http.ResponseWriter, results []BulkPipelinePermissionsResult) {
+	response := BulkPipelinePermissionsResponse{
+		Count:   len(results),
+		Results: results,
+	}
+	for _, result := range results {
+		if result.Status == BulkStatusFailed {
+			response.Failed++
+		} else {
+			response.Succeeded++
+		}
+	}
+
+	responseBytes, err := json.Marshal(response)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Failed to marshal response: %v", err))
+		return
+	}
+
+	statusCode := http.StatusOK
+	if response.Failed > 0 {
+		statusCode = http.StatusMultiStatus
+	}
+	h.writeJSONResponse(w, statusCode, responseBytes)
+	h.Log.Printf("Bulk pipeline permission request completed: %d succeeded, %d failed", response.Succeeded, response.Failed)
+}
@@ -0,0 +1,309 @@
+package pipelinepermission
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers"
+	"github.com/rs/zerolog"
+)
+
+const (
+	testEndpointID = "8d0ac7a4-4c6a-4c56-9b6d-7d6f1b0e3a21"
+)
+
+var (
+	queuePermissionURL    = fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/pipelines/pipelinepermissions/queue/12?api-version=%s", testOrg, testProject, testAPIVersion)
+	endpointPermissionURL = fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/pipelines/pipelinepermissions/endpoint/%s?api-version=%s", testOrg, testProject, testEndpointID, testAPIVersion)
+	batchPermissionURL    = fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/pipelines/pipelinepermissions?api-version=%s", testOrg, testProject, testAPIVersion)
+
+	queuePermissionResp    = `{"resource":{"type":"queue","id":"12"},"pipelines":[{"id":5,"authorized":true}],"allPipelines":{"authorized":false}}`
+	endpointPermissionResp = `{"resource":{"type":"endpoint","id":"` + testEndpointID + `"},"pipelines":[]}`
+)
+
+func createTestBulkQueryHandler(mockClient *mockHTTPClient) *bulkQueryHandler {
+	logger := zerolog.New(io.Discard).With().Timestamp().Logger()
+	return &bulkQueryHandler{
+		baseHandler: &baseHandler{
+			HandlerOptions: handlers.HandlerOptions{
+				Client: mockClient,
+				Log:    &logger,
+			},
+		},
+	}
+}
+
+func createTestBulkUpdateHandler(mockClient *mockHTTPClient, replace bool) *bulkUpdateHandler {
+	logger := zerolog.New(io.Discard).With().Timestamp().Logger()
+	return &bulkUpdateHandler{
+		baseHandler: &baseHandler{
+			HandlerOptions: handlers.HandlerOptions{
+				Client: mockClient,
+				Log:    &logger,
+			},
+		},
+		replace: replace,
+	}
+}
+
+func TestBulkQueryHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name                 string
+		authHeader           string
+		requestBody          string
+		setupMock            func(*mockHTTPClient)
+		expectedStatus       int
+		expectedBodyContains string
+		expectedRequestCount int
+		verifyResponse       func(t *testing.T, response BulkPipelinePermissionsResponse)
+	}{
+		{
+			name:        "all resources retrieved",
+			authHeader:  testAuthHeader,
+			requestBody: `{"resources":[{"resourceType":"Queue","resourceId":"12"},{"resourceType":"endpoint","resourceId":"` + testEndpointID + `"}]}`,
+			setupMock: func(mockClient *mockHTTPClient) {
+				mockClient.setResponse(queuePermissionURL, http.StatusOK, queuePermissionResp)
+				mockClient.setResponse(endpointPermissionURL, http.StatusOK, endpointPermissionResp)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedRequestCount: 2,
+			verifyResponse: func(t *testing.T, response BulkPipelinePermissionsResponse) {
+				if response.Count != 2 || response.Succeeded != 2 || response.Failed != 0 {
+					t.Errorf("unexpected counters: %+v", response)
+				}
+				if response.Results[0].ResourceType != "queue" || response.Results[0].Status != BulkStatusRetrieved {
+					t.Errorf("unexpected first result: %+v", response.Results[0])
+				}
+				if !strings.Contains(string(response.Results[1].Permissions), `"allPipelines":{"authorized":false},"pipelines":[]`) {
+					t.Errorf("expected normalized permissions, got %s", string(response.Results[1].Permissions))
+				}
+			},
+		},
+		{
+			name:        "partial failure",
+			authHeader:  testAuthHeader,
+			requestBody: `{"resources":[{"resourceType":"queue","resourceId":"12"},{"resourceType":"endpoint","resourceId":"` + testEndpointID + `"},{"resourceType":"pipelines","resourceId":"1"}]}`,
+			setupMock: func(mockClient *mockHTTPClient) {
+				mockClient.setResponse(queuePermissionURL, http.StatusOK, queuePermissionResp)
+				mockClient.setResponse(endpointPermissionURL, http.StatusNotFound, permissionNotFoundResp)
+			},
+			expectedStatus:       http.StatusMultiStatus,
+			expectedRequestCount: 2,
+			verifyResponse: func(t *testing.T, response BulkPipelinePermissionsResponse) {
+				if response.Count != 3 || response.Succeeded != 1 || response.Failed != 2 {
+					t.Errorf("unexpected counters: %+v", response)
+				}
+				if response.Results[1].Status != BulkStatusFailed || response.Results[1].StatusCode != http.StatusNotFound {
+					t.Errorf("unexpected second result: %+v", response.Results[1])
+				}
+				if response.Results[2].StatusCode != http.StatusBadRequest || !strings.Contains(response.Results[2].Error, "resource type 'pipelines' is not supported") {
+					t.Errorf("unexpected third result: %+v", response.Results[2])
+				}
+			},
+		},
+		{
+			name:                 "missing authorization header",
+			requestBody:          `{"resources":[{"resourceType":"queue","resourceId":"12"}]}`,
+			expectedStatus:       http.StatusUnauthorized,
+			expectedBodyContains: "Request rejected due to missing or invalid Basic authentication",
+		},
+		{
+			name:                 "no resources",
+			authHeader:           testAuthHeader,
+			requestBody:          `{"resources":[]}`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBodyContains: "Invalid bulk request: at least one resource is required",
+		},
+		{
+			name:                 "invalid JSON",
+			authHeader:           testAuthHeader,
+			requestBody:          `{"resources":`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBodyContains: "Invalid JSON in request body",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := newMockHTTPClient()
+			if tt.setupMock != nil {
+				tt.setupMock(mockClient)
+			}
+
+			handler := createTestBulkQueryHandler(mockClient)
+
+			url := fmt.Sprintf("/api/placeholder/placeholder/pipelines/pipelinepermissions/query?api-version=%s", testAPIVersion)
+			req := httptest.NewRequest("POST", url, strings.NewReader(tt.requestBody))
+			req.SetPathValue("organization", testOrg)
+			req.SetPathValue("project", testProject)
+			if tt.authHeader != "" {
+				req.SetBasicAuth(testUsername, testPassword)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			verifyBulkResponse(t, rr, mockClient, tt.expectedStatus, tt.expectedBodyContains, tt.expectedRequestCount, tt.verifyResponse)
+		})
+	}
+}
+
+func TestBulkUpdateHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name                 string
+		replace              bool
+		requestBody          string
+		setupMock            func(*mockHTTPClient)
+		expectedStatus       int
+		expectedBodyContains string
+		expectedRequestCount int
+		verifyRequests       func(t *testing.T, mockClient *mockHTTPClient)
+		verifyResponse       func(t *testing.T, response BulkPipelinePermissionsResponse)
+	}{
+		{
+			name:        "single batch request for changed resources",
+			replace:     true,
+			requestBody: `{"resources":[{"resourceType":"queue","resourceId":"12","pipelines":[{"id":5}]},{"resourceType":"endpoint","resourceId":"` + testEndpointID + `","pipelines":[{"id":5}]}]}`,
+			setupMock: func(mockClient *mockHTTPClient) {
+				mockClient.setResponse(queuePermissionURL, http.StatusOK, queuePermissionResp)
+				mockClient.setResponse(endpointPermissionURL, http.StatusOK, endpointPermissionResp)
+				mockClient.setResponse(batchPermissionURL, http.StatusOK, `[{"resource":{"type":"endpoint","id":"`+strings.ToUpper(testEndpointID)+`"},"pipelines":[{"id":5,"authorized":true}]}]`)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedRequestCount: 3,
+			verifyRequests: func(t *testing.T, mockClient *mockHTTPClient) {
+				req := mockClient.getLastRequest()
+				if req.Method != "PATCH" || req.URL.String() != batchPermissionURL {
+					t.Fatalf("expected batch PATCH request, got %s %s", req.Method, req.URL.String())
+				}
+				body, _ := io.ReadAll(req.Body)
+				expected := `[{"pipelines":[{"authorized":true,"id":5}],"resource":{"id":"` + testEndpointID + `","type":"endpoint"}}]`
+				if string(body) != expected {
+					t.Errorf("unexpected batch body.\nGot: %s\nWant: %s", string(body), expected)
+				}
+			},
+			verifyResponse: func(t *testing.T, response BulkPipelinePermissionsResponse) {
+				if response.Succeeded != 2 || response.Failed != 0 {
+					t.Errorf("unexpected counters: %+v", response)
+				}
+				if response.Results[0].Status != BulkStatusUnchanged {
+					t.Errorf("expected queue to be unchanged, got %+v", response.Results[0])
+				}
+				if response.Results[1].Status != BulkStatusUpdated || !strings.Contains(string(response.Results[1].Permissions), `"pipelines":[{"authorized":true,"id":5}]`) {
+					t.Errorf("unexpected endpoint result: %+v", response.Results[1])
+				}
+			},
+		},
+		{
+			name:        "batch failure falls back to one request per resource",
+			replace:     false,
+			requestBody: `{"resources":[{"resourceType":"queue","resourceId":"12","pipelines":[{"id":6}]},{"resourceType":"endpoint","resourceId":"` + testEndpointID + `","pipelines":[{"id":6}]}]}`,
+			setupMock: func(mockClient *mockHTTPClient) {
+				mockClient.setMethodResponse("GET", queuePermissionURL, http.StatusOK, queuePermissionResp)
+				mockClient.setMethodResponse("GET", endpointPermissionURL, http.StatusOK, endpointPermissionResp)
+				mockClient.setResponse(batchPermissionURL, http.StatusBadRequest, `{"message":"invalid resource"}`)
+				mockClient.setMethodResponse("PATCH", queuePermissionURL, http.StatusOK, `{"resource":{"type":"queue","id":"12"},"pipelines":[{"id":6,"authorized":true},{"id":5,"authorized":true}]}`)
+				mockClient.setMethodResponse("PATCH", endpointPermissionURL, http.StatusForbidden, `{"message":"Forbidden"}`)
+			},
+			expectedStatus:       http.StatusMultiStatus,
+			expectedRequestCount: 5,
+			verifyResponse: func(t *testing.T, response BulkPipelinePermissionsResponse) {
+				if response.Succeeded != 1 || response.Failed != 1 {
+					t.Errorf("unexpected counters: %+v", response)
+				}
+				if response.Results[0].Status != BulkStatusUpdated || !strings.Contains(string(response.Results[0].Permissions), `"pipelines":[{"authorized":true,"id":5},{"authorized":true,"id":6}]`) {
+					t.Errorf("unexpected queue result: %+v", response.Results[0])
+				}
+				if response.Results[1].Status != BulkStatusFailed || response.Results[1].StatusCode != http.StatusForbidden || !strings.Contains(response.Results[1].Error, "Forbidden") {
+					t.Errorf("unexpected endpoint result: %+v", response.Results[1])
+				}
+			},
+		},
+		{
+			name:        "invalid and duplicated resources are reported",
+			requestBody: `{"resources":[{"resourceType":"queue","resourceId":"12","pipelines":[{"id":5}]},{"resourceType":"QUEUE","resourceId":"12"},{"resourceType":"endpoint","resourceId":"` + testEndpointID + `","pipelines":[{"id":0}]}]}`,
+			setupMock: func(mockClient *mockHTTPClient) {
+				mockClient.setResponse(queuePermissionURL, http.StatusOK, queuePermissionResp)
+			},
+			expectedStatus:       http.StatusMultiStatus,
+			expectedRequestCount: 1,
+			verifyResponse: func(t *testing.T, response BulkPipelinePermissionsResponse) {
+				if response.Succeeded != 1 || response.Failed != 2 {
+					t.Errorf("unexpected counters: %+v", response)
+				}
+				if !strings.Contains(response.Results[1].Error, "listed more than once") || response.Results[1].StatusCode != http.StatusBadRequest {
+					t.Errorf("unexpected duplicated result: %+v", response.Results[1])
+				}
+				if !strings.Contains(response.Results[2].Error, "pipeline id must be a positive number") {
+					t.Errorf("unexpected invalid result: %+v", response.Results[2])
+				}
+			},
+		},
+		{
+			name:                 "too many resources",
+			requestBody:          `{"resources":[` + strings.TrimSuffix(strings.Repeat(`{"resourceType":"queue","resourceId":"12"},`, maxBulkResources+1), ",") + `]}`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBodyContains: fmt.Sprintf("at most %d resources are allowed", maxBulkResources),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := newMockHTTPClient()
+			if tt.setupMock != nil {
+				tt.setupMock(mockClient)
+			}
+
+			handler := createTestBulkUpdateHandler(mockClient, tt.replace)
+
+			method := "PATCH"
+			if tt.replace {
+				method = "PUT"
+			}
+			url := fmt.Sprintf("/api/placeholder/placeholder/pipelines/pipelinepermissions?api-version=%s", testAPIVersion)
+			req := httptest.NewRequest(method, url, strings.NewReader(tt.requestBody))
+			req.SetPathValue("organization", testOrg)
+			req.SetPathValue("project", testProject)
+			req.SetBasicAuth(testUsername, testPassword)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			verifyBulkResponse(t, rr, mockClient, tt.expectedStatus, tt.expectedBodyContains, tt.expectedRequestCount, tt.verifyResponse)
+
+			if tt.verifyRequests != nil {
+				tt.verifyRequests(t, mockClient)
+			}
+		})
+	}
+}
+
+// verifyBulkResponse checks status code, body and number of requests of a bulk handler test
+func verifyBulkResponse(t *testing.T, rr *httptest.ResponseRecorder, mockClient *mockHTTPClient, expectedStatus int, expectedBodyContains string, expectedRequestCount int, verifyResponse func(t *testing.T, response BulkPipelinePermissionsResponse)) {
+	t.Helper()
+
+	if rr.Code != expectedStatus {
+		t.Errorf("handler returned wrong status code: got %v want %v. Body: %s", rr.Code, expectedStatus, rr.Body.String())
+	}
+
+	if expectedBodyContains != "" && !strings.Contains(rr.Body.String(), expectedBodyContains) {
+		t.Errorf("handler response body does not contain expected content.\nGot: %s\nWant to contain: %s", rr.Body.String(), expectedBodyContains)
+	}
+
+	if mockClient.getRequestCount() != expectedRequestCount {
+		t.Errorf("expected %d requests, got %d", expectedRequestCount, mockClient.getRequestCount())
+	}
+
+	if verifyResponse != nil {
+		var response BulkPipelinePermissionsResponse
+		if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		verifyResponse(t, response)
+	}
+}
@@ -2,20 +2,46 @@ package pipelinepermission
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 
 	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers"
 )
 
+// azureDevOpsError is returned when Azure DevOps API responds with a non-200 status code
+// The body is kept so that it can be forwarded to the caller
+type azureDevOpsError struct {
+	StatusCode int
+	Body       []byte
+}
+
+func (e *azureDevOpsError) Error() string {
+	return fmt.Sprintf("azure devops API returned status %d: %s", e.StatusCode, string(e.Body))
+}
+
 // Handler constructors
 func GetPipelinePermission(opts handlers.HandlerOptions) handlers.Handler {
 	return &getHandler{baseHandler: newBaseHandler(opts)}
 }
 
+// PutPipelinePermission replaces the pipeline permissions of a resource:
+// authorized pipelines not listed in the request are revoked
+func PutPipelinePermission(opts handlers.HandlerOptions) handlers.Handler {
+	return &updateHandler{baseHandler: newBaseHandler(opts), replace: true}
+}
+
+// PatchPipelinePermission updates only the pipeline permissions listed in the request
+func PatchPipelinePermission(opts handlers.HandlerOptions) handlers.Handler {
+	return &updateHandler{baseHandler: newBaseHandler(opts), replace: false}
+}
+
 // Interface compliance verification
 var _ handlers.Handler = &getHandler{}
+var _ handlers.Handler = &updateHandler{}
 
 // Base handler with common functionality
 type baseHandler struct {
@@ -32,6 +58,11 @@ type getHandler struct {
 	*baseHandler
 }
 
+type updateHandler struct {
+	*baseHandler
+	replace bool // true for PUT, false for PATCH
+}
+
 // Common methods, defined once on baseHandler
 func (h *baseHandler) makeAzuredevopsRequest(method, url string, authHeader string, body []byte) (*http.Response, error) {
 	var bodyReader io.Reader
@@ -83,12 +114,17 @@ func (h *baseHandler) writeJSONResponse(w http.ResponseWriter, statusCode int, b
 // @ID get-pipelinepermission
 // @Param organization path string true "Organization name"
 // @Param project path string true "Project name"
-// @Param resourceType path string true "Resource type (e.g., pipelines, repositories)"
-// @Param resourceId path string true "Resource ID (e.g., pipeline ID, repository ID)"
+// @Param resourceType path string true "Resource type" Enums(queue, endpoint, variablegroup, securefile, environment, repository)
+// @Param resourceId path string true "Resource ID (numeric ID for queue, variablegroup and environment, GUID for endpoint and securefile, <projectId>.<repositoryId> for repository)"
 // @Param api-version query string true "API version (e.g., 7.2-preview.1)"
+// @Param stripAuditFields query bool false "Remove the volatile authorizedOn and authorizedBy fields from the response"
 // @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
 // @Produce json
 // @Success 200 {object} ResourcePipelinePermissions "Pipeline permission details"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
 // @Router /api/{organization}/{project}/pipelines/pipelinepermissions/{resourceType}/{resourceId} [get]
 func (h *getHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	organization := r.PathValue("organization")
@@ -105,16 +141,28 @@ func (h *getHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	normalizedResourceType, err := parseResource(resourceType, resourceId)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid resource: %v", err))
+		return
+	}
+	resourceType = string(normalizedResourceType)
+
+	stripAuditFields, ok := h.parseStripAuditFields(w, r)
+	if !ok {
+		return
+	}
+
 	h.Log.Printf("Getting pipeline permission for resource %s/%s/%s/%s", organization, project, resourceType, resourceId)
 
 	// Get PipelinePermission
-	err := h.getPipelinePermissionAndRespond(w, organization, project, resourceType, resourceId, apiVersion, authHeader)
+	err = h.getPipelinePermissionAndRespond(w, organization, project, resourceType, resourceId, apiVersion, authHeader, stripAuditFields)
 	if err != nil {
 		h.writeErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Error getting pipeline permission: %v", err))
 	}
 }
 
-func (h *getHandler) getPipelinePermissionAndRespond(w http.ResponseWriter, organization, project, resourceType, resourceId, apiVersion, authHeader string) error {
+func (h *getHandler) getPipelinePermissionAndRespond(w http.ResponseWriter, organization, project, resourceType, resourceId, apiVersion, authHeader string, stripAuditFields bool) error {
 	// Construct the URL for the Azure DevOps API
 	url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/pipelines/pipelinepermissions/%s/%s?api-version=%s", organization, project, resourceType, resourceId, apiVersion)
 
@@ -144,7 +192,7 @@ func (h *getHandler) getPipelinePermissionAndRespond(w http.ResponseWriter, orga
 	}
 
 	// Process the response body through the transformation pipeline
-	processedBody, err := h.processPipelinepermissionResponse(body)
+	processedBody, err := h.processPipelinepermissionResponse(body, stripAuditFields)
 	if err != nil {
 		h.Log.Printf("Failed to process response, returning original raw response from Azure DevOps API: %v", err)
 		// Fallback to sending the original, valid response
@@ -157,41 +205,215 @@ func (h *getHandler) getPipelinePermissionAndRespond(w http.ResponseWriter, orga
 	return nil
 }
 
-func (h *getHandler) processPipelinepermissionResponse(body []byte) ([]byte, error) {
-
-	finalBody := body
-
-	// Adjusting the response body if needed
-	// Check if the field 'allPipelines' exists in the response body
-	if _, err := ReadFieldFromBody(body, "allPipelines"); err != nil {
+// parseStripAuditFields reads the optional 'stripAuditFields' query parameter
+// It writes a 400 response and returns false if the value is not a boolean
+func (h *baseHandler) parseStripAuditFields(w http.ResponseWriter, r *http.Request) (bool, bool) {
+	value := r.URL.Query().Get("stripAuditFields")
+	if value == "" {
+		return false, true
+	}
+	stripAuditFields, err := strconv.ParseBool(value)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid value for query parameter 'stripAuditFields': %s", value))
+		return false, false
+	}
+	return stripAuditFields, true
+}
 
-		// If the field does not exist, we will add it
-		h.Log.Printf("Field 'allPipelines' not found in response body: %v", err)
+// processPipelinepermissionResponse normalizes a pipeline permission body returned by Azure DevOps:
+// 'allPipelines' is added when missing, 'pipelines' is always present and sorted by pipeline ID
+// If stripAuditFields is true, the volatile 'authorizedOn' and 'authorizedBy' fields are removed
+func (h *baseHandler) processPipelinepermissionResponse(body []byte, stripAuditFields bool) ([]byte, error) {
+	var data map[string]interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
 
-		// Add the field 'allPipelines' with value 'authorized: false' (map)
-		authorizedFalse := map[string]interface{}{
+	// Azure DevOps does not return the field 'allPipelines' when it is not authorized
+	if _, ok := data["allPipelines"].(map[string]interface{}); !ok {
+		h.Log.Printf("Field 'allPipelines' not found in response body, adding it with value 'authorized: false'")
+		data["allPipelines"] = map[string]interface{}{
 			"authorized": false,
 		}
+	}
+
+	pipelines, _ := data["pipelines"].([]interface{})
+	if pipelines == nil {
+		pipelines = []interface{}{}
+	}
+	sortPipelinePermissions(pipelines)
+	data["pipelines"] = pipelines
 
-		finalBody, err = AddFieldToBody(body, "allPipelines", authorizedFalse)
-		if err != nil {
-			return nil, fmt.Errorf("failed to add message field: %w", err)
+	if stripAuditFields {
+		removeAuditFields(data["allPipelines"])
+		for _, pipeline := range pipelines {
+			removeAuditFields(pipeline)
 		}
-		h.Log.Printf("Field 'allPipelines' added to response body with value 'false'")
-		return finalBody, nil
+	}
+
+	return json.Marshal(data)
+}
+
+// getPipelinePermissions retrieves the current pipeline permissions of a resource
+func (h *baseHandler) getPipelinePermissions(organization, project, resourceType, resourceId, apiVersion, authHeader string) (*ResourcePipelinePermissions, []byte, error) {
+	url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/pipelines/pipelinepermissions/%s/%s?api-version=%s", organization, project, resourceType, resourceId, apiVersion)
+	return h.doPipelinePermissionsRequest("GET", url, authHeader, nil)
+}
+
+// patchPipelinePermissions applies the given authorize/revoke entries to the pipeline permissions of a resource
+func (h *baseHandler) patchPipelinePermissions(organization, project, resourceType, resourceId, apiVersion, authHeader string, delta *ResourcePipelinePermissions) (*ResourcePipelinePermissions, []byte, error) {
+	url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/pipelines/pipelinepermissions/%s/%s?api-version=%s", organization, project, resourceType, resourceId, apiVersion)
+
+	requestBody, err := json.Marshal(delta)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal pipeline permission update: %w", err)
+	}
+	h.Log.Printf("Updating pipeline permission with request body: %s", string(requestBody))
+
+	return h.doPipelinePermissionsRequest("PATCH", url, authHeader, requestBody)
+}
+
+// doPipelinePermissionsRequest performs a request to the pipeline permissions API and decodes the response
+// A non-200 status code is returned as *azureDevOpsError
+func (h *baseHandler) doPipelinePermissionsRequest(method, url, authHeader string, requestBody []byte) (*ResourcePipelinePermissions, []byte, error) {
+	resp, err := h.makeAzuredevopsRequest(method, url, authHeader, requestBody)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to make pipeline permission request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		h.Log.Printf("Azure DevOps API returned a non-200 status for %s %s: %d. Body: %s", method, url, resp.StatusCode, string(body))
+		return nil, nil, &azureDevOpsError{StatusCode: resp.StatusCode, Body: body}
+	}
+
+	var permissions ResourcePipelinePermissions
+	if err := json.Unmarshal(body, &permissions); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal pipeline permission: %w", err)
+	}
+
+	return &permissions, body, nil
+}
 
+// PUT and PATCH handler implementation
+// @Summary Update the pipeline permission of a resource
+// @Description Authorize or revoke pipelines on a protected resource. Only the entries that differ from the current state are sent to Azure DevOps.
+// @Description With PUT, authorized pipelines not listed in the request are revoked. With PATCH, only the listed pipelines are updated.
+// @ID update-pipelinepermission
+// @Param organization path string true "Organization name"
+// @Param project path string true "Project name"
+// @Param resourceType path string true "Resource type" Enums(queue, endpoint, variablegroup, securefile, environment, repository)
+// @Param resourceId path string true "Resource ID (numeric ID for queue, variablegroup and environment, GUID for endpoint and securefile, <projectId>.<repositoryId> for repository)"
+// @Param api-version query string true "API version (e.g., 7.2-preview.1)"
+// @Param stripAuditFields query bool false "Remove the volatile authorizedOn and authorizedBy fields from the response"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Param pipelinePermission body UpdatePipelinePermissionRequest true "Desired pipeline permissions"
+// @Accept json
+// @Produce json
+// @Success 200 {object} ResourcePipelinePermissions "Updated pipeline permission details"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{project}/pipelines/pipelinepermissions/{resourceType}/{resourceId} [put]
+// @Router /api/{organization}/{project}/pipelines/pipelinepermissions/{resourceType}/{resourceId} [patch]
+func (h *updateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	project := r.PathValue("project")
+	resourceType := r.PathValue("resourceType")
+	resourceId := r.PathValue("resourceId")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	// This single check handles missing headers, incorrect formats, and empty credentials.
+	username, password, ok := r.BasicAuth()
+	if !ok || username == "" || password == "" {
+		h.writeErrorResponse(w, http.StatusUnauthorized, "Request rejected due to missing or invalid Basic authentication")
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Failed to read request body")
+		return
+	}
+
+	normalizedResourceType, err := parseResource(resourceType, resourceId)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid resource: %v", err))
+		return
+	}
+	resourceType = string(normalizedResourceType)
+
+	stripAuditFields, ok := h.parseStripAuditFields(w, r)
+	if !ok {
+		return
 	}
 
-	// If the field was found, no processing is needed (happy path)
-	h.Log.Printf("Field 'allPipelines' already found in AzureDevOps raw response body, body not modified")
-	return finalBody, nil
+	var updateRequest UpdatePipelinePermissionRequest
+	if err := json.Unmarshal(body, &updateRequest); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid JSON in request body")
+		h.Log.Printf("Failed to unmarshal request body: %v", err)
+		return
+	}
+
+	if err := validateUpdatePipelinePermissionRequest(&updateRequest); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid pipeline permission: %v", err))
+		return
+	}
+
+	h.Log.Printf("Updating pipeline permission for resource %s/%s/%s/%s", organization, project, resourceType, resourceId)
+
+	// Read the current state to compute the entries to be authorized or revoked
+	current, currentBody, err := h.getPipelinePermissions(organization, project, resourceType, resourceId, apiVersion, authHeader)
+	if err != nil {
+		h.handleAzureDevOpsError(w, err, organization, project, resourceType, resourceId)
+		return
+	}
+
+	delta := computePipelinePermissionsDelta(current, &updateRequest, h.replace)
+	if delta == nil {
+		h.Log.Printf("Pipeline permission for resource %s/%s/%s/%s already up to date", organization, project, resourceType, resourceId)
+		h.writePipelinePermissionResponse(w, currentBody, stripAuditFields)
+		return
+	}
+
+	_, updatedBody, err := h.patchPipelinePermissions(organization, project, resourceType, resourceId, apiVersion, authHeader, delta)
+	if err != nil {
+		h.handleAzureDevOpsError(w, err, organization, project, resourceType, resourceId)
+		return
+	}
 
-	// Add message field
-	// If added, types need to be adjusted accordingly
-	//message := fmt.Sprintf()
-	//finalBody, err := AddFieldToResponse(correctedBody, "message", message)
-	//if err != nil {
-	//	return nil, fmt.Errorf("failed to add message field: %w", err)
-	//}
+	h.writePipelinePermissionResponse(w, updatedBody, stripAuditFields)
+	h.Log.Printf("Successfully updated pipeline permission for resource %s/%s/%s/%s", organization, project, resourceType, resourceId)
+}
+
+// handleAzureDevOpsError writes the response for an error returned while calling the pipeline permissions API
+func (h *baseHandler) handleAzureDevOpsError(w http.ResponseWriter, err error, organization, project, resourceType, resourceId string) {
+	var adoErr *azureDevOpsError
+	if errors.As(err, &adoErr) {
+		if adoErr.StatusCode == http.StatusNotFound {
+			h.writeErrorResponse(w, http.StatusNotFound, fmt.Sprintf("Pipeline permission for resource %s/%s/%s/%s not found", organization, project, resourceType, resourceId))
+			return
+		}
+		// For other non-200 responses, return the raw response
+		h.writeJSONResponse(w, adoErr.StatusCode, adoErr.Body)
+		return
+	}
+	h.writeErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Error updating pipeline permission: %v", err))
+}
 
+// writePipelinePermissionResponse normalizes and writes a pipeline permission body returned by Azure DevOps
+func (h *baseHandler) writePipelinePermissionResponse(w http.ResponseWriter, body []byte, stripAuditFields bool) {
+	processedBody, err := h.processPipelinepermissionResponse(body, stripAuditFields)
+	if err != nil {
+		h.Log.Printf("Failed to process response, returning original raw response from Azure DevOps API: %v", err)
+		processedBody = body
+	}
+	h.writeJSONResponse(w, http.StatusOK, processedBody)
 }
@@ -46,7 +46,10 @@ func (m *mockHTTPClient) Do(req *http.Request) (*http.Response, error) {
 		return nil, err
 	}
 
-	// Return configured response or default 404
+	// Return configured response (method specific first) or default 404
+	if resp, exists := m.responses[req.Method+" "+key]; exists {
+		return resp, nil
+	}
 	if resp, exists := m.responses[key]; exists {
 		return resp, nil
 	}
@@ -69,6 +72,12 @@ func (m *mockHTTPClient) setResponse(url string, statusCode int, body string) {
 	}
 }
 
+// setMethodResponse allows setting a predefined response for a specific method and URL
+// (e.g., GET and PATCH on the same pipeline permission URL)
+func (m *mockHTTPClient) setMethodResponse(method, url string, statusCode int, body string) {
+	m.setResponse(method+" "+url, statusCode, body)
+}
+
 func (m *mockHTTPClient) setError(url string, err error) {
 	m.errors[url] = err
 }
@@ -98,11 +107,25 @@ func createTestGetHandler(mockClient *mockHTTPClient) *getHandler {
 	return h
 }
 
+// createTestUpdateHandler creates a PUT (replace) or PATCH handler instance for testing with a mock client
+func createTestUpdateHandler(mockClient *mockHTTPClient, replace bool) *updateHandler {
+	logger := zerolog.New(io.Discard).With().Timestamp().Logger()
+	return &updateHandler{
+		baseHandler: &baseHandler{
+			HandlerOptions: handlers.HandlerOptions{
+				Client: mockClient,
+				Log:    &logger,
+			},
+		},
+		replace: replace,
+	}
+}
+
 // Test data constants
 const (
 	testOrg          = "testorg"
 	testProject      = "testproject"
-	testResourceType = "pipelines"
+	testResourceType = "queue"
 	testResourceID   = "123"
 	testAPIVersion   = "7.2-preview.1"
 	testAuthHeader   = "Basic dGVzdDp0ZXN0"
@@ -115,6 +138,7 @@ var (
 
 	validPermissionRespWithAllPipelines    = `{"resource":{"type":"pipeline","id":"123"},"pipelines":[],"allPipelines":{"authorized":true}}`
 	validPermissionRespWithoutAllPipelines = `{"resource":{"type":"pipeline","id":"123"},"pipelines":[]}`
+	validPermissionRespUnsorted            = `{"resource":{"type":"pipeline","id":"123"},"pipelines":[{"id":14,"authorized":true,"authorizedOn":"2025-06-29T10:00:00Z"},{"id":3,"authorized":true,"authorizedOn":"2025-06-30T14:33:02.06Z","authorizedBy":{"id":"user-id"}}],"allPipelines":{"authorized":true,"authorizedOn":"2025-06-30T14:33:02.06Z"}}`
 	permissionNotFoundResp                 = `{"message": "Resource not found"}`
 	unauthorizedResp                       = `{"message": "Unauthorized"}`
 )
@@ -163,6 +187,7 @@ func TestGetHandler_ServeHTTP(t *testing.T) {
 		resourceType         string
 		resourceID           string
 		apiVersion           string
+		extraQuery           string
 		authHeader           string
 		setupMock            func(*mockHTTPClient)
 		expectedStatus       int
@@ -218,6 +243,108 @@ func TestGetHandler_ServeHTTP(t *testing.T) {
 			expectedBodyContains: `"allPipelines":{"authorized":false}`,
 			expectedRequestCount: 1,
 		},
+		{
+			name:         "pipelines sorted by ID",
+			organization: testOrg,
+			project:      testProject,
+			resourceType: testResourceType,
+			resourceID:   testResourceID,
+			apiVersion:   testAPIVersion,
+			authHeader:   testAuthHeader,
+			setupMock: func(mockClient *mockHTTPClient) {
+				mockClient.setResponse(permissionGetURL, http.StatusOK, validPermissionRespUnsorted)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedContentType:  "application/json",
+			expectedBodyContains: `"pipelines":[{"authorized":true,"authorizedBy":{"id":"user-id"},"authorizedOn":"2025-06-30T14:33:02.06Z","id":3},{"authorized":true,"authorizedOn":"2025-06-29T10:00:00Z","id":14}]`,
+			expectedRequestCount: 1,
+		},
+		{
+			name:         "audit fields stripped",
+			organization: testOrg,
+			project:      testProject,
+			resourceType: testResourceType,
+			resourceID:   testResourceID,
+			apiVersion:   testAPIVersion,
+			extraQuery:   "&stripAuditFields=true",
+			authHeader:   testAuthHeader,
+			setupMock: func(mockClient *mockHTTPClient) {
+				mockClient.setResponse(permissionGetURL, http.StatusOK, validPermissionRespUnsorted)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedContentType:  "application/json",
+			expectedBodyContains: `"allPipelines":{"authorized":true},"pipelines":[{"authorized":true,"id":3},{"authorized":true,"id":14}]`,
+			expectedRequestCount: 1,
+		},
+		{
+			name:         "missing pipelines returned as empty array",
+			organization: testOrg,
+			project:      testProject,
+			resourceType: testResourceType,
+			resourceID:   testResourceID,
+			apiVersion:   testAPIVersion,
+			authHeader:   testAuthHeader,
+			setupMock: func(mockClient *mockHTTPClient) {
+				mockClient.setResponse(permissionGetURL, http.StatusOK, `{"resource":{"type":"pipeline","id":"123"}}`)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedContentType:  "application/json",
+			expectedBodyContains: `"allPipelines":{"authorized":false},"pipelines":[]`,
+			expectedRequestCount: 1,
+		},
+		{
+			name:         "resource type with different casing",
+			organization: testOrg,
+			project:      testProject,
+			resourceType: "Queue",
+			resourceID:   testResourceID,
+			apiVersion:   testAPIVersion,
+			authHeader:   testAuthHeader,
+			setupMock: func(mockClient *mockHTTPClient) {
+				mockClient.setResponse(permissionGetURL, http.StatusOK, validPermissionRespWithAllPipelines)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedContentType:  "application/json",
+			expectedBodyContains: `"allPipelines":{"authorized":true}`,
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "unsupported resource type",
+			organization:         testOrg,
+			project:              testProject,
+			resourceType:         "pipelines",
+			resourceID:           testResourceID,
+			apiVersion:           testAPIVersion,
+			authHeader:           testAuthHeader,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBodyContains: "Invalid resource: resource type 'pipelines' is not supported",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "invalid resource id",
+			organization:         testOrg,
+			project:              testProject,
+			resourceType:         "repository",
+			resourceID:           "repo123",
+			apiVersion:           testAPIVersion,
+			authHeader:           testAuthHeader,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBodyContains: "'<projectId>.<repositoryId>' is expected",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "invalid stripAuditFields",
+			organization:         testOrg,
+			project:              testProject,
+			resourceType:         testResourceType,
+			resourceID:           testResourceID,
+			apiVersion:           testAPIVersion,
+			extraQuery:           "&stripAuditFields=maybe",
+			authHeader:           testAuthHeader,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBodyContains: "Invalid value for query parameter 'stripAuditFields': maybe",
+			expectedRequestCount: 0,
+		},
 		{
 			name:                 "missing authorization header",
 			organization:         testOrg,
@@ -291,7 +418,7 @@ func TestGetHandler_ServeHTTP(t *testing.T) {
 			handler := createTestGetHandler(mockClient)
 
 			// Create request
-			url := fmt.Sprintf("/api/placeholder/placeholder/pipelines/pipelinepermissions/placeholder/placeholder?api-version=%s", tt.apiVersion)
+			url := fmt.Sprintf("/api/placeholder/placeholder/pipelines/pipelinepermissions/placeholder/placeholder?api-version=%s%s", tt.apiVersion, tt.extraQuery)
 			req := httptest.NewRequest("GET", url, nil)
 
 			// Set path values
@@ -344,3 +471,231 @@ func TestGetHandler_ServeHTTP(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateHandler_ServeHTTP(t *testing.T) {
+	currentPermissions := `{"resource":{"type":"queue","id":"123"},"pipelines":[{"id":1,"authorized":true,"authorizedOn":"2024-01-01T00:00:00Z"},{"id":2,"authorized":true}],"allPipelines":{"authorized":false}}`
+
+	tests := []struct {
+		name                 string
+		replace              bool
+		authHeader           string
+		requestBody          string
+		setupMock            func(*mockHTTPClient)
+		expectedStatus       int
+		expectedBodyContains string
+		expectedRequestCount int
+		expectedPatchBody    string
+	}{
+		{
+			name:        "PUT revokes pipelines not listed and authorizes new ones",
+			replace:     true,
+			authHeader:  testAuthHeader,
+			requestBody: `{"pipelines":[{"id":2},{"id":3}]}`,
+			setupMock: func(mockClient *mockHTTPClient) {
+				mockClient.setMethodResponse("GET", permissionGetURL, http.StatusOK, currentPermissions)
+				mockClient.setMethodResponse("PATCH", permissionGetURL, http.StatusOK, `{"resource":{"type":"queue","id":"123"},"pipelines":[{"id":1,"authorized":false},{"id":2,"authorized":true},{"id":3,"authorized":true}]}`)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `"allPipelines":{"authorized":false}`,
+			expectedRequestCount: 2,
+			expectedPatchBody:    `{"pipelines":[{"authorized":false,"id":1},{"authorized":true,"id":3}]}`,
+		},
+		{
+			name:        "PATCH only updates listed pipelines",
+			replace:     false,
+			authHeader:  testAuthHeader,
+			requestBody: `{"pipelines":[{"id":2,"authorized":false},{"id":3}]}`,
+			setupMock: func(mockClient *mockHTTPClient) {
+				mockClient.setMethodResponse("GET", permissionGetURL, http.StatusOK, currentPermissions)
+				mockClient.setMethodResponse("PATCH", permissionGetURL, http.StatusOK, `{"pipelines":[{"id":1,"authorized":true},{"id":2,"authorized":false},{"id":3,"authorized":true}]}`)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedRequestCount: 2,
+			expectedPatchBody:    `{"pipelines":[{"authorized":false,"id":2},{"authorized":true,"id":3}]}`,
+		},
+		{
+			name:        "allPipelines authorized",
+			replace:     false,
+			authHeader:  testAuthHeader,
+			requestBody: `{"allPipelines":{"authorized":true}}`,
+			setupMock: func(mockClient *mockHTTPClient) {
+				mockClient.setMethodResponse("GET", permissionGetURL, http.StatusOK, currentPermissions)
+				mockClient.setMethodResponse("PATCH", permissionGetURL, http.StatusOK, `{"allPipelines":{"authorized":true},"pipelines":[]}`)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `"allPipelines":{"authorized":true}`,
+			expectedRequestCount: 2,
+			expectedPatchBody:    `{"allPipelines":{"authorized":true}}`,
+		},
+		{
+			name:        "already up to date",
+			replace:     true,
+			authHeader:  testAuthHeader,
+			requestBody: `{"allPipelines":{"authorized":false},"pipelines":[{"id":1},{"id":2,"authorized":true}]}`,
+			setupMock: func(mockClient *mockHTTPClient) {
+				mockClient.setMethodResponse("GET", permissionGetURL, http.StatusOK, currentPermissions)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `"pipelines":[{"authorized":true,"authorizedOn":"2024-01-01T00:00:00Z","id":1},{"authorized":true,"id":2}]`,
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "missing authorization header",
+			authHeader:           "",
+			requestBody:          `{"pipelines":[{"id":1}]}`,
+			expectedStatus:       http.StatusUnauthorized,
+			expectedBodyContains: "Request rejected due to missing or invalid Basic authentication",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "invalid JSON",
+			authHeader:           testAuthHeader,
+			requestBody:          `{"pipelines":`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBodyContains: "Invalid JSON in request body",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "duplicated pipeline id",
+			authHeader:           testAuthHeader,
+			requestBody:          `{"pipelines":[{"id":1},{"id":1,"authorized":false}]}`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBodyContains: "pipeline id 1 is listed more than once",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "invalid pipeline id",
+			authHeader:           testAuthHeader,
+			requestBody:          `{"pipelines":[{"id":0}]}`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBodyContains: "pipeline id must be a positive number",
+			expectedRequestCount: 0,
+		},
+		{
+			name:        "resource not found",
+			authHeader:  testAuthHeader,
+			requestBody: `{"pipelines":[{"id":1}]}`,
+			setupMock: func(mockClient *mockHTTPClient) {
+				mockClient.setMethodResponse("GET", permissionGetURL, http.StatusNotFound, permissionNotFoundResp)
+			},
+			expectedStatus:       http.StatusNotFound,
+			expectedBodyContains: fmt.Sprintf("Pipeline permission for resource %s/%s/%s/%s not found", testOrg, testProject, testResourceType, testResourceID),
+			expectedRequestCount: 1,
+		},
+		{
+			name:        "update forbidden",
+			authHeader:  testAuthHeader,
+			requestBody: `{"pipelines":[{"id":3}]}`,
+			setupMock: func(mockClient *mockHTTPClient) {
+				mockClient.setMethodResponse("GET", permissionGetURL, http.StatusOK, currentPermissions)
+				mockClient.setMethodResponse("PATCH", permissionGetURL, http.StatusForbidden, `{"message":"Forbidden"}`)
+			},
+			expectedStatus:       http.StatusForbidden,
+			expectedBodyContains: "Forbidden",
+			expectedRequestCount: 2,
+		},
+		{
+			name:        "network error",
+			authHeader:  testAuthHeader,
+			requestBody: `{"pipelines":[{"id":3}]}`,
+			setupMock: func(mockClient *mockHTTPClient) {
+				mockClient.setError(permissionGetURL, fmt.Errorf("network error"))
+			},
+			expectedStatus:       http.StatusInternalServerError,
+			expectedBodyContains: "Error updating pipeline permission",
+			expectedRequestCount: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := newMockHTTPClient()
+			if tt.setupMock != nil {
+				tt.setupMock(mockClient)
+			}
+
+			handler := createTestUpdateHandler(mockClient, tt.replace)
+
+			method := "PATCH"
+			if tt.replace {
+				method = "PUT"
+			}
+			url := fmt.Sprintf("/api/placeholder/placeholder/pipelines/pipelinepermissions/placeholder/placeholder?api-version=%s", testAPIVersion)
+			req := httptest.NewRequest(method, url, strings.NewReader(tt.requestBody))
+			req.SetPathValue("organization", testOrg)
+			req.SetPathValue("project", testProject)
+			req.SetPathValue("resourceType", testResourceType)
+			req.SetPathValue("resourceId", testResourceID)
+
+			if tt.authHeader != "" {
+				req.SetBasicAuth(testUsername, testPassword)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("handler returned wrong status code: got %v want %v. Body: %s", rr.Code, tt.expectedStatus, rr.Body.String())
+			}
+
+			if tt.expectedBodyContains != "" && !strings.Contains(rr.Body.String(), tt.expectedBodyContains) {
+				t.Errorf("handler response body does not contain expected content.\nGot: %s\nWant to contain: %s", rr.Body.String(), tt.expectedBodyContains)
+			}
+
+			if mockClient.getRequestCount() != tt.expectedRequestCount {
+				t.Errorf("expected %d requests, got %d", tt.expectedRequestCount, mockClient.getRequestCount())
+			}
+
+			if tt.expectedPatchBody != "" {
+				req := mockClient.getLastRequest()
+				if req.Method != "PATCH" {
+					t.Fatalf("expected last request to be PATCH, got %s", req.Method)
+				}
+				body, _ := io.ReadAll(req.Body)
+				if string(body) != tt.expectedPatchBody {
+					t.Errorf("unexpected PATCH body.\nGot: %s\nWant: %s", string(body), tt.expectedPatchBody)
+				}
+			}
+		})
+	}
+}
+
+func TestParseResource(t *testing.T) {
+	const guid = "8d0ac7a4-4c6a-4c56-9b6d-7d6f1b0e3a21"
+	const otherGUID = "1b4f0a3e-2c5d-4e6f-8a9b-0c1d2e3f4a5b"
+
+	tests := []struct {
+		resourceType string
+		resourceID   string
+		expected     ResourceType
+		wantErr      bool
+	}{
+		{"queue", "12", ResourceTypeQueue, false},
+		{"Queue", "12", ResourceTypeQueue, false},
+		{"queue", "abc", "", true},
+		{"queue", "-1", "", true},
+		{"VariableGroup", "3", ResourceTypeVariableGroup, false},
+		{"environment", "7", ResourceTypeEnvironment, false},
+		{"endpoint", guid, ResourceTypeEndpoint, false},
+		{"endpoint", "42", "", true},
+		{"SecureFile", guid, ResourceTypeSecureFile, false},
+		{"securefile", "file", "", true},
+		{"repository", guid + "." + otherGUID, ResourceTypeRepository, false},
+		{"repository", otherGUID, "", true},
+		{"repository", guid + ".repo", "", true},
+		{"pipelines", "1", "", true},
+		{"", "1", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.resourceType+"/"+tt.resourceID, func(t *testing.T) {
+			got, err := parseResource(tt.resourceType, tt.resourceID)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseResource(%q, %q) error = %v, wantErr %v", tt.resourceType, tt.resourceID, err, tt.wantErr)
+			}
+			if got != tt.expected {
+				t.Errorf("parseResource(%q, %q) = %q, want %q", tt.resourceType, tt.resourceID, got, tt.expected)
+			}
+		})
+	}
+}
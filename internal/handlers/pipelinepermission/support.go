@@ -3,8 +3,14 @@ package pipelinepermission
 import (
 	"encoding/json"
 	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 )
 
+var guidRegex = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
 // function to add a field to the response body
 func AddFieldToBody(body []byte, fieldName string, fieldValue interface{}) ([]byte, error) {
 	var data map[string]interface{}
@@ -32,3 +38,140 @@ func ReadFieldFromBody(body []byte, fieldName string) (interface{}, error) {
 
 	return value, nil
 }
+
+// isAuthorized returns the desired authorization of a pipeline, which defaults to true
+func (p PipelinePermissionRequest) isAuthorized() bool {
+	return p.Authorized == nil || *p.Authorized
+}
+
+// validateUpdatePipelinePermissionRequest checks that pipeline IDs are valid and not repeated
+func validateUpdatePipelinePermissionRequest(request *UpdatePipelinePermissionRequest) error {
+	seen := make(map[int32]bool, len(request.Pipelines))
+	for _, pipeline := range request.Pipelines {
+		if pipeline.ID <= 0 {
+			return fmt.Errorf("pipeline id must be a positive number, got %d", pipeline.ID)
+		}
+		if seen[pipeline.ID] {
+			return fmt.Errorf("pipeline id %d is listed more than once", pipeline.ID)
+		}
+		seen[pipeline.ID] = true
+	}
+	return nil
+}
+
+// computePipelinePermissionsDelta returns the authorize/revoke entries needed to move from the current to the desired state
+// If replace is true, authorized pipelines not listed in the desired state are revoked
+// It returns nil if the current state already matches the desired one
+func computePipelinePermissionsDelta(current *ResourcePipelinePermissions, desired *UpdatePipelinePermissionRequest, replace bool) *ResourcePipelinePermissions {
+	currentAuthorized := make(map[int32]bool)
+	currentAllPipelines := false
+	if current != nil {
+		for _, pipeline := range current.Pipelines {
+			if pipeline.Authorized {
+				currentAuthorized[pipeline.ID] = true
+			}
+		}
+		currentAllPipelines = current.AllPipelines != nil && current.AllPipelines.Authorized
+	}
+
+	delta := &ResourcePipelinePermissions{}
+	changed := false
+
+	if desired.AllPipelines != nil && desired.AllPipelines.Authorized != currentAllPipelines {
+		delta.AllPipelines = &Permission{Authorized: desired.AllPipelines.Authorized}
+		changed = true
+	}
+
+	listed := make(map[int32]bool, len(desired.Pipelines))
+	for _, pipeline := range desired.Pipelines {
+		listed[pipeline.ID] = true
+		if pipeline.isAuthorized() != currentAuthorized[pipeline.ID] {
+			delta.Pipelines = append(delta.Pipelines, PipelinePermission{ID: pipeline.ID, Permission: Permission{Authorized: pipeline.isAuthorized()}})
+		}
+	}
+
+	if replace {
+		for id := range currentAuthorized {
+			if !listed[id] {
+				delta.Pipelines = append(delta.Pipelines, PipelinePermission{ID: id, Permission: Permission{Authorized: false}})
+			}
+		}
+	}
+
+	if len(delta.Pipelines) == 0 && !changed {
+		return nil
+	}
+
+	sort.Slice(delta.Pipelines, func(i, j int) bool { return delta.Pipelines[i].ID < delta.Pipelines[j].ID })
+	return delta
+}
+
+// sortPipelinePermissions sorts the raw 'pipelines' entries of a pipeline permission body by pipeline ID
+func sortPipelinePermissions(pipelines []interface{}) {
+	id := func(pipeline interface{}) float64 {
+		if p, ok := pipeline.(map[string]interface{}); ok {
+			if id, ok := p["id"].(float64); ok {
+				return id
+			}
+		}
+		return 0
+	}
+	sort.SliceStable(pipelines, func(i, j int) bool { return id(pipelines[i]) < id(pipelines[j]) })
+}
+
+// removeAuditFields removes the volatile 'authorizedOn' and 'authorizedBy' fields from a raw permission
+func removeAuditFields(permission interface{}) {
+	if p, ok := permission.(map[string]interface{}); ok {
+		delete(p, "authorizedOn")
+		delete(p, "authorizedBy")
+	}
+}
+
+// parseResourceType returns the resource type in the casing expected by Azure DevOps (e.g., 'VariableGroup' -> 'variablegroup')
+func parseResourceType(resourceType string) (ResourceType, error) {
+	normalized := ResourceType(strings.ToLower(strings.TrimSpace(resourceType)))
+	for _, supported := range supportedResourceTypes {
+		if normalized == supported {
+			return supported, nil
+		}
+	}
+
+	names := make([]string, 0, len(supportedResourceTypes))
+	for _, supported := range supportedResourceTypes {
+		names = append(names, string(supported))
+	}
+	return "", fmt.Errorf("resource type '%s' is not supported, supported types are: %s", resourceType, strings.Join(names, ", "))
+}
+
+// validateResourceID checks that the resource ID has the format expected for the resource type
+func validateResourceID(resourceType ResourceType, resourceId string) error {
+	switch resourceType {
+	case ResourceTypeQueue, ResourceTypeVariableGroup, ResourceTypeEnvironment:
+		if id, err := strconv.Atoi(resourceId); err != nil || id <= 0 {
+			return fmt.Errorf("resource id '%s' is not valid for resource type '%s', a positive number is expected", resourceId, resourceType)
+		}
+	case ResourceTypeEndpoint, ResourceTypeSecureFile:
+		if !guidRegex.MatchString(resourceId) {
+			return fmt.Errorf("resource id '%s' is not valid for resource type '%s', a GUID is expected", resourceId, resourceType)
+		}
+	case ResourceTypeRepository:
+		projectID, repositoryID, found := strings.Cut(resourceId, ".")
+		if !found || !guidRegex.MatchString(projectID) || !guidRegex.MatchString(repositoryID) {
+			return fmt.Errorf("resource id '%s' is not valid for resource type '%s', '<projectId>.<repositoryId>' is expected (both GUIDs)", resourceId, resourceType)
+		}
+	}
+	return nil
+}
+
+// parseResource validates resource type and ID of a pipeline permission request
+// and returns the resource type in the casing expected by Azure DevOps
+func parseResource(resourceType, resourceId string) (ResourceType, error) {
+	normalized, err := parseResourceType(resourceType)
+	if err != nil {
+		return "", err
+	}
+	if err := validateResourceID(normalized, resourceId); err != nil {
+		return "", err
+	}
+	return normalized, nil
+}
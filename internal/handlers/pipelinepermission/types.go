@@ -1,6 +1,7 @@
 package pipelinepermission
 
 import (
+	"encoding/json"
 	"time"
 )
 
@@ -24,6 +25,23 @@ type PipelinePermission struct {
 	ID int32 `json:"id"`
 }
 
+// UpdatePipelinePermissionRequest represents the desired pipeline permissions of a resource
+type UpdatePipelinePermissionRequest struct {
+	AllPipelines *AllPipelinesPermissionRequest `json:"allPipelines,omitempty"` // If not set, the current value is kept
+	Pipelines    []PipelinePermissionRequest    `json:"pipelines,omitempty"`
+}
+
+// AllPipelinesPermissionRequest represents the desired permission for all the pipelines of the project
+type AllPipelinesPermissionRequest struct {
+	Authorized bool `json:"authorized"`
+}
+
+// PipelinePermissionRequest represents the desired permission of a single pipeline
+type PipelinePermissionRequest struct {
+	ID         int32 `json:"id"`                   // Required
+	Authorized *bool `json:"authorized,omitempty"` // Defaults to true
+}
+
 // Resource represents a resource with basic identification
 type Resource struct {
 	ID   string `json:"id,omitempty"`
@@ -57,3 +75,76 @@ type GraphSubjectBase struct {
 type ReferenceLinks struct {
 	Links map[string]interface{} `json:"links,omitempty"`
 }
+
+// ResourceType represents the type of a protected resource supporting pipeline permissions
+type ResourceType string
+
+const (
+	ResourceTypeQueue         ResourceType = "queue"         // Agent queue, identified by a numeric ID
+	ResourceTypeEndpoint      ResourceType = "endpoint"      // Service connection, identified by a GUID
+	ResourceTypeVariableGroup ResourceType = "variablegroup" // Variable group, identified by a numeric ID
+	ResourceTypeSecureFile    ResourceType = "securefile"    // Secure file, identified by a GUID
+	ResourceTypeEnvironment   ResourceType = "environment"   // Environment, identified by a numeric ID
+	ResourceTypeRepository    ResourceType = "repository"    // Git repository, identified by '<projectId>.<repositoryId>' (GUIDs)
+)
+
+// supportedResourceTypes lists the resource types accepted by the plugin
+var supportedResourceTypes = []ResourceType{
+	ResourceTypeQueue,
+	ResourceTypeEndpoint,
+	ResourceTypeVariableGroup,
+	ResourceTypeSecureFile,
+	ResourceTypeEnvironment,
+	ResourceTypeRepository,
+}
+
+// BulkPipelinePermissionsQueryRequest represents the request body of the bulk query endpoint
+type BulkPipelinePermissionsQueryRequest struct {
+	Resources []BulkResourceReference `json:"resources"` // Required
+}
+
+// BulkResourceReference identifies a protected resource in a bulk request
+type BulkResourceReference struct {
+	ResourceType string `json:"resourceType"` // Required - see ResourceType
+	ResourceID   string `json:"resourceId"`   // Required
+}
+
+// BulkPipelinePermissionsUpdateRequest represents the request body of the bulk update endpoint
+type BulkPipelinePermissionsUpdateRequest struct {
+	Resources []BulkResourcePipelinePermissionsRequest `json:"resources"` // Required
+}
+
+// BulkResourcePipelinePermissionsRequest represents the desired pipeline permissions of a resource in a bulk request
+type BulkResourcePipelinePermissionsRequest struct {
+	BulkResourceReference
+	UpdatePipelinePermissionRequest
+}
+
+// BulkPipelinePermissionsResponse represents the response of the bulk endpoints
+type BulkPipelinePermissionsResponse struct {
+	Count     int                             `json:"count"`
+	Succeeded int                             `json:"succeeded"`
+	Failed    int                             `json:"failed"`
+	Results   []BulkPipelinePermissionsResult `json:"results"`
+}
+
+// BulkPipelinePermissionsResult represents the outcome for a single resource of a bulk request
+// Results are returned in the same order as the resources of the request
+type BulkPipelinePermissionsResult struct {
+	ResourceType string          `json:"resourceType"`
+	ResourceID   string          `json:"resourceId"`
+	Status       string          `json:"status"`                                     // enum: retrieved, unchanged, updated, failed
+	StatusCode   int             `json:"statusCode,omitempty"`                       // Only for failed resources
+	Error        string          `json:"error,omitempty"`                            // Only for failed resources
+	Permissions  json.RawMessage `json:"permissions,omitempty" swaggertype:"object"` // Normalized ResourcePipelinePermissions
+}
+
+const (
+	BulkStatusRetrieved = "retrieved"
+	BulkStatusUnchanged = "unchanged"
+	BulkStatusUpdated   = "updated"
+	BulkStatusFailed    = "failed"
+)
+
+// maxBulkResources is the maximum number of resources accepted in a single bulk request
+const maxBulkResources = 100
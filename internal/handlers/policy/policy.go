@@ -0,0 +1,461 @@
+package policy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	neturl "net/url"
+	"strconv"
+	"strings"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers"
+)
+
+// Handler constructors
+func GetPolicy(opts handlers.HandlerOptions) handlers.Handler {
+	return &getHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func PostPolicy(opts handlers.HandlerOptions) handlers.Handler {
+	return &postHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func PutPolicy(opts handlers.HandlerOptions) handlers.Handler {
+	return &putHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func DeletePolicy(opts handlers.HandlerOptions) handlers.Handler {
+	return &deleteHandler{baseHandler: newBaseHandler(opts)}
+}
+
+// Interface compliance verification
+var _ handlers.Handler = &getHandler{}
+var _ handlers.Handler = &postHandler{}
+var _ handlers.Handler = &putHandler{}
+var _ handlers.Handler = &deleteHandler{}
+
+// Base handler with common functionality
+type baseHandler struct {
+	*handlers.BaseHandler
+}
+
+// Constructor for the base handler
+func newBaseHandler(opts handlers.HandlerOptions) *baseHandler {
+	return &baseHandler{BaseHandler: handlers.NewBaseHandler(opts)}
+}
+
+// Handler types embedding the base handler
+type getHandler struct {
+	*baseHandler
+}
+
+type postHandler struct {
+	*baseHandler
+}
+
+type putHandler struct {
+	*baseHandler
+}
+
+type deleteHandler struct {
+	*baseHandler
+}
+
+// errUnknownPolicyType is returned when the type of a request does not match any policy type
+type errUnknownPolicyType struct {
+	Type string
+}
+
+func (e *errUnknownPolicyType) Error() string {
+	return fmt.Sprintf("policy type '%s' not found", e.Type)
+}
+
+// resolvePolicyType returns the ID of the policy type given by ID, alias or display name
+// Display names are resolved through the policy types of the project
+func (h *baseHandler) resolvePolicyType(organization, project, policyType, apiVersion, authHeader string) (string, error) {
+	if guidRegex.MatchString(policyType) {
+		return strings.ToLower(policyType), nil
+	}
+	if typeID, exists := PolicyTypeAliases[strings.ToLower(policyType)]; exists {
+		return typeID, nil
+	}
+
+	url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/policy/types?api-version=%s", organization, project, apiVersion)
+	body, err := h.DoAzureDevOpsRequest("GET", url, authHeader, nil)
+	if err != nil {
+		return "", err
+	}
+
+	var types PolicyTypeList
+	if err := json.Unmarshal(body, &types); err != nil {
+		return "", fmt.Errorf("failed to unmarshal policy types: %w", err)
+	}
+	for _, t := range types.Value {
+		if strings.EqualFold(t.DisplayName, policyType) {
+			return strings.ToLower(t.ID), nil
+		}
+	}
+	return "", &errUnknownPolicyType{Type: policyType}
+}
+
+// ErrRepeatedContinuationToken is returned when Azure DevOps returns a continuation token already followed while listing policies
+var ErrRepeatedContinuationToken = errors.New("azure devops returned an already seen continuation token")
+
+// listPolicies returns the policy configurations of the type that may apply to the repository and ref of the request
+// The pages are followed through the continuation token returned by Azure DevOps
+func (h *baseHandler) listPolicies(organization, project string, request *PolicyRequest, typeID, apiVersion, authHeader string) ([]PolicyConfiguration, error) {
+	configurations := []PolicyConfiguration{}
+	seenTokens := map[string]bool{}
+	continuationToken := ""
+	for page := 1; ; page++ {
+		query := neturl.Values{}
+		if request.RepositoryID != "" {
+			query.Set("repositoryId", request.RepositoryID)
+			if request.RefName != "" {
+				query.Set("refName", request.RefName)
+			}
+		}
+		query.Set("policyType", typeID)
+		if continuationToken != "" {
+			query.Set("continuationToken", continuationToken)
+		}
+		query.Set("api-version", apiVersion)
+		url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/policy/configurations?%s", organization, project, query.Encode())
+
+		body, header, err := h.DoAzureDevOpsRequestWithHeader("GET", url, authHeader, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var configurationList PolicyConfigurationList
+		if err := decodeJSON(body, &configurationList); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal policy configurations: %w", err)
+		}
+		configurations = append(configurations, configurationList.Value...)
+
+		continuationToken = header.Get(ContinuationTokenHeader)
+		if continuationToken == "" {
+			return configurations, nil
+		}
+		// Azure DevOps returning the same token over and over would make the list incomplete, so it is reported instead of truncating
+		if seenTokens[continuationToken] {
+			h.Log.Printf("Continuation token of page %d already seen", page)
+			return nil, fmt.Errorf("%w after %d pages", ErrRepeatedContinuationToken, page)
+		}
+		seenTokens[continuationToken] = true
+	}
+}
+
+// getPolicy returns the policy configuration with the given ID
+// Deleted policy configurations are returned as not found
+func (h *baseHandler) getPolicy(organization, project, id, apiVersion, authHeader string) (*PolicyConfiguration, error) {
+	url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/policy/configurations/%s?api-version=%s", organization, project, id, apiVersion)
+
+	body, err := h.DoAzureDevOpsRequest("GET", url, authHeader, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var configuration PolicyConfiguration
+	if err := decodeJSON(body, &configuration); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal policy configuration: %w", err)
+	}
+	if configuration.IsDeleted {
+		return nil, &handlers.AzureDevOpsError{StatusCode: http.StatusNotFound, Body: body}
+	}
+	return &configuration, nil
+}
+
+// savePolicy creates the policy configuration (POST) or replaces the one with the given ID (PUT)
+func (h *baseHandler) savePolicy(organization, project, method, id string, configuration *PolicyConfiguration, apiVersion, authHeader string) (*PolicyConfiguration, error) {
+	requestBody, err := json.Marshal(configuration)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal policy configuration: %w", err)
+	}
+
+	url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/policy/configurations?api-version=%s", organization, project, apiVersion)
+	if id != "" {
+		url = fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/policy/configurations/%s?api-version=%s", organization, project, id, apiVersion)
+	}
+	body, err := h.DoAzureDevOpsRequest(method, url, authHeader, requestBody)
+	if err != nil {
+		return nil, err
+	}
+
+	var saved PolicyConfiguration
+	if err := decodeJSON(body, &saved); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal policy configuration: %w", err)
+	}
+	return &saved, nil
+}
+
+// readPolicyRequest reads and validates the request body, and resolves the policy type
+func (h *baseHandler) readPolicyRequest(w http.ResponseWriter, r *http.Request, organization, project, apiVersion, authHeader string) (*PolicyRequest, string, bool) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Failed to read request body")
+		return nil, "", false
+	}
+
+	var policyRequest PolicyRequest
+	if err := decodeJSON(body, &policyRequest); err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Invalid JSON in request body")
+		return nil, "", false
+	}
+	if err := validatePolicyRequest(&policyRequest); err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid policy: %v", err))
+		return nil, "", false
+	}
+
+	typeID, err := h.resolvePolicyType(organization, project, policyRequest.Type, apiVersion, authHeader)
+	if err != nil {
+		var unknown *errUnknownPolicyType
+		if errors.As(err, &unknown) {
+			h.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid policy: %v", err))
+			return nil, "", false
+		}
+		h.WriteAzureDevOpsError(w, err, "get policy types", fmt.Sprintf("Project %s not found", project))
+		return nil, "", false
+	}
+	return &policyRequest, typeID, true
+}
+
+// validatePolicyID checks that the policy ID is a positive number
+func (h *baseHandler) validatePolicyID(w http.ResponseWriter, id string) bool {
+	if policyID, err := strconv.Atoi(id); err != nil || policyID <= 0 {
+		h.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid policy ID: %s", id))
+		return false
+	}
+	return true
+}
+
+// GET handler implementation
+// @Summary Get a branch policy
+// @Description Get a policy configuration with its settings normalized: without scope and null values, keys sorted
+// @ID get-policy
+// @Param organization path string true "Organization name"
+// @Param project path string true "Project name or ID"
+// @Param id path int true "Policy configuration ID"
+// @Param api-version query string true "API version (e.g., 7.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Produce json
+// @Success 200 {object} PolicyResponse "Policy details"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{project}/policy/configurations/{id} [get]
+func (h *getHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	project := r.PathValue("project")
+	id := r.PathValue("id")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, project, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+	if !h.validatePolicyID(w, id) {
+		return
+	}
+
+	h.Log.Printf("Getting policy %s for project %s in organization %s", id, project, organization)
+
+	configuration, err := h.getPolicy(organization, project, id, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "get policy", fmt.Sprintf("Policy %s not found in project %s", id, project))
+		return
+	}
+
+	h.WriteObjectResponse(w, http.StatusOK, PolicyResponse(*mapPolicyFromAzure(configuration)))
+	h.Log.Printf("Successfully retrieved policy %s", id)
+}
+
+// POST handler implementation
+// @Summary Create a branch policy
+// @Description Create a policy configuration scoped to a repository and a branch. If a policy of the same type already exists on the same scope (and, for build validation and status policies, for the same build definition or status), it is updated if needed and 200 is returned instead.
+// @ID post-policy
+// @Param organization path string true "Organization name"
+// @Param project path string true "Project name or ID"
+// @Param api-version query string true "API version (e.g., 7.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Param policy body PolicyRequest true "Policy creation request body"
+// @Accept json
+// @Produce json
+// @Success 200 {object} PolicyResponse "Existing policy"
+// @Success 201 {object} PolicyResponse "Created policy"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Failure 502 "Bad Gateway"
+// @Router /api/{organization}/{project}/policy/configurations [post]
+func (h *postHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	project := r.PathValue("project")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, project, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	policyRequest, typeID, ok := h.readPolicyRequest(w, r, organization, project, apiVersion, authHeader)
+	if !ok {
+		return
+	}
+
+	h.Log.Printf("Creating policy of type %s on repository '%s' and ref '%s' for project %s in organization %s", typeID, policyRequest.RepositoryID, policyRequest.RefName, project, organization)
+
+	configurations, err := h.listPolicies(organization, project, policyRequest, typeID, apiVersion, authHeader)
+	if err != nil {
+		if errors.Is(err, ErrRepeatedContinuationToken) {
+			// The existing policy could be on the pages not returned, creating one could duplicate it
+			h.WriteErrorResponse(w, http.StatusBadGateway, fmt.Sprintf("Failed to list policies: %v", err))
+			return
+		}
+		h.WriteAzureDevOpsError(w, err, "list policies", fmt.Sprintf("Project %s not found", project))
+		return
+	}
+
+	existing := findPolicy(configurations, policyRequest, typeID)
+	if existing == nil {
+		created, err := h.savePolicy(organization, project, "POST", "", buildPolicyConfiguration(policyRequest, typeID), apiVersion, authHeader)
+		if err != nil {
+			h.WriteAzureDevOpsError(w, err, "create policy", fmt.Sprintf("Project %s not found", project))
+			return
+		}
+		h.WriteObjectResponse(w, http.StatusCreated, PolicyResponse(*mapPolicyFromAzure(created)))
+		h.Log.Printf("Successfully created policy %d", created.ID)
+		return
+	}
+
+	id := strconv.Itoa(existing.ID)
+	h.Log.Printf("Policy of type %s already exists with ID %s", typeID, id)
+	if !isUpToDate(existing, policyRequest) {
+		existing, err = h.savePolicy(organization, project, "PUT", id, buildPolicyConfiguration(policyRequest, typeID), apiVersion, authHeader)
+		if err != nil {
+			h.WriteAzureDevOpsError(w, err, "update policy", fmt.Sprintf("Policy %s not found in project %s", id, project))
+			return
+		}
+		h.Log.Printf("Updated existing policy %s", id)
+	}
+
+	h.WriteObjectResponse(w, http.StatusOK, PolicyResponse(*mapPolicyFromAzure(existing)))
+}
+
+// PUT handler implementation
+// @Summary Update a branch policy
+// @Description Update the scope, flags and settings of a policy configuration. Settings not set are reset to their defaults by Azure DevOps. The policy is not written if it is already up to date. The type of a policy cannot be changed.
+// @ID put-policy
+// @Param organization path string true "Organization name"
+// @Param project path string true "Project name or ID"
+// @Param id path int true "Policy configuration ID"
+// @Param api-version query string true "API version (e.g., 7.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Param policy body PolicyRequest true "Policy update request body"
+// @Accept json
+// @Produce json
+// @Success 200 {object} PolicyResponse "Updated policy"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{project}/policy/configurations/{id} [put]
+func (h *putHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	project := r.PathValue("project")
+	id := r.PathValue("id")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, project, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+	if !h.validatePolicyID(w, id) {
+		return
+	}
+
+	policyRequest, typeID, ok := h.readPolicyRequest(w, r, organization, project, apiVersion, authHeader)
+	if !ok {
+		return
+	}
+
+	h.Log.Printf("Updating policy %s for project %s in organization %s", id, project, organization)
+
+	configuration, err := h.getPolicy(organization, project, id, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "get policy", fmt.Sprintf("Policy %s not found in project %s", id, project))
+		return
+	}
+	if !strings.EqualFold(configuration.Type.ID, typeID) {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Invalid policy: type cannot be changed")
+		return
+	}
+
+	if !isUpToDate(configuration, policyRequest) {
+		configuration, err = h.savePolicy(organization, project, "PUT", id, buildPolicyConfiguration(policyRequest, typeID), apiVersion, authHeader)
+		if err != nil {
+			h.WriteAzureDevOpsError(w, err, "update policy", fmt.Sprintf("Policy %s not found in project %s", id, project))
+			return
+		}
+	}
+
+	h.WriteObjectResponse(w, http.StatusOK, PolicyResponse(*mapPolicyFromAzure(configuration)))
+	h.Log.Printf("Successfully updated policy %s", id)
+}
+
+// DELETE handler implementation
+// @Summary Delete a branch policy
+// @Description Delete a policy configuration
+// @ID delete-policy
+// @Param organization path string true "Organization name"
+// @Param project path string true "Project name or ID"
+// @Param id path int true "Policy configuration ID"
+// @Param api-version query string true "API version (e.g., 7.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Success 204 "No Content"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{project}/policy/configurations/{id} [delete]
+func (h *deleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	project := r.PathValue("project")
+	id := r.PathValue("id")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, project, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+	if !h.validatePolicyID(w, id) {
+		return
+	}
+
+	h.Log.Printf("Deleting policy %s for project %s in organization %s", id, project, organization)
+
+	url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/policy/configurations/%s?api-version=%s", organization, project, id, apiVersion)
+	if _, err := h.DoAzureDevOpsRequest("DELETE", url, authHeader, nil); err != nil {
+		h.WriteAzureDevOpsError(w, err, "delete policy", fmt.Sprintf("Policy %s not found in project %s", id, project))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	h.Log.Printf("Successfully deleted policy %s", id)
+}
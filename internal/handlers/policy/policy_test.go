@@ -0,0 +1,480 @@
+package policy
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers"
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/handlerstest"
+	"github.com/rs/zerolog"
+)
+
+// newTestBaseHandler creates a base handler for testing with a mock client
+func newTestBaseHandler(mockClient *handlerstest.MockHTTPClient) *baseHandler {
+	return newBaseHandler(handlerstest.NewHandlerOptions(mockClient))
+}
+
+// Test data constants
+const (
+	testOrg        = "testorg"
+	testProject    = "Platform"
+	testAPIVersion = "7.1"
+	testUsername   = "test"
+	testPassword   = "test"
+	testRepository = "5b6c7d8e-0000-0000-0000-000000000002"
+	mainRef        = "refs/heads/main"
+)
+
+var (
+	configurationsURL = fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/policy/configurations?api-version=%s", testOrg, testProject, testAPIVersion)
+	typesURL          = fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/policy/types?api-version=%s", testOrg, testProject, testAPIVersion)
+	typesResp         = `{"count":2,"value":[{"id":"` + MinimumReviewersPolicyType + `","displayName":"Minimum number of reviewers"},{"id":"` + BuildPolicyType + `","displayName":"Build"}]}`
+
+	reviewersPolicyResp = `{"id":12,"revision":3,"isEnabled":true,"isBlocking":true,"isDeleted":false,"type":{"id":"` + MinimumReviewersPolicyType + `","displayName":"Minimum number of reviewers"},"settings":{"minimumApproverCount":2,"creatorVoteCounts":false,"allowDownvotes":null,"scope":[{"repositoryId":"5B6C7D8E-0000-0000-0000-000000000002","refName":"refs/heads/main","matchKind":"Exact"}]}}`
+	expectedReviewers   = `{"id":12,"type":"` + MinimumReviewersPolicyType + `","typeName":"Minimum number of reviewers","repositoryId":"` + testRepository + `","refName":"refs/heads/main","matchKind":"Exact","isEnabled":true,"isBlocking":true,"settings":{"creatorVoteCounts":false,"minimumApproverCount":2}}`
+)
+
+// configurationURL returns the URL of the policy configuration with the given ID
+func configurationURL(id int) string {
+	return fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/policy/configurations/%d?api-version=%s", testOrg, testProject, id, testAPIVersion)
+}
+
+// listURL returns the URL used to list the policy configurations of a type on the repository and ref
+func listURL(typeID, repositoryID, refName, continuationToken string) string {
+	query := url.Values{"api-version": {testAPIVersion}, "policyType": {typeID}}
+	if repositoryID != "" {
+		query.Set("repositoryId", repositoryID)
+		if refName != "" {
+			query.Set("refName", refName)
+		}
+	}
+	if continuationToken != "" {
+		query.Set("continuationToken", continuationToken)
+	}
+	return fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/policy/configurations?%s", testOrg, testProject, query.Encode())
+}
+
+// policyList returns a list response of policy configurations
+func policyList(policies ...string) string {
+	return fmt.Sprintf(`{"count":%d,"value":[%s]}`, len(policies), strings.Join(policies, ","))
+}
+
+// policyTestCase is a test case for the handlers of this package
+type policyTestCase struct {
+	name                 string
+	pathValues           map[string]string
+	query                url.Values
+	noAuth               bool
+	body                 string
+	setupMock            func(*handlerstest.MockHTTPClient)
+	expectedStatus       int
+	expectedBody         string
+	expectedBodyContains string
+	expectedRequestCount int
+	verify               func(t *testing.T, mockClient *handlerstest.MockHTTPClient)
+}
+
+// policyPathValues returns the path values of the policy endpoints, with the policy ID if it is set
+func policyPathValues(id string) map[string]string {
+	values := map[string]string{"organization": testOrg, "project": testProject}
+	if id != "" {
+		values["id"] = id
+	}
+	return values
+}
+
+// runPolicyTests runs the given test cases against the handler built by newHandler
+func runPolicyTests(t *testing.T, method string, newHandler func(*baseHandler) http.Handler, tests []policyTestCase) {
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := handlerstest.NewMockHTTPClient()
+			if tt.setupMock != nil {
+				tt.setupMock(mockClient)
+			}
+			handler := newHandler(newTestBaseHandler(mockClient))
+
+			target := "/api/test"
+			if len(tt.query) > 0 {
+				target += "?" + tt.query.Encode()
+			}
+			var body io.Reader
+			if tt.body != "" {
+				body = strings.NewReader(tt.body)
+			}
+			req := httptest.NewRequest(method, target, body)
+			for key, value := range tt.pathValues {
+				req.SetPathValue(key, value)
+			}
+			if !tt.noAuth {
+				req.SetBasicAuth(testUsername, testPassword)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("Status code = %d, want %d. Body: %s", rr.Code, tt.expectedStatus, rr.Body.String())
+			}
+			if tt.expectedBody != "" && rr.Body.String() != tt.expectedBody {
+				t.Errorf("Body = %s, want %s", rr.Body.String(), tt.expectedBody)
+			}
+			if tt.expectedBodyContains != "" && !strings.Contains(rr.Body.String(), tt.expectedBodyContains) {
+				t.Errorf("Body = %s, want it to contain %s", rr.Body.String(), tt.expectedBodyContains)
+			}
+			if mockClient.RequestCount() != tt.expectedRequestCount {
+				t.Errorf("Request count = %d, want %d", mockClient.RequestCount(), tt.expectedRequestCount)
+			}
+			if tt.verify != nil {
+				tt.verify(t, mockClient)
+			}
+		})
+	}
+}
+
+// apiVersionQuery returns the query with the test API version
+func apiVersionQuery() url.Values {
+	return url.Values{"api-version": {testAPIVersion}}
+}
+
+// requestBody returns the body of the n-th request sent to Azure DevOps
+func requestBody(t *testing.T, mockClient *handlerstest.MockHTTPClient, n int) string {
+	t.Helper()
+	if len(mockClient.Requests) <= n || mockClient.Requests[n].Body == nil {
+		t.Fatalf("request %d has no body", n)
+	}
+	body, err := io.ReadAll(mockClient.Requests[n].Body)
+	if err != nil {
+		t.Fatalf("failed to read request %d body: %v", n, err)
+	}
+	return string(body)
+}
+
+// containsRequest reports whether a request was sent with the given method and URL
+func containsRequest(mockClient *handlerstest.MockHTTPClient, method, url string) bool {
+	for _, req := range mockClient.Requests {
+		if req.Method+" "+req.URL.String() == method+" "+url {
+			return true
+		}
+	}
+	return false
+}
+
+// Test constructor functions
+func TestPolicyConstructors(t *testing.T) {
+	client := &http.Client{}
+	logger := zerolog.New(os.Stdout).With().Timestamp().Logger()
+	opts := handlers.HandlerOptions{
+		Client: client,
+		Log:    &logger,
+	}
+
+	constructors := map[string]func(handlers.HandlerOptions) handlers.Handler{
+		"GetPolicy":    GetPolicy,
+		"PostPolicy":   PostPolicy,
+		"PutPolicy":    PutPolicy,
+		"DeletePolicy": DeletePolicy,
+	}
+
+	for name, constructor := range constructors {
+		t.Run(name, func(t *testing.T) {
+			if h := constructor(opts); h == nil {
+				t.Fatalf("%s should return a non-nil handler", name)
+			}
+		})
+	}
+}
+
+// Test GET handler
+func TestGetHandler_ServeHTTP(t *testing.T) {
+	runPolicyTests(t, "GET", func(b *baseHandler) http.Handler { return &getHandler{baseHandler: b} }, []policyTestCase{
+		{
+			name:       "policy with normalized settings",
+			pathValues: policyPathValues("12"),
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(configurationURL(12), http.StatusOK, reviewersPolicyResp)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBody:         expectedReviewers,
+			expectedRequestCount: 1,
+		},
+		{
+			name:       "deleted policy",
+			pathValues: policyPathValues("12"),
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(configurationURL(12), http.StatusOK, strings.Replace(reviewersPolicyResp, `"isDeleted":false`, `"isDeleted":true`, 1))
+			},
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Policy 12 not found in project Platform",
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "invalid policy ID",
+			pathValues:           policyPathValues("abc"),
+			query:                apiVersionQuery(),
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid policy ID: abc",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "missing api version",
+			pathValues:           policyPathValues("12"),
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "API version is required",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "missing basic auth",
+			pathValues:           policyPathValues("12"),
+			query:                apiVersionQuery(),
+			noAuth:               true,
+			expectedStatus:       http.StatusUnauthorized,
+			expectedBody:         "Request rejected due to missing or invalid Basic authentication",
+			expectedRequestCount: 0,
+		},
+	})
+}
+
+// Test POST handler
+func TestPostHandler_ServeHTTP(t *testing.T) {
+	reviewersRequest := `{"type":"minimumReviewers","repositoryId":"5B6C7D8E-0000-0000-0000-000000000002","refName":"main","settings":{"minimumApproverCount":2,"creatorVoteCounts":false}}`
+
+	runPolicyTests(t, "POST", func(b *baseHandler) http.Handler { return &postHandler{baseHandler: b} }, []policyTestCase{
+		{
+			name:       "policy created when none matches",
+			pathValues: policyPathValues(""),
+			query:      apiVersionQuery(),
+			body:       reviewersRequest,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(listURL(MinimumReviewersPolicyType, testRepository, mainRef, ""), http.StatusOK, policyList())
+				m.SetMethodResponse("POST", configurationsURL, http.StatusOK, reviewersPolicyResp)
+			},
+			expectedStatus:       http.StatusCreated,
+			expectedBody:         expectedReviewers,
+			expectedRequestCount: 2,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				want := `{"isEnabled":true,"isBlocking":true,"type":{"id":"` + MinimumReviewersPolicyType + `"},"settings":{"creatorVoteCounts":false,"minimumApproverCount":2,"scope":[{"repositoryId":"` + testRepository + `","refName":"refs/heads/main","matchKind":"Exact"}]}}`
+				if got := requestBody(t, m, 1); got != want {
+					t.Errorf("Request body = %s, want %s", got, want)
+				}
+			},
+		},
+		{
+			name:       "existing policy up to date",
+			pathValues: policyPathValues(""),
+			query:      apiVersionQuery(),
+			body:       reviewersRequest,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(listURL(MinimumReviewersPolicyType, testRepository, mainRef, ""), http.StatusOK, policyList(reviewersPolicyResp))
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBody:         expectedReviewers,
+			expectedRequestCount: 1,
+		},
+		{
+			name:       "existing policy updated",
+			pathValues: policyPathValues(""),
+			query:      apiVersionQuery(),
+			body:       `{"type":"minimumReviewers","repositoryId":"` + testRepository + `","refName":"refs/heads/main","isBlocking":false,"settings":{"minimumApproverCount":2}}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(listURL(MinimumReviewersPolicyType, testRepository, mainRef, ""), http.StatusOK, policyList(reviewersPolicyResp))
+				m.SetMethodResponse("PUT", configurationURL(12), http.StatusOK, strings.Replace(reviewersPolicyResp, `"isBlocking":true`, `"isBlocking":false`, 1))
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `"id":12,`,
+			expectedRequestCount: 2,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				if !containsRequest(m, "PUT", configurationURL(12)) {
+					t.Errorf("Existing policy should be updated")
+				}
+			},
+		},
+		{
+			name:       "policy on the next page matched",
+			pathValues: policyPathValues(""),
+			query:      apiVersionQuery(),
+			body:       reviewersRequest,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(listURL(MinimumReviewersPolicyType, testRepository, mainRef, ""), http.StatusOK, policyList(strings.Replace(reviewersPolicyResp, `"refName":"refs/heads/main","matchKind":"Exact"`, `"refName":"refs/heads/main","matchKind":"Prefix"`, 1)))
+				m.SetResponseHeader(listURL(MinimumReviewersPolicyType, testRepository, mainRef, ""), ContinuationTokenHeader, "page 2")
+				m.SetResponse(listURL(MinimumReviewersPolicyType, testRepository, mainRef, "page 2"), http.StatusOK, policyList(reviewersPolicyResp))
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBody:         expectedReviewers,
+			expectedRequestCount: 2,
+		},
+		{
+			name:       "repeated continuation token",
+			pathValues: policyPathValues(""),
+			query:      apiVersionQuery(),
+			body:       reviewersRequest,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(listURL(MinimumReviewersPolicyType, testRepository, mainRef, ""), http.StatusOK, policyList())
+				m.SetResponseHeader(listURL(MinimumReviewersPolicyType, testRepository, mainRef, ""), ContinuationTokenHeader, "page 2")
+				m.SetResponse(listURL(MinimumReviewersPolicyType, testRepository, mainRef, "page 2"), http.StatusOK, policyList())
+				m.SetResponseHeader(listURL(MinimumReviewersPolicyType, testRepository, mainRef, "page 2"), ContinuationTokenHeader, "page 2")
+			},
+			expectedStatus:       http.StatusBadGateway,
+			expectedBodyContains: "already seen continuation token",
+			expectedRequestCount: 2,
+		},
+		{
+			name:       "type resolved by display name",
+			pathValues: policyPathValues(""),
+			query:      apiVersionQuery(),
+			body:       strings.Replace(reviewersRequest, `"minimumReviewers"`, `"minimum number of reviewers"`, 1),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(typesURL, http.StatusOK, typesResp)
+				m.SetResponse(listURL(MinimumReviewersPolicyType, testRepository, mainRef, ""), http.StatusOK, policyList(reviewersPolicyResp))
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBody:         expectedReviewers,
+			expectedRequestCount: 2,
+		},
+		{
+			name:       "unknown policy type",
+			pathValues: policyPathValues(""),
+			query:      apiVersionQuery(),
+			body:       `{"type":"Unknown"}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(typesURL, http.StatusOK, typesResp)
+			},
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid policy: policy type 'Unknown' not found",
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "scope in settings",
+			pathValues:           policyPathValues(""),
+			query:                apiVersionQuery(),
+			body:                 `{"type":"build","settings":{"scope":[]}}`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid policy: settings.scope is not allowed, use repositoryId, refName and matchKind",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "invalid JSON",
+			pathValues:           policyPathValues(""),
+			query:                apiVersionQuery(),
+			body:                 `{"type":`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid JSON in request body",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "missing project",
+			pathValues:           map[string]string{"organization": testOrg},
+			query:                apiVersionQuery(),
+			body:                 reviewersRequest,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Project parameter is required",
+			expectedRequestCount: 0,
+		},
+	})
+}
+
+// Test PUT handler
+func TestPutHandler_ServeHTTP(t *testing.T) {
+	runPolicyTests(t, "PUT", func(b *baseHandler) http.Handler { return &putHandler{baseHandler: b} }, []policyTestCase{
+		{
+			name:       "policy updated",
+			pathValues: policyPathValues("12"),
+			query:      apiVersionQuery(),
+			body:       `{"type":"` + MinimumReviewersPolicyType + `","repositoryId":"` + testRepository + `","refName":"main","settings":{"minimumApproverCount":3}}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetMethodResponse("GET", configurationURL(12), http.StatusOK, reviewersPolicyResp)
+				m.SetMethodResponse("PUT", configurationURL(12), http.StatusOK, strings.Replace(reviewersPolicyResp, `"minimumApproverCount":2`, `"minimumApproverCount":3`, 1))
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `"settings":{"creatorVoteCounts":false,"minimumApproverCount":3}`,
+			expectedRequestCount: 2,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				want := `{"isEnabled":true,"isBlocking":true,"type":{"id":"` + MinimumReviewersPolicyType + `"},"settings":{"minimumApproverCount":3,"scope":[{"repositoryId":"` + testRepository + `","refName":"refs/heads/main","matchKind":"Exact"}]}}`
+				if got := requestBody(t, m, 1); got != want {
+					t.Errorf("Request body = %s, want %s", got, want)
+				}
+			},
+		},
+		{
+			name:       "policy up to date not written",
+			pathValues: policyPathValues("12"),
+			query:      apiVersionQuery(),
+			body:       `{"type":"minimumReviewers","repositoryId":"` + testRepository + `","refName":"main","settings":{"minimumApproverCount":2.0}}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetMethodResponse("GET", configurationURL(12), http.StatusOK, reviewersPolicyResp)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBody:         expectedReviewers,
+			expectedRequestCount: 1,
+		},
+		{
+			name:       "type cannot be changed",
+			pathValues: policyPathValues("12"),
+			query:      apiVersionQuery(),
+			body:       `{"type":"build","repositoryId":"` + testRepository + `","refName":"main","settings":{"buildDefinitionId":5}}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetMethodResponse("GET", configurationURL(12), http.StatusOK, reviewersPolicyResp)
+			},
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid policy: type cannot be changed",
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "invalid match kind",
+			pathValues:           policyPathValues("12"),
+			query:                apiVersionQuery(),
+			body:                 `{"type":"minimumReviewers","refName":"main","matchKind":"DefaultBranch"}`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid policy: matchKind must be Exact or Prefix, got 'DefaultBranch'",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "policy not found",
+			pathValues:           policyPathValues("12"),
+			query:                apiVersionQuery(),
+			body:                 `{"type":"minimumReviewers"}`,
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Policy 12 not found in project Platform",
+			expectedRequestCount: 1,
+		},
+	})
+}
+
+// Test DELETE handler
+func TestDeleteHandler_ServeHTTP(t *testing.T) {
+	runPolicyTests(t, "DELETE", func(b *baseHandler) http.Handler { return &deleteHandler{baseHandler: b} }, []policyTestCase{
+		{
+			name:       "policy deleted",
+			pathValues: policyPathValues("12"),
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetMethodResponse("DELETE", configurationURL(12), http.StatusNoContent, "")
+			},
+			expectedStatus:       http.StatusNoContent,
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "policy not found",
+			pathValues:           policyPathValues("12"),
+			query:                apiVersionQuery(),
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Policy 12 not found in project Platform",
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "invalid policy ID",
+			pathValues:           policyPathValues("0"),
+			query:                apiVersionQuery(),
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid policy ID: 0",
+			expectedRequestCount: 0,
+		},
+	})
+}
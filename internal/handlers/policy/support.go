@@ -0,0 +1,294 @@
+package policy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var guidRegex = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// identitySettings lists, for the policy types that can be configured more than once on the same scope,
+// the settings that identify a policy together with its type and scope
+// (e.g., a build validation policy for each build definition)
+var identitySettings = map[string][]string{
+	BuildPolicyType:  {"buildDefinitionId"},
+	StatusPolicyType: {"statusGenre", "statusName"},
+}
+
+// unorderedSettings lists the settings whose values are sets, sorted when normalizing the settings
+var unorderedSettings = map[string]bool{
+	"requiredReviewerIds": true,
+}
+
+// decodeJSON unmarshals the body keeping numbers as json.Number, so that the settings are returned as they are
+func decodeJSON(body []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+// normalizeRefName returns the full name of the ref, e.g., main becomes refs/heads/main
+func normalizeRefName(refName string) string {
+	refName = strings.TrimSpace(refName)
+	if refName == "" || strings.HasPrefix(refName, "refs/") {
+		return refName
+	}
+	return "refs/heads/" + refName
+}
+
+// validatePolicyRequest checks and normalizes the fields of a policy request
+func validatePolicyRequest(request *PolicyRequest) error {
+	request.Type = strings.TrimSpace(request.Type)
+	if request.Type == "" {
+		return fmt.Errorf("type is required")
+	}
+
+	request.RepositoryID = strings.ToLower(strings.TrimSpace(request.RepositoryID))
+	if request.RepositoryID != "" && !guidRegex.MatchString(request.RepositoryID) {
+		return fmt.Errorf("repositoryId must be a GUID, got '%s'", request.RepositoryID)
+	}
+	request.RefName = normalizeRefName(request.RefName)
+
+	switch {
+	case request.MatchKind == "" || strings.EqualFold(request.MatchKind, "exact"):
+		request.MatchKind = "Exact"
+	case strings.EqualFold(request.MatchKind, "prefix"):
+		request.MatchKind = "Prefix"
+	default:
+		return fmt.Errorf("matchKind must be Exact or Prefix, got '%s'", request.MatchKind)
+	}
+
+	if _, exists := request.Settings["scope"]; exists {
+		return fmt.Errorf("settings.scope is not allowed, use repositoryId, refName and matchKind")
+	}
+	if request.Settings == nil {
+		request.Settings = map[string]interface{}{}
+	}
+	return nil
+}
+
+// isEnabled returns the desired enabled flag of a policy, which defaults to true
+func (p PolicyRequest) isEnabled() bool {
+	return p.IsEnabled == nil || *p.IsEnabled
+}
+
+// isBlocking returns the desired blocking flag of a policy, which defaults to true
+func (p PolicyRequest) isBlocking() bool {
+	return p.IsBlocking == nil || *p.IsBlocking
+}
+
+// buildPolicyConfiguration returns the policy configuration to send to Azure DevOps for the request
+func buildPolicyConfiguration(request *PolicyRequest, typeID string) *PolicyConfiguration {
+	settings := map[string]interface{}{}
+	for key, value := range request.Settings {
+		settings[key] = value
+	}
+
+	scope := PolicyScope{RefName: request.RefName, MatchKind: request.MatchKind}
+	if request.RepositoryID != "" {
+		repositoryID := request.RepositoryID
+		scope.RepositoryID = &repositoryID
+	}
+	if request.RefName == "" {
+		// A policy on all the branches has no ref name and no match kind
+		scope.MatchKind = ""
+	}
+	settings["scope"] = []PolicyScope{scope}
+
+	return &PolicyConfiguration{
+		IsEnabled:  request.isEnabled(),
+		IsBlocking: request.isBlocking(),
+		Type:       PolicyTypeRef{ID: typeID},
+		Settings:   settings,
+	}
+}
+
+// policyScope returns the scope of the policy configuration, false if it does not have exactly one scope entry
+func policyScope(configuration *PolicyConfiguration) (PolicyScope, bool) {
+	var scopes []PolicyScope
+	raw, err := json.Marshal(configuration.Settings["scope"])
+	if err != nil || json.Unmarshal(raw, &scopes) != nil || len(scopes) != 1 {
+		return PolicyScope{}, false
+	}
+	return scopes[0], true
+}
+
+// matchesPolicy reports whether the existing policy configuration has the type and the scope of the request
+// For the policy types listed in identitySettings, the identifying settings must match too
+func matchesPolicy(configuration *PolicyConfiguration, request *PolicyRequest, typeID string) bool {
+	if !strings.EqualFold(configuration.Type.ID, typeID) || configuration.IsDeleted {
+		return false
+	}
+
+	scope, ok := policyScope(configuration)
+	if !ok {
+		return false
+	}
+	repositoryID := ""
+	if scope.RepositoryID != nil {
+		repositoryID = strings.ToLower(*scope.RepositoryID)
+	}
+	if repositoryID != request.RepositoryID || scope.RefName != request.RefName {
+		return false
+	}
+	if request.RefName != "" && !strings.EqualFold(scope.MatchKind, request.MatchKind) {
+		return false
+	}
+
+	current := normalizeSettings(configuration.Settings)
+	desired := normalizeSettings(request.Settings)
+	for _, key := range identitySettings[strings.ToLower(typeID)] {
+		if canonicalValue(current[key]) != canonicalValue(desired[key]) {
+			return false
+		}
+	}
+	return true
+}
+
+// findPolicy returns the first policy configuration (lowest ID) matching the request, nil if there is none
+func findPolicy(configurations []PolicyConfiguration, request *PolicyRequest, typeID string) *PolicyConfiguration {
+	var match *PolicyConfiguration
+	for i := range configurations {
+		if !matchesPolicy(&configurations[i], request, typeID) {
+			continue
+		}
+		if match == nil || configurations[i].ID < match.ID {
+			match = &configurations[i]
+		}
+	}
+	return match
+}
+
+// normalizeSettings returns the settings without scope and null values, with the unordered settings sorted
+// Keys are sorted when the settings are marshaled, so that the returned settings are stable
+func normalizeSettings(settings map[string]interface{}) map[string]interface{} {
+	normalized := map[string]interface{}{}
+	for key, value := range settings {
+		if key == "scope" || value == nil {
+			continue
+		}
+		value = normalizeValue(value)
+		if unorderedSettings[key] {
+			value = sortValues(value)
+		}
+		normalized[key] = value
+	}
+	return normalized
+}
+
+// normalizeValue removes the null values of the nested objects
+func normalizeValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		normalized := map[string]interface{}{}
+		for key, nested := range v {
+			if nested != nil {
+				normalized[key] = normalizeValue(nested)
+			}
+		}
+		return normalized
+	case []interface{}:
+		normalized := []interface{}{}
+		for _, nested := range v {
+			normalized = append(normalized, normalizeValue(nested))
+		}
+		return normalized
+	}
+	return value
+}
+
+// sortValues sorts a list of strings (case insensitive, lowercased), other values are returned as they are
+func sortValues(value interface{}) interface{} {
+	list, ok := value.([]interface{})
+	if !ok {
+		return value
+	}
+	values := []string{}
+	for _, item := range list {
+		s, ok := item.(string)
+		if !ok {
+			return value
+		}
+		values = append(values, strings.ToLower(s))
+	}
+	sort.Strings(values)
+
+	sorted := []interface{}{}
+	for _, s := range values {
+		sorted = append(sorted, s)
+	}
+	return sorted
+}
+
+// canonicalValue returns the value as it is marshaled, so that numbers decoded as json.Number and float64 compare equal
+func canonicalValue(value interface{}) string {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return string(raw)
+	}
+	raw, _ = json.Marshal(decoded)
+	return string(raw)
+}
+
+// isUpToDate reports whether the policy configuration already matches the request
+// Settings not set in the request are ignored, since Azure DevOps fills them with their defaults
+func isUpToDate(configuration *PolicyConfiguration, request *PolicyRequest) bool {
+	if configuration.IsEnabled != request.isEnabled() || configuration.IsBlocking != request.isBlocking() {
+		return false
+	}
+
+	desiredScope := buildPolicyConfiguration(request, configuration.Type.ID).Settings["scope"]
+	currentScope, ok := policyScope(configuration)
+	if !ok || canonicalValue(desiredScope) != canonicalValue([]PolicyScope{lowerRepositoryID(currentScope)}) {
+		return false
+	}
+
+	current := normalizeSettings(configuration.Settings)
+	for key, value := range normalizeSettings(request.Settings) {
+		if canonicalValue(current[key]) != canonicalValue(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// lowerRepositoryID returns the scope with the repository ID lowercased
+func lowerRepositoryID(scope PolicyScope) PolicyScope {
+	if scope.RepositoryID != nil {
+		repositoryID := strings.ToLower(*scope.RepositoryID)
+		scope.RepositoryID = &repositoryID
+	}
+	if scope.RefName == "" {
+		scope.MatchKind = ""
+	}
+	return scope
+}
+
+// mapPolicyFromAzure converts an Azure DevOps policy configuration to the policy returned by the plugin
+func mapPolicyFromAzure(configuration *PolicyConfiguration) *Policy {
+	policy := &Policy{
+		ID:         configuration.ID,
+		Type:       strings.ToLower(configuration.Type.ID),
+		TypeName:   configuration.Type.DisplayName,
+		IsEnabled:  configuration.IsEnabled,
+		IsBlocking: configuration.IsBlocking,
+		Settings:   normalizeSettings(configuration.Settings),
+	}
+	if scope, ok := policyScope(configuration); ok {
+		scope = lowerRepositoryID(scope)
+		if scope.RepositoryID != nil {
+			policy.RepositoryID = *scope.RepositoryID
+		}
+		policy.RefName = scope.RefName
+		policy.MatchKind = scope.MatchKind
+	}
+	return policy
+}
@@ -0,0 +1,116 @@
+package policy
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// decodeConfiguration decodes a policy configuration as it is returned by Azure DevOps
+func decodeConfiguration(t *testing.T, body string) *PolicyConfiguration {
+	t.Helper()
+	var configuration PolicyConfiguration
+	if err := decodeJSON([]byte(body), &configuration); err != nil {
+		t.Fatalf("failed to decode configuration: %v", err)
+	}
+	return &configuration
+}
+
+func TestValidatePolicyRequest(t *testing.T) {
+	tests := []struct {
+		name          string
+		request       PolicyRequest
+		expectedError string
+		expected      PolicyRequest
+	}{
+		{
+			name:     "defaults",
+			request:  PolicyRequest{Type: " build ", RepositoryID: "5B6C7D8E-0000-0000-0000-000000000002", RefName: "main"},
+			expected: PolicyRequest{Type: "build", RepositoryID: testRepository, RefName: mainRef, MatchKind: "Exact", Settings: map[string]interface{}{}},
+		},
+		{
+			name:     "prefix match kind",
+			request:  PolicyRequest{Type: "build", RefName: "refs/heads/release", MatchKind: "prefix"},
+			expected: PolicyRequest{Type: "build", RefName: "refs/heads/release", MatchKind: "Prefix", Settings: map[string]interface{}{}},
+		},
+		{name: "missing type", request: PolicyRequest{}, expectedError: "type is required"},
+		{name: "invalid repository ID", request: PolicyRequest{Type: "build", RepositoryID: "platform-api"}, expectedError: "repositoryId must be a GUID, got 'platform-api'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePolicyRequest(&tt.request)
+			if tt.expectedError != "" {
+				if err == nil || err.Error() != tt.expectedError {
+					t.Fatalf("validatePolicyRequest() error = %v, want %s", err, tt.expectedError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("validatePolicyRequest() error = %v", err)
+			}
+			got, _ := json.Marshal(tt.request)
+			want, _ := json.Marshal(tt.expected)
+			if string(got) != string(want) {
+				t.Errorf("validatePolicyRequest() = %s, want %s", got, want)
+			}
+		})
+	}
+}
+
+func TestNormalizeSettings(t *testing.T) {
+	configuration := decodeConfiguration(t, `{"settings":{"requiredReviewerIds":["B-2","a-1"],"message":null,"filenamePatterns":["/src/*"],"nested":{"x":null,"y":1},"scope":[{"repositoryId":null}]}}`)
+
+	got, _ := json.Marshal(normalizeSettings(configuration.Settings))
+	want := `{"filenamePatterns":["/src/*"],"nested":{"y":1},"requiredReviewerIds":["a-1","b-2"]}`
+	if string(got) != want {
+		t.Errorf("normalizeSettings() = %s, want %s", got, want)
+	}
+}
+
+func TestFindPolicy(t *testing.T) {
+	build := func(id, definition int, refName string) PolicyConfiguration {
+		body, _ := json.Marshal(map[string]interface{}{
+			"id":       id,
+			"type":     map[string]string{"id": BuildPolicyType},
+			"settings": map[string]interface{}{"buildDefinitionId": definition, "scope": []map[string]string{{"repositoryId": "5B6C7D8E-0000-0000-0000-000000000002", "refName": refName, "matchKind": "Exact"}}},
+		})
+		return *decodeConfiguration(t, string(body))
+	}
+	configurations := []PolicyConfiguration{build(30, 5, mainRef), build(20, 5, mainRef), build(10, 6, mainRef), build(5, 5, "refs/heads/dev")}
+
+	request := PolicyRequest{Type: "build", RepositoryID: testRepository, RefName: "main", Settings: map[string]interface{}{"buildDefinitionId": 5.0}}
+	if err := validatePolicyRequest(&request); err != nil {
+		t.Fatalf("validatePolicyRequest() error = %v", err)
+	}
+	if match := findPolicy(configurations, &request, BuildPolicyType); match == nil || match.ID != 20 {
+		t.Errorf("findPolicy() = %v, want policy 20", match)
+	}
+
+	request.Settings["buildDefinitionId"] = 7
+	if match := findPolicy(configurations, &request, BuildPolicyType); match != nil {
+		t.Errorf("findPolicy() = %v, want no policy for another build definition", match)
+	}
+}
+
+func TestIsUpToDate(t *testing.T) {
+	configuration := decodeConfiguration(t, reviewersPolicyResp)
+	request := PolicyRequest{Type: "minimumReviewers", RepositoryID: testRepository, RefName: "main", Settings: map[string]interface{}{"minimumApproverCount": 2}}
+	if err := validatePolicyRequest(&request); err != nil {
+		t.Fatalf("validatePolicyRequest() error = %v", err)
+	}
+	if !isUpToDate(configuration, &request) {
+		t.Errorf("isUpToDate() = false, want true")
+	}
+
+	disabled := false
+	request.IsEnabled = &disabled
+	if isUpToDate(configuration, &request) {
+		t.Errorf("isUpToDate() = true, want false when the enabled flag differs")
+	}
+
+	request.IsEnabled = nil
+	request.MatchKind = "Prefix"
+	if isUpToDate(configuration, &request) {
+		t.Errorf("isUpToDate() = true, want false when the match kind differs")
+	}
+}
@@ -0,0 +1,105 @@
+package policy
+
+// Policy represents the branch policy returned by the plugin
+// It is built from the PolicyConfiguration returned by:
+// GET https://dev.azure.com/{organization}/{project}/_apis/policy/configurations/{configurationId}
+type Policy struct {
+	ID           int                    `json:"id"`
+	Type         string                 `json:"type"`     // Policy type ID
+	TypeName     string                 `json:"typeName"` // e.g., Minimum number of reviewers
+	RepositoryID string                 `json:"repositoryId,omitempty"`
+	RefName      string                 `json:"refName,omitempty"`
+	MatchKind    string                 `json:"matchKind,omitempty"`
+	IsEnabled    bool                   `json:"isEnabled"`
+	IsBlocking   bool                   `json:"isBlocking"`
+	Settings     map[string]interface{} `json:"settings"` // Normalized: without scope and null values, keys sorted
+}
+
+// PolicyResponse represents the response of the policy endpoints
+type PolicyResponse Policy
+
+// PolicyRequest represents the request body for creating or updating a branch policy
+type PolicyRequest struct {
+	Type         string                 `json:"type"`                   // Required: policy type ID, alias (e.g., minimumReviewers) or display name
+	RepositoryID string                 `json:"repositoryId,omitempty"` // If not set, the policy applies to all the repositories of the project
+	RefName      string                 `json:"refName,omitempty"`      // e.g., main or refs/heads/main. If not set, the policy applies to all the branches
+	MatchKind    string                 `json:"matchKind,omitempty"`    // Exact (default) or Prefix
+	IsEnabled    *bool                  `json:"isEnabled,omitempty"`    // Defaults to true
+	IsBlocking   *bool                  `json:"isBlocking,omitempty"`   // Defaults to true
+	Settings     map[string]interface{} `json:"settings,omitempty"`     // Settings of the policy type, without scope
+}
+
+// ---
+
+// PolicyTypeRef represents the type of a policy configuration
+type PolicyTypeRef struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName,omitempty"`
+	URL         string `json:"url,omitempty"`
+}
+
+// PolicyConfiguration represents a policy configuration of Azure DevOps
+type PolicyConfiguration struct {
+	ID         int                    `json:"id,omitempty"`
+	Revision   int                    `json:"revision,omitempty"`
+	IsEnabled  bool                   `json:"isEnabled"`
+	IsBlocking bool                   `json:"isBlocking"`
+	IsDeleted  bool                   `json:"isDeleted,omitempty"`
+	Type       PolicyTypeRef          `json:"type"`
+	Settings   map[string]interface{} `json:"settings"`
+	URL        string                 `json:"url,omitempty"`
+}
+
+// PolicyConfigurationList represents a page of the response from:
+// GET https://dev.azure.com/{organization}/{project}/_apis/policy/configurations?repositoryId={repositoryId}&refName={refName}&policyType={policyType}
+// The continuation token of the next page is returned in the x-ms-continuationtoken header
+type PolicyConfigurationList struct {
+	Count int                   `json:"count"`
+	Value []PolicyConfiguration `json:"value"`
+}
+
+// PolicyScope represents an entry of the scope of a policy configuration, stored in its settings
+type PolicyScope struct {
+	RepositoryID *string `json:"repositoryId"` // null for all the repositories of the project
+	RefName      string  `json:"refName,omitempty"`
+	MatchKind    string  `json:"matchKind,omitempty"`
+}
+
+// PolicyType represents a policy type of Azure DevOps
+type PolicyType struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+	Description string `json:"description,omitempty"`
+}
+
+// PolicyTypeList represents the response from:
+// GET https://dev.azure.com/{organization}/{project}/_apis/policy/types
+type PolicyTypeList struct {
+	Count int          `json:"count"`
+	Value []PolicyType `json:"value"`
+}
+
+// ContinuationTokenHeader is the header with the continuation token of the next page of policy configurations
+const ContinuationTokenHeader = "x-ms-continuationtoken"
+
+// Well-known policy type IDs
+const (
+	MinimumReviewersPolicyType    = "fa4e907d-c16b-4a4c-9dfa-4906e5d171dd"
+	BuildPolicyType               = "0609b952-1397-4640-95ec-e00a01b2c241"
+	CommentRequirementsPolicyType = "c6a1889d-b943-4856-b76f-9e46bb6b0df2"
+	RequiredReviewersPolicyType   = "fd2167ab-b0be-447a-8ec8-39368250530e"
+	WorkItemLinkingPolicyType     = "40e92b44-2fe1-4dd6-b3d8-74a9c21d0c6e"
+	MergeStrategyPolicyType       = "fa4e907d-c16b-4a4c-9dfa-4916e5d171ab"
+	StatusPolicyType              = "cbdc66da-9728-4af8-aada-9a5a32e4a226"
+)
+
+// PolicyTypeAliases maps the aliases accepted in requests to the policy type IDs
+var PolicyTypeAliases = map[string]string{
+	"minimumreviewers":    MinimumReviewersPolicyType,
+	"build":               BuildPolicyType,
+	"commentrequirements": CommentRequirementsPolicyType,
+	"requiredreviewers":   RequiredReviewersPolicyType,
+	"workitemlinking":     WorkItemLinkingPolicyType,
+	"mergestrategy":       MergeStrategyPolicyType,
+	"status":              StatusPolicyType,
+}
@@ -0,0 +1,70 @@
+package project
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/utils"
+)
+
+// operationPoller polls the asynchronous operations of project creation, update and deletion (a variable, so that tests can shorten it)
+var operationPoller = utils.DefaultPoller()
+
+// operationError is returned when an operation completes without succeeding (failed or cancelled)
+type operationError struct {
+	Operation Operation
+}
+
+func (e *operationError) Error() string {
+	message := e.Operation.ResultMessage
+	if message == "" {
+		message = e.Operation.DetailedMessage
+	}
+	return fmt.Sprintf("operation %s %s: %s", e.Operation.ID, e.Operation.Status, message)
+}
+
+// decodeOperationReference reads the operation reference returned by Azure DevOps for an asynchronous request
+func decodeOperationReference(body []byte) (*OperationReference, error) {
+	var reference OperationReference
+	if err := json.Unmarshal(body, &reference); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal operation reference: %w", err)
+	}
+	if reference.ID == "" {
+		return nil, fmt.Errorf("operation reference without ID: %s", string(body))
+	}
+	return &reference, nil
+}
+
+// waitForOperation polls the operation until it succeeds
+// *operationError is returned if it fails or is cancelled, utils.ErrPollTimeout if it does not complete in time
+func (h *baseHandler) waitForOperation(ctx context.Context, organization, operationID, apiVersion, authHeader string) (*Operation, error) {
+	url := fmt.Sprintf("https://dev.azure.com/%s/_apis/operations/%s?api-version=%s", organization, operationID, apiVersion)
+
+	var operation Operation
+	err := operationPoller.Poll(ctx, func() (bool, error) {
+		body, err := h.DoAzureDevOpsRequestWithContext(ctx, "GET", url, authHeader, nil)
+		if err != nil {
+			return false, err
+		}
+
+		operation = Operation{}
+		if err := json.Unmarshal(body, &operation); err != nil {
+			return false, fmt.Errorf("failed to unmarshal operation: %w", err)
+		}
+
+		switch operation.Status {
+		case OperationStatusSucceeded:
+			return true, nil
+		case OperationStatusFailed, OperationStatusCancelled:
+			return false, &operationError{Operation: operation}
+		}
+
+		h.Log.Printf("Operation %s is %s, waiting %s", operationID, operation.Status, operationPoller.Interval)
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &operation, nil
+}
@@ -0,0 +1,444 @@
+package project
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers"
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/utils"
+)
+
+// Handler constructors
+func GetProject(opts handlers.HandlerOptions) handlers.Handler {
+	return &getHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func PostProject(opts handlers.HandlerOptions) handlers.Handler {
+	return &postHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func PatchProject(opts handlers.HandlerOptions) handlers.Handler {
+	return &patchHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func DeleteProject(opts handlers.HandlerOptions) handlers.Handler {
+	return &deleteHandler{baseHandler: newBaseHandler(opts)}
+}
+
+// Interface compliance verification
+var _ handlers.Handler = &getHandler{}
+var _ handlers.Handler = &postHandler{}
+var _ handlers.Handler = &patchHandler{}
+var _ handlers.Handler = &deleteHandler{}
+
+// Base handler with common functionality
+type baseHandler struct {
+	*handlers.BaseHandler
+}
+
+// Constructor for the base handler
+func newBaseHandler(opts handlers.HandlerOptions) *baseHandler {
+	return &baseHandler{BaseHandler: handlers.NewBaseHandler(opts)}
+}
+
+// Handler types embedding the base handler
+type getHandler struct {
+	*baseHandler
+}
+
+type postHandler struct {
+	*baseHandler
+}
+
+type patchHandler struct {
+	*baseHandler
+}
+
+type deleteHandler struct {
+	*baseHandler
+}
+
+// validateOrganizationParams validates the parameters of the organization level endpoints
+func (h *baseHandler) validateOrganizationParams(w http.ResponseWriter, organization, apiVersion string) bool {
+	if organization == "" {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Organization parameter is required")
+		return false
+	}
+	if apiVersion == "" {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "API version is required")
+		return false
+	}
+	return true
+}
+
+// isNotFound reports whether Azure DevOps returned 404
+func isNotFound(err error) bool {
+	var adoErr *handlers.AzureDevOpsError
+	return errors.As(err, &adoErr) && adoErr.StatusCode == http.StatusNotFound
+}
+
+// getProject retrieves a project by ID or name, together with its capabilities
+func (h *baseHandler) getProject(ctx context.Context, organization, id, apiVersion, authHeader string) (*Project, error) {
+	url := fmt.Sprintf("https://dev.azure.com/%s/_apis/projects/%s?includeCapabilities=true&api-version=%s", organization, url.PathEscape(id), apiVersion)
+
+	body, err := h.DoAzureDevOpsRequestWithContext(ctx, "GET", url, authHeader, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var project Project
+	if err := json.Unmarshal(body, &project); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal project: %w", err)
+	}
+	return normalizeProject(&project), nil
+}
+
+// resolveProcessTemplate returns the ID of the process template given by name or ID
+// The default process of the organization is returned when processTemplate is empty
+func (h *baseHandler) resolveProcessTemplate(ctx context.Context, organization, processTemplate, apiVersion, authHeader string) (string, error) {
+	if guidPattern.MatchString(processTemplate) {
+		return strings.ToLower(processTemplate), nil
+	}
+
+	url := fmt.Sprintf("https://dev.azure.com/%s/_apis/process/processes?api-version=%s", organization, apiVersion)
+	body, err := h.DoAzureDevOpsRequestWithContext(ctx, "GET", url, authHeader, nil)
+	if err != nil {
+		return "", err
+	}
+
+	var processes ProcessList
+	if err := json.Unmarshal(body, &processes); err != nil {
+		return "", fmt.Errorf("failed to unmarshal processes: %w", err)
+	}
+
+	process := findProcess(processes.Value, processTemplate)
+	if process == nil {
+		return "", nil
+	}
+	return strings.ToLower(process.ID), nil
+}
+
+// writeOperationError writes the response for an error returned by a handler running an asynchronous operation
+// A timeout (of the polling or of a request interrupted by the deadline of the handler) is returned as 504 so that
+// the caller retries, the other errors as WriteAzureDevOpsError does
+func (h *baseHandler) writeOperationError(w http.ResponseWriter, err error, action, notFoundMessage string) {
+	if errors.Is(err, utils.ErrPollTimeout) || errors.Is(err, context.DeadlineExceeded) {
+		h.WriteErrorResponse(w, http.StatusGatewayTimeout, fmt.Sprintf("Failed to %s: the operation did not complete within %s, retry the request", action, operationPoller.Timeout))
+		return
+	}
+	h.WriteAzureDevOpsError(w, err, action, notFoundMessage)
+}
+
+// GET handler implementation
+// @Summary Get a project
+// @Description Get a project of the organization by ID or name, together with its source control and process template
+// @ID get-project
+// @Param organization path string true "Organization name"
+// @Param id path string true "Project ID or name"
+// @Param api-version query string true "API version (e.g., 7.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Produce json
+// @Success 200 {object} ProjectResponse "Project details"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/core/projects/{id} [get]
+func (h *getHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	id := r.PathValue("id")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.validateOrganizationParams(w, organization, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	h.Log.Printf("Getting project %s for organization %s", id, organization)
+
+	project, err := h.getProject(r.Context(), organization, id, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "get project", fmt.Sprintf("Project %s not found", id))
+		return
+	}
+
+	h.WriteObjectResponse(w, http.StatusOK, ProjectResponse(*project))
+	h.Log.Printf("Successfully retrieved project %s", id)
+}
+
+// POST handler implementation
+// @Summary Create a project
+// @Description Create a project and wait for the asynchronous creation to complete. If a project with the same name already exists, it is returned with status 200.
+// @ID post-project
+// @Param organization path string true "Organization name"
+// @Param api-version query string true "API version (e.g., 7.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Param project body CreateProjectRequest true "Project creation request body"
+// @Accept json
+// @Produce json
+// @Success 200 {object} ProjectResponse "Existing project"
+// @Success 201 {object} ProjectResponse "Created project"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 500 "Internal Server Error"
+// @Failure 504 "Gateway Timeout"
+// @Router /api/{organization}/core/projects [post]
+func (h *postHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.validateOrganizationParams(w, organization, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Failed to read request body")
+		return
+	}
+
+	var createRequest CreateProjectRequest
+	if err := json.Unmarshal(body, &createRequest); err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Invalid JSON in request body")
+		return
+	}
+	if err := validateCreateProjectRequest(&createRequest); err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid project: %v", err))
+		return
+	}
+
+	h.Log.Printf("Creating project %s for organization %s", createRequest.Name, organization)
+
+	// All the requests share the timeout of the operation so that the caller gets a response before the write timeout
+	ctx, cancel := operationPoller.WithTimeout(r.Context())
+	defer cancel()
+
+	// Look up the project by name first so that the creation is idempotent (e.g., when retried after a timeout)
+	existing, err := h.getProject(ctx, organization, createRequest.Name, apiVersion, authHeader)
+	if err == nil {
+		h.WriteObjectResponse(w, http.StatusOK, ProjectResponse(*existing))
+		h.Log.Printf("Project %s already exists with ID %s", createRequest.Name, existing.ID)
+		return
+	}
+	if !isNotFound(err) {
+		h.writeOperationError(w, err, "get project", fmt.Sprintf("Organization %s not found", organization))
+		return
+	}
+
+	processTemplateID, err := h.resolveProcessTemplate(ctx, organization, createRequest.ProcessTemplate, apiVersion, authHeader)
+	if err != nil {
+		h.writeOperationError(w, err, "get process templates", fmt.Sprintf("Organization %s not found", organization))
+		return
+	}
+	if processTemplateID == "" {
+		h.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid project: process template '%s' not found", createRequest.ProcessTemplate))
+		return
+	}
+
+	requestBody, err := json.Marshal(AzureCreateProject{
+		Name:        createRequest.Name,
+		Description: createRequest.Description,
+		Visibility:  createRequest.Visibility,
+		Capabilities: &ProjectCapabilities{
+			VersionControl:  &VersionControlCapability{SourceControlType: createRequest.SourceControlType},
+			ProcessTemplate: &ProcessTemplateCapability{TemplateTypeID: processTemplateID},
+		},
+	})
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Failed to marshal project: %v", err))
+		return
+	}
+
+	url := fmt.Sprintf("https://dev.azure.com/%s/_apis/projects?api-version=%s", organization, apiVersion)
+	responseBody, err := h.DoAzureDevOpsRequestWithContext(ctx, "POST", url, authHeader, requestBody)
+	if err != nil {
+		h.writeOperationError(w, err, "create project", fmt.Sprintf("Organization %s not found", organization))
+		return
+	}
+
+	reference, err := decodeOperationReference(responseBody)
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Failed to create project: %v", err))
+		return
+	}
+	if _, err := h.waitForOperation(ctx, organization, reference.ID, apiVersion, authHeader); err != nil {
+		h.writeOperationError(w, err, "create project", fmt.Sprintf("Operation %s not found", reference.ID))
+		return
+	}
+
+	project, err := h.getProject(ctx, organization, createRequest.Name, apiVersion, authHeader)
+	if err != nil {
+		h.writeOperationError(w, err, "get project", fmt.Sprintf("Project %s not found", createRequest.Name))
+		return
+	}
+
+	h.WriteObjectResponse(w, http.StatusCreated, ProjectResponse(*project))
+	h.Log.Printf("Successfully created project %s with ID %s", project.Name, project.ID)
+}
+
+// PATCH handler implementation
+// @Summary Update a project
+// @Description Update the name, description or visibility of a project and wait for the asynchronous update to complete. Fields not set are not changed.
+// @ID patch-project
+// @Param organization path string true "Organization name"
+// @Param id path string true "Project ID"
+// @Param api-version query string true "API version (e.g., 7.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Param project body UpdateProjectRequest true "Project update request body"
+// @Accept json
+// @Produce json
+// @Success 200 {object} ProjectResponse "Updated project"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Failure 504 "Gateway Timeout"
+// @Router /api/{organization}/core/projects/{id} [patch]
+func (h *patchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	id := r.PathValue("id")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.validateOrganizationParams(w, organization, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Failed to read request body")
+		return
+	}
+
+	var updateRequest UpdateProjectRequest
+	if err := json.Unmarshal(body, &updateRequest); err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Invalid JSON in request body")
+		return
+	}
+	if err := validateUpdateProjectRequest(&updateRequest); err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid project: %v", err))
+		return
+	}
+
+	h.Log.Printf("Updating project %s for organization %s", id, organization)
+
+	// All the requests share the timeout of the operation so that the caller gets a response before the write timeout
+	ctx, cancel := operationPoller.WithTimeout(r.Context())
+	defer cancel()
+
+	current, err := h.getProject(ctx, organization, id, apiVersion, authHeader)
+	if err != nil {
+		h.writeOperationError(w, err, "get project", fmt.Sprintf("Project %s not found", id))
+		return
+	}
+
+	update := buildUpdateProject(&updateRequest, current)
+	if update == nil {
+		h.WriteObjectResponse(w, http.StatusOK, ProjectResponse(*current))
+		h.Log.Printf("Project %s is already up to date", id)
+		return
+	}
+
+	requestBody, err := json.Marshal(update)
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Failed to marshal project: %v", err))
+		return
+	}
+
+	url := fmt.Sprintf("https://dev.azure.com/%s/_apis/projects/%s?api-version=%s", organization, current.ID, apiVersion)
+	responseBody, err := h.DoAzureDevOpsRequestWithContext(ctx, "PATCH", url, authHeader, requestBody)
+	if err != nil {
+		h.writeOperationError(w, err, "update project", fmt.Sprintf("Project %s not found", id))
+		return
+	}
+
+	reference, err := decodeOperationReference(responseBody)
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Failed to update project: %v", err))
+		return
+	}
+	if _, err := h.waitForOperation(ctx, organization, reference.ID, apiVersion, authHeader); err != nil {
+		h.writeOperationError(w, err, "update project", fmt.Sprintf("Operation %s not found", reference.ID))
+		return
+	}
+
+	project, err := h.getProject(ctx, organization, current.ID, apiVersion, authHeader)
+	if err != nil {
+		h.writeOperationError(w, err, "get project", fmt.Sprintf("Project %s not found", id))
+		return
+	}
+
+	h.WriteObjectResponse(w, http.StatusOK, ProjectResponse(*project))
+	h.Log.Printf("Successfully updated project %s", id)
+}
+
+// DELETE handler implementation
+// @Summary Delete a project
+// @Description Delete a project and wait for the asynchronous deletion to complete
+// @ID delete-project
+// @Param organization path string true "Organization name"
+// @Param id path string true "Project ID"
+// @Param api-version query string true "API version (e.g., 7.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Success 204 "No Content"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Failure 504 "Gateway Timeout"
+// @Router /api/{organization}/core/projects/{id} [delete]
+func (h *deleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	id := r.PathValue("id")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.validateOrganizationParams(w, organization, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	h.Log.Printf("Deleting project %s for organization %s", id, organization)
+
+	// All the requests share the timeout of the operation so that the caller gets a response before the write timeout
+	ctx, cancel := operationPoller.WithTimeout(r.Context())
+	defer cancel()
+
+	url := fmt.Sprintf("https://dev.azure.com/%s/_apis/projects/%s?api-version=%s", organization, id, apiVersion)
+	responseBody, err := h.DoAzureDevOpsRequestWithContext(ctx, "DELETE", url, authHeader, nil)
+	if err != nil {
+		h.writeOperationError(w, err, "delete project", fmt.Sprintf("Project %s not found", id))
+		return
+	}
+
+	reference, err := decodeOperationReference(responseBody)
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Failed to delete project: %v", err))
+		return
+	}
+	if _, err := h.waitForOperation(ctx, organization, reference.ID, apiVersion, authHeader); err != nil {
+		h.writeOperationError(w, err, "delete project", fmt.Sprintf("Operation %s not found", reference.ID))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	h.Log.Printf("Successfully deleted project %s", id)
+}
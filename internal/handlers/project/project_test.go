@@ -0,0 +1,517 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers"
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/handlerstest"
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/utils"
+	"github.com/rs/zerolog"
+)
+
+// newTestBaseHandler creates a base handler for testing with a mock client
+func newTestBaseHandler(mockClient *handlerstest.MockHTTPClient) *baseHandler {
+	return newBaseHandler(handlerstest.NewHandlerOptions(mockClient))
+}
+
+// Test data constants
+const (
+	testOrg         = "testorg"
+	testProjectID   = "5e0b2f9a-1c2d-4e5f-8a9b-0c1d2e3f4a5b"
+	testProjectName = "Platform"
+	testOperationID = "op-1"
+	testAPIVersion  = "7.1"
+	testUsername    = "test"
+	testPassword    = "test"
+	agileProcessID  = "adcc42ab-9882-485e-a3ed-7678f01f66bc"
+	scrumProcessID  = "6b724908-ef14-45cf-84f8-768b5384da45"
+)
+
+var (
+	projectsURL      = fmt.Sprintf("https://dev.azure.com/%s/_apis/projects?api-version=%s", testOrg, testAPIVersion)
+	projectURL       = fmt.Sprintf("https://dev.azure.com/%s/_apis/projects/%s?api-version=%s", testOrg, testProjectID, testAPIVersion)
+	projectByIDURL   = fmt.Sprintf("https://dev.azure.com/%s/_apis/projects/%s?includeCapabilities=true&api-version=%s", testOrg, testProjectID, testAPIVersion)
+	projectByNameURL = fmt.Sprintf("https://dev.azure.com/%s/_apis/projects/%s?includeCapabilities=true&api-version=%s", testOrg, testProjectName, testAPIVersion)
+	processesURL     = fmt.Sprintf("https://dev.azure.com/%s/_apis/process/processes?api-version=%s", testOrg, testAPIVersion)
+	operationURL     = fmt.Sprintf("https://dev.azure.com/%s/_apis/operations/%s?api-version=%s", testOrg, testOperationID, testAPIVersion)
+
+	validProjectResp = `{"id":"5E0B2F9A-1C2D-4E5F-8A9B-0C1D2E3F4A5B","name":"Platform","description":"Platform team","url":"https://dev.azure.com/testorg/_apis/projects/5e0b2f9a-1c2d-4e5f-8a9b-0c1d2e3f4a5b","state":"wellFormed","revision":42,"visibility":"private","lastUpdateTime":"2025-06-30T14:33:02.06Z","capabilities":{"versioncontrol":{"sourceControlType":"Git","gitEnabled":"True","tfvcEnabled":"False"},"processTemplate":{"templateName":"Agile","templateTypeId":"ADCC42AB-9882-485E-A3ED-7678F01F66BC"}}}`
+	processesResp    = `{"count":2,"value":[{"id":"6b724908-ef14-45cf-84f8-768b5384da45","name":"Scrum","isDefault":false},{"id":"adcc42ab-9882-485e-a3ed-7678f01f66bc","name":"Agile","isDefault":true}]}`
+	operationRefResp = `{"id":"op-1","status":"notSet","url":"https://dev.azure.com/testorg/_apis/operations/op-1"}`
+
+	operationInProgress = handlerstest.MockResponse{StatusCode: http.StatusOK, Body: `{"id":"op-1","status":"inProgress"}`}
+	operationSucceeded  = handlerstest.MockResponse{StatusCode: http.StatusOK, Body: `{"id":"op-1","status":"succeeded"}`}
+	projectNotFound     = handlerstest.MockResponse{StatusCode: http.StatusNotFound, Body: `{"message":"TF200016: The following project does not exist: Platform."}`}
+	projectFound        = handlerstest.MockResponse{StatusCode: http.StatusOK, Body: validProjectResp}
+
+	expectedProjectResp = `{"description":"Platform team","id":"5e0b2f9a-1c2d-4e5f-8a9b-0c1d2e3f4a5b","lastUpdateTime":"2025-06-30T14:33:02Z","name":"Platform","revision":42,"state":"wellFormed","url":"https://dev.azure.com/testorg/_apis/projects/5e0b2f9a-1c2d-4e5f-8a9b-0c1d2e3f4a5b","visibility":"private","capabilities":{"versioncontrol":{"sourceControlType":"Git"},"processTemplate":{"templateName":"Agile","templateTypeId":"adcc42ab-9882-485e-a3ed-7678f01f66bc"}}}`
+)
+
+// projectTestCase is a test case for the handlers of this package
+type projectTestCase struct {
+	name                 string
+	pathValues           map[string]string
+	query                url.Values
+	noAuth               bool
+	body                 string
+	setupMock            func(*handlerstest.MockHTTPClient)
+	expectedStatus       int
+	expectedBody         string
+	expectedBodyContains string
+	expectedRequestCount int
+	verify               func(t *testing.T, mockClient *handlerstest.MockHTTPClient)
+}
+
+// runProjectTests runs the given test cases against the handler built by newHandler
+func runProjectTests(t *testing.T, method string, newHandler func(*baseHandler) http.Handler, tests []projectTestCase) {
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := handlerstest.NewMockHTTPClient()
+			if tt.setupMock != nil {
+				tt.setupMock(mockClient)
+			}
+			handler := newHandler(newTestBaseHandler(mockClient))
+
+			target := "/api/test"
+			if len(tt.query) > 0 {
+				target += "?" + tt.query.Encode()
+			}
+			var body io.Reader
+			if tt.body != "" {
+				body = strings.NewReader(tt.body)
+			}
+			req := httptest.NewRequest(method, target, body)
+			for key, value := range tt.pathValues {
+				req.SetPathValue(key, value)
+			}
+			if !tt.noAuth {
+				req.SetBasicAuth(testUsername, testPassword)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("Status code = %d, want %d. Body: %s", rr.Code, tt.expectedStatus, rr.Body.String())
+			}
+			if tt.expectedBody != "" && rr.Body.String() != tt.expectedBody {
+				t.Errorf("Body = %s, want %s", rr.Body.String(), tt.expectedBody)
+			}
+			if tt.expectedBodyContains != "" && !strings.Contains(rr.Body.String(), tt.expectedBodyContains) {
+				t.Errorf("Body = %s, want it to contain %s", rr.Body.String(), tt.expectedBodyContains)
+			}
+			if mockClient.RequestCount() != tt.expectedRequestCount {
+				t.Errorf("Request count = %d, want %d", mockClient.RequestCount(), tt.expectedRequestCount)
+			}
+			if tt.verify != nil {
+				tt.verify(t, mockClient)
+			}
+		})
+	}
+}
+
+// apiVersionQuery returns the query with the test API version
+func apiVersionQuery() url.Values {
+	return url.Values{"api-version": {testAPIVersion}}
+}
+
+// requestBody returns the body of the n-th request sent to Azure DevOps
+func requestBody(t *testing.T, mockClient *handlerstest.MockHTTPClient, n int) string {
+	t.Helper()
+	if len(mockClient.Requests) <= n || mockClient.Requests[n].Body == nil {
+		t.Fatalf("request %d has no body", n)
+	}
+	body, err := io.ReadAll(mockClient.Requests[n].Body)
+	if err != nil {
+		t.Fatalf("failed to read request %d body: %v", n, err)
+	}
+	return string(body)
+}
+
+// shortenOperationPolling makes the operations polled by the handlers complete quickly in tests
+func shortenOperationPolling(t *testing.T, interval, timeout time.Duration) {
+	poller := operationPoller
+	operationPoller = utils.Poller{Interval: interval, Timeout: timeout}
+	t.Cleanup(func() {
+		operationPoller = poller
+	})
+}
+
+// Test constructor functions
+func TestProjectConstructors(t *testing.T) {
+	client := &http.Client{}
+	logger := zerolog.New(os.Stdout).With().Timestamp().Logger()
+	opts := handlers.HandlerOptions{
+		Client: client,
+		Log:    &logger,
+	}
+
+	constructors := map[string]func(handlers.HandlerOptions) handlers.Handler{
+		"GetProject":    GetProject,
+		"PostProject":   PostProject,
+		"PatchProject":  PatchProject,
+		"DeleteProject": DeleteProject,
+	}
+
+	for name, constructor := range constructors {
+		t.Run(name, func(t *testing.T) {
+			if h := constructor(opts); h == nil {
+				t.Fatalf("%s should return a non-nil handler", name)
+			}
+		})
+	}
+}
+
+// Test GET handler
+func TestGetHandler_ServeHTTP(t *testing.T) {
+	pathValues := map[string]string{"organization": testOrg, "id": testProjectID}
+	runProjectTests(t, "GET", func(b *baseHandler) http.Handler { return &getHandler{baseHandler: b} }, []projectTestCase{
+		{
+			name:                 "successful retrieval with capabilities",
+			pathValues:           pathValues,
+			query:                apiVersionQuery(),
+			setupMock:            func(m *handlerstest.MockHTTPClient) { m.SetResponse(projectByIDURL, http.StatusOK, validProjectResp) },
+			expectedStatus:       http.StatusOK,
+			expectedBody:         expectedProjectResp,
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "missing organization",
+			pathValues:           map[string]string{"id": testProjectID},
+			query:                apiVersionQuery(),
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Organization parameter is required",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "missing basic auth",
+			pathValues:           pathValues,
+			query:                apiVersionQuery(),
+			noAuth:               true,
+			expectedStatus:       http.StatusUnauthorized,
+			expectedBody:         "Request rejected due to missing or invalid Basic authentication",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "project not found",
+			pathValues:           pathValues,
+			query:                apiVersionQuery(),
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Project 5e0b2f9a-1c2d-4e5f-8a9b-0c1d2e3f4a5b not found",
+			expectedRequestCount: 1,
+		},
+	})
+}
+
+// Test POST handler
+func TestPostHandler_ServeHTTP(t *testing.T) {
+	shortenOperationPolling(t, time.Millisecond, time.Second)
+	pathValues := map[string]string{"organization": testOrg}
+	runProjectTests(t, "POST", func(b *baseHandler) http.Handler { return &postHandler{baseHandler: b} }, []projectTestCase{
+		{
+			name:       "project created with the default process after polling the operation",
+			pathValues: pathValues,
+			query:      apiVersionQuery(),
+			body:       `{"name":"Platform","description":"Platform team"}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponseSequence(projectByNameURL, projectNotFound, projectFound)
+				m.SetResponse(processesURL, http.StatusOK, processesResp)
+				m.SetMethodResponse("POST", projectsURL, http.StatusAccepted, operationRefResp)
+				m.SetResponseSequence(operationURL, operationInProgress, operationInProgress, operationSucceeded)
+			},
+			expectedStatus:       http.StatusCreated,
+			expectedBody:         expectedProjectResp,
+			expectedRequestCount: 7,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				want := `{"name":"Platform","description":"Platform team","visibility":"private","capabilities":{"versioncontrol":{"sourceControlType":"Git"},"processTemplate":{"templateTypeId":"adcc42ab-9882-485e-a3ed-7678f01f66bc"}}}`
+				if got := requestBody(t, m, 2); got != want {
+					t.Errorf("Request body = %s, want %s", got, want)
+				}
+			},
+		},
+		{
+			name:       "process template given by name, source control and visibility normalized",
+			pathValues: pathValues,
+			query:      apiVersionQuery(),
+			body:       `{"name":"Platform","processTemplate":"scrum","sourceControlType":"tfvc","visibility":"Public"}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponseSequence(projectByNameURL, projectNotFound, projectFound)
+				m.SetResponse(processesURL, http.StatusOK, processesResp)
+				m.SetMethodResponse("POST", projectsURL, http.StatusAccepted, operationRefResp)
+				m.SetResponseSequence(operationURL, operationSucceeded)
+			},
+			expectedStatus:       http.StatusCreated,
+			expectedRequestCount: 5,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				want := `{"name":"Platform","visibility":"public","capabilities":{"versioncontrol":{"sourceControlType":"Tfvc"},"processTemplate":{"templateTypeId":"6b724908-ef14-45cf-84f8-768b5384da45"}}}`
+				if got := requestBody(t, m, 2); got != want {
+					t.Errorf("Request body = %s, want %s", got, want)
+				}
+			},
+		},
+		{
+			name:       "process template given by ID is not looked up",
+			pathValues: pathValues,
+			query:      apiVersionQuery(),
+			body:       `{"name":"Platform","processTemplate":"6B724908-EF14-45CF-84F8-768B5384DA45"}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponseSequence(projectByNameURL, projectNotFound, projectFound)
+				m.SetMethodResponse("POST", projectsURL, http.StatusAccepted, operationRefResp)
+				m.SetResponseSequence(operationURL, operationSucceeded)
+			},
+			expectedStatus:       http.StatusCreated,
+			expectedRequestCount: 4,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				if got := requestBody(t, m, 1); !strings.Contains(got, `"templateTypeId":"6b724908-ef14-45cf-84f8-768b5384da45"`) {
+					t.Errorf("Request body = %s, want it to contain the lowercased process template ID", got)
+				}
+			},
+		},
+		{
+			name:       "existing project returned",
+			pathValues: pathValues,
+			query:      apiVersionQuery(),
+			body:       `{"name":"Platform"}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(projectByNameURL, http.StatusOK, validProjectResp)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBody:         expectedProjectResp,
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "missing name",
+			pathValues:           pathValues,
+			query:                apiVersionQuery(),
+			body:                 `{"description":"Platform team"}`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid project: name is required",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "unsupported source control",
+			pathValues:           pathValues,
+			query:                apiVersionQuery(),
+			body:                 `{"name":"Platform","sourceControlType":"svn"}`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid project: sourceControlType 'svn' is not supported, supported types are: Git, Tfvc",
+			expectedRequestCount: 0,
+		},
+		{
+			name:       "unknown process template",
+			pathValues: pathValues,
+			query:      apiVersionQuery(),
+			body:       `{"name":"Platform","processTemplate":"Kanban"}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(projectByNameURL, http.StatusNotFound, `{}`)
+				m.SetResponse(processesURL, http.StatusOK, processesResp)
+			},
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid project: process template 'Kanban' not found",
+			expectedRequestCount: 2,
+		},
+		{
+			name:       "failed operation",
+			pathValues: pathValues,
+			query:      apiVersionQuery(),
+			body:       `{"name":"Platform","processTemplate":"Agile"}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(projectByNameURL, http.StatusNotFound, `{}`)
+				m.SetResponse(processesURL, http.StatusOK, processesResp)
+				m.SetMethodResponse("POST", projectsURL, http.StatusAccepted, operationRefResp)
+				m.SetResponse(operationURL, http.StatusOK, `{"id":"op-1","status":"failed","resultMessage":"Project name is reserved"}`)
+			},
+			expectedStatus:       http.StatusInternalServerError,
+			expectedBody:         "Failed to create project: operation op-1 failed: Project name is reserved",
+			expectedRequestCount: 4,
+		},
+		{
+			name:       "azure devops error on lookup forwarded",
+			pathValues: pathValues,
+			query:      apiVersionQuery(),
+			body:       `{"name":"Platform"}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(projectByNameURL, http.StatusForbidden, `{"message":"forbidden"}`)
+			},
+			expectedStatus:       http.StatusForbidden,
+			expectedBody:         `{"message":"forbidden"}`,
+			expectedRequestCount: 1,
+		},
+	})
+}
+
+// Test POST handler when the operation does not complete in time
+func TestPostHandler_OperationTimeout(t *testing.T) {
+	shortenOperationPolling(t, 10*time.Millisecond, 5*time.Millisecond)
+	runProjectTests(t, "POST", func(b *baseHandler) http.Handler { return &postHandler{baseHandler: b} }, []projectTestCase{
+		{
+			name:       "operation still in progress",
+			pathValues: map[string]string{"organization": testOrg},
+			query:      apiVersionQuery(),
+			body:       `{"name":"Platform","processTemplate":"` + agileProcessID + `"}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(projectByNameURL, http.StatusNotFound, `{}`)
+				m.SetMethodResponse("POST", projectsURL, http.StatusAccepted, operationRefResp)
+				m.SetResponseSequence(operationURL, operationInProgress)
+			},
+			expectedStatus:       http.StatusGatewayTimeout,
+			expectedBody:         "Failed to create project: the operation did not complete within 5ms, retry the request",
+			expectedRequestCount: 3,
+			verify: func(t *testing.T, mockClient *handlerstest.MockHTTPClient) {
+				// The requests made before and after the polling are bounded too
+				for i, req := range mockClient.Requests {
+					if _, ok := req.Context().Deadline(); !ok {
+						t.Errorf("request %d (%s %s) has no deadline", i, req.Method, req.URL)
+					}
+				}
+			},
+		},
+		{
+			name:       "request interrupted by the deadline",
+			pathValues: map[string]string{"organization": testOrg},
+			query:      apiVersionQuery(),
+			body:       `{"name":"Platform","processTemplate":"` + agileProcessID + `"}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetError(projectByNameURL, context.DeadlineExceeded)
+			},
+			expectedStatus:       http.StatusGatewayTimeout,
+			expectedBody:         "Failed to get project: the operation did not complete within 5ms, retry the request",
+			expectedRequestCount: 1,
+		},
+	})
+}
+
+// Test PATCH handler
+func TestPatchHandler_ServeHTTP(t *testing.T) {
+	shortenOperationPolling(t, time.Millisecond, time.Second)
+	pathValues := map[string]string{"organization": testOrg, "id": testProjectID}
+	runProjectTests(t, "PATCH", func(b *baseHandler) http.Handler { return &patchHandler{baseHandler: b} }, []projectTestCase{
+		{
+			name:       "only changed fields sent",
+			pathValues: pathValues,
+			query:      apiVersionQuery(),
+			body:       `{"name":"Platform","description":"Platform engineering","visibility":"PUBLIC"}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponseSequence(projectByIDURL, projectFound, handlerstest.MockResponse{StatusCode: http.StatusOK, Body: strings.Replace(validProjectResp, `"description":"Platform team"`, `"description":"Platform engineering"`, 1)})
+				m.SetMethodResponse("PATCH", projectURL, http.StatusAccepted, operationRefResp)
+				m.SetResponseSequence(operationURL, operationInProgress, operationSucceeded)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `"description":"Platform engineering"`,
+			expectedRequestCount: 5,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				if got, want := requestBody(t, m, 1), `{"description":"Platform engineering","visibility":"public"}`; got != want {
+					t.Errorf("Request body = %s, want %s", got, want)
+				}
+			},
+		},
+		{
+			name:                 "project already up to date",
+			pathValues:           pathValues,
+			query:                apiVersionQuery(),
+			body:                 `{"name":"Platform","description":"Platform team","visibility":"private"}`,
+			setupMock:            func(m *handlerstest.MockHTTPClient) { m.SetResponse(projectByIDURL, http.StatusOK, validProjectResp) },
+			expectedStatus:       http.StatusOK,
+			expectedBody:         expectedProjectResp,
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "unsupported visibility",
+			pathValues:           pathValues,
+			query:                apiVersionQuery(),
+			body:                 `{"visibility":"internal"}`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid project: visibility 'internal' is not supported, supported values are: private, public",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "project not found",
+			pathValues:           pathValues,
+			query:                apiVersionQuery(),
+			body:                 `{"name":"Platform 2"}`,
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Project 5e0b2f9a-1c2d-4e5f-8a9b-0c1d2e3f4a5b not found",
+			expectedRequestCount: 1,
+		},
+	})
+}
+
+// Test DELETE handler
+func TestDeleteHandler_ServeHTTP(t *testing.T) {
+	shortenOperationPolling(t, time.Millisecond, time.Second)
+	pathValues := map[string]string{"organization": testOrg, "id": testProjectID}
+	runProjectTests(t, "DELETE", func(b *baseHandler) http.Handler { return &deleteHandler{baseHandler: b} }, []projectTestCase{
+		{
+			name:       "successful deletion after polling the operation",
+			pathValues: pathValues,
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetMethodResponse("DELETE", projectURL, http.StatusAccepted, operationRefResp)
+				m.SetResponseSequence(operationURL, operationInProgress, operationSucceeded)
+			},
+			expectedStatus:       http.StatusNoContent,
+			expectedRequestCount: 3,
+		},
+		{
+			name:                 "project not found",
+			pathValues:           pathValues,
+			query:                apiVersionQuery(),
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Project 5e0b2f9a-1c2d-4e5f-8a9b-0c1d2e3f4a5b not found",
+			expectedRequestCount: 1,
+		},
+		{
+			name:       "network error while polling",
+			pathValues: pathValues,
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetMethodResponse("DELETE", projectURL, http.StatusAccepted, operationRefResp)
+				m.SetError(operationURL, errors.New("connection reset"))
+			},
+			expectedStatus:       http.StatusInternalServerError,
+			expectedBodyContains: "Failed to delete project",
+			expectedRequestCount: 2,
+		},
+	})
+}
+
+func TestFindProcess(t *testing.T) {
+	processes := []Process{{ID: scrumProcessID, Name: "Scrum"}, {ID: agileProcessID, Name: "Agile", IsDefault: true}}
+
+	tests := []struct {
+		name       string
+		processes  []Process
+		search     string
+		expectedID string
+	}{
+		{name: "default process", processes: processes, expectedID: agileProcessID},
+		{name: "by name (case insensitive)", processes: processes, search: "SCRUM", expectedID: scrumProcessID},
+		{name: "unknown name", processes: processes, search: "CMMI"},
+		{name: "no default process", processes: processes[:1]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			process := findProcess(tt.processes, tt.search)
+			if tt.expectedID == "" {
+				if process != nil {
+					t.Errorf("findProcess() = %v, want nil", process)
+				}
+				return
+			}
+			if process == nil || process.ID != tt.expectedID {
+				t.Errorf("findProcess() = %v, want process %s", process, tt.expectedID)
+			}
+		})
+	}
+}
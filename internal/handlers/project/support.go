@@ -0,0 +1,110 @@
+package project
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/gitrepository"
+)
+
+// guidPattern matches the IDs of Azure DevOps resources (e.g., process templates)
+var guidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// validateCreateProjectRequest checks the fields of a project creation request and applies the defaults
+func validateCreateProjectRequest(request *CreateProjectRequest) error {
+	request.Name = strings.TrimSpace(request.Name)
+	if request.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	visibility, err := normalizeVisibility(request.Visibility)
+	if err != nil {
+		return err
+	}
+	request.Visibility = visibility
+
+	switch strings.ToLower(strings.TrimSpace(request.SourceControlType)) {
+	case "", "git":
+		request.SourceControlType = SourceControlTypeGit
+	case "tfvc":
+		request.SourceControlType = SourceControlTypeTfvc
+	default:
+		return fmt.Errorf("sourceControlType '%s' is not supported, supported types are: %s, %s", request.SourceControlType, SourceControlTypeGit, SourceControlTypeTfvc)
+	}
+
+	request.ProcessTemplate = strings.TrimSpace(request.ProcessTemplate)
+	return nil
+}
+
+// validateUpdateProjectRequest checks the fields of a project update request
+func validateUpdateProjectRequest(request *UpdateProjectRequest) error {
+	request.Name = strings.TrimSpace(request.Name)
+	if request.Visibility == "" {
+		return nil
+	}
+
+	visibility, err := normalizeVisibility(request.Visibility)
+	if err != nil {
+		return err
+	}
+	request.Visibility = visibility
+	return nil
+}
+
+// normalizeVisibility lowercases the visibility of a project, private is returned when not set
+func normalizeVisibility(visibility gitrepository.ProjectVisibility) (gitrepository.ProjectVisibility, error) {
+	switch gitrepository.ProjectVisibility(strings.ToLower(strings.TrimSpace(string(visibility)))) {
+	case "", gitrepository.ProjectVisibilityPrivate:
+		return gitrepository.ProjectVisibilityPrivate, nil
+	case gitrepository.ProjectVisibilityPublic:
+		return gitrepository.ProjectVisibilityPublic, nil
+	}
+	return "", fmt.Errorf("visibility '%s' is not supported, supported values are: %s, %s", visibility, gitrepository.ProjectVisibilityPrivate, gitrepository.ProjectVisibilityPublic)
+}
+
+// findProcess returns the process with the given name (case insensitive), or the default process when name is empty
+// nil is returned if there is none
+func findProcess(processes []Process, name string) *Process {
+	for i := range processes {
+		if name == "" && processes[i].IsDefault {
+			return &processes[i]
+		}
+		if name != "" && strings.EqualFold(processes[i].Name, name) {
+			return &processes[i]
+		}
+	}
+	return nil
+}
+
+// buildUpdateProject returns the Azure DevOps request body with the fields that differ from the current project
+// nil is returned if the project is already up to date
+func buildUpdateProject(request *UpdateProjectRequest, current *Project) *AzureUpdateProject {
+	update := &AzureUpdateProject{}
+	changed := false
+	if request.Name != "" && request.Name != current.Name {
+		update.Name = request.Name
+		changed = true
+	}
+	if request.Description != nil && *request.Description != current.Description {
+		update.Description = request.Description
+		changed = true
+	}
+	if request.Visibility != "" && request.Visibility != current.Visibility {
+		update.Visibility = request.Visibility
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+	return update
+}
+
+// normalizeProject lowercases the IDs returned by Azure DevOps
+func normalizeProject(project *Project) *Project {
+	project.ID = strings.ToLower(project.ID)
+	if project.Capabilities != nil && project.Capabilities.ProcessTemplate != nil {
+		project.Capabilities.ProcessTemplate.TemplateTypeID = strings.ToLower(project.Capabilities.ProcessTemplate.TemplateTypeID)
+	}
+	return project
+}
@@ -0,0 +1,121 @@
+package project
+
+import (
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/gitrepository"
+)
+
+// Project represents the project returned by the plugin
+// It has the shape of the TeamProjectReference returned by Azure DevOps (see gitrepository.TeamProjectReference),
+// together with the capabilities the project was created with, as returned by:
+// GET /{organization}/_apis/projects/{projectId}?includeCapabilities=true
+type Project struct {
+	gitrepository.TeamProjectReference
+	Capabilities *ProjectCapabilities `json:"capabilities,omitempty"`
+}
+
+// ProjectCapabilities represents the capabilities of a project
+// The same shape is used in the creation request sent to Azure DevOps
+type ProjectCapabilities struct {
+	VersionControl  *VersionControlCapability  `json:"versioncontrol,omitempty"`
+	ProcessTemplate *ProcessTemplateCapability `json:"processTemplate,omitempty"`
+}
+
+// VersionControlCapability represents the source control of a project
+type VersionControlCapability struct {
+	SourceControlType string `json:"sourceControlType"` // enum: Git, Tfvc
+}
+
+// ProcessTemplateCapability represents the process template of a project
+type ProcessTemplateCapability struct {
+	TemplateName   string `json:"templateName,omitempty"`
+	TemplateTypeID string `json:"templateTypeId"`
+}
+
+// CreateProjectRequest represents the request body for creating a project
+type CreateProjectRequest struct {
+	Name              string                          `json:"name"` // Required
+	Description       string                          `json:"description,omitempty"`
+	Visibility        gitrepository.ProjectVisibility `json:"visibility,omitempty"`        // Defaults to private
+	SourceControlType string                          `json:"sourceControlType,omitempty"` // Git (default) or Tfvc
+	ProcessTemplate   string                          `json:"processTemplate,omitempty"`   // Name or ID of the process, defaults to the default process of the organization
+}
+
+// UpdateProjectRequest represents the request body for updating a project
+// Fields not set are not changed. Source control and process template cannot be changed
+type UpdateProjectRequest struct {
+	Name        string                          `json:"name,omitempty"`
+	Description *string                         `json:"description,omitempty"`
+	Visibility  gitrepository.ProjectVisibility `json:"visibility,omitempty"`
+}
+
+// ProjectResponse represents the response of the project endpoints
+type ProjectResponse Project
+
+// ---
+
+// AzureCreateProject represents the request body sent to Azure DevOps to create a project:
+// POST /{organization}/_apis/projects
+type AzureCreateProject struct {
+	Name         string                          `json:"name"`
+	Description  string                          `json:"description,omitempty"`
+	Visibility   gitrepository.ProjectVisibility `json:"visibility"`
+	Capabilities *ProjectCapabilities            `json:"capabilities"`
+}
+
+// AzureUpdateProject represents the request body sent to Azure DevOps to update a project:
+// PATCH /{organization}/_apis/projects/{projectId}
+type AzureUpdateProject struct {
+	Name        string                          `json:"name,omitempty"`
+	Description *string                         `json:"description,omitempty"`
+	Visibility  gitrepository.ProjectVisibility `json:"visibility,omitempty"`
+}
+
+// OperationReference represents the reference to an asynchronous operation returned by Azure DevOps
+// when a project is created, updated or deleted (202 Accepted)
+type OperationReference struct {
+	ID     string          `json:"id"`
+	Status OperationStatus `json:"status"`
+	URL    string          `json:"url"`
+}
+
+// Operation represents an asynchronous operation of Azure DevOps:
+// GET /{organization}/_apis/operations/{operationId}
+type Operation struct {
+	ID              string          `json:"id"`
+	Status          OperationStatus `json:"status"`
+	ResultMessage   string          `json:"resultMessage,omitempty"`
+	DetailedMessage string          `json:"detailedMessage,omitempty"`
+}
+
+// OperationStatus represents the status of an asynchronous operation
+type OperationStatus string
+
+const (
+	OperationStatusNotSet     OperationStatus = "notSet"
+	OperationStatusQueued     OperationStatus = "queued"
+	OperationStatusInProgress OperationStatus = "inProgress"
+	OperationStatusCancelled  OperationStatus = "cancelled"
+	OperationStatusSucceeded  OperationStatus = "succeeded"
+	OperationStatusFailed     OperationStatus = "failed"
+)
+
+// Process represents a process (template) of the organization:
+// GET /{organization}/_apis/process/processes
+type Process struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	IsDefault bool   `json:"isDefault"`
+}
+
+// ProcessList represents the response from:
+// GET /{organization}/_apis/process/processes
+type ProcessList struct {
+	Count int       `json:"count"`
+	Value []Process `json:"value"`
+}
+
+// Source control types of a project
+const (
+	SourceControlTypeGit  = "Git"
+	SourceControlTypeTfvc = "Tfvc"
+)
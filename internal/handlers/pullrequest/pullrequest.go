@@ -0,0 +1,351 @@
+package pullrequest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	neturl "net/url"
+	"strconv"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers"
+)
+
+// Handler constructors
+func GetPullRequest(opts handlers.HandlerOptions) handlers.Handler {
+	return &getHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func PostPullRequest(opts handlers.HandlerOptions) handlers.Handler {
+	return &postHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func DeletePullRequest(opts handlers.HandlerOptions) handlers.Handler {
+	return &deleteHandler{baseHandler: newBaseHandler(opts)}
+}
+
+// Interface compliance verification
+var _ handlers.Handler = &getHandler{}
+var _ handlers.Handler = &postHandler{}
+var _ handlers.Handler = &deleteHandler{}
+
+// Base handler with common functionality
+type baseHandler struct {
+	*handlers.BaseHandler
+}
+
+// Constructor for the base handler
+func newBaseHandler(opts handlers.HandlerOptions) *baseHandler {
+	return &baseHandler{BaseHandler: handlers.NewBaseHandler(opts)}
+}
+
+// Handler types embedding the base handler
+type getHandler struct {
+	*baseHandler
+}
+
+type postHandler struct {
+	*baseHandler
+}
+
+type deleteHandler struct {
+	*baseHandler
+}
+
+// getPullRequest returns the pull request with the given ID, with its work items
+func (h *baseHandler) getPullRequest(organization, projectId, repositoryId, id, apiVersion, authHeader string) (*GitPullRequest, error) {
+	url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/git/repositories/%s/pullrequests/%s?includeWorkItemRefs=true&api-version=%s", organization, projectId, repositoryId, id, apiVersion)
+
+	body, err := h.DoAzureDevOpsRequest("GET", url, authHeader, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var pullRequest GitPullRequest
+	if err := json.Unmarshal(body, &pullRequest); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal pull request: %w", err)
+	}
+	return &pullRequest, nil
+}
+
+// findActivePullRequest returns the active pull request of the source and target branches (lowest ID), nil if there is none
+func (h *baseHandler) findActivePullRequest(organization, projectId, repositoryId, sourceRefName, targetRefName, apiVersion, authHeader string) (*GitPullRequest, error) {
+	query := neturl.Values{}
+	query.Set("searchCriteria.sourceRefName", sourceRefName)
+	query.Set("searchCriteria.targetRefName", targetRefName)
+	query.Set("searchCriteria.status", StatusActive)
+	query.Set("api-version", apiVersion)
+	url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/git/repositories/%s/pullrequests?%s", organization, projectId, repositoryId, query.Encode())
+
+	body, err := h.DoAzureDevOpsRequest("GET", url, authHeader, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var pullRequests GitPullRequestList
+	if err := json.Unmarshal(body, &pullRequests); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal pull requests: %w", err)
+	}
+
+	var match *GitPullRequest
+	for i := range pullRequests.Value {
+		pullRequest := &pullRequests.Value[i]
+		if pullRequest.Status != StatusActive || pullRequest.SourceRefName != sourceRefName || pullRequest.TargetRefName != targetRefName {
+			continue
+		}
+		if match == nil || pullRequest.PullRequestID < match.PullRequestID {
+			match = pullRequest
+		}
+	}
+	return match, nil
+}
+
+// createPullRequest creates the pull request of the request, with its reviewers, work items and labels
+func (h *baseHandler) createPullRequest(organization, projectId, repositoryId string, request *PullRequestRequest, apiVersion, authHeader string) (*GitPullRequest, error) {
+	requestBody, err := json.Marshal(buildPullRequestCreate(request))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal pull request: %w", err)
+	}
+
+	url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/git/repositories/%s/pullrequests?api-version=%s", organization, projectId, repositoryId, apiVersion)
+	body, err := h.DoAzureDevOpsRequest("POST", url, authHeader, requestBody)
+	if err != nil {
+		return nil, err
+	}
+
+	var pullRequest GitPullRequest
+	if err := json.Unmarshal(body, &pullRequest); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal pull request: %w", err)
+	}
+	return &pullRequest, nil
+}
+
+// updatePullRequest updates the fields of the pull request that are set in the update
+func (h *baseHandler) updatePullRequest(organization, projectId, repositoryId string, id int, update *GitPullRequestUpdate, apiVersion, authHeader string) error {
+	requestBody, err := json.Marshal(update)
+	if err != nil {
+		return fmt.Errorf("failed to marshal pull request update: %w", err)
+	}
+
+	url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/git/repositories/%s/pullrequests/%d?api-version=%s", organization, projectId, repositoryId, id, apiVersion)
+	_, err = h.DoAzureDevOpsRequest("PATCH", url, authHeader, requestBody)
+	return err
+}
+
+// readPullRequestRequest reads and validates the request body
+func (h *baseHandler) readPullRequestRequest(w http.ResponseWriter, r *http.Request) (*PullRequestRequest, bool) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Failed to read request body")
+		return nil, false
+	}
+
+	var request PullRequestRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Invalid JSON in request body")
+		return nil, false
+	}
+	if err := validatePullRequestRequest(&request); err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid pull request: %v", err))
+		return nil, false
+	}
+	return &request, true
+}
+
+// validatePullRequestID checks that the pull request ID is a positive number
+func (h *baseHandler) validatePullRequestID(w http.ResponseWriter, id string) bool {
+	if value, err := strconv.Atoi(id); err != nil || value <= 0 {
+		h.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid pull request ID: %s", id))
+		return false
+	}
+	return true
+}
+
+// GET handler implementation
+// @Summary Get a pull request
+// @Description Get a pull request with its status, reviewers, work items, labels and completion options
+// @ID get-pull-request
+// @Param organization path string true "Organization name"
+// @Param projectId path string true "Project ID or name"
+// @Param repositoryId path string true "Repository ID or name"
+// @Param pullRequestId path int true "Pull request ID"
+// @Param api-version query string true "API version (e.g., 7.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Produce json
+// @Success 200 {object} PullRequestResponse "Pull request details"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{projectId}/git/repositories/{repositoryId}/pullrequests/{pullRequestId} [get]
+func (h *getHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	projectId := r.PathValue("projectId")
+	repositoryId := r.PathValue("repositoryId")
+	pullRequestId := r.PathValue("pullRequestId")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, projectId, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+	if !h.validatePullRequestID(w, pullRequestId) {
+		return
+	}
+
+	h.Log.Printf("Getting pull request %s of repository %s for project %s in organization %s", pullRequestId, repositoryId, projectId, organization)
+
+	pullRequest, err := h.getPullRequest(organization, projectId, repositoryId, pullRequestId, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "get pull request", fmt.Sprintf("Pull request %s not found in repository %s", pullRequestId, repositoryId))
+		return
+	}
+
+	h.WriteObjectResponse(w, http.StatusOK, PullRequestResponse(*mapPullRequestFromAzure(pullRequest)))
+	h.Log.Printf("Successfully retrieved pull request %s", pullRequestId)
+}
+
+// POST handler implementation
+// @Summary Create a pull request
+// @Description Create a pull request with reviewers, work items, labels, completion options and auto-complete. If an active pull request of the same source and target branches already exists, it is updated if needed and 200 is returned instead: reviewers, work items and labels are added if missing, but not removed.
+// @ID post-pull-request
+// @Param organization path string true "Organization name"
+// @Param projectId path string true "Project ID or name"
+// @Param repositoryId path string true "Repository ID or name"
+// @Param api-version query string true "API version (e.g., 7.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Param pullRequest body PullRequestRequest true "Pull request creation request body"
+// @Accept json
+// @Produce json
+// @Success 200 {object} PullRequestResponse "Existing pull request"
+// @Success 201 {object} PullRequestResponse "Created pull request"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{projectId}/git/repositories/{repositoryId}/pullrequests [post]
+func (h *postHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	projectId := r.PathValue("projectId")
+	repositoryId := r.PathValue("repositoryId")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, projectId, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	request, ok := h.readPullRequestRequest(w, r)
+	if !ok {
+		return
+	}
+
+	h.Log.Printf("Creating pull request from %s to %s in repository %s for project %s in organization %s", request.SourceRefName, request.TargetRefName, repositoryId, projectId, organization)
+
+	notFoundMessage := fmt.Sprintf("Repository %s not found in project %s", repositoryId, projectId)
+	existing, err := h.findActivePullRequest(organization, projectId, repositoryId, request.SourceRefName, request.TargetRefName, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "list pull requests", notFoundMessage)
+		return
+	}
+
+	statusCode := http.StatusOK
+	var pullRequest *GitPullRequest
+	if existing == nil {
+		pullRequest, err = h.createPullRequest(organization, projectId, repositoryId, request, apiVersion, authHeader)
+		if err != nil {
+			h.WriteAzureDevOpsError(w, err, "create pull request", notFoundMessage)
+			return
+		}
+		h.Log.Printf("Created pull request %d", pullRequest.PullRequestID)
+		statusCode = http.StatusCreated
+	} else {
+		h.Log.Printf("Active pull request %d of the same branches already exists", existing.PullRequestID)
+		// The list does not return the work items of the pull requests
+		pullRequest, err = h.getPullRequest(organization, projectId, repositoryId, strconv.Itoa(existing.PullRequestID), apiVersion, authHeader)
+		if err != nil {
+			h.WriteAzureDevOpsError(w, err, "get pull request", fmt.Sprintf("Pull request %d not found in repository %s", existing.PullRequestID, repositoryId))
+			return
+		}
+	}
+
+	changed, err := h.reconcilePullRequest(organization, projectId, repositoryId, pullRequest, request, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "update pull request", fmt.Sprintf("Pull request %d not found in repository %s", pullRequest.PullRequestID, repositoryId))
+		return
+	}
+	if changed {
+		pullRequest, err = h.getPullRequest(organization, projectId, repositoryId, strconv.Itoa(pullRequest.PullRequestID), apiVersion, authHeader)
+		if err != nil {
+			h.WriteAzureDevOpsError(w, err, "get pull request", fmt.Sprintf("Pull request %d not found in repository %s", pullRequest.PullRequestID, repositoryId))
+			return
+		}
+	}
+
+	h.WriteObjectResponse(w, statusCode, PullRequestResponse(*mapPullRequestFromAzure(pullRequest)))
+	h.Log.Printf("Pull request %d is up to date", pullRequest.PullRequestID)
+}
+
+// DELETE handler implementation
+// @Summary Abandon a pull request
+// @Description Abandon an active pull request. Pull requests that are already abandoned or completed are left as they are.
+// @ID delete-pull-request
+// @Param organization path string true "Organization name"
+// @Param projectId path string true "Project ID or name"
+// @Param repositoryId path string true "Repository ID or name"
+// @Param pullRequestId path int true "Pull request ID"
+// @Param api-version query string true "API version (e.g., 7.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Success 204 "No Content"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{projectId}/git/repositories/{repositoryId}/pullrequests/{pullRequestId} [delete]
+func (h *deleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	projectId := r.PathValue("projectId")
+	repositoryId := r.PathValue("repositoryId")
+	pullRequestId := r.PathValue("pullRequestId")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, projectId, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+	if !h.validatePullRequestID(w, pullRequestId) {
+		return
+	}
+
+	h.Log.Printf("Abandoning pull request %s of repository %s for project %s in organization %s", pullRequestId, repositoryId, projectId, organization)
+
+	notFoundMessage := fmt.Sprintf("Pull request %s not found in repository %s", pullRequestId, repositoryId)
+	pullRequest, err := h.getPullRequest(organization, projectId, repositoryId, pullRequestId, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "get pull request", notFoundMessage)
+		return
+	}
+
+	if pullRequest.Status != StatusActive {
+		h.Log.Printf("Pull request %s is already %s", pullRequestId, pullRequest.Status)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	if err := h.updatePullRequest(organization, projectId, repositoryId, pullRequest.PullRequestID, &GitPullRequestUpdate{Status: StatusAbandoned}, apiVersion, authHeader); err != nil {
+		h.WriteAzureDevOpsError(w, err, "abandon pull request", notFoundMessage)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	h.Log.Printf("Successfully abandoned pull request %s", pullRequestId)
+}
@@ -0,0 +1,472 @@
+package pullrequest
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers"
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/handlerstest"
+	"github.com/rs/zerolog"
+)
+
+// newTestBaseHandler creates a base handler for testing with a mock client
+func newTestBaseHandler(mockClient *handlerstest.MockHTTPClient) *baseHandler {
+	return newBaseHandler(handlerstest.NewHandlerOptions(mockClient))
+}
+
+// Test data constants
+const (
+	testOrg          = "testorg"
+	testProject      = "Platform"
+	testRepository   = "platform-api"
+	testAPIVersion   = "7.1"
+	testUsername     = "test"
+	testPassword     = "test"
+	testProjectID    = "0a1b2c3d-0000-0000-0000-000000000001"
+	testRepositoryID = "6f2d1e4a-0000-0000-0000-00000000000f"
+	reviewerID       = "5b2e8c1d-0000-0000-0000-0000000000a1"
+	teamReviewerID   = "5b2e8c1d-0000-0000-0000-0000000000b2"
+	botUserID        = "7e6d5c4b-0000-0000-0000-0000000000c3"
+)
+
+var (
+	listURL        = fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/git/repositories/%s/pullrequests?%s", testOrg, testProject, testRepository, url.Values{"searchCriteria.sourceRefName": {"refs/heads/bot/update"}, "searchCriteria.targetRefName": {"refs/heads/main"}, "searchCriteria.status": {"active"}, "api-version": {testAPIVersion}}.Encode())
+	createURL      = fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/git/repositories/%s/pullrequests?api-version=%s", testOrg, testProject, testRepository, testAPIVersion)
+	pullRequestURL = fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/git/repositories/%s/pullrequests/12?includeWorkItemRefs=true&api-version=%s", testOrg, testProject, testRepository, testAPIVersion)
+	updateURL      = fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/git/repositories/%s/pullrequests/12?api-version=%s", testOrg, testProject, testRepository, testAPIVersion)
+	reviewerURL    = fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/git/repositories/%s/pullrequests/12/reviewers/%s?api-version=%s", testOrg, testProject, testRepository, teamReviewerID, testAPIVersion)
+	labelsURL      = fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/git/repositories/%s/pullrequests/12/labels?api-version=%s", testOrg, testProject, testRepository, DefaultLabelsAPIVersion)
+	workItemURL    = fmt.Sprintf("https://dev.azure.com/%s/_apis/wit/workitems/43?api-version=%s", testOrg, testAPIVersion)
+	connectionURL  = fmt.Sprintf("https://dev.azure.com/%s/_apis/connectionData", testOrg)
+	connectionResp = `{"authenticatedUser":{"id":"` + botUserID + `","providerDisplayName":"Platform Bot"}}`
+	emptyListResp  = `{"count":0,"value":[]}`
+)
+
+// pullRequestResp returns a pull request of the bot/update branch with the given status and extra fields
+func pullRequestResp(status, extra string) string {
+	body := `{"pullRequestId":12,"status":"` + status + `","mergeStatus":"succeeded","sourceRefName":"refs/heads/bot/update","targetRefName":"refs/heads/main","title":"Update generated files","description":"Generated","isDraft":false,` +
+		`"repository":{"id":"` + testRepositoryID + `","name":"platform-api","project":{"id":"` + testProjectID + `","name":"Platform"}},"createdBy":{"id":"` + botUserID + `"}`
+	if extra != "" {
+		body += "," + extra
+	}
+	return body + "}"
+}
+
+// Fields of a pull request matching fullRequest
+const fullFields = `"reviewers":[{"id":"` + reviewerID + `","displayName":"Jane","vote":10,"isRequired":true}],"workItemRefs":[{"id":"42"},{"id":"43"}],"labels":[{"name":"generated","active":true}],` +
+	`"autoCompleteSetBy":{"id":"` + botUserID + `"},"completionOptions":{"mergeStrategy":"squash","deleteSourceBranch":true,"transitionWorkItems":false}`
+
+// fullRequest is a pull request request with every field set
+const fullRequest = `{"sourceRefName":"bot/update","targetRefName":"main","title":"Update generated files","description":"Generated",` +
+	`"reviewers":[{"id":"` + reviewerID + `","isRequired":true}],"workItemIds":[43,42],"labels":["generated"],"autoComplete":true,` +
+	`"completionOptions":{"mergeStrategy":"Squash","deleteSourceBranch":true}}`
+
+// pullRequestTestCase is a test case for the handlers of this package
+type pullRequestTestCase struct {
+	name                 string
+	pathValues           map[string]string
+	query                url.Values
+	noAuth               bool
+	body                 string
+	setupMock            func(*handlerstest.MockHTTPClient)
+	expectedStatus       int
+	expectedBody         string
+	expectedBodyContains string
+	expectedRequestCount int
+	verify               func(t *testing.T, mockClient *handlerstest.MockHTTPClient)
+}
+
+// pullRequestPathValues returns the path values of the pull request endpoints, with the pull request ID if it is set
+func pullRequestPathValues(pullRequestId string) map[string]string {
+	pathValues := map[string]string{"organization": testOrg, "projectId": testProject, "repositoryId": testRepository}
+	if pullRequestId != "" {
+		pathValues["pullRequestId"] = pullRequestId
+	}
+	return pathValues
+}
+
+// runPullRequestTests runs the given test cases against the handler built by newHandler
+func runPullRequestTests(t *testing.T, method string, newHandler func(*baseHandler) http.Handler, tests []pullRequestTestCase) {
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := handlerstest.NewMockHTTPClient()
+			if tt.setupMock != nil {
+				tt.setupMock(mockClient)
+			}
+			handler := newHandler(newTestBaseHandler(mockClient))
+
+			target := "/api/test"
+			if len(tt.query) > 0 {
+				target += "?" + tt.query.Encode()
+			}
+			var body io.Reader
+			if tt.body != "" {
+				body = strings.NewReader(tt.body)
+			}
+			req := httptest.NewRequest(method, target, body)
+			for key, value := range tt.pathValues {
+				req.SetPathValue(key, value)
+			}
+			if !tt.noAuth {
+				req.SetBasicAuth(testUsername, testPassword)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("Status code = %d, want %d. Body: %s", rr.Code, tt.expectedStatus, rr.Body.String())
+			}
+			if tt.expectedBody != "" && rr.Body.String() != tt.expectedBody {
+				t.Errorf("Body = %s, want %s", rr.Body.String(), tt.expectedBody)
+			}
+			if tt.expectedBodyContains != "" && !strings.Contains(rr.Body.String(), tt.expectedBodyContains) {
+				t.Errorf("Body = %s, want it to contain %s", rr.Body.String(), tt.expectedBodyContains)
+			}
+			if mockClient.RequestCount() != tt.expectedRequestCount {
+				t.Errorf("Request count = %d, want %d", mockClient.RequestCount(), tt.expectedRequestCount)
+			}
+			if tt.verify != nil {
+				tt.verify(t, mockClient)
+			}
+		})
+	}
+}
+
+// apiVersionQuery returns the query with the test API version
+func apiVersionQuery() url.Values {
+	return url.Values{"api-version": {testAPIVersion}}
+}
+
+// requestBody returns the body of the n-th request sent to Azure DevOps
+func requestBody(t *testing.T, mockClient *handlerstest.MockHTTPClient, n int) string {
+	t.Helper()
+	if len(mockClient.Requests) <= n || mockClient.Requests[n].Body == nil {
+		t.Fatalf("request %d has no body", n)
+	}
+	body, err := io.ReadAll(mockClient.Requests[n].Body)
+	if err != nil {
+		t.Fatalf("failed to read request %d body: %v", n, err)
+	}
+	return string(body)
+}
+
+// containsRequest reports whether a request was sent with the given method and URL
+func containsRequest(mockClient *handlerstest.MockHTTPClient, method, url string) bool {
+	for _, req := range mockClient.Requests {
+		if req.Method+" "+req.URL.String() == method+" "+url {
+			return true
+		}
+	}
+	return false
+}
+
+// requestIndex returns the index of the first request sent with the given method and URL, -1 if there is none
+func requestIndex(mockClient *handlerstest.MockHTTPClient, method, url string) int {
+	for i, req := range mockClient.Requests {
+		if req.Method+" "+req.URL.String() == method+" "+url {
+			return i
+		}
+	}
+	return -1
+}
+
+// Test constructor functions
+func TestPullRequestConstructors(t *testing.T) {
+	client := &http.Client{}
+	logger := zerolog.New(os.Stdout).With().Timestamp().Logger()
+	opts := handlers.HandlerOptions{
+		Client: client,
+		Log:    &logger,
+	}
+
+	if h := GetPullRequest(opts); h == nil {
+		t.Fatalf("GetPullRequest should return a non-nil handler")
+	}
+	if h := PostPullRequest(opts); h == nil {
+		t.Fatalf("PostPullRequest should return a non-nil handler")
+	}
+	if h := DeletePullRequest(opts); h == nil {
+		t.Fatalf("DeletePullRequest should return a non-nil handler")
+	}
+}
+
+// Test GET handler
+func TestGetHandler_ServeHTTP(t *testing.T) {
+	runPullRequestTests(t, "GET", func(b *baseHandler) http.Handler { return &getHandler{baseHandler: b} }, []pullRequestTestCase{
+		{
+			name:       "pull request with reviewers, work items and labels",
+			pathValues: pullRequestPathValues("12"),
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(pullRequestURL, http.StatusOK, pullRequestResp(StatusActive, fullFields+`,"lastMergeSourceCommit":{"commitId":"abc"}`))
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"pullRequestId":12,"status":"active","mergeStatus":"succeeded","sourceRefName":"refs/heads/bot/update","targetRefName":"refs/heads/main","title":"Update generated files","description":"Generated","isDraft":false,` +
+				`"reviewers":[{"id":"` + reviewerID + `","displayName":"Jane","isRequired":true,"vote":10}],"workItemIds":[42,43],"labels":["generated"],"autoComplete":true,` +
+				`"completionOptions":{"mergeStrategy":"squash","deleteSourceBranch":true,"transitionWorkItems":false}}`,
+			expectedRequestCount: 1,
+		},
+		{
+			name:       "completed pull request",
+			pathValues: pullRequestPathValues("12"),
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(pullRequestURL, http.StatusOK, pullRequestResp(StatusCompleted, `"autoCompleteSetBy":{"id":"`+NoIdentityID+`"},"lastMergeCommit":{"commitId":"abc"}`))
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `"reviewers":[],"workItemIds":[],"labels":[],"autoComplete":false,"lastMergeCommitId":"abc"}`,
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "pull request not found",
+			pathValues:           pullRequestPathValues("12"),
+			query:                apiVersionQuery(),
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Pull request 12 not found in repository platform-api",
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "invalid pull request ID",
+			pathValues:           pullRequestPathValues("latest"),
+			query:                apiVersionQuery(),
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid pull request ID: latest",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "missing basic auth",
+			pathValues:           pullRequestPathValues("12"),
+			query:                apiVersionQuery(),
+			noAuth:               true,
+			expectedStatus:       http.StatusUnauthorized,
+			expectedBody:         "Request rejected due to missing or invalid Basic authentication",
+			expectedRequestCount: 0,
+		},
+	})
+}
+
+// Test POST handler
+func TestPostHandler_ServeHTTP(t *testing.T) {
+	t.Setenv("GIT_PULL_REQUEST_LABELS_API_VERSION", "")
+
+	runPullRequestTests(t, "POST", func(b *baseHandler) http.Handler { return &postHandler{baseHandler: b} }, []pullRequestTestCase{
+		{
+			name:       "pull request created with auto-complete set by the authenticated user",
+			pathValues: pullRequestPathValues(""),
+			query:      apiVersionQuery(),
+			body:       fullRequest,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(listURL, http.StatusOK, emptyListResp)
+				m.SetMethodResponse("POST", createURL, http.StatusCreated, pullRequestResp(StatusActive, `"reviewers":[{"id":"`+reviewerID+`","vote":0,"isRequired":true}],"workItemRefs":[{"id":"42"},{"id":"43"}],"labels":[{"name":"generated","active":true}]`))
+				m.SetResponse(connectionURL, http.StatusOK, connectionResp)
+				m.SetMethodResponse("PATCH", updateURL, http.StatusOK, pullRequestResp(StatusActive, ""))
+				m.SetResponse(pullRequestURL, http.StatusOK, pullRequestResp(StatusActive, fullFields))
+			},
+			expectedStatus:       http.StatusCreated,
+			expectedBodyContains: `"workItemIds":[42,43],"labels":["generated"],"autoComplete":true,"completionOptions":{"mergeStrategy":"squash","deleteSourceBranch":true,"transitionWorkItems":false}}`,
+			expectedRequestCount: 5,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				wantCreate := `{"sourceRefName":"refs/heads/bot/update","targetRefName":"refs/heads/main","title":"Update generated files","description":"Generated",` +
+					`"reviewers":[{"id":"` + reviewerID + `","isRequired":true,"vote":0}],"workItemRefs":[{"id":"42"},{"id":"43"}],"labels":[{"name":"generated"}]}`
+				if got := requestBody(t, m, 1); got != wantCreate {
+					t.Errorf("Create body = %s, want %s", got, wantCreate)
+				}
+				wantUpdate := `{"autoCompleteSetBy":{"id":"` + botUserID + `"},"completionOptions":{"mergeStrategy":"squash","deleteSourceBranch":true,"transitionWorkItems":false}}`
+				if got := requestBody(t, m, 3); got != wantUpdate {
+					t.Errorf("Update body = %s, want %s", got, wantUpdate)
+				}
+			},
+		},
+		{
+			name:       "pull request created without update",
+			pathValues: pullRequestPathValues(""),
+			query:      apiVersionQuery(),
+			body:       `{"sourceRefName":"refs/heads/bot/update","targetRefName":"refs/heads/main","title":"Update generated files","isDraft":true}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(listURL, http.StatusOK, emptyListResp)
+				m.SetMethodResponse("POST", createURL, http.StatusCreated, strings.Replace(pullRequestResp(StatusActive, ""), `"isDraft":false`, `"isDraft":true`, 1))
+			},
+			expectedStatus:       http.StatusCreated,
+			expectedBodyContains: `"isDraft":true,"reviewers":[],"workItemIds":[],"labels":[],"autoComplete":false}`,
+			expectedRequestCount: 2,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				if got := requestBody(t, m, 1); !strings.Contains(got, `"isDraft":true`) {
+					t.Errorf("Create body = %s, want it to contain isDraft", got)
+				}
+			},
+		},
+		{
+			name:       "active pull request already up to date",
+			pathValues: pullRequestPathValues(""),
+			query:      apiVersionQuery(),
+			body:       fullRequest,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(listURL, http.StatusOK, `{"count":1,"value":[`+pullRequestResp(StatusActive, "")+`]}`)
+				m.SetResponse(pullRequestURL, http.StatusOK, pullRequestResp(StatusActive, fullFields))
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `"pullRequestId":12,"status":"active"`,
+			expectedRequestCount: 2,
+		},
+		{
+			name:       "active pull request updated with the missing reviewers, work items and labels",
+			pathValues: pullRequestPathValues(""),
+			query:      apiVersionQuery(),
+			body: `{"sourceRefName":"bot/update","targetRefName":"main","title":"Update generated files (2)",` +
+				`"reviewers":[{"id":"` + strings.ToUpper(reviewerID) + `"},{"id":"` + teamReviewerID + `","isRequired":true}],"workItemIds":[42,43],"labels":["Generated","bot"]}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(listURL, http.StatusOK, `{"count":1,"value":[`+pullRequestResp(StatusActive, "")+`]}`)
+				m.SetResponseSequence(pullRequestURL,
+					handlerstest.MockResponse{StatusCode: http.StatusOK, Body: pullRequestResp(StatusActive, `"reviewers":[{"id":"`+reviewerID+`","vote":10,"isRequired":true}],"workItemRefs":[{"id":"42"}],"labels":[{"name":"generated","active":true},{"name":"bot","active":false}]`)},
+					handlerstest.MockResponse{StatusCode: http.StatusOK, Body: pullRequestResp(StatusActive, fullFields)})
+				m.SetMethodResponse("PATCH", updateURL, http.StatusOK, pullRequestResp(StatusActive, ""))
+				m.SetMethodResponse("PUT", reviewerURL, http.StatusOK, `{"id":"`+teamReviewerID+`","vote":0,"isRequired":true}`)
+				m.SetMethodResponse("PATCH", workItemURL, http.StatusOK, `{"id":43}`)
+				m.SetMethodResponse("POST", labelsURL, http.StatusCreated, `{"name":"bot","active":true}`)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `"pullRequestId":12`,
+			expectedRequestCount: 7,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				if got := requestBody(t, m, requestIndex(m, "PATCH", updateURL)); got != `{"title":"Update generated files (2)"}` {
+					t.Errorf("Update body = %s, want only the title", got)
+				}
+				if got := requestBody(t, m, requestIndex(m, "PUT", reviewerURL)); got != `{"id":"`+teamReviewerID+`","isRequired":true,"vote":0}` {
+					t.Errorf("Reviewer body = %s", got)
+				}
+				if got := requestBody(t, m, requestIndex(m, "POST", labelsURL)); got != `{"name":"bot"}` {
+					t.Errorf("Label body = %s, want only the inactive label", got)
+				}
+				index := requestIndex(m, "PATCH", workItemURL)
+				wantLink := `[{"op":"add","path":"/relations/-","value":{"rel":"ArtifactLink","url":"vstfs:///Git/PullRequestId/` + testProjectID + `%2F` + testRepositoryID + `%2F12","attributes":{"name":"Pull Request"}}}]`
+				if got := requestBody(t, m, index); got != wantLink {
+					t.Errorf("Work item body = %s, want %s", got, wantLink)
+				}
+				if got := m.Requests[index].Header.Get("Content-Type"); got != "application/json-patch+json" {
+					t.Errorf("Work item Content-Type = %s, want application/json-patch+json", got)
+				}
+			},
+		},
+		{
+			name:       "auto-complete cancelled",
+			pathValues: pullRequestPathValues(""),
+			query:      apiVersionQuery(),
+			body:       `{"sourceRefName":"bot/update","targetRefName":"main","title":"Update generated files","autoComplete":false}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(listURL, http.StatusOK, `{"count":1,"value":[`+pullRequestResp(StatusActive, "")+`]}`)
+				m.SetResponseSequence(pullRequestURL,
+					handlerstest.MockResponse{StatusCode: http.StatusOK, Body: pullRequestResp(StatusActive, fullFields)},
+					handlerstest.MockResponse{StatusCode: http.StatusOK, Body: pullRequestResp(StatusActive, "")})
+				m.SetMethodResponse("PATCH", updateURL, http.StatusOK, pullRequestResp(StatusActive, ""))
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `"autoComplete":false`,
+			expectedRequestCount: 4,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				if got := requestBody(t, m, 2); got != `{"autoCompleteSetBy":{"id":"`+NoIdentityID+`"}}` {
+					t.Errorf("Update body = %s, want auto-complete cancelled", got)
+				}
+			},
+		},
+		{
+			name:                 "repository not found",
+			pathValues:           pullRequestPathValues(""),
+			query:                apiVersionQuery(),
+			body:                 fullRequest,
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Repository platform-api not found in project Platform",
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "same source and target branches",
+			pathValues:           pullRequestPathValues(""),
+			query:                apiVersionQuery(),
+			body:                 `{"sourceRefName":"main","targetRefName":"refs/heads/main","title":"x"}`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid pull request: sourceRefName and targetRefName must be different",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "unknown merge strategy",
+			pathValues:           pullRequestPathValues(""),
+			query:                apiVersionQuery(),
+			body:                 `{"sourceRefName":"bot/update","targetRefName":"main","title":"x","completionOptions":{"mergeStrategy":"fastForward"}}`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid pull request: mergeStrategy must be one of noFastForward, squash, rebase, rebaseMerge, got 'fastForward'",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "invalid JSON",
+			pathValues:           pullRequestPathValues(""),
+			query:                apiVersionQuery(),
+			body:                 `{"title":`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid JSON in request body",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "missing basic auth",
+			pathValues:           pullRequestPathValues(""),
+			query:                apiVersionQuery(),
+			noAuth:               true,
+			expectedStatus:       http.StatusUnauthorized,
+			expectedBody:         "Request rejected due to missing or invalid Basic authentication",
+			expectedRequestCount: 0,
+		},
+	})
+}
+
+// Test DELETE handler
+func TestDeleteHandler_ServeHTTP(t *testing.T) {
+	runPullRequestTests(t, "DELETE", func(b *baseHandler) http.Handler { return &deleteHandler{baseHandler: b} }, []pullRequestTestCase{
+		{
+			name:       "active pull request abandoned",
+			pathValues: pullRequestPathValues("12"),
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(pullRequestURL, http.StatusOK, pullRequestResp(StatusActive, ""))
+				m.SetMethodResponse("PATCH", updateURL, http.StatusOK, pullRequestResp(StatusAbandoned, ""))
+			},
+			expectedStatus:       http.StatusNoContent,
+			expectedRequestCount: 2,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				if got := requestBody(t, m, 1); got != `{"status":"abandoned"}` {
+					t.Errorf("Update body = %s, want the abandoned status", got)
+				}
+			},
+		},
+		{
+			name:       "completed pull request left as it is",
+			pathValues: pullRequestPathValues("12"),
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(pullRequestURL, http.StatusOK, pullRequestResp(StatusCompleted, ""))
+			},
+			expectedStatus:       http.StatusNoContent,
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "pull request not found",
+			pathValues:           pullRequestPathValues("12"),
+			query:                apiVersionQuery(),
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Pull request 12 not found in repository platform-api",
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "invalid pull request ID",
+			pathValues:           pullRequestPathValues("0"),
+			query:                apiVersionQuery(),
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid pull request ID: 0",
+			expectedRequestCount: 0,
+		},
+	})
+}
@@ -0,0 +1,119 @@
+package pullrequest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// getAuthenticatedUserID returns the identity ID of the user of the Authorization header
+func (h *baseHandler) getAuthenticatedUserID(organization, authHeader string) (string, error) {
+	url := fmt.Sprintf("https://dev.azure.com/%s/_apis/connectionData", organization)
+
+	body, err := h.DoAzureDevOpsRequest("GET", url, authHeader, nil)
+	if err != nil {
+		return "", err
+	}
+
+	var connectionData ConnectionData
+	if err := json.Unmarshal(body, &connectionData); err != nil {
+		return "", fmt.Errorf("failed to unmarshal connection data: %w", err)
+	}
+	if connectionData.AuthenticatedUser.ID == "" {
+		return "", fmt.Errorf("authenticated user not returned by connection data")
+	}
+	return connectionData.AuthenticatedUser.ID, nil
+}
+
+// addReviewer adds the reviewer to the pull request
+func (h *baseHandler) addReviewer(organization, projectId, repositoryId string, id int, reviewer ReviewerRequest, apiVersion, authHeader string) error {
+	requestBody, err := json.Marshal(IdentityRefWithVote{ID: reviewer.ID, IsRequired: reviewer.IsRequired})
+	if err != nil {
+		return fmt.Errorf("failed to marshal reviewer: %w", err)
+	}
+
+	url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/git/repositories/%s/pullrequests/%d/reviewers/%s?api-version=%s", organization, projectId, repositoryId, id, reviewer.ID, apiVersion)
+	_, err = h.DoAzureDevOpsRequest("PUT", url, authHeader, requestBody)
+	return err
+}
+
+// addLabel adds the label to the pull request, creating it in the project if needed
+func (h *baseHandler) addLabel(organization, projectId, repositoryId string, id int, label, authHeader string) error {
+	requestBody, err := json.Marshal(WebApiTagDefinition{Name: label})
+	if err != nil {
+		return fmt.Errorf("failed to marshal label: %w", err)
+	}
+
+	url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/git/repositories/%s/pullrequests/%d/labels?api-version=%s", organization, projectId, repositoryId, id, labelsAPIVersion())
+	_, err = h.DoAzureDevOpsRequest("POST", url, authHeader, requestBody)
+	return err
+}
+
+// linkWorkItem links the work item to the pull request through an artifact link of the work item
+// Pull requests have no API to link work items once they are created
+func (h *baseHandler) linkWorkItem(organization string, pullRequest *GitPullRequest, workItemID int, apiVersion, authHeader string) error {
+	requestBody, err := json.Marshal([]JSONPatchOperation{{
+		Op:   "add",
+		Path: "/relations/-",
+		Value: WorkItemRelation{
+			Rel:        "ArtifactLink",
+			URL:        pullRequestArtifactURL(pullRequest),
+			Attributes: map[string]string{"name": "Pull Request"},
+		},
+	}})
+	if err != nil {
+		return fmt.Errorf("failed to marshal work item link: %w", err)
+	}
+
+	url := fmt.Sprintf("https://dev.azure.com/%s/_apis/wit/workitems/%d?api-version=%s", organization, workItemID, apiVersion)
+	// The work item tracking API expects a JSON patch document
+	_, _, err = h.DoAzureDevOpsRequestWithHeader("PATCH", url, authHeader, requestBody, http.Header{"Content-Type": {"application/json-patch+json"}})
+	return err
+}
+
+// reconcilePullRequest updates the pull request to match the request and reports whether anything was changed
+// Fields and completion options are updated, reviewers, work items and labels are added if missing
+func (h *baseHandler) reconcilePullRequest(organization, projectId, repositoryId string, pullRequest *GitPullRequest, request *PullRequestRequest, apiVersion, authHeader string) (bool, error) {
+	changed := false
+
+	userID := ""
+	if needsAutoCompleteUser(pullRequest, request) {
+		var err error
+		if userID, err = h.getAuthenticatedUserID(organization, authHeader); err != nil {
+			return false, err
+		}
+	}
+	if update := buildPullRequestUpdate(pullRequest, request, userID); update != nil {
+		if err := h.updatePullRequest(organization, projectId, repositoryId, pullRequest.PullRequestID, update, apiVersion, authHeader); err != nil {
+			return false, err
+		}
+		h.Log.Printf("Updated pull request %d", pullRequest.PullRequestID)
+		changed = true
+	}
+
+	for _, reviewer := range missingReviewers(pullRequest, request) {
+		if err := h.addReviewer(organization, projectId, repositoryId, pullRequest.PullRequestID, reviewer, apiVersion, authHeader); err != nil {
+			return false, err
+		}
+		h.Log.Printf("Added reviewer %s to pull request %d", reviewer.ID, pullRequest.PullRequestID)
+		changed = true
+	}
+
+	for _, workItemID := range missingWorkItems(pullRequest, request) {
+		if err := h.linkWorkItem(organization, pullRequest, workItemID, apiVersion, authHeader); err != nil {
+			return false, err
+		}
+		h.Log.Printf("Linked work item %d to pull request %d", workItemID, pullRequest.PullRequestID)
+		changed = true
+	}
+
+	for _, label := range missingLabels(pullRequest, request) {
+		if err := h.addLabel(organization, projectId, repositoryId, pullRequest.PullRequestID, label, authHeader); err != nil {
+			return false, err
+		}
+		h.Log.Printf("Added label %s to pull request %d", label, pullRequest.PullRequestID)
+		changed = true
+	}
+
+	return changed, nil
+}
@@ -0,0 +1,313 @@
+package pullrequest
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var guidRegex = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// normalizeRefName returns the full name of the branch, e.g., main becomes refs/heads/main
+func normalizeRefName(refName string) string {
+	refName = strings.TrimSpace(refName)
+	if refName == "" || strings.HasPrefix(refName, "refs/") {
+		return refName
+	}
+	return "refs/heads/" + refName
+}
+
+// labelsAPIVersion returns the API version of the Pull Request Labels API
+func labelsAPIVersion() string {
+	if apiVersion := os.Getenv("GIT_PULL_REQUEST_LABELS_API_VERSION"); apiVersion != "" {
+		return apiVersion
+	}
+	return DefaultLabelsAPIVersion
+}
+
+// validatePullRequestRequest checks and normalizes the fields of a pull request request
+func validatePullRequestRequest(request *PullRequestRequest) error {
+	request.SourceRefName = normalizeRefName(request.SourceRefName)
+	request.TargetRefName = normalizeRefName(request.TargetRefName)
+	request.Title = strings.TrimSpace(request.Title)
+	switch {
+	case request.SourceRefName == "":
+		return fmt.Errorf("sourceRefName is required")
+	case request.TargetRefName == "":
+		return fmt.Errorf("targetRefName is required")
+	case request.SourceRefName == request.TargetRefName:
+		return fmt.Errorf("sourceRefName and targetRefName must be different")
+	case request.Title == "":
+		return fmt.Errorf("title is required")
+	}
+
+	reviewers := []ReviewerRequest{}
+	seen := map[string]bool{}
+	for _, reviewer := range request.Reviewers {
+		reviewer.ID = strings.ToLower(strings.TrimSpace(reviewer.ID))
+		if !guidRegex.MatchString(reviewer.ID) {
+			return fmt.Errorf("reviewer id must be a GUID, got '%s'", reviewer.ID)
+		}
+		if seen[reviewer.ID] {
+			return fmt.Errorf("reviewer %s listed more than once", reviewer.ID)
+		}
+		seen[reviewer.ID] = true
+		reviewers = append(reviewers, reviewer)
+	}
+	request.Reviewers = reviewers
+
+	workItemIDs := []int{}
+	for _, id := range request.WorkItemIDs {
+		if id <= 0 {
+			return fmt.Errorf("work item id must be a positive number, got %d", id)
+		}
+		if !containsInt(workItemIDs, id) {
+			workItemIDs = append(workItemIDs, id)
+		}
+	}
+	sort.Ints(workItemIDs)
+	request.WorkItemIDs = workItemIDs
+
+	labels := []string{}
+	for _, label := range request.Labels {
+		label = strings.TrimSpace(label)
+		if label == "" {
+			return fmt.Errorf("label cannot be empty")
+		}
+		if !containsFold(labels, label) {
+			labels = append(labels, label)
+		}
+	}
+	request.Labels = labels
+
+	if options := request.CompletionOptions; options != nil {
+		strategy := ""
+		for _, mergeStrategy := range MergeStrategies {
+			if strings.EqualFold(mergeStrategy, strings.TrimSpace(options.MergeStrategy)) {
+				strategy = mergeStrategy
+			}
+		}
+		if strategy == "" && options.MergeStrategy != "" {
+			return fmt.Errorf("mergeStrategy must be one of %s, got '%s'", strings.Join(MergeStrategies, ", "), options.MergeStrategy)
+		}
+		options.MergeStrategy = strategy
+	}
+	return nil
+}
+
+// containsInt reports whether the list contains the value
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// containsFold reports whether the list contains the value (case insensitive)
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// buildPullRequestCreate returns the pull request to send to Azure DevOps for the request
+func buildPullRequestCreate(request *PullRequestRequest) *GitPullRequestCreate {
+	create := &GitPullRequestCreate{
+		SourceRefName: request.SourceRefName,
+		TargetRefName: request.TargetRefName,
+		Title:         request.Title,
+		IsDraft:       request.IsDraft != nil && *request.IsDraft,
+	}
+	if request.Description != nil {
+		create.Description = *request.Description
+	}
+	for _, reviewer := range request.Reviewers {
+		create.Reviewers = append(create.Reviewers, IdentityRefWithVote{ID: reviewer.ID, IsRequired: reviewer.IsRequired})
+	}
+	for _, id := range request.WorkItemIDs {
+		create.WorkItemRefs = append(create.WorkItemRefs, ResourceRef{ID: strconv.Itoa(id)})
+	}
+	for _, label := range request.Labels {
+		create.Labels = append(create.Labels, WebApiTagDefinition{Name: label})
+	}
+	return create
+}
+
+// isAutoCompleteSet reports whether auto-complete is set on the pull request
+func isAutoCompleteSet(pullRequest *GitPullRequest) bool {
+	return pullRequest.AutoCompleteSetBy != nil && pullRequest.AutoCompleteSetBy.ID != "" && pullRequest.AutoCompleteSetBy.ID != NoIdentityID
+}
+
+// needsAutoCompleteUser reports whether auto-complete must be set on the pull request, by the authenticated user
+func needsAutoCompleteUser(pullRequest *GitPullRequest, request *PullRequestRequest) bool {
+	return request.AutoComplete != nil && *request.AutoComplete && !isAutoCompleteSet(pullRequest)
+}
+
+// buildPullRequestUpdate returns the update of the fields of the pull request that differ from the request, nil if there is none
+// userID is the authenticated user, who sets auto-complete when needsAutoCompleteUser reports it
+func buildPullRequestUpdate(pullRequest *GitPullRequest, request *PullRequestRequest, userID string) *GitPullRequestUpdate {
+	update := &GitPullRequestUpdate{}
+	changed := false
+
+	if pullRequest.Title != request.Title {
+		update.Title = request.Title
+		changed = true
+	}
+	if request.Description != nil && pullRequest.Description != *request.Description {
+		update.Description = request.Description
+		changed = true
+	}
+	if request.IsDraft != nil && pullRequest.IsDraft != *request.IsDraft {
+		update.IsDraft = request.IsDraft
+		changed = true
+	}
+	if request.CompletionOptions != nil && !completionOptionsMatch(pullRequest.CompletionOptions, request.CompletionOptions) {
+		options := GitPullRequestCompletionOptions(*request.CompletionOptions)
+		update.CompletionOptions = &options
+		changed = true
+	}
+	if needsAutoCompleteUser(pullRequest, request) {
+		update.AutoCompleteSetBy = &IdentityRef{ID: userID}
+		changed = true
+	} else if request.AutoComplete != nil && !*request.AutoComplete && isAutoCompleteSet(pullRequest) {
+		update.AutoCompleteSetBy = &IdentityRef{ID: NoIdentityID}
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+	return update
+}
+
+// completionOptionsMatch reports whether the completion options of the pull request match the desired ones
+// A merge strategy that is not set in the request is not compared
+func completionOptionsMatch(current *GitPullRequestCompletionOptions, desired *CompletionOptions) bool {
+	if current == nil {
+		current = &GitPullRequestCompletionOptions{}
+	}
+	if desired.MergeStrategy != "" && !strings.EqualFold(current.MergeStrategy, desired.MergeStrategy) {
+		return false
+	}
+	return current.DeleteSourceBranch == desired.DeleteSourceBranch &&
+		current.TransitionWorkItems == desired.TransitionWorkItems &&
+		current.MergeCommitMessage == desired.MergeCommitMessage
+}
+
+// missingReviewers returns the reviewers of the request that are not reviewers of the pull request
+// Existing reviewers are left as they are, so that their votes are kept
+func missingReviewers(pullRequest *GitPullRequest, request *PullRequestRequest) []ReviewerRequest {
+	current := map[string]bool{}
+	for _, reviewer := range pullRequest.Reviewers {
+		current[strings.ToLower(reviewer.ID)] = true
+	}
+
+	missing := []ReviewerRequest{}
+	for _, reviewer := range request.Reviewers {
+		if !current[reviewer.ID] {
+			missing = append(missing, reviewer)
+		}
+	}
+	return missing
+}
+
+// missingWorkItems returns the work items of the request that are not linked to the pull request
+func missingWorkItems(pullRequest *GitPullRequest, request *PullRequestRequest) []int {
+	missing := []int{}
+	for _, id := range request.WorkItemIDs {
+		linked := false
+		for _, ref := range pullRequest.WorkItemRefs {
+			if ref.ID == strconv.Itoa(id) {
+				linked = true
+				break
+			}
+		}
+		if !linked {
+			missing = append(missing, id)
+		}
+	}
+	return missing
+}
+
+// missingLabels returns the labels of the request that are not active labels of the pull request
+func missingLabels(pullRequest *GitPullRequest, request *PullRequestRequest) []string {
+	current := []string{}
+	for _, label := range pullRequest.Labels {
+		if label.Active == nil || *label.Active {
+			current = append(current, label.Name)
+		}
+	}
+
+	missing := []string{}
+	for _, label := range request.Labels {
+		if !containsFold(current, label) {
+			missing = append(missing, label)
+		}
+	}
+	return missing
+}
+
+// pullRequestArtifactURL returns the URL a work item links the pull request with
+func pullRequestArtifactURL(pullRequest *GitPullRequest) string {
+	return fmt.Sprintf("vstfs:///Git/PullRequestId/%s%%2F%s%%2F%d", pullRequest.Repository.Project.ID, pullRequest.Repository.ID, pullRequest.PullRequestID)
+}
+
+// mapPullRequestFromAzure converts an Azure DevOps pull request to the pull request returned by the plugin
+func mapPullRequestFromAzure(pullRequest *GitPullRequest) *PullRequest {
+	result := &PullRequest{
+		PullRequestID: pullRequest.PullRequestID,
+		Status:        pullRequest.Status,
+		MergeStatus:   pullRequest.MergeStatus,
+		SourceRefName: pullRequest.SourceRefName,
+		TargetRefName: pullRequest.TargetRefName,
+		Title:         pullRequest.Title,
+		Description:   pullRequest.Description,
+		IsDraft:       pullRequest.IsDraft,
+		Reviewers:     []Reviewer{},
+		WorkItemIDs:   []int{},
+		Labels:        []string{},
+		AutoComplete:  isAutoCompleteSet(pullRequest),
+	}
+
+	for _, reviewer := range pullRequest.Reviewers {
+		result.Reviewers = append(result.Reviewers, Reviewer{
+			ID:          strings.ToLower(reviewer.ID),
+			DisplayName: reviewer.DisplayName,
+			UniqueName:  reviewer.UniqueName,
+			IsRequired:  reviewer.IsRequired,
+			Vote:        reviewer.Vote,
+		})
+	}
+	sort.Slice(result.Reviewers, func(i, j int) bool { return result.Reviewers[i].ID < result.Reviewers[j].ID })
+
+	for _, ref := range pullRequest.WorkItemRefs {
+		if id, err := strconv.Atoi(ref.ID); err == nil {
+			result.WorkItemIDs = append(result.WorkItemIDs, id)
+		}
+	}
+	sort.Ints(result.WorkItemIDs)
+
+	for _, label := range pullRequest.Labels {
+		if label.Active == nil || *label.Active {
+			result.Labels = append(result.Labels, label.Name)
+		}
+	}
+	sort.Strings(result.Labels)
+
+	if options := pullRequest.CompletionOptions; options != nil {
+		completionOptions := CompletionOptions(*options)
+		result.CompletionOptions = &completionOptions
+	}
+	if pullRequest.LastMergeCommit != nil {
+		result.LastMergeCommitID = pullRequest.LastMergeCommit.CommitID
+	}
+	return result
+}
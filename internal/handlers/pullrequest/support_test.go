@@ -0,0 +1,92 @@
+package pullrequest
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestValidatePullRequestRequest(t *testing.T) {
+	tests := []struct {
+		name          string
+		request       PullRequestRequest
+		expectedError string
+	}{
+		{name: "valid", request: PullRequestRequest{SourceRefName: "bot/update", TargetRefName: "main", Title: "x", Reviewers: []ReviewerRequest{{ID: reviewerID}}, WorkItemIDs: []int{1, 1}, Labels: []string{"a", "A"}}},
+		{name: "missing source", request: PullRequestRequest{TargetRefName: "main", Title: "x"}, expectedError: "sourceRefName is required"},
+		{name: "missing target", request: PullRequestRequest{SourceRefName: "bot/update", Title: "x"}, expectedError: "targetRefName is required"},
+		{name: "missing title", request: PullRequestRequest{SourceRefName: "bot/update", TargetRefName: "main", Title: " "}, expectedError: "title is required"},
+		{name: "reviewer that is not a GUID", request: PullRequestRequest{SourceRefName: "a", TargetRefName: "b", Title: "x", Reviewers: []ReviewerRequest{{ID: "jane@example.com"}}}, expectedError: "reviewer id must be a GUID, got 'jane@example.com'"},
+		{name: "reviewer listed twice", request: PullRequestRequest{SourceRefName: "a", TargetRefName: "b", Title: "x", Reviewers: []ReviewerRequest{{ID: reviewerID}, {ID: reviewerID, IsRequired: true}}}, expectedError: "reviewer " + reviewerID + " listed more than once"},
+		{name: "invalid work item", request: PullRequestRequest{SourceRefName: "a", TargetRefName: "b", Title: "x", WorkItemIDs: []int{0}}, expectedError: "work item id must be a positive number, got 0"},
+		{name: "empty label", request: PullRequestRequest{SourceRefName: "a", TargetRefName: "b", Title: "x", Labels: []string{" "}}, expectedError: "label cannot be empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePullRequestRequest(&tt.request)
+			if tt.expectedError == "" && err != nil {
+				t.Fatalf("validatePullRequestRequest() error = %v", err)
+			}
+			if tt.expectedError != "" && (err == nil || err.Error() != tt.expectedError) {
+				t.Fatalf("validatePullRequestRequest() error = %v, want %s", err, tt.expectedError)
+			}
+		})
+	}
+}
+
+func TestValidatePullRequestRequest_Normalization(t *testing.T) {
+	request := PullRequestRequest{
+		SourceRefName:     "bot/update",
+		TargetRefName:     "refs/heads/main",
+		Title:             " Update ",
+		WorkItemIDs:       []int{43, 42, 43},
+		Labels:            []string{"generated", "Generated", "bot"},
+		CompletionOptions: &CompletionOptions{MergeStrategy: "REBASEMERGE"},
+	}
+	if err := validatePullRequestRequest(&request); err != nil {
+		t.Fatalf("validatePullRequestRequest() error = %v", err)
+	}
+
+	got, _ := json.Marshal(request)
+	want := `{"sourceRefName":"refs/heads/bot/update","targetRefName":"refs/heads/main","title":"Update","workItemIds":[42,43],"labels":["generated","bot"],` +
+		`"completionOptions":{"mergeStrategy":"rebaseMerge","deleteSourceBranch":false,"transitionWorkItems":false}}`
+	if string(got) != want {
+		t.Errorf("Normalized request = %s, want %s", got, want)
+	}
+}
+
+func TestBuildPullRequestUpdate(t *testing.T) {
+	enabled, disabled := true, false
+	description := "Generated"
+	autoCompleted := &GitPullRequest{Title: "x", AutoCompleteSetBy: &IdentityRef{ID: botUserID}}
+
+	tests := []struct {
+		name        string
+		pullRequest *GitPullRequest
+		request     PullRequestRequest
+		expected    string
+	}{
+		{name: "nothing to update", pullRequest: autoCompleted, request: PullRequestRequest{Title: "x", AutoComplete: &enabled}, expected: "null"},
+		{name: "description and draft", pullRequest: &GitPullRequest{Title: "x", IsDraft: true}, request: PullRequestRequest{Title: "x", Description: &description, IsDraft: &disabled}, expected: `{"description":"Generated","isDraft":false}`},
+		{name: "auto-complete set", pullRequest: &GitPullRequest{Title: "x"}, request: PullRequestRequest{Title: "x", AutoComplete: &enabled}, expected: `{"autoCompleteSetBy":{"id":"` + botUserID + `"}}`},
+		{name: "auto-complete cancelled", pullRequest: autoCompleted, request: PullRequestRequest{Title: "x", AutoComplete: &disabled}, expected: `{"autoCompleteSetBy":{"id":"` + NoIdentityID + `"}}`},
+		{name: "completion options without merge strategy", pullRequest: &GitPullRequest{Title: "x", CompletionOptions: &GitPullRequestCompletionOptions{MergeStrategy: "squash"}}, request: PullRequestRequest{Title: "x", CompletionOptions: &CompletionOptions{}}, expected: "null"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _ := json.Marshal(buildPullRequestUpdate(tt.pullRequest, &tt.request, botUserID))
+			if string(got) != tt.expected {
+				t.Errorf("buildPullRequestUpdate() = %s, want %s", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPullRequestArtifactURL(t *testing.T) {
+	pullRequest := &GitPullRequest{PullRequestID: 12, Repository: GitRepositoryRef{ID: testRepositoryID, Project: TeamProjectReference{ID: testProjectID}}}
+	want := "vstfs:///Git/PullRequestId/" + testProjectID + "%2F" + testRepositoryID + "%2F12"
+	if got := pullRequestArtifactURL(pullRequest); got != want {
+		t.Errorf("pullRequestArtifactURL() = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,213 @@
+package pullrequest
+
+// PullRequest represents the pull request returned by the plugin
+// It is built from the GitPullRequest returned by:
+// GET https://dev.azure.com/{organization}/{project}/_apis/git/repositories/{repositoryId}/pullrequests/{pullRequestId}?includeWorkItemRefs=true
+type PullRequest struct {
+	PullRequestID     int                `json:"pullRequestId"`
+	Status            string             `json:"status"`      // active, abandoned or completed
+	MergeStatus       string             `json:"mergeStatus"` // e.g., queued, succeeded or conflicts
+	SourceRefName     string             `json:"sourceRefName"`
+	TargetRefName     string             `json:"targetRefName"`
+	Title             string             `json:"title"`
+	Description       string             `json:"description"`
+	IsDraft           bool               `json:"isDraft"`
+	Reviewers         []Reviewer         `json:"reviewers"`   // Sorted by ID
+	WorkItemIDs       []int              `json:"workItemIds"` // Sorted
+	Labels            []string           `json:"labels"`      // Sorted
+	AutoComplete      bool               `json:"autoComplete"`
+	CompletionOptions *CompletionOptions `json:"completionOptions,omitempty"`
+	LastMergeCommitID string             `json:"lastMergeCommitId,omitempty"` // Merge commit, set once the pull request is completed
+}
+
+// PullRequestResponse represents the response of the pull request endpoints
+type PullRequestResponse PullRequest
+
+// Reviewer represents a reviewer of a pull request
+type Reviewer struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName,omitempty"`
+	UniqueName  string `json:"uniqueName,omitempty"`
+	IsRequired  bool   `json:"isRequired"`
+	Vote        int    `json:"vote"` // 10 approved, 5 approved with suggestions, 0 no vote, -5 waiting for author, -10 rejected
+}
+
+// CompletionOptions represents how a pull request is merged when it is completed
+type CompletionOptions struct {
+	MergeStrategy       string `json:"mergeStrategy,omitempty"` // noFastForward, squash, rebase or rebaseMerge
+	DeleteSourceBranch  bool   `json:"deleteSourceBranch"`
+	TransitionWorkItems bool   `json:"transitionWorkItems"`
+	MergeCommitMessage  string `json:"mergeCommitMessage,omitempty"`
+}
+
+// PullRequestRequest represents the request body for creating a pull request
+// When an active pull request of the same source and target branches exists, it is updated instead:
+// reviewers, work items and labels are added if missing, but not removed
+type PullRequestRequest struct {
+	SourceRefName     string             `json:"sourceRefName"`               // Required: e.g., feature/x or refs/heads/feature/x
+	TargetRefName     string             `json:"targetRefName"`               // Required: e.g., main or refs/heads/main
+	Title             string             `json:"title"`                       // Required
+	Description       *string            `json:"description,omitempty"`       // If not set, the description of an existing pull request is kept
+	IsDraft           *bool              `json:"isDraft,omitempty"`           // If not set, defaults to false on creation
+	Reviewers         []ReviewerRequest  `json:"reviewers,omitempty"`         // Reviewers to add
+	WorkItemIDs       []int              `json:"workItemIds,omitempty"`       // Work items to link
+	Labels            []string           `json:"labels,omitempty"`            // Labels to add
+	AutoComplete      *bool              `json:"autoComplete,omitempty"`      // If not set, auto-complete is left as it is
+	CompletionOptions *CompletionOptions `json:"completionOptions,omitempty"` // If not set, the completion options are left as they are
+}
+
+// ReviewerRequest represents a reviewer to add to a pull request
+type ReviewerRequest struct {
+	ID         string `json:"id"`                   // Required: identity ID (GUID) of a user or a group
+	IsRequired bool   `json:"isRequired,omitempty"` // Defaults to false (optional reviewer)
+}
+
+// ---
+
+// IdentityRef represents a reference to an identity of Azure DevOps
+type IdentityRef struct {
+	ID          string `json:"id,omitempty"`
+	DisplayName string `json:"displayName,omitempty"`
+	UniqueName  string `json:"uniqueName,omitempty"`
+}
+
+// IdentityRefWithVote represents a reviewer of a pull request of Azure DevOps
+type IdentityRefWithVote struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName,omitempty"`
+	UniqueName  string `json:"uniqueName,omitempty"`
+	IsRequired  bool   `json:"isRequired,omitempty"`
+	Vote        int    `json:"vote"`
+}
+
+// ResourceRef represents a work item linked to a pull request
+type ResourceRef struct {
+	ID  string `json:"id"`
+	URL string `json:"url,omitempty"`
+}
+
+// WebApiTagDefinition represents a label of a pull request
+type WebApiTagDefinition struct {
+	ID     string `json:"id,omitempty"`
+	Name   string `json:"name"`
+	Active *bool  `json:"active,omitempty"`
+}
+
+// GitPullRequestCompletionOptions represents the completion options of a pull request of Azure DevOps
+type GitPullRequestCompletionOptions struct {
+	MergeStrategy       string `json:"mergeStrategy,omitempty"`
+	DeleteSourceBranch  bool   `json:"deleteSourceBranch"`
+	TransitionWorkItems bool   `json:"transitionWorkItems"`
+	MergeCommitMessage  string `json:"mergeCommitMessage,omitempty"`
+}
+
+// GitCommitRef represents a commit of a pull request
+type GitCommitRef struct {
+	CommitID string `json:"commitId"`
+}
+
+// TeamProjectReference represents the project of a repository
+type TeamProjectReference struct {
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+// GitRepositoryRef represents the repository of a pull request
+type GitRepositoryRef struct {
+	ID      string               `json:"id"`
+	Name    string               `json:"name,omitempty"`
+	Project TeamProjectReference `json:"project"`
+}
+
+// GitPullRequest represents a pull request of Azure DevOps
+type GitPullRequest struct {
+	PullRequestID         int                              `json:"pullRequestId"`
+	Status                string                           `json:"status"`
+	MergeStatus           string                           `json:"mergeStatus,omitempty"`
+	SourceRefName         string                           `json:"sourceRefName"`
+	TargetRefName         string                           `json:"targetRefName"`
+	Title                 string                           `json:"title"`
+	Description           string                           `json:"description,omitempty"`
+	IsDraft               bool                             `json:"isDraft"`
+	Repository            GitRepositoryRef                 `json:"repository"`
+	CreatedBy             *IdentityRef                     `json:"createdBy,omitempty"`
+	AutoCompleteSetBy     *IdentityRef                     `json:"autoCompleteSetBy,omitempty"`
+	CompletionOptions     *GitPullRequestCompletionOptions `json:"completionOptions,omitempty"`
+	Reviewers             []IdentityRefWithVote            `json:"reviewers,omitempty"`
+	WorkItemRefs          []ResourceRef                    `json:"workItemRefs,omitempty"`
+	Labels                []WebApiTagDefinition            `json:"labels,omitempty"`
+	LastMergeCommit       *GitCommitRef                    `json:"lastMergeCommit,omitempty"`
+	LastMergeSourceCommit *GitCommitRef                    `json:"lastMergeSourceCommit,omitempty"`
+}
+
+// GitPullRequestList represents the response from:
+// GET https://dev.azure.com/{organization}/{project}/_apis/git/repositories/{repositoryId}/pullrequests?searchCriteria.sourceRefName={sourceRefName}&searchCriteria.targetRefName={targetRefName}&searchCriteria.status=active
+type GitPullRequestList struct {
+	Count int              `json:"count"`
+	Value []GitPullRequest `json:"value"`
+}
+
+// GitPullRequestCreate represents the request body of:
+// POST https://dev.azure.com/{organization}/{project}/_apis/git/repositories/{repositoryId}/pullrequests
+// Auto-complete can only be set by an update, once the pull request exists
+type GitPullRequestCreate struct {
+	SourceRefName string                `json:"sourceRefName"`
+	TargetRefName string                `json:"targetRefName"`
+	Title         string                `json:"title"`
+	Description   string                `json:"description,omitempty"`
+	IsDraft       bool                  `json:"isDraft,omitempty"`
+	Reviewers     []IdentityRefWithVote `json:"reviewers,omitempty"`
+	WorkItemRefs  []ResourceRef         `json:"workItemRefs,omitempty"`
+	Labels        []WebApiTagDefinition `json:"labels,omitempty"`
+}
+
+// GitPullRequestUpdate represents the request body of:
+// PATCH https://dev.azure.com/{organization}/{project}/_apis/git/repositories/{repositoryId}/pullrequests/{pullRequestId}
+// Only the fields that are set are updated
+type GitPullRequestUpdate struct {
+	Status            string                           `json:"status,omitempty"`
+	Title             string                           `json:"title,omitempty"`
+	Description       *string                          `json:"description,omitempty"`
+	IsDraft           *bool                            `json:"isDraft,omitempty"`
+	AutoCompleteSetBy *IdentityRef                     `json:"autoCompleteSetBy,omitempty"`
+	CompletionOptions *GitPullRequestCompletionOptions `json:"completionOptions,omitempty"`
+}
+
+// ConnectionData represents the response from:
+// GET https://dev.azure.com/{organization}/_apis/connectionData
+// The authenticated user is the identity auto-complete is set by
+type ConnectionData struct {
+	AuthenticatedUser IdentityRef `json:"authenticatedUser"`
+}
+
+// JSONPatchOperation represents an operation of the JSON patch document of:
+// PATCH https://dev.azure.com/{organization}/_apis/wit/workitems/{id}
+type JSONPatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value"`
+}
+
+// WorkItemRelation represents a link of a work item (e.g., the artifact link to a pull request)
+type WorkItemRelation struct {
+	Rel        string            `json:"rel"`
+	URL        string            `json:"url"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// Pull request statuses
+const (
+	StatusActive    = "active"
+	StatusAbandoned = "abandoned"
+	StatusCompleted = "completed"
+)
+
+// NoIdentityID is the identity ID that cancels auto-complete when set as autoCompleteSetBy
+const NoIdentityID = "00000000-0000-0000-0000-000000000000"
+
+// DefaultLabelsAPIVersion is the API version of the Pull Request Labels API, which is only available as preview
+// It can be overridden with the GIT_PULL_REQUEST_LABELS_API_VERSION environment variable
+const DefaultLabelsAPIVersion = "7.1-preview.1"
+
+// MergeStrategies lists the merge strategies of the completion options
+var MergeStrategies = []string{"noFastForward", "squash", "rebase", "rebaseMerge"}
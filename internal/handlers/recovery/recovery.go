@@ -0,0 +1,89 @@
+package recovery
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers"
+)
+
+// RequestIDHeader is the header used to read (if set by the caller) and to return the ID of a request
+const RequestIDHeader = "X-Request-Id"
+
+// ErrorResponse represents the body returned when a handler panics
+type ErrorResponse struct {
+	Error     string `json:"error"`
+	Message   string `json:"message"`
+	RequestID string `json:"requestId"`
+}
+
+// responseWriter keeps track of whether the wrapped handler already started writing the response
+type responseWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+}
+
+func (w *responseWriter) WriteHeader(statusCode int) {
+	w.wroteHeader = true
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	return w.ResponseWriter.Write(b)
+}
+
+// Middleware recovers from any panic of the next handler, so that a single request cannot crash the plugin
+// The panic is logged together with the stack trace and converted to a 500 response containing the request ID
+func Middleware(log handlers.Logger, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(RequestIDHeader)
+		if requestID == "" {
+			requestID = newRequestID()
+		}
+		w.Header().Set(RequestIDHeader, requestID)
+
+		rw := &responseWriter{ResponseWriter: w}
+
+		defer func() {
+			rec := recover()
+			if rec == nil {
+				return
+			}
+			// http.ErrAbortHandler is used on purpose to abort a response, let the server handle it
+			if rec == http.ErrAbortHandler {
+				panic(rec)
+			}
+
+			log.Printf("[%s] Recovered from panic while handling %s %s: %v\n%s", requestID, r.Method, r.URL.Path, rec, debug.Stack())
+
+			// The status code cannot be changed once the response has been started
+			if rw.wroteHeader {
+				return
+			}
+
+			body, _ := json.Marshal(ErrorResponse{
+				Error:     http.StatusText(http.StatusInternalServerError),
+				Message:   "An unexpected error occurred while processing the request",
+				RequestID: requestID,
+			})
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write(body)
+		}()
+
+		next.ServeHTTP(rw, r)
+	})
+}
+
+// newRequestID returns a random 16 bytes hex encoded ID
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(b)
+}
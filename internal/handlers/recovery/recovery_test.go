@@ -0,0 +1,119 @@
+package recovery
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestMiddleware(t *testing.T) {
+	logger := zerolog.New(io.Discard)
+
+	tests := []struct {
+		name              string
+		handler           http.HandlerFunc
+		requestID         string
+		expectedStatus    int
+		expectedBody      string
+		expectedErrorBody bool
+	}{
+		{
+			name: "no panic",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte("OK"))
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "OK",
+		},
+		{
+			name: "panic before writing the response",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				var raw map[string]interface{}
+				_ = raw["_links"].(map[string]interface{})["self"]
+			},
+			expectedStatus:    http.StatusInternalServerError,
+			expectedErrorBody: true,
+		},
+		{
+			name: "panic with request ID set by the caller",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				panic("boom")
+			},
+			requestID:         "caller-request-id",
+			expectedStatus:    http.StatusInternalServerError,
+			expectedErrorBody: true,
+		},
+		{
+			name: "panic after writing the response",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusAccepted)
+				panic("boom")
+			},
+			expectedStatus: http.StatusAccepted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/api/testorg/testproject/pipelines/1", nil)
+			if tt.requestID != "" {
+				req.Header.Set(RequestIDHeader, tt.requestID)
+			}
+			rr := httptest.NewRecorder()
+
+			Middleware(&logger, tt.handler).ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+
+			requestID := rr.Header().Get(RequestIDHeader)
+			if requestID == "" {
+				t.Errorf("expected %s header to be set", RequestIDHeader)
+			}
+			if tt.requestID != "" && requestID != tt.requestID {
+				t.Errorf("expected request ID %q, got %q", tt.requestID, requestID)
+			}
+
+			if tt.expectedBody != "" && rr.Body.String() != tt.expectedBody {
+				t.Errorf("expected body %q, got %q", tt.expectedBody, rr.Body.String())
+			}
+
+			if tt.expectedErrorBody {
+				if contentType := rr.Header().Get("Content-Type"); contentType != "application/json" {
+					t.Errorf("expected Content-Type application/json, got %q", contentType)
+				}
+				var response ErrorResponse
+				if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+					t.Fatalf("failed to unmarshal error response: %v", err)
+				}
+				if response.RequestID != requestID {
+					t.Errorf("expected request ID %q in body, got %q", requestID, response.RequestID)
+				}
+				if response.Error != "Internal Server Error" {
+					t.Errorf("expected error %q, got %q", "Internal Server Error", response.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestMiddleware_ErrAbortHandler(t *testing.T) {
+	logger := zerolog.New(io.Discard)
+	handler := Middleware(&logger, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+
+	defer func() {
+		if rec := recover(); rec != http.ErrAbortHandler {
+			t.Errorf("expected http.ErrAbortHandler to be re-panicked, got %v", rec)
+		}
+	}()
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+}
@@ -0,0 +1,365 @@
+package serviceendpoint
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers"
+)
+
+// errServiceEndpointNotFound is returned when the service connection does not exist
+var errServiceEndpointNotFound = errors.New("service connection not found")
+
+// Handler constructors
+func GetServiceEndpoint(opts handlers.HandlerOptions) handlers.Handler {
+	return &getHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func PostServiceEndpoint(opts handlers.HandlerOptions) handlers.Handler {
+	return &postHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func PutServiceEndpoint(opts handlers.HandlerOptions) handlers.Handler {
+	return &putHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func DeleteServiceEndpoint(opts handlers.HandlerOptions) handlers.Handler {
+	return &deleteHandler{baseHandler: newBaseHandler(opts)}
+}
+
+// Interface compliance verification
+var _ handlers.Handler = &getHandler{}
+var _ handlers.Handler = &postHandler{}
+var _ handlers.Handler = &putHandler{}
+var _ handlers.Handler = &deleteHandler{}
+
+// Base handler with common functionality
+type baseHandler struct {
+	*handlers.BaseHandler
+}
+
+// Constructor for the base handler
+func newBaseHandler(opts handlers.HandlerOptions) *baseHandler {
+	return &baseHandler{BaseHandler: handlers.NewBaseHandler(opts)}
+}
+
+// Handler types embedding the base handler
+type getHandler struct {
+	*baseHandler
+}
+
+type postHandler struct {
+	*baseHandler
+}
+
+type putHandler struct {
+	*baseHandler
+}
+
+type deleteHandler struct {
+	*baseHandler
+}
+
+// writeServiceEndpointError writes the response for an error returned while managing a service connection
+// 404 is returned with a custom message, other Azure DevOps errors are forwarded as they are
+func (h *baseHandler) writeServiceEndpointError(w http.ResponseWriter, err error, action, id string) {
+	var adoErr *handlers.AzureDevOpsError
+	switch {
+	case errors.Is(err, errServiceEndpointNotFound):
+		h.WriteErrorResponse(w, http.StatusNotFound, fmt.Sprintf("Service connection with ID %s not found", id))
+	case errors.As(err, &adoErr) && adoErr.StatusCode == http.StatusNotFound:
+		h.WriteErrorResponse(w, http.StatusNotFound, fmt.Sprintf("Service connection with ID %s not found", id))
+	case errors.As(err, &adoErr):
+		h.WriteJSONResponse(w, adoErr.StatusCode, adoErr.Body)
+	default:
+		h.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Failed to %s: %v", action, err))
+	}
+}
+
+// writeServiceEndpointResponse decodes a service connection returned by Azure DevOps and writes it
+func (h *baseHandler) writeServiceEndpointResponse(w http.ResponseWriter, statusCode int, body []byte) {
+	var endpoint AzureServiceEndpoint
+	if err := json.Unmarshal(body, &endpoint); err != nil {
+		h.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Failed to unmarshal service connection: %v", err))
+		return
+	}
+
+	responseBytes, err := json.Marshal(ServiceEndpointResponse(*mapServiceEndpointFromAzure(&endpoint)))
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Failed to marshal response: %v", err))
+		return
+	}
+	h.WriteJSONResponse(w, statusCode, responseBytes)
+}
+
+// getServiceEndpoint retrieves a service connection
+// errServiceEndpointNotFound is returned if the service connection does not exist
+func (h *baseHandler) getServiceEndpoint(organization, projectID, id, apiVersion, authHeader string) (*AzureServiceEndpoint, []byte, error) {
+	url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/serviceendpoint/endpoints/%s?api-version=%s", organization, projectID, id, apiVersion)
+
+	body, err := h.DoAzureDevOpsRequest("GET", url, authHeader, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	if isEmptyBody(body) {
+		return nil, nil, errServiceEndpointNotFound
+	}
+
+	var endpoint AzureServiceEndpoint
+	if err := json.Unmarshal(body, &endpoint); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal service connection: %w", err)
+	}
+
+	return &endpoint, body, nil
+}
+
+// readServiceEndpointRequest reads and validates the service connection in the request body
+// Secret parameters required by the authorization scheme are only checked on creation
+func (h *baseHandler) readServiceEndpointRequest(w http.ResponseWriter, r *http.Request, create bool) (*ServiceEndpointRequest, bool) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Failed to read request body")
+		return nil, false
+	}
+
+	var request ServiceEndpointRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Invalid JSON in request body")
+		return nil, false
+	}
+	if err := validateServiceEndpointRequest(&request, create); err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid service connection: %v", err))
+		return nil, false
+	}
+
+	return &request, true
+}
+
+// GET handler implementation
+// @Summary Get a service connection
+// @Description Get a service connection. Secret authorization parameters are not returned, parameter names are lowercased and project references are sorted by project ID.
+// @ID get-serviceendpoint
+// @Param organization path string true "Organization name"
+// @Param projectId path string true "Project ID"
+// @Param id path string true "Service connection ID"
+// @Param api-version query string true "API version (e.g., 7.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Produce json
+// @Success 200 {object} ServiceEndpointResponse "Service connection details"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{projectId}/serviceendpoints/{id} [get]
+func (h *getHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	projectID := r.PathValue("projectId")
+	id := r.PathValue("id")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, projectID, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	h.Log.Printf("Getting service connection with ID %s for organization %s and project %s", id, organization, projectID)
+
+	_, body, err := h.getServiceEndpoint(organization, projectID, id, apiVersion, authHeader)
+	if err != nil {
+		h.writeServiceEndpointError(w, err, "get service connection", id)
+		return
+	}
+
+	h.writeServiceEndpointResponse(w, http.StatusOK, body)
+	h.Log.Printf("Successfully retrieved service connection with ID %s", id)
+}
+
+// POST handler implementation
+// @Summary Create a service connection
+// @Description Create a Kubernetes, Azure RM or generic service connection, optionally shared with other projects
+// @ID post-serviceendpoint
+// @Param organization path string true "Organization name"
+// @Param projectId path string true "Project ID"
+// @Param api-version query string true "API version (e.g., 7.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Param serviceEndpoint body ServiceEndpointRequest true "Service connection creation request body"
+// @Accept json
+// @Produce json
+// @Success 201 {object} ServiceEndpointResponse "Created service connection"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{projectId}/serviceendpoints [post]
+func (h *postHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	projectID := r.PathValue("projectId")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, projectID, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	request, ok := h.readServiceEndpointRequest(w, r, true)
+	if !ok {
+		return
+	}
+
+	h.Log.Printf("Creating service connection %s for organization %s and project %s", request.Name, organization, projectID)
+
+	requestBody, err := json.Marshal(buildAzureServiceEndpoint(request, projectID))
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Failed to marshal service connection: %v", err))
+		return
+	}
+
+	// Service connections are created at organization level and shared with the projects in the references
+	url := fmt.Sprintf("https://dev.azure.com/%s/_apis/serviceendpoint/endpoints?api-version=%s", organization, apiVersion)
+	responseBody, err := h.DoAzureDevOpsRequest("POST", url, authHeader, requestBody)
+	if err != nil {
+		var adoErr *handlers.AzureDevOpsError
+		if errors.As(err, &adoErr) {
+			h.WriteJSONResponse(w, adoErr.StatusCode, adoErr.Body)
+			return
+		}
+		h.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Failed to create service connection: %v", err))
+		return
+	}
+
+	h.writeServiceEndpointResponse(w, http.StatusCreated, responseBody)
+	h.Log.Printf("Successfully created service connection %s", request.Name)
+}
+
+// PUT handler implementation
+// @Summary Update a service connection
+// @Description Replace a service connection. Secret authorization parameters not sent keep their current value.
+// @ID put-serviceendpoint
+// @Param organization path string true "Organization name"
+// @Param projectId path string true "Project ID"
+// @Param id path string true "Service connection ID"
+// @Param api-version query string true "API version (e.g., 7.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Param serviceEndpoint body ServiceEndpointRequest true "Service connection update request body"
+// @Accept json
+// @Produce json
+// @Success 200 {object} ServiceEndpointResponse "Updated service connection"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{projectId}/serviceendpoints/{id} [put]
+func (h *putHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	projectID := r.PathValue("projectId")
+	id := r.PathValue("id")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, projectID, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	request, ok := h.readServiceEndpointRequest(w, r, false)
+	if !ok {
+		return
+	}
+
+	h.Log.Printf("Updating service connection with ID %s for organization %s and project %s", id, organization, projectID)
+
+	current, _, err := h.getServiceEndpoint(organization, projectID, id, apiVersion, authHeader)
+	if err != nil {
+		h.writeServiceEndpointError(w, err, "get service connection", id)
+		return
+	}
+
+	// Stored secrets can only be kept if type and scheme do not change
+	if !strings.EqualFold(current.Type, request.Type) || current.Authorization == nil || !strings.EqualFold(current.Authorization.Scheme, request.Authorization.Scheme) {
+		if err := validateServiceEndpointRequest(request, true); err != nil {
+			h.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid service connection: %v", err))
+			return
+		}
+	}
+
+	endpoint := buildAzureServiceEndpoint(request, projectID)
+	endpoint.ID = current.ID
+	requestBody, err := json.Marshal(endpoint)
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Failed to marshal service connection: %v", err))
+		return
+	}
+
+	url := fmt.Sprintf("https://dev.azure.com/%s/_apis/serviceendpoint/endpoints/%s?api-version=%s", organization, id, apiVersion)
+	responseBody, err := h.DoAzureDevOpsRequest("PUT", url, authHeader, requestBody)
+	if err != nil {
+		h.writeServiceEndpointError(w, err, "update service connection", id)
+		return
+	}
+
+	h.writeServiceEndpointResponse(w, http.StatusOK, responseBody)
+	h.Log.Printf("Successfully updated service connection with ID %s", id)
+}
+
+// DELETE handler implementation
+// @Summary Delete a service connection
+// @Description Delete a service connection from all the projects it is shared with
+// @ID delete-serviceendpoint
+// @Param organization path string true "Organization name"
+// @Param projectId path string true "Project ID"
+// @Param id path string true "Service connection ID"
+// @Param api-version query string true "API version (e.g., 7.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Success 204 "No Content"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{projectId}/serviceendpoints/{id} [delete]
+func (h *deleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	projectID := r.PathValue("projectId")
+	id := r.PathValue("id")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, projectID, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	h.Log.Printf("Deleting service connection with ID %s for organization %s and project %s", id, organization, projectID)
+
+	// Azure DevOps requires the IDs of the projects to delete the service connection from
+	current, _, err := h.getServiceEndpoint(organization, projectID, id, apiVersion, authHeader)
+	if err != nil {
+		h.writeServiceEndpointError(w, err, "get service connection", id)
+		return
+	}
+	projects := projectIDs(current)
+	if projects == "" {
+		projects = projectID
+	}
+
+	url := fmt.Sprintf("https://dev.azure.com/%s/_apis/serviceendpoint/endpoints/%s?projectIds=%s&api-version=%s", organization, id, projects, apiVersion)
+	if _, err := h.DoAzureDevOpsRequest("DELETE", url, authHeader, nil); err != nil {
+		h.writeServiceEndpointError(w, err, "delete service connection", id)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	h.Log.Printf("Successfully deleted service connection with ID %s", id)
+}
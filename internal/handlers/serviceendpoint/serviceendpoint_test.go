@@ -0,0 +1,273 @@
+package serviceendpoint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers"
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/handlerstest"
+	"github.com/rs/zerolog"
+)
+
+// Test data constants
+const (
+	testOrg        = "testorg"
+	testProjectID  = "0d5ba2a0-4d8e-4b1f-8f49-7c1f5f8c2e11"
+	testEndpointID = "9a1c2b3d-0000-0000-0000-000000000001"
+	testAPIVersion = "7.1"
+	testUsername   = "test"
+	testPassword   = "test"
+)
+
+var (
+	endpointsURL        = fmt.Sprintf("https://dev.azure.com/%s/_apis/serviceendpoint/endpoints?api-version=%s", testOrg, testAPIVersion)
+	endpointURL         = fmt.Sprintf("https://dev.azure.com/%s/_apis/serviceendpoint/endpoints/%s?api-version=%s", testOrg, testEndpointID, testAPIVersion)
+	projectEndpointURL  = fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/serviceendpoint/endpoints/%s?api-version=%s", testOrg, testProjectID, testEndpointID, testAPIVersion)
+	kubernetesEndpoint  = fmt.Sprintf(`{"id":"9A1C2B3D-0000-0000-0000-000000000001","name":"aks","type":"kubernetes","url":"https://aks.example.com","description":"","authorization":{"scheme":"Kubeconfig","parameters":{"kubeconfig":null,"clusterContext":"aks-admin","acceptUntrustedCerts":""}},"data":{"authorizationType":"Kubeconfig","acceptUntrustedCerts":null},"isShared":true,"isReady":true,"createdBy":{"id":"user-id"},"serviceEndpointProjectReferences":[{"name":"aks","projectReference":{"id":"F1E2D3C4-0000-0000-0000-000000000000"}},{"name":"aks","projectReference":{"id":%q}}]}`, testProjectID)
+	kubernetesRequest   = `{"name":"aks","type":"Kubernetes","url":"https://aks.example.com","authorization":{"scheme":"kubeconfig","parameters":{"kubeConfig":"apiVersion: v1","clusterContext":"aks-admin"}}}`
+	azureRMEndpoint     = fmt.Sprintf(`{"id":%q,"name":"azure","type":"azurerm","url":"https://management.azure.com/","authorization":{"scheme":"ServicePrincipal","parameters":{"tenantid":"tenant","serviceprincipalid":"sp","serviceprincipalkey":null}},"data":{"subscriptionId":"sub"},"serviceEndpointProjectReferences":[{"name":"azure","projectReference":{"id":%q}}]}`, testEndpointID, testProjectID)
+	azureRMUpdateNoKey  = `{"name":"azure","type":"azurerm","authorization":{"scheme":"ServicePrincipal","parameters":{"tenantId":"tenant","servicePrincipalId":"sp"}},"data":{"subscriptionId":"sub"}}`
+	genericUpdateNoPass = `{"name":"azure","type":"generic","url":"https://example.com","authorization":{"scheme":"UsernamePassword","parameters":{"username":"user"}}}`
+)
+
+// createTestHandler creates a handler for testing with a mock client
+func createTestHandler(mockClient *handlerstest.MockHTTPClient, newHandler func(*baseHandler) http.Handler) http.Handler {
+	return newHandler(newBaseHandler(handlerstest.NewHandlerOptions(mockClient)))
+}
+
+// serveTestRequest sends a request with the service endpoint path values to the handler
+func serveTestRequest(handler http.Handler, method, id, body string) *httptest.ResponseRecorder {
+	var bodyReader io.Reader
+	if body != "" {
+		bodyReader = strings.NewReader(body)
+	}
+	req := httptest.NewRequest(method, "/api/test?api-version="+testAPIVersion, bodyReader)
+	req.SetPathValue("organization", testOrg)
+	req.SetPathValue("projectId", testProjectID)
+	if id != "" {
+		req.SetPathValue("id", id)
+	}
+	req.SetBasicAuth(testUsername, testPassword)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	return rr
+}
+
+// Test constructor functions
+func TestConstructors(t *testing.T) {
+	client := &http.Client{}
+	logger := zerolog.New(os.Stdout).With().Timestamp().Logger()
+	opts := handlers.HandlerOptions{
+		Client: client,
+		Log:    &logger,
+	}
+
+	for name, constructor := range map[string]func(handlers.HandlerOptions) handlers.Handler{
+		"GetServiceEndpoint":    GetServiceEndpoint,
+		"PostServiceEndpoint":   PostServiceEndpoint,
+		"PutServiceEndpoint":    PutServiceEndpoint,
+		"DeleteServiceEndpoint": DeleteServiceEndpoint,
+	} {
+		t.Run(name, func(t *testing.T) {
+			if h := constructor(opts); h == nil {
+				t.Fatalf("%s should return a non-nil handler", name)
+			}
+		})
+	}
+}
+
+// Test GET handler
+func TestGetHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name           string
+		setupMock      func(*handlerstest.MockHTTPClient)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "masked and secret parameters removed",
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(projectEndpointURL, http.StatusOK, kubernetesEndpoint)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   fmt.Sprintf(`{"id":"9a1c2b3d-0000-0000-0000-000000000001","name":"aks","type":"kubernetes","url":"https://aks.example.com","authorization":{"scheme":"Kubeconfig","parameters":{"clustercontext":"aks-admin"}},"data":{"authorizationType":"Kubeconfig"},"isShared":true,"isReady":true,"projectReferences":[{"projectId":%q,"name":"aks"},{"projectId":"f1e2d3c4-0000-0000-0000-000000000000","name":"aks"}]}`, testProjectID),
+		},
+		{
+			name:           "empty body means not found",
+			setupMock:      func(m *handlerstest.MockHTTPClient) { m.SetResponse(projectEndpointURL, http.StatusOK, "null") },
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   "Service connection with ID 9a1c2b3d-0000-0000-0000-000000000001 not found",
+		},
+		{
+			name: "azure devops error forwarded",
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(projectEndpointURL, http.StatusForbidden, `{"message":"Forbidden"}`)
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `{"message":"Forbidden"}`,
+		},
+		{
+			name:           "network error",
+			setupMock:      func(m *handlerstest.MockHTTPClient) { m.SetError(projectEndpointURL, fmt.Errorf("connection refused")) },
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := handlerstest.NewMockHTTPClient()
+			tt.setupMock(mockClient)
+			handler := createTestHandler(mockClient, func(b *baseHandler) http.Handler { return &getHandler{baseHandler: b} })
+
+			rr := serveTestRequest(handler, "GET", testEndpointID, "")
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("Status code = %d, want %d. Body: %s", rr.Code, tt.expectedStatus, rr.Body.String())
+			}
+			if tt.expectedBody != "" && rr.Body.String() != tt.expectedBody {
+				t.Errorf("Body = %s, want %s", rr.Body.String(), tt.expectedBody)
+			}
+		})
+	}
+}
+
+// Test POST handler
+func TestPostHandler_ServeHTTP(t *testing.T) {
+	t.Run("creates a service connection shared with the project in the path", func(t *testing.T) {
+		mockClient := handlerstest.NewMockHTTPClient()
+		mockClient.SetResponse(endpointsURL, http.StatusOK, kubernetesEndpoint)
+		handler := createTestHandler(mockClient, func(b *baseHandler) http.Handler { return &postHandler{baseHandler: b} })
+
+		rr := serveTestRequest(handler, "POST", "", kubernetesRequest)
+
+		if rr.Code != http.StatusCreated {
+			t.Fatalf("Status code = %d, want %d. Body: %s", rr.Code, http.StatusCreated, rr.Body.String())
+		}
+		want := fmt.Sprintf(`{"name":"aks","type":"kubernetes","url":"https://aks.example.com","description":"","authorization":{"scheme":"Kubeconfig","parameters":{"clustercontext":"aks-admin","kubeconfig":"apiVersion: v1"}},"data":{},"isShared":false,"isReady":false,"owner":"library","serviceEndpointProjectReferences":[{"name":"aks","description":"","projectReference":{"id":%q}}]}`, testProjectID)
+		if mockClient.Bodies[0] != want {
+			t.Errorf("Request body = %s, want %s", mockClient.Bodies[0], want)
+		}
+		if strings.Contains(rr.Body.String(), "kubeconfig\"") {
+			t.Errorf("Response should not contain the kubeconfig: %s", rr.Body.String())
+		}
+	})
+
+	for _, tt := range []struct {
+		name string
+		body string
+		want string
+	}{
+		{name: "missing name", body: `{"type":"generic"}`, want: "Invalid service connection: name is required"},
+		{name: "unsupported type", body: `{"name":"a","type":"github"}`, want: "Invalid service connection: type 'github' is not supported, supported types are: kubernetes, azurerm, generic"},
+		{name: "unsupported scheme", body: `{"name":"a","type":"kubernetes","authorization":{"scheme":"Token"}}`, want: "Invalid service connection: authorization scheme 'Token' is not supported for type 'kubernetes', supported schemes are: Kubeconfig, ServiceAccount"},
+		{name: "missing secret on creation", body: `{"name":"a","type":"azurerm","authorization":{"scheme":"ServicePrincipal","parameters":{"tenantid":"t","serviceprincipalid":"s"}},"data":{"subscriptionId":"sub"}}`, want: "Invalid service connection: authorization parameter 'serviceprincipalkey' is required for scheme 'ServicePrincipal'"},
+		{name: "missing subscription", body: `{"name":"a","type":"azurerm","authorization":{"scheme":"WorkloadIdentityFederation","parameters":{"tenantid":"t","serviceprincipalid":"s"}}}`, want: "Invalid service connection: data.subscriptionId or data.managementGroupId is required for type 'azurerm'"},
+		{name: "missing url", body: `{"name":"a","type":"generic","authorization":{"scheme":"UsernamePassword","parameters":{"username":"u"}}}`, want: "Invalid service connection: url is required for type 'generic'"},
+		{name: "invalid JSON", body: `{"name":`, want: "Invalid JSON in request body"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := handlerstest.NewMockHTTPClient()
+			handler := createTestHandler(mockClient, func(b *baseHandler) http.Handler { return &postHandler{baseHandler: b} })
+
+			rr := serveTestRequest(handler, "POST", "", tt.body)
+
+			if rr.Code != http.StatusBadRequest || rr.Body.String() != tt.want {
+				t.Errorf("Response = %d %s, want 400 %s", rr.Code, rr.Body.String(), tt.want)
+			}
+			if len(mockClient.Requests) != 0 {
+				t.Errorf("Expected no requests to Azure DevOps, got %d", len(mockClient.Requests))
+			}
+		})
+	}
+}
+
+// Test PUT handler
+func TestPutHandler_ServeHTTP(t *testing.T) {
+	t.Run("secret not sent is kept when scheme does not change", func(t *testing.T) {
+		mockClient := handlerstest.NewMockHTTPClient()
+		mockClient.SetResponse(projectEndpointURL, http.StatusOK, azureRMEndpoint)
+		mockClient.SetMethodResponse("PUT", endpointURL, http.StatusOK, azureRMEndpoint)
+		handler := createTestHandler(mockClient, func(b *baseHandler) http.Handler { return &putHandler{baseHandler: b} })
+
+		rr := serveTestRequest(handler, "PUT", testEndpointID, azureRMUpdateNoKey)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Status code = %d, want %d. Body: %s", rr.Code, http.StatusOK, rr.Body.String())
+		}
+		var sent AzureServiceEndpoint
+		if err := json.Unmarshal([]byte(mockClient.Bodies[1]), &sent); err != nil {
+			t.Fatalf("Failed to unmarshal request body: %v", err)
+		}
+		if sent.ID != testEndpointID {
+			t.Errorf("ID = %s, want %s", sent.ID, testEndpointID)
+		}
+		if _, exists := sent.Authorization.Parameters["serviceprincipalkey"]; exists {
+			t.Error("Secret not in the request should not be sent")
+		}
+		if rr.Body.String() != fmt.Sprintf(`{"id":%q,"name":"azure","type":"azurerm","url":"https://management.azure.com/","authorization":{"scheme":"ServicePrincipal","parameters":{"serviceprincipalid":"sp","tenantid":"tenant"}},"data":{"subscriptionId":"sub"},"isShared":false,"isReady":false,"projectReferences":[{"projectId":%q,"name":"azure"}]}`, testEndpointID, testProjectID) {
+			t.Errorf("Unexpected response body: %s", rr.Body.String())
+		}
+	})
+
+	t.Run("type change requires the secrets of the new scheme", func(t *testing.T) {
+		mockClient := handlerstest.NewMockHTTPClient()
+		mockClient.SetResponse(projectEndpointURL, http.StatusOK, azureRMEndpoint)
+		handler := createTestHandler(mockClient, func(b *baseHandler) http.Handler { return &putHandler{baseHandler: b} })
+
+		rr := serveTestRequest(handler, "PUT", testEndpointID, strings.Replace(kubernetesRequest, `"kubeConfig":"apiVersion: v1",`, "", 1))
+
+		if rr.Code != http.StatusBadRequest || rr.Body.String() != "Invalid service connection: authorization parameter 'kubeconfig' is required for scheme 'Kubeconfig'" {
+			t.Errorf("Response = %d %s", rr.Code, rr.Body.String())
+		}
+		if len(mockClient.Requests) != 1 {
+			t.Errorf("Expected 1 request to Azure DevOps, got %d", len(mockClient.Requests))
+		}
+	})
+
+	t.Run("service connection not found", func(t *testing.T) {
+		mockClient := handlerstest.NewMockHTTPClient()
+		mockClient.SetResponse(projectEndpointURL, http.StatusOK, "")
+		handler := createTestHandler(mockClient, func(b *baseHandler) http.Handler { return &putHandler{baseHandler: b} })
+
+		rr := serveTestRequest(handler, "PUT", testEndpointID, genericUpdateNoPass)
+
+		if rr.Code != http.StatusNotFound {
+			t.Errorf("Status code = %d, want %d. Body: %s", rr.Code, http.StatusNotFound, rr.Body.String())
+		}
+	})
+}
+
+// Test DELETE handler
+func TestDeleteHandler_ServeHTTP(t *testing.T) {
+	t.Run("deletes the service connection from all shared projects", func(t *testing.T) {
+		deleteURL := fmt.Sprintf("https://dev.azure.com/%s/_apis/serviceendpoint/endpoints/%s?projectIds=%s,F1E2D3C4-0000-0000-0000-000000000000&api-version=%s", testOrg, testEndpointID, testProjectID, testAPIVersion)
+		mockClient := handlerstest.NewMockHTTPClient()
+		mockClient.SetResponse(projectEndpointURL, http.StatusOK, kubernetesEndpoint)
+		mockClient.SetMethodResponse("DELETE", deleteURL, http.StatusNoContent, "")
+		handler := createTestHandler(mockClient, func(b *baseHandler) http.Handler { return &deleteHandler{baseHandler: b} })
+
+		rr := serveTestRequest(handler, "DELETE", testEndpointID, "")
+
+		if rr.Code != http.StatusNoContent {
+			t.Errorf("Status code = %d, want %d. Body: %s", rr.Code, http.StatusNoContent, rr.Body.String())
+		}
+	})
+
+	t.Run("service connection not found", func(t *testing.T) {
+		mockClient := handlerstest.NewMockHTTPClient()
+		handler := createTestHandler(mockClient, func(b *baseHandler) http.Handler { return &deleteHandler{baseHandler: b} })
+
+		rr := serveTestRequest(handler, "DELETE", testEndpointID, "")
+
+		if rr.Code != http.StatusNotFound || len(mockClient.Requests) != 1 {
+			t.Errorf("Response = %d after %d requests, want 404 after 1 request", rr.Code, len(mockClient.Requests))
+		}
+	})
+}
@@ -0,0 +1,225 @@
+package serviceendpoint
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// supportedSchemes lists the authorization schemes supported for each service connection type
+// Schemes are keyed by their lowercased name
+var supportedSchemes = map[string]map[string]authorizationScheme{
+	ServiceEndpointTypeKubernetes: {
+		"kubeconfig":     {Name: "Kubeconfig", RequiredSecrets: []string{"kubeconfig"}},
+		"serviceaccount": {Name: "ServiceAccount", RequiredSecrets: []string{"apitoken", "serviceaccountcertificate"}},
+	},
+	ServiceEndpointTypeAzureRM: {
+		"serviceprincipal":           {Name: "ServicePrincipal", RequiredParameters: []string{"tenantid", "serviceprincipalid"}, RequiredSecrets: []string{"serviceprincipalkey"}},
+		"workloadidentityfederation": {Name: "WorkloadIdentityFederation", RequiredParameters: []string{"tenantid", "serviceprincipalid"}},
+		"managedserviceidentity":     {Name: "ManagedServiceIdentity", RequiredParameters: []string{"tenantid"}},
+	},
+	ServiceEndpointTypeGeneric: {
+		"usernamepassword": {Name: "UsernamePassword", RequiredParameters: []string{"username"}},
+	},
+}
+
+// secretParameters lists the authorization parameters that are write only (lowercased)
+var secretParameters = map[string]bool{
+	"apitoken":                  true,
+	"certificate":               true,
+	"kubeconfig":                true,
+	"password":                  true,
+	"serviceaccountcertificate": true,
+	"serviceprincipalkey":       true,
+}
+
+// validateServiceEndpointRequest checks the fields of a service connection request and normalizes type, scheme and parameter names
+// Secret parameters required by the scheme are only checked on creation
+func validateServiceEndpointRequest(request *ServiceEndpointRequest, create bool) error {
+	if strings.TrimSpace(request.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	request.Type = strings.ToLower(strings.TrimSpace(request.Type))
+	schemes, ok := supportedSchemes[request.Type]
+	if !ok {
+		return fmt.Errorf("type '%s' is not supported, supported types are: %s, %s, %s", request.Type, ServiceEndpointTypeKubernetes, ServiceEndpointTypeAzureRM, ServiceEndpointTypeGeneric)
+	}
+
+	scheme, ok := schemes[strings.ToLower(strings.TrimSpace(request.Authorization.Scheme))]
+	if !ok {
+		names := []string{}
+		for _, supported := range schemes {
+			names = append(names, supported.Name)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("authorization scheme '%s' is not supported for type '%s', supported schemes are: %s", request.Authorization.Scheme, request.Type, strings.Join(names, ", "))
+	}
+	request.Authorization.Scheme = scheme.Name
+	request.Authorization.Parameters = normalizeParameters(request.Authorization.Parameters)
+
+	required := scheme.RequiredParameters
+	if create {
+		required = append(append([]string{}, required...), scheme.RequiredSecrets...)
+	}
+	for _, parameter := range required {
+		if request.Authorization.Parameters[parameter] == "" {
+			return fmt.Errorf("authorization parameter '%s' is required for scheme '%s'", parameter, scheme.Name)
+		}
+	}
+
+	switch request.Type {
+	case ServiceEndpointTypeAzureRM:
+		if request.URL == "" {
+			request.URL = DefaultAzureRMURL
+		}
+		if request.Data["subscriptionId"] == "" && request.Data["managementGroupId"] == "" {
+			return fmt.Errorf("data.subscriptionId or data.managementGroupId is required for type '%s'", request.Type)
+		}
+	default:
+		if strings.TrimSpace(request.URL) == "" {
+			return fmt.Errorf("url is required for type '%s'", request.Type)
+		}
+	}
+
+	projects := map[string]bool{}
+	for _, reference := range request.ProjectReferences {
+		projectID := strings.ToLower(strings.TrimSpace(reference.ProjectID))
+		if projectID == "" {
+			return fmt.Errorf("projectReferences: projectId is required")
+		}
+		if projects[projectID] {
+			return fmt.Errorf("projectReferences: project %s is referenced more than once", reference.ProjectID)
+		}
+		projects[projectID] = true
+	}
+
+	return nil
+}
+
+// normalizeParameters returns the authorization parameters with lowercased names
+// Azure DevOps handles parameter names case insensitively but may return them with a different case
+func normalizeParameters(parameters map[string]string) map[string]string {
+	normalized := map[string]string{}
+	for name, value := range parameters {
+		normalized[strings.ToLower(name)] = value
+	}
+	return normalized
+}
+
+// buildAzureServiceEndpoint converts a validated service connection request to the Azure DevOps request body
+func buildAzureServiceEndpoint(request *ServiceEndpointRequest, projectID string) *AzureServiceEndpoint {
+	endpoint := &AzureServiceEndpoint{
+		Name:        request.Name,
+		Type:        request.Type,
+		URL:         request.URL,
+		Description: request.Description,
+		Authorization: &AzureEndpointAuthorization{
+			Scheme:     request.Authorization.Scheme,
+			Parameters: map[string]*string{},
+		},
+		Data:                             map[string]*string{},
+		Owner:                            ServiceEndpointOwner,
+		ServiceEndpointProjectReferences: []AzureServiceEndpointProjectReference{},
+	}
+
+	for name, value := range request.Authorization.Parameters {
+		value := value
+		endpoint.Authorization.Parameters[name] = &value
+	}
+	for name, value := range request.Data {
+		value := value
+		endpoint.Data[name] = &value
+	}
+
+	references := request.ProjectReferences
+	if len(references) == 0 {
+		references = []ServiceEndpointProjectReference{{ProjectID: projectID}}
+	}
+	for _, reference := range references {
+		name := reference.Name
+		if name == "" {
+			name = request.Name
+		}
+		endpoint.ServiceEndpointProjectReferences = append(endpoint.ServiceEndpointProjectReferences, AzureServiceEndpointProjectReference{
+			Name:             name,
+			Description:      reference.Description,
+			ProjectReference: &ProjectReference{ID: strings.ToLower(reference.ProjectID)},
+		})
+	}
+
+	return endpoint
+}
+
+// mapServiceEndpointFromAzure converts an Azure DevOps service endpoint to the service connection returned by the plugin
+// Secret parameters and parameters masked by Azure DevOps (null or empty) are not returned
+func mapServiceEndpointFromAzure(endpoint *AzureServiceEndpoint) *ServiceEndpoint {
+	serviceEndpoint := &ServiceEndpoint{
+		ID:                strings.ToLower(endpoint.ID),
+		Name:              endpoint.Name,
+		Type:              strings.ToLower(endpoint.Type),
+		URL:               endpoint.URL,
+		Description:       endpoint.Description,
+		Authorization:     EndpointAuthorization{Parameters: map[string]string{}},
+		IsShared:          endpoint.IsShared,
+		IsReady:           endpoint.IsReady,
+		ProjectReferences: []ServiceEndpointProjectReference{},
+	}
+
+	if endpoint.Authorization != nil {
+		serviceEndpoint.Authorization.Scheme = endpoint.Authorization.Scheme
+		for name, value := range endpoint.Authorization.Parameters {
+			name = strings.ToLower(name)
+			if secretParameters[name] || value == nil || *value == "" {
+				continue
+			}
+			serviceEndpoint.Authorization.Parameters[name] = *value
+		}
+	}
+
+	for name, value := range endpoint.Data {
+		if value == nil || *value == "" {
+			continue
+		}
+		if serviceEndpoint.Data == nil {
+			serviceEndpoint.Data = map[string]string{}
+		}
+		serviceEndpoint.Data[name] = *value
+	}
+
+	for _, reference := range endpoint.ServiceEndpointProjectReferences {
+		if reference.ProjectReference == nil {
+			continue
+		}
+		serviceEndpoint.ProjectReferences = append(serviceEndpoint.ProjectReferences, ServiceEndpointProjectReference{
+			ProjectID:   strings.ToLower(reference.ProjectReference.ID),
+			Name:        reference.Name,
+			Description: reference.Description,
+		})
+	}
+	sort.Slice(serviceEndpoint.ProjectReferences, func(i, j int) bool {
+		return serviceEndpoint.ProjectReferences[i].ProjectID < serviceEndpoint.ProjectReferences[j].ProjectID
+	})
+
+	return serviceEndpoint
+}
+
+// projectIDs returns the IDs of the projects the service endpoint is shared with, comma separated
+func projectIDs(endpoint *AzureServiceEndpoint) string {
+	ids := []string{}
+	for _, reference := range endpoint.ServiceEndpointProjectReferences {
+		if reference.ProjectReference != nil && reference.ProjectReference.ID != "" {
+			ids = append(ids, reference.ProjectReference.ID)
+		}
+	}
+	sort.Strings(ids)
+	return strings.Join(ids, ",")
+}
+
+// isEmptyBody reports whether Azure DevOps returned an empty body or null
+// Azure DevOps returns 200 with an empty body when the service endpoint does not exist
+func isEmptyBody(body []byte) bool {
+	trimmed := bytes.TrimSpace(body)
+	return len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null"))
+}
@@ -0,0 +1,103 @@
+package serviceendpoint
+
+// ServiceEndpoint represents the service connection returned by the plugin
+// It is built from the AzureServiceEndpoint returned by:
+// GET /{organization}/{project}/_apis/serviceendpoint/endpoints/{endpointId}
+// Secret authorization parameters are never returned
+type ServiceEndpoint struct {
+	ID                string                            `json:"id"`
+	Name              string                            `json:"name"`
+	Type              string                            `json:"type"` // enum: kubernetes, azurerm, generic
+	URL               string                            `json:"url"`
+	Description       string                            `json:"description,omitempty"`
+	Authorization     EndpointAuthorization             `json:"authorization"`
+	Data              map[string]string                 `json:"data,omitempty"`
+	IsShared          bool                              `json:"isShared"`
+	IsReady           bool                              `json:"isReady"`
+	ProjectReferences []ServiceEndpointProjectReference `json:"projectReferences"` // Sorted by project ID
+}
+
+// EndpointAuthorization represents the authorization of a service connection
+type EndpointAuthorization struct {
+	Scheme     string            `json:"scheme"`     // e.g., Kubeconfig, ServiceAccount, ServicePrincipal, WorkloadIdentityFederation, ManagedServiceIdentity, UsernamePassword
+	Parameters map[string]string `json:"parameters"` // Keys are lowercased. In responses secret parameters and parameters without value are not returned, always present
+}
+
+// ServiceEndpointProjectReference represents a project the service connection is shared with
+type ServiceEndpointProjectReference struct {
+	ProjectID   string `json:"projectId"`      // Required
+	Name        string `json:"name,omitempty"` // Name of the service connection in the project, defaults to the name of the service connection
+	Description string `json:"description,omitempty"`
+}
+
+// ServiceEndpointRequest represents the request body for creating or updating a service connection
+type ServiceEndpointRequest struct {
+	Name              string                            `json:"name"` // Required
+	Type              string                            `json:"type"` // Required - enum: kubernetes, azurerm, generic
+	URL               string                            `json:"url"`  // Required for kubernetes and generic, defaults to https://management.azure.com/ for azurerm
+	Description       string                            `json:"description,omitempty"`
+	Authorization     EndpointAuthorization             `json:"authorization"` // Required - secret parameters are write only
+	Data              map[string]string                 `json:"data,omitempty"`
+	ProjectReferences []ServiceEndpointProjectReference `json:"projectReferences,omitempty"` // Defaults to the project in the path
+}
+
+// ServiceEndpointResponse represents the response of the service connection endpoints
+type ServiceEndpointResponse ServiceEndpoint
+
+// ---
+
+// AzureServiceEndpoint represents a service endpoint of Azure DevOps:
+// /{organization}/_apis/serviceendpoint/endpoints/{endpointId}
+type AzureServiceEndpoint struct {
+	ID                               string                                 `json:"id,omitempty"`
+	Name                             string                                 `json:"name"`
+	Type                             string                                 `json:"type"`
+	URL                              string                                 `json:"url"`
+	Description                      string                                 `json:"description"`
+	Authorization                    *AzureEndpointAuthorization            `json:"authorization"`
+	Data                             map[string]*string                     `json:"data"`
+	IsShared                         bool                                   `json:"isShared"`
+	IsReady                          bool                                   `json:"isReady"`
+	Owner                            string                                 `json:"owner,omitempty"`
+	ServiceEndpointProjectReferences []AzureServiceEndpointProjectReference `json:"serviceEndpointProjectReferences"`
+}
+
+// AzureEndpointAuthorization represents the authorization of an Azure DevOps service endpoint
+// Azure DevOps returns secret parameters as null or does not return them at all
+type AzureEndpointAuthorization struct {
+	Scheme     string             `json:"scheme"`
+	Parameters map[string]*string `json:"parameters"`
+}
+
+// AzureServiceEndpointProjectReference represents a project reference of an Azure DevOps service endpoint
+type AzureServiceEndpointProjectReference struct {
+	Name             string            `json:"name"`
+	Description      string            `json:"description"`
+	ProjectReference *ProjectReference `json:"projectReference"`
+}
+
+// ProjectReference represents a reference to a project
+type ProjectReference struct {
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+// authorizationScheme describes an authorization scheme supported by the plugin
+type authorizationScheme struct {
+	Name               string   // Name of the scheme as expected by Azure DevOps
+	RequiredParameters []string // Parameters required on creation and update
+	RequiredSecrets    []string // Secret parameters required on creation only
+}
+
+// Service connection types handled by the plugin
+const (
+	ServiceEndpointTypeKubernetes = "kubernetes"
+	ServiceEndpointTypeAzureRM    = "azurerm"
+	ServiceEndpointTypeGeneric    = "generic"
+)
+
+// DefaultAzureRMURL is the URL of Azure RM service connections when not set
+const DefaultAzureRMURL = "https://management.azure.com/"
+
+// ServiceEndpointOwner is the owner of the service connections created by the plugin
+const ServiceEndpointOwner = "library"
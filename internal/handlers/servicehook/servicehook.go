@@ -0,0 +1,417 @@
+package servicehook
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers"
+)
+
+// Handler constructors
+func GetSubscription(opts handlers.HandlerOptions) handlers.Handler {
+	return &getHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func PostSubscription(opts handlers.HandlerOptions) handlers.Handler {
+	return &postHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func PutSubscription(opts handlers.HandlerOptions) handlers.Handler {
+	return &putHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func DeleteSubscription(opts handlers.HandlerOptions) handlers.Handler {
+	return &deleteHandler{baseHandler: newBaseHandler(opts)}
+}
+
+// Interface compliance verification
+var _ handlers.Handler = &getHandler{}
+var _ handlers.Handler = &postHandler{}
+var _ handlers.Handler = &putHandler{}
+var _ handlers.Handler = &deleteHandler{}
+
+// Base handler with common functionality
+type baseHandler struct {
+	*handlers.BaseHandler
+}
+
+// Constructor for the base handler
+func newBaseHandler(opts handlers.HandlerOptions) *baseHandler {
+	return &baseHandler{BaseHandler: handlers.NewBaseHandler(opts)}
+}
+
+// Handler types embedding the base handler
+type getHandler struct {
+	*baseHandler
+}
+
+type postHandler struct {
+	*baseHandler
+}
+
+type putHandler struct {
+	*baseHandler
+}
+
+type deleteHandler struct {
+	*baseHandler
+}
+
+// resolveProjectID returns the ID of the project given by ID or name, as the subscriptions reference the project by ID
+func (h *baseHandler) resolveProjectID(organization, projectId, apiVersion, authHeader string) (string, error) {
+	if guidRegex.MatchString(projectId) {
+		return strings.ToLower(projectId), nil
+	}
+
+	url := fmt.Sprintf("https://dev.azure.com/%s/_apis/projects/%s?api-version=%s", organization, projectId, apiVersion)
+	body, err := h.DoAzureDevOpsRequest("GET", url, authHeader, nil)
+	if err != nil {
+		return "", err
+	}
+
+	var project TeamProject
+	if err := json.Unmarshal(body, &project); err != nil {
+		return "", fmt.Errorf("failed to unmarshal project: %w", err)
+	}
+	return strings.ToLower(project.ID), nil
+}
+
+// listSubscriptions returns the subscriptions of the organization with the publisher, event and consumer of the request
+func (h *baseHandler) listSubscriptions(organization string, request *SubscriptionRequest, apiVersion, authHeader string) ([]AzureSubscription, error) {
+	query := url.Values{
+		"publisherId":      {request.PublisherID},
+		"eventType":        {request.EventType},
+		"consumerId":       {request.ConsumerID},
+		"consumerActionId": {request.ConsumerActionID},
+		"api-version":      {apiVersion},
+	}
+	url := fmt.Sprintf("https://dev.azure.com/%s/_apis/hooks/subscriptions?%s", organization, query.Encode())
+
+	body, err := h.DoAzureDevOpsRequest("GET", url, authHeader, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var subscriptions AzureSubscriptionList
+	if err := json.Unmarshal(body, &subscriptions); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal subscriptions: %w", err)
+	}
+	return subscriptions.Value, nil
+}
+
+// getSubscription returns the subscription with the given ID
+// A subscription of another project is reported as not found
+func (h *baseHandler) getSubscription(organization, projectID, id, apiVersion, authHeader string) (*AzureSubscription, error) {
+	url := fmt.Sprintf("https://dev.azure.com/%s/_apis/hooks/subscriptions/%s?api-version=%s", organization, id, apiVersion)
+
+	body, err := h.DoAzureDevOpsRequest("GET", url, authHeader, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var subscription AzureSubscription
+	if err := json.Unmarshal(body, &subscription); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal subscription: %w", err)
+	}
+	if subscriptionProjectID(&subscription) != projectID {
+		h.Log.Printf("Subscription %s belongs to project %s, not to project %s", id, subscriptionProjectID(&subscription), projectID)
+		return nil, &handlers.AzureDevOpsError{StatusCode: http.StatusNotFound}
+	}
+	return &subscription, nil
+}
+
+// saveSubscription creates a subscription (POST, without ID) or replaces the subscription with the ID of the body (PUT)
+func (h *baseHandler) saveSubscription(organization string, subscription *AzureSubscription, apiVersion, authHeader string) (*AzureSubscription, error) {
+	requestBody, err := json.Marshal(subscription)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal subscription: %w", err)
+	}
+
+	method := "POST"
+	url := fmt.Sprintf("https://dev.azure.com/%s/_apis/hooks/subscriptions?api-version=%s", organization, apiVersion)
+	if subscription.ID != "" {
+		method = "PUT"
+		url = fmt.Sprintf("https://dev.azure.com/%s/_apis/hooks/subscriptions/%s?api-version=%s", organization, subscription.ID, apiVersion)
+	}
+	body, err := h.DoAzureDevOpsRequest(method, url, authHeader, requestBody)
+	if err != nil {
+		return nil, err
+	}
+
+	var saved AzureSubscription
+	if err := json.Unmarshal(body, &saved); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal subscription: %w", err)
+	}
+	return &saved, nil
+}
+
+// readSubscriptionRequest reads and validates the subscription request body
+func (h *baseHandler) readSubscriptionRequest(w http.ResponseWriter, r *http.Request) (*SubscriptionRequest, bool) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Failed to read request body")
+		return nil, false
+	}
+	var request SubscriptionRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Invalid JSON in request body")
+		return nil, false
+	}
+	if err := validateSubscriptionRequest(&request); err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid subscription: %v", err))
+		return nil, false
+	}
+	return &request, true
+}
+
+// GET handler implementation
+// @Summary Get a service hook subscription
+// @Description Get a service hook subscription of the project. Secret consumer inputs are not returned.
+// @ID get-servicehook-subscription
+// @Param organization path string true "Organization name"
+// @Param projectId path string true "Project ID or name"
+// @Param id path string true "Subscription ID"
+// @Param api-version query string true "API version (e.g., 7.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Produce json
+// @Success 200 {object} SubscriptionResponse "Subscription details"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{projectId}/hooks/subscriptions/{id} [get]
+func (h *getHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	projectId := r.PathValue("projectId")
+	id := r.PathValue("id")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, projectId, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	h.Log.Printf("Getting service hook subscription %s for project %s in organization %s", id, projectId, organization)
+
+	projectID, err := h.resolveProjectID(organization, projectId, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "get project", fmt.Sprintf("Project %s not found", projectId))
+		return
+	}
+	subscription, err := h.getSubscription(organization, projectID, id, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "get subscription", fmt.Sprintf("Subscription %s not found in project %s", id, projectId))
+		return
+	}
+
+	h.WriteObjectResponse(w, http.StatusOK, SubscriptionResponse(*mapSubscriptionFromAzure(subscription)))
+	h.Log.Printf("Successfully retrieved service hook subscription %s", id)
+}
+
+// POST handler implementation
+// @Summary Create a service hook subscription
+// @Description Create a service hook subscription for the project. If a subscription of the project with the same publisher, event type, consumer and consumer action already exists, it is updated if needed and 200 is returned instead. Secret consumer inputs are write only.
+// @ID post-servicehook-subscription
+// @Param organization path string true "Organization name"
+// @Param projectId path string true "Project ID or name"
+// @Param api-version query string true "API version (e.g., 7.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Param subscription body SubscriptionRequest true "Subscription creation request body"
+// @Accept json
+// @Produce json
+// @Success 200 {object} SubscriptionResponse "Existing subscription"
+// @Success 201 {object} SubscriptionResponse "Created subscription"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 409 "Conflict"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{projectId}/hooks/subscriptions [post]
+func (h *postHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	projectId := r.PathValue("projectId")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, projectId, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	request, ok := h.readSubscriptionRequest(w, r)
+	if !ok {
+		return
+	}
+
+	h.Log.Printf("Creating service hook subscription %s/%s -> %s/%s for project %s in organization %s", request.PublisherID, request.EventType, request.ConsumerID, request.ConsumerActionID, projectId, organization)
+
+	notFoundMessage := fmt.Sprintf("Project %s not found", projectId)
+	projectID, err := h.resolveProjectID(organization, projectId, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "get project", notFoundMessage)
+		return
+	}
+	subscriptions, err := h.listSubscriptions(organization, request, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "list subscriptions", notFoundMessage)
+		return
+	}
+
+	matches := []AzureSubscription{}
+	for _, subscription := range subscriptions {
+		if subscriptionProjectID(&subscription) == projectID && matchesRequest(&subscription, request) {
+			matches = append(matches, subscription)
+		}
+	}
+
+	if len(matches) > 1 {
+		h.WriteErrorResponse(w, http.StatusConflict, fmt.Sprintf("Conflict: %d subscriptions of project %s match publisher %s, event type %s and consumer %s/%s, update them by ID", len(matches), projectId, request.PublisherID, request.EventType, request.ConsumerID, request.ConsumerActionID))
+		return
+	}
+	if len(matches) == 0 {
+		created, err := h.saveSubscription(organization, buildAzureSubscription(request, projectID, nil), apiVersion, authHeader)
+		if err != nil {
+			h.WriteAzureDevOpsError(w, err, "create subscription", notFoundMessage)
+			return
+		}
+		h.WriteObjectResponse(w, http.StatusCreated, SubscriptionResponse(*mapSubscriptionFromAzure(created)))
+		h.Log.Printf("Successfully created service hook subscription %s", created.ID)
+		return
+	}
+
+	existing := &matches[0]
+	h.Log.Printf("Service hook subscription already exists with ID %s", existing.ID)
+	if needsUpdate(existing, request) {
+		existing, err = h.saveSubscription(organization, buildAzureSubscription(request, projectID, existing), apiVersion, authHeader)
+		if err != nil {
+			h.WriteAzureDevOpsError(w, err, "update subscription", fmt.Sprintf("Subscription %s not found in project %s", matches[0].ID, projectId))
+			return
+		}
+		h.Log.Printf("Updated service hook subscription %s", existing.ID)
+	}
+
+	h.WriteObjectResponse(w, http.StatusOK, SubscriptionResponse(*mapSubscriptionFromAzure(existing)))
+}
+
+// PUT handler implementation
+// @Summary Update a service hook subscription
+// @Description Replace a service hook subscription of the project. Secret consumer inputs not sent keep their current value.
+// @ID put-servicehook-subscription
+// @Param organization path string true "Organization name"
+// @Param projectId path string true "Project ID or name"
+// @Param id path string true "Subscription ID"
+// @Param api-version query string true "API version (e.g., 7.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Param subscription body SubscriptionRequest true "Subscription update request body"
+// @Accept json
+// @Produce json
+// @Success 200 {object} SubscriptionResponse "Updated subscription"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{projectId}/hooks/subscriptions/{id} [put]
+func (h *putHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	projectId := r.PathValue("projectId")
+	id := r.PathValue("id")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, projectId, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	request, ok := h.readSubscriptionRequest(w, r)
+	if !ok {
+		return
+	}
+
+	h.Log.Printf("Updating service hook subscription %s for project %s in organization %s", id, projectId, organization)
+
+	projectID, err := h.resolveProjectID(organization, projectId, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "get project", fmt.Sprintf("Project %s not found", projectId))
+		return
+	}
+	notFoundMessage := fmt.Sprintf("Subscription %s not found in project %s", id, projectId)
+	current, err := h.getSubscription(organization, projectID, id, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "get subscription", notFoundMessage)
+		return
+	}
+
+	updated, err := h.saveSubscription(organization, buildAzureSubscription(request, projectID, current), apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "update subscription", notFoundMessage)
+		return
+	}
+
+	h.WriteObjectResponse(w, http.StatusOK, SubscriptionResponse(*mapSubscriptionFromAzure(updated)))
+	h.Log.Printf("Successfully updated service hook subscription %s", id)
+}
+
+// DELETE handler implementation
+// @Summary Delete a service hook subscription
+// @Description Delete a service hook subscription of the project
+// @ID delete-servicehook-subscription
+// @Param organization path string true "Organization name"
+// @Param projectId path string true "Project ID or name"
+// @Param id path string true "Subscription ID"
+// @Param api-version query string true "API version (e.g., 7.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Success 204 "No Content"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{projectId}/hooks/subscriptions/{id} [delete]
+func (h *deleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	projectId := r.PathValue("projectId")
+	id := r.PathValue("id")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, projectId, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	h.Log.Printf("Deleting service hook subscription %s for project %s in organization %s", id, projectId, organization)
+
+	projectID, err := h.resolveProjectID(organization, projectId, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "get project", fmt.Sprintf("Project %s not found", projectId))
+		return
+	}
+	notFoundMessage := fmt.Sprintf("Subscription %s not found in project %s", id, projectId)
+	subscription, err := h.getSubscription(organization, projectID, id, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "get subscription", notFoundMessage)
+		return
+	}
+
+	url := fmt.Sprintf("https://dev.azure.com/%s/_apis/hooks/subscriptions/%s?api-version=%s", organization, subscription.ID, apiVersion)
+	if _, err := h.DoAzureDevOpsRequest("DELETE", url, authHeader, nil); err != nil {
+		h.WriteAzureDevOpsError(w, err, "delete subscription", notFoundMessage)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	h.Log.Printf("Successfully deleted service hook subscription %s", id)
+}
@@ -0,0 +1,456 @@
+package servicehook
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers"
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/handlerstest"
+	"github.com/rs/zerolog"
+)
+
+// newTestBaseHandler creates a base handler for testing with a mock client
+func newTestBaseHandler(mockClient *handlerstest.MockHTTPClient) *baseHandler {
+	return newBaseHandler(handlerstest.NewHandlerOptions(mockClient))
+}
+
+// Test data constants
+const (
+	testOrg            = "test-org"
+	testProject        = "Platform"
+	testProjectID      = "5a7e2c1d-3b4f-4e6a-8c9d-0e1f2a3b4c5d"
+	testSubscriptionID = "9f8e7d6c-5b4a-4392-8172-6a5b4c3d2e1f"
+	testAPIVersion     = "7.1"
+	testUsername       = "user"
+	testPassword       = "token"
+)
+
+var (
+	projectURL      = "https://dev.azure.com/" + testOrg + "/_apis/projects/" + testProject + "?api-version=" + testAPIVersion
+	subscriptionURL = "https://dev.azure.com/" + testOrg + "/_apis/hooks/subscriptions/" + testSubscriptionID + "?api-version=" + testAPIVersion
+	createURL       = "https://dev.azure.com/" + testOrg + "/_apis/hooks/subscriptions?api-version=" + testAPIVersion
+	listURL         = "https://dev.azure.com/" + testOrg + "/_apis/hooks/subscriptions?api-version=" + testAPIVersion + "&consumerActionId=httpRequest&consumerId=webHooks&eventType=git.push&publisherId=tfs"
+	projectResp     = `{"id":"` + strings.ToUpper(testProjectID) + `","name":"Platform"}`
+
+	// webHookRequest is the request of a web hook notified of the pushes to the main branch of a repository
+	webHookRequest = `{"publisherId":"tfs","eventType":"git.push","resourceVersion":"1.0","consumerId":"webHooks","consumerActionId":"httpRequest",` +
+		`"publisherInputs":{"repository":"platform-api","branch":"main"},"consumerInputs":{"url":"https://hooks.example.com/push","basicAuthUsername":"platform","basicAuthPassword":"s3cret"}}`
+)
+
+// subscriptionResp returns a web hook subscription of the project with the given ID, repository and URL, the password is masked
+func subscriptionResp(id, projectID, repository, hookURL string) string {
+	return `{"id":"` + id + `","status":"enabled","publisherId":"tfs","eventType":"git.push","eventDescription":"Repository platform-api, branch main","resourceVersion":"1.0",` +
+		`"consumerId":"webHooks","consumerActionId":"httpRequest","actionDescription":"To host hooks.example.com",` +
+		`"publisherInputs":{"branch":"main","projectId":"` + projectID + `","repository":"` + repository + `","tfsSubscriptionId":"e7c1d2f3-0000-4000-8000-000000000001"},` +
+		`"consumerInputs":{"basicAuthPassword":"********","basicAuthUsername":"platform","url":"` + hookURL + `"}}`
+}
+
+// subscriptionsResp returns a list of subscriptions
+func subscriptionsResp(subscriptions ...string) string {
+	return `{"count":` + strconv.Itoa(len(subscriptions)) + `,"value":[` + strings.Join(subscriptions, ",") + `]}`
+}
+
+// subscriptionTestCase is a test case for the handlers of this package
+type subscriptionTestCase struct {
+	name                 string
+	pathValues           map[string]string
+	query                url.Values
+	noAuth               bool
+	body                 string
+	setupMock            func(*handlerstest.MockHTTPClient)
+	expectedStatus       int
+	expectedBody         string
+	expectedBodyContains string
+	expectedRequestCount int
+	verify               func(t *testing.T, mockClient *handlerstest.MockHTTPClient)
+}
+
+// subscriptionPathValues returns the path values of the subscription endpoints
+func subscriptionPathValues(id string) map[string]string {
+	return map[string]string{"organization": testOrg, "projectId": testProject, "id": id}
+}
+
+// runSubscriptionTests runs the given test cases against the handler built by newHandler
+func runSubscriptionTests(t *testing.T, method string, newHandler func(*baseHandler) http.Handler, tests []subscriptionTestCase) {
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := handlerstest.NewMockHTTPClient()
+			if tt.setupMock != nil {
+				tt.setupMock(mockClient)
+			}
+			handler := newHandler(newTestBaseHandler(mockClient))
+
+			target := "/api/test"
+			if len(tt.query) > 0 {
+				target += "?" + tt.query.Encode()
+			}
+			var body io.Reader
+			if tt.body != "" {
+				body = strings.NewReader(tt.body)
+			}
+			req := httptest.NewRequest(method, target, body)
+			for key, value := range tt.pathValues {
+				req.SetPathValue(key, value)
+			}
+			if !tt.noAuth {
+				req.SetBasicAuth(testUsername, testPassword)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("Status code = %d, want %d. Body: %s", rr.Code, tt.expectedStatus, rr.Body.String())
+			}
+			if tt.expectedBody != "" && rr.Body.String() != tt.expectedBody {
+				t.Errorf("Body = %s, want %s", rr.Body.String(), tt.expectedBody)
+			}
+			if tt.expectedBodyContains != "" && !strings.Contains(rr.Body.String(), tt.expectedBodyContains) {
+				t.Errorf("Body = %s, want it to contain %s", rr.Body.String(), tt.expectedBodyContains)
+			}
+			if mockClient.RequestCount() != tt.expectedRequestCount {
+				t.Errorf("Request count = %d, want %d", mockClient.RequestCount(), tt.expectedRequestCount)
+			}
+			if tt.verify != nil {
+				tt.verify(t, mockClient)
+			}
+		})
+	}
+}
+
+// apiVersionQuery returns the query with the test API version
+func apiVersionQuery() url.Values {
+	return url.Values{"api-version": {testAPIVersion}}
+}
+
+// requestBody returns the body of the n-th request sent to Azure DevOps
+func requestBody(t *testing.T, mockClient *handlerstest.MockHTTPClient, n int) string {
+	t.Helper()
+	if len(mockClient.Requests) <= n || mockClient.Requests[n].Body == nil {
+		t.Fatalf("request %d has no body", n)
+	}
+	body, err := io.ReadAll(mockClient.Requests[n].Body)
+	if err != nil {
+		t.Fatalf("failed to read request %d body: %v", n, err)
+	}
+	return string(body)
+}
+
+// containsRequest reports whether a request was sent with the given method and URL
+func containsRequest(mockClient *handlerstest.MockHTTPClient, method, url string) bool {
+	for _, req := range mockClient.Requests {
+		if req.Method+" "+req.URL.String() == method+" "+url {
+			return true
+		}
+	}
+	return false
+}
+
+// Test constructor functions
+func TestServiceHookConstructors(t *testing.T) {
+	client := &http.Client{}
+	logger := zerolog.New(os.Stdout).With().Timestamp().Logger()
+	opts := handlers.HandlerOptions{
+		Client: client,
+		Log:    &logger,
+	}
+
+	constructors := map[string]func(handlers.HandlerOptions) handlers.Handler{
+		"GetSubscription":    GetSubscription,
+		"PostSubscription":   PostSubscription,
+		"PutSubscription":    PutSubscription,
+		"DeleteSubscription": DeleteSubscription,
+	}
+	for name, constructor := range constructors {
+		if h := constructor(opts); h == nil {
+			t.Fatalf("%s should return a non-nil handler", name)
+		}
+	}
+}
+
+// Test GET handler
+func TestGetHandler_ServeHTTP(t *testing.T) {
+	runSubscriptionTests(t, "GET", func(b *baseHandler) http.Handler { return &getHandler{baseHandler: b} }, []subscriptionTestCase{
+		{
+			name:       "subscription without secret inputs",
+			pathValues: subscriptionPathValues(testSubscriptionID),
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(projectURL, http.StatusOK, projectResp)
+				m.SetResponse(subscriptionURL, http.StatusOK, subscriptionResp(strings.ToUpper(testSubscriptionID), testProjectID, "platform-api", "https://hooks.example.com/push"))
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"id":"` + testSubscriptionID + `","status":"enabled","projectId":"` + testProjectID + `","publisherId":"tfs","eventType":"git.push","resourceVersion":"1.0","consumerId":"webHooks","consumerActionId":"httpRequest",` +
+				`"publisherInputs":{"branch":"main","repository":"platform-api"},"consumerInputs":{"basicAuthUsername":"platform","url":"https://hooks.example.com/push"},` +
+				`"eventDescription":"Repository platform-api, branch main","actionDescription":"To host hooks.example.com"}`,
+			expectedRequestCount: 2,
+		},
+		{
+			name:       "subscription of another project",
+			pathValues: subscriptionPathValues(testSubscriptionID),
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(projectURL, http.StatusOK, projectResp)
+				m.SetResponse(subscriptionURL, http.StatusOK, subscriptionResp(testSubscriptionID, "0a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d", "platform-api", "https://hooks.example.com/push"))
+			},
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Subscription " + testSubscriptionID + " not found in project Platform",
+			expectedRequestCount: 2,
+		},
+		{
+			name:       "subscription not found",
+			pathValues: subscriptionPathValues(testSubscriptionID),
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(projectURL, http.StatusOK, projectResp)
+			},
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Subscription " + testSubscriptionID + " not found in project Platform",
+			expectedRequestCount: 2,
+		},
+		{
+			name:                 "project not found",
+			pathValues:           subscriptionPathValues(testSubscriptionID),
+			query:                apiVersionQuery(),
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Project Platform not found",
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "missing basic auth",
+			pathValues:           subscriptionPathValues(testSubscriptionID),
+			query:                apiVersionQuery(),
+			noAuth:               true,
+			expectedStatus:       http.StatusUnauthorized,
+			expectedBody:         "Request rejected due to missing or invalid Basic authentication",
+			expectedRequestCount: 0,
+		},
+	})
+}
+
+// Test POST handler
+func TestPostHandler_ServeHTTP(t *testing.T) {
+	otherProjectResp := subscriptionResp("11111111-2222-4333-8444-555555555555", "0a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d", "platform-api", "https://hooks.example.com/push")
+
+	runSubscriptionTests(t, "POST", func(b *baseHandler) http.Handler { return &postHandler{baseHandler: b} }, []subscriptionTestCase{
+		{
+			name:       "subscription created",
+			pathValues: subscriptionPathValues(""),
+			query:      apiVersionQuery(),
+			body:       webHookRequest,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(projectURL, http.StatusOK, projectResp)
+				m.SetResponse(listURL, http.StatusOK, subscriptionsResp(otherProjectResp))
+				m.SetMethodResponse("POST", createURL, http.StatusOK, subscriptionResp(testSubscriptionID, testProjectID, "platform-api", "https://hooks.example.com/push"))
+			},
+			expectedStatus:       http.StatusCreated,
+			expectedBodyContains: `"consumerInputs":{"basicAuthUsername":"platform","url":"https://hooks.example.com/push"}`,
+			expectedRequestCount: 3,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				want := `{"publisherId":"tfs","eventType":"git.push","resourceVersion":"1.0","consumerId":"webHooks","consumerActionId":"httpRequest",` +
+					`"publisherInputs":{"branch":"main","projectId":"` + testProjectID + `","repository":"platform-api"},` +
+					`"consumerInputs":{"basicAuthPassword":"s3cret","basicAuthUsername":"platform","url":"https://hooks.example.com/push"}}`
+				if got := requestBody(t, m, 2); got != want {
+					t.Errorf("Request body = %s, want %s", got, want)
+				}
+			},
+		},
+		{
+			name:       "existing subscription updated when secrets are sent",
+			pathValues: subscriptionPathValues(""),
+			query:      apiVersionQuery(),
+			body:       webHookRequest,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(projectURL, http.StatusOK, projectResp)
+				m.SetResponse(listURL, http.StatusOK, subscriptionsResp(otherProjectResp, subscriptionResp(testSubscriptionID, testProjectID, "platform-api", "https://hooks.example.com/push")))
+				m.SetMethodResponse("PUT", subscriptionURL, http.StatusOK, subscriptionResp(testSubscriptionID, testProjectID, "platform-api", "https://hooks.example.com/push"))
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `"id":"` + testSubscriptionID + `"`,
+			expectedRequestCount: 3,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				if got := requestBody(t, m, 2); !strings.Contains(got, `"id":"`+testSubscriptionID+`"`) || !strings.Contains(got, `"basicAuthPassword":"s3cret"`) {
+					t.Errorf("Request body = %s", got)
+				}
+			},
+		},
+		{
+			name:       "existing subscription with another URL",
+			pathValues: subscriptionPathValues(""),
+			query:      apiVersionQuery(),
+			body:       `{"publisherId":"tfs","eventType":"git.push","consumerId":"webHooks","consumerActionId":"httpRequest","publisherInputs":{"repository":"platform-api","branch":"main"},"consumerInputs":{"url":"https://hooks.example.com/v2/push","basicAuthUsername":"platform"}}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(projectURL, http.StatusOK, projectResp)
+				m.SetResponse(listURL, http.StatusOK, subscriptionsResp(subscriptionResp(testSubscriptionID, testProjectID, "platform-api", "https://hooks.example.com/push")))
+				m.SetMethodResponse("PUT", subscriptionURL, http.StatusOK, subscriptionResp(testSubscriptionID, testProjectID, "platform-api", "https://hooks.example.com/v2/push"))
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `"url":"https://hooks.example.com/v2/push"`,
+			expectedRequestCount: 3,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				want := `"consumerInputs":{"basicAuthPassword":"********","basicAuthUsername":"platform","url":"https://hooks.example.com/v2/push"}`
+				if got := requestBody(t, m, 2); !strings.Contains(got, want) {
+					t.Errorf("Request body = %s, want the masked password kept", got)
+				}
+			},
+		},
+		{
+			name:       "existing subscription up to date",
+			pathValues: subscriptionPathValues(""),
+			query:      apiVersionQuery(),
+			body:       `{"publisherId":"tfs","eventType":"git.push","resourceVersion":"1.0","consumerId":"webHooks","consumerActionId":"httpRequest","publisherInputs":{"repository":"platform-api","branch":"main","pushedBy":""},"consumerInputs":{"url":"https://hooks.example.com/push","basicAuthUsername":"platform"}}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(projectURL, http.StatusOK, projectResp)
+				m.SetResponse(listURL, http.StatusOK, subscriptionsResp(subscriptionResp(testSubscriptionID, testProjectID, "platform-api", "https://hooks.example.com/push")))
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `"id":"` + testSubscriptionID + `"`,
+			expectedRequestCount: 2,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				if containsRequest(m, "PUT", subscriptionURL) {
+					t.Errorf("Subscription up to date should not be updated")
+				}
+			},
+		},
+		{
+			name:       "several subscriptions match",
+			pathValues: subscriptionPathValues(""),
+			query:      apiVersionQuery(),
+			body:       webHookRequest,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(projectURL, http.StatusOK, projectResp)
+				m.SetResponse(listURL, http.StatusOK, subscriptionsResp(
+					subscriptionResp(testSubscriptionID, testProjectID, "platform-api", "https://hooks.example.com/push"),
+					subscriptionResp("11111111-2222-4333-8444-555555555555", testProjectID, "platform-web", "https://hooks.example.com/push"),
+				))
+			},
+			expectedStatus:       http.StatusConflict,
+			expectedBody:         "Conflict: 2 subscriptions of project Platform match publisher tfs, event type git.push and consumer webHooks/httpRequest, update them by ID",
+			expectedRequestCount: 2,
+		},
+		{
+			name:                 "project not found",
+			pathValues:           subscriptionPathValues(""),
+			query:                apiVersionQuery(),
+			body:                 webHookRequest,
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Project Platform not found",
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "project ID in the publisher inputs",
+			pathValues:           subscriptionPathValues(""),
+			query:                apiVersionQuery(),
+			body:                 `{"publisherId":"tfs","eventType":"git.push","consumerId":"webHooks","consumerActionId":"httpRequest","publisherInputs":{"projectId":"` + testProjectID + `"}}`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid subscription: publisherInputs.projectId is set from the path and cannot be set",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "missing consumer action",
+			pathValues:           subscriptionPathValues(""),
+			query:                apiVersionQuery(),
+			body:                 `{"publisherId":"tfs","eventType":"git.push","consumerId":"webHooks"}`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid subscription: consumerActionId is required",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "invalid JSON",
+			pathValues:           subscriptionPathValues(""),
+			query:                apiVersionQuery(),
+			body:                 `{"publisherId":`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid JSON in request body",
+			expectedRequestCount: 0,
+		},
+	})
+}
+
+// Test PUT handler
+func TestPutHandler_ServeHTTP(t *testing.T) {
+	runSubscriptionTests(t, "PUT", func(b *baseHandler) http.Handler { return &putHandler{baseHandler: b} }, []subscriptionTestCase{
+		{
+			name:       "subscription updated keeping the password",
+			pathValues: map[string]string{"organization": testOrg, "projectId": strings.ToUpper(testProjectID), "id": testSubscriptionID},
+			query:      apiVersionQuery(),
+			body:       `{"publisherId":"tfs","eventType":"git.push","consumerId":"webHooks","consumerActionId":"httpRequest","publisherInputs":{"repository":"platform-web"},"consumerInputs":{"url":"https://hooks.example.com/push"}}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(subscriptionURL, http.StatusOK, subscriptionResp(testSubscriptionID, testProjectID, "platform-api", "https://hooks.example.com/push"))
+				m.SetMethodResponse("PUT", subscriptionURL, http.StatusOK, subscriptionResp(testSubscriptionID, testProjectID, "platform-web", "https://hooks.example.com/push"))
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `"repository":"platform-web"`,
+			expectedRequestCount: 2,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				want := `{"id":"` + testSubscriptionID + `","publisherId":"tfs","eventType":"git.push","consumerId":"webHooks","consumerActionId":"httpRequest",` +
+					`"publisherInputs":{"projectId":"` + testProjectID + `","repository":"platform-web"},"consumerInputs":{"basicAuthPassword":"********","url":"https://hooks.example.com/push"}}`
+				if got := requestBody(t, m, 1); got != want {
+					t.Errorf("Request body = %s, want %s", got, want)
+				}
+			},
+		},
+		{
+			name:       "subscription not found",
+			pathValues: subscriptionPathValues(testSubscriptionID),
+			query:      apiVersionQuery(),
+			body:       webHookRequest,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(projectURL, http.StatusOK, projectResp)
+			},
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Subscription " + testSubscriptionID + " not found in project Platform",
+			expectedRequestCount: 2,
+		},
+		{
+			name:                 "masked secret",
+			pathValues:           subscriptionPathValues(testSubscriptionID),
+			query:                apiVersionQuery(),
+			body:                 `{"publisherId":"tfs","eventType":"git.push","consumerId":"webHooks","consumerActionId":"httpRequest","consumerInputs":{"basicAuthPassword":"********"}}`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid subscription: consumerInputs.basicAuthPassword cannot be the masked value ********",
+			expectedRequestCount: 0,
+		},
+	})
+}
+
+// Test DELETE handler
+func TestDeleteHandler_ServeHTTP(t *testing.T) {
+	runSubscriptionTests(t, "DELETE", func(b *baseHandler) http.Handler { return &deleteHandler{baseHandler: b} }, []subscriptionTestCase{
+		{
+			name:       "subscription deleted",
+			pathValues: subscriptionPathValues(testSubscriptionID),
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(projectURL, http.StatusOK, projectResp)
+				m.SetResponse(subscriptionURL, http.StatusOK, subscriptionResp(testSubscriptionID, testProjectID, "platform-api", "https://hooks.example.com/push"))
+				m.SetMethodResponse("DELETE", subscriptionURL, http.StatusNoContent, "")
+			},
+			expectedStatus:       http.StatusNoContent,
+			expectedRequestCount: 3,
+		},
+		{
+			name:       "subscription of another project",
+			pathValues: subscriptionPathValues(testSubscriptionID),
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(projectURL, http.StatusOK, projectResp)
+				m.SetResponse(subscriptionURL, http.StatusOK, subscriptionResp(testSubscriptionID, "0a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d", "platform-api", "https://hooks.example.com/push"))
+			},
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Subscription " + testSubscriptionID + " not found in project Platform",
+			expectedRequestCount: 2,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				if containsRequest(m, "DELETE", subscriptionURL) {
+					t.Errorf("Subscription of another project should not be deleted")
+				}
+			},
+		},
+	})
+}
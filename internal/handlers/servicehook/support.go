@@ -0,0 +1,194 @@
+package servicehook
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var guidRegex = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// secretConsumerInputs lists the consumer inputs that are write only (lowercased)
+var secretConsumerInputs = map[string]bool{
+	"accountkey":        true,
+	"basicauthpassword": true,
+	"connectionstring":  true,
+	"httpheaders":       true,
+	"password":          true,
+	"sastoken":          true,
+}
+
+// ignoredPublisherInputs lists the publisher inputs set by the plugin or by Azure DevOps, not compared with the request
+var ignoredPublisherInputs = map[string]bool{
+	"projectId":         true,
+	"tfsSubscriptionId": true,
+}
+
+// isSecretInput reports whether the consumer input is write only
+func isSecretInput(name string) bool {
+	return secretConsumerInputs[strings.ToLower(name)]
+}
+
+// validateSubscriptionRequest checks the fields of a subscription request and drops the inputs without value
+func validateSubscriptionRequest(request *SubscriptionRequest) error {
+	request.PublisherID = strings.TrimSpace(request.PublisherID)
+	request.EventType = strings.TrimSpace(request.EventType)
+	request.ConsumerID = strings.TrimSpace(request.ConsumerID)
+	request.ConsumerActionID = strings.TrimSpace(request.ConsumerActionID)
+	switch {
+	case request.PublisherID == "":
+		return fmt.Errorf("publisherId is required")
+	case request.EventType == "":
+		return fmt.Errorf("eventType is required")
+	case request.ConsumerID == "":
+		return fmt.Errorf("consumerId is required")
+	case request.ConsumerActionID == "":
+		return fmt.Errorf("consumerActionId is required")
+	}
+
+	for name := range request.PublisherInputs {
+		if strings.EqualFold(name, "projectId") {
+			return fmt.Errorf("publisherInputs.projectId is set from the path and cannot be set")
+		}
+	}
+	for name, value := range request.ConsumerInputs {
+		if value == MaskedInputValue {
+			return fmt.Errorf("consumerInputs.%s cannot be the masked value %s", name, MaskedInputValue)
+		}
+	}
+
+	request.PublisherInputs = withoutEmptyInputs(request.PublisherInputs)
+	request.ConsumerInputs = withoutEmptyInputs(request.ConsumerInputs)
+	return nil
+}
+
+// withoutEmptyInputs returns the inputs with a value, always non-nil
+func withoutEmptyInputs(inputs map[string]string) map[string]string {
+	filtered := map[string]string{}
+	for name, value := range inputs {
+		if value != "" {
+			filtered[name] = value
+		}
+	}
+	return filtered
+}
+
+// subscriptionProjectID returns the lowercased ID of the project the subscription belongs to
+func subscriptionProjectID(subscription *AzureSubscription) string {
+	return strings.ToLower(subscription.PublisherInputs["projectId"])
+}
+
+// matchesRequest reports whether the subscription has the publisher, event and consumer of the request
+func matchesRequest(subscription *AzureSubscription, request *SubscriptionRequest) bool {
+	return strings.EqualFold(subscription.PublisherID, request.PublisherID) &&
+		strings.EqualFold(subscription.EventType, request.EventType) &&
+		strings.EqualFold(subscription.ConsumerID, request.ConsumerID) &&
+		strings.EqualFold(subscription.ConsumerActionID, request.ConsumerActionID)
+}
+
+// needsUpdate reports whether the subscription differs from the request
+// Secret inputs cannot be compared, as Azure DevOps masks them: the subscription is updated whenever one is sent
+func needsUpdate(subscription *AzureSubscription, request *SubscriptionRequest) bool {
+	if request.ResourceVersion != "" && request.ResourceVersion != subscription.ResourceVersion {
+		return true
+	}
+
+	publisherInputs := map[string]string{}
+	for name, value := range withoutEmptyInputs(subscription.PublisherInputs) {
+		if !ignoredPublisherInputs[name] {
+			publisherInputs[name] = value
+		}
+	}
+	if !sameInputs(publisherInputs, request.PublisherInputs) {
+		return true
+	}
+
+	for name := range request.ConsumerInputs {
+		if isSecretInput(name) {
+			return true
+		}
+	}
+	return !sameInputs(publicConsumerInputs(subscription.ConsumerInputs), request.ConsumerInputs)
+}
+
+// sameInputs reports whether both inputs have the same names and values
+func sameInputs(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for name, value := range a {
+		if other, ok := b[name]; !ok || other != value {
+			return false
+		}
+	}
+	return true
+}
+
+// publicConsumerInputs returns the consumer inputs without the secret inputs and the values masked by Azure DevOps
+func publicConsumerInputs(inputs map[string]string) map[string]string {
+	public := map[string]string{}
+	for name, value := range inputs {
+		if isSecretInput(name) || value == "" || value == MaskedInputValue {
+			continue
+		}
+		public[name] = value
+	}
+	return public
+}
+
+// buildAzureSubscription converts a validated subscription request to the Azure DevOps request body
+// current is the subscription being updated, nil on creation. Its secret inputs not sent in the request are sent back masked,
+// so that Azure DevOps keeps their value
+func buildAzureSubscription(request *SubscriptionRequest, projectID string, current *AzureSubscription) *AzureSubscription {
+	subscription := &AzureSubscription{
+		PublisherID:      request.PublisherID,
+		EventType:        request.EventType,
+		ResourceVersion:  request.ResourceVersion,
+		ConsumerID:       request.ConsumerID,
+		ConsumerActionID: request.ConsumerActionID,
+		PublisherInputs:  map[string]string{"projectId": projectID},
+		ConsumerInputs:   map[string]string{},
+	}
+	for name, value := range request.PublisherInputs {
+		subscription.PublisherInputs[name] = value
+	}
+	for name, value := range request.ConsumerInputs {
+		subscription.ConsumerInputs[name] = value
+	}
+
+	if current != nil {
+		subscription.ID = current.ID
+		for name, value := range current.ConsumerInputs {
+			if _, sent := subscription.ConsumerInputs[name]; !sent && isSecretInput(name) && value != "" {
+				subscription.ConsumerInputs[name] = value
+			}
+		}
+	}
+
+	return subscription
+}
+
+// mapSubscriptionFromAzure converts an Azure DevOps subscription to the subscription returned by the plugin
+// Secret consumer inputs and values masked by Azure DevOps are not returned
+func mapSubscriptionFromAzure(subscription *AzureSubscription) *Subscription {
+	mapped := &Subscription{
+		ID:                strings.ToLower(subscription.ID),
+		Status:            subscription.Status,
+		ProjectID:         subscriptionProjectID(subscription),
+		PublisherID:       subscription.PublisherID,
+		EventType:         subscription.EventType,
+		ResourceVersion:   subscription.ResourceVersion,
+		ConsumerID:        subscription.ConsumerID,
+		ConsumerActionID:  subscription.ConsumerActionID,
+		PublisherInputs:   map[string]string{},
+		ConsumerInputs:    publicConsumerInputs(subscription.ConsumerInputs),
+		EventDescription:  subscription.EventDescription,
+		ActionDescription: subscription.ActionDescription,
+	}
+	for name, value := range withoutEmptyInputs(subscription.PublisherInputs) {
+		if !ignoredPublisherInputs[name] {
+			mapped.PublisherInputs[name] = value
+		}
+	}
+	return mapped
+}
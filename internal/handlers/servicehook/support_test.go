@@ -0,0 +1,80 @@
+package servicehook
+
+import (
+	"testing"
+)
+
+func TestNeedsUpdate(t *testing.T) {
+	current := &AzureSubscription{
+		PublisherID:      "tfs",
+		EventType:        "git.push",
+		ResourceVersion:  "1.0",
+		ConsumerID:       "webHooks",
+		ConsumerActionID: "httpRequest",
+		PublisherInputs:  map[string]string{"projectId": "p", "tfsSubscriptionId": "s", "repository": "platform-api", "branch": ""},
+		ConsumerInputs:   map[string]string{"url": "https://hooks.example.com", "basicAuthPassword": MaskedInputValue},
+	}
+
+	tests := []struct {
+		name     string
+		request  SubscriptionRequest
+		expected bool
+	}{
+		{name: "same inputs", request: SubscriptionRequest{PublisherInputs: map[string]string{"repository": "platform-api"}, ConsumerInputs: map[string]string{"url": "https://hooks.example.com"}}},
+		{name: "resource version not set", request: SubscriptionRequest{ResourceVersion: "", PublisherInputs: map[string]string{"repository": "platform-api"}, ConsumerInputs: map[string]string{"url": "https://hooks.example.com"}}},
+		{name: "other resource version", request: SubscriptionRequest{ResourceVersion: "2.0", PublisherInputs: map[string]string{"repository": "platform-api"}, ConsumerInputs: map[string]string{"url": "https://hooks.example.com"}}, expected: true},
+		{name: "filter removed", request: SubscriptionRequest{PublisherInputs: map[string]string{}, ConsumerInputs: map[string]string{"url": "https://hooks.example.com"}}, expected: true},
+		{name: "other URL", request: SubscriptionRequest{PublisherInputs: map[string]string{"repository": "platform-api"}, ConsumerInputs: map[string]string{"url": "https://hooks.example.com/v2"}}, expected: true},
+		{name: "secret sent", request: SubscriptionRequest{PublisherInputs: map[string]string{"repository": "platform-api"}, ConsumerInputs: map[string]string{"url": "https://hooks.example.com", "BasicAuthPassword": "s3cret"}}, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := needsUpdate(current, &tt.request); got != tt.expected {
+				t.Errorf("needsUpdate() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMapSubscriptionFromAzure(t *testing.T) {
+	subscription := mapSubscriptionFromAzure(&AzureSubscription{
+		ID:              "9F8E7D6C-5B4A-4392-8172-6A5B4C3D2E1F",
+		PublisherInputs: map[string]string{"projectId": "5A7E2C1D-3B4F-4E6A-8C9D-0E1F2A3B4C5D", "repository": "platform-api", "pushedBy": ""},
+		ConsumerInputs:  map[string]string{"url": "https://hooks.example.com", "httpHeaders": "Authorization: Bearer token", "accountName": MaskedInputValue},
+	})
+
+	if subscription.ID != "9f8e7d6c-5b4a-4392-8172-6a5b4c3d2e1f" || subscription.ProjectID != "5a7e2c1d-3b4f-4e6a-8c9d-0e1f2a3b4c5d" {
+		t.Errorf("IDs = %s, %s, want them lowercased", subscription.ID, subscription.ProjectID)
+	}
+	if len(subscription.PublisherInputs) != 1 || subscription.PublisherInputs["repository"] != "platform-api" {
+		t.Errorf("PublisherInputs = %v, want the repository only", subscription.PublisherInputs)
+	}
+	if len(subscription.ConsumerInputs) != 1 || subscription.ConsumerInputs["url"] != "https://hooks.example.com" {
+		t.Errorf("ConsumerInputs = %v, want the URL only", subscription.ConsumerInputs)
+	}
+}
+
+func TestBuildAzureSubscription(t *testing.T) {
+	request := &SubscriptionRequest{
+		PublisherID:      "tfs",
+		EventType:        "git.push",
+		ConsumerID:       "webHooks",
+		ConsumerActionID: "httpRequest",
+		PublisherInputs:  map[string]string{},
+		ConsumerInputs:   map[string]string{"url": "https://hooks.example.com", "httpHeaders": "X-Token: new"},
+	}
+	current := &AzureSubscription{
+		ID:             "9f8e7d6c-5b4a-4392-8172-6a5b4c3d2e1f",
+		ConsumerInputs: map[string]string{"url": "https://old.example.com", "httpHeaders": MaskedInputValue, "basicAuthPassword": MaskedInputValue, "basicAuthUsername": "platform"},
+	}
+
+	subscription := buildAzureSubscription(request, "p", current)
+	if subscription.ID != current.ID || subscription.PublisherInputs["projectId"] != "p" {
+		t.Errorf("Subscription = %+v", subscription)
+	}
+	expected := map[string]string{"url": "https://hooks.example.com", "httpHeaders": "X-Token: new", "basicAuthPassword": MaskedInputValue}
+	if !sameInputs(subscription.ConsumerInputs, expected) {
+		t.Errorf("ConsumerInputs = %v, want %v", subscription.ConsumerInputs, expected)
+	}
+}
@@ -0,0 +1,68 @@
+package servicehook
+
+// MaskedInputValue is the value Azure DevOps returns instead of the value of a secret input
+const MaskedInputValue = "********"
+
+// Subscription represents the service hook subscription returned by the plugin
+// It is built from the AzureSubscription returned by:
+// GET https://dev.azure.com/{organization}/_apis/hooks/subscriptions/{subscriptionId}
+// Secret consumer inputs are never returned
+type Subscription struct {
+	ID                string            `json:"id"`
+	Status            string            `json:"status"` // e.g., enabled, onProbation, disabledByUser, disabledBySystem
+	ProjectID         string            `json:"projectId"`
+	PublisherID       string            `json:"publisherId"`
+	EventType         string            `json:"eventType"`
+	ResourceVersion   string            `json:"resourceVersion,omitempty"`
+	ConsumerID        string            `json:"consumerId"`
+	ConsumerActionID  string            `json:"consumerActionId"`
+	PublisherInputs   map[string]string `json:"publisherInputs"` // Filters of the event, without projectId, always present
+	ConsumerInputs    map[string]string `json:"consumerInputs"`  // Without secret inputs, always present
+	EventDescription  string            `json:"eventDescription,omitempty"`
+	ActionDescription string            `json:"actionDescription,omitempty"`
+}
+
+// SubscriptionResponse represents the response of the service hook subscription endpoints
+type SubscriptionResponse Subscription
+
+// SubscriptionRequest represents the request body for creating or updating a service hook subscription
+type SubscriptionRequest struct {
+	PublisherID      string            `json:"publisherId"`               // Required, e.g., tfs
+	EventType        string            `json:"eventType"`                 // Required, e.g., git.push
+	ResourceVersion  string            `json:"resourceVersion,omitempty"` // e.g., 1.0
+	ConsumerID       string            `json:"consumerId"`                // Required, e.g., webHooks
+	ConsumerActionID string            `json:"consumerActionId"`          // Required, e.g., httpRequest
+	PublisherInputs  map[string]string `json:"publisherInputs,omitempty"` // Filters of the event (e.g., repository, branch), projectId is set from the path
+	ConsumerInputs   map[string]string `json:"consumerInputs,omitempty"`  // e.g., url - secret inputs are write only
+}
+
+// Azure DevOps API types
+
+// AzureSubscription represents a service hook subscription from:
+// GET https://dev.azure.com/{organization}/_apis/hooks/subscriptions/{subscriptionId}
+type AzureSubscription struct {
+	ID                string            `json:"id,omitempty"`
+	Status            string            `json:"status,omitempty"`
+	PublisherID       string            `json:"publisherId"`
+	EventType         string            `json:"eventType"`
+	EventDescription  string            `json:"eventDescription,omitempty"`
+	ResourceVersion   string            `json:"resourceVersion,omitempty"`
+	ConsumerID        string            `json:"consumerId"`
+	ConsumerActionID  string            `json:"consumerActionId"`
+	ActionDescription string            `json:"actionDescription,omitempty"`
+	PublisherInputs   map[string]string `json:"publisherInputs"`
+	ConsumerInputs    map[string]string `json:"consumerInputs"`
+}
+
+// AzureSubscriptionList represents the list response of the service hook subscriptions endpoint
+type AzureSubscriptionList struct {
+	Count int                 `json:"count"`
+	Value []AzureSubscription `json:"value"`
+}
+
+// TeamProject represents the project returned by:
+// GET https://dev.azure.com/{organization}/_apis/projects/{projectId}
+type TeamProject struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
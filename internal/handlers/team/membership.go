@@ -0,0 +1,95 @@
+package team
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/graphmembership"
+)
+
+// errIdentityNotFound is returned when a principal name does not match any identity
+type errIdentityNotFound struct {
+	PrincipalName string
+}
+
+func (e *errIdentityNotFound) Error() string {
+	return fmt.Sprintf("identity '%s' not found", e.PrincipalName)
+}
+
+// getTeamDescriptor returns the graph descriptor of the group backing the team
+func (h *baseHandler) getTeamDescriptor(organization, teamID, apiVersion, authHeader string) (string, error) {
+	url := fmt.Sprintf("https://vssps.dev.azure.com/%s/_apis/graph/descriptors/%s?api-version=%s", organization, teamID, apiVersion)
+
+	body, err := h.DoAzureDevOpsRequest("GET", url, authHeader, nil)
+	if err != nil {
+		return "", err
+	}
+
+	var result GraphDescriptorResult
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", fmt.Errorf("failed to unmarshal team descriptor: %w", err)
+	}
+	if result.Value == "" {
+		return "", fmt.Errorf("no descriptor returned for team %s", teamID)
+	}
+	return result.Value, nil
+}
+
+// resolvePrincipalName returns the subject descriptor of the identity with the given principal name (e.g., UPN)
+func (h *baseHandler) resolvePrincipalName(organization, principalName, apiVersion, authHeader string) (string, error) {
+	url := fmt.Sprintf("https://vssps.dev.azure.com/%s/_apis/identities?searchFilter=General&filterValue=%s&queryMembership=None&api-version=%s", organization, url.QueryEscape(principalName), apiVersion)
+
+	body, err := h.DoAzureDevOpsRequest("GET", url, authHeader, nil)
+	if err != nil {
+		return "", err
+	}
+
+	var identities IdentityList
+	if err := json.Unmarshal(body, &identities); err != nil {
+		return "", fmt.Errorf("failed to unmarshal identities: %w", err)
+	}
+	// The search also matches display names and prefixes, only exact matches are kept
+	identity := matchIdentity(identities.Value, principalName)
+	if identity == nil {
+		return "", &errIdentityNotFound{PrincipalName: principalName}
+	}
+	return identity.SubjectDescriptor, nil
+}
+
+// resolveMembers returns the descriptors of the requested members, resolving principal names through the identity lookup
+func (h *baseHandler) resolveMembers(organization string, members []TeamMemberRequest, apiVersion, authHeader string) ([]string, error) {
+	descriptors := []string{}
+	for _, member := range members {
+		if member.Descriptor != "" {
+			descriptors = append(descriptors, member.Descriptor)
+			continue
+		}
+		descriptor, err := h.resolvePrincipalName(organization, member.PrincipalName, apiVersion, authHeader)
+		if err != nil {
+			return nil, err
+		}
+		descriptors = append(descriptors, descriptor)
+	}
+	return descriptors, nil
+}
+
+// memberships returns the graph membership helper sending its requests through this handler
+// The members of a team are the members of the graph group backing it
+func (h *baseHandler) memberships() *graphmembership.Memberships {
+	return graphmembership.New(h.BaseHandler)
+}
+
+// getTeamMembers returns the members of the team sorted by descriptor
+func (h *baseHandler) getTeamMembers(organization, teamDescriptor, apiVersion, authHeader string) ([]TeamMember, error) {
+	memberships := h.memberships()
+	descriptors, err := memberships.ListMemberDescriptors(organization, teamDescriptor, apiVersion, authHeader)
+	if err != nil {
+		return nil, err
+	}
+	subjects, err := memberships.LookupSubjects(organization, descriptors, apiVersion, authHeader)
+	if err != nil {
+		return nil, err
+	}
+	return buildTeamMembers(descriptors, subjects), nil
+}
@@ -0,0 +1,114 @@
+package team
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/graphmembership"
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/handlerstest"
+)
+
+func TestBuildTeamMembers(t *testing.T) {
+	subjects := map[string]graphmembership.GraphSubject{
+		aliceDescriptor: {Descriptor: aliceDescriptor, PrincipalName: "alice@example.com", DisplayName: "Alice"},
+	}
+
+	members := buildTeamMembers([]string{bobDescriptor, aliceDescriptor}, subjects)
+	expected := []TeamMember{
+		{Descriptor: aliceDescriptor, PrincipalName: "alice@example.com", DisplayName: "Alice"},
+		{Descriptor: bobDescriptor}, // Not found by the subject lookup
+	}
+	if !reflect.DeepEqual(members, expected) {
+		t.Errorf("buildTeamMembers() = %v, want %v", members, expected)
+	}
+}
+
+func TestValidateTeamRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		request     TeamRequest
+		expectedErr string
+	}{
+		{name: "valid request", request: TeamRequest{Name: " Platform Team ", Members: []TeamMemberRequest{{Descriptor: aliceDescriptor}, {PrincipalName: "bob@example.com"}}}},
+		{name: "missing name", request: TeamRequest{Name: " "}, expectedErr: "name is required"},
+		{name: "both descriptor and principal name", request: TeamRequest{Name: "Platform Team", Members: []TeamMemberRequest{{Descriptor: aliceDescriptor, PrincipalName: "alice@example.com"}}}, expectedErr: "members[0]: either descriptor or principalName is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateTeamRequest(&tt.request)
+			if tt.expectedErr == "" {
+				if err != nil {
+					t.Fatalf("validateTeamRequest() error = %v", err)
+				}
+				if tt.request.Name != "Platform Team" {
+					t.Errorf("Name = %q, want it trimmed", tt.request.Name)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.expectedErr {
+				t.Errorf("validateTeamRequest() error = %v, want %s", err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestResolvePrincipalName(t *testing.T) {
+	tests := []struct {
+		name               string
+		principalName      string
+		identitiesResp     string
+		expectedDescriptor string
+		expectNotFound     bool
+	}{
+		{
+			name:               "identity without descriptor skipped",
+			principalName:      "bob@example.com",
+			identitiesResp:     `{"count":2,"value":[{"id":"legacy-id","properties":{"Account":{"$value":"bob@example.com"}}},{"id":"bob-id","subjectDescriptor":"aad.Bob","properties":{"Account":{"$value":"bob@example.com"}}}]}`,
+			expectedDescriptor: bobDescriptor,
+		},
+		{
+			name:               "non matching identity returned first by the search",
+			principalName:      "bob@example.com",
+			identitiesResp:     `{"count":2,"value":[{"id":"bobby-id","subjectDescriptor":"aad.Bobby","providerDisplayName":"Bob","properties":{"Account":{"$value":"bob@example.com.au"}}},{"id":"bob-id","subjectDescriptor":"aad.Bob","properties":{"Account":{"$value":"Bob@Example.com"}}}]}`,
+			expectedDescriptor: bobDescriptor,
+		},
+		{
+			name:               "match on mail address",
+			principalName:      "bob@example.com",
+			identitiesResp:     `{"count":1,"value":[{"id":"bob-id","subjectDescriptor":"aad.Bob","properties":{"Account":{"$value":"bob@contoso.onmicrosoft.com"},"Mail":{"$value":"bob@example.com"}}}]}`,
+			expectedDescriptor: bobDescriptor,
+		},
+		{
+			name:           "only non matching identities",
+			principalName:  "bob@example.com",
+			identitiesResp: `{"count":1,"value":[{"id":"bobby-id","subjectDescriptor":"aad.Bobby","providerDisplayName":"bob@example.com","properties":{"Account":{"$value":"bobby@example.com"}}}]}`,
+			expectNotFound: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := handlerstest.NewMockHTTPClient()
+			mockClient.SetResponse(identitiesURL(tt.principalName), http.StatusOK, tt.identitiesResp)
+			h := newTestBaseHandler(mockClient)
+
+			descriptor, err := h.resolvePrincipalName(testOrg, tt.principalName, testAPIVersion, "Basic dGVzdDp0ZXN0")
+			if tt.expectNotFound {
+				var notFound *errIdentityNotFound
+				if !errors.As(err, &notFound) {
+					t.Fatalf("resolvePrincipalName() = %s, %v, want errIdentityNotFound", descriptor, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolvePrincipalName() error = %v", err)
+			}
+			if descriptor != tt.expectedDescriptor {
+				t.Errorf("resolvePrincipalName() = %s, want %s", descriptor, tt.expectedDescriptor)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package team
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/graphmembership"
+)
+
+// validateTeamRequest checks the fields of a team request
+func validateTeamRequest(request *TeamRequest) error {
+	request.Name = strings.TrimSpace(request.Name)
+	if request.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	for i := range request.Members {
+		member := &request.Members[i]
+		member.Descriptor = strings.TrimSpace(member.Descriptor)
+		member.PrincipalName = strings.TrimSpace(member.PrincipalName)
+		if (member.Descriptor == "") == (member.PrincipalName == "") {
+			return fmt.Errorf("members[%d]: either descriptor or principalName is required", i)
+		}
+	}
+
+	return nil
+}
+
+// matchIdentity returns the identity whose account name (UPN) or mail address is the given principal name, ignoring case
+// Identities without subject descriptor (e.g., not materialized in the organization) are skipped. If several identities
+// match, the one with the lowest descriptor is returned so that the result does not depend on the order of the search
+func matchIdentity(identities []Identity, principalName string) *Identity {
+	var match *Identity
+	for i := range identities {
+		if identities[i].SubjectDescriptor == "" {
+			continue
+		}
+		properties := identities[i].Properties
+		if !strings.EqualFold(properties.Account.Value, principalName) && !strings.EqualFold(properties.Mail.Value, principalName) {
+			continue
+		}
+		if match == nil || identities[i].SubjectDescriptor < match.SubjectDescriptor {
+			match = &identities[i]
+		}
+	}
+	return match
+}
+
+// buildTeamMembers returns the members of the team sorted by descriptor, with the details found by the subject lookup
+func buildTeamMembers(descriptors []string, subjects map[string]graphmembership.GraphSubject) []TeamMember {
+	members := []TeamMember{}
+	for _, descriptor := range descriptors {
+		subject := subjects[descriptor]
+		members = append(members, TeamMember{
+			Descriptor:    descriptor,
+			PrincipalName: subject.PrincipalName,
+			DisplayName:   subject.DisplayName,
+		})
+	}
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].Descriptor < members[j].Descriptor
+	})
+	return members
+}
+
+// mapTeamFromAzure converts an Azure DevOps team and its members to the team returned by the plugin
+func mapTeamFromAzure(team *WebApiTeam, members []TeamMember) *Team {
+	return &Team{
+		ID:          strings.ToLower(team.ID),
+		Name:        team.Name,
+		Description: team.Description,
+		ProjectID:   strings.ToLower(team.ProjectID),
+		Members:     members,
+	}
+}
@@ -0,0 +1,364 @@
+package team
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers"
+)
+
+// Handler constructors
+func GetTeam(opts handlers.HandlerOptions) handlers.Handler {
+	return &getHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func PostTeam(opts handlers.HandlerOptions) handlers.Handler {
+	return &postHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func PutTeam(opts handlers.HandlerOptions) handlers.Handler {
+	return &putHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func DeleteTeam(opts handlers.HandlerOptions) handlers.Handler {
+	return &deleteHandler{baseHandler: newBaseHandler(opts)}
+}
+
+// Interface compliance verification
+var _ handlers.Handler = &getHandler{}
+var _ handlers.Handler = &postHandler{}
+var _ handlers.Handler = &putHandler{}
+var _ handlers.Handler = &deleteHandler{}
+
+// Base handler with common functionality
+type baseHandler struct {
+	*handlers.BaseHandler
+}
+
+// Constructor for the base handler
+func newBaseHandler(opts handlers.HandlerOptions) *baseHandler {
+	return &baseHandler{BaseHandler: handlers.NewBaseHandler(opts)}
+}
+
+// Handler types embedding the base handler
+type getHandler struct {
+	*baseHandler
+}
+
+type postHandler struct {
+	*baseHandler
+}
+
+type putHandler struct {
+	*baseHandler
+}
+
+type deleteHandler struct {
+	*baseHandler
+}
+
+// getTeam retrieves a team of the project by ID or name
+func (h *baseHandler) getTeam(organization, projectID, id, apiVersion, authHeader string) (*WebApiTeam, error) {
+	url := fmt.Sprintf("https://dev.azure.com/%s/_apis/projects/%s/teams/%s?api-version=%s", organization, projectID, id, apiVersion)
+
+	body, err := h.DoAzureDevOpsRequest("GET", url, authHeader, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var team WebApiTeam
+	if err := json.Unmarshal(body, &team); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal team: %w", err)
+	}
+	team.ID = strings.ToLower(team.ID)
+	return &team, nil
+}
+
+// buildTeam returns the team with its members, reconciling the members first when desired is not nil
+func (h *baseHandler) buildTeam(organization string, team *WebApiTeam, desired []string, apiVersion, authHeader string) (*Team, error) {
+	teamDescriptor, err := h.getTeamDescriptor(organization, strings.ToLower(team.ID), apiVersion, authHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	if desired != nil {
+		if err := h.memberships().ReconcileMembers(organization, teamDescriptor, desired, apiVersion, authHeader); err != nil {
+			return nil, err
+		}
+	}
+
+	members, err := h.getTeamMembers(organization, teamDescriptor, apiVersion, authHeader)
+	if err != nil {
+		return nil, err
+	}
+	return mapTeamFromAzure(team, members), nil
+}
+
+// readTeamRequest reads and validates the request body, and resolves the requested members to descriptors
+// The returned descriptors are nil when the members are not managed by the request
+func (h *baseHandler) readTeamRequest(w http.ResponseWriter, r *http.Request, organization, apiVersion, authHeader string) (*TeamRequest, []string, bool) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Failed to read request body")
+		return nil, nil, false
+	}
+
+	var teamRequest TeamRequest
+	if err := json.Unmarshal(body, &teamRequest); err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Invalid JSON in request body")
+		return nil, nil, false
+	}
+	if err := validateTeamRequest(&teamRequest); err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid team: %v", err))
+		return nil, nil, false
+	}
+	if teamRequest.Members == nil {
+		return &teamRequest, nil, true
+	}
+
+	// Members are resolved before changing anything so that an unknown principal name leaves the team untouched
+	desired, err := h.resolveMembers(organization, teamRequest.Members, apiVersion, authHeader)
+	if err != nil {
+		var notFound *errIdentityNotFound
+		if errors.As(err, &notFound) {
+			h.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid team: %v", err))
+			return nil, nil, false
+		}
+		h.WriteAzureDevOpsError(w, err, "resolve team members", fmt.Sprintf("Organization %s not found", organization))
+		return nil, nil, false
+	}
+	return &teamRequest, desired, true
+}
+
+// GET handler implementation
+// @Summary Get a team
+// @Description Get a team of the project with its members sorted by descriptor
+// @ID get-team
+// @Param organization path string true "Organization name"
+// @Param projectId path string true "Project ID"
+// @Param id path string true "Team ID or name"
+// @Param api-version query string true "API version (e.g., 7.1-preview.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Produce json
+// @Success 200 {object} TeamResponse "Team details"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{projectId}/teams/{id} [get]
+func (h *getHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	projectID := r.PathValue("projectId")
+	id := r.PathValue("id")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, projectID, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	h.Log.Printf("Getting team %s for project %s in organization %s", id, projectID, organization)
+
+	team, err := h.getTeam(organization, projectID, id, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "get team", fmt.Sprintf("Team %s not found in project %s", id, projectID))
+		return
+	}
+
+	result, err := h.buildTeam(organization, team, nil, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "get team members", fmt.Sprintf("Team %s not found in project %s", id, projectID))
+		return
+	}
+
+	h.WriteObjectResponse(w, http.StatusOK, TeamResponse(*result))
+	h.Log.Printf("Successfully retrieved team %s", id)
+}
+
+// POST handler implementation
+// @Summary Create a team
+// @Description Create a team in the project. If members are set, they are added to the team after its creation.
+// @ID post-team
+// @Param organization path string true "Organization name"
+// @Param projectId path string true "Project ID"
+// @Param api-version query string true "API version (e.g., 7.1-preview.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Param team body TeamRequest true "Team creation request body"
+// @Accept json
+// @Produce json
+// @Success 201 {object} TeamResponse "Created team"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{projectId}/teams [post]
+func (h *postHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	projectID := r.PathValue("projectId")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, projectID, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	teamRequest, desired, ok := h.readTeamRequest(w, r, organization, apiVersion, authHeader)
+	if !ok {
+		return
+	}
+
+	h.Log.Printf("Creating team %s for project %s in organization %s", teamRequest.Name, projectID, organization)
+
+	requestBody, err := json.Marshal(WebApiTeam{Name: teamRequest.Name, Description: teamRequest.Description})
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Failed to marshal team: %v", err))
+		return
+	}
+
+	url := fmt.Sprintf("https://dev.azure.com/%s/_apis/projects/%s/teams?api-version=%s", organization, projectID, apiVersion)
+	responseBody, err := h.DoAzureDevOpsRequest("POST", url, authHeader, requestBody)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "create team", fmt.Sprintf("Project %s not found", projectID))
+		return
+	}
+
+	var created WebApiTeam
+	if err := json.Unmarshal(responseBody, &created); err != nil {
+		h.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Failed to unmarshal team: %v", err))
+		return
+	}
+
+	result, err := h.buildTeam(organization, &created, desired, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "update team members", fmt.Sprintf("Team %s not found in project %s", created.ID, projectID))
+		return
+	}
+
+	h.WriteObjectResponse(w, http.StatusCreated, TeamResponse(*result))
+	h.Log.Printf("Successfully created team %s with ID %s", created.Name, created.ID)
+}
+
+// PUT handler implementation
+// @Summary Update a team
+// @Description Update the name and description of a team. If members are set, the members of the team are reconciled with them: missing members are added and the others are removed.
+// @ID put-team
+// @Param organization path string true "Organization name"
+// @Param projectId path string true "Project ID"
+// @Param id path string true "Team ID"
+// @Param api-version query string true "API version (e.g., 7.1-preview.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Param team body TeamRequest true "Team update request body"
+// @Accept json
+// @Produce json
+// @Success 200 {object} TeamResponse "Updated team"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{projectId}/teams/{id} [put]
+func (h *putHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	projectID := r.PathValue("projectId")
+	id := r.PathValue("id")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, projectID, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	teamRequest, desired, ok := h.readTeamRequest(w, r, organization, apiVersion, authHeader)
+	if !ok {
+		return
+	}
+
+	h.Log.Printf("Updating team %s for project %s in organization %s", id, projectID, organization)
+
+	team, err := h.getTeam(organization, projectID, id, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "get team", fmt.Sprintf("Team %s not found in project %s", id, projectID))
+		return
+	}
+
+	if team.Name != teamRequest.Name || team.Description != teamRequest.Description {
+		requestBody, err := json.Marshal(WebApiTeam{Name: teamRequest.Name, Description: teamRequest.Description})
+		if err != nil {
+			h.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Failed to marshal team: %v", err))
+			return
+		}
+
+		url := fmt.Sprintf("https://dev.azure.com/%s/_apis/projects/%s/teams/%s?api-version=%s", organization, projectID, team.ID, apiVersion)
+		responseBody, err := h.DoAzureDevOpsRequest("PATCH", url, authHeader, requestBody)
+		if err != nil {
+			h.WriteAzureDevOpsError(w, err, "update team", fmt.Sprintf("Team %s not found in project %s", id, projectID))
+			return
+		}
+		if err := json.Unmarshal(responseBody, team); err != nil {
+			h.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Failed to unmarshal team: %v", err))
+			return
+		}
+	}
+
+	result, err := h.buildTeam(organization, team, desired, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "update team members", fmt.Sprintf("Team %s not found in project %s", id, projectID))
+		return
+	}
+
+	h.WriteObjectResponse(w, http.StatusOK, TeamResponse(*result))
+	h.Log.Printf("Successfully updated team %s", id)
+}
+
+// DELETE handler implementation
+// @Summary Delete a team
+// @Description Delete a team of the project
+// @ID delete-team
+// @Param organization path string true "Organization name"
+// @Param projectId path string true "Project ID"
+// @Param id path string true "Team ID"
+// @Param api-version query string true "API version (e.g., 7.1-preview.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Success 204 "No Content"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{projectId}/teams/{id} [delete]
+func (h *deleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	projectID := r.PathValue("projectId")
+	id := r.PathValue("id")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, projectID, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	h.Log.Printf("Deleting team %s for project %s in organization %s", id, projectID, organization)
+
+	url := fmt.Sprintf("https://dev.azure.com/%s/_apis/projects/%s/teams/%s?api-version=%s", organization, projectID, id, apiVersion)
+	if _, err := h.DoAzureDevOpsRequest("DELETE", url, authHeader, nil); err != nil {
+		h.WriteAzureDevOpsError(w, err, "delete team", fmt.Sprintf("Team %s not found in project %s", id, projectID))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	h.Log.Printf("Successfully deleted team %s", id)
+}
@@ -0,0 +1,459 @@
+package team
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers"
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/handlerstest"
+	"github.com/rs/zerolog"
+)
+
+// newTestBaseHandler creates a base handler for testing with a mock client
+func newTestBaseHandler(mockClient *handlerstest.MockHTTPClient) *baseHandler {
+	return newBaseHandler(handlerstest.NewHandlerOptions(mockClient))
+}
+
+// Test data constants
+const (
+	testOrg            = "testorg"
+	testProjectID      = "9a3c2b1d-0000-0000-0000-000000000001"
+	testTeamID         = "c1f2e3d4-0000-0000-0000-000000000002"
+	testTeamDescriptor = "vssgp.TeamDescriptor"
+	testAPIVersion     = "7.1-preview.1"
+	testUsername       = "test"
+	testPassword       = "test"
+	aliceDescriptor    = "aad.Alice"
+	bobDescriptor      = "aad.Bob"
+	carolDescriptor    = "aad.Carol"
+)
+
+var (
+	teamsURL          = fmt.Sprintf("https://dev.azure.com/%s/_apis/projects/%s/teams?api-version=%s", testOrg, testProjectID, testAPIVersion)
+	teamURL           = fmt.Sprintf("https://dev.azure.com/%s/_apis/projects/%s/teams/%s?api-version=%s", testOrg, testProjectID, testTeamID, testAPIVersion)
+	teamDescriptorURL = fmt.Sprintf("https://vssps.dev.azure.com/%s/_apis/graph/descriptors/%s?api-version=%s", testOrg, testTeamID, testAPIVersion)
+	membershipsURL    = fmt.Sprintf("https://vssps.dev.azure.com/%s/_apis/graph/memberships/%s?direction=down&api-version=%s", testOrg, testTeamDescriptor, testAPIVersion)
+	subjectLookupURL  = fmt.Sprintf("https://vssps.dev.azure.com/%s/_apis/graph/subjectlookup?api-version=%s", testOrg, testAPIVersion)
+
+	validTeamResp       = `{"id":"C1F2E3D4-0000-0000-0000-000000000002","name":"Platform Team","description":"Platform","url":"https://dev.azure.com/testorg/_apis/projects/x/teams/y","projectId":"9A3C2B1D-0000-0000-0000-000000000001","projectName":"Platform","identityUrl":"https://spsprodweu5.vssps.visualstudio.com/x"}`
+	teamDescriptorResp  = `{"value":"vssgp.TeamDescriptor"}`
+	bobAndAliceResp     = `{"count":2,"value":[{"containerDescriptor":"vssgp.TeamDescriptor","memberDescriptor":"aad.Bob"},{"containerDescriptor":"vssgp.TeamDescriptor","memberDescriptor":"aad.Alice"}]}`
+	aliceAndCarolResp   = `{"count":2,"value":[{"containerDescriptor":"vssgp.TeamDescriptor","memberDescriptor":"aad.Carol"},{"containerDescriptor":"vssgp.TeamDescriptor","memberDescriptor":"aad.Alice"}]}`
+	subjectLookupResp   = `{"count":3,"value":{"aad.Alice":{"descriptor":"aad.Alice","subjectKind":"user","principalName":"alice@example.com","displayName":"Alice"},"aad.Bob":{"descriptor":"aad.Bob","subjectKind":"user","principalName":"bob@example.com","displayName":"Bob"},"aad.Carol":{"descriptor":"aad.Carol","subjectKind":"user","principalName":"carol@example.com","displayName":"Carol"}}}`
+	expectedBobAndAlice = `{"id":"c1f2e3d4-0000-0000-0000-000000000002","name":"Platform Team","description":"Platform","projectId":"9a3c2b1d-0000-0000-0000-000000000001","members":[{"descriptor":"aad.Alice","principalName":"alice@example.com","displayName":"Alice"},{"descriptor":"aad.Bob","principalName":"bob@example.com","displayName":"Bob"}]}`
+)
+
+// membershipURL returns the URL used to add or remove a member of the test team
+func membershipURL(descriptor string) string {
+	return fmt.Sprintf("https://vssps.dev.azure.com/%s/_apis/graph/memberships/%s/%s?api-version=%s", testOrg, descriptor, testTeamDescriptor, testAPIVersion)
+}
+
+// identitiesURL returns the URL used to resolve a principal name
+func identitiesURL(principalName string) string {
+	return fmt.Sprintf("https://vssps.dev.azure.com/%s/_apis/identities?searchFilter=General&filterValue=%s&queryMembership=None&api-version=%s", testOrg, url.QueryEscape(principalName), testAPIVersion)
+}
+
+// setupTeamMock configures the responses used to build the test team with its members
+func setupTeamMock(m *handlerstest.MockHTTPClient) {
+	m.SetResponse(teamDescriptorURL, http.StatusOK, teamDescriptorResp)
+	m.SetMethodResponse("POST", subjectLookupURL, http.StatusOK, subjectLookupResp)
+}
+
+// teamTestCase is a test case for the handlers of this package
+type teamTestCase struct {
+	name                 string
+	pathValues           map[string]string
+	query                url.Values
+	noAuth               bool
+	body                 string
+	setupMock            func(*handlerstest.MockHTTPClient)
+	expectedStatus       int
+	expectedBody         string
+	expectedBodyContains string
+	expectedRequestCount int
+	verify               func(t *testing.T, mockClient *handlerstest.MockHTTPClient)
+}
+
+// runTeamTests runs the given test cases against the handler built by newHandler
+func runTeamTests(t *testing.T, method string, newHandler func(*baseHandler) http.Handler, tests []teamTestCase) {
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := handlerstest.NewMockHTTPClient()
+			if tt.setupMock != nil {
+				tt.setupMock(mockClient)
+			}
+			handler := newHandler(newTestBaseHandler(mockClient))
+
+			target := "/api/test"
+			if len(tt.query) > 0 {
+				target += "?" + tt.query.Encode()
+			}
+			var body io.Reader
+			if tt.body != "" {
+				body = strings.NewReader(tt.body)
+			}
+			req := httptest.NewRequest(method, target, body)
+			for key, value := range tt.pathValues {
+				req.SetPathValue(key, value)
+			}
+			if !tt.noAuth {
+				req.SetBasicAuth(testUsername, testPassword)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("Status code = %d, want %d. Body: %s", rr.Code, tt.expectedStatus, rr.Body.String())
+			}
+			if tt.expectedBody != "" && rr.Body.String() != tt.expectedBody {
+				t.Errorf("Body = %s, want %s", rr.Body.String(), tt.expectedBody)
+			}
+			if tt.expectedBodyContains != "" && !strings.Contains(rr.Body.String(), tt.expectedBodyContains) {
+				t.Errorf("Body = %s, want it to contain %s", rr.Body.String(), tt.expectedBodyContains)
+			}
+			if mockClient.RequestCount() != tt.expectedRequestCount {
+				t.Errorf("Request count = %d, want %d", mockClient.RequestCount(), tt.expectedRequestCount)
+			}
+			if tt.verify != nil {
+				tt.verify(t, mockClient)
+			}
+		})
+	}
+}
+
+// apiVersionQuery returns the query with the test API version
+func apiVersionQuery() url.Values {
+	return url.Values{"api-version": {testAPIVersion}}
+}
+
+// requestBody returns the body of the n-th request sent to Azure DevOps
+func requestBody(t *testing.T, mockClient *handlerstest.MockHTTPClient, n int) string {
+	t.Helper()
+	if len(mockClient.Requests) <= n || mockClient.Requests[n].Body == nil {
+		t.Fatalf("request %d has no body", n)
+	}
+	body, err := io.ReadAll(mockClient.Requests[n].Body)
+	if err != nil {
+		t.Fatalf("failed to read request %d body: %v", n, err)
+	}
+	return string(body)
+}
+
+// teamPathValues returns the path values of the team endpoints
+func teamPathValues() map[string]string {
+	return map[string]string{"organization": testOrg, "projectId": testProjectID, "id": testTeamID}
+}
+
+// requestURLs returns "METHOD url" for every request sent to Azure DevOps
+func requestURLs(mockClient *handlerstest.MockHTTPClient) []string {
+	urls := []string{}
+	for _, req := range mockClient.Requests {
+		urls = append(urls, req.Method+" "+req.URL.String())
+	}
+	return urls
+}
+
+// containsRequest reports whether a request was sent with the given method and URL
+func containsRequest(mockClient *handlerstest.MockHTTPClient, method, url string) bool {
+	for _, request := range requestURLs(mockClient) {
+		if request == method+" "+url {
+			return true
+		}
+	}
+	return false
+}
+
+// Test constructor functions
+func TestTeamConstructors(t *testing.T) {
+	client := &http.Client{}
+	logger := zerolog.New(os.Stdout).With().Timestamp().Logger()
+	opts := handlers.HandlerOptions{
+		Client: client,
+		Log:    &logger,
+	}
+
+	constructors := map[string]func(handlers.HandlerOptions) handlers.Handler{
+		"GetTeam":    GetTeam,
+		"PostTeam":   PostTeam,
+		"PutTeam":    PutTeam,
+		"DeleteTeam": DeleteTeam,
+	}
+
+	for name, constructor := range constructors {
+		t.Run(name, func(t *testing.T) {
+			if h := constructor(opts); h == nil {
+				t.Fatalf("%s should return a non-nil handler", name)
+			}
+		})
+	}
+}
+
+// Test GET handler
+func TestGetHandler_ServeHTTP(t *testing.T) {
+	runTeamTests(t, "GET", func(b *baseHandler) http.Handler { return &getHandler{baseHandler: b} }, []teamTestCase{
+		{
+			name:       "successful retrieval with members sorted by descriptor",
+			pathValues: teamPathValues(),
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				setupTeamMock(m)
+				m.SetResponse(teamURL, http.StatusOK, validTeamResp)
+				m.SetResponse(membershipsURL, http.StatusOK, bobAndAliceResp)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBody:         expectedBobAndAlice,
+			expectedRequestCount: 4,
+		},
+		{
+			name:       "team without members",
+			pathValues: teamPathValues(),
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				setupTeamMock(m)
+				m.SetResponse(teamURL, http.StatusOK, validTeamResp)
+				m.SetResponse(membershipsURL, http.StatusOK, `{"count":0,"value":[]}`)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `"members":[]`,
+			expectedRequestCount: 3,
+		},
+		{
+			name:                 "missing project",
+			pathValues:           map[string]string{"organization": testOrg, "id": testTeamID},
+			query:                apiVersionQuery(),
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Project parameter is required",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "missing basic auth",
+			pathValues:           teamPathValues(),
+			query:                apiVersionQuery(),
+			noAuth:               true,
+			expectedStatus:       http.StatusUnauthorized,
+			expectedBody:         "Request rejected due to missing or invalid Basic authentication",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "team not found",
+			pathValues:           teamPathValues(),
+			query:                apiVersionQuery(),
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Team c1f2e3d4-0000-0000-0000-000000000002 not found in project 9a3c2b1d-0000-0000-0000-000000000001",
+			expectedRequestCount: 1,
+		},
+	})
+}
+
+// Test POST handler
+func TestPostHandler_ServeHTTP(t *testing.T) {
+	pathValues := map[string]string{"organization": testOrg, "projectId": testProjectID}
+	runTeamTests(t, "POST", func(b *baseHandler) http.Handler { return &postHandler{baseHandler: b} }, []teamTestCase{
+		{
+			name:       "team created with members given by descriptor and principal name",
+			pathValues: pathValues,
+			query:      apiVersionQuery(),
+			body:       `{"name":"Platform Team","description":"Platform","members":[{"principalName":"bob@example.com"},{"descriptor":"aad.Alice"}]}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				setupTeamMock(m)
+				m.SetResponse(identitiesURL("bob@example.com"), http.StatusOK, `{"count":1,"value":[{"id":"bob-id","subjectDescriptor":"aad.Bob","providerDisplayName":"Bob","properties":{"Account":{"$type":"System.String","$value":"bob@example.com"}}}]}`)
+				m.SetMethodResponse("POST", teamsURL, http.StatusOK, validTeamResp)
+				m.SetResponseSequence(membershipsURL, handlerstest.MockResponse{StatusCode: http.StatusOK, Body: `{"count":0,"value":[]}`}, handlerstest.MockResponse{StatusCode: http.StatusOK, Body: bobAndAliceResp})
+				m.SetMethodResponse("PUT", membershipURL(aliceDescriptor), http.StatusCreated, `{}`)
+				m.SetMethodResponse("PUT", membershipURL(bobDescriptor), http.StatusCreated, `{}`)
+			},
+			expectedStatus:       http.StatusCreated,
+			expectedBody:         expectedBobAndAlice,
+			expectedRequestCount: 8,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				if got, want := requestBody(t, m, 1), `{"name":"Platform Team","description":"Platform"}`; got != want {
+					t.Errorf("Request body = %s, want %s", got, want)
+				}
+			},
+		},
+		{
+			name:       "team created without managing members",
+			pathValues: pathValues,
+			query:      apiVersionQuery(),
+			body:       `{"name":"Platform Team","description":"Platform"}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				setupTeamMock(m)
+				m.SetMethodResponse("POST", teamsURL, http.StatusOK, validTeamResp)
+				m.SetResponse(membershipsURL, http.StatusOK, bobAndAliceResp)
+			},
+			expectedStatus:       http.StatusCreated,
+			expectedBody:         expectedBobAndAlice,
+			expectedRequestCount: 4,
+		},
+		{
+			name:       "unknown principal name",
+			pathValues: pathValues,
+			query:      apiVersionQuery(),
+			body:       `{"name":"Platform Team","members":[{"principalName":"nobody@example.com"}]}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(identitiesURL("nobody@example.com"), http.StatusOK, `{"count":0,"value":[]}`)
+			},
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid team: identity 'nobody@example.com' not found",
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "member without descriptor and principal name",
+			pathValues:           pathValues,
+			query:                apiVersionQuery(),
+			body:                 `{"name":"Platform Team","members":[{"descriptor":"aad.Alice"},{}]}`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid team: members[1]: either descriptor or principalName is required",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "missing name",
+			pathValues:           pathValues,
+			query:                apiVersionQuery(),
+			body:                 `{"description":"Platform"}`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid team: name is required",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "invalid json",
+			pathValues:           pathValues,
+			query:                apiVersionQuery(),
+			body:                 `{"name":`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid JSON in request body",
+			expectedRequestCount: 0,
+		},
+		{
+			name:       "azure devops error forwarded",
+			pathValues: pathValues,
+			query:      apiVersionQuery(),
+			body:       `{"name":"Platform Team"}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetMethodResponse("POST", teamsURL, http.StatusConflict, `{"message":"team already exists"}`)
+			},
+			expectedStatus:       http.StatusConflict,
+			expectedBody:         `{"message":"team already exists"}`,
+			expectedRequestCount: 1,
+		},
+	})
+}
+
+// Test PUT handler
+func TestPutHandler_ServeHTTP(t *testing.T) {
+	runTeamTests(t, "PUT", func(b *baseHandler) http.Handler { return &putHandler{baseHandler: b} }, []teamTestCase{
+		{
+			name:       "members reconciled without updating the team",
+			pathValues: teamPathValues(),
+			query:      apiVersionQuery(),
+			body:       `{"name":"Platform Team","description":"Platform","members":[{"descriptor":"aad.Bob"},{"descriptor":"aad.Alice"}]}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				setupTeamMock(m)
+				m.SetMethodResponse("GET", teamURL, http.StatusOK, validTeamResp)
+				m.SetResponseSequence(membershipsURL, handlerstest.MockResponse{StatusCode: http.StatusOK, Body: aliceAndCarolResp}, handlerstest.MockResponse{StatusCode: http.StatusOK, Body: bobAndAliceResp})
+				m.SetMethodResponse("PUT", membershipURL(bobDescriptor), http.StatusCreated, `{}`)
+				m.SetMethodResponse("DELETE", membershipURL(carolDescriptor), http.StatusOK, "")
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBody:         expectedBobAndAlice,
+			expectedRequestCount: 7,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				if containsRequest(m, "PATCH", teamURL) {
+					t.Errorf("Team should not be updated, requests: %v", requestURLs(m))
+				}
+				if containsRequest(m, "PUT", membershipURL(aliceDescriptor)) {
+					t.Errorf("Existing member should not be added again, requests: %v", requestURLs(m))
+				}
+			},
+		},
+		{
+			name:       "description updated and members not managed",
+			pathValues: teamPathValues(),
+			query:      apiVersionQuery(),
+			body:       `{"name":"Platform Team","description":"Platform engineering"}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				setupTeamMock(m)
+				m.SetMethodResponse("GET", teamURL, http.StatusOK, validTeamResp)
+				m.SetMethodResponse("PATCH", teamURL, http.StatusOK, strings.Replace(validTeamResp, `"description":"Platform"`, `"description":"Platform engineering"`, 1))
+				m.SetResponse(membershipsURL, http.StatusOK, bobAndAliceResp)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `"description":"Platform engineering"`,
+			expectedRequestCount: 5,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				if got, want := requestBody(t, m, 1), `{"name":"Platform Team","description":"Platform engineering"}`; got != want {
+					t.Errorf("Request body = %s, want %s", got, want)
+				}
+			},
+		},
+		{
+			name:       "empty member list removes all the members",
+			pathValues: teamPathValues(),
+			query:      apiVersionQuery(),
+			body:       `{"name":"Platform Team","description":"Platform","members":[]}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				setupTeamMock(m)
+				m.SetMethodResponse("GET", teamURL, http.StatusOK, validTeamResp)
+				m.SetResponseSequence(membershipsURL, handlerstest.MockResponse{StatusCode: http.StatusOK, Body: bobAndAliceResp}, handlerstest.MockResponse{StatusCode: http.StatusOK, Body: `{"count":0,"value":[]}`})
+				m.SetMethodResponse("DELETE", membershipURL(aliceDescriptor), http.StatusOK, "")
+				m.SetMethodResponse("DELETE", membershipURL(bobDescriptor), http.StatusOK, "")
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `"members":[]`,
+			expectedRequestCount: 6,
+		},
+		{
+			name:                 "team not found",
+			pathValues:           teamPathValues(),
+			query:                apiVersionQuery(),
+			body:                 `{"name":"Platform Team"}`,
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Team c1f2e3d4-0000-0000-0000-000000000002 not found in project 9a3c2b1d-0000-0000-0000-000000000001",
+			expectedRequestCount: 1,
+		},
+		{
+			name:       "azure devops error while adding a member forwarded",
+			pathValues: teamPathValues(),
+			query:      apiVersionQuery(),
+			body:       `{"name":"Platform Team","description":"Platform","members":[{"descriptor":"aad.Bob"}]}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				setupTeamMock(m)
+				m.SetMethodResponse("GET", teamURL, http.StatusOK, validTeamResp)
+				m.SetResponse(membershipsURL, http.StatusOK, `{"count":0,"value":[]}`)
+				m.SetMethodResponse("PUT", membershipURL(bobDescriptor), http.StatusForbidden, `{"message":"forbidden"}`)
+			},
+			expectedStatus:       http.StatusForbidden,
+			expectedBody:         `{"message":"forbidden"}`,
+			expectedRequestCount: 4,
+		},
+	})
+}
+
+// Test DELETE handler
+func TestDeleteHandler_ServeHTTP(t *testing.T) {
+	runTeamTests(t, "DELETE", func(b *baseHandler) http.Handler { return &deleteHandler{baseHandler: b} }, []teamTestCase{
+		{
+			name:                 "successful deletion",
+			pathValues:           teamPathValues(),
+			query:                apiVersionQuery(),
+			setupMock:            func(m *handlerstest.MockHTTPClient) { m.SetMethodResponse("DELETE", teamURL, http.StatusNoContent, "") },
+			expectedStatus:       http.StatusNoContent,
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "team not found",
+			pathValues:           teamPathValues(),
+			query:                apiVersionQuery(),
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Team c1f2e3d4-0000-0000-0000-000000000002 not found in project 9a3c2b1d-0000-0000-0000-000000000001",
+			expectedRequestCount: 1,
+		},
+	})
+}
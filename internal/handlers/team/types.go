@@ -0,0 +1,81 @@
+package team
+
+// Team represents the team returned by the plugin
+// It is built from the WebApiTeam returned by:
+// GET /{organization}/_apis/projects/{projectId}/teams/{teamId}
+// and from the members of the team group returned by:
+// GET https://vssps.dev.azure.com/{organization}/_apis/graph/memberships/{teamDescriptor}?direction=down
+type Team struct {
+	ID          string       `json:"id"`
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	ProjectID   string       `json:"projectId"`
+	Members     []TeamMember `json:"members"` // Sorted by descriptor
+}
+
+// TeamMember represents a member (user or group) of a team
+type TeamMember struct {
+	Descriptor    string `json:"descriptor"`
+	PrincipalName string `json:"principalName,omitempty"`
+	DisplayName   string `json:"displayName,omitempty"`
+}
+
+// TeamRequest represents the request body for creating or updating a team
+type TeamRequest struct {
+	Name        string              `json:"name"` // Required
+	Description string              `json:"description,omitempty"`
+	Members     []TeamMemberRequest `json:"members,omitempty"` // If set, the members of the team are reconciled with it. If not set, members are not changed
+}
+
+// TeamMemberRequest represents a member of a team in a request
+// Either descriptor or principalName (e.g., the UPN of a user) is required
+type TeamMemberRequest struct {
+	Descriptor    string `json:"descriptor,omitempty"`
+	PrincipalName string `json:"principalName,omitempty"`
+}
+
+// TeamResponse represents the response of the team endpoints
+type TeamResponse Team
+
+// ---
+
+// WebApiTeam represents a team of Azure DevOps:
+// /{organization}/_apis/projects/{projectId}/teams/{teamId}
+type WebApiTeam struct {
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	ProjectID   string `json:"projectId,omitempty"`
+}
+
+// GraphDescriptorResult represents the response from:
+// GET https://vssps.dev.azure.com/{organization}/_apis/graph/descriptors/{storageKey}
+type GraphDescriptorResult struct {
+	Value string `json:"value"`
+}
+
+// Identity represents an identity returned by:
+// GET https://vssps.dev.azure.com/{organization}/_apis/identities?searchFilter=General&filterValue={principalName}
+type Identity struct {
+	ID                  string             `json:"id"`
+	SubjectDescriptor   string             `json:"subjectDescriptor"`
+	ProviderDisplayName string             `json:"providerDisplayName"`
+	Properties          IdentityProperties `json:"properties"`
+}
+
+// IdentityProperties represents the properties of an identity used to match a principal name
+type IdentityProperties struct {
+	Account IdentityProperty `json:"Account"` // UPN of a user
+	Mail    IdentityProperty `json:"Mail"`
+}
+
+// IdentityProperty represents a property of an identity, e.g., {"$type":"System.String","$value":"alice@example.com"}
+type IdentityProperty struct {
+	Value string `json:"$value"`
+}
+
+// IdentityList represents the response of the identity lookup
+type IdentityList struct {
+	Count int        `json:"count"`
+	Value []Identity `json:"value"`
+}
@@ -0,0 +1,244 @@
+package variablegroup
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// validateVariableGroupRequest checks the fields of a variable group request
+func validateVariableGroupRequest(request *VariableGroupRequest) error {
+	if strings.TrimSpace(request.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+	if len(request.Variables) == 0 {
+		return fmt.Errorf("at least one variable is required")
+	}
+	for name, variable := range request.Variables {
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("variable names must not be empty")
+		}
+		if strings.EqualFold(name, SecretHashesVariable) {
+			return fmt.Errorf("variable name '%s' is reserved", SecretHashesVariable)
+		}
+		if request.KeyVault != nil && variable.Value != nil {
+			return fmt.Errorf("variable '%s' must not have a value, values of Key Vault variable groups are read from the key vault", name)
+		}
+	}
+
+	if request.KeyVault != nil {
+		if strings.TrimSpace(request.KeyVault.ServiceEndpointID) == "" {
+			return fmt.Errorf("keyVault.serviceEndpointId is required")
+		}
+		if strings.TrimSpace(request.KeyVault.Vault) == "" {
+			return fmt.Errorf("keyVault.vault is required")
+		}
+	}
+
+	projects := map[string]bool{}
+	for _, reference := range request.ProjectReferences {
+		projectID := strings.ToLower(strings.TrimSpace(reference.ProjectID))
+		if projectID == "" {
+			return fmt.Errorf("projectReferences: projectId is required")
+		}
+		if projects[projectID] {
+			return fmt.Errorf("projectReferences: project %s is referenced more than once", reference.ProjectID)
+		}
+		projects[projectID] = true
+	}
+
+	return nil
+}
+
+// buildVariableGroupParameters converts a variable group request to the Azure DevOps request body
+// current is the variable group being updated, nil on creation. It is used to keep the secret values (and their hashes) not sent in the request
+// hashKey is the key of the hashes of the secret values (see hashSecret)
+func buildVariableGroupParameters(request *VariableGroupRequest, projectID string, current *AzureVariableGroup, hashKey []byte) (*AzureVariableGroupParameters, error) {
+	parameters := &AzureVariableGroupParameters{
+		Name:                           request.Name,
+		Description:                    request.Description,
+		Variables:                      map[string]AzureVariableValue{},
+		VariableGroupProjectReferences: []AzureVariableGroupProjectReference{},
+	}
+
+	references := request.ProjectReferences
+	if len(references) == 0 {
+		references = []VariableGroupProjectReference{{ProjectID: projectID}}
+	}
+	for _, reference := range references {
+		name := reference.Name
+		if name == "" {
+			name = request.Name
+		}
+		parameters.VariableGroupProjectReferences = append(parameters.VariableGroupProjectReferences, AzureVariableGroupProjectReference{
+			Name:             name,
+			Description:      reference.Description,
+			ProjectReference: &ProjectReference{ID: strings.ToLower(reference.ProjectID)},
+		})
+	}
+
+	if request.KeyVault != nil {
+		parameters.Type = VariableGroupTypeAzureKeyVault
+		parameters.ProviderData = &AzureKeyVaultProviderData{
+			ServiceEndpointID: strings.ToLower(request.KeyVault.ServiceEndpointID),
+			Vault:             request.KeyVault.Vault,
+		}
+		enabled := true
+		for name := range request.Variables {
+			parameters.Variables[name] = AzureVariableValue{IsSecret: true, Enabled: &enabled}
+		}
+		return parameters, nil
+	}
+
+	parameters.Type = VariableGroupTypeVsts
+
+	currentHashes := &secretHashes{Hashes: map[string]string{}}
+	if current != nil && current.Type != VariableGroupTypeAzureKeyVault {
+		currentHashes = readSecretHashes(current)
+	}
+	hashes := &secretHashes{Hashes: map[string]string{}}
+
+	for name, variable := range request.Variables {
+		value := AzureVariableValue{Value: variable.Value, IsSecret: variable.IsSecret, IsReadOnly: variable.IsReadOnly}
+
+		if !variable.IsSecret {
+			if value.Value == nil {
+				empty := ""
+				value.Value = &empty
+			}
+			parameters.Variables[name] = value
+			continue
+		}
+
+		if variable.Value != nil {
+			hashes.Hashes[name] = hashSecret(hashKey, name, *variable.Value)
+		} else {
+			// Azure DevOps keeps the current value of a secret variable sent without value
+			currentVariable, exists := AzureVariableValue{}, false
+			if current != nil && current.Type != VariableGroupTypeAzureKeyVault {
+				currentVariable, exists = current.Variables[name]
+			}
+			if !exists || !currentVariable.IsSecret {
+				return nil, fmt.Errorf("value is required for new secret variable '%s'", name)
+			}
+			if hash, ok := currentHashes.Hashes[name]; ok {
+				hashes.Hashes[name] = hash
+			}
+		}
+		parameters.Variables[name] = value
+	}
+
+	if len(hashes.Hashes) > 0 {
+		hashesBytes, err := json.Marshal(hashes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal secret hashes: %w", err)
+		}
+		hashesValue := string(hashesBytes)
+		parameters.Variables[SecretHashesVariable] = AzureVariableValue{Value: &hashesValue, IsReadOnly: true}
+	}
+
+	return parameters, nil
+}
+
+// readSecretHashes returns the secret hashes stored in the given variable group
+// An empty set of hashes is returned if the variable is missing or invalid
+func readSecretHashes(group *AzureVariableGroup) *secretHashes {
+	hashes := &secretHashes{Hashes: map[string]string{}}
+	variable, exists := group.Variables[SecretHashesVariable]
+	if !exists || variable.Value == nil {
+		return hashes
+	}
+	if err := json.Unmarshal([]byte(*variable.Value), hashes); err != nil || hashes.Hashes == nil {
+		return &secretHashes{Hashes: map[string]string{}}
+	}
+	return hashes
+}
+
+// hashSecret returns the hash returned in place of the value of a secret variable
+// The hash is keyed with a key known only by the plugin, so that the hashes stored in the variable group
+// cannot be used to guess the secret values, and it is deterministic, so that an unchanged secret keeps the same hash
+func hashSecret(key []byte, name, value string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(name))
+	mac.Write([]byte{0})
+	mac.Write([]byte(value))
+	return SecretHashPrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// mapVariableGroupFromAzure converts an Azure DevOps variable group to the variable group returned by the plugin
+func mapVariableGroupFromAzure(group *AzureVariableGroup) *VariableGroup {
+	variableGroup := &VariableGroup{
+		ID:                group.ID,
+		Name:              group.Name,
+		Description:       group.Description,
+		Type:              group.Type,
+		Variables:         map[string]Variable{},
+		ProjectReferences: []VariableGroupProjectReference{},
+	}
+
+	if group.Type == VariableGroupTypeAzureKeyVault {
+		if group.ProviderData != nil {
+			variableGroup.KeyVault = &KeyVaultLink{
+				ServiceEndpointID: strings.ToLower(group.ProviderData.ServiceEndpointID),
+				Vault:             group.ProviderData.Vault,
+			}
+		}
+		for name := range group.Variables {
+			variableGroup.Variables[name] = Variable{IsSecret: true}
+		}
+	} else {
+		hashes := readSecretHashes(group)
+		for name, value := range group.Variables {
+			if name == SecretHashesVariable {
+				continue
+			}
+			variable := Variable{IsSecret: value.IsSecret, IsReadOnly: value.IsReadOnly}
+			if value.IsSecret {
+				variable.Value = hashes.Hashes[name]
+			} else if value.Value != nil {
+				variable.Value = *value.Value
+			}
+			variableGroup.Variables[name] = variable
+		}
+	}
+
+	for _, reference := range group.VariableGroupProjectReferences {
+		if reference.ProjectReference == nil {
+			continue
+		}
+		variableGroup.ProjectReferences = append(variableGroup.ProjectReferences, VariableGroupProjectReference{
+			ProjectID:   strings.ToLower(reference.ProjectReference.ID),
+			Name:        reference.Name,
+			Description: reference.Description,
+		})
+	}
+	sort.Slice(variableGroup.ProjectReferences, func(i, j int) bool {
+		return variableGroup.ProjectReferences[i].ProjectID < variableGroup.ProjectReferences[j].ProjectID
+	})
+
+	return variableGroup
+}
+
+// projectIDs returns the IDs of the projects the variable group is shared with, comma separated
+func projectIDs(group *AzureVariableGroup) string {
+	ids := []string{}
+	for _, reference := range group.VariableGroupProjectReferences {
+		if reference.ProjectReference != nil && reference.ProjectReference.ID != "" {
+			ids = append(ids, reference.ProjectReference.ID)
+		}
+	}
+	sort.Strings(ids)
+	return strings.Join(ids, ",")
+}
+
+// isEmptyBody reports whether Azure DevOps returned an empty body or null
+// Azure DevOps returns 200 with an empty body when the variable group does not exist
+func isEmptyBody(body []byte) bool {
+	trimmed := bytes.TrimSpace(body)
+	return len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null"))
+}
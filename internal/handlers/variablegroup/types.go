@@ -0,0 +1,131 @@
+package variablegroup
+
+// VariableGroup represents the variable group returned by the plugin
+// It is built from the AzureVariableGroup returned by:
+// GET /{organization}/{project}/_apis/distributedtask/variablegroups/{groupId}
+// Audit fields (createdBy, createdOn, modifiedBy, modifiedOn) are not returned since they change at every update
+type VariableGroup struct {
+	ID                int32                           `json:"id"`
+	Name              string                          `json:"name"`
+	Description       string                          `json:"description,omitempty"`
+	Type              string                          `json:"type"`      // enum: Vsts, AzureKeyVault
+	Variables         map[string]Variable             `json:"variables"` // Always present
+	KeyVault          *KeyVaultLink                   `json:"keyVault,omitempty"`
+	ProjectReferences []VariableGroupProjectReference `json:"projectReferences"` // Sorted by project ID
+}
+
+// Variable represents a variable of a variable group as returned by the plugin
+// The value of secret variables is replaced with a hash of the value set through the plugin (e.g., hmac-sha256:...)
+type Variable struct {
+	Value      string `json:"value,omitempty"`
+	IsSecret   bool   `json:"isSecret"`
+	IsReadOnly bool   `json:"isReadOnly"`
+}
+
+// KeyVaultLink represents the Azure Key Vault linked to a variable group
+type KeyVaultLink struct {
+	ServiceEndpointID string `json:"serviceEndpointId"` // Required - ID of the Azure Resource Manager service connection
+	Vault             string `json:"vault"`             // Required - Name of the key vault
+}
+
+// VariableGroupProjectReference represents a project the variable group is shared with
+type VariableGroupProjectReference struct {
+	ProjectID   string `json:"projectId"`      // Required
+	Name        string `json:"name,omitempty"` // Name of the variable group in the project, defaults to the name of the variable group
+	Description string `json:"description,omitempty"`
+}
+
+// VariableGroupRequest represents the request body for creating or updating a variable group
+type VariableGroupRequest struct {
+	Name              string                          `json:"name"` // Required
+	Description       string                          `json:"description,omitempty"`
+	Variables         map[string]VariableRequest      `json:"variables"` // Required - at least one variable
+	KeyVault          *KeyVaultLink                   `json:"keyVault,omitempty"`
+	ProjectReferences []VariableGroupProjectReference `json:"projectReferences,omitempty"` // Defaults to the project in the path
+}
+
+// VariableRequest represents a variable in the request body
+type VariableRequest struct {
+	Value      *string `json:"value,omitempty"` // Omit to keep the current value of a secret variable (update only), not allowed for Key Vault variable groups
+	IsSecret   bool    `json:"isSecret"`
+	IsReadOnly bool    `json:"isReadOnly"`
+}
+
+// VariableGroupResponse represents the response of the variable group endpoints
+type VariableGroupResponse VariableGroup
+
+// ---
+
+// AzureVariableGroup represents a variable group of Azure DevOps:
+// /{organization}/{project}/_apis/distributedtask/variablegroups/{groupId}
+type AzureVariableGroup struct {
+	ID                             int32                                `json:"id"`
+	Name                           string                               `json:"name"`
+	Description                    string                               `json:"description"`
+	Type                           string                               `json:"type"`
+	Variables                      map[string]AzureVariableValue        `json:"variables"`
+	ProviderData                   *AzureKeyVaultProviderData           `json:"providerData"`
+	VariableGroupProjectReferences []AzureVariableGroupProjectReference `json:"variableGroupProjectReferences"`
+}
+
+// AzureVariableValue represents a variable of an Azure DevOps variable group
+// Azure DevOps returns the value of secret variables as null
+type AzureVariableValue struct {
+	Value      *string `json:"value"`
+	IsSecret   bool    `json:"isSecret,omitempty"`
+	IsReadOnly bool    `json:"isReadOnly,omitempty"`
+	Enabled    *bool   `json:"enabled,omitempty"` // Only for Key Vault variable groups
+}
+
+// AzureKeyVaultProviderData represents the provider data of a variable group linked to an Azure Key Vault
+type AzureKeyVaultProviderData struct {
+	ServiceEndpointID string `json:"serviceEndpointId"`
+	Vault             string `json:"vault"`
+}
+
+// AzureVariableGroupProjectReference represents a project reference of an Azure DevOps variable group
+type AzureVariableGroupProjectReference struct {
+	Name             string            `json:"name"`
+	Description      string            `json:"description"`
+	ProjectReference *ProjectReference `json:"projectReference"`
+}
+
+// ProjectReference represents a reference to a project
+type ProjectReference struct {
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+// AzureVariableGroupParameters represents the request body for:
+// POST /{organization}/_apis/distributedtask/variablegroups
+// PUT /{organization}/_apis/distributedtask/variablegroups/{groupId}
+type AzureVariableGroupParameters struct {
+	Name                           string                               `json:"name"`
+	Description                    string                               `json:"description"`
+	Type                           string                               `json:"type"`
+	Variables                      map[string]AzureVariableValue        `json:"variables"`
+	ProviderData                   *AzureKeyVaultProviderData           `json:"providerData,omitempty"`
+	VariableGroupProjectReferences []AzureVariableGroupProjectReference `json:"variableGroupProjectReferences"`
+}
+
+// secretHashes represents the value of the SecretHashesVariable variable
+type secretHashes struct {
+	Hashes map[string]string `json:"hashes"`
+}
+
+// Variable group types
+const (
+	VariableGroupTypeVsts          = "Vsts"
+	VariableGroupTypeAzureKeyVault = "AzureKeyVault"
+)
+
+// SecretHashesVariable is the read only variable where the plugin stores the hashes of the secret variables
+// Azure DevOps never returns secret values, so the hashes are computed when the values are set through the plugin
+const SecretHashesVariable = "krateo.secretHashes"
+
+// SecretHashPrefix is the prefix of the hashes returned in place of secret values
+const SecretHashPrefix = "hmac-sha256:"
+
+// SecretHashKeyEnv is the environment variable with the key of the hashes of the secret values
+// The key is never sent to Azure DevOps, a random key is used if it is not set
+const SecretHashKeyEnv = "VARIABLE_GROUP_SECRET_HASH_KEY"
@@ -0,0 +1,386 @@
+package variablegroup
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers"
+)
+
+// errVariableGroupNotFound is returned when the variable group does not exist
+var errVariableGroupNotFound = errors.New("variable group not found")
+
+// Handler constructors
+func GetVariableGroup(opts handlers.HandlerOptions) handlers.Handler {
+	return &getHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func PostVariableGroup(opts handlers.HandlerOptions) handlers.Handler {
+	return &postHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func PutVariableGroup(opts handlers.HandlerOptions) handlers.Handler {
+	return &putHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func DeleteVariableGroup(opts handlers.HandlerOptions) handlers.Handler {
+	return &deleteHandler{baseHandler: newBaseHandler(opts)}
+}
+
+// Interface compliance verification
+var _ handlers.Handler = &getHandler{}
+var _ handlers.Handler = &postHandler{}
+var _ handlers.Handler = &putHandler{}
+var _ handlers.Handler = &deleteHandler{}
+
+// Base handler with common functionality
+type baseHandler struct {
+	*handlers.BaseHandler
+}
+
+// Constructor for the base handler
+func newBaseHandler(opts handlers.HandlerOptions) *baseHandler {
+	return &baseHandler{BaseHandler: handlers.NewBaseHandler(opts)}
+}
+
+// Handler types embedding the base handler
+type getHandler struct {
+	*baseHandler
+}
+
+type postHandler struct {
+	*baseHandler
+}
+
+type putHandler struct {
+	*baseHandler
+}
+
+type deleteHandler struct {
+	*baseHandler
+}
+
+// randomSecretHashKey returns the key of the hashes of the secret values used when SecretHashKeyEnv is not set
+// The key is generated once per process, so a secret set again after a restart gets a new hash
+var randomSecretHashKey = sync.OnceValue(func() []byte {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		panic(fmt.Sprintf("failed to generate secret hash key: %v", err))
+	}
+	return key
+})
+
+// secretHashKey returns the key of the hashes of the secret values, read from the environment variable SecretHashKeyEnv
+func (h *baseHandler) secretHashKey() []byte {
+	if key := os.Getenv(SecretHashKeyEnv); key != "" {
+		return []byte(key)
+	}
+	h.Log.Printf("%s environment variable not set, using a random key for the hashes of the secret values", SecretHashKeyEnv)
+	return randomSecretHashKey()
+}
+
+// writeVariableGroupError writes the response for an error returned while managing a variable group
+// 404 is returned with a custom message, other Azure DevOps errors are forwarded as they are
+func (h *baseHandler) writeVariableGroupError(w http.ResponseWriter, err error, action, id string) {
+	var adoErr *handlers.AzureDevOpsError
+	switch {
+	case errors.Is(err, errVariableGroupNotFound):
+		h.WriteErrorResponse(w, http.StatusNotFound, fmt.Sprintf("Variable group with ID %s not found", id))
+	case errors.As(err, &adoErr) && adoErr.StatusCode == http.StatusNotFound:
+		h.WriteErrorResponse(w, http.StatusNotFound, fmt.Sprintf("Variable group with ID %s not found", id))
+	case errors.As(err, &adoErr):
+		h.WriteJSONResponse(w, adoErr.StatusCode, adoErr.Body)
+	default:
+		h.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Failed to %s: %v", action, err))
+	}
+}
+
+// writeVariableGroupResponse decodes a variable group returned by Azure DevOps and writes it
+func (h *baseHandler) writeVariableGroupResponse(w http.ResponseWriter, statusCode int, body []byte) {
+	var group AzureVariableGroup
+	if err := json.Unmarshal(body, &group); err != nil {
+		h.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Failed to unmarshal variable group: %v", err))
+		return
+	}
+
+	responseBytes, err := json.Marshal(VariableGroupResponse(*mapVariableGroupFromAzure(&group)))
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Failed to marshal response: %v", err))
+		return
+	}
+	h.WriteJSONResponse(w, statusCode, responseBytes)
+}
+
+// getVariableGroup retrieves a variable group
+// errVariableGroupNotFound is returned if the variable group does not exist
+func (h *baseHandler) getVariableGroup(organization, projectID, id, apiVersion, authHeader string) (*AzureVariableGroup, []byte, error) {
+	url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/distributedtask/variablegroups/%s?api-version=%s", organization, projectID, id, apiVersion)
+
+	body, err := h.DoAzureDevOpsRequest("GET", url, authHeader, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	if isEmptyBody(body) {
+		return nil, nil, errVariableGroupNotFound
+	}
+
+	var group AzureVariableGroup
+	if err := json.Unmarshal(body, &group); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal variable group: %w", err)
+	}
+
+	return &group, body, nil
+}
+
+// readVariableGroupRequest reads and validates the variable group in the request body
+func (h *baseHandler) readVariableGroupRequest(w http.ResponseWriter, r *http.Request) (*VariableGroupRequest, bool) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Failed to read request body")
+		return nil, false
+	}
+
+	var request VariableGroupRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Invalid JSON in request body")
+		return nil, false
+	}
+	if err := validateVariableGroupRequest(&request); err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid variable group: %v", err))
+		return nil, false
+	}
+
+	return &request, true
+}
+
+// GET handler implementation
+// @Summary Get a variable group
+// @Description Get a variable group. Secret values are replaced with a hash of the value set through the plugin, audit fields are not returned and project references are sorted by project ID.
+// @ID get-variablegroup
+// @Param organization path string true "Organization name"
+// @Param projectId path string true "Project ID"
+// @Param id path string true "Variable group ID"
+// @Param api-version query string true "API version (e.g., 7.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Produce json
+// @Success 200 {object} VariableGroupResponse "Variable group details"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{projectId}/variablegroups/{id} [get]
+func (h *getHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	projectID := r.PathValue("projectId")
+	id := r.PathValue("id")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, projectID, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	h.Log.Printf("Getting variable group with ID %s for organization %s and project %s", id, organization, projectID)
+
+	_, body, err := h.getVariableGroup(organization, projectID, id, apiVersion, authHeader)
+	if err != nil {
+		h.writeVariableGroupError(w, err, "get variable group", id)
+		return
+	}
+
+	h.writeVariableGroupResponse(w, http.StatusOK, body)
+	h.Log.Printf("Successfully retrieved variable group with ID %s", id)
+}
+
+// POST handler implementation
+// @Summary Create a variable group
+// @Description Create a variable group, optionally linked to an Azure Key Vault and shared with other projects
+// @ID post-variablegroup
+// @Param organization path string true "Organization name"
+// @Param projectId path string true "Project ID"
+// @Param api-version query string true "API version (e.g., 7.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Param variableGroup body VariableGroupRequest true "Variable group creation request body"
+// @Accept json
+// @Produce json
+// @Success 201 {object} VariableGroupResponse "Created variable group"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{projectId}/variablegroups [post]
+func (h *postHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	projectID := r.PathValue("projectId")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, projectID, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	request, ok := h.readVariableGroupRequest(w, r)
+	if !ok {
+		return
+	}
+
+	h.Log.Printf("Creating variable group %s for organization %s and project %s", request.Name, organization, projectID)
+
+	parameters, err := buildVariableGroupParameters(request, projectID, nil, h.secretHashKey())
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid variable group: %v", err))
+		return
+	}
+	requestBody, err := json.Marshal(parameters)
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Failed to marshal variable group: %v", err))
+		return
+	}
+
+	// Variable groups are created at organization level and shared with the projects in the references
+	url := fmt.Sprintf("https://dev.azure.com/%s/_apis/distributedtask/variablegroups?api-version=%s", organization, apiVersion)
+	responseBody, err := h.DoAzureDevOpsRequest("POST", url, authHeader, requestBody)
+	if err != nil {
+		var adoErr *handlers.AzureDevOpsError
+		if errors.As(err, &adoErr) {
+			h.WriteJSONResponse(w, adoErr.StatusCode, adoErr.Body)
+			return
+		}
+		h.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Failed to create variable group: %v", err))
+		return
+	}
+
+	h.writeVariableGroupResponse(w, http.StatusCreated, responseBody)
+	h.Log.Printf("Successfully created variable group %s", request.Name)
+}
+
+// PUT handler implementation
+// @Summary Update a variable group
+// @Description Replace a variable group. Secret variables sent without value keep their current value.
+// @ID put-variablegroup
+// @Param organization path string true "Organization name"
+// @Param projectId path string true "Project ID"
+// @Param id path string true "Variable group ID"
+// @Param api-version query string true "API version (e.g., 7.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Param variableGroup body VariableGroupRequest true "Variable group update request body"
+// @Accept json
+// @Produce json
+// @Success 200 {object} VariableGroupResponse "Updated variable group"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{projectId}/variablegroups/{id} [put]
+func (h *putHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	projectID := r.PathValue("projectId")
+	id := r.PathValue("id")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, projectID, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	request, ok := h.readVariableGroupRequest(w, r)
+	if !ok {
+		return
+	}
+
+	h.Log.Printf("Updating variable group with ID %s for organization %s and project %s", id, organization, projectID)
+
+	// The current variable group is needed to keep the secret values not sent in the request
+	current, _, err := h.getVariableGroup(organization, projectID, id, apiVersion, authHeader)
+	if err != nil {
+		h.writeVariableGroupError(w, err, "get variable group", id)
+		return
+	}
+
+	parameters, err := buildVariableGroupParameters(request, projectID, current, h.secretHashKey())
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid variable group: %v", err))
+		return
+	}
+	requestBody, err := json.Marshal(parameters)
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Failed to marshal variable group: %v", err))
+		return
+	}
+
+	url := fmt.Sprintf("https://dev.azure.com/%s/_apis/distributedtask/variablegroups/%s?api-version=%s", organization, id, apiVersion)
+	responseBody, err := h.DoAzureDevOpsRequest("PUT", url, authHeader, requestBody)
+	if err != nil {
+		h.writeVariableGroupError(w, err, "update variable group", id)
+		return
+	}
+
+	h.writeVariableGroupResponse(w, http.StatusOK, responseBody)
+	h.Log.Printf("Successfully updated variable group with ID %s", id)
+}
+
+// DELETE handler implementation
+// @Summary Delete a variable group
+// @Description Delete a variable group from all the projects it is shared with
+// @ID delete-variablegroup
+// @Param organization path string true "Organization name"
+// @Param projectId path string true "Project ID"
+// @Param id path string true "Variable group ID"
+// @Param api-version query string true "API version (e.g., 7.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Success 204 "No Content"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{projectId}/variablegroups/{id} [delete]
+func (h *deleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	projectID := r.PathValue("projectId")
+	id := r.PathValue("id")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, projectID, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	h.Log.Printf("Deleting variable group with ID %s for organization %s and project %s", id, organization, projectID)
+
+	// Azure DevOps requires the IDs of the projects to delete the variable group from
+	current, _, err := h.getVariableGroup(organization, projectID, id, apiVersion, authHeader)
+	if err != nil {
+		h.writeVariableGroupError(w, err, "get variable group", id)
+		return
+	}
+	projects := projectIDs(current)
+	if projects == "" {
+		projects = projectID
+	}
+
+	url := fmt.Sprintf("https://dev.azure.com/%s/_apis/distributedtask/variablegroups/%s?projectIds=%s&api-version=%s", organization, id, projects, apiVersion)
+	if _, err := h.DoAzureDevOpsRequest("DELETE", url, authHeader, nil); err != nil {
+		h.writeVariableGroupError(w, err, "delete variable group", id)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	h.Log.Printf("Successfully deleted variable group with ID %s", id)
+}
@@ -0,0 +1,371 @@
+package variablegroup
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers"
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/handlerstest"
+	"github.com/rs/zerolog"
+)
+
+// Test data constants
+const (
+	testOrg        = "testorg"
+	testProjectID  = "0d5ba2a0-4d8e-4b1f-8f49-7c1f5f8c2e11"
+	testGroupID    = "5"
+	testAPIVersion = "7.1"
+	testUsername   = "test"
+	testPassword   = "test"
+	testHashKey    = "test-hash-key"
+)
+
+var (
+	groupsURL         = fmt.Sprintf("https://dev.azure.com/%s/_apis/distributedtask/variablegroups?api-version=%s", testOrg, testAPIVersion)
+	groupURL          = fmt.Sprintf("https://dev.azure.com/%s/_apis/distributedtask/variablegroups/%s?api-version=%s", testOrg, testGroupID, testAPIVersion)
+	projectGroupURL   = fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/distributedtask/variablegroups/%s?api-version=%s", testOrg, testProjectID, testGroupID, testAPIVersion)
+	testSecretHash    = hashSecret([]byte(testHashKey), "password", "s3cr3t")
+	hashesValue       = fmt.Sprintf(`{"hashes":{"password":%q}}`, testSecretHash)
+	hashesVariable    = fmt.Sprintf(`{"value":%q,"isReadOnly":true}`, hashesValue)
+	validGroupResp    = fmt.Sprintf(`{"id":5,"name":"app","description":"App settings","type":"Vsts","createdOn":"2025-06-30T14:33:02.06Z","variables":{"user":{"value":"admin"},"password":{"value":null,"isSecret":true},%q:%s},"variableGroupProjectReferences":[{"name":"app","projectReference":{"id":"F1E2D3C4-0000-0000-0000-000000000000"}},{"name":"app","projectReference":{"id":%q}}]}`, SecretHashesVariable, hashesVariable, testProjectID)
+	keyVaultGroupResp = `{"id":6,"name":"kv","type":"AzureKeyVault","providerData":{"serviceEndpointId":"AB12CD34-0000-0000-0000-000000000000","vault":"my-vault"},"variables":{"db-password":{"isSecret":true,"enabled":true,"contentType":""}},"variableGroupProjectReferences":[{"name":"kv","projectReference":{"id":"0d5ba2a0-4d8e-4b1f-8f49-7c1f5f8c2e11"}}]}`
+)
+
+// createTestHandler creates a handler for testing with a mock client
+func createTestHandler(mockClient *handlerstest.MockHTTPClient, newHandler func(*baseHandler) http.Handler) http.Handler {
+	return newHandler(newBaseHandler(handlerstest.NewHandlerOptions(mockClient)))
+}
+
+// serveTestRequest sends a request with the variable group path values to the handler
+func serveTestRequest(handler http.Handler, method, id, body string) *httptest.ResponseRecorder {
+	var bodyReader io.Reader
+	if body != "" {
+		bodyReader = strings.NewReader(body)
+	}
+	req := httptest.NewRequest(method, "/api/test?api-version="+testAPIVersion, bodyReader)
+	req.SetPathValue("organization", testOrg)
+	req.SetPathValue("projectId", testProjectID)
+	if id != "" {
+		req.SetPathValue("id", id)
+	}
+	req.SetBasicAuth(testUsername, testPassword)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	return rr
+}
+
+// Test constructor functions
+func TestConstructors(t *testing.T) {
+	client := &http.Client{}
+	logger := zerolog.New(os.Stdout).With().Timestamp().Logger()
+	opts := handlers.HandlerOptions{
+		Client: client,
+		Log:    &logger,
+	}
+
+	for name, constructor := range map[string]func(handlers.HandlerOptions) handlers.Handler{
+		"GetVariableGroup":    GetVariableGroup,
+		"PostVariableGroup":   PostVariableGroup,
+		"PutVariableGroup":    PutVariableGroup,
+		"DeleteVariableGroup": DeleteVariableGroup,
+	} {
+		t.Run(name, func(t *testing.T) {
+			if h := constructor(opts); h == nil {
+				t.Fatalf("%s should return a non-nil handler", name)
+			}
+		})
+	}
+}
+
+// Test GET handler
+func TestGetHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name           string
+		setupMock      func(*handlerstest.MockHTTPClient)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "secret replaced with hash and references sorted",
+			setupMock:      func(m *handlerstest.MockHTTPClient) { m.SetResponse(projectGroupURL, http.StatusOK, validGroupResp) },
+			expectedStatus: http.StatusOK,
+			expectedBody:   fmt.Sprintf(`{"id":5,"name":"app","description":"App settings","type":"Vsts","variables":{"password":{"value":%q,"isSecret":true,"isReadOnly":false},"user":{"value":"admin","isSecret":false,"isReadOnly":false}},"projectReferences":[{"projectId":%q,"name":"app"},{"projectId":"f1e2d3c4-0000-0000-0000-000000000000","name":"app"}]}`, testSecretHash, testProjectID),
+		},
+		{
+			name:           "key vault variable group",
+			setupMock:      func(m *handlerstest.MockHTTPClient) { m.SetResponse(projectGroupURL, http.StatusOK, keyVaultGroupResp) },
+			expectedStatus: http.StatusOK,
+			expectedBody:   fmt.Sprintf(`{"id":6,"name":"kv","type":"AzureKeyVault","variables":{"db-password":{"isSecret":true,"isReadOnly":false}},"keyVault":{"serviceEndpointId":"ab12cd34-0000-0000-0000-000000000000","vault":"my-vault"},"projectReferences":[{"projectId":%q,"name":"kv"}]}`, testProjectID),
+		},
+		{
+			name:           "empty body means not found",
+			setupMock:      func(m *handlerstest.MockHTTPClient) { m.SetResponse(projectGroupURL, http.StatusOK, "") },
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   "Variable group with ID 5 not found",
+		},
+		{
+			name: "azure devops error forwarded",
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(projectGroupURL, http.StatusForbidden, `{"message":"Forbidden"}`)
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `{"message":"Forbidden"}`,
+		},
+		{
+			name:           "network error",
+			setupMock:      func(m *handlerstest.MockHTTPClient) { m.SetError(projectGroupURL, fmt.Errorf("connection refused")) },
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := handlerstest.NewMockHTTPClient()
+			tt.setupMock(mockClient)
+			handler := createTestHandler(mockClient, func(b *baseHandler) http.Handler { return &getHandler{baseHandler: b} })
+
+			rr := serveTestRequest(handler, "GET", testGroupID, "")
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("Status code = %d, want %d. Body: %s", rr.Code, tt.expectedStatus, rr.Body.String())
+			}
+			if tt.expectedBody != "" && rr.Body.String() != tt.expectedBody {
+				t.Errorf("Body = %s, want %s", rr.Body.String(), tt.expectedBody)
+			}
+		})
+	}
+}
+
+// Test POST handler
+func TestPostHandler_ServeHTTP(t *testing.T) {
+	t.Setenv(SecretHashKeyEnv, testHashKey)
+
+	t.Run("creates a variable group shared with the project in the path", func(t *testing.T) {
+		mockClient := handlerstest.NewMockHTTPClient()
+		mockClient.SetResponse(groupsURL, http.StatusOK, validGroupResp)
+		handler := createTestHandler(mockClient, func(b *baseHandler) http.Handler { return &postHandler{baseHandler: b} })
+
+		rr := serveTestRequest(handler, "POST", "", `{"name":"app","variables":{"user":{"value":"admin"},"password":{"value":"s3cr3t","isSecret":true}}}`)
+
+		if rr.Code != http.StatusCreated {
+			t.Fatalf("Status code = %d, want %d. Body: %s", rr.Code, http.StatusCreated, rr.Body.String())
+		}
+
+		var sent AzureVariableGroupParameters
+		if err := json.Unmarshal([]byte(mockClient.Bodies[0]), &sent); err != nil {
+			t.Fatalf("Failed to unmarshal request body: %v", err)
+		}
+		if sent.Type != VariableGroupTypeVsts {
+			t.Errorf("Type = %s, want %s", sent.Type, VariableGroupTypeVsts)
+		}
+		if len(sent.VariableGroupProjectReferences) != 1 || sent.VariableGroupProjectReferences[0].ProjectReference.ID != testProjectID {
+			t.Errorf("Project references = %+v, want the project in the path", sent.VariableGroupProjectReferences)
+		}
+		if sent.Variables["password"].Value == nil || *sent.Variables["password"].Value != "s3cr3t" {
+			t.Error("Secret value should be sent to Azure DevOps")
+		}
+		hashes := readSecretHashes(&AzureVariableGroup{Variables: sent.Variables})
+		if hashes.Hashes["password"] != testSecretHash {
+			t.Errorf("Secret hashes = %+v, want the hash of password", hashes)
+		}
+	})
+
+	t.Run("creates a key vault variable group", func(t *testing.T) {
+		mockClient := handlerstest.NewMockHTTPClient()
+		mockClient.SetResponse(groupsURL, http.StatusOK, keyVaultGroupResp)
+		handler := createTestHandler(mockClient, func(b *baseHandler) http.Handler { return &postHandler{baseHandler: b} })
+
+		rr := serveTestRequest(handler, "POST", "", `{"name":"kv","keyVault":{"serviceEndpointId":"AB12CD34-0000-0000-0000-000000000000","vault":"my-vault"},"variables":{"db-password":{}}}`)
+
+		if rr.Code != http.StatusCreated {
+			t.Fatalf("Status code = %d, want %d. Body: %s", rr.Code, http.StatusCreated, rr.Body.String())
+		}
+		want := fmt.Sprintf(`{"name":"kv","description":"","type":"AzureKeyVault","variables":{"db-password":{"value":null,"isSecret":true,"enabled":true}},"providerData":{"serviceEndpointId":"ab12cd34-0000-0000-0000-000000000000","vault":"my-vault"},"variableGroupProjectReferences":[{"name":"kv","description":"","projectReference":{"id":%q}}]}`, testProjectID)
+		if mockClient.Bodies[0] != want {
+			t.Errorf("Request body = %s, want %s", mockClient.Bodies[0], want)
+		}
+	})
+
+	for _, tt := range []struct {
+		name string
+		body string
+		want string
+	}{
+		{name: "missing name", body: `{"variables":{"a":{"value":"b"}}}`, want: "Invalid variable group: name is required"},
+		{name: "no variables", body: `{"name":"app"}`, want: "Invalid variable group: at least one variable is required"},
+		{name: "reserved variable", body: `{"name":"app","variables":{"krateo.secretHashes":{"value":"b"}}}`, want: "Invalid variable group: variable name 'krateo.secretHashes' is reserved"},
+		{name: "secret without value", body: `{"name":"app","variables":{"password":{"isSecret":true}}}`, want: "Invalid variable group: value is required for new secret variable 'password'"},
+		{name: "key vault variable with value", body: `{"name":"kv","keyVault":{"serviceEndpointId":"se","vault":"v"},"variables":{"a":{"value":"b"}}}`, want: "Invalid variable group: variable 'a' must not have a value, values of Key Vault variable groups are read from the key vault"},
+		{name: "duplicate project reference", body: `{"name":"app","variables":{"a":{"value":"b"}},"projectReferences":[{"projectId":"A"},{"projectId":"a"}]}`, want: "Invalid variable group: projectReferences: project a is referenced more than once"},
+		{name: "invalid JSON", body: `{"name":`, want: "Invalid JSON in request body"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := handlerstest.NewMockHTTPClient()
+			handler := createTestHandler(mockClient, func(b *baseHandler) http.Handler { return &postHandler{baseHandler: b} })
+
+			rr := serveTestRequest(handler, "POST", "", tt.body)
+
+			if rr.Code != http.StatusBadRequest || rr.Body.String() != tt.want {
+				t.Errorf("Response = %d %s, want 400 %s", rr.Code, rr.Body.String(), tt.want)
+			}
+			if len(mockClient.Requests) != 0 {
+				t.Errorf("Expected no requests to Azure DevOps, got %d", len(mockClient.Requests))
+			}
+		})
+	}
+}
+
+// Test PUT handler
+func TestPutHandler_ServeHTTP(t *testing.T) {
+	t.Setenv(SecretHashKeyEnv, testHashKey)
+
+	t.Run("secret without value keeps its value and hash", func(t *testing.T) {
+		mockClient := handlerstest.NewMockHTTPClient()
+		mockClient.SetResponse(projectGroupURL, http.StatusOK, validGroupResp)
+		mockClient.SetMethodResponse("PUT", groupURL, http.StatusOK, validGroupResp)
+		handler := createTestHandler(mockClient, func(b *baseHandler) http.Handler { return &putHandler{baseHandler: b} })
+
+		rr := serveTestRequest(handler, "PUT", testGroupID, `{"name":"app","variables":{"user":{"value":"root"},"password":{"isSecret":true}}}`)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Status code = %d, want %d. Body: %s", rr.Code, http.StatusOK, rr.Body.String())
+		}
+		var sent AzureVariableGroupParameters
+		if err := json.Unmarshal([]byte(mockClient.Bodies[1]), &sent); err != nil {
+			t.Fatalf("Failed to unmarshal request body: %v", err)
+		}
+		if sent.Variables["password"].Value != nil {
+			t.Error("Secret without value should be sent without value")
+		}
+		hashes := readSecretHashes(&AzureVariableGroup{Variables: sent.Variables})
+		if hashes.Hashes["password"] != testSecretHash {
+			t.Errorf("Secret hashes = %+v, want the current hash", hashes)
+		}
+	})
+
+	t.Run("unchanged secret produces no diff", func(t *testing.T) {
+		mockClient := handlerstest.NewMockHTTPClient()
+		mockClient.SetResponse(projectGroupURL, http.StatusOK, validGroupResp)
+		getRR := serveTestRequest(createTestHandler(mockClient, func(b *baseHandler) http.Handler { return &getHandler{baseHandler: b} }), "GET", testGroupID, "")
+
+		mockClient = handlerstest.NewMockHTTPClient()
+		mockClient.SetResponse(projectGroupURL, http.StatusOK, validGroupResp)
+		mockClient.SetMethodResponse("PUT", groupURL, http.StatusOK, validGroupResp)
+		handler := createTestHandler(mockClient, func(b *baseHandler) http.Handler { return &putHandler{baseHandler: b} })
+
+		rr := serveTestRequest(handler, "PUT", testGroupID, `{"name":"app","description":"App settings","variables":{"user":{"value":"admin"},"password":{"value":"s3cr3t","isSecret":true}},"projectReferences":[{"projectId":"F1E2D3C4-0000-0000-0000-000000000000"},{"projectId":"0d5ba2a0-4d8e-4b1f-8f49-7c1f5f8c2e11"}]}`)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Status code = %d, want %d. Body: %s", rr.Code, http.StatusOK, rr.Body.String())
+		}
+		var sent AzureVariableGroupParameters
+		if err := json.Unmarshal([]byte(mockClient.Bodies[1]), &sent); err != nil {
+			t.Fatalf("Failed to unmarshal request body: %v", err)
+		}
+		if value := sent.Variables[SecretHashesVariable].Value; value == nil || *value != hashesValue {
+			t.Errorf("Secret hashes = %v, want the stored hashes %s", value, hashesValue)
+		}
+		if rr.Body.String() != getRR.Body.String() {
+			t.Errorf("PUT response = %s, want the GET response %s", rr.Body.String(), getRR.Body.String())
+		}
+	})
+
+	t.Run("changed secret gets a new hash", func(t *testing.T) {
+		mockClient := handlerstest.NewMockHTTPClient()
+		mockClient.SetResponse(projectGroupURL, http.StatusOK, validGroupResp)
+		mockClient.SetMethodResponse("PUT", groupURL, http.StatusOK, validGroupResp)
+		handler := createTestHandler(mockClient, func(b *baseHandler) http.Handler { return &putHandler{baseHandler: b} })
+
+		rr := serveTestRequest(handler, "PUT", testGroupID, `{"name":"app","variables":{"password":{"value":"changed","isSecret":true}}}`)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Status code = %d, want %d. Body: %s", rr.Code, http.StatusOK, rr.Body.String())
+		}
+		var sent AzureVariableGroupParameters
+		if err := json.Unmarshal([]byte(mockClient.Bodies[1]), &sent); err != nil {
+			t.Fatalf("Failed to unmarshal request body: %v", err)
+		}
+		hashes := readSecretHashes(&AzureVariableGroup{Variables: sent.Variables})
+		if hashes.Hashes["password"] != hashSecret([]byte(testHashKey), "password", "changed") {
+			t.Errorf("Secret hashes = %+v, want the hash of the new value", hashes)
+		}
+	})
+
+	t.Run("variable group not found", func(t *testing.T) {
+		mockClient := handlerstest.NewMockHTTPClient()
+		mockClient.SetResponse(projectGroupURL, http.StatusOK, "null")
+		handler := createTestHandler(mockClient, func(b *baseHandler) http.Handler { return &putHandler{baseHandler: b} })
+
+		rr := serveTestRequest(handler, "PUT", testGroupID, `{"name":"app","variables":{"a":{"value":"b"}}}`)
+
+		if rr.Code != http.StatusNotFound {
+			t.Errorf("Status code = %d, want %d", rr.Code, http.StatusNotFound)
+		}
+	})
+}
+
+// Test DELETE handler
+func TestDeleteHandler_ServeHTTP(t *testing.T) {
+	t.Run("deletes the variable group from all shared projects", func(t *testing.T) {
+		deleteURL := fmt.Sprintf("https://dev.azure.com/%s/_apis/distributedtask/variablegroups/%s?projectIds=%s,F1E2D3C4-0000-0000-0000-000000000000&api-version=%s", testOrg, testGroupID, testProjectID, testAPIVersion)
+		mockClient := handlerstest.NewMockHTTPClient()
+		mockClient.SetResponse(projectGroupURL, http.StatusOK, validGroupResp)
+		mockClient.SetMethodResponse("DELETE", deleteURL, http.StatusNoContent, "")
+		handler := createTestHandler(mockClient, func(b *baseHandler) http.Handler { return &deleteHandler{baseHandler: b} })
+
+		rr := serveTestRequest(handler, "DELETE", testGroupID, "")
+
+		if rr.Code != http.StatusNoContent {
+			t.Errorf("Status code = %d, want %d. Body: %s", rr.Code, http.StatusNoContent, rr.Body.String())
+		}
+	})
+
+	t.Run("variable group not found", func(t *testing.T) {
+		mockClient := handlerstest.NewMockHTTPClient()
+		mockClient.SetResponse(projectGroupURL, http.StatusOK, "")
+		handler := createTestHandler(mockClient, func(b *baseHandler) http.Handler { return &deleteHandler{baseHandler: b} })
+
+		rr := serveTestRequest(handler, "DELETE", testGroupID, "")
+
+		if rr.Code != http.StatusNotFound || len(mockClient.Requests) != 1 {
+			t.Errorf("Response = %d after %d requests, want 404 after 1 request", rr.Code, len(mockClient.Requests))
+		}
+	})
+}
+
+func TestHashSecret(t *testing.T) {
+	key := []byte("key")
+	if hashSecret(key, "name", "value") != hashSecret(key, "name", "value") {
+		t.Error("hashSecret should be deterministic")
+	}
+	if hashSecret(key, "name", "value") == hashSecret([]byte("other"), "name", "value") {
+		t.Error("hashSecret should depend on the key")
+	}
+	if hashSecret(key, "a", "bc") == hashSecret(key, "ab", "c") {
+		t.Error("hashSecret should separate name and value")
+	}
+	if !strings.HasPrefix(hashSecret(key, "name", "value"), SecretHashPrefix) {
+		t.Errorf("hashSecret should start with %s", SecretHashPrefix)
+	}
+}
+
+func TestSecretHashKey(t *testing.T) {
+	h := newBaseHandler(handlerstest.NewHandlerOptions(handlerstest.NewMockHTTPClient()))
+
+	t.Setenv(SecretHashKeyEnv, testHashKey)
+	if string(h.secretHashKey()) != testHashKey {
+		t.Errorf("secretHashKey() = %s, want the key of %s", h.secretHashKey(), SecretHashKeyEnv)
+	}
+
+	t.Setenv(SecretHashKeyEnv, "")
+	if key := h.secretHashKey(); len(key) != 32 || string(key) != string(h.secretHashKey()) {
+		t.Errorf("secretHashKey() = %x, want the same random key at every call", key)
+	}
+}
@@ -0,0 +1,307 @@
+package wiki
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	neturl "net/url"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers"
+)
+
+// pageURL returns the URL of the wiki page, in the branch of a code wiki when version is set
+func pageURL(organization, projectId, wikiIdentifier, pagePath, version string, includeContent bool, apiVersion string) string {
+	query := neturl.Values{}
+	query.Set("path", pagePath)
+	if includeContent {
+		query.Set("includeContent", "true")
+	}
+	if version != "" {
+		query.Set("versionDescriptor.version", version)
+	}
+	query.Set("api-version", apiVersion)
+	return fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/wiki/wikis/%s/pages?%s", organization, projectId, wikiIdentifier, query.Encode())
+}
+
+// getPage returns the wiki page with its content and its ETag
+func (h *baseHandler) getPage(organization, projectId, wikiIdentifier, pagePath, version, apiVersion, authHeader string) (*WikiPage, string, error) {
+	body, header, err := h.DoAzureDevOpsRequestWithHeader("GET", pageURL(organization, projectId, wikiIdentifier, pagePath, version, true, apiVersion), authHeader, nil, nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var page WikiPage
+	if err := json.Unmarshal(body, &page); err != nil {
+		return nil, "", fmt.Errorf("failed to unmarshal wiki page: %w", err)
+	}
+	return &page, header.Get("ETag"), nil
+}
+
+// putPage creates the wiki page, or updates it if eTag is set, and returns the page with its new ETag
+// Azure DevOps rejects the update with 412 if the page was changed since eTag was read
+func (h *baseHandler) putPage(organization, projectId, wikiIdentifier, pagePath, version, content, eTag, apiVersion, authHeader string) (*WikiPage, string, error) {
+	requestBody, err := json.Marshal(WikiPageCreateOrUpdateParameters{Content: content})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to marshal wiki page: %w", err)
+	}
+
+	requestHeader := http.Header{}
+	if eTag != "" {
+		requestHeader.Set("If-Match", eTag)
+	}
+	body, header, err := h.DoAzureDevOpsRequestWithHeader("PUT", pageURL(organization, projectId, wikiIdentifier, pagePath, version, false, apiVersion), authHeader, requestBody, requestHeader)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var page WikiPage
+	if err := json.Unmarshal(body, &page); err != nil {
+		return nil, "", fmt.Errorf("failed to unmarshal wiki page: %w", err)
+	}
+	return &page, header.Get("ETag"), nil
+}
+
+// isNotFound reports whether Azure DevOps returned 404
+func isNotFound(err error) bool {
+	var adoErr *handlers.AzureDevOpsError
+	return errors.As(err, &adoErr) && adoErr.StatusCode == http.StatusNotFound
+}
+
+// writePageError writes the response for an error returned while reading or writing a wiki page
+// A 412 of Azure DevOps means that the page was changed concurrently, the other errors are written as writeAzureDevOpsError does
+func (h *baseHandler) writePageError(w http.ResponseWriter, err error, action, pagePath, notFoundMessage string) {
+	var adoErr *handlers.AzureDevOpsError
+	if errors.As(err, &adoErr) && adoErr.StatusCode == http.StatusPreconditionFailed {
+		h.WriteErrorResponse(w, http.StatusPreconditionFailed, fmt.Sprintf("Precondition failed: page %s was changed since it was read", pagePath))
+		return
+	}
+	h.WriteAzureDevOpsError(w, err, action, notFoundMessage)
+}
+
+// writePageResponse writes the page and its ETag, which is also returned in the ETag header
+func (h *baseHandler) writePageResponse(w http.ResponseWriter, statusCode int, page *WikiPage, eTag string) {
+	if eTag != "" {
+		w.Header().Set("ETag", eTag)
+	}
+	h.WriteObjectResponse(w, statusCode, PageResponse(*mapPageFromAzure(page, eTag)))
+}
+
+// validatePagePath checks and normalizes the path of the page, the root page cannot be managed
+func (h *baseHandler) validatePagePath(w http.ResponseWriter, pagePath string) (string, bool) {
+	normalized := normalizePath(pagePath)
+	if normalized == "" {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Page path is required")
+		return "", false
+	}
+	return normalized, true
+}
+
+// GET page handler implementation
+// @Summary Get a wiki page
+// @Description Get a wiki page with its content. Its ETag is returned in the body and in the ETag header, to send in the If-Match header of an update.
+// @ID get-wiki-page
+// @Param organization path string true "Organization name"
+// @Param projectId path string true "Project ID or name"
+// @Param wikiIdentifier path string true "Wiki ID or name"
+// @Param path path string true "Page path (e.g., Onboarding/Access)"
+// @Param version query string false "Branch of a code wiki, defaults to its first published branch"
+// @Param api-version query string true "API version (e.g., 7.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Produce json
+// @Success 200 {object} PageResponse "Wiki page"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{projectId}/wiki/wikis/{wikiIdentifier}/pages/{path} [get]
+func (h *getPageHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	projectId := r.PathValue("projectId")
+	wikiIdentifier := r.PathValue("wikiIdentifier")
+	version := normalizeBranch(r.URL.Query().Get("version"))
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, projectId, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+	pagePath, ok := h.validatePagePath(w, r.PathValue("path"))
+	if !ok {
+		return
+	}
+
+	h.Log.Printf("Getting page %s of wiki %s for project %s in organization %s", pagePath, wikiIdentifier, projectId, organization)
+
+	page, eTag, err := h.getPage(organization, projectId, wikiIdentifier, pagePath, version, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "get wiki page", fmt.Sprintf("Page %s not found in wiki %s", pagePath, wikiIdentifier))
+		return
+	}
+
+	h.writePageResponse(w, http.StatusOK, page, eTag)
+	h.Log.Printf("Successfully retrieved page %s", pagePath)
+}
+
+// PUT page handler implementation
+// @Summary Create or update a wiki page
+// @Description Create a wiki page, or update its content. With an If-Match header, the page must exist and still have this ETag (412 otherwise). Without it, the page is updated from its current ETag, so that a concurrent change between the read and the update is not overwritten either. A page that already has the content is not updated.
+// @ID put-wiki-page
+// @Param organization path string true "Organization name"
+// @Param projectId path string true "Project ID or name"
+// @Param wikiIdentifier path string true "Wiki ID or name"
+// @Param path path string true "Page path (e.g., Onboarding/Access)"
+// @Param version query string false "Branch of a code wiki, defaults to its first published branch"
+// @Param api-version query string true "API version (e.g., 7.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Param If-Match header string false "ETag of the page, as returned when it was read"
+// @Param page body PageRequest true "Wiki page request body"
+// @Accept json
+// @Produce json
+// @Success 200 {object} PageResponse "Updated or unchanged page"
+// @Success 201 {object} PageResponse "Created page"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 412 "Precondition Failed"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{projectId}/wiki/wikis/{wikiIdentifier}/pages/{path} [put]
+func (h *putPageHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	projectId := r.PathValue("projectId")
+	wikiIdentifier := r.PathValue("wikiIdentifier")
+	version := normalizeBranch(r.URL.Query().Get("version"))
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+	ifMatch := r.Header.Get("If-Match")
+
+	if !h.ValidateBasicParams(w, organization, projectId, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+	pagePath, ok := h.validatePagePath(w, r.PathValue("path"))
+	if !ok {
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Failed to read request body")
+		return
+	}
+	var pageRequest PageRequest
+	if err := json.Unmarshal(body, &pageRequest); err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Invalid JSON in request body")
+		return
+	}
+
+	h.Log.Printf("Saving page %s of wiki %s for project %s in organization %s", pagePath, wikiIdentifier, projectId, organization)
+
+	current, eTag, err := h.getPage(organization, projectId, wikiIdentifier, pagePath, version, apiVersion, authHeader)
+	if err != nil && !isNotFound(err) {
+		h.WriteAzureDevOpsError(w, err, "get wiki page", fmt.Sprintf("Wiki %s not found in project %s", wikiIdentifier, projectId))
+		return
+	}
+
+	if current == nil {
+		if ifMatch != "" {
+			h.WriteErrorResponse(w, http.StatusPreconditionFailed, fmt.Sprintf("Precondition failed: page %s does not exist", pagePath))
+			return
+		}
+		created, eTag, err := h.putPage(organization, projectId, wikiIdentifier, pagePath, version, pageRequest.Content, "", apiVersion, authHeader)
+		if err != nil {
+			h.writePageError(w, err, "create wiki page", pagePath, fmt.Sprintf("Wiki %s not found in project %s", wikiIdentifier, projectId))
+			return
+		}
+		h.writePageResponse(w, http.StatusCreated, created, eTag)
+		h.Log.Printf("Successfully created page %s", pagePath)
+		return
+	}
+
+	if ifMatch != "" && !sameETag(ifMatch, eTag) {
+		h.WriteErrorResponse(w, http.StatusPreconditionFailed, fmt.Sprintf("Precondition failed: page %s was changed since it was read", pagePath))
+		return
+	}
+	if current.Content == pageRequest.Content {
+		h.Log.Printf("Page %s is up to date", pagePath)
+		h.writePageResponse(w, http.StatusOK, current, eTag)
+		return
+	}
+
+	updated, eTag, err := h.putPage(organization, projectId, wikiIdentifier, pagePath, version, pageRequest.Content, eTag, apiVersion, authHeader)
+	if err != nil {
+		h.writePageError(w, err, "update wiki page", pagePath, fmt.Sprintf("Page %s not found in wiki %s", pagePath, wikiIdentifier))
+		return
+	}
+	h.writePageResponse(w, http.StatusOK, updated, eTag)
+	h.Log.Printf("Successfully updated page %s", pagePath)
+}
+
+// DELETE page handler implementation
+// @Summary Delete a wiki page
+// @Description Delete a wiki page and its sub-pages. With an If-Match header, the page must still have this ETag (412 otherwise).
+// @ID delete-wiki-page
+// @Param organization path string true "Organization name"
+// @Param projectId path string true "Project ID or name"
+// @Param wikiIdentifier path string true "Wiki ID or name"
+// @Param path path string true "Page path (e.g., Onboarding/Access)"
+// @Param version query string false "Branch of a code wiki, defaults to its first published branch"
+// @Param api-version query string true "API version (e.g., 7.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Param If-Match header string false "ETag of the page, as returned when it was read"
+// @Success 204 "No Content"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 412 "Precondition Failed"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{projectId}/wiki/wikis/{wikiIdentifier}/pages/{path} [delete]
+func (h *deletePageHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	projectId := r.PathValue("projectId")
+	wikiIdentifier := r.PathValue("wikiIdentifier")
+	version := normalizeBranch(r.URL.Query().Get("version"))
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+	ifMatch := r.Header.Get("If-Match")
+
+	if !h.ValidateBasicParams(w, organization, projectId, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+	pagePath, ok := h.validatePagePath(w, r.PathValue("path"))
+	if !ok {
+		return
+	}
+
+	h.Log.Printf("Deleting page %s of wiki %s for project %s in organization %s", pagePath, wikiIdentifier, projectId, organization)
+
+	notFoundMessage := fmt.Sprintf("Page %s not found in wiki %s", pagePath, wikiIdentifier)
+	if ifMatch != "" {
+		_, eTag, err := h.getPage(organization, projectId, wikiIdentifier, pagePath, version, apiVersion, authHeader)
+		if err != nil {
+			h.WriteAzureDevOpsError(w, err, "get wiki page", notFoundMessage)
+			return
+		}
+		if !sameETag(ifMatch, eTag) {
+			h.WriteErrorResponse(w, http.StatusPreconditionFailed, fmt.Sprintf("Precondition failed: page %s was changed since it was read", pagePath))
+			return
+		}
+	}
+
+	if _, err := h.DoAzureDevOpsRequest("DELETE", pageURL(organization, projectId, wikiIdentifier, pagePath, version, false, apiVersion), authHeader, nil); err != nil {
+		h.WriteAzureDevOpsError(w, err, "delete wiki page", notFoundMessage)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	h.Log.Printf("Successfully deleted page %s", pagePath)
+}
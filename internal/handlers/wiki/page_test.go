@@ -0,0 +1,233 @@
+package wiki
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/handlerstest"
+)
+
+// Test GET page handler
+func TestGetPageHandler_ServeHTTP(t *testing.T) {
+	runWikiTests(t, "GET", func(b *baseHandler) http.Handler { return &getPageHandler{baseHandler: b} }, []wikiTestCase{
+		{
+			name:       "page with its ETag",
+			pathValues: wikiPathValues("Platform.wiki", "Onboarding/Access/"),
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(testPageURL("/Onboarding/Access", true), http.StatusOK, pageResp("/Onboarding/Access", "# Access"))
+				m.SetResponseHeader(testPageURL("/Onboarding/Access", true), "ETag", firstETag)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBody:         `{"id":7,"path":"/Onboarding/Access","content":"# Access","eTag":"\"1111\"","gitItemPath":"/Onboarding/Access.md","isParentPage":false}`,
+			expectedETag:         firstETag,
+			expectedRequestCount: 1,
+		},
+		{
+			name:       "page of a branch of a code wiki",
+			pathValues: wikiPathValues("Platform.wiki", "Onboarding"),
+			query:      url.Values{"api-version": {testAPIVersion}, "version": {"refs/heads/release/1.0"}},
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				versionURL := pageURL(testOrg, testProject, "Platform.wiki", "/Onboarding", "release/1.0", true, testAPIVersion)
+				m.SetResponse(versionURL, http.StatusOK, pageResp("/Onboarding", "# Onboarding"))
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `"path":"/Onboarding"`,
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "page not found",
+			pathValues:           wikiPathValues("Platform.wiki", "Onboarding"),
+			query:                apiVersionQuery(),
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Page /Onboarding not found in wiki Platform.wiki",
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "root page",
+			pathValues:           wikiPathValues("Platform.wiki", "/"),
+			query:                apiVersionQuery(),
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Page path is required",
+			expectedRequestCount: 0,
+		},
+	})
+}
+
+// Test PUT page handler
+func TestPutPageHandler_ServeHTTP(t *testing.T) {
+	runWikiTests(t, "PUT", func(b *baseHandler) http.Handler { return &putPageHandler{baseHandler: b} }, []wikiTestCase{
+		{
+			name:       "page created",
+			pathValues: wikiPathValues("Platform.wiki", "Onboarding"),
+			query:      apiVersionQuery(),
+			body:       `{"content":"# Onboarding"}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetMethodResponse("PUT", testPageURL("/Onboarding", false), http.StatusCreated, pageResp("/Onboarding", "# Onboarding"))
+				m.SetResponseHeader("PUT "+testPageURL("/Onboarding", false), "ETag", firstETag)
+			},
+			expectedStatus:       http.StatusCreated,
+			expectedBodyContains: `"content":"# Onboarding","eTag":"\"1111\""`,
+			expectedETag:         firstETag,
+			expectedRequestCount: 2,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				if got := m.Requests[1].Header.Get("If-Match"); got != "" {
+					t.Errorf("If-Match = %s, want none for a new page", got)
+				}
+				if got := requestBody(t, m, 1); got != `{"content":"# Onboarding"}` {
+					t.Errorf("Request body = %s", got)
+				}
+			},
+		},
+		{
+			name:       "page updated from its current ETag",
+			pathValues: wikiPathValues("Platform.wiki", "Onboarding"),
+			query:      apiVersionQuery(),
+			body:       `{"content":"# Onboarding v2"}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(testPageURL("/Onboarding", true), http.StatusOK, pageResp("/Onboarding", "# Onboarding"))
+				m.SetResponseHeader(testPageURL("/Onboarding", true), "ETag", firstETag)
+				m.SetMethodResponse("PUT", testPageURL("/Onboarding", false), http.StatusOK, pageResp("/Onboarding", "# Onboarding v2"))
+				m.SetResponseHeader("PUT "+testPageURL("/Onboarding", false), "ETag", secondETag)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `"content":"# Onboarding v2","eTag":"\"2222\""`,
+			expectedETag:         secondETag,
+			expectedRequestCount: 2,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				if got := m.Requests[1].Header.Get("If-Match"); got != firstETag {
+					t.Errorf("If-Match = %s, want %s", got, firstETag)
+				}
+			},
+		},
+		{
+			name:       "page updated with the ETag of the caller",
+			pathValues: wikiPathValues("Platform.wiki", "Onboarding"),
+			query:      apiVersionQuery(),
+			ifMatch:    `W/"1111"`,
+			body:       `{"content":"# Onboarding v2"}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(testPageURL("/Onboarding", true), http.StatusOK, pageResp("/Onboarding", "# Onboarding"))
+				m.SetResponseHeader(testPageURL("/Onboarding", true), "ETag", firstETag)
+				m.SetMethodResponse("PUT", testPageURL("/Onboarding", false), http.StatusOK, pageResp("/Onboarding", "# Onboarding v2"))
+				m.SetResponseHeader("PUT "+testPageURL("/Onboarding", false), "ETag", secondETag)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `"content":"# Onboarding v2"`,
+			expectedETag:         secondETag,
+			expectedRequestCount: 2,
+		},
+		{
+			name:       "page changed since the caller read it",
+			pathValues: wikiPathValues("Platform.wiki", "Onboarding"),
+			query:      apiVersionQuery(),
+			ifMatch:    firstETag,
+			body:       `{"content":"# Onboarding v2"}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(testPageURL("/Onboarding", true), http.StatusOK, pageResp("/Onboarding", "# Onboarding (edited)"))
+				m.SetResponseHeader(testPageURL("/Onboarding", true), "ETag", secondETag)
+			},
+			expectedStatus:       http.StatusPreconditionFailed,
+			expectedBody:         "Precondition failed: page /Onboarding was changed since it was read",
+			expectedRequestCount: 1,
+		},
+		{
+			name:       "page changed between the read and the update",
+			pathValues: wikiPathValues("Platform.wiki", "Onboarding"),
+			query:      apiVersionQuery(),
+			body:       `{"content":"# Onboarding v2"}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(testPageURL("/Onboarding", true), http.StatusOK, pageResp("/Onboarding", "# Onboarding"))
+				m.SetResponseHeader(testPageURL("/Onboarding", true), "ETag", firstETag)
+				m.SetMethodResponse("PUT", testPageURL("/Onboarding", false), http.StatusPreconditionFailed, `{"message":"The page has been changed by someone else"}`)
+			},
+			expectedStatus:       http.StatusPreconditionFailed,
+			expectedBody:         "Precondition failed: page /Onboarding was changed since it was read",
+			expectedRequestCount: 2,
+		},
+		{
+			name:       "page already up to date",
+			pathValues: wikiPathValues("Platform.wiki", "Onboarding"),
+			query:      apiVersionQuery(),
+			body:       `{"content":"# Onboarding"}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(testPageURL("/Onboarding", true), http.StatusOK, pageResp("/Onboarding", "# Onboarding"))
+				m.SetResponseHeader(testPageURL("/Onboarding", true), "ETag", firstETag)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `"eTag":"\"1111\""`,
+			expectedETag:         firstETag,
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "If-Match on a page that does not exist",
+			pathValues:           wikiPathValues("Platform.wiki", "Onboarding"),
+			query:                apiVersionQuery(),
+			ifMatch:              firstETag,
+			body:                 `{"content":"# Onboarding"}`,
+			expectedStatus:       http.StatusPreconditionFailed,
+			expectedBody:         "Precondition failed: page /Onboarding does not exist",
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "invalid JSON",
+			pathValues:           wikiPathValues("Platform.wiki", "Onboarding"),
+			query:                apiVersionQuery(),
+			body:                 `{"content":`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid JSON in request body",
+			expectedRequestCount: 0,
+		},
+	})
+}
+
+// Test DELETE page handler
+func TestDeletePageHandler_ServeHTTP(t *testing.T) {
+	runWikiTests(t, "DELETE", func(b *baseHandler) http.Handler { return &deletePageHandler{baseHandler: b} }, []wikiTestCase{
+		{
+			name:       "page deleted",
+			pathValues: wikiPathValues("Platform.wiki", "Onboarding"),
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetMethodResponse("DELETE", testPageURL("/Onboarding", false), http.StatusOK, pageResp("/Onboarding", ""))
+			},
+			expectedStatus:       http.StatusNoContent,
+			expectedRequestCount: 1,
+		},
+		{
+			name:       "page deleted with the ETag of the caller",
+			pathValues: wikiPathValues("Platform.wiki", "Onboarding"),
+			query:      apiVersionQuery(),
+			ifMatch:    firstETag,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(testPageURL("/Onboarding", true), http.StatusOK, pageResp("/Onboarding", "# Onboarding"))
+				m.SetResponseHeader(testPageURL("/Onboarding", true), "ETag", firstETag)
+				m.SetMethodResponse("DELETE", testPageURL("/Onboarding", false), http.StatusOK, pageResp("/Onboarding", ""))
+			},
+			expectedStatus:       http.StatusNoContent,
+			expectedRequestCount: 2,
+		},
+		{
+			name:       "page changed since the caller read it",
+			pathValues: wikiPathValues("Platform.wiki", "Onboarding"),
+			query:      apiVersionQuery(),
+			ifMatch:    firstETag,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(testPageURL("/Onboarding", true), http.StatusOK, pageResp("/Onboarding", "# Onboarding"))
+				m.SetResponseHeader(testPageURL("/Onboarding", true), "ETag", secondETag)
+			},
+			expectedStatus:       http.StatusPreconditionFailed,
+			expectedBody:         "Precondition failed: page /Onboarding was changed since it was read",
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "page not found",
+			pathValues:           wikiPathValues("Platform.wiki", "Onboarding"),
+			query:                apiVersionQuery(),
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Page /Onboarding not found in wiki Platform.wiki",
+			expectedRequestCount: 1,
+		},
+	})
+}
@@ -0,0 +1,146 @@
+package wiki
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+var guidRegex = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// normalizeBranch returns the name of the branch without the refs/heads/ prefix, as wiki versions are named
+func normalizeBranch(branch string) string {
+	return strings.TrimPrefix(strings.TrimSpace(branch), "refs/heads/")
+}
+
+// normalizePath returns the path with a leading slash, without trailing slash and duplicate slashes, e.g., Onboarding/ becomes /Onboarding
+// The root path is returned as an empty string
+func normalizePath(p string) string {
+	p = strings.TrimSpace(p)
+	if p == "" {
+		return ""
+	}
+	p = path.Clean("/" + p)
+	if p == "/" {
+		return ""
+	}
+	return p
+}
+
+// validateWikiRequest checks and normalizes the fields of a wiki request
+func validateWikiRequest(request *WikiRequest) error {
+	request.Name = strings.TrimSpace(request.Name)
+	if request.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	switch {
+	case request.Type == "" || strings.EqualFold(request.Type, ProjectWikiType):
+		request.Type = ProjectWikiType
+		if request.RepositoryID != "" || request.MappedPath != "" || request.Version != "" {
+			return fmt.Errorf("repositoryId, mappedPath and version can only be set for a code wiki")
+		}
+		return nil
+	case strings.EqualFold(request.Type, CodeWikiType):
+		request.Type = CodeWikiType
+	default:
+		return fmt.Errorf("type must be projectWiki or codeWiki, got '%s'", request.Type)
+	}
+
+	request.RepositoryID = strings.ToLower(strings.TrimSpace(request.RepositoryID))
+	if !guidRegex.MatchString(request.RepositoryID) {
+		return fmt.Errorf("repositoryId must be a GUID, got '%s'", request.RepositoryID)
+	}
+	request.MappedPath = normalizePath(request.MappedPath)
+	if request.MappedPath == "" {
+		request.MappedPath = "/"
+	}
+	request.Version = normalizeBranch(request.Version)
+	if request.Version == "" {
+		return fmt.Errorf("version is required for a code wiki")
+	}
+	return nil
+}
+
+// findWiki returns the wiki of the project matching the request, nil if there is none
+// A project has at most one project wiki, whatever its name, code wikis are matched by name (case insensitive)
+func findWiki(wikis []WikiV2, request *WikiRequest) *WikiV2 {
+	for i := range wikis {
+		if request.Type == ProjectWikiType && wikis[i].Type == ProjectWikiType {
+			return &wikis[i]
+		}
+		if request.Type == CodeWikiType && strings.EqualFold(wikis[i].Name, request.Name) {
+			return &wikis[i]
+		}
+	}
+	return nil
+}
+
+// wikiConflict returns why the existing wiki cannot be used for the request, an empty string if it can
+func wikiConflict(wiki *WikiV2, request *WikiRequest) string {
+	if request.Type == ProjectWikiType {
+		if !strings.EqualFold(wiki.Name, request.Name) {
+			return fmt.Sprintf("the project already has the project wiki %s", wiki.Name)
+		}
+		return ""
+	}
+	if wiki.Type != CodeWikiType {
+		return fmt.Sprintf("wiki %s already exists as a project wiki", wiki.Name)
+	}
+	if !strings.EqualFold(wiki.RepositoryID, request.RepositoryID) || normalizePath(wiki.MappedPath) != normalizePath(request.MappedPath) {
+		return fmt.Sprintf("wiki %s already publishes %s of repository %s", wiki.Name, wiki.MappedPath, strings.ToLower(wiki.RepositoryID))
+	}
+	return ""
+}
+
+// hasVersion reports whether the code wiki publishes the branch
+func hasVersion(wiki *WikiV2, branch string) bool {
+	for _, version := range wiki.Versions {
+		if normalizeBranch(version.Version) == branch {
+			return true
+		}
+	}
+	return false
+}
+
+// mapWikiFromAzure converts an Azure DevOps wiki to the wiki returned by the plugin
+func mapWikiFromAzure(wiki *WikiV2) *Wiki {
+	result := &Wiki{
+		ID:           strings.ToLower(wiki.ID),
+		Name:         wiki.Name,
+		Type:         wiki.Type,
+		ProjectID:    strings.ToLower(wiki.ProjectID),
+		RepositoryID: strings.ToLower(wiki.RepositoryID),
+		MappedPath:   wiki.MappedPath,
+		Versions:     []string{},
+		RemoteURL:    wiki.RemoteURL,
+	}
+	if wiki.Type == CodeWikiType {
+		for _, version := range wiki.Versions {
+			result.Versions = append(result.Versions, normalizeBranch(version.Version))
+		}
+	}
+	return result
+}
+
+// mapPageFromAzure converts an Azure DevOps wiki page and its ETag to the page returned by the plugin
+func mapPageFromAzure(page *WikiPage, eTag string) *Page {
+	return &Page{
+		ID:           page.ID,
+		Path:         page.Path,
+		Content:      page.Content,
+		ETag:         eTag,
+		GitItemPath:  page.GitItemPath,
+		IsParentPage: page.IsParentPage,
+		RemoteURL:    page.RemoteURL,
+	}
+}
+
+// sameETag reports whether the ETags are equal, ignoring the weak prefix and the quotes
+func sameETag(a, b string) bool {
+	normalize := func(eTag string) string {
+		return strings.Trim(strings.TrimPrefix(strings.TrimSpace(eTag), "W/"), `"`)
+	}
+	return normalize(a) == normalize(b)
+}
@@ -0,0 +1,63 @@
+package wiki
+
+import (
+	"testing"
+)
+
+func TestNormalizePath(t *testing.T) {
+	tests := map[string]string{
+		"Onboarding":            "/Onboarding",
+		" /Onboarding//Access/": "/Onboarding/Access",
+		"/":                     "",
+		"":                      "",
+	}
+	for pagePath, expected := range tests {
+		if got := normalizePath(pagePath); got != expected {
+			t.Errorf("normalizePath(%q) = %q, want %q", pagePath, got, expected)
+		}
+	}
+}
+
+func TestSameETag(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected bool
+	}{
+		{a: `"1111"`, b: `"1111"`, expected: true},
+		{a: `W/"1111"`, b: `"1111"`, expected: true},
+		{a: `1111`, b: `"1111"`, expected: true},
+		{a: `"1111"`, b: `"2222"`, expected: false},
+	}
+	for _, tt := range tests {
+		if got := sameETag(tt.a, tt.b); got != tt.expected {
+			t.Errorf("sameETag(%s, %s) = %v, want %v", tt.a, tt.b, got, tt.expected)
+		}
+	}
+}
+
+func TestValidateWikiRequest(t *testing.T) {
+	tests := []struct {
+		name          string
+		request       WikiRequest
+		expectedError string
+	}{
+		{name: "project wiki", request: WikiRequest{Name: "Platform.wiki"}},
+		{name: "code wiki", request: WikiRequest{Name: "Docs", Type: "codewiki", RepositoryID: testRepositoryID, Version: "main"}},
+		{name: "missing name", request: WikiRequest{}, expectedError: "name is required"},
+		{name: "unknown type", request: WikiRequest{Name: "Docs", Type: "repositoryWiki"}, expectedError: "type must be projectWiki or codeWiki, got 'repositoryWiki'"},
+		{name: "repository of a project wiki", request: WikiRequest{Name: "Docs", RepositoryID: testRepositoryID}, expectedError: "repositoryId, mappedPath and version can only be set for a code wiki"},
+		{name: "repository name", request: WikiRequest{Name: "Docs", Type: "codeWiki", RepositoryID: "platform-api", Version: "main"}, expectedError: "repositoryId must be a GUID, got 'platform-api'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateWikiRequest(&tt.request)
+			if tt.expectedError == "" && err != nil {
+				t.Fatalf("validateWikiRequest() error = %v", err)
+			}
+			if tt.expectedError != "" && (err == nil || err.Error() != tt.expectedError) {
+				t.Fatalf("validateWikiRequest() error = %v, want %s", err, tt.expectedError)
+			}
+		})
+	}
+}
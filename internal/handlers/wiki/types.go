@@ -0,0 +1,123 @@
+package wiki
+
+// Wiki represents the wiki returned by the plugin
+// It is built from the WikiV2 returned by:
+// GET https://dev.azure.com/{organization}/{project}/_apis/wiki/wikis/{wikiIdentifier}
+type Wiki struct {
+	ID           string   `json:"id"`
+	Name         string   `json:"name"`
+	Type         string   `json:"type"` // projectWiki or codeWiki
+	ProjectID    string   `json:"projectId"`
+	RepositoryID string   `json:"repositoryId"`
+	MappedPath   string   `json:"mappedPath"`
+	Versions     []string `json:"versions"` // Published branches, only set for code wikis
+	RemoteURL    string   `json:"remoteUrl,omitempty"`
+}
+
+// WikiResponse represents the response of the wiki endpoints
+type WikiResponse Wiki
+
+// WikiRequest represents the request body for creating a project wiki or publishing a code wiki
+type WikiRequest struct {
+	Name         string `json:"name"`                   // Required
+	Type         string `json:"type,omitempty"`         // projectWiki (default) or codeWiki
+	RepositoryID string `json:"repositoryId,omitempty"` // Required for a code wiki: repository ID (GUID)
+	MappedPath   string `json:"mappedPath,omitempty"`   // Folder of the repository published by a code wiki, defaults to /
+	Version      string `json:"version,omitempty"`      // Required for a code wiki: branch published, e.g., main
+}
+
+// Page represents the wiki page returned by the plugin
+type Page struct {
+	ID           int    `json:"id"`
+	Path         string `json:"path"`
+	Content      string `json:"content"`
+	ETag         string `json:"eTag"` // Version of the page, to send in the If-Match header of an update
+	GitItemPath  string `json:"gitItemPath,omitempty"`
+	IsParentPage bool   `json:"isParentPage"`
+	RemoteURL    string `json:"remoteUrl,omitempty"`
+}
+
+// PageResponse represents the response of the wiki page endpoints
+type PageResponse Page
+
+// PageRequest represents the request body for creating or updating a wiki page
+type PageRequest struct {
+	Content string `json:"content"` // Markdown content of the page
+}
+
+// ---
+
+// GitVersionDescriptor represents a branch of a code wiki
+type GitVersionDescriptor struct {
+	Version     string `json:"version"`
+	VersionType string `json:"versionType,omitempty"`
+}
+
+// WikiV2 represents a wiki of Azure DevOps
+type WikiV2 struct {
+	ID           string                 `json:"id"`
+	Name         string                 `json:"name"`
+	Type         string                 `json:"type"`
+	ProjectID    string                 `json:"projectId"`
+	RepositoryID string                 `json:"repositoryId"`
+	MappedPath   string                 `json:"mappedPath"`
+	Versions     []GitVersionDescriptor `json:"versions,omitempty"`
+	RemoteURL    string                 `json:"remoteUrl,omitempty"`
+	URL          string                 `json:"url,omitempty"`
+}
+
+// WikiV2List represents the response from:
+// GET https://dev.azure.com/{organization}/{project}/_apis/wiki/wikis
+type WikiV2List struct {
+	Count int      `json:"count"`
+	Value []WikiV2 `json:"value"`
+}
+
+// WikiCreateParametersV2 represents the request body of:
+// POST https://dev.azure.com/{organization}/{project}/_apis/wiki/wikis
+type WikiCreateParametersV2 struct {
+	Name         string                `json:"name"`
+	Type         string                `json:"type"`
+	ProjectID    string                `json:"projectId"`
+	RepositoryID string                `json:"repositoryId,omitempty"`
+	MappedPath   string                `json:"mappedPath,omitempty"`
+	Version      *GitVersionDescriptor `json:"version,omitempty"`
+}
+
+// WikiUpdateParameters represents the request body of:
+// PATCH https://dev.azure.com/{organization}/{project}/_apis/wiki/wikis/{wikiIdentifier}
+type WikiUpdateParameters struct {
+	Versions []GitVersionDescriptor `json:"versions"`
+}
+
+// TeamProject represents the project a project wiki is created in
+type TeamProject struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// WikiPage represents a wiki page of Azure DevOps, from:
+// GET https://dev.azure.com/{organization}/{project}/_apis/wiki/wikis/{wikiIdentifier}/pages?path={path}&includeContent=true
+// The version of the page is returned in the ETag header
+type WikiPage struct {
+	ID           int    `json:"id,omitempty"`
+	Path         string `json:"path"`
+	Content      string `json:"content,omitempty"`
+	GitItemPath  string `json:"gitItemPath,omitempty"`
+	IsParentPage bool   `json:"isParentPage,omitempty"`
+	RemoteURL    string `json:"remoteUrl,omitempty"`
+	URL          string `json:"url,omitempty"`
+}
+
+// WikiPageCreateOrUpdateParameters represents the request body of:
+// PUT https://dev.azure.com/{organization}/{project}/_apis/wiki/wikis/{wikiIdentifier}/pages?path={path}
+// The page is updated when the If-Match header is set to its ETag, created otherwise
+type WikiPageCreateOrUpdateParameters struct {
+	Content string `json:"content"`
+}
+
+// Wiki types
+const (
+	ProjectWikiType = "projectWiki"
+	CodeWikiType    = "codeWiki"
+)
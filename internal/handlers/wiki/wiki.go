@@ -0,0 +1,346 @@
+package wiki
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers"
+)
+
+// Handler constructors
+func GetWiki(opts handlers.HandlerOptions) handlers.Handler {
+	return &getHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func PostWiki(opts handlers.HandlerOptions) handlers.Handler {
+	return &postHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func DeleteWiki(opts handlers.HandlerOptions) handlers.Handler {
+	return &deleteHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func GetWikiPage(opts handlers.HandlerOptions) handlers.Handler {
+	return &getPageHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func PutWikiPage(opts handlers.HandlerOptions) handlers.Handler {
+	return &putPageHandler{baseHandler: newBaseHandler(opts)}
+}
+
+func DeleteWikiPage(opts handlers.HandlerOptions) handlers.Handler {
+	return &deletePageHandler{baseHandler: newBaseHandler(opts)}
+}
+
+// Interface compliance verification
+var _ handlers.Handler = &getHandler{}
+var _ handlers.Handler = &postHandler{}
+var _ handlers.Handler = &deleteHandler{}
+var _ handlers.Handler = &getPageHandler{}
+var _ handlers.Handler = &putPageHandler{}
+var _ handlers.Handler = &deletePageHandler{}
+
+// Base handler with common functionality
+type baseHandler struct {
+	*handlers.BaseHandler
+}
+
+// Constructor for the base handler
+func newBaseHandler(opts handlers.HandlerOptions) *baseHandler {
+	return &baseHandler{BaseHandler: handlers.NewBaseHandler(opts)}
+}
+
+// Handler types embedding the base handler
+type getHandler struct {
+	*baseHandler
+}
+
+type postHandler struct {
+	*baseHandler
+}
+
+type deleteHandler struct {
+	*baseHandler
+}
+
+type getPageHandler struct {
+	*baseHandler
+}
+
+type putPageHandler struct {
+	*baseHandler
+}
+
+type deletePageHandler struct {
+	*baseHandler
+}
+
+// listWikis returns the wikis of the project
+func (h *baseHandler) listWikis(organization, projectId, apiVersion, authHeader string) ([]WikiV2, error) {
+	url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/wiki/wikis?api-version=%s", organization, projectId, apiVersion)
+
+	body, err := h.DoAzureDevOpsRequest("GET", url, authHeader, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var wikis WikiV2List
+	if err := json.Unmarshal(body, &wikis); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal wikis: %w", err)
+	}
+	return wikis.Value, nil
+}
+
+// getWiki returns the wiki with the given ID or name
+func (h *baseHandler) getWiki(organization, projectId, wikiIdentifier, apiVersion, authHeader string) (*WikiV2, error) {
+	url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/wiki/wikis/%s?api-version=%s", organization, projectId, wikiIdentifier, apiVersion)
+
+	body, err := h.DoAzureDevOpsRequest("GET", url, authHeader, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var wiki WikiV2
+	if err := json.Unmarshal(body, &wiki); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal wiki: %w", err)
+	}
+	return &wiki, nil
+}
+
+// resolveProjectID returns the ID of the project given by ID or name, as the wiki creation requires the project ID
+func (h *baseHandler) resolveProjectID(organization, projectId, apiVersion, authHeader string) (string, error) {
+	if guidRegex.MatchString(projectId) {
+		return strings.ToLower(projectId), nil
+	}
+
+	url := fmt.Sprintf("https://dev.azure.com/%s/_apis/projects/%s?api-version=%s", organization, projectId, apiVersion)
+	body, err := h.DoAzureDevOpsRequest("GET", url, authHeader, nil)
+	if err != nil {
+		return "", err
+	}
+
+	var project TeamProject
+	if err := json.Unmarshal(body, &project); err != nil {
+		return "", fmt.Errorf("failed to unmarshal project: %w", err)
+	}
+	return strings.ToLower(project.ID), nil
+}
+
+// saveWiki creates a wiki (POST, without ID) or updates the versions of the wiki with the given ID (PATCH)
+func (h *baseHandler) saveWiki(organization, projectId, method, id string, parameters interface{}, apiVersion, authHeader string) (*WikiV2, error) {
+	requestBody, err := json.Marshal(parameters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal wiki: %w", err)
+	}
+
+	url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/wiki/wikis?api-version=%s", organization, projectId, apiVersion)
+	if id != "" {
+		url = fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/wiki/wikis/%s?api-version=%s", organization, projectId, id, apiVersion)
+	}
+	body, err := h.DoAzureDevOpsRequest(method, url, authHeader, requestBody)
+	if err != nil {
+		return nil, err
+	}
+
+	var wiki WikiV2
+	if err := json.Unmarshal(body, &wiki); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal wiki: %w", err)
+	}
+	return &wiki, nil
+}
+
+// GET handler implementation
+// @Summary Get a wiki
+// @Description Get a project wiki or a code wiki with its published branches
+// @ID get-wiki
+// @Param organization path string true "Organization name"
+// @Param projectId path string true "Project ID or name"
+// @Param wikiIdentifier path string true "Wiki ID or name"
+// @Param api-version query string true "API version (e.g., 7.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Produce json
+// @Success 200 {object} WikiResponse "Wiki details"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{projectId}/wiki/wikis/{wikiIdentifier} [get]
+func (h *getHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	projectId := r.PathValue("projectId")
+	wikiIdentifier := r.PathValue("wikiIdentifier")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, projectId, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	h.Log.Printf("Getting wiki %s for project %s in organization %s", wikiIdentifier, projectId, organization)
+
+	wiki, err := h.getWiki(organization, projectId, wikiIdentifier, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "get wiki", fmt.Sprintf("Wiki %s not found in project %s", wikiIdentifier, projectId))
+		return
+	}
+
+	h.WriteObjectResponse(w, http.StatusOK, WikiResponse(*mapWikiFromAzure(wiki)))
+	h.Log.Printf("Successfully retrieved wiki %s", wikiIdentifier)
+}
+
+// POST handler implementation
+// @Summary Create a wiki
+// @Description Create the project wiki, or publish a folder of a branch of a repository as a code wiki. If the project wiki, or a code wiki of the same name publishing the same folder, already exists, 200 is returned instead: the branch is added to the published branches of a code wiki if needed.
+// @ID post-wiki
+// @Param organization path string true "Organization name"
+// @Param projectId path string true "Project ID or name"
+// @Param api-version query string true "API version (e.g., 7.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Param wiki body WikiRequest true "Wiki creation request body"
+// @Accept json
+// @Produce json
+// @Success 200 {object} WikiResponse "Existing wiki"
+// @Success 201 {object} WikiResponse "Created wiki"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 409 "Conflict"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{projectId}/wiki/wikis [post]
+func (h *postHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	projectId := r.PathValue("projectId")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, projectId, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Failed to read request body")
+		return
+	}
+	var wikiRequest WikiRequest
+	if err := json.Unmarshal(body, &wikiRequest); err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, "Invalid JSON in request body")
+		return
+	}
+	if err := validateWikiRequest(&wikiRequest); err != nil {
+		h.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid wiki: %v", err))
+		return
+	}
+
+	h.Log.Printf("Creating %s %s for project %s in organization %s", wikiRequest.Type, wikiRequest.Name, projectId, organization)
+
+	notFoundMessage := fmt.Sprintf("Project %s not found", projectId)
+	wikis, err := h.listWikis(organization, projectId, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "list wikis", notFoundMessage)
+		return
+	}
+
+	existing := findWiki(wikis, &wikiRequest)
+	if existing == nil {
+		projectID, err := h.resolveProjectID(organization, projectId, apiVersion, authHeader)
+		if err != nil {
+			h.WriteAzureDevOpsError(w, err, "get project", notFoundMessage)
+			return
+		}
+
+		parameters := WikiCreateParametersV2{Name: wikiRequest.Name, Type: wikiRequest.Type, ProjectID: projectID}
+		if wikiRequest.Type == CodeWikiType {
+			parameters.RepositoryID = wikiRequest.RepositoryID
+			parameters.MappedPath = wikiRequest.MappedPath
+			parameters.Version = &GitVersionDescriptor{Version: wikiRequest.Version}
+		}
+		created, err := h.saveWiki(organization, projectId, "POST", "", parameters, apiVersion, authHeader)
+		if err != nil {
+			h.WriteAzureDevOpsError(w, err, "create wiki", notFoundMessage)
+			return
+		}
+		h.WriteObjectResponse(w, http.StatusCreated, WikiResponse(*mapWikiFromAzure(created)))
+		h.Log.Printf("Successfully created wiki %s", created.ID)
+		return
+	}
+
+	h.Log.Printf("Wiki %s already exists with ID %s", existing.Name, existing.ID)
+	if conflict := wikiConflict(existing, &wikiRequest); conflict != "" {
+		h.WriteErrorResponse(w, http.StatusConflict, fmt.Sprintf("Conflict: %s", conflict))
+		return
+	}
+
+	if wikiRequest.Type == CodeWikiType && !hasVersion(existing, wikiRequest.Version) {
+		versions := append(existing.Versions, GitVersionDescriptor{Version: wikiRequest.Version})
+		existing, err = h.saveWiki(organization, projectId, "PATCH", existing.ID, WikiUpdateParameters{Versions: versions}, apiVersion, authHeader)
+		if err != nil {
+			h.WriteAzureDevOpsError(w, err, "update wiki", fmt.Sprintf("Wiki %s not found in project %s", wikiRequest.Name, projectId))
+			return
+		}
+		h.Log.Printf("Published branch %s in wiki %s", wikiRequest.Version, existing.Name)
+	}
+
+	h.WriteObjectResponse(w, http.StatusOK, WikiResponse(*mapWikiFromAzure(existing)))
+}
+
+// DELETE handler implementation
+// @Summary Unpublish a code wiki
+// @Description Unpublish a code wiki, the repository and its content are kept. Project wikis cannot be deleted.
+// @ID delete-wiki
+// @Param organization path string true "Organization name"
+// @Param projectId path string true "Project ID or name"
+// @Param wikiIdentifier path string true "Wiki ID or name"
+// @Param api-version query string true "API version (e.g., 7.1)"
+// @Param Authorization header string true "Basic Auth header (Basic <base64-encoded-username:password>)"
+// @Success 204 "No Content"
+// @Failure 400 "Bad Request"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Not Found"
+// @Failure 500 "Internal Server Error"
+// @Router /api/{organization}/{projectId}/wiki/wikis/{wikiIdentifier} [delete]
+func (h *deleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	organization := r.PathValue("organization")
+	projectId := r.PathValue("projectId")
+	wikiIdentifier := r.PathValue("wikiIdentifier")
+	apiVersion := r.URL.Query().Get("api-version")
+	authHeader := r.Header.Get("Authorization")
+
+	if !h.ValidateBasicParams(w, organization, projectId, apiVersion) {
+		return
+	}
+	if !h.ValidateBasicAuth(w, r) {
+		return
+	}
+
+	h.Log.Printf("Deleting wiki %s for project %s in organization %s", wikiIdentifier, projectId, organization)
+
+	notFoundMessage := fmt.Sprintf("Wiki %s not found in project %s", wikiIdentifier, projectId)
+	wiki, err := h.getWiki(organization, projectId, wikiIdentifier, apiVersion, authHeader)
+	if err != nil {
+		h.WriteAzureDevOpsError(w, err, "get wiki", notFoundMessage)
+		return
+	}
+	if wiki.Type != CodeWikiType {
+		h.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid wiki: %s is a project wiki, only code wikis can be unpublished", wiki.Name))
+		return
+	}
+
+	url := fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/wiki/wikis/%s?api-version=%s", organization, projectId, wiki.ID, apiVersion)
+	if _, err := h.DoAzureDevOpsRequest("DELETE", url, authHeader, nil); err != nil {
+		h.WriteAzureDevOpsError(w, err, "delete wiki", notFoundMessage)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	h.Log.Printf("Successfully unpublished wiki %s", wikiIdentifier)
+}
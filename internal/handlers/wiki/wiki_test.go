@@ -0,0 +1,417 @@
+package wiki
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers"
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/handlerstest"
+	"github.com/rs/zerolog"
+)
+
+// newTestBaseHandler creates a base handler for testing with a mock client
+func newTestBaseHandler(mockClient *handlerstest.MockHTTPClient) *baseHandler {
+	return newBaseHandler(handlerstest.NewHandlerOptions(mockClient))
+}
+
+// Test data constants
+const (
+	testOrg          = "testorg"
+	testProject      = "Platform"
+	testProjectID    = "0a1b2c3d-0000-0000-0000-000000000001"
+	testRepositoryID = "6f2d1e4a-0000-0000-0000-00000000000f"
+	testWikiID       = "9c8b7a65-0000-0000-0000-00000000000a"
+	testAPIVersion   = "7.1"
+	testUsername     = "test"
+	testPassword     = "test"
+	firstETag        = `"1111"`
+	secondETag       = `"2222"`
+)
+
+var (
+	wikisURL   = fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/wiki/wikis?api-version=%s", testOrg, testProject, testAPIVersion)
+	wikiURL    = fmt.Sprintf("https://dev.azure.com/%s/%s/_apis/wiki/wikis/%s?api-version=%s", testOrg, testProject, testWikiID, testAPIVersion)
+	projectURL = fmt.Sprintf("https://dev.azure.com/%s/_apis/projects/%s?api-version=%s", testOrg, testProject, testAPIVersion)
+
+	projectWikiResp = `{"id":"` + testWikiID + `","name":"Platform.wiki","type":"projectWiki","projectId":"` + testProjectID + `","repositoryId":"` + testWikiID + `","mappedPath":"/","versions":[{"version":"wikiMaster"}]}`
+	codeWikiResp    = `{"id":"` + testWikiID + `","name":"Platform docs","type":"codeWiki","projectId":"` + testProjectID + `","repositoryId":"` + strings.ToUpper(testRepositoryID) + `","mappedPath":"/docs","versions":[{"version":"main"}]}`
+)
+
+// wikisResp returns the list of wikis of the project
+func wikisResp(wikis ...string) string {
+	return fmt.Sprintf(`{"count":%d,"value":[%s]}`, len(wikis), strings.Join(wikis, ","))
+}
+
+// testPageURL returns the URL of the page of the test wiki, with the content when it is read
+func testPageURL(pagePath string, includeContent bool) string {
+	return pageURL(testOrg, testProject, "Platform.wiki", pagePath, "", includeContent, testAPIVersion)
+}
+
+// pageResp returns a page of the test wiki with the given content
+func pageResp(pagePath, content string) string {
+	return `{"id":7,"path":"` + pagePath + `","content":"` + content + `","gitItemPath":"` + pagePath + `.md","isParentPage":false}`
+}
+
+// wikiTestCase is a test case for the handlers of this package
+type wikiTestCase struct {
+	name                 string
+	pathValues           map[string]string
+	query                url.Values
+	noAuth               bool
+	ifMatch              string
+	body                 string
+	setupMock            func(*handlerstest.MockHTTPClient)
+	expectedStatus       int
+	expectedBody         string
+	expectedBodyContains string
+	expectedETag         string
+	expectedRequestCount int
+	verify               func(t *testing.T, mockClient *handlerstest.MockHTTPClient)
+}
+
+// wikiPathValues returns the path values of the wiki endpoints, with the wiki identifier and the page path if they are set
+func wikiPathValues(wikiIdentifier, pagePath string) map[string]string {
+	pathValues := map[string]string{"organization": testOrg, "projectId": testProject}
+	if wikiIdentifier != "" {
+		pathValues["wikiIdentifier"] = wikiIdentifier
+	}
+	if pagePath != "" {
+		pathValues["path"] = pagePath
+	}
+	return pathValues
+}
+
+// runWikiTests runs the given test cases against the handler built by newHandler
+func runWikiTests(t *testing.T, method string, newHandler func(*baseHandler) http.Handler, tests []wikiTestCase) {
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := handlerstest.NewMockHTTPClient()
+			if tt.setupMock != nil {
+				tt.setupMock(mockClient)
+			}
+			handler := newHandler(newTestBaseHandler(mockClient))
+
+			target := "/api/test"
+			if len(tt.query) > 0 {
+				target += "?" + tt.query.Encode()
+			}
+			var body io.Reader
+			if tt.body != "" {
+				body = strings.NewReader(tt.body)
+			}
+			req := httptest.NewRequest(method, target, body)
+			for key, value := range tt.pathValues {
+				req.SetPathValue(key, value)
+			}
+			if !tt.noAuth {
+				req.SetBasicAuth(testUsername, testPassword)
+			}
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("Status code = %d, want %d. Body: %s", rr.Code, tt.expectedStatus, rr.Body.String())
+			}
+			if tt.expectedBody != "" && rr.Body.String() != tt.expectedBody {
+				t.Errorf("Body = %s, want %s", rr.Body.String(), tt.expectedBody)
+			}
+			if tt.expectedBodyContains != "" && !strings.Contains(rr.Body.String(), tt.expectedBodyContains) {
+				t.Errorf("Body = %s, want it to contain %s", rr.Body.String(), tt.expectedBodyContains)
+			}
+			if mockClient.RequestCount() != tt.expectedRequestCount {
+				t.Errorf("Request count = %d, want %d", mockClient.RequestCount(), tt.expectedRequestCount)
+			}
+			if got := rr.Header().Get("ETag"); got != tt.expectedETag {
+				t.Errorf("ETag header = %s, want %s", got, tt.expectedETag)
+			}
+			if tt.verify != nil {
+				tt.verify(t, mockClient)
+			}
+		})
+	}
+}
+
+// apiVersionQuery returns the query with the test API version
+func apiVersionQuery() url.Values {
+	return url.Values{"api-version": {testAPIVersion}}
+}
+
+// requestBody returns the body of the n-th request sent to Azure DevOps
+func requestBody(t *testing.T, mockClient *handlerstest.MockHTTPClient, n int) string {
+	t.Helper()
+	if len(mockClient.Requests) <= n || mockClient.Requests[n].Body == nil {
+		t.Fatalf("request %d has no body", n)
+	}
+	body, err := io.ReadAll(mockClient.Requests[n].Body)
+	if err != nil {
+		t.Fatalf("failed to read request %d body: %v", n, err)
+	}
+	return string(body)
+}
+
+// containsRequest reports whether a request was sent with the given method and URL
+func containsRequest(mockClient *handlerstest.MockHTTPClient, method, url string) bool {
+	for _, req := range mockClient.Requests {
+		if req.Method+" "+req.URL.String() == method+" "+url {
+			return true
+		}
+	}
+	return false
+}
+
+// Test constructor functions
+func TestWikiConstructors(t *testing.T) {
+	client := &http.Client{}
+	logger := zerolog.New(os.Stdout).With().Timestamp().Logger()
+	opts := handlers.HandlerOptions{
+		Client: client,
+		Log:    &logger,
+	}
+
+	constructors := map[string]func(handlers.HandlerOptions) handlers.Handler{
+		"GetWiki":        GetWiki,
+		"PostWiki":       PostWiki,
+		"DeleteWiki":     DeleteWiki,
+		"GetWikiPage":    GetWikiPage,
+		"PutWikiPage":    PutWikiPage,
+		"DeleteWikiPage": DeleteWikiPage,
+	}
+	for name, constructor := range constructors {
+		if h := constructor(opts); h == nil {
+			t.Fatalf("%s should return a non-nil handler", name)
+		}
+	}
+}
+
+// Test GET handler
+func TestGetHandler_ServeHTTP(t *testing.T) {
+	runWikiTests(t, "GET", func(b *baseHandler) http.Handler { return &getHandler{baseHandler: b} }, []wikiTestCase{
+		{
+			name:       "code wiki",
+			pathValues: wikiPathValues(testWikiID, ""),
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(wikiURL, http.StatusOK, codeWikiResp)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBody:         `{"id":"` + testWikiID + `","name":"Platform docs","type":"codeWiki","projectId":"` + testProjectID + `","repositoryId":"` + testRepositoryID + `","mappedPath":"/docs","versions":["main"]}`,
+			expectedRequestCount: 1,
+		},
+		{
+			name:       "project wiki without versions",
+			pathValues: wikiPathValues(testWikiID, ""),
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(wikiURL, http.StatusOK, projectWikiResp)
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `"type":"projectWiki","projectId":"` + testProjectID + `","repositoryId":"` + testWikiID + `","mappedPath":"/","versions":[]}`,
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "wiki not found",
+			pathValues:           wikiPathValues(testWikiID, ""),
+			query:                apiVersionQuery(),
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Wiki " + testWikiID + " not found in project Platform",
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "missing basic auth",
+			pathValues:           wikiPathValues(testWikiID, ""),
+			query:                apiVersionQuery(),
+			noAuth:               true,
+			expectedStatus:       http.StatusUnauthorized,
+			expectedBody:         "Request rejected due to missing or invalid Basic authentication",
+			expectedRequestCount: 0,
+		},
+	})
+}
+
+// Test POST handler
+func TestPostHandler_ServeHTTP(t *testing.T) {
+	codeWikiRequest := `{"name":"Platform docs","type":"codeWiki","repositoryId":"` + testRepositoryID + `","mappedPath":"docs/","version":"refs/heads/main"}`
+
+	runWikiTests(t, "POST", func(b *baseHandler) http.Handler { return &postHandler{baseHandler: b} }, []wikiTestCase{
+		{
+			name:       "project wiki created with the project ID",
+			pathValues: wikiPathValues("", ""),
+			query:      apiVersionQuery(),
+			body:       `{"name":"Platform.wiki"}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(wikisURL, http.StatusOK, wikisResp(codeWikiResp))
+				m.SetResponse(projectURL, http.StatusOK, `{"id":"`+strings.ToUpper(testProjectID)+`","name":"Platform"}`)
+				m.SetMethodResponse("POST", wikisURL, http.StatusCreated, projectWikiResp)
+			},
+			expectedStatus:       http.StatusCreated,
+			expectedBodyContains: `"name":"Platform.wiki","type":"projectWiki"`,
+			expectedRequestCount: 3,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				want := `{"name":"Platform.wiki","type":"projectWiki","projectId":"` + testProjectID + `"}`
+				if got := requestBody(t, m, 2); got != want {
+					t.Errorf("Request body = %s, want %s", got, want)
+				}
+			},
+		},
+		{
+			name:       "code wiki published",
+			pathValues: wikiPathValues("", ""),
+			query:      apiVersionQuery(),
+			body:       codeWikiRequest,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(wikisURL, http.StatusOK, wikisResp(projectWikiResp))
+				m.SetResponse(projectURL, http.StatusOK, `{"id":"`+testProjectID+`","name":"Platform"}`)
+				m.SetMethodResponse("POST", wikisURL, http.StatusCreated, codeWikiResp)
+			},
+			expectedStatus:       http.StatusCreated,
+			expectedBodyContains: `"versions":["main"]`,
+			expectedRequestCount: 3,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				want := `{"name":"Platform docs","type":"codeWiki","projectId":"` + testProjectID + `","repositoryId":"` + testRepositoryID + `","mappedPath":"/docs","version":{"version":"main"}}`
+				if got := requestBody(t, m, 2); got != want {
+					t.Errorf("Request body = %s, want %s", got, want)
+				}
+			},
+		},
+		{
+			name:       "existing project wiki",
+			pathValues: wikiPathValues("", ""),
+			query:      apiVersionQuery(),
+			body:       `{"name":"platform.wiki","type":"ProjectWiki"}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(wikisURL, http.StatusOK, wikisResp(projectWikiResp))
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `"name":"Platform.wiki"`,
+			expectedRequestCount: 1,
+		},
+		{
+			name:       "existing code wiki with another published branch",
+			pathValues: wikiPathValues("", ""),
+			query:      apiVersionQuery(),
+			body:       strings.Replace(codeWikiRequest, "refs/heads/main", "release/1.0", 1),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(wikisURL, http.StatusOK, wikisResp(projectWikiResp, codeWikiResp))
+				m.SetMethodResponse("PATCH", wikiURL, http.StatusOK, strings.Replace(codeWikiResp, `[{"version":"main"}]`, `[{"version":"main"},{"version":"release/1.0"}]`, 1))
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `"versions":["main","release/1.0"]`,
+			expectedRequestCount: 2,
+			verify: func(t *testing.T, m *handlerstest.MockHTTPClient) {
+				want := `{"versions":[{"version":"main"},{"version":"release/1.0"}]}`
+				if got := requestBody(t, m, 1); got != want {
+					t.Errorf("Request body = %s, want %s", got, want)
+				}
+			},
+		},
+		{
+			name:       "existing code wiki up to date",
+			pathValues: wikiPathValues("", ""),
+			query:      apiVersionQuery(),
+			body:       codeWikiRequest,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(wikisURL, http.StatusOK, wikisResp(codeWikiResp))
+			},
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `"name":"Platform docs"`,
+			expectedRequestCount: 1,
+		},
+		{
+			name:       "code wiki of the same name publishing another folder",
+			pathValues: wikiPathValues("", ""),
+			query:      apiVersionQuery(),
+			body:       strings.Replace(codeWikiRequest, "docs/", "/", 1),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(wikisURL, http.StatusOK, wikisResp(codeWikiResp))
+			},
+			expectedStatus:       http.StatusConflict,
+			expectedBody:         "Conflict: wiki Platform docs already publishes /docs of repository " + testRepositoryID,
+			expectedRequestCount: 1,
+		},
+		{
+			name:       "project wiki of another name",
+			pathValues: wikiPathValues("", ""),
+			query:      apiVersionQuery(),
+			body:       `{"name":"Handbook"}`,
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(wikisURL, http.StatusOK, wikisResp(projectWikiResp))
+			},
+			expectedStatus:       http.StatusConflict,
+			expectedBody:         "Conflict: the project already has the project wiki Platform.wiki",
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "project not found",
+			pathValues:           wikiPathValues("", ""),
+			query:                apiVersionQuery(),
+			body:                 `{"name":"Platform.wiki"}`,
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Project Platform not found",
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "code wiki without version",
+			pathValues:           wikiPathValues("", ""),
+			query:                apiVersionQuery(),
+			body:                 `{"name":"Platform docs","type":"codeWiki","repositoryId":"` + testRepositoryID + `"}`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid wiki: version is required for a code wiki",
+			expectedRequestCount: 0,
+		},
+		{
+			name:                 "invalid JSON",
+			pathValues:           wikiPathValues("", ""),
+			query:                apiVersionQuery(),
+			body:                 `{"name":`,
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid JSON in request body",
+			expectedRequestCount: 0,
+		},
+	})
+}
+
+// Test DELETE handler
+func TestDeleteHandler_ServeHTTP(t *testing.T) {
+	runWikiTests(t, "DELETE", func(b *baseHandler) http.Handler { return &deleteHandler{baseHandler: b} }, []wikiTestCase{
+		{
+			name:       "code wiki unpublished",
+			pathValues: wikiPathValues(testWikiID, ""),
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(wikiURL, http.StatusOK, codeWikiResp)
+				m.SetMethodResponse("DELETE", wikiURL, http.StatusOK, codeWikiResp)
+			},
+			expectedStatus:       http.StatusNoContent,
+			expectedRequestCount: 2,
+		},
+		{
+			name:       "project wiki",
+			pathValues: wikiPathValues(testWikiID, ""),
+			query:      apiVersionQuery(),
+			setupMock: func(m *handlerstest.MockHTTPClient) {
+				m.SetResponse(wikiURL, http.StatusOK, projectWikiResp)
+			},
+			expectedStatus:       http.StatusBadRequest,
+			expectedBody:         "Invalid wiki: Platform.wiki is a project wiki, only code wikis can be unpublished",
+			expectedRequestCount: 1,
+		},
+		{
+			name:                 "wiki not found",
+			pathValues:           wikiPathValues(testWikiID, ""),
+			query:                apiVersionQuery(),
+			expectedStatus:       http.StatusNotFound,
+			expectedBody:         "Wiki " + testWikiID + " not found in project Platform",
+			expectedRequestCount: 1,
+		},
+	})
+}
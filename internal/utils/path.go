@@ -0,0 +1,16 @@
+package utils
+
+import "strings"
+
+// SplitPath splits a path of Azure DevOps (e.g., a pipeline folder or a classification node) into its segments,
+// accepting both '/' and '\' as separators
+// Empty segments (leading, trailing or repeated separators) are dropped
+func SplitPath(path string) []string {
+	segments := []string{}
+	for _, segment := range strings.FieldsFunc(path, func(r rune) bool { return r == '/' || r == '\\' }) {
+		if segment = strings.TrimSpace(segment); segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	return segments
+}
@@ -0,0 +1,22 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitPath(t *testing.T) {
+	tests := map[string][]string{
+		"folder/subfolder":      {"folder", "subfolder"},
+		`\Team A\Backend\`:      {"Team A", "Backend"},
+		" / Team A //Backend/ ": {"Team A", "Backend"},
+		`/folder\subfolder/`:    {"folder", "subfolder"},
+		"/":                     {},
+		"":                      {},
+	}
+	for path, expected := range tests {
+		if got := SplitPath(path); !reflect.DeepEqual(got, expected) {
+			t.Errorf("SplitPath(%q) = %q, want %q", path, got, expected)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrPollTimeout is returned by Poll when the operation does not complete within the timeout of the poller
+var ErrPollTimeout = errors.New("operation did not complete in time")
+
+// Poller polls an asynchronous operation (e.g., a project creation or a fork sync request) until it completes
+type Poller struct {
+	Interval time.Duration // Wait between two checks
+	Timeout  time.Duration // Maximum duration of the polling
+}
+
+// DefaultPoller returns the poller used by the handlers that wait for asynchronous operations of Azure DevOps
+// The handlers also make requests before and after the polling, so the timeout bounds the whole handling of the
+// request through WithTimeout rather than the polling alone. It stays below the write timeout of the server (see main.go),
+// leaving room for the response to be written, so that the caller always gets a response
+func DefaultPoller() Poller {
+	return Poller{Interval: 2 * time.Second, Timeout: 40 * time.Second}
+}
+
+// WithTimeout returns a context canceled after the timeout of the poller, to be used by all the requests of a handler
+func (p Poller) WithTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, p.Timeout)
+}
+
+// Poll calls check until it reports the operation as done or returns an error, waiting Interval between two calls
+// ErrPollTimeout is returned if the next check would start after Timeout or after the deadline of the context,
+// the error of the context if it is canceled first
+func (p Poller) Poll(ctx context.Context, check func() (bool, error)) error {
+	deadline := time.Now().Add(p.Timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+
+	for {
+		done, err := check()
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+
+		if time.Now().Add(p.Interval).After(deadline) {
+			return ErrPollTimeout
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(p.Interval):
+		}
+	}
+}
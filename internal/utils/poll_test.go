@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPoller_Poll(t *testing.T) {
+	poller := Poller{Interval: time.Millisecond, Timeout: 50 * time.Millisecond}
+
+	t.Run("returns when the operation is done", func(t *testing.T) {
+		calls := 0
+		err := poller.Poll(context.Background(), func() (bool, error) {
+			calls++
+			return calls == 3, nil
+		})
+		if err != nil || calls != 3 {
+			t.Errorf("Poll() = %v after %d calls, want nil after 3 calls", err, calls)
+		}
+	})
+
+	t.Run("returns the error of the check", func(t *testing.T) {
+		checkErr := errors.New("failed")
+		calls := 0
+		err := poller.Poll(context.Background(), func() (bool, error) {
+			calls++
+			return false, checkErr
+		})
+		if !errors.Is(err, checkErr) || calls != 1 {
+			t.Errorf("Poll() = %v after %d calls, want %v after 1 call", err, calls, checkErr)
+		}
+	})
+
+	t.Run("times out", func(t *testing.T) {
+		err := Poller{Interval: 10 * time.Millisecond, Timeout: 25 * time.Millisecond}.Poll(context.Background(), func() (bool, error) {
+			return false, nil
+		})
+		if !errors.Is(err, ErrPollTimeout) {
+			t.Errorf("Poll() = %v, want %v", err, ErrPollTimeout)
+		}
+	})
+
+	t.Run("times out at the deadline of the context", func(t *testing.T) {
+		ctx, cancel := Poller{Timeout: 25 * time.Millisecond}.WithTimeout(context.Background())
+		defer cancel()
+		calls := 0
+		err := Poller{Interval: 10 * time.Millisecond, Timeout: time.Minute}.Poll(ctx, func() (bool, error) {
+			calls++
+			return false, nil
+		})
+		if !errors.Is(err, ErrPollTimeout) || calls > 3 {
+			t.Errorf("Poll() = %v after %d calls, want %v after at most 3 calls", err, calls, ErrPollTimeout)
+		}
+	})
+
+	t.Run("stops when the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := poller.Poll(ctx, func() (bool, error) {
+			return false, nil
+		})
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Poll() = %v, want %v", err, context.Canceled)
+		}
+	})
+}
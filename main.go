@@ -13,10 +13,27 @@ import (
 
 	_ "github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/docs"
 	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers"
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/agentpool"
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/classification"
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/environment"
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/forksync"
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/gitpermission"
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/gitpush"
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/gitref"
 	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/gitrepository"
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/graph"
 	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/health"
 	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/pipeline"
 	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/pipelinepermission"
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/policy"
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/project"
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/pullrequest"
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/recovery"
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/serviceendpoint"
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/servicehook"
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/team"
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/variablegroup"
+	"github.com/krateoplatformops/azuredevops-rest-dynamic-controller-plugin/internal/handlers/wiki"
 	"github.com/krateoplatformops/plumbing/env"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
@@ -82,17 +99,142 @@ func main() {
 	// Business logic routes to handle some Azure DevOps API's endpoints
 
 	// Pipeline
+	mux.Handle("GET /api/{organization}/{project}/pipelines", pipeline.ListPipelines(opts))
 	mux.Handle("GET /api/{organization}/{project}/pipelines/{id}", pipeline.GetPipeline(opts))
 	mux.Handle("PUT /api/{organization}/{project}/pipelines/{id}", pipeline.PutPipeline(opts))
 	mux.Handle("DELETE /api/{organization}/{project}/pipelines/{id}", pipeline.DeletePipeline(opts))
 	//mux.Handle("POST /api/{organization}/{project}/pipelines", pipeline.PostPipeline(opts)) // Implemented but not used
 
+	// Pipeline folders
+	mux.Handle("GET /api/{organization}/{project}/pipelines/folders", pipeline.ListPipelineFolders(opts))
+	mux.Handle("POST /api/{organization}/{project}/pipelines/folders", pipeline.PostPipelineFolder(opts))
+	mux.Handle("GET /api/{organization}/{project}/pipelines/folders/{path...}", pipeline.GetPipelineFolder(opts))
+	mux.Handle("DELETE /api/{organization}/{project}/pipelines/folders/{path...}", pipeline.DeletePipelineFolder(opts))
+
 	// PipelinePermission
 	mux.Handle("GET /api/{organization}/{project}/pipelines/pipelinepermissions/{resourceType}/{resourceId}", pipelinepermission.GetPipelinePermission(opts))
+	mux.Handle("PUT /api/{organization}/{project}/pipelines/pipelinepermissions/{resourceType}/{resourceId}", pipelinepermission.PutPipelinePermission(opts))
+	mux.Handle("PATCH /api/{organization}/{project}/pipelines/pipelinepermissions/{resourceType}/{resourceId}", pipelinepermission.PatchPipelinePermission(opts))
+	mux.Handle("POST /api/{organization}/{project}/pipelines/pipelinepermissions/query", pipelinepermission.QueryPipelinePermissions(opts))
+	mux.Handle("PUT /api/{organization}/{project}/pipelines/pipelinepermissions", pipelinepermission.PutPipelinePermissions(opts))
+	mux.Handle("PATCH /api/{organization}/{project}/pipelines/pipelinepermissions", pipelinepermission.PatchPipelinePermissions(opts))
+
+	// Environment
+	mux.Handle("POST /api/{organization}/{project}/environments", environment.PostEnvironment(opts))
+	mux.Handle("GET /api/{organization}/{project}/environments/{id}", environment.GetEnvironment(opts))
+	mux.Handle("PUT /api/{organization}/{project}/environments/{id}", environment.PutEnvironment(opts))
+	mux.Handle("DELETE /api/{organization}/{project}/environments/{id}", environment.DeleteEnvironment(opts))
+
+	// Environment Kubernetes resources
+	mux.Handle("POST /api/{organization}/{project}/environments/{environmentId}/kubernetes", environment.PostKubernetesResource(opts))
+	mux.Handle("GET /api/{organization}/{project}/environments/{environmentId}/kubernetes/{resourceId}", environment.GetKubernetesResource(opts))
+	mux.Handle("DELETE /api/{organization}/{project}/environments/{environmentId}/kubernetes/{resourceId}", environment.DeleteKubernetesResource(opts))
+
+	// Environment checks
+	mux.Handle("GET /api/{organization}/{project}/environments/{environmentId}/checks", environment.ListEnvironmentChecks(opts))
+	mux.Handle("POST /api/{organization}/{project}/environments/{environmentId}/checks", environment.PostEnvironmentCheck(opts))
+	mux.Handle("GET /api/{organization}/{project}/environments/{environmentId}/checks/{checkId}", environment.GetEnvironmentCheck(opts))
+	mux.Handle("PUT /api/{organization}/{project}/environments/{environmentId}/checks/{checkId}", environment.PutEnvironmentCheck(opts))
+	mux.Handle("DELETE /api/{organization}/{project}/environments/{environmentId}/checks/{checkId}", environment.DeleteEnvironmentCheck(opts))
+
+	// VariableGroup
+	mux.Handle("POST /api/{organization}/{projectId}/variablegroups", variablegroup.PostVariableGroup(opts))
+	mux.Handle("GET /api/{organization}/{projectId}/variablegroups/{id}", variablegroup.GetVariableGroup(opts))
+	mux.Handle("PUT /api/{organization}/{projectId}/variablegroups/{id}", variablegroup.PutVariableGroup(opts))
+	mux.Handle("DELETE /api/{organization}/{projectId}/variablegroups/{id}", variablegroup.DeleteVariableGroup(opts))
+
+	// ServiceEndpoint
+	mux.Handle("POST /api/{organization}/{projectId}/serviceendpoints", serviceendpoint.PostServiceEndpoint(opts))
+	mux.Handle("GET /api/{organization}/{projectId}/serviceendpoints/{id}", serviceendpoint.GetServiceEndpoint(opts))
+	mux.Handle("PUT /api/{organization}/{projectId}/serviceendpoints/{id}", serviceendpoint.PutServiceEndpoint(opts))
+	mux.Handle("DELETE /api/{organization}/{projectId}/serviceendpoints/{id}", serviceendpoint.DeleteServiceEndpoint(opts))
+
+	// AgentPool
+	mux.Handle("POST /api/{organization}/distributedtask/pools", agentpool.PostAgentPool(opts))
+	mux.Handle("GET /api/{organization}/distributedtask/pools", agentpool.FindAgentPool(opts))
+	mux.Handle("GET /api/{organization}/distributedtask/pools/{id}", agentpool.GetAgentPool(opts))
+	mux.Handle("DELETE /api/{organization}/distributedtask/pools/{id}", agentpool.DeleteAgentPool(opts))
+
+	// AgentQueue
+	mux.Handle("POST /api/{organization}/{project}/distributedtask/queues", agentpool.PostAgentQueue(opts))
+	mux.Handle("GET /api/{organization}/{project}/distributedtask/queues", agentpool.FindAgentQueue(opts))
+	mux.Handle("GET /api/{organization}/{project}/distributedtask/queues/{id}", agentpool.GetAgentQueue(opts))
+	mux.Handle("DELETE /api/{organization}/{project}/distributedtask/queues/{id}", agentpool.DeleteAgentQueue(opts))
+
+	// Project
+	mux.Handle("POST /api/{organization}/core/projects", project.PostProject(opts))
+	mux.Handle("GET /api/{organization}/core/projects/{id}", project.GetProject(opts))
+	mux.Handle("PATCH /api/{organization}/core/projects/{id}", project.PatchProject(opts))
+	mux.Handle("DELETE /api/{organization}/core/projects/{id}", project.DeleteProject(opts))
+
+	// Team
+	mux.Handle("POST /api/{organization}/{projectId}/teams", team.PostTeam(opts))
+	mux.Handle("GET /api/{organization}/{projectId}/teams/{id}", team.GetTeam(opts))
+	mux.Handle("PUT /api/{organization}/{projectId}/teams/{id}", team.PutTeam(opts))
+	mux.Handle("DELETE /api/{organization}/{projectId}/teams/{id}", team.DeleteTeam(opts))
+
+	// Graph
+	mux.Handle("GET /api/{organization}/graph/groups", graph.ListGroups(opts))
+	mux.Handle("POST /api/{organization}/graph/groups", graph.PostGroup(opts))
+	mux.Handle("GET /api/{organization}/graph/groups/{descriptor}", graph.GetGroup(opts))
+	mux.Handle("PUT /api/{organization}/graph/groups/{descriptor}", graph.PutGroup(opts))
+	mux.Handle("DELETE /api/{organization}/graph/groups/{descriptor}", graph.DeleteGroup(opts))
+	mux.Handle("POST /api/{organization}/graph/subjects/resolve", graph.ResolveSubjects(opts))
 
 	// GitRepository
 	mux.Handle("POST /api/{organization}/{projectId}/git/repositories", gitrepository.PostGitRepository(opts))
 
+	// GitRepository permissions
+	mux.Handle("GET /api/{organization}/{projectId}/git/repositories/{repositoryId}/permissions", gitpermission.GetRepositoryPermissions(opts))
+	mux.Handle("PUT /api/{organization}/{projectId}/git/repositories/{repositoryId}/permissions", gitpermission.PutRepositoryPermissions(opts))
+	mux.Handle("DELETE /api/{organization}/{projectId}/git/repositories/{repositoryId}/permissions/{descriptor}", gitpermission.DeleteRepositoryPermission(opts))
+
+	// GitRepository refs
+	mux.Handle("GET /api/{organization}/{projectId}/git/repositories/{repositoryId}/refs", gitref.ListRefs(opts))
+	mux.Handle("POST /api/{organization}/{projectId}/git/repositories/{repositoryId}/branches", gitref.PostBranch(opts))
+	mux.Handle("GET /api/{organization}/{projectId}/git/repositories/{repositoryId}/branches/{branch...}", gitref.GetBranch(opts))
+	mux.Handle("PATCH /api/{organization}/{projectId}/git/repositories/{repositoryId}/branches/{branch...}", gitref.PatchBranch(opts))
+	mux.Handle("DELETE /api/{organization}/{projectId}/git/repositories/{repositoryId}/branches/{branch...}", gitref.DeleteBranch(opts))
+
+	// GitRepository pushes
+	mux.Handle("POST /api/{organization}/{projectId}/git/repositories/{repositoryId}/pushes", gitpush.PostPush(opts))
+
+	// GitRepository fork sync
+	mux.Handle("GET /api/{organization}/{projectId}/git/repositories/{repositoryId}/forksync", forksync.GetForkStatus(opts))
+	mux.Handle("POST /api/{organization}/{projectId}/git/repositories/{repositoryId}/forksync", forksync.PostForkSync(opts))
+	mux.Handle("GET /api/{organization}/{projectId}/git/repositories/{repositoryId}/forksync/requests/{operationId}", forksync.GetForkSyncRequest(opts))
+
+	// GitRepository pull requests
+	mux.Handle("POST /api/{organization}/{projectId}/git/repositories/{repositoryId}/pullrequests", pullrequest.PostPullRequest(opts))
+	mux.Handle("GET /api/{organization}/{projectId}/git/repositories/{repositoryId}/pullrequests/{pullRequestId}", pullrequest.GetPullRequest(opts))
+	mux.Handle("DELETE /api/{organization}/{projectId}/git/repositories/{repositoryId}/pullrequests/{pullRequestId}", pullrequest.DeletePullRequest(opts))
+
+	// Wiki
+	mux.Handle("POST /api/{organization}/{projectId}/wiki/wikis", wiki.PostWiki(opts))
+	mux.Handle("GET /api/{organization}/{projectId}/wiki/wikis/{wikiIdentifier}", wiki.GetWiki(opts))
+	mux.Handle("DELETE /api/{organization}/{projectId}/wiki/wikis/{wikiIdentifier}", wiki.DeleteWiki(opts))
+	mux.Handle("GET /api/{organization}/{projectId}/wiki/wikis/{wikiIdentifier}/pages/{path...}", wiki.GetWikiPage(opts))
+	mux.Handle("PUT /api/{organization}/{projectId}/wiki/wikis/{wikiIdentifier}/pages/{path...}", wiki.PutWikiPage(opts))
+	mux.Handle("DELETE /api/{organization}/{projectId}/wiki/wikis/{wikiIdentifier}/pages/{path...}", wiki.DeleteWikiPage(opts))
+
+	// Classification nodes (areas and iterations)
+	mux.Handle("POST /api/{organization}/{projectId}/wit/classificationnodes/{structureGroup}", classification.PostClassificationNode(opts))
+	mux.Handle("GET /api/{organization}/{projectId}/wit/classificationnodes/{structureGroup}/{path...}", classification.GetClassificationNode(opts))
+	mux.Handle("PATCH /api/{organization}/{projectId}/wit/classificationnodes/{structureGroup}/{path...}", classification.PatchClassificationNode(opts))
+	mux.Handle("DELETE /api/{organization}/{projectId}/wit/classificationnodes/{structureGroup}/{path...}", classification.DeleteClassificationNode(opts))
+
+	// Service hook subscriptions
+	mux.Handle("POST /api/{organization}/{projectId}/hooks/subscriptions", servicehook.PostSubscription(opts))
+	mux.Handle("GET /api/{organization}/{projectId}/hooks/subscriptions/{id}", servicehook.GetSubscription(opts))
+	mux.Handle("PUT /api/{organization}/{projectId}/hooks/subscriptions/{id}", servicehook.PutSubscription(opts))
+	mux.Handle("DELETE /api/{organization}/{projectId}/hooks/subscriptions/{id}", servicehook.DeleteSubscription(opts))
+
+	// Policy
+	mux.Handle("POST /api/{organization}/{project}/policy/configurations", policy.PostPolicy(opts))
+	mux.Handle("GET /api/{organization}/{project}/policy/configurations/{id}", policy.GetPolicy(opts))
+	mux.Handle("PUT /api/{organization}/{project}/policy/configurations/{id}", policy.PutPolicy(opts))
+	mux.Handle("DELETE /api/{organization}/{project}/policy/configurations/{id}", policy.DeletePolicy(opts))
+
 	// Swagger UI
 	mux.Handle("/swagger/", httpSwagger.WrapHandler)
 
@@ -102,7 +244,7 @@ func main() {
 
 	server := &http.Server{
 		Addr:         fmt.Sprintf(":%d", *port),
-		Handler:      mux,
+		Handler:      recovery.Middleware(opts.Log, mux), // Converts any handler panic into a 500 response
 		ReadTimeout:  10 * time.Second,
 		WriteTimeout: 50 * time.Second,
 		IdleTimeout:  30 * time.Second,